@@ -0,0 +1,47 @@
+package datareader
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/julianshen/gonp-datareader/sources"
+)
+
+// ErrActionsNotSupported is returned by ReadActions for sources that do
+// not implement sources.ActionReader.
+var ErrActionsNotSupported = errors.New("source does not support corporate actions")
+
+// ReadActions fetches the dividends and splits of symbol with ex-dates in
+// the range, sorted by date, for building adjusted return series.
+// Supported sources are "yahoo" (div and split events), "tiingo" (divCash
+// and splitFactor of daily prices) and "finmind" (TaiwanStockDividend cash
+// and stock dividends).
+//
+// # Example Usage
+//
+//	actions, err := datareader.ReadActions(ctx, "yahoo", "AAPL", start, end, nil)
+//	if err != nil {
+//		log.Fatal(err)
+//	}
+//	for _, a := range actions {
+//		switch a.Type {
+//		case sources.ActionDividend:
+//			fmt.Printf("%s dividend %.2f\n", a.Date.Format("2006-01-02"), a.Amount)
+//		case sources.ActionSplit:
+//			fmt.Printf("%s split %gx\n", a.Date.Format("2006-01-02"), a.Ratio)
+//		}
+//	}
+func ReadActions(ctx context.Context, source, symbol string, start, end time.Time, opts *Options) ([]sources.Action, error) {
+	reader, err := DataReader(source, opts)
+	if err != nil {
+		return nil, err
+	}
+
+	actionReader, ok := reader.(sources.ActionReader)
+	if !ok {
+		return nil, fmt.Errorf("%w: %s", ErrActionsNotSupported, source)
+	}
+	return actionReader.ReadActions(ctx, symbol, start, end)
+}
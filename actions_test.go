@@ -0,0 +1,69 @@
+package datareader_test
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	datareader "github.com/julianshen/gonp-datareader"
+	"github.com/julianshen/gonp-datareader/sources"
+	"github.com/julianshen/gonp-datareader/sources/finmind"
+	"github.com/julianshen/gonp-datareader/sources/tiingo"
+	"github.com/julianshen/gonp-datareader/sources/yahoo"
+)
+
+// Compile-time checks that the action sources implement sources.ActionReader
+var (
+	_ sources.ActionReader = (*yahoo.YahooReader)(nil)
+	_ sources.ActionReader = (*tiingo.TiingoReader)(nil)
+	_ sources.ActionReader = (*finmind.FinMindReader)(nil)
+)
+
+func TestReadActions(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`[{"date": "2020-08-28T00:00:00.000Z", "close": 499, "divCash": 0, "splitFactor": 1},
+			{"date": "2020-08-31T00:00:00.000Z", "close": 129, "divCash": 0, "splitFactor": 4}]`))
+	}))
+	defer server.Close()
+
+	opts := &datareader.Options{
+		APIKey:           "key",
+		BaseURLOverrides: map[string]string{"tiingo": server.URL + "/%s/prices"},
+	}
+	start := time.Date(2020, 8, 1, 0, 0, 0, 0, time.UTC)
+	end := time.Date(2020, 9, 30, 0, 0, 0, 0, time.UTC)
+
+	actions, err := datareader.ReadActions(context.Background(), "tiingo", "AAPL", start, end, opts)
+	if err != nil {
+		t.Fatalf("ReadActions() error = %v", err)
+	}
+	if len(actions) != 1 || actions[0].Type != sources.ActionSplit || actions[0].Ratio != 4 {
+		t.Errorf("ReadActions() = %+v, want one 4:1 split", actions)
+	}
+}
+
+func TestReadActions_Errors(t *testing.T) {
+	tests := []struct {
+		name    string
+		source  string
+		wantErr error
+	}{
+		{"not supported", "fred", datareader.ErrActionsNotSupported},
+		{"unknown source", "unknown", datareader.ErrUnknownSource},
+	}
+
+	start := time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC)
+	end := time.Date(2020, 12, 31, 0, 0, 0, 0, time.UTC)
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := datareader.ReadActions(context.Background(), tt.source, "AAPL", start, end, nil)
+			if !errors.Is(err, tt.wantErr) {
+				t.Errorf("ReadActions(%q) error = %v, want %v", tt.source, err, tt.wantErr)
+			}
+		})
+	}
+}
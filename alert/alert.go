@@ -0,0 +1,260 @@
+// Package alert evaluates rules (threshold crosses, percentage moves, new
+// highs, macro levels) against fetched datasets and notifies callbacks or
+// webhooks when they trigger.
+//
+// # Example Usage
+//
+//	engine := alert.NewEngine(alert.NotifierFunc(func(ctx context.Context, e alert.Event) error {
+//		log.Println(e.Message)
+//		return nil
+//	}))
+//	engine.Add("AAPL", alert.CrossAbove{Column: "Close", Threshold: 200})
+//	engine.Add("", alert.PercentChange{Column: "Close", Window: 5, Percent: 10})
+//	engine.Add("UNRATE", alert.Above{Column: "Value", Threshold: 5})
+//
+//	// Poll every hour until ctx is cancelled
+//	err := engine.Run(ctx, time.Hour, func(ctx context.Context) ([]*dataset.Dataset, error) {
+//		return fetchWatchlist(ctx)
+//	})
+package alert
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/julianshen/gonp-datareader/dataset"
+)
+
+// Event describes a triggered rule.
+type Event struct {
+	Rule    string    `json:"rule"`
+	Symbol  string    `json:"symbol"`
+	Source  string    `json:"source"`
+	Date    time.Time `json:"date"`
+	Value   float64   `json:"value"`
+	Message string    `json:"message"`
+}
+
+// Notifier receives triggered events.
+type Notifier interface {
+	Notify(ctx context.Context, e Event) error
+}
+
+// NotifierFunc adapts a function to a Notifier.
+type NotifierFunc func(ctx context.Context, e Event) error
+
+// Notify implements Notifier.
+func (f NotifierFunc) Notify(ctx context.Context, e Event) error {
+	return f(ctx, e)
+}
+
+// Webhook posts each event as JSON to URL.
+type Webhook struct {
+	URL string
+	// Client is the HTTP client to use; nil means http.DefaultClient.
+	Client *http.Client
+}
+
+// Notify implements Notifier.
+func (w *Webhook) Notify(ctx context.Context, e Event) error {
+	body, err := json.Marshal(e)
+	if err != nil {
+		return fmt.Errorf("encode event: %w", err)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, w.URL, bytes.NewReader(body))
+	if err != nil {
+		return fmt.Errorf("create webhook request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	client := w.Client
+	if client == nil {
+		client = http.DefaultClient
+	}
+	resp, err := client.Do(req)
+	if err != nil {
+		return fmt.Errorf("webhook: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return fmt.Errorf("webhook: HTTP %d", resp.StatusCode)
+	}
+	return nil
+}
+
+// binding attaches a rule to a symbol; an empty symbol matches all.
+type binding struct {
+	symbol string
+	rule   Rule
+}
+
+// Engine evaluates rules against datasets and notifies when they trigger.
+// Each rule fires at most once per symbol and observation date, so polling
+// the same data repeatedly does not repeat notifications. An event a
+// notifier fails to receive is retried for that notifier when the rule
+// triggers again on a later Evaluate.
+//
+// Engine is safe for concurrent use.
+type Engine struct {
+	// OnError, when set, receives fetch and evaluation errors from Run.
+	OnError func(error)
+
+	notifiers []Notifier
+
+	mu       sync.Mutex
+	bindings []binding
+	// fired holds the deliveries of each series ("source|symbol") by rule
+	// and observation date
+	fired map[string]map[string]*delivery
+}
+
+// delivery tracks which notifiers have received an event.
+type delivery struct {
+	date time.Time
+	sent []bool
+	// busy is set while the event is being delivered
+	busy bool
+}
+
+// done reports whether every notifier has received the event.
+func (d *delivery) done() bool {
+	for _, sent := range d.sent {
+		if !sent {
+			return false
+		}
+	}
+	return true
+}
+
+// NewEngine creates an Engine that sends events to notifiers.
+func NewEngine(notifiers ...Notifier) *Engine {
+	return &Engine{
+		notifiers: notifiers,
+		fired:     make(map[string]map[string]*delivery),
+	}
+}
+
+// Add registers rule for symbol. An empty symbol applies the rule to every
+// dataset.
+func (e *Engine) Add(symbol string, rule Rule) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	e.bindings = append(e.bindings, binding{symbol: symbol, rule: rule})
+}
+
+// Evaluate checks every matching rule against the datasets, notifies for
+// newly triggered rules and returns their events, including those retried
+// for notifiers that failed before. Rule and notifier errors are joined;
+// evaluation continues past them.
+//
+// Deliveries of observations before a dataset's first date are forgotten,
+// since the rule can no longer trigger on them, so Run does not accumulate
+// state as the polled range moves forward.
+func (e *Engine) Evaluate(ctx context.Context, datasets ...*dataset.Dataset) ([]Event, error) {
+	var events []Event
+	var pending []*delivery
+	var errs []error
+
+	e.mu.Lock()
+	for _, ds := range datasets {
+		series := ds.Source + "|" + ds.Symbol
+		fired := e.fired[series]
+		if fired == nil {
+			fired = make(map[string]*delivery)
+			e.fired[series] = fired
+		}
+		if len(ds.Dates) > 0 {
+			for key, d := range fired {
+				if !d.busy && d.date.Before(ds.Dates[0]) {
+					delete(fired, key)
+				}
+			}
+		}
+
+		for _, b := range e.bindings {
+			if b.symbol != "" && b.symbol != ds.Symbol {
+				continue
+			}
+
+			ev, err := b.rule.Evaluate(ds)
+			if err != nil {
+				errs = append(errs, err)
+				continue
+			}
+			if ev == nil {
+				continue
+			}
+
+			key := ev.Rule + "|" + ev.Date.Format(time.RFC3339)
+			d := fired[key]
+			if d == nil {
+				d = &delivery{date: ev.Date, sent: make([]bool, len(e.notifiers))}
+				fired[key] = d
+			} else if d.busy || d.done() {
+				continue
+			}
+			d.busy = true
+			events = append(events, *ev)
+			pending = append(pending, d)
+		}
+	}
+	e.mu.Unlock()
+
+	for i, ev := range events {
+		// Other calls skip d while it is busy, so d.sent is ours to update
+		d := pending[i]
+		for j, n := range e.notifiers {
+			if d.sent[j] {
+				continue
+			}
+			if err := n.Notify(ctx, ev); err != nil {
+				errs = append(errs, fmt.Errorf("notify %s: %w", ev.Rule, err))
+				continue
+			}
+			d.sent[j] = true
+		}
+
+		e.mu.Lock()
+		d.busy = false
+		e.mu.Unlock()
+	}
+
+	return events, errors.Join(errs...)
+}
+
+// Run calls fetch every interval and evaluates the result until ctx is
+// cancelled, returning ctx.Err(). Fetch and evaluation errors are passed
+// to OnError and do not stop the loop.
+func (e *Engine) Run(ctx context.Context, interval time.Duration, fetch func(ctx context.Context) ([]*dataset.Dataset, error)) error {
+	report := func(err error) {
+		if err != nil && e.OnError != nil {
+			e.OnError(err)
+		}
+	}
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		datasets, err := fetch(ctx)
+		report(err)
+		if len(datasets) > 0 {
+			_, err = e.Evaluate(ctx, datasets...)
+			report(err)
+		}
+
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-ticker.C:
+		}
+	}
+}
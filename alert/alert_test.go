@@ -0,0 +1,190 @@
+package alert_test
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/julianshen/gonp-datareader/alert"
+	"github.com/julianshen/gonp-datareader/dataset"
+)
+
+func TestEngine_Evaluate(t *testing.T) {
+	var notified []alert.Event
+	engine := alert.NewEngine(alert.NotifierFunc(func(ctx context.Context, e alert.Event) error {
+		notified = append(notified, e)
+		return nil
+	}))
+	engine.Add("AAPL", alert.CrossAbove{Column: "Close", Threshold: 100})
+	engine.Add("", alert.Above{Column: "Close", Threshold: 50})
+
+	aapl := daily(t, "AAPL", 99, 101)
+	msft := daily(t, "MSFT", 60, 101)
+
+	events, err := engine.Evaluate(context.Background(), aapl, msft)
+	if err != nil {
+		t.Fatalf("Evaluate() error = %v", err)
+	}
+	// AAPL triggers both rules, MSFT only the rule for all symbols
+	if len(events) != 3 || len(notified) != 3 {
+		t.Fatalf("events = %d, notified = %d, want 3", len(events), len(notified))
+	}
+
+	// Re-evaluating the same observations does not fire again
+	events, _ = engine.Evaluate(context.Background(), aapl, msft)
+	if len(events) != 0 {
+		t.Errorf("second Evaluate() = %v, want no events", events)
+	}
+}
+
+func TestEngine_Evaluate_Errors(t *testing.T) {
+	engine := alert.NewEngine(alert.NotifierFunc(func(ctx context.Context, e alert.Event) error {
+		return errors.New("notifier down")
+	}))
+	engine.Add("", alert.Above{Column: "Open", Threshold: 1})
+	engine.Add("", alert.Above{Column: "Close", Threshold: 1})
+
+	events, err := engine.Evaluate(context.Background(), daily(t, "AAPL", 2))
+	if err == nil {
+		t.Fatal("Evaluate() expected joined error")
+	}
+	if len(events) != 1 {
+		t.Errorf("events = %d, want 1 despite errors", len(events))
+	}
+}
+
+func TestEngine_Evaluate_RetriesFailedNotifiers(t *testing.T) {
+	var failing, working int
+	down := true
+	engine := alert.NewEngine(
+		alert.NotifierFunc(func(ctx context.Context, e alert.Event) error {
+			failing++
+			if down {
+				return errors.New("webhook down")
+			}
+			return nil
+		}),
+		alert.NotifierFunc(func(ctx context.Context, e alert.Event) error {
+			working++
+			return nil
+		}),
+	)
+	engine.Add("", alert.Above{Column: "Close", Threshold: 1})
+	ds := daily(t, "AAPL", 2)
+
+	if _, err := engine.Evaluate(context.Background(), ds); err == nil {
+		t.Fatal("Evaluate() expected notifier error")
+	}
+
+	// The failed delivery is retried, only for the notifier that failed
+	down = false
+	events, err := engine.Evaluate(context.Background(), ds)
+	if err != nil || len(events) != 1 {
+		t.Fatalf("retry Evaluate() = %v, %v, want the event again", events, err)
+	}
+	if failing != 2 || working != 1 {
+		t.Errorf("notified failing = %d, working = %d, want 2 and 1", failing, working)
+	}
+
+	if events, _ := engine.Evaluate(context.Background(), ds); len(events) != 0 {
+		t.Errorf("Evaluate() after delivery = %v, want no events", events)
+	}
+}
+
+func TestEngine_Evaluate_ForgetsOldObservations(t *testing.T) {
+	var notified int
+	engine := alert.NewEngine(alert.NotifierFunc(func(ctx context.Context, e alert.Event) error {
+		notified++
+		return nil
+	}))
+	engine.Add("", alert.Above{Column: "Close", Threshold: 1})
+
+	old := daily(t, "AAPL", 2)
+	engine.Evaluate(context.Background(), old)
+
+	// A window past the old observation forgets it...
+	day2 := old.Dates[0].AddDate(0, 0, 1)
+	engine.Evaluate(context.Background(), daily(t, "AAPL", 0, 0).Between(day2, day2))
+
+	// ...so the engine holds no state for it and notifies again
+	engine.Evaluate(context.Background(), old)
+	if notified != 2 {
+		t.Errorf("notified = %d, want 2 after the observation was forgotten", notified)
+	}
+}
+
+func TestWebhook_Notify(t *testing.T) {
+	var got alert.Event
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost || r.Header.Get("Content-Type") != "application/json" {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		if err := json.NewDecoder(r.Body).Decode(&got); err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+		}
+	}))
+	defer server.Close()
+
+	hook := &alert.Webhook{URL: server.URL}
+	if err := hook.Notify(context.Background(), alert.Event{Rule: "r", Symbol: "AAPL", Value: 1.5}); err != nil {
+		t.Fatalf("Notify() error = %v", err)
+	}
+	if got.Symbol != "AAPL" || got.Value != 1.5 {
+		t.Errorf("received %+v", got)
+	}
+
+	failing := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusInternalServerError)
+	}))
+	defer failing.Close()
+
+	if err := (&alert.Webhook{URL: failing.URL}).Notify(context.Background(), alert.Event{}); err == nil {
+		t.Error("Notify() expected error for HTTP 500")
+	}
+}
+
+func TestEngine_Run(t *testing.T) {
+	var fetches, fired int32
+	engine := alert.NewEngine(alert.NotifierFunc(func(ctx context.Context, e alert.Event) error {
+		atomic.AddInt32(&fired, 1)
+		return nil
+	}))
+	engine.Add("", alert.Above{Column: "Close", Threshold: 1})
+
+	var errCount int32
+	engine.OnError = func(err error) { atomic.AddInt32(&errCount, 1) }
+
+	ctx, cancel := context.WithCancel(context.Background())
+	fetch := func(ctx context.Context) ([]*dataset.Dataset, error) {
+		n := atomic.AddInt32(&fetches, 1)
+		if n == 2 {
+			return nil, errors.New("temporary failure")
+		}
+		if n >= 3 {
+			cancel()
+		}
+		// Each fetch returns one more observation
+		values := make([]float64, n)
+		for i := range values {
+			values[i] = 2
+		}
+		return []*dataset.Dataset{daily(t, "AAPL", values...)}, nil
+	}
+
+	err := engine.Run(ctx, time.Millisecond, fetch)
+	if !errors.Is(err, context.Canceled) {
+		t.Errorf("Run() error = %v, want context.Canceled", err)
+	}
+	if atomic.LoadInt32(&fired) != 2 {
+		t.Errorf("fired = %d, want 2 (one per new observation)", fired)
+	}
+	if atomic.LoadInt32(&errCount) != 1 {
+		t.Errorf("errors = %d, want 1", errCount)
+	}
+}
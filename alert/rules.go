@@ -0,0 +1,215 @@
+package alert
+
+import (
+	"fmt"
+	"math"
+	"time"
+
+	"github.com/julianshen/gonp-datareader/dataset"
+)
+
+// Rule checks the latest observation of a dataset. Evaluate returns nil
+// when the rule does not trigger.
+type Rule interface {
+	// Name describes the rule, e.g. "Close crosses above 200".
+	Name() string
+	Evaluate(ds *dataset.Dataset) (*Event, error)
+}
+
+// CrossAbove triggers when Column moves from at or below Threshold to
+// above it between the last two observations.
+type CrossAbove struct {
+	Column    string
+	Threshold float64
+}
+
+// Name implements Rule.
+func (r CrossAbove) Name() string {
+	return fmt.Sprintf("%s crosses above %g", r.Column, r.Threshold)
+}
+
+// Evaluate implements Rule.
+func (r CrossAbove) Evaluate(ds *dataset.Dataset) (*Event, error) {
+	prev, last, err := lastTwo(ds, r.Column)
+	if err != nil || prev < 0 {
+		return nil, err
+	}
+	values, _ := ds.Column(r.Column)
+	if values[prev] <= r.Threshold && values[last] > r.Threshold {
+		return newEvent(r, ds, last, values[last]), nil
+	}
+	return nil, nil
+}
+
+// CrossBelow triggers when Column moves from at or above Threshold to
+// below it between the last two observations.
+type CrossBelow struct {
+	Column    string
+	Threshold float64
+}
+
+// Name implements Rule.
+func (r CrossBelow) Name() string {
+	return fmt.Sprintf("%s crosses below %g", r.Column, r.Threshold)
+}
+
+// Evaluate implements Rule.
+func (r CrossBelow) Evaluate(ds *dataset.Dataset) (*Event, error) {
+	prev, last, err := lastTwo(ds, r.Column)
+	if err != nil || prev < 0 {
+		return nil, err
+	}
+	values, _ := ds.Column(r.Column)
+	if values[prev] >= r.Threshold && values[last] < r.Threshold {
+		return newEvent(r, ds, last, values[last]), nil
+	}
+	return nil, nil
+}
+
+// Above triggers while the latest value of Column is above Threshold. It
+// suits slow-moving macro series (e.g., UNRATE above 5).
+type Above struct {
+	Column    string
+	Threshold float64
+}
+
+// Name implements Rule.
+func (r Above) Name() string {
+	return fmt.Sprintf("%s above %g", r.Column, r.Threshold)
+}
+
+// Evaluate implements Rule.
+func (r Above) Evaluate(ds *dataset.Dataset) (*Event, error) {
+	_, last, err := lastTwo(ds, r.Column)
+	if err != nil || last < 0 {
+		return nil, err
+	}
+	values, _ := ds.Column(r.Column)
+	if values[last] > r.Threshold {
+		return newEvent(r, ds, last, values[last]), nil
+	}
+	return nil, nil
+}
+
+// PercentChange triggers when Column changed by at least Percent (in
+// either direction) over the last Window observations.
+type PercentChange struct {
+	Column  string
+	Window  int
+	Percent float64
+}
+
+// Name implements Rule.
+func (r PercentChange) Name() string {
+	return fmt.Sprintf("%s moves %g%% over %d observations", r.Column, r.Percent, r.Window)
+}
+
+// Evaluate implements Rule.
+func (r PercentChange) Evaluate(ds *dataset.Dataset) (*Event, error) {
+	if r.Window < 1 {
+		return nil, fmt.Errorf("%s: window must be positive", r.Name())
+	}
+	points, err := observed(ds, r.Column)
+	if err != nil || len(points) <= r.Window {
+		return nil, err
+	}
+
+	values, _ := ds.Column(r.Column)
+	last := points[len(points)-1]
+	base := values[points[len(points)-1-r.Window]]
+	if base == 0 {
+		return nil, nil
+	}
+
+	change := (values[last] - base) / math.Abs(base) * 100
+	if math.Abs(change) >= r.Percent {
+		e := newEvent(r, ds, last, values[last])
+		e.Message = fmt.Sprintf("%s %s changed %+.2f%% over %d observations", e.Symbol, r.Column, change, r.Window)
+		return e, nil
+	}
+	return nil, nil
+}
+
+// NewHigh triggers when the latest value of Column exceeds every value in
+// the preceding Lookback period. A zero Lookback means 52 weeks.
+type NewHigh struct {
+	Column   string
+	Lookback time.Duration
+}
+
+// Name implements Rule.
+func (r NewHigh) Name() string {
+	if r.Lookback == 0 {
+		return fmt.Sprintf("%s at 52-week high", r.Column)
+	}
+	return fmt.Sprintf("%s at %s high", r.Column, r.Lookback)
+}
+
+// Evaluate implements Rule.
+func (r NewHigh) Evaluate(ds *dataset.Dataset) (*Event, error) {
+	lookback := r.Lookback
+	if lookback == 0 {
+		lookback = 52 * 7 * 24 * time.Hour
+	}
+
+	points, err := observed(ds, r.Column)
+	if err != nil || len(points) < 2 {
+		return nil, err
+	}
+
+	values, _ := ds.Column(r.Column)
+	last := points[len(points)-1]
+	since := ds.Dates[last].Add(-lookback)
+	for _, i := range points[:len(points)-1] {
+		if ds.Dates[i].Before(since) {
+			continue
+		}
+		if values[i] >= values[last] {
+			return nil, nil
+		}
+	}
+	return newEvent(r, ds, last, values[last]), nil
+}
+
+// observed returns the indexes of non-missing values of column.
+func observed(ds *dataset.Dataset, column string) ([]int, error) {
+	values, ok := ds.Column(column)
+	if !ok {
+		return nil, fmt.Errorf("%s: column %q not found", ds.Symbol, column)
+	}
+
+	var idx []int
+	for i, v := range values {
+		if !math.IsNaN(v) {
+			idx = append(idx, i)
+		}
+	}
+	return idx, nil
+}
+
+// lastTwo returns the indexes of the last two non-missing values of
+// column, or -1 where there are not enough values.
+func lastTwo(ds *dataset.Dataset, column string) (prev, last int, err error) {
+	points, err := observed(ds, column)
+	if err != nil {
+		return -1, -1, err
+	}
+	switch len(points) {
+	case 0:
+		return -1, -1, nil
+	case 1:
+		return -1, points[0], nil
+	}
+	return points[len(points)-2], points[len(points)-1], nil
+}
+
+func newEvent(r Rule, ds *dataset.Dataset, i int, value float64) *Event {
+	return &Event{
+		Rule:    r.Name(),
+		Symbol:  ds.Symbol,
+		Source:  ds.Source,
+		Date:    ds.Dates[i],
+		Value:   value,
+		Message: fmt.Sprintf("%s %s (%g on %s)", ds.Symbol, r.Name(), value, ds.Dates[i].Format("2006-01-02")),
+	}
+}
@@ -0,0 +1,77 @@
+package alert_test
+
+import (
+	"math"
+	"testing"
+	"time"
+
+	"github.com/julianshen/gonp-datareader/alert"
+	"github.com/julianshen/gonp-datareader/dataset"
+)
+
+// daily returns a dataset with one value per day starting 2024-01-01.
+func daily(t *testing.T, symbol string, values ...float64) *dataset.Dataset {
+	t.Helper()
+	dates := make([]time.Time, len(values))
+	for i := range dates {
+		dates[i] = time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC).AddDate(0, 0, i)
+	}
+	ds := dataset.New(symbol, "yahoo", dates)
+	if err := ds.AddColumn("Close", values); err != nil {
+		t.Fatal(err)
+	}
+	return ds
+}
+
+func TestRules(t *testing.T) {
+	nan := math.NaN()
+
+	tests := []struct {
+		name   string
+		rule   alert.Rule
+		values []float64
+		want   bool
+	}{
+		{"cross above", alert.CrossAbove{Column: "Close", Threshold: 100}, []float64{95, 99, 101}, true},
+		{"cross above skips missing", alert.CrossAbove{Column: "Close", Threshold: 100}, []float64{99, nan, 101}, true},
+		{"already above", alert.CrossAbove{Column: "Close", Threshold: 100}, []float64{101, 102}, false},
+		{"single value", alert.CrossAbove{Column: "Close", Threshold: 100}, []float64{101}, false},
+		{"cross below", alert.CrossBelow{Column: "Close", Threshold: 100}, []float64{100, 99}, true},
+		{"not below", alert.CrossBelow{Column: "Close", Threshold: 100}, []float64{101, 100}, false},
+		{"above", alert.Above{Column: "Close", Threshold: 5}, []float64{4, 5.2}, true},
+		{"not above", alert.Above{Column: "Close", Threshold: 5}, []float64{6, 5}, false},
+		{"pct up", alert.PercentChange{Column: "Close", Window: 2, Percent: 10}, []float64{100, 105, 110}, true},
+		{"pct down", alert.PercentChange{Column: "Close", Window: 2, Percent: 10}, []float64{100, 95, 89}, true},
+		{"pct small", alert.PercentChange{Column: "Close", Window: 2, Percent: 10}, []float64{100, 105, 109}, false},
+		{"pct short", alert.PercentChange{Column: "Close", Window: 5, Percent: 1}, []float64{100, 200}, false},
+		{"new high", alert.NewHigh{Column: "Close"}, []float64{10, 12, 11, 13}, true},
+		{"not new high", alert.NewHigh{Column: "Close"}, []float64{10, 13, 11, 13}, false},
+		{"high outside lookback", alert.NewHigh{Column: "Close", Lookback: 48 * time.Hour}, []float64{20, 10, 11, 12}, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ev, err := tt.rule.Evaluate(daily(t, "AAPL", tt.values...))
+			if err != nil {
+				t.Fatalf("Evaluate() error = %v", err)
+			}
+			if (ev != nil) != tt.want {
+				t.Fatalf("Evaluate() triggered = %v, want %v", ev != nil, tt.want)
+			}
+			if ev != nil && (ev.Symbol != "AAPL" || ev.Rule != tt.rule.Name() || ev.Message == "") {
+				t.Errorf("Evaluate() = %+v", ev)
+			}
+		})
+	}
+}
+
+func TestRules_Errors(t *testing.T) {
+	ds := daily(t, "AAPL", 1, 2)
+
+	if _, err := (alert.CrossAbove{Column: "Open"}).Evaluate(ds); err == nil {
+		t.Error("CrossAbove expected error for missing column")
+	}
+	if _, err := (alert.PercentChange{Column: "Close"}).Evaluate(ds); err == nil {
+		t.Error("PercentChange expected error for zero window")
+	}
+}
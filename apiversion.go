@@ -0,0 +1,137 @@
+package datareader
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// ErrUnsupportedVersion is matched by errors.Is for every
+// *UnsupportedVersionError.
+var ErrUnsupportedVersion = errors.New("unsupported API version")
+
+// UnsupportedVersionError is returned by DataReader when Options.APIVersions
+// pins a version the source does not implement or that the provider has
+// retired.
+type UnsupportedVersionError struct {
+	// Source is the data source name (e.g., "finmind")
+	Source string
+	// Version is the pinned version
+	Version string
+	// Retired reports whether the provider has shut the version down, as
+	// opposed to it never having been implemented by this package
+	Retired bool
+	// Note explains the retirement, if known
+	Note string
+	// Supported lists the versions that can be pinned, default first
+	Supported []string
+}
+
+// Error implements the error interface.
+func (e *UnsupportedVersionError) Error() string {
+	msg := fmt.Sprintf("%s: API version %q is not supported", e.Source, e.Version)
+	if e.Retired {
+		msg = fmt.Sprintf("%s: API version %q is no longer supported", e.Source, e.Version)
+	}
+	if e.Note != "" {
+		msg += ": " + e.Note
+	}
+	return fmt.Sprintf("%s (supported: %s)", msg, strings.Join(e.Supported, ", "))
+}
+
+// Is implements error matching for errors.Is.
+func (e *UnsupportedVersionError) Is(target error) bool {
+	return target == ErrUnsupportedVersion
+}
+
+// apiVersion is an implemented version of a source's API. Empty URLs mean
+// the reader's built-in endpoint, which serves the default version.
+type apiVersion struct {
+	baseURL    string
+	sandboxURL string
+}
+
+// sourceVersions describes the API versions known for a source.
+type sourceVersions struct {
+	def       string
+	supported map[string]apiVersion
+	retired   map[string]string
+}
+
+// versions lists the API versions of each built-in source. Retiring a
+// version here turns silent breakage into an explicit error for callers who
+// pinned it.
+var versions = map[string]sourceVersions{
+	"yahoo":        {def: "v7"},
+	"fred":         {def: "v1"},
+	"worldbank":    {def: "v2", retired: map[string]string{"v1": "use the v2 API"}},
+	"alphavantage": {def: "v1"},
+	"stooq":        {def: "v1"},
+	"iex": {def: "stable", supported: map[string]apiVersion{
+		"v1": {
+			baseURL:    "https://cloud.iexapis.com/v1/stock/%s/chart/%s?token=%s",
+			sandboxURL: "https://sandbox.iexapis.com/v1/stock/%s/chart/%s?token=%s",
+		},
+	}},
+	"tiingo":   {def: "v1"},
+	"oecd":     {def: "sdmx-json"},
+	"eurostat": {def: "1.0", retired: map[string]string{"2.1": "the JSON web service was replaced by the dissemination API 1.0"}},
+	"twse":     {def: "v1"},
+	"finmind":  {def: "v4", retired: map[string]string{"v3": "FinMind shut down the v3 API"}},
+	"finra":    {def: "v1"},
+	"ecb":      {def: "v1"},
+}
+
+// APIVersions returns the API versions of source that can be pinned with
+// Options.APIVersions; the first one is the default.
+func APIVersions(source string) ([]string, error) {
+	v, ok := versions[source]
+	if !ok {
+		return nil, fmt.Errorf("%w: %s", ErrUnknownSource, source)
+	}
+	return v.list(), nil
+}
+
+// list returns the default version followed by the other supported
+// versions in sorted order.
+func (v sourceVersions) list() []string {
+	others := make([]string, 0, len(v.supported))
+	for name := range v.supported {
+		if name != v.def {
+			others = append(others, name)
+		}
+	}
+	sort.Strings(others)
+	return append([]string{v.def}, others...)
+}
+
+// resolveVersion validates the version pinned for source and returns the
+// base URL serving it in the given environment, or "" for the reader's
+// built-in endpoint.
+func resolveVersion(source string, opts *Options) (string, error) {
+	if opts == nil {
+		return "", nil
+	}
+	pinned := opts.APIVersions[source]
+	v, ok := versions[source]
+	if pinned == "" || !ok || pinned == v.def {
+		return "", nil
+	}
+
+	if av, ok := v.supported[pinned]; ok {
+		if opts.Environment == EnvironmentSandbox {
+			return av.sandboxURL, nil
+		}
+		return av.baseURL, nil
+	}
+
+	note, retired := v.retired[pinned]
+	return "", &UnsupportedVersionError{
+		Source:    source,
+		Version:   pinned,
+		Retired:   retired,
+		Note:      note,
+		Supported: v.list(),
+	}
+}
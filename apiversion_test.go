@@ -0,0 +1,69 @@
+package datareader_test
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+
+	datareader "github.com/julianshen/gonp-datareader"
+)
+
+func TestDataReader_APIVersions(t *testing.T) {
+	tests := []struct {
+		name        string
+		source      string
+		version     string
+		wantErr     bool
+		wantRetired bool
+	}{
+		{"default version", "iex", "", false, false},
+		{"explicit default", "iex", "stable", false, false},
+		{"alternate version", "iex", "v1", false, false},
+		{"retired version", "finmind", "v3", true, true},
+		{"unknown version", "yahoo", "v8", true, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			opts := &datareader.Options{APIVersions: map[string]string{tt.source: tt.version}}
+			_, err := datareader.DataReader(tt.source, opts)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("DataReader() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if !tt.wantErr {
+				return
+			}
+
+			if !errors.Is(err, datareader.ErrUnsupportedVersion) {
+				t.Errorf("error %v does not match ErrUnsupportedVersion", err)
+			}
+			var verr *datareader.UnsupportedVersionError
+			if !errors.As(err, &verr) {
+				t.Fatalf("error %T is not *UnsupportedVersionError", err)
+			}
+			if verr.Source != tt.source || verr.Version != tt.version || verr.Retired != tt.wantRetired {
+				t.Errorf("error = %+v", verr)
+			}
+		})
+	}
+}
+
+func TestAPIVersions(t *testing.T) {
+	got, err := datareader.APIVersions("iex")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := []string{"stable", "v1"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("APIVersions(iex) = %v, want %v", got, want)
+	}
+
+	for _, source := range datareader.ListSources() {
+		if _, err := datareader.APIVersions(source); err != nil {
+			t.Errorf("APIVersions(%s) error = %v", source, err)
+		}
+	}
+
+	if _, err := datareader.APIVersions("unknown"); !errors.Is(err, datareader.ErrUnknownSource) {
+		t.Errorf("APIVersions(unknown) error = %v, want ErrUnknownSource", err)
+	}
+}
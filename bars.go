@@ -0,0 +1,35 @@
+package datareader
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/julianshen/gonp-datareader/ohlcv"
+)
+
+// ReadBars fetches symbol like ReadDataset, with the same options, and
+// returns its price columns as ohlcv.Bars, whatever the source's column
+// names. It returns an error wrapping ohlcv.ErrNoClose for sources without
+// prices, such as FRED.
+//
+// # Example Usage
+//
+//	bars, err := datareader.ReadBars(ctx, "2330", "twse", start, end, nil)
+//	if err != nil {
+//		log.Fatal(err)
+//	}
+//	for i, date := range bars.Date {
+//		fmt.Println(date.Format("2006-01-02"), bars.Close[i], bars.Volume[i])
+//	}
+func ReadBars(ctx context.Context, symbol string, source string, start, end time.Time, opts *Options) (*ohlcv.Bars, error) {
+	ds, err := ReadDataset(ctx, symbol, source, start, end, opts)
+	if err != nil {
+		return nil, err
+	}
+	bars, err := ohlcv.FromDataset(ds)
+	if err != nil {
+		return nil, fmt.Errorf("%s %s: %w", source, symbol, err)
+	}
+	return bars, nil
+}
@@ -0,0 +1,55 @@
+package datareader_test
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"testing"
+	"time"
+
+	datareader "github.com/julianshen/gonp-datareader"
+	"github.com/julianshen/gonp-datareader/ohlcv"
+)
+
+func TestReadBars(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "text/csv")
+		w.Write([]byte("Date,Open,High,Low,Close,Volume\n2024-01-03,2,3,1,2.5,200\n2024-01-02,1,2,1,1.5,100\n"))
+	}))
+	defer server.Close()
+
+	opts := &datareader.Options{BaseURLOverrides: map[string]string{"stooq": server.URL + "?s=%s"}}
+	start := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	end := time.Date(2024, 1, 31, 0, 0, 0, 0, time.UTC)
+
+	bars, err := datareader.ReadBars(context.Background(), "AAPL.US", "stooq", start, end, opts)
+	if err != nil {
+		t.Fatalf("ReadBars() error = %v", err)
+	}
+	// Rows are sorted by date as in ReadDataset
+	if bars.Len() != 2 || bars.Date[0].Day() != 2 {
+		t.Fatalf("ReadBars() dates = %v", bars.Date)
+	}
+	if !reflect.DeepEqual(bars.Close, []float64{1.5, 2.5}) || !reflect.DeepEqual(bars.Volume, []int64{100, 200}) {
+		t.Errorf("ReadBars() Close = %v, Volume = %v", bars.Close, bars.Volume)
+	}
+}
+
+func TestReadBars_NoPrices(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"observations":[{"date":"2024-01-03","value":"3.91"}]}`))
+	}))
+	defer server.Close()
+
+	opts := &datareader.Options{APIKey: "key", BaseURLOverrides: map[string]string{"fred": server.URL}}
+	start := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	end := time.Date(2024, 1, 31, 0, 0, 0, 0, time.UTC)
+
+	_, err := datareader.ReadBars(context.Background(), "DGS10", "fred", start, end, opts)
+	if !errors.Is(err, ohlcv.ErrNoClose) {
+		t.Errorf("ReadBars(fred) error = %v, want ErrNoClose", err)
+	}
+}
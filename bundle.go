@@ -0,0 +1,69 @@
+package datareader
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/julianshen/gonp-datareader/sources"
+	"github.com/julianshen/gonp-datareader/sources/bundle"
+)
+
+// ErrNoBundle is returned by DataReader for the "bundle" source when
+// Options.BundlePath is not set.
+var ErrNoBundle = errors.New("no bundle file configured")
+
+// BundleRequest selects series to package into a bundle: Symbols of
+// Source from Start through End.
+type BundleRequest struct {
+	Source  string
+	Symbols []string
+	Start   time.Time
+	End     time.Time
+}
+
+// BuildBundle fetches every requested series with ReadDataset and packages
+// them into a single self-contained bundle, which the "bundle" source
+// serves offline once saved. Series are stored as float64 Datasets under
+// the source and symbol they were read with.
+//
+// # Example Usage
+//
+//	b, err := datareader.BuildBundle(ctx, []datareader.BundleRequest{
+//		{Source: "yahoo", Symbols: []string{"AAPL", "MSFT"}, Start: start, End: end},
+//		{Source: "fred", Symbols: []string{"GDP"}, Start: start, End: end},
+//	}, opts)
+//	if err != nil {
+//		log.Fatal(err)
+//	}
+//	err = b.Save("course.bundle")
+//
+//	// Students read the file without network access
+//	reader, err := datareader.DataReader("bundle", &datareader.Options{BundlePath: "course.bundle"})
+//	data, err := reader.ReadSingle(ctx, "AAPL", start, end)
+func BuildBundle(ctx context.Context, requests []BundleRequest, opts *Options) (*bundle.Bundle, error) {
+	b := bundle.New()
+	for _, req := range requests {
+		for _, symbol := range req.Symbols {
+			ds, err := ReadDataset(ctx, symbol, req.Source, req.Start, req.End, opts)
+			if err != nil {
+				return nil, fmt.Errorf("bundle %s:%s: %w", req.Source, symbol, err)
+			}
+			b.Add(ds, req.Start, req.End)
+		}
+	}
+	return b, nil
+}
+
+// openBundle creates the reader of the "bundle" source.
+func openBundle(opts *Options) (sources.Reader, error) {
+	if opts == nil || opts.BundlePath == "" {
+		return nil, ErrNoBundle
+	}
+	b, err := bundle.Open(opts.BundlePath)
+	if err != nil {
+		return nil, err
+	}
+	return bundle.NewBundleReader(b), nil
+}
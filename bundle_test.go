@@ -0,0 +1,99 @@
+package datareader_test
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	datareader "github.com/julianshen/gonp-datareader"
+)
+
+func TestBuildBundle(t *testing.T) {
+	var requests atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests.Add(1)
+		switch r.URL.Query().Get("s") {
+		case "AAA.US":
+			w.Write([]byte("Date,Open,High,Low,Close,Volume\n2024-01-02,1,1,1,10,100\n2024-01-03,1,1,1,11,100\n"))
+		default:
+			w.Write([]byte("Date,Open,High,Low,Close,Volume\n2024-01-02,2,2,2,20,200\n"))
+		}
+	}))
+	defer server.Close()
+
+	opts := &datareader.Options{
+		Environment:     datareader.EnvironmentSandbox,
+		SandboxBaseURLs: map[string]string{"stooq": server.URL + "?s=%s"},
+	}
+	start := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	end := time.Date(2024, 1, 31, 0, 0, 0, 0, time.UTC)
+	ctx := context.Background()
+
+	b, err := datareader.BuildBundle(ctx, []datareader.BundleRequest{
+		{Source: "stooq", Symbols: []string{"AAA.US", "BBB.US"}, Start: start, End: end},
+	}, opts)
+	if err != nil {
+		t.Fatalf("BuildBundle() error = %v", err)
+	}
+	path := filepath.Join(t.TempDir(), "course.bundle")
+	if err := b.Save(path); err != nil {
+		t.Fatalf("Save() error = %v", err)
+	}
+	fetched := requests.Load()
+
+	// Reads are served from the file, in any environment
+	bundleOpts := &datareader.Options{BundlePath: path, Environment: datareader.EnvironmentSandbox}
+	ds, err := datareader.ReadDataset(ctx, "aaa.us", "bundle", start, end, bundleOpts)
+	if err != nil {
+		t.Fatalf("ReadDataset(bundle) error = %v", err)
+	}
+	if ds.Source != "stooq" || ds.Symbol != "AAA.US" || ds.Len() != 2 {
+		t.Errorf("ReadDataset(bundle) = %s:%s with %d rows", ds.Source, ds.Symbol, ds.Len())
+	}
+	closes, _ := ds.Column("Close")
+	if closes[0] != 10 || closes[1] != 11 {
+		t.Errorf("Close = %v, want [10 11]", closes)
+	}
+	if ds.Meta["bundled_at"] == "" {
+		t.Errorf("Meta = %v, want bundled_at", ds.Meta)
+	}
+
+	frame, err := datareader.ReadFrame(ctx, []string{"AAA.US", "BBB.US"}, "bundle", start, end, bundleOpts)
+	if err != nil {
+		t.Fatalf("ReadFrame(bundle) error = %v", err)
+	}
+	if _, ok := frame.Column("BBB.US.Close"); !ok {
+		t.Errorf("ReadFrame(bundle) columns = %v", frame.ColumnNames())
+	}
+
+	if requests.Load() != fetched {
+		t.Errorf("bundle reads made %d upstream requests", requests.Load()-fetched)
+	}
+}
+
+func TestDataReader_BundleErrors(t *testing.T) {
+	tests := []struct {
+		name string
+		opts *datareader.Options
+	}{
+		{"nil options", nil},
+		{"no path", &datareader.Options{}},
+		{"missing file", &datareader.Options{BundlePath: filepath.Join(t.TempDir(), "missing.bundle")}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := datareader.DataReader("bundle", tt.opts)
+			if err == nil {
+				t.Fatal("DataReader(bundle) returned nil error")
+			}
+			if wantNoBundle := tt.opts == nil || tt.opts.BundlePath == ""; errors.Is(err, datareader.ErrNoBundle) != wantNoBundle {
+				t.Errorf("DataReader(bundle) error = %v", err)
+			}
+		})
+	}
+}
@@ -0,0 +1,143 @@
+package datareader
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"sort"
+	"time"
+
+	"github.com/julianshen/gonp-datareader/internal/cache"
+	internalhttp "github.com/julianshen/gonp-datareader/internal/http"
+)
+
+// CacheEntry describes one cached response of a CacheDir, as listed by
+// CacheInventory.
+type CacheEntry struct {
+	// Source, Symbol, Start and End describe the read the response was
+	// fetched for. Entries written before this was recorded, or by reads
+	// that bypass the package functions (e.g., reader.Read with several
+	// symbols), leave the unknown fields empty.
+	Source string
+	Symbol string
+	Start  time.Time
+	End    time.Time
+
+	// StoredAt is when the response was cached, and ExpiresAt when it
+	// expires (zero means no expiration)
+	StoredAt  time.Time
+	ExpiresAt time.Time
+
+	// Size is the size of the entry's file in bytes, and Path the file
+	Size int64
+	Path string
+}
+
+// Age returns how long ago the entry was cached.
+func (e CacheEntry) Age() time.Duration {
+	return time.Since(e.StoredAt)
+}
+
+// Expired reports whether the entry has passed its expiration time.
+func (e CacheEntry) Expired() bool {
+	return !e.ExpiresAt.IsZero() && time.Now().After(e.ExpiresAt)
+}
+
+// Covers reports whether the entry holds source's symbol over all of
+// [start, end].
+func (e CacheEntry) Covers(source, symbol string, start, end time.Time) bool {
+	return e.Source == source && e.Symbol == symbol &&
+		!e.Start.IsZero() && !e.Start.After(start) && !e.End.IsZero() && !e.End.Before(end)
+}
+
+// CacheInventory lists the responses cached in dir, an Options.CacheDir,
+// including the caches of its subdirectories (such as datareaderd's
+// per-tenant caches). Entries are sorted by source, symbol, start and
+// path; a missing dir has none. Cached responses themselves are not
+// returned, and the request URLs they were keyed by, which may contain
+// API keys, are not recorded.
+//
+// The inventory lets sync jobs plan which ranges still need a download:
+//
+//	entries, err := datareader.CacheInventory(".cache/datareader")
+//	if err != nil {
+//		log.Fatal(err)
+//	}
+//	for _, e := range entries {
+//		if e.Covers("yahoo", "AAPL", start, end) && !e.Expired() {
+//			// served from the cache
+//		}
+//	}
+func CacheInventory(dir string) ([]CacheEntry, error) {
+	listings, err := cache.List(dir)
+	if err != nil {
+		return nil, fmt.Errorf("cache inventory: %w", err)
+	}
+
+	entries := make([]CacheEntry, len(listings))
+	for i, l := range listings {
+		entries[i] = CacheEntry{
+			Source:    l.Label.Source,
+			Symbol:    l.Label.Symbol,
+			Start:     l.Label.Start,
+			End:       l.Label.End,
+			StoredAt:  l.StoredAt,
+			ExpiresAt: l.ExpiresAt,
+			Size:      l.Size,
+			Path:      l.Path,
+		}
+	}
+
+	sort.SliceStable(entries, func(i, j int) bool {
+		a, b := entries[i], entries[j]
+		if a.Source != b.Source {
+			return a.Source < b.Source
+		}
+		if a.Symbol != b.Symbol {
+			return a.Symbol < b.Symbol
+		}
+		return a.Start.Before(b.Start)
+	})
+	return entries, nil
+}
+
+// PruneCache removes the cached responses of dir, as listed by
+// CacheInventory, that are expired or, when maxAge is positive, older than
+// maxAge, and returns them. Entries already gone are skipped; other
+// removal errors are joined.
+//
+// # Example Usage
+//
+//	removed, err := datareader.PruneCache(".cache/datareader", 30*24*time.Hour)
+//	if err != nil {
+//		log.Fatal(err)
+//	}
+//	log.Printf("pruned %d cache entries", len(removed))
+func PruneCache(dir string, maxAge time.Duration) ([]CacheEntry, error) {
+	entries, err := CacheInventory(dir)
+	if err != nil {
+		return nil, err
+	}
+
+	var removed []CacheEntry
+	var errs []error
+	for _, e := range entries {
+		if !e.Expired() && (maxAge <= 0 || e.Age() <= maxAge) {
+			continue
+		}
+		if err := os.Remove(e.Path); err != nil && !errors.Is(err, os.ErrNotExist) {
+			errs = append(errs, err)
+			continue
+		}
+		removed = append(removed, e)
+	}
+	return removed, errors.Join(errs...)
+}
+
+// labelCache returns ctx with the responses cached while reading symbol
+// over [start, end] labeled for CacheInventory; the source is recorded by
+// the reader's client.
+func labelCache(ctx context.Context, symbol string, start, end time.Time) context.Context {
+	return internalhttp.WithCacheLabel(ctx, cache.Label{Symbol: symbol, Start: start, End: end})
+}
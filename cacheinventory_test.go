@@ -0,0 +1,81 @@
+package datareader_test
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	datareader "github.com/julianshen/gonp-datareader"
+)
+
+func TestCacheInventory(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "text/csv")
+		w.Write([]byte("Date,Open,High,Low,Close,Volume\n2024-01-02,1,2,1,1.5,100\n"))
+	}))
+	defer server.Close()
+
+	dir := t.TempDir()
+	opts := &datareader.Options{
+		CacheDir:         dir,
+		CacheTTL:         time.Hour,
+		BaseURLOverrides: map[string]string{"stooq": server.URL + "?s=%s"},
+	}
+	start := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	end := time.Date(2024, 1, 31, 0, 0, 0, 0, time.UTC)
+
+	for _, symbol := range []string{"MSFT.US", "AAPL.US"} {
+		if _, err := datareader.ReadDataset(context.Background(), symbol, "stooq", start, end, opts); err != nil {
+			t.Fatalf("ReadDataset(%s) error = %v", symbol, err)
+		}
+	}
+
+	entries, err := datareader.CacheInventory(dir)
+	if err != nil {
+		t.Fatalf("CacheInventory() error = %v", err)
+	}
+	if len(entries) != 2 {
+		t.Fatalf("CacheInventory() = %d entries, want 2", len(entries))
+	}
+	e := entries[0]
+	if e.Source != "stooq" || e.Symbol != "AAPL.US" || !e.Start.Equal(start) || !e.End.Equal(end) {
+		t.Errorf("entries[0] = %+v, want stooq AAPL.US over the read's range", e)
+	}
+	if e.Size == 0 || e.Age() < 0 || e.Age() > time.Minute || e.Expired() {
+		t.Errorf("entries[0] Size = %d, Age = %v, Expired = %v", e.Size, e.Age(), e.Expired())
+	}
+	if !e.Covers("stooq", "AAPL.US", start.AddDate(0, 0, 7), end) || e.Covers("stooq", "AAPL.US", start, end.AddDate(0, 0, 1)) {
+		t.Errorf("Covers() does not match the entry's range %v - %v", e.Start, e.End)
+	}
+}
+
+func TestPruneCache(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "text/csv")
+		w.Write([]byte("Date,Open,High,Low,Close,Volume\n2024-01-02,1,2,1,1.5,100\n"))
+	}))
+	defer server.Close()
+
+	dir := t.TempDir()
+	opts := &datareader.Options{CacheDir: dir, BaseURLOverrides: map[string]string{"stooq": server.URL + "?s=%s"}}
+	start := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	end := time.Date(2024, 1, 31, 0, 0, 0, 0, time.UTC)
+	if _, err := datareader.Read(context.Background(), "AAPL.US", "stooq", start, end, opts); err != nil {
+		t.Fatalf("Read() error = %v", err)
+	}
+
+	// Entries without a TTL are kept unless they exceed maxAge
+	removed, err := datareader.PruneCache(dir, 0)
+	if err != nil || len(removed) != 0 {
+		t.Fatalf("PruneCache(0) = %v, %v, want nothing removed", removed, err)
+	}
+	removed, err = datareader.PruneCache(dir, time.Nanosecond)
+	if err != nil || len(removed) != 1 || removed[0].Symbol != "AAPL.US" {
+		t.Fatalf("PruneCache(1ns) = %v, %v, want the entry removed", removed, err)
+	}
+	if entries, _ := datareader.CacheInventory(dir); len(entries) != 0 {
+		t.Errorf("CacheInventory() after prune = %v", entries)
+	}
+}
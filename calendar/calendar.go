@@ -0,0 +1,135 @@
+// Package calendar provides exchange trading calendars, used to compute how
+// many daily bars a date range should contain.
+//
+// # Example Usage
+//
+//	nyse := calendar.NYSE()
+//	n := nyse.TradingDays(start, end) // sessions in [start, end]
+//	open := nyse.IsTradingDay(time.Date(2024, 7, 4, 0, 0, 0, 0, time.UTC)) // false
+package calendar
+
+import (
+	"sort"
+	"sync"
+	"time"
+)
+
+// Calendar reports which days an exchange holds a trading session. Days are
+// compared by calendar date; the time of day and location are ignored.
+// A Calendar is safe for concurrent use.
+type Calendar struct {
+	name     string
+	holidays func(year int) []time.Time
+
+	mu    sync.Mutex
+	years map[int]map[civil]bool
+}
+
+// civil is a calendar date without time or location.
+type civil struct {
+	year  int
+	month time.Month
+	day   int
+}
+
+func civilOf(t time.Time) civil {
+	y, m, d := t.Date()
+	return civil{y, m, d}
+}
+
+// New creates a calendar trading Monday to Friday except on the given
+// holidays, for example an exchange's published closure list.
+func New(name string, holidays ...time.Time) *Calendar {
+	byYear := make(map[int][]time.Time)
+	for _, h := range holidays {
+		byYear[h.Year()] = append(byYear[h.Year()], h)
+	}
+	return newCalendar(name, func(year int) []time.Time { return byYear[year] })
+}
+
+func newCalendar(name string, holidays func(year int) []time.Time) *Calendar {
+	return &Calendar{name: name, holidays: holidays, years: make(map[int]map[civil]bool)}
+}
+
+// Weekdays returns a calendar trading every Monday to Friday. It is the
+// fallback for exchanges without a holiday calendar and overestimates
+// their sessions by their holidays.
+func Weekdays() *Calendar {
+	return newCalendar("weekdays", func(int) []time.Time { return nil })
+}
+
+// Name returns the calendar name (e.g., "NYSE").
+func (c *Calendar) Name() string {
+	return c.name
+}
+
+// Holidays returns the weekday closures of the given year in date order.
+func (c *Calendar) Holidays(year int) []time.Time {
+	var out []time.Time
+	for d := range c.year(year) {
+		out = append(out, time.Date(d.year, d.month, d.day, 0, 0, 0, 0, time.UTC))
+	}
+	sort.Slice(out, func(i, j int) bool { return out[i].Before(out[j]) })
+	return out
+}
+
+// year returns the holiday set of a year, computing it on first use.
+func (c *Calendar) year(year int) map[civil]bool {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	set, ok := c.years[year]
+	if !ok {
+		set = make(map[civil]bool)
+		for _, h := range c.holidays(year) {
+			if !isWeekend(h) {
+				set[civilOf(h)] = true
+			}
+		}
+		c.years[year] = set
+	}
+	return set
+}
+
+// IsTradingDay reports whether the exchange holds a session on t's date.
+func (c *Calendar) IsTradingDay(t time.Time) bool {
+	return !isWeekend(t) && !c.year(t.Year())[civilOf(t)]
+}
+
+// TradingDays returns the number of sessions from start through end,
+// inclusive. It returns 0 when end is before start.
+func (c *Calendar) TradingDays(start, end time.Time) int {
+	from := civilOf(start)
+	to := civilOf(end)
+	day := time.Date(from.year, from.month, from.day, 0, 0, 0, 0, time.UTC)
+	last := time.Date(to.year, to.month, to.day, 0, 0, 0, 0, time.UTC)
+
+	n := 0
+	for ; !day.After(last); day = day.AddDate(0, 0, 1) {
+		if c.IsTradingDay(day) {
+			n++
+		}
+	}
+	return n
+}
+
+func isWeekend(t time.Time) bool {
+	return t.Weekday() == time.Saturday || t.Weekday() == time.Sunday
+}
+
+// ForSource returns the calendar of the exchanges a source's daily equity
+// data comes from, or nil for sources without daily trading data (e.g.,
+// the economic sources fred, worldbank, oecd and eurostat).
+//
+// Sources covering several exchanges (stooq) and exchanges without a
+// bundled holiday list (twse, finmind) use Weekdays.
+func ForSource(source string) *Calendar {
+	switch source {
+	case "yahoo", "tiingo", "iex", "alphavantage":
+		return NYSE()
+	case "stooq", "twse", "finmind":
+		return Weekdays()
+	default:
+		return nil
+	}
+}
@@ -0,0 +1,112 @@
+package calendar_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/julianshen/gonp-datareader/calendar"
+)
+
+func date(year int, month time.Month, day int) time.Time {
+	return time.Date(year, month, day, 0, 0, 0, 0, time.UTC)
+}
+
+func TestNYSE_TradingDays(t *testing.T) {
+	nyse := calendar.NYSE()
+
+	tests := []struct {
+		year int
+		want int
+	}{
+		{2021, 252},
+		{2022, 251}, // New Year's Day on a Saturday is not observed
+		{2023, 250},
+		{2024, 252},
+		{2025, 250}, // includes the unscheduled closure of 2025-01-09
+	}
+
+	for _, tt := range tests {
+		got := nyse.TradingDays(date(tt.year, time.January, 1), date(tt.year, time.December, 31))
+		if got != tt.want {
+			t.Errorf("TradingDays(%d) = %d, want %d", tt.year, got, tt.want)
+		}
+	}
+}
+
+func TestNYSE_Holidays(t *testing.T) {
+	want := []time.Time{
+		date(2024, time.January, 1),
+		date(2024, time.January, 15),
+		date(2024, time.February, 19),
+		date(2024, time.March, 29),
+		date(2024, time.May, 27),
+		date(2024, time.June, 19),
+		date(2024, time.July, 4),
+		date(2024, time.September, 2),
+		date(2024, time.November, 28),
+		date(2024, time.December, 25),
+	}
+
+	got := calendar.NYSE().Holidays(2024)
+	if len(got) != len(want) {
+		t.Fatalf("Holidays(2024) = %v, want %v", got, want)
+	}
+	for i := range want {
+		if !got[i].Equal(want[i]) {
+			t.Errorf("Holidays(2024)[%d] = %v, want %v", i, got[i], want[i])
+		}
+	}
+}
+
+func TestCalendar_IsTradingDay(t *testing.T) {
+	nyse := calendar.NYSE()
+	taipei := time.FixedZone("CST", 8*3600)
+
+	tests := []struct {
+		name string
+		day  time.Time
+		want bool
+	}{
+		{"weekday", date(2024, time.July, 5), true},
+		{"saturday", date(2024, time.July, 6), false},
+		{"holiday", date(2024, time.July, 4), false},
+		{"observed on friday", date(2020, time.July, 3), false},
+		{"observed on monday", date(2022, time.December, 26), false},
+		{"time of day ignored", time.Date(2024, time.July, 4, 23, 0, 0, 0, taipei), false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := nyse.IsTradingDay(tt.day); got != tt.want {
+				t.Errorf("IsTradingDay(%v) = %v, want %v", tt.day, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestNew(t *testing.T) {
+	cal := calendar.New("TWSE", date(2024, time.February, 8), date(2024, time.February, 10))
+
+	// 2024-02-10 is a Saturday and does not reduce the count
+	if got := cal.TradingDays(date(2024, time.February, 5), date(2024, time.February, 11)); got != 4 {
+		t.Errorf("TradingDays() = %d, want 4", got)
+	}
+	if got := cal.TradingDays(date(2024, time.February, 11), date(2024, time.February, 5)); got != 0 {
+		t.Errorf("TradingDays() with end before start = %d, want 0", got)
+	}
+	if got := calendar.Weekdays().TradingDays(date(2024, time.February, 5), date(2024, time.February, 11)); got != 5 {
+		t.Errorf("Weekdays().TradingDays() = %d, want 5", got)
+	}
+}
+
+func TestForSource(t *testing.T) {
+	if cal := calendar.ForSource("yahoo"); cal == nil || cal.Name() != "NYSE" {
+		t.Errorf("ForSource(yahoo) = %v, want NYSE", cal)
+	}
+	if cal := calendar.ForSource("twse"); cal == nil || cal.Name() != "weekdays" {
+		t.Errorf("ForSource(twse) = %v, want weekdays", cal)
+	}
+	if cal := calendar.ForSource("fred"); cal != nil {
+		t.Errorf("ForSource(fred) = %v, want nil", cal)
+	}
+}
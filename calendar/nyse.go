@@ -0,0 +1,97 @@
+package calendar
+
+import "time"
+
+// nyseClosures lists unscheduled NYSE closures since 2001.
+var nyseClosures = []time.Time{
+	date(2001, time.September, 11), // September 11 attacks
+	date(2001, time.September, 12),
+	date(2001, time.September, 13),
+	date(2001, time.September, 14),
+	date(2004, time.June, 11),    // President Reagan's funeral
+	date(2007, time.January, 2),  // President Ford's funeral
+	date(2012, time.October, 29), // Hurricane Sandy
+	date(2012, time.October, 30),
+	date(2018, time.December, 5), // President G. H. W. Bush's funeral
+	date(2025, time.January, 9),  // President Carter's funeral
+}
+
+// NYSE returns the New York Stock Exchange calendar, which Nasdaq shares.
+// Holidays follow the exchange's current rules, with Juneteenth from 2022,
+// plus unscheduled closures since 2001.
+func NYSE() *Calendar {
+	return newCalendar("NYSE", nyseHolidays)
+}
+
+func nyseHolidays(year int) []time.Time {
+	holidays := []time.Time{
+		nyseObserved(date(year, time.January, 1)),
+		nthWeekday(year, time.January, time.Monday, 3),  // Martin Luther King Jr. Day
+		nthWeekday(year, time.February, time.Monday, 3), // Washington's Birthday
+		easter(year).AddDate(0, 0, -2),                  // Good Friday
+		lastWeekday(year, time.May, time.Monday),        // Memorial Day
+		nyseObserved(date(year, time.July, 4)),
+		nthWeekday(year, time.September, time.Monday, 1),  // Labor Day
+		nthWeekday(year, time.November, time.Thursday, 4), // Thanksgiving
+		nyseObserved(date(year, time.December, 25)),
+	}
+	if year >= 2022 {
+		holidays = append(holidays, nyseObserved(date(year, time.June, 19)))
+	}
+	for _, c := range nyseClosures {
+		if c.Year() == year {
+			holidays = append(holidays, c)
+		}
+	}
+	return holidays
+}
+
+// nyseObserved moves a Sunday holiday to Monday and a Saturday holiday to
+// Friday. New Year's Day on a Saturday is not observed, as the exchange
+// does not close on December 31; the weekend date is kept and ignored.
+func nyseObserved(t time.Time) time.Time {
+	switch t.Weekday() {
+	case time.Sunday:
+		return t.AddDate(0, 0, 1)
+	case time.Saturday:
+		if t.Month() == time.January && t.Day() == 1 {
+			return t
+		}
+		return t.AddDate(0, 0, -1)
+	}
+	return t
+}
+
+func date(year int, month time.Month, day int) time.Time {
+	return time.Date(year, month, day, 0, 0, 0, 0, time.UTC)
+}
+
+// nthWeekday returns the n-th given weekday of a month.
+func nthWeekday(year int, month time.Month, weekday time.Weekday, n int) time.Time {
+	first := date(year, month, 1)
+	offset := (int(weekday) - int(first.Weekday()) + 7) % 7
+	return first.AddDate(0, 0, offset+7*(n-1))
+}
+
+// lastWeekday returns the last given weekday of a month.
+func lastWeekday(year int, month time.Month, weekday time.Weekday) time.Time {
+	last := date(year, month+1, 0)
+	offset := (int(last.Weekday()) - int(weekday) + 7) % 7
+	return last.AddDate(0, 0, -offset)
+}
+
+// easter returns Western Easter Sunday (anonymous Gregorian algorithm).
+func easter(year int) time.Time {
+	a := year % 19
+	b, c := year/100, year%100
+	d, e := b/4, b%4
+	f := (b + 8) / 25
+	g := (b - f + 1) / 3
+	h := (19*a + b - d - g + 15) % 30
+	i, k := c/4, c%4
+	l := (32 + 2*e + 2*i - h - k) % 7
+	m := (a + 11*h + 22*l) / 451
+	month := (h + l - 7*m + 114) / 31
+	day := (h+l-7*m+114)%31 + 1
+	return date(year, time.Month(month), day)
+}
@@ -0,0 +1,94 @@
+package datareader
+
+import (
+	"context"
+	"time"
+
+	internalhttp "github.com/julianshen/gonp-datareader/internal/http"
+	"github.com/julianshen/gonp-datareader/sources"
+)
+
+// CallOption overrides a reader setting for one call, without creating a
+// new reader. Pass it to ReadSingleWith or ReadWith, or attach it to a
+// context with WithCallOptions to apply it to any reader method.
+type CallOption func(*internalhttp.CallOptions)
+
+// WithAPIKey replaces the reader's API key or token for the call.
+// Supported by: fred, alphavantage, iex, tiingo, finmind.
+func WithAPIKey(key string) CallOption {
+	return func(o *internalhttp.CallOptions) { o.APIKey = key }
+}
+
+// WithTimeout bounds each request of the call, including retries and
+// reading the response body. Options.Timeout still applies to every
+// attempt, so the call's timeout can shorten it but not extend it.
+func WithTimeout(timeout time.Duration) CallOption {
+	return func(o *internalhttp.CallOptions) { o.Timeout = timeout }
+}
+
+// WithCacheTTL sets the maximum age of cached responses the call accepts;
+// older ones are refetched and stored for later calls with
+// Options.CacheTTL as usual. A ttl of 0 bypasses the cache, neither
+// reading nor storing responses.
+func WithCacheTTL(ttl time.Duration) CallOption {
+	return func(o *internalhttp.CallOptions) { o.CacheTTL = &ttl }
+}
+
+// WithDataset replaces the reader's dataset for the call.
+// Supported by: finmind (e.g., "TaiwanStockPER").
+func WithDataset(dataset string) CallOption {
+	return func(o *internalhttp.CallOptions) { o.Dataset = dataset }
+}
+
+// WithCallOptions returns a copy of ctx carrying opts, added to any call
+// options ctx already carries. Every reader method called with the
+// returned context applies them.
+//
+// # Example Usage
+//
+//	ctx := datareader.WithCallOptions(ctx, datareader.WithCacheTTL(0))
+//	vintages, err := fredReader.ReadVintages(ctx, "GDP", start, end)
+func WithCallOptions(ctx context.Context, opts ...CallOption) context.Context {
+	call := internalhttp.CallOptionsFrom(ctx)
+	for _, opt := range opts {
+		opt(&call)
+	}
+	return internalhttp.WithCallOptions(ctx, call)
+}
+
+// ReadSingleWith reads one symbol like reader.ReadSingle with opts
+// applied to this call only, so one reader can serve mixed workloads.
+//
+// # Example Usage
+//
+//	reader, err := datareader.DataReader("finmind", nil)
+//	data, err := datareader.ReadSingleWith(ctx, reader, "2330", start, end,
+//		datareader.WithCacheTTL(0), datareader.WithDataset("TaiwanStockPER"))
+func ReadSingleWith(ctx context.Context, reader sources.Reader, symbol string, start, end time.Time, opts ...CallOption) (interface{}, error) {
+	ctx = labelCache(WithCallOptions(ctx, opts...), symbol, start, end)
+	return reader.ReadSingle(ctx, symbol, start, end)
+}
+
+// ReadWith reads symbols like reader.Read with opts applied to this call
+// only.
+func ReadWith(ctx context.Context, reader sources.Reader, symbols []string, start, end time.Time, opts ...CallOption) (interface{}, error) {
+	return reader.Read(WithCallOptions(ctx, opts...), symbols, start, end)
+}
+
+// ReadSingleWith reads one symbol as T with opts applied to this call
+// only.
+//
+// # Example Usage
+//
+//	reader, err := datareader.NewReader[*finmind.ParsedData]("finmind", nil)
+//	per, err := reader.ReadSingleWith(ctx, "2330", start, end,
+//		datareader.WithCacheTTL(0), datareader.WithDataset("TaiwanStockPER"))
+func (r *Reader[T]) ReadSingleWith(ctx context.Context, symbol string, start, end time.Time, opts ...CallOption) (T, error) {
+	return r.ReadSingle(WithCallOptions(ctx, opts...), symbol, start, end)
+}
+
+// ReadWith reads symbols as T, keyed by symbol, with opts applied to this
+// call only.
+func (r *Reader[T]) ReadWith(ctx context.Context, symbols []string, start, end time.Time, opts ...CallOption) (map[string]T, error) {
+	return r.Read(WithCallOptions(ctx, opts...), symbols, start, end)
+}
@@ -0,0 +1,166 @@
+package datareader_test
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+
+	datareader "github.com/julianshen/gonp-datareader"
+	internalhttp "github.com/julianshen/gonp-datareader/internal/http"
+	"github.com/julianshen/gonp-datareader/sources/finmind"
+)
+
+// finmindRequest records what a FinMind mock server was asked for.
+type finmindRequest struct {
+	dataset string
+	auth    string
+}
+
+func finmindServer(t *testing.T, delay time.Duration) (*httptest.Server, func() []finmindRequest) {
+	t.Helper()
+
+	var mu sync.Mutex
+	var requests []finmindRequest
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		requests = append(requests, finmindRequest{
+			dataset: r.URL.Query().Get("dataset"),
+			auth:    r.Header.Get("Authorization"),
+		})
+		mu.Unlock()
+
+		time.Sleep(delay)
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"data":[{"date":"2024-01-02","stock_id":"2330","open":1,"max":1,"min":1,"close":1}]}`))
+	}))
+	t.Cleanup(server.Close)
+
+	return server, func() []finmindRequest {
+		mu.Lock()
+		defer mu.Unlock()
+		return append([]finmindRequest(nil), requests...)
+	}
+}
+
+func TestReader_ReadSingleWith(t *testing.T) {
+	server, requests := finmindServer(t, 0)
+	start := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	end := time.Date(2024, 1, 31, 0, 0, 0, 0, time.UTC)
+
+	opts := &internalhttp.ClientOptions{
+		Timeout:   5 * time.Second,
+		RateLimit: 1000,
+		CacheDir:  t.TempDir(),
+		CacheTTL:  time.Hour,
+	}
+	reader := datareader.Typed[*finmind.ParsedData](
+		finmind.NewFinMindReaderWithTokenAndEndpoint(opts, "reader-token", server.URL))
+	ctx := context.Background()
+
+	// Fill the cache, then read from it
+	for i := 0; i < 2; i++ {
+		if _, err := reader.ReadSingle(ctx, "2330", start, end); err != nil {
+			t.Fatalf("ReadSingle() error = %v", err)
+		}
+	}
+	if n := len(requests()); n != 1 {
+		t.Fatalf("cached reads made %d requests, want 1", n)
+	}
+
+	tests := []struct {
+		name        string
+		opts        []datareader.CallOption
+		wantRequest *finmindRequest
+	}{
+		{
+			name:        "cache bypass",
+			opts:        []datareader.CallOption{datareader.WithCacheTTL(0)},
+			wantRequest: &finmindRequest{dataset: finmind.DefaultDataset, auth: "Bearer reader-token"},
+		},
+		{
+			name: "cache entry young enough",
+			opts: []datareader.CallOption{datareader.WithCacheTTL(time.Hour)},
+		},
+		{
+			name:        "cache entry too old",
+			opts:        []datareader.CallOption{datareader.WithCacheTTL(time.Nanosecond)},
+			wantRequest: &finmindRequest{dataset: finmind.DefaultDataset, auth: "Bearer reader-token"},
+		},
+		{
+			name:        "dataset and API key",
+			opts:        []datareader.CallOption{datareader.WithDataset("TaiwanStockPER"), datareader.WithAPIKey("call-token")},
+			wantRequest: &finmindRequest{dataset: "TaiwanStockPER", auth: "Bearer call-token"},
+		},
+		{
+			name: "no overrides",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			before := len(requests())
+
+			data, err := reader.ReadSingleWith(ctx, "2330", start, end, tt.opts...)
+			if err != nil {
+				t.Fatalf("ReadSingleWith() error = %v", err)
+			}
+			if len(data.Rows) != 1 {
+				t.Errorf("got %d rows, want 1", len(data.Rows))
+			}
+
+			made := requests()[before:]
+			if tt.wantRequest == nil {
+				if len(made) != 0 {
+					t.Errorf("made %d requests, want a cache hit", len(made))
+				}
+				return
+			}
+			if len(made) != 1 || made[0] != *tt.wantRequest {
+				t.Errorf("requests = %+v, want [%+v]", made, *tt.wantRequest)
+			}
+		})
+	}
+}
+
+func TestReadWith_Timeout(t *testing.T) {
+	server, _ := finmindServer(t, 200*time.Millisecond)
+	start := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	end := time.Date(2024, 1, 31, 0, 0, 0, 0, time.UTC)
+
+	opts := &internalhttp.ClientOptions{Timeout: 5 * time.Second, RateLimit: 1000}
+	reader := finmind.NewFinMindReaderWithEndpoint(opts, server.URL)
+
+	_, err := datareader.ReadWith(context.Background(), reader, []string{"2330", "2317"}, start, end,
+		datareader.WithTimeout(20*time.Millisecond))
+	if !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatalf("ReadWith() error = %v, want context.DeadlineExceeded", err)
+	}
+
+	// The override applies to the call only
+	if _, err := datareader.ReadSingleWith(context.Background(), reader, "2330", start, end); err != nil {
+		t.Errorf("ReadSingleWith() without overrides error = %v", err)
+	}
+}
+
+func TestWithCallOptions_Merges(t *testing.T) {
+	server, requests := finmindServer(t, 0)
+	start := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	end := time.Date(2024, 1, 31, 0, 0, 0, 0, time.UTC)
+
+	opts := &internalhttp.ClientOptions{Timeout: 5 * time.Second, RateLimit: 1000}
+	reader := finmind.NewFinMindReaderWithEndpoint(opts, server.URL)
+
+	ctx := datareader.WithCallOptions(context.Background(), datareader.WithAPIKey("ctx-token"))
+	if _, err := datareader.ReadSingleWith(ctx, reader, "2330", start, end, datareader.WithDataset("TaiwanStockPER")); err != nil {
+		t.Fatalf("ReadSingleWith() error = %v", err)
+	}
+
+	want := finmindRequest{dataset: "TaiwanStockPER", auth: "Bearer ctx-token"}
+	if got := requests(); len(got) != 1 || got[0] != want {
+		t.Errorf("requests = %+v, want [%+v]", got, want)
+	}
+}
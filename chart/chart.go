@@ -0,0 +1,116 @@
+// Package chart converts datasets into plot-ready structures: XY series
+// compatible with gonum/plot, and ECharts or Chart.js option objects that
+// marshal directly to the JSON those libraries expect.
+//
+// # Example Usage
+//
+//	ds, err := datareader.ReadDataset(ctx, "AAPL", "yahoo", start, end, nil)
+//	if err != nil {
+//		log.Fatal(err)
+//	}
+//
+//	// ECharts line chart
+//	option, err := chart.ECharts(ds, "Close")
+//	js, _ := json.Marshal(option) // pass to echarts.setOption
+//
+//	// gonum/plot line, no extra conversion needed
+//	xy, err := chart.XY(ds, "Close")
+//	line, err := plotter.NewLine(xy)
+package chart
+
+import (
+	"fmt"
+	"math"
+	"strconv"
+
+	"github.com/julianshen/gonp-datareader/dataset"
+)
+
+// DateLayout is the layout used for date labels.
+const DateLayout = "2006-01-02"
+
+// Values is a float64 slice whose missing (NaN or infinite) values marshal
+// to JSON null, which charting libraries render as gaps.
+type Values []float64
+
+// MarshalJSON implements json.Marshaler.
+func (v Values) MarshalJSON() ([]byte, error) {
+	b := make([]byte, 0, len(v)*8+2)
+	b = append(b, '[')
+	for i, f := range v {
+		if i > 0 {
+			b = append(b, ',')
+		}
+		if math.IsNaN(f) || math.IsInf(f, 0) {
+			b = append(b, "null"...)
+			continue
+		}
+		b = strconv.AppendFloat(b, f, 'g', -1, 64)
+	}
+	return append(b, ']'), nil
+}
+
+// XYSeries is a named series of points. It implements the gonum/plot
+// plotter.XYer interface (Len and XY), so it can be passed to
+// plotter.NewLine or plotter.NewScatter without importing gonum here.
+type XYSeries struct {
+	Name string
+	// X holds dates as Unix seconds, as expected by plot.TimeTicks.
+	X []float64
+	Y []float64
+}
+
+// Len returns the number of points.
+func (s *XYSeries) Len() int {
+	return len(s.X)
+}
+
+// XY returns the i-th point.
+func (s *XYSeries) XY(i int) (x, y float64) {
+	return s.X[i], s.Y[i]
+}
+
+// XY returns column of ds as an XY series, skipping missing values.
+func XY(ds *dataset.Dataset, column string) (*XYSeries, error) {
+	values, err := lookup(ds, column)
+	if err != nil {
+		return nil, err
+	}
+
+	s := &XYSeries{Name: column}
+	for i, v := range values {
+		if math.IsNaN(v) || math.IsInf(v, 0) {
+			continue
+		}
+		s.X = append(s.X, float64(ds.Dates[i].Unix()))
+		s.Y = append(s.Y, v)
+	}
+	return s, nil
+}
+
+// dateLabels formats the dataset dates as category labels.
+func dateLabels(ds *dataset.Dataset) []string {
+	labels := make([]string, len(ds.Dates))
+	for i, d := range ds.Dates {
+		labels[i] = d.Format(DateLayout)
+	}
+	return labels
+}
+
+// lookup returns the values of column, or an error naming the columns
+// that are available.
+func lookup(ds *dataset.Dataset, column string) ([]float64, error) {
+	values, ok := ds.Column(column)
+	if !ok {
+		return nil, fmt.Errorf("column %q not found (have %v)", column, ds.ColumnNames())
+	}
+	return values, nil
+}
+
+// title returns the chart title for ds.
+func title(ds *dataset.Dataset) string {
+	if ds.Symbol != "" {
+		return ds.Symbol
+	}
+	return ds.Source
+}
@@ -0,0 +1,135 @@
+package chart_test
+
+import (
+	"encoding/json"
+	"math"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/julianshen/gonp-datareader/chart"
+	"github.com/julianshen/gonp-datareader/dataset"
+)
+
+func ohlc(t *testing.T) *dataset.Dataset {
+	t.Helper()
+	ds := dataset.New("AAPL", "yahoo", []time.Time{
+		time.Date(2024, 1, 2, 0, 0, 0, 0, time.UTC),
+		time.Date(2024, 1, 3, 0, 0, 0, 0, time.UTC),
+		time.Date(2024, 1, 4, 0, 0, 0, 0, time.UTC),
+	})
+	for _, c := range []struct {
+		name   string
+		values []float64
+	}{
+		{"Open", []float64{10, 11, 12}},
+		{"High", []float64{12, 13, 14}},
+		{"Low", []float64{9, 10, 11}},
+		{"Close", []float64{11, math.NaN(), 13.5}},
+	} {
+		if err := ds.AddColumn(c.name, c.values); err != nil {
+			t.Fatal(err)
+		}
+	}
+	return ds
+}
+
+func marshal(t *testing.T, v interface{}) string {
+	t.Helper()
+	b, err := json.Marshal(v)
+	if err != nil {
+		t.Fatalf("json.Marshal() error = %v", err)
+	}
+	return string(b)
+}
+
+func TestValues_MarshalJSON(t *testing.T) {
+	got := marshal(t, chart.Values{1, math.NaN(), 2.5, math.Inf(1)})
+	if got != "[1,null,2.5,null]" {
+		t.Errorf("MarshalJSON() = %s, want [1,null,2.5,null]", got)
+	}
+}
+
+func TestXY(t *testing.T) {
+	xy, err := chart.XY(ohlc(t), "Close")
+	if err != nil {
+		t.Fatalf("XY() error = %v", err)
+	}
+
+	if xy.Len() != 2 {
+		t.Fatalf("Len() = %d, want 2 (missing value skipped)", xy.Len())
+	}
+	x, y := xy.XY(1)
+	if want := float64(time.Date(2024, 1, 4, 0, 0, 0, 0, time.UTC).Unix()); x != want || y != 13.5 {
+		t.Errorf("XY(1) = (%v, %v), want (%v, 13.5)", x, y, want)
+	}
+
+	if _, err := chart.XY(ohlc(t), "Volume"); err == nil {
+		t.Error("XY() expected error for missing column")
+	}
+}
+
+func TestECharts(t *testing.T) {
+	option, err := chart.ECharts(ohlc(t), "Close")
+	if err != nil {
+		t.Fatalf("ECharts() error = %v", err)
+	}
+
+	got := marshal(t, option)
+	for _, want := range []string{
+		`"title":{"text":"AAPL"}`,
+		`"xAxis":{"type":"category","data":["2024-01-02","2024-01-03","2024-01-04"]}`,
+		`"series":[{"name":"Close","type":"line","data":[11,null,13.5],"showSymbol":false}]`,
+	} {
+		if !strings.Contains(got, want) {
+			t.Errorf("ECharts JSON missing %s:\n%s", want, got)
+		}
+	}
+}
+
+func TestECharts_AllColumns(t *testing.T) {
+	option, err := chart.ECharts(ohlc(t))
+	if err != nil {
+		t.Fatalf("ECharts() error = %v", err)
+	}
+	if len(option.Series) != 4 {
+		t.Errorf("len(Series) = %d, want 4", len(option.Series))
+	}
+}
+
+func TestEChartsCandlestick(t *testing.T) {
+	option, err := chart.EChartsCandlestick(ohlc(t))
+	if err != nil {
+		t.Fatalf("EChartsCandlestick() error = %v", err)
+	}
+
+	got := marshal(t, option.Series)
+	want := `[{"name":"AAPL","type":"candlestick","data":[[10,11,9,12],[11,null,10,13],[12,13.5,11,14]]}]`
+	if got != want {
+		t.Errorf("Series = %s, want %s", got, want)
+	}
+
+	ds := dataset.New("GDP", "fred", nil)
+	if _, err := chart.EChartsCandlestick(ds); err == nil {
+		t.Error("EChartsCandlestick() expected error without OHLC columns")
+	}
+}
+
+func TestChartJS(t *testing.T) {
+	config, err := chart.ChartJS(ohlc(t), "Open", "Close")
+	if err != nil {
+		t.Fatalf("ChartJS() error = %v", err)
+	}
+
+	got := marshal(t, config)
+	for _, want := range []string{
+		`"type":"line"`,
+		`"labels":["2024-01-02","2024-01-03","2024-01-04"]`,
+		`{"label":"Close","data":[11,null,13.5],"pointRadius":0,"spanGaps":false}`,
+		`"title":{"display":true,"text":"AAPL"}`,
+	} {
+		if !strings.Contains(got, want) {
+			t.Errorf("Chart.js JSON missing %s:\n%s", want, got)
+		}
+	}
+}
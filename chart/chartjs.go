@@ -0,0 +1,70 @@
+package chart
+
+import (
+	"github.com/julianshen/gonp-datareader/dataset"
+)
+
+// ChartJSConfig is a Chart.js configuration object. Marshal it to JSON and
+// pass it to new Chart(ctx, config).
+type ChartJSConfig struct {
+	Type    string         `json:"type"`
+	Data    ChartJSData    `json:"data"`
+	Options ChartJSOptions `json:"options"`
+}
+
+// ChartJSData holds the category labels and datasets.
+type ChartJSData struct {
+	Labels   []string         `json:"labels"`
+	Datasets []ChartJSDataset `json:"datasets"`
+}
+
+// ChartJSDataset is one series.
+type ChartJSDataset struct {
+	Label       string  `json:"label"`
+	Data        Values  `json:"data"`
+	PointRadius float64 `json:"pointRadius"`
+	SpanGaps    bool    `json:"spanGaps"`
+}
+
+// ChartJSOptions holds the chart options.
+type ChartJSOptions struct {
+	Plugins ChartJSPlugins `json:"plugins"`
+}
+
+// ChartJSPlugins configures the built-in plugins.
+type ChartJSPlugins struct {
+	Title ChartJSTitle `json:"title"`
+}
+
+// ChartJSTitle is the chart title.
+type ChartJSTitle struct {
+	Display bool   `json:"display"`
+	Text    string `json:"text"`
+}
+
+// ChartJS builds a Chart.js line chart of the given columns of ds. With no
+// columns, every column is plotted.
+func ChartJS(ds *dataset.Dataset, columns ...string) (*ChartJSConfig, error) {
+	if len(columns) == 0 {
+		columns = ds.ColumnNames()
+	}
+
+	config := &ChartJSConfig{
+		Type: "line",
+		Data: ChartJSData{Labels: dateLabels(ds)},
+		Options: ChartJSOptions{
+			Plugins: ChartJSPlugins{Title: ChartJSTitle{Display: true, Text: title(ds)}},
+		},
+	}
+	for _, name := range columns {
+		values, err := lookup(ds, name)
+		if err != nil {
+			return nil, err
+		}
+		config.Data.Datasets = append(config.Data.Datasets, ChartJSDataset{
+			Label: name,
+			Data:  Values(values),
+		})
+	}
+	return config, nil
+}
@@ -0,0 +1,104 @@
+package chart
+
+import (
+	"github.com/julianshen/gonp-datareader/dataset"
+)
+
+// EChartsOption is the subset of the ECharts option object needed for
+// time series charts. Marshal it to JSON and pass it to chart.setOption.
+type EChartsOption struct {
+	Title   EChartsTitle    `json:"title"`
+	Tooltip EChartsTooltip  `json:"tooltip"`
+	Legend  EChartsLegend   `json:"legend"`
+	XAxis   EChartsAxis     `json:"xAxis"`
+	YAxis   EChartsAxis     `json:"yAxis"`
+	Series  []EChartsSeries `json:"series"`
+}
+
+// EChartsTitle is the chart title.
+type EChartsTitle struct {
+	Text string `json:"text"`
+}
+
+// EChartsTooltip configures the tooltip.
+type EChartsTooltip struct {
+	Trigger string `json:"trigger"`
+}
+
+// EChartsLegend lists the series names.
+type EChartsLegend struct {
+	Data []string `json:"data"`
+}
+
+// EChartsAxis configures an axis.
+type EChartsAxis struct {
+	Type  string   `json:"type"`
+	Data  []string `json:"data,omitempty"`
+	Scale bool     `json:"scale,omitempty"`
+}
+
+// EChartsSeries is one series. Data holds Values for line series and
+// [open, close, low, high] rows for candlestick series.
+type EChartsSeries struct {
+	Name       string      `json:"name"`
+	Type       string      `json:"type"`
+	Data       interface{} `json:"data"`
+	ShowSymbol *bool       `json:"showSymbol,omitempty"`
+}
+
+// ECharts builds a line chart of the given columns of ds. With no columns,
+// every column is plotted.
+func ECharts(ds *dataset.Dataset, columns ...string) (*EChartsOption, error) {
+	if len(columns) == 0 {
+		columns = ds.ColumnNames()
+	}
+
+	option := newEChartsOption(ds)
+	showSymbol := false
+	for _, name := range columns {
+		values, err := lookup(ds, name)
+		if err != nil {
+			return nil, err
+		}
+		option.Legend.Data = append(option.Legend.Data, name)
+		option.Series = append(option.Series, EChartsSeries{
+			Name:       name,
+			Type:       "line",
+			Data:       Values(values),
+			ShowSymbol: &showSymbol,
+		})
+	}
+	return option, nil
+}
+
+// EChartsCandlestick builds a candlestick chart from the Open, High, Low
+// and Close columns of ds.
+func EChartsCandlestick(ds *dataset.Dataset) (*EChartsOption, error) {
+	var ohlc [4][]float64
+	for i, name := range []string{"Open", "Close", "Low", "High"} {
+		values, err := lookup(ds, name)
+		if err != nil {
+			return nil, err
+		}
+		ohlc[i] = values
+	}
+
+	rows := make([]Values, len(ds.Dates))
+	for i := range rows {
+		rows[i] = Values{ohlc[0][i], ohlc[1][i], ohlc[2][i], ohlc[3][i]}
+	}
+
+	option := newEChartsOption(ds)
+	option.Legend.Data = []string{title(ds)}
+	option.Series = []EChartsSeries{{Name: title(ds), Type: "candlestick", Data: rows}}
+	return option, nil
+}
+
+func newEChartsOption(ds *dataset.Dataset) *EChartsOption {
+	return &EChartsOption{
+		Title:   EChartsTitle{Text: title(ds)},
+		Tooltip: EChartsTooltip{Trigger: "axis"},
+		XAxis:   EChartsAxis{Type: "category", Data: dateLabels(ds)},
+		YAxis:   EChartsAxis{Type: "value", Scale: true},
+	}
+}
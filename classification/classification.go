@@ -0,0 +1,165 @@
+// Package classification maps symbols to sectors and industries so that
+// market data can be grouped, e.g., when aggregating the performance of a
+// market snapshot by sector.
+//
+// Maps are built from FinMind's TaiwanStockInfo industry categories with
+// FromFinMind, or loaded from a JSON file of custom (e.g., GICS-like)
+// mappings with Load. Maps can be combined with Merge.
+//
+// # Example Usage
+//
+//	reader := finmind.NewFinMindReader(nil)
+//	sectors, err := classification.FromFinMind(ctx, reader)
+//	if err != nil {
+//		log.Fatal(err)
+//	}
+//	snapshot, _ := datareader.ReadMarketSnapshot(ctx, "twse", time.Time{}, nil)
+//	summary, _ := report.SummarizeMarket("TWSE", snapshot, nil, 10)
+//	for _, s := range report.SectorPerformance(summary.Movers, sectors.Sector) {
+//		fmt.Printf("%s %+.2f%%\n", s.Sector, s.MeanChangePct)
+//	}
+package classification
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"sort"
+
+	"github.com/julianshen/gonp-datareader/sources/finmind"
+)
+
+// Unclassified is the group of symbols without a known sector.
+const Unclassified = "Unclassified"
+
+// Entry classifies one symbol.
+type Entry struct {
+	Symbol   string `json:"symbol"`
+	Name     string `json:"name,omitempty"`
+	Sector   string `json:"sector"`
+	Industry string `json:"industry,omitempty"`
+	Market   string `json:"market,omitempty"`
+}
+
+// Map holds entries keyed by symbol.
+type Map map[string]Entry
+
+// Sector returns the sector of symbol, or Unclassified when it is unknown.
+func (m Map) Sector(symbol string) string {
+	if e, ok := m[symbol]; ok && e.Sector != "" {
+		return e.Sector
+	}
+	return Unclassified
+}
+
+// Group returns symbols grouped by sector, each group in input order.
+func (m Map) Group(symbols []string) map[string][]string {
+	groups := make(map[string][]string)
+	for _, symbol := range symbols {
+		sector := m.Sector(symbol)
+		groups[sector] = append(groups[sector], symbol)
+	}
+	return groups
+}
+
+// Sectors returns the distinct sectors in m, sorted.
+func (m Map) Sectors() []string {
+	seen := make(map[string]bool)
+	var out []string
+	for _, e := range m {
+		if e.Sector != "" && !seen[e.Sector] {
+			seen[e.Sector] = true
+			out = append(out, e.Sector)
+		}
+	}
+	sort.Strings(out)
+	return out
+}
+
+// Merge returns a map holding the entries of every map; entries of later
+// maps replace earlier ones, so custom mappings can override a source.
+func Merge(maps ...Map) Map {
+	out := make(Map)
+	for _, m := range maps {
+		for symbol, e := range m {
+			out[symbol] = e
+		}
+	}
+	return out
+}
+
+// FromFinMind builds a map from FinMind's TaiwanStockInfo dataset, using
+// the industry category as both sector and industry and the listing type
+// ("twse" or "tpex") as market. A security listed under several industry
+// categories keeps its most recent entry.
+func FromFinMind(ctx context.Context, reader *finmind.FinMindReader) (Map, error) {
+	info, err := reader.ReadStockInfo(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("read stock info: %w", err)
+	}
+
+	m := make(Map, len(info))
+	dates := make(map[string]string, len(info))
+	for _, s := range info {
+		if s.StockID == "" {
+			continue
+		}
+		if prev, ok := dates[s.StockID]; ok && s.Date < prev {
+			continue
+		}
+		dates[s.StockID] = s.Date
+		m[s.StockID] = Entry{
+			Symbol:   s.StockID,
+			Name:     s.StockName,
+			Sector:   s.IndustryCategory,
+			Industry: s.IndustryCategory,
+			Market:   s.Type,
+		}
+	}
+	return m, nil
+}
+
+// Read decodes a JSON array of entries.
+func Read(r io.Reader) (Map, error) {
+	var entries []Entry
+	if err := json.NewDecoder(r).Decode(&entries); err != nil {
+		return nil, fmt.Errorf("decode classification: %w", err)
+	}
+
+	m := make(Map, len(entries))
+	for _, e := range entries {
+		if e.Symbol == "" {
+			return nil, fmt.Errorf("decode classification: entry without symbol")
+		}
+		m[e.Symbol] = e
+	}
+	return m, nil
+}
+
+// Load reads a JSON array of entries from path, e.g.:
+//
+//	[{"symbol": "AAPL", "sector": "Information Technology", "industry": "Technology Hardware"}]
+func Load(path string) (Map, error) {
+	f, err := os.Open(path) // #nosec G304 - Path is chosen by the caller
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+	return Read(f)
+}
+
+// Write encodes m as a JSON array of entries sorted by symbol, in the
+// format read by Read and Load.
+func (m Map) Write(w io.Writer) error {
+	entries := make([]Entry, 0, len(m))
+	for _, e := range m {
+		entries = append(entries, e)
+	}
+	sort.Slice(entries, func(i, j int) bool { return entries[i].Symbol < entries[j].Symbol })
+
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	return enc.Encode(entries)
+}
@@ -0,0 +1,96 @@
+package classification_test
+
+import (
+	"bytes"
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+
+	"github.com/julianshen/gonp-datareader/classification"
+	"github.com/julianshen/gonp-datareader/sources/finmind"
+)
+
+func TestFromFinMind(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if got := r.URL.Query().Get("dataset"); got != "TaiwanStockInfo" {
+			t.Errorf("dataset = %q, want TaiwanStockInfo", got)
+		}
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"msg":"success","status":200,"data":[
+			{"industry_category":"半導體業","stock_id":"2330","stock_name":"台積電","type":"twse","date":"2024-01-02"},
+			{"industry_category":"電子工業","stock_id":"2330","stock_name":"台積電","type":"twse","date":"2023-06-01"},
+			{"industry_category":"其他電子業","stock_id":"2317","stock_name":"鴻海","type":"twse","date":"2024-01-02"},
+			{"industry_category":"ETF","stock_id":"0050","stock_name":"元大台灣50","type":"twse","date":"2024-01-02"}
+		]}`))
+	}))
+	defer server.Close()
+
+	reader := finmind.NewFinMindReaderWithEndpoint(nil, server.URL)
+	m, err := classification.FromFinMind(context.Background(), reader)
+	if err != nil {
+		t.Fatalf("FromFinMind() error = %v", err)
+	}
+
+	want := classification.Entry{Symbol: "2330", Name: "台積電", Sector: "半導體業", Industry: "半導體業", Market: "twse"}
+	if m["2330"] != want {
+		t.Errorf("m[2330] = %+v, want %+v", m["2330"], want)
+	}
+	if got := m.Sectors(); !reflect.DeepEqual(got, []string{"ETF", "其他電子業", "半導體業"}) {
+		t.Errorf("Sectors() = %v", got)
+	}
+}
+
+func TestMap_Group(t *testing.T) {
+	m := classification.Map{
+		"AAPL": {Symbol: "AAPL", Sector: "Information Technology"},
+		"MSFT": {Symbol: "MSFT", Sector: "Information Technology"},
+		"XOM":  {Symbol: "XOM", Sector: "Energy"},
+	}
+
+	got := m.Group([]string{"AAPL", "XOM", "MSFT", "ZZZZ"})
+	want := map[string][]string{
+		"Information Technology":    {"AAPL", "MSFT"},
+		"Energy":                    {"XOM"},
+		classification.Unclassified: {"ZZZZ"},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("Group() = %v, want %v", got, want)
+	}
+}
+
+func TestLoadAndMerge(t *testing.T) {
+	base := classification.Map{
+		"AAPL": {Symbol: "AAPL", Sector: "Technology"},
+		"XOM":  {Symbol: "XOM", Sector: "Energy"},
+	}
+	custom := classification.Map{"AAPL": {Symbol: "AAPL", Sector: "Information Technology", Industry: "Technology Hardware"}}
+
+	var buf bytes.Buffer
+	if err := custom.Write(&buf); err != nil {
+		t.Fatal(err)
+	}
+	path := filepath.Join(t.TempDir(), "gics.json")
+	if err := os.WriteFile(path, buf.Bytes(), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	loaded, err := classification.Load(path)
+	if err != nil {
+		t.Fatalf("Load() error = %v", err)
+	}
+	merged := classification.Merge(base, loaded)
+	if got := merged.Sector("AAPL"); got != "Information Technology" {
+		t.Errorf("Sector(AAPL) = %q, want the custom mapping", got)
+	}
+	if got := merged.Sector("XOM"); got != "Energy" {
+		t.Errorf("Sector(XOM) = %q, want Energy", got)
+	}
+
+	if _, err := classification.Read(bytes.NewBufferString(`[{"sector":"Energy"}]`)); err == nil {
+		t.Error("Read() expected error for entry without symbol")
+	}
+}
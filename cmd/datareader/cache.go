@@ -0,0 +1,98 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"text/tabwriter"
+	"time"
+
+	datareader "github.com/julianshen/gonp-datareader"
+)
+
+// defaultCacheDir is the default of the -cache-dir flags.
+const defaultCacheDir = ".cache/datareader"
+
+// runCache implements "datareader cache".
+func runCache(ctx context.Context, args []string, stdout io.Writer) error {
+	const usage = "usage: datareader cache ls [-cache-dir dir] | prune [-cache-dir dir] [-older-than duration]"
+
+	if len(args) == 0 {
+		return errors.New(usage)
+	}
+
+	fs := flag.NewFlagSet("cache "+args[0], flag.ContinueOnError)
+	dir := fs.String("cache-dir", defaultCacheDir, "response cache directory")
+	var olderThan *time.Duration
+	if args[0] == "prune" {
+		olderThan = fs.Duration("older-than", 0, "also remove entries cached longer ago than this (default: expired entries only)")
+	}
+	if err := fs.Parse(args[1:]); err != nil {
+		return err
+	}
+
+	switch args[0] {
+	case "ls":
+		entries, err := datareader.CacheInventory(*dir)
+		if err != nil {
+			return err
+		}
+		printCacheEntries(stdout, entries)
+		fmt.Fprintf(stdout, "%d entries, %d bytes\n", len(entries), totalSize(entries))
+	case "prune":
+		removed, err := datareader.PruneCache(*dir, *olderThan)
+		printCacheEntries(stdout, removed)
+		fmt.Fprintf(stdout, "removed %d entries, %d bytes\n", len(removed), totalSize(removed))
+		if err != nil {
+			return fmt.Errorf("cache prune: %w", err)
+		}
+	default:
+		return errors.New(usage)
+	}
+	return nil
+}
+
+// printCacheEntries writes entries as a table; unknown fields are shown
+// as "-".
+func printCacheEntries(w io.Writer, entries []datareader.CacheEntry) {
+	if len(entries) == 0 {
+		return
+	}
+
+	tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(tw, "SOURCE\tSYMBOL\tSTART\tEND\tAGE\tSIZE\tSTATUS")
+	for _, e := range entries {
+		status := "fresh"
+		if e.Expired() {
+			status = "expired"
+		}
+		fmt.Fprintf(tw, "%s\t%s\t%s\t%s\t%s\t%d\t%s\n",
+			orDash(e.Source), orDash(e.Symbol), formatDay(e.Start), formatDay(e.End),
+			e.Age().Round(time.Second), e.Size, status)
+	}
+	tw.Flush()
+}
+
+func totalSize(entries []datareader.CacheEntry) int64 {
+	var n int64
+	for _, e := range entries {
+		n += e.Size
+	}
+	return n
+}
+
+func orDash(s string) string {
+	if s == "" {
+		return "-"
+	}
+	return s
+}
+
+func formatDay(t time.Time) string {
+	if t.IsZero() {
+		return "-"
+	}
+	return t.Format("2006-01-02")
+}
@@ -0,0 +1,54 @@
+package main
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/julianshen/gonp-datareader/internal/cache"
+)
+
+func TestRunCache(t *testing.T) {
+	dir := t.TempDir()
+	c := cache.NewFileCache(dir)
+	label := &cache.Label{
+		Source: "yahoo",
+		Symbol: "AAPL",
+		Start:  time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC),
+		End:    time.Date(2024, 1, 31, 0, 0, 0, 0, time.UTC),
+	}
+	if err := c.SetLabeled("fresh", []byte("data"), time.Hour, label); err != nil {
+		t.Fatal(err)
+	}
+	if err := c.Set("expired", []byte("data"), time.Nanosecond); err != nil {
+		t.Fatal(err)
+	}
+	time.Sleep(time.Millisecond)
+
+	out, err := runCLI(t, "cache", "ls", "-cache-dir", dir)
+	if err != nil {
+		t.Fatalf("cache ls error = %v", err)
+	}
+	for _, want := range []string{"yahoo", "AAPL", "2024-01-01", "2024-01-31", "fresh", "expired", "2 entries"} {
+		if !strings.Contains(out, want) {
+			t.Errorf("cache ls output lacks %q:\n%s", want, out)
+		}
+	}
+
+	out, err = runCLI(t, "cache", "prune", "-cache-dir", dir)
+	if err != nil {
+		t.Fatalf("cache prune error = %v", err)
+	}
+	if !strings.Contains(out, "removed 1 entries") || strings.Contains(out, "AAPL") {
+		t.Errorf("cache prune should remove only the expired entry:\n%s", out)
+	}
+
+	out, err = runCLI(t, "cache", "prune", "-cache-dir", dir, "-older-than", "1ns")
+	if err != nil || !strings.Contains(out, "AAPL") {
+		t.Errorf("cache prune -older-than = %q, %v, want the fresh entry removed", out, err)
+	}
+
+	if _, err := runCLI(t, "cache", "bogus"); err == nil {
+		t.Error("cache bogus expected a usage error")
+	}
+}
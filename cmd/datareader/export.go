@@ -0,0 +1,215 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	datareader "github.com/julianshen/gonp-datareader"
+	"github.com/julianshen/gonp-datareader/dataset"
+	"github.com/julianshen/gonp-datareader/watchlist"
+)
+
+// exportFormat writes a dataset in one file format.
+type exportFormat struct {
+	ext   string
+	write func(d *dataset.Dataset, w io.Writer) error
+}
+
+// exportFormats holds the -format values of "datareader export"; arrow
+// and parquet are added by builds with the arrow build tag.
+var exportFormats = map[string]exportFormat{
+	"csv": {ext: ".csv", write: (*dataset.Dataset).WriteCSV},
+}
+
+// listSymbols is replaced in tests.
+var listSymbols = datareader.ListSymbols
+
+// runExport implements "datareader export": a resumable backfill of a
+// whole symbol universe into one file per symbol.
+func runExport(ctx context.Context, args []string, stdout io.Writer) error {
+	fs := flag.NewFlagSet("export", flag.ContinueOnError)
+	source := fs.String("source", defaultSource, "data source to download from")
+	universe := fs.String("universe", "", "saved watchlist name, or a source whose listing gives the symbols (yahoo, twse, stooq)")
+	symbols := fs.String("symbols", "", "comma-separated symbols, added to the universe")
+	startFlag := fs.String("start", "", "first date, YYYY-MM-DD (required)")
+	endFlag := fs.String("end", "", "last date, YYYY-MM-DD (default today)")
+	format := fs.String("format", "csv", "file format: "+strings.Join(exportFormatNames(), ", "))
+	out := fs.String("out", "data", "output directory; files are written to <out>/<source>/<symbol>.<format>")
+	apiKey := fs.String("api-key", "", "API key for the source")
+	rate := fs.Float64("rate", 0, "maximum requests per second (default: the source's limit)")
+	quota := fs.Int("quota", 0, "maximum symbols to download in this run; rerun to resume (0 means no limit)")
+	overwrite := fs.Bool("overwrite", false, "download symbols whose file already exists")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+
+	enc, ok := exportFormats[*format]
+	if !ok && (*format == "arrow" || *format == "parquet") {
+		return fmt.Errorf("export: format %q requires a build with -tags arrow", *format)
+	}
+	if !ok {
+		return fmt.Errorf("export: unsupported format %q (available: %s)", *format, strings.Join(exportFormatNames(), ", "))
+	}
+	if *startFlag == "" {
+		return errors.New("export: -start is required")
+	}
+	start, err := time.Parse("2006-01-02", *startFlag)
+	if err != nil {
+		return fmt.Errorf("export: invalid -start: %w", err)
+	}
+	end := time.Now()
+	if *endFlag != "" {
+		if end, err = time.Parse("2006-01-02", *endFlag); err != nil {
+			return fmt.Errorf("export: invalid -end: %w", err)
+		}
+	}
+
+	opts := datareader.DefaultOptions()
+	opts.APIKey = *apiKey
+	if *rate > 0 {
+		opts.RateLimit = *rate
+	}
+
+	syms, err := resolveUniverse(ctx, *universe, *symbols)
+	if err != nil {
+		return fmt.Errorf("export: %w", err)
+	}
+
+	dir := filepath.Join(*out, *source)
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return fmt.Errorf("export: %w", err)
+	}
+
+	// Symbols are downloaded one at a time in universe order; existing
+	// files are skipped so an interrupted or quota-limited run resumes
+	// where it stopped.
+	var exported, skipped, failed, requests int
+	width := len(fmt.Sprint(len(syms)))
+	for i, symbol := range syms {
+		if err := ctx.Err(); err != nil {
+			return fmt.Errorf("export: %w", err)
+		}
+		prefix := fmt.Sprintf("[%*d/%d] %s", width, i+1, len(syms), symbol)
+
+		path := filepath.Join(dir, safeFilename(symbol)+enc.ext)
+		if !*overwrite {
+			if _, err := os.Stat(path); err == nil {
+				skipped++
+				fmt.Fprintf(stdout, "%s skipped, %s exists\n", prefix, path)
+				continue
+			}
+		}
+		if *quota > 0 && requests >= *quota {
+			fmt.Fprintf(stdout, "quota of %d symbols reached, %d left; rerun to resume\n", *quota, len(syms)-i)
+			break
+		}
+
+		requests++
+		ds, err := readDataset(ctx, symbol, *source, start, end, opts)
+		if err == nil {
+			err = writeFileAtomic(path, func(w io.Writer) error { return enc.write(ds, w) })
+		}
+		if err != nil {
+			failed++
+			fmt.Fprintf(stdout, "%s failed: %v\n", prefix, err)
+			continue
+		}
+		exported++
+		fmt.Fprintf(stdout, "%s %d rows -> %s\n", prefix, ds.Len(), path)
+	}
+
+	fmt.Fprintf(stdout, "exported %d, skipped %d, failed %d of %d symbols\n", exported, skipped, failed, len(syms))
+	if failed > 0 {
+		return fmt.Errorf("export: %d symbols failed", failed)
+	}
+	return nil
+}
+
+// resolveUniverse returns the symbols of -universe followed by those of
+// -symbols. A universe is a saved watchlist, such as "sp500", or else the
+// name of a source whose symbol listing is used.
+func resolveUniverse(ctx context.Context, universe, symbols string) ([]string, error) {
+	var syms []string
+	if universe != "" {
+		store, err := openStore()
+		if err != nil {
+			return nil, err
+		}
+		w, err := store.Get(universe)
+		switch {
+		case err == nil:
+			syms = append(syms, w.Symbols...)
+		case errors.Is(err, watchlist.ErrNotFound):
+			infos, err := listSymbols(ctx, universe, nil)
+			if err != nil {
+				return nil, fmt.Errorf("universe %q is neither a watchlist nor a listing: %w", universe, err)
+			}
+			for _, info := range infos {
+				syms = append(syms, info.Symbol)
+			}
+		default:
+			return nil, err
+		}
+	}
+	syms = append(syms, splitList(symbols)...)
+
+	if len(syms) == 0 {
+		return nil, errors.New("-universe or -symbols is required")
+	}
+	return syms, nil
+}
+
+// exportFormatNames returns the -format values, csv first.
+func exportFormatNames() []string {
+	names := []string{"csv"}
+	for _, name := range []string{"arrow", "parquet"} {
+		if _, ok := exportFormats[name]; ok {
+			names = append(names, name)
+		}
+	}
+	return names
+}
+
+// writeFileAtomic fills path using write through a temporary file that
+// replaces path once complete, so a failed download never leaves a file
+// that a resumed run would skip.
+func writeFileAtomic(path string, write func(w io.Writer) error) error {
+	tmp := path + ".tmp"
+	f, err := os.Create(tmp)
+	if err != nil {
+		return err
+	}
+	if err := write(f); err != nil {
+		_ = f.Close()
+		_ = os.Remove(tmp)
+		return err
+	}
+	if err := f.Close(); err != nil {
+		_ = os.Remove(tmp)
+		return err
+	}
+	if err := os.Rename(tmp, path); err != nil {
+		_ = os.Remove(tmp)
+		return err
+	}
+	return nil
+}
+
+// safeFilename replaces characters that are unsafe in file names, as
+// recipes does for fetch.
+func safeFilename(symbol string) string {
+	return strings.Map(func(r rune) rune {
+		switch r {
+		case '/', '\\', ':', '*', '?', '"', '<', '>', '|':
+			return '_'
+		}
+		return r
+	}, symbol)
+}
@@ -0,0 +1,11 @@
+//go:build arrow
+// +build arrow
+
+package main
+
+import "github.com/julianshen/gonp-datareader/dataset"
+
+func init() {
+	exportFormats["arrow"] = exportFormat{ext: ".arrow", write: (*dataset.Dataset).WriteArrow}
+	exportFormats["parquet"] = exportFormat{ext: ".parquet", write: (*dataset.Dataset).WriteParquet}
+}
@@ -0,0 +1,101 @@
+package main
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	datareader "github.com/julianshen/gonp-datareader"
+	"github.com/julianshen/gonp-datareader/sources"
+)
+
+func TestRunExport_QuotaAndResume(t *testing.T) {
+	useTempWatchlists(t)
+	stubReadDataset(t)
+
+	if _, err := runCLI(t, "watchlist", "add", "sp500", "AAPL", "MSFT", "NVDA"); err != nil {
+		t.Fatal(err)
+	}
+
+	dir := t.TempDir()
+	args := []string{"export", "-source", "tiingo", "-universe", "sp500", "-start", "2024-01-01", "-out", dir, "-quota", "2"}
+	out, err := runCLI(t, args...)
+	if err != nil {
+		t.Fatalf("export error = %v", err)
+	}
+	if !strings.Contains(out, "[1/3] AAPL 2 rows") || !strings.Contains(out, "quota of 2 symbols reached, 1 left") {
+		t.Errorf("unexpected progress:\n%s", out)
+	}
+	if _, err := os.Stat(filepath.Join(dir, "tiingo", "NVDA.csv")); !os.IsNotExist(err) {
+		t.Errorf("NVDA.csv written past the quota: %v", err)
+	}
+
+	// The rerun skips the exported symbols and fetches the rest
+	out, err = runCLI(t, args...)
+	if err != nil {
+		t.Fatalf("resumed export error = %v", err)
+	}
+	if !strings.Contains(out, "[2/3] MSFT skipped") || !strings.Contains(out, "exported 1, skipped 2, failed 0 of 3 symbols") {
+		t.Errorf("unexpected progress on resume:\n%s", out)
+	}
+	content, err := os.ReadFile(filepath.Join(dir, "tiingo", "NVDA.csv"))
+	if err != nil || !strings.Contains(string(content), "2024-01-03") {
+		t.Errorf("NVDA.csv = %q, %v", content, err)
+	}
+}
+
+func TestRunExport_ListingUniverse(t *testing.T) {
+	useTempWatchlists(t)
+	stubReadDataset(t)
+
+	var gotSource string
+	orig := listSymbols
+	listSymbols = func(ctx context.Context, source string, opts *datareader.Options) ([]sources.SymbolInfo, error) {
+		gotSource = source
+		return []sources.SymbolInfo{{Symbol: "AAPL"}, {Symbol: "FAIL"}}, nil
+	}
+	t.Cleanup(func() { listSymbols = orig })
+
+	dir := t.TempDir()
+	out, err := runCLI(t, "export", "-universe", "yahoo", "-symbols", "BRK/B", "-start", "2024-01-01", "-out", dir)
+	if err == nil || !strings.Contains(err.Error(), "1 symbols failed") {
+		t.Errorf("export error = %v, want 1 failure", err)
+	}
+	if gotSource != "yahoo" {
+		t.Errorf("listed source = %q, want yahoo", gotSource)
+	}
+	if !strings.Contains(out, "[2/3] FAIL failed: upstream failed") {
+		t.Errorf("unexpected progress:\n%s", out)
+	}
+	for _, name := range []string{"AAPL.csv", "BRK_B.csv"} {
+		if _, err := os.Stat(filepath.Join(dir, "yahoo", name)); err != nil {
+			t.Errorf("%s not written: %v", name, err)
+		}
+	}
+}
+
+func TestRunExport_Errors(t *testing.T) {
+	useTempWatchlists(t)
+	stubReadDataset(t)
+
+	tests := []struct {
+		name string
+		args []string
+		want string
+	}{
+		{"no start", []string{"-symbols", "AAPL"}, "-start is required"},
+		{"bad start", []string{"-symbols", "AAPL", "-start", "2024/01/01"}, "invalid -start"},
+		{"no symbols", []string{"-start", "2024-01-01"}, "-universe or -symbols is required"},
+		{"bad format", []string{"-symbols", "AAPL", "-start", "2024-01-01", "-format", "xml"}, "unsupported format"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			args := append([]string{"export", "-out", t.TempDir()}, tt.args...)
+			if _, err := runCLI(t, args...); err == nil || !strings.Contains(err.Error(), tt.want) {
+				t.Errorf("export error = %v, want %q", err, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,60 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"io"
+	"time"
+
+	datareader "github.com/julianshen/gonp-datareader"
+	"github.com/julianshen/gonp-datareader/recipes"
+)
+
+// newReader is replaced in tests.
+var newReader = datareader.DataReader
+
+// runFetch implements "datareader fetch".
+func runFetch(ctx context.Context, args []string, stdout io.Writer) error {
+	fs := flag.NewFlagSet("fetch", flag.ContinueOnError)
+	source := fs.String("source", "", "data source (default: the watchlist's source, or yahoo)")
+	symbols := fs.String("symbols", "", "comma-separated symbols")
+	list := watchlistFlag(fs)
+	days := fs.Int("days", 365, "number of calendar days to fetch")
+	dir := fs.String("dir", "data", "output directory; files are written to <dir>/<source>/<symbol>.csv")
+	apiKey := fs.String("api-key", "", "API key for the source")
+	incremental := fs.Bool("incremental", false, "append only the days after the last date of existing files")
+	cacheDir := fs.String("cache-dir", "", "cache responses in this directory, e.g. "+defaultCacheDir+" (see \"datareader cache\")")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+
+	syms, src, err := resolveSymbols(*symbols, *list, *source)
+	if err != nil {
+		return fmt.Errorf("fetch: %w", err)
+	}
+
+	opts := datareader.DefaultOptions()
+	opts.APIKey = *apiKey
+	opts.CacheDir = *cacheDir
+	reader, err := newReader(src, opts)
+	if err != nil {
+		return fmt.Errorf("fetch: %w", err)
+	}
+
+	end := time.Now()
+	start := end.AddDate(0, 0, -*days)
+
+	fetch := recipes.BuildLocalDB
+	if *incremental {
+		fetch = recipes.UpdateLocalDB
+	}
+	paths, err := fetch(ctx, reader, syms, start, end, *dir)
+	for _, path := range paths {
+		fmt.Fprintln(stdout, path)
+	}
+	if err != nil {
+		return fmt.Errorf("fetch: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,95 @@
+// Command datareader fetches data from the datareader sources on the
+// command line.
+//
+// # Usage
+//
+//	datareader <command> [flags]
+//
+// Commands:
+//
+//	cache      list or prune cached responses
+//	export     backfill a whole symbol universe to files
+//	fetch      download symbols or a watchlist to CSV files
+//	market     summarize a whole-market daily snapshot
+//	report     render a market report for a list of symbols
+//	watchlist  manage saved symbol lists
+//
+// Run "datareader <command> -h" for the flags of each command. Upstream
+// API keys are given with -api-key. Watchlists are stored in the user
+// config directory (see watchlist.DefaultDir):
+//
+//	datareader watchlist add tech AAPL MSFT NVDA
+//	datareader fetch -watchlist tech -days 30
+//	datareader fetch -watchlist tech -incremental -cache-dir .cache/datareader
+//	datareader cache ls
+//	datareader cache prune -older-than 720h
+//	datareader export -source tiingo -universe sp500 -start 2000-01-01 -out data/
+package main
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+)
+
+// command is a datareader subcommand.
+type command struct {
+	name  string
+	usage string
+	run   func(ctx context.Context, args []string, stdout io.Writer) error
+}
+
+var commands = []command{
+	{name: "cache", usage: "list or prune cached responses", run: runCache},
+	{name: "export", usage: "backfill a whole symbol universe to files", run: runExport},
+	{name: "fetch", usage: "download symbols or a watchlist to CSV files", run: runFetch},
+	{name: "market", usage: "summarize a whole-market daily snapshot", run: runMarket},
+	{name: "report", usage: "render a market report for a list of symbols", run: runReport},
+	{name: "watchlist", usage: "manage saved symbol lists", run: runWatchlist},
+}
+
+func main() {
+	if err := run(context.Background(), os.Args[1:], os.Stdout, os.Stderr); err != nil {
+		fmt.Fprintln(os.Stderr, "datareader:", err)
+		os.Exit(1)
+	}
+}
+
+// run dispatches args to the matching command.
+func run(ctx context.Context, args []string, stdout, stderr io.Writer) error {
+	if len(args) == 0 || args[0] == "-h" || args[0] == "help" {
+		printUsage(stderr)
+		return nil
+	}
+
+	for _, c := range commands {
+		if c.name == args[0] {
+			return c.run(ctx, args[1:], stdout)
+		}
+	}
+
+	printUsage(stderr)
+	return fmt.Errorf("unknown command %q", args[0])
+}
+
+func printUsage(w io.Writer) {
+	fmt.Fprintln(w, "Usage: datareader <command> [flags]")
+	fmt.Fprintln(w)
+	fmt.Fprintln(w, "Commands:")
+	for _, c := range commands {
+		fmt.Fprintf(w, "  %-10s %s\n", c.name, c.usage)
+	}
+}
+
+// splitList splits a comma-separated flag value, dropping empty entries.
+func splitList(s string) []string {
+	var out []string
+	for _, v := range strings.Split(s, ",") {
+		if v = strings.TrimSpace(v); v != "" {
+			out = append(out, v)
+		}
+	}
+	return out
+}
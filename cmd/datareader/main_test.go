@@ -0,0 +1,105 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	datareader "github.com/julianshen/gonp-datareader"
+	"github.com/julianshen/gonp-datareader/dataset"
+)
+
+// stubReadDataset replaces readDataset with a fake returning a rising
+// series for every symbol.
+func stubReadDataset(t *testing.T) {
+	t.Helper()
+	orig := readDataset
+	t.Cleanup(func() { readDataset = orig })
+
+	readDataset = func(ctx context.Context, symbol, source string, start, end time.Time, opts *datareader.Options) (*dataset.Dataset, error) {
+		if symbol == "FAIL" {
+			return nil, errors.New("upstream failed")
+		}
+		ds := dataset.New(symbol, source, []time.Time{
+			time.Date(2024, 1, 2, 0, 0, 0, 0, time.UTC),
+			time.Date(2024, 1, 3, 0, 0, 0, 0, time.UTC),
+		})
+		if err := ds.AddColumn("Close", []float64{100, 102}); err != nil {
+			return nil, err
+		}
+		return ds, nil
+	}
+}
+
+func TestRun_Usage(t *testing.T) {
+	var stderr bytes.Buffer
+	if err := run(context.Background(), nil, &bytes.Buffer{}, &stderr); err != nil {
+		t.Fatalf("run() error = %v", err)
+	}
+	if !strings.Contains(stderr.String(), "report") {
+		t.Errorf("usage does not list commands:\n%s", stderr.String())
+	}
+
+	if err := run(context.Background(), []string{"bogus"}, &bytes.Buffer{}, &stderr); err == nil {
+		t.Error("run() expected error for unknown command")
+	}
+}
+
+func TestRunReport(t *testing.T) {
+	stubReadDataset(t)
+
+	var stdout bytes.Buffer
+	err := run(context.Background(), []string{"report", "-symbols", "AAPL, MSFT"}, &stdout, &bytes.Buffer{})
+	if err != nil {
+		t.Fatalf("run() error = %v", err)
+	}
+
+	out := stdout.String()
+	if !strings.Contains(out, "# Daily Market Report") || !strings.Contains(out, "| MSFT | yahoo | 102.00 | +2.00% |") {
+		t.Errorf("unexpected report:\n%s", out)
+	}
+}
+
+func TestRunReport_HTMLFile(t *testing.T) {
+	stubReadDataset(t)
+	path := filepath.Join(t.TempDir(), "report.html")
+
+	err := run(context.Background(), []string{"report", "-symbols", "AAPL", "-format", "html", "-o", path}, &bytes.Buffer{}, &bytes.Buffer{})
+	if err != nil {
+		t.Fatalf("run() error = %v", err)
+	}
+
+	content, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.HasPrefix(string(content), "<!DOCTYPE html>") {
+		t.Errorf("unexpected HTML:\n%s", content)
+	}
+}
+
+func TestRunReport_Errors(t *testing.T) {
+	stubReadDataset(t)
+
+	tests := []struct {
+		name string
+		args []string
+	}{
+		{"missing symbols", []string{"report"}},
+		{"bad format", []string{"report", "-symbols", "AAPL", "-format", "pdf"}},
+		{"fetch error", []string{"report", "-symbols", "AAPL,FAIL"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := run(context.Background(), tt.args, &bytes.Buffer{}, &bytes.Buffer{}); err == nil {
+				t.Error("run() expected error")
+			}
+		})
+	}
+}
@@ -0,0 +1,129 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"time"
+
+	datareader "github.com/julianshen/gonp-datareader"
+	"github.com/julianshen/gonp-datareader/classification"
+	"github.com/julianshen/gonp-datareader/dataset"
+	"github.com/julianshen/gonp-datareader/report"
+	"github.com/julianshen/gonp-datareader/sources/finmind"
+)
+
+// readSnapshot is replaced in tests.
+var readSnapshot = datareader.ReadMarketSnapshot
+
+// runMarket implements "datareader market".
+func runMarket(ctx context.Context, args []string, stdout io.Writer) error {
+	fs := flag.NewFlagSet("market", flag.ContinueOnError)
+	source := fs.String("source", "twse", "snapshot source: twse or stooq")
+	day := fs.String("date", "", "trading day as YYYY-MM-DD (default: latest, twse only)")
+	prev := fs.String("prev", "", "previous trading day as YYYY-MM-DD (default: the weekday before -date, when needed)")
+	top := fs.Int("top", 10, "number of gainers, losers and volume leaders")
+	title := fs.String("title", "Market Summary", "report title")
+	format := fs.String("format", "md", "output format: md or html")
+	output := fs.String("o", "", "output file (default stdout)")
+	sectors := fs.String("sectors", "", `sector classification: a JSON file, or "finmind" for TaiwanStockInfo`)
+	apiKey := fs.String("api-key", "", "FinMind token for -sectors finmind")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+
+	if *format != "md" && *format != "html" {
+		return fmt.Errorf("market: unsupported format %q", *format)
+	}
+	date, err := parseDay(*day)
+	if err != nil {
+		return fmt.Errorf("market: -date: %w", err)
+	}
+	prevDate, err := parseDay(*prev)
+	if err != nil {
+		return fmt.Errorf("market: -prev: %w", err)
+	}
+
+	opts := datareader.DefaultOptions()
+	snapshot, err := readSnapshot(ctx, *source, date, opts)
+	if err != nil {
+		return fmt.Errorf("market: %w", err)
+	}
+
+	// Snapshots without a Change column need the previous day's closes
+	var previous map[string]*dataset.Dataset
+	if prevDate.IsZero() && !date.IsZero() && !hasColumn(snapshot, "Change") {
+		prevDate = previousWeekday(date)
+	}
+	if !prevDate.IsZero() {
+		if previous, err = readSnapshot(ctx, *source, prevDate, opts); err != nil {
+			return fmt.Errorf("market: previous day: %w", err)
+		}
+	}
+
+	summary, err := report.SummarizeMarket(*title, snapshot, previous, *top)
+	if err != nil {
+		return fmt.Errorf("market: %w", err)
+	}
+
+	if *sectors != "" {
+		m, err := loadSectors(ctx, *sectors, *apiKey)
+		if err != nil {
+			return fmt.Errorf("market: sectors: %w", err)
+		}
+		summary.Sectors = report.SectorPerformance(summary.Movers, m.Sector)
+	}
+
+	w := stdout
+	if *output != "" {
+		f, err := os.Create(*output)
+		if err != nil {
+			return fmt.Errorf("market: %w", err)
+		}
+		defer f.Close()
+		w = f
+	}
+
+	if *format == "html" {
+		return summary.WriteHTML(w)
+	}
+	return summary.WriteMarkdown(w)
+}
+
+// loadSectors loads a classification file, or fetches FinMind's industry
+// categories when spec is "finmind".
+func loadSectors(ctx context.Context, spec, token string) (classification.Map, error) {
+	if spec != "finmind" {
+		return classification.Load(spec)
+	}
+	return classification.FromFinMind(ctx, finmind.NewFinMindReaderWithToken(nil, token))
+}
+
+// parseDay parses a YYYY-MM-DD flag value; empty means the zero time.
+func parseDay(s string) (time.Time, error) {
+	if s == "" {
+		return time.Time{}, nil
+	}
+	return time.Parse("2006-01-02", s)
+}
+
+// previousWeekday returns the closest Monday-to-Friday date before t.
+func previousWeekday(t time.Time) time.Time {
+	t = t.AddDate(0, 0, -1)
+	for t.Weekday() == time.Saturday || t.Weekday() == time.Sunday {
+		t = t.AddDate(0, 0, -1)
+	}
+	return t
+}
+
+// hasColumn reports whether any dataset in snapshot has the named column.
+func hasColumn(snapshot map[string]*dataset.Dataset, name string) bool {
+	for _, ds := range snapshot {
+		if _, ok := ds.Column(name); ok {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,108 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	datareader "github.com/julianshen/gonp-datareader"
+	"github.com/julianshen/gonp-datareader/dataset"
+)
+
+// stubReadSnapshot replaces readSnapshot with a fake returning a Stooq-like
+// snapshot (no Change column) whose closes rise by one per day, recording
+// the requested dates.
+func stubReadSnapshot(t *testing.T) *[]time.Time {
+	t.Helper()
+	orig := readSnapshot
+	t.Cleanup(func() { readSnapshot = orig })
+
+	var dates []time.Time
+	readSnapshot = func(ctx context.Context, source string, date time.Time, opts *datareader.Options) (map[string]*dataset.Dataset, error) {
+		dates = append(dates, date)
+		out := make(map[string]*dataset.Dataset)
+		for i, symbol := range []string{"AAPL.US", "MSFT.US"} {
+			ds := dataset.New(symbol, source, []time.Time{date})
+			close := float64(100*(i+1) + date.Day())
+			if err := ds.AddColumn("Close", []float64{close}); err != nil {
+				return nil, err
+			}
+			if err := ds.AddColumn("Volume", []float64{float64(1000 * (i + 1))}); err != nil {
+				return nil, err
+			}
+			out[symbol] = ds
+		}
+		return out, nil
+	}
+	return &dates
+}
+
+func TestRunMarket(t *testing.T) {
+	dates := stubReadSnapshot(t)
+
+	var stdout bytes.Buffer
+	err := run(context.Background(), []string{"market", "-source", "stooq", "-date", "2024-01-08"}, &stdout, &bytes.Buffer{})
+	if err != nil {
+		t.Fatalf("run() error = %v", err)
+	}
+
+	// 2024-01-08 is a Monday, so the previous snapshot is Friday's
+	if len(*dates) != 2 || (*dates)[1].Format("2006-01-02") != "2024-01-05" {
+		t.Errorf("requested dates = %v, want 2024-01-08 and 2024-01-05", *dates)
+	}
+
+	out := stdout.String()
+	for _, want := range []string{
+		"# Market Summary",
+		"| 2 | 0 | 0 | n/a | 3,000 |",
+		"| AAPL.US | 108.00 | +2.86% | 1,000 |",
+	} {
+		if !strings.Contains(out, want) {
+			t.Errorf("output missing %q:\n%s", want, out)
+		}
+	}
+}
+
+func TestRunMarket_Sectors(t *testing.T) {
+	stubReadSnapshot(t)
+	path := filepath.Join(t.TempDir(), "sectors.json")
+	if err := os.WriteFile(path, []byte(`[{"symbol":"AAPL.US","sector":"Technology"}]`), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	var stdout bytes.Buffer
+	args := []string{"market", "-source", "stooq", "-date", "2024-01-08", "-sectors", path}
+	if err := run(context.Background(), args, &stdout, &bytes.Buffer{}); err != nil {
+		t.Fatalf("run() error = %v", err)
+	}
+
+	out := stdout.String()
+	for _, want := range []string{"## Sectors", "| Technology | 1 | 1 | 0 | +2.86% | 1,000 |", "| Unclassified | 1 |"} {
+		if !strings.Contains(out, want) {
+			t.Errorf("output missing %q:\n%s", want, out)
+		}
+	}
+}
+
+func TestRunMarket_Errors(t *testing.T) {
+	stubReadSnapshot(t)
+
+	tests := []struct {
+		name string
+		args []string
+	}{
+		{"bad format", []string{"market", "-format", "pdf"}},
+		{"bad date", []string{"market", "-date", "01/08/2024"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := run(context.Background(), tt.args, &bytes.Buffer{}, &bytes.Buffer{}); err == nil {
+				t.Error("run() expected error")
+			}
+		})
+	}
+}
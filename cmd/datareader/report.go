@@ -0,0 +1,77 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"time"
+
+	datareader "github.com/julianshen/gonp-datareader"
+	"github.com/julianshen/gonp-datareader/dataset"
+	"github.com/julianshen/gonp-datareader/report"
+)
+
+// readDataset is replaced in tests.
+var readDataset = datareader.ReadDataset
+
+// runReport implements "datareader report".
+func runReport(ctx context.Context, args []string, stdout io.Writer) error {
+	fs := flag.NewFlagSet("report", flag.ContinueOnError)
+	source := fs.String("source", "", "data source (default: the watchlist's source, or yahoo)")
+	symbols := fs.String("symbols", "", "comma-separated symbols")
+	list := watchlistFlag(fs)
+	column := fs.String("column", "Close", "column to summarize")
+	days := fs.Int("days", 30, "number of calendar days to cover")
+	title := fs.String("title", "Daily Market Report", "report title")
+	format := fs.String("format", "md", "output format: md or html")
+	output := fs.String("o", "", "output file (default stdout)")
+	apiKey := fs.String("api-key", "", "API key for the source")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+
+	syms, src, err := resolveSymbols(*symbols, *list, *source)
+	if err != nil {
+		return fmt.Errorf("report: %w", err)
+	}
+	if *format != "md" && *format != "html" {
+		return fmt.Errorf("report: unsupported format %q", *format)
+	}
+
+	opts := datareader.DefaultOptions()
+	opts.APIKey = *apiKey
+
+	end := time.Now()
+	start := end.AddDate(0, 0, -*days)
+
+	datasets := make([]*dataset.Dataset, 0, len(syms))
+	for _, symbol := range syms {
+		ds, err := readDataset(ctx, symbol, src, start, end, opts)
+		if err != nil {
+			return fmt.Errorf("report: %s: %w", symbol, err)
+		}
+		datasets = append(datasets, ds)
+	}
+
+	r, err := report.New(*title, *column, datasets...)
+	if err != nil {
+		return fmt.Errorf("report: %w", err)
+	}
+
+	w := stdout
+	if *output != "" {
+		f, err := os.Create(*output)
+		if err != nil {
+			return fmt.Errorf("report: %w", err)
+		}
+		defer f.Close()
+		w = f
+	}
+
+	if *format == "html" {
+		return r.WriteHTML(w)
+	}
+	return r.WriteMarkdown(w)
+}
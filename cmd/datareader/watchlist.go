@@ -0,0 +1,126 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/julianshen/gonp-datareader/watchlist"
+)
+
+// defaultSource is used when neither -source nor the watchlist names one.
+const defaultSource = "yahoo"
+
+// watchlistDir overrides the watchlist directory; empty means
+// watchlist.DefaultDir. Replaced in tests.
+var watchlistDir = ""
+
+// openStore returns the watchlist store.
+func openStore() (*watchlist.Store, error) {
+	if watchlistDir != "" {
+		return watchlist.NewStore(watchlistDir), nil
+	}
+	return watchlist.DefaultStore()
+}
+
+// resolveSymbols returns the symbols and source to fetch from the -symbols,
+// -watchlist and -source flags.
+func resolveSymbols(symbols, list, source string) ([]string, string, error) {
+	syms := splitList(symbols)
+	if list != "" {
+		store, err := openStore()
+		if err != nil {
+			return nil, "", err
+		}
+		w, err := store.Get(list)
+		if err != nil {
+			return nil, "", err
+		}
+		syms = append(syms, w.Symbols...)
+		if source == "" {
+			source = w.Source
+		}
+	}
+
+	if len(syms) == 0 {
+		return nil, "", errors.New("-symbols or -watchlist is required")
+	}
+	if source == "" {
+		source = defaultSource
+	}
+	return syms, source, nil
+}
+
+// runWatchlist implements "datareader watchlist".
+func runWatchlist(ctx context.Context, args []string, stdout io.Writer) error {
+	const usage = "usage: datareader watchlist list | show <name> | add <name> <symbol>... | remove <name> <symbol>... | delete <name> | source <name> <source>"
+
+	if len(args) == 0 {
+		return errors.New(usage)
+	}
+	store, err := openStore()
+	if err != nil {
+		return err
+	}
+
+	action, rest := args[0], args[1:]
+	if action == "list" {
+		names, err := store.List()
+		if err != nil {
+			return err
+		}
+		for _, name := range names {
+			fmt.Fprintln(stdout, name)
+		}
+		return nil
+	}
+
+	if len(rest) == 0 {
+		return errors.New(usage)
+	}
+	name, rest := rest[0], rest[1:]
+
+	var w *watchlist.Watchlist
+	switch action {
+	case "show":
+		w, err = store.Get(name)
+	case "add":
+		w, err = store.Add(name, rest...)
+	case "remove":
+		w, err = store.Remove(name, rest...)
+	case "delete":
+		return store.Delete(name)
+	case "source":
+		if len(rest) != 1 {
+			return errors.New(usage)
+		}
+		if w, err = store.Get(name); err == nil {
+			w.Source = rest[0]
+			err = store.Save(w)
+		}
+	default:
+		return errors.New(usage)
+	}
+	if err != nil {
+		return err
+	}
+
+	printWatchlist(stdout, w)
+	return nil
+}
+
+func printWatchlist(w io.Writer, list *watchlist.Watchlist) {
+	source := list.Source
+	if source == "" {
+		source = defaultSource + " (default)"
+	}
+	fmt.Fprintf(w, "%s [%s]: %s\n", list.Name, source, strings.Join(list.Symbols, ", "))
+}
+
+// watchlistFlag registers the -watchlist flag shared by fetch commands.
+func watchlistFlag(fs *flag.FlagSet) *string {
+	return fs.String("watchlist", "", "name of a saved watchlist to fetch")
+}
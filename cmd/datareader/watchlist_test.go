@@ -0,0 +1,124 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	datareader "github.com/julianshen/gonp-datareader"
+	"github.com/julianshen/gonp-datareader/sources"
+	"github.com/julianshen/gonp-datareader/sources/stooq"
+)
+
+// useTempWatchlists points the CLI at an empty watchlist directory.
+func useTempWatchlists(t *testing.T) {
+	t.Helper()
+	orig := watchlistDir
+	watchlistDir = t.TempDir()
+	t.Cleanup(func() { watchlistDir = orig })
+}
+
+func runCLI(t *testing.T, args ...string) (string, error) {
+	t.Helper()
+	var stdout bytes.Buffer
+	err := run(context.Background(), args, &stdout, &bytes.Buffer{})
+	return stdout.String(), err
+}
+
+func TestRunWatchlist(t *testing.T) {
+	useTempWatchlists(t)
+
+	steps := []struct {
+		args []string
+		want string
+	}{
+		{[]string{"watchlist", "add", "tech", "AAPL", "MSFT"}, "tech [yahoo (default)]: AAPL, MSFT\n"},
+		{[]string{"watchlist", "add", "tech", "NVDA"}, "tech [yahoo (default)]: AAPL, MSFT, NVDA\n"},
+		{[]string{"watchlist", "remove", "tech", "MSFT"}, "tech [yahoo (default)]: AAPL, NVDA\n"},
+		{[]string{"watchlist", "source", "tech", "stooq"}, "tech [stooq]: AAPL, NVDA\n"},
+		{[]string{"watchlist", "add", "macro", "GDP"}, "macro [yahoo (default)]: GDP\n"},
+		{[]string{"watchlist", "list"}, "macro\ntech\n"},
+		{[]string{"watchlist", "delete", "macro"}, ""},
+		{[]string{"watchlist", "show", "tech"}, "tech [stooq]: AAPL, NVDA\n"},
+	}
+
+	for _, step := range steps {
+		got, err := runCLI(t, step.args...)
+		if err != nil {
+			t.Fatalf("%v: error = %v", step.args, err)
+		}
+		if got != step.want {
+			t.Errorf("%v: output = %q, want %q", step.args, got, step.want)
+		}
+	}
+
+	for _, args := range [][]string{
+		{"watchlist"},
+		{"watchlist", "show"},
+		{"watchlist", "show", "macro"},
+		{"watchlist", "rename", "tech"},
+	} {
+		if _, err := runCLI(t, args...); err == nil {
+			t.Errorf("%v: expected error", args)
+		}
+	}
+}
+
+func TestRunFetch_Watchlist(t *testing.T) {
+	useTempWatchlists(t)
+
+	upstream := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "text/csv")
+		w.Write([]byte("Date,Open,High,Low,Close,Volume\n2023-01-03,130,131,124,125.07,112117500\n"))
+	}))
+	defer upstream.Close()
+
+	var gotSource string
+	orig := newReader
+	newReader = func(source string, opts *datareader.Options) (sources.Reader, error) {
+		gotSource = source
+		return stooq.NewStooqReaderWithBaseURL(nil, upstream.URL+"?s=%s"), nil
+	}
+	t.Cleanup(func() { newReader = orig })
+
+	if _, err := runCLI(t, "watchlist", "add", "tech", "AAPL.US", "MSFT.US"); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := runCLI(t, "watchlist", "source", "tech", "stooq"); err != nil {
+		t.Fatal(err)
+	}
+
+	dir := t.TempDir()
+	out, err := runCLI(t, "fetch", "-watchlist", "tech", "-dir", dir)
+	if err != nil {
+		t.Fatalf("fetch error = %v", err)
+	}
+
+	if gotSource != "stooq" {
+		t.Errorf("source = %q, want stooq from the watchlist", gotSource)
+	}
+	if lines := strings.Fields(out); len(lines) != 2 {
+		t.Errorf("output = %q, want 2 paths", out)
+	}
+	if _, err := os.Stat(filepath.Join(dir, "stooq", "MSFT.US.csv")); err != nil {
+		t.Errorf("MSFT.US.csv not written: %v", err)
+	}
+
+	// Existing files are kept and appended to
+	if _, err := runCLI(t, "fetch", "-watchlist", "tech", "-dir", dir, "-incremental"); err != nil {
+		t.Fatalf("fetch -incremental error = %v", err)
+	}
+	content, err := os.ReadFile(filepath.Join(dir, "stooq", "MSFT.US.csv"))
+	if err != nil || strings.Count(string(content), "2023-01-03") != 1 {
+		t.Errorf("MSFT.US.csv after -incremental = %q, %v; want the row once", content, err)
+	}
+
+	if _, err := runCLI(t, "fetch", "-watchlist", "missing"); err == nil {
+		t.Error("fetch expected error for missing watchlist")
+	}
+}
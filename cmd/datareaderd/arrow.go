@@ -0,0 +1,10 @@
+//go:build arrow
+// +build arrow
+
+package main
+
+import "github.com/julianshen/gonp-datareader/dataset"
+
+func init() {
+	formats["arrow"] = encoder{contentType: dataset.ArrowContentType, write: (*dataset.Dataset).WriteArrow}
+}
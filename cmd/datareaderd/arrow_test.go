@@ -0,0 +1,40 @@
+//go:build arrow
+// +build arrow
+
+package main
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/apache/arrow-go/v18/arrow/ipc"
+
+	"github.com/julianshen/gonp-datareader/dataset"
+)
+
+func TestServer_ReadArrow(t *testing.T) {
+	server := newTestServer(t, Config{})
+
+	resp, err := http.Get(server.URL + "/v1/stooq/AAPL.US?start=2023-01-01&end=2023-01-31&format=arrow")
+	if err != nil {
+		t.Fatalf("GET error = %v", err)
+	}
+	defer resp.Body.Close()
+
+	if ct := resp.Header.Get("Content-Type"); ct != dataset.ArrowContentType {
+		t.Errorf("Content-Type = %q, want %q", ct, dataset.ArrowContentType)
+	}
+
+	r, err := ipc.NewReader(resp.Body)
+	if err != nil {
+		t.Fatalf("ipc.NewReader() error = %v", err)
+	}
+	defer r.Release()
+
+	if !r.Next() {
+		t.Fatalf("no record batch: %v", r.Err())
+	}
+	if got := r.Record().NumRows(); got != 2 {
+		t.Errorf("NumRows() = %d, want 2", got)
+	}
+}
@@ -0,0 +1,344 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"runtime/debug"
+	"sort"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	datareader "github.com/julianshen/gonp-datareader"
+	"github.com/julianshen/gonp-datareader/sources"
+)
+
+// version is the release of the binary, set at build time with
+// -ldflags "-X main.version=v1.2.3". The module version from the build
+// info is reported when it is empty.
+var version = ""
+
+// defaultPingTTL is the default of Config.PingTTL.
+const defaultPingTTL = time.Minute
+
+// sourceStats counts the reads of a source since the server started,
+// across tenants.
+type sourceStats struct {
+	requests, errors, rateLimited atomic.Uint64
+	cacheHits, cacheMisses        atomic.Uint64
+
+	mu              sync.Mutex
+	lastRateLimited time.Time
+	retryAfter      time.Duration
+}
+
+// record counts a completed read.
+func (st *sourceStats) record(err error) {
+	st.requests.Add(1)
+	if err == nil {
+		return
+	}
+	st.errors.Add(1)
+
+	var rle *sources.RateLimitError
+	if errors.As(err, &rle) {
+		st.rateLimited.Add(1)
+		st.mu.Lock()
+		st.lastRateLimited = time.Now()
+		st.retryAfter = rle.RetryAfter
+		st.mu.Unlock()
+	}
+}
+
+// sourceStats returns the counters of source, creating them on first use.
+func (s *Server) sourceStats(source string) *sourceStats {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.sourceStatsLocked(source)
+}
+
+// sourceStatsLocked is sourceStats for callers holding s.mu.
+func (s *Server) sourceStatsLocked(source string) *sourceStats {
+	st, ok := s.stats[source]
+	if !ok {
+		st = &sourceStats{}
+		s.stats[source] = st
+	}
+	return st
+}
+
+// pingResult is the outcome of pinging a source.
+type pingResult struct {
+	err       error
+	latency   time.Duration
+	checkedAt time.Time
+}
+
+// buildResponse describes the running binary.
+type buildResponse struct {
+	Version  string `json:"version"`
+	Go       string `json:"go"`
+	Revision string `json:"revision,omitempty"`
+	Time     string `json:"time,omitempty"`
+	Modified bool   `json:"modified,omitempty"`
+}
+
+// buildInfo returns the version and VCS stamp of the binary.
+func buildInfo() buildResponse {
+	resp := buildResponse{Version: version}
+	info, ok := debug.ReadBuildInfo()
+	if !ok {
+		return resp
+	}
+	resp.Go = info.GoVersion
+	if resp.Version == "" {
+		resp.Version = info.Main.Version
+	}
+	for _, setting := range info.Settings {
+		switch setting.Key {
+		case "vcs.revision":
+			resp.Revision = setting.Value
+		case "vcs.time":
+			resp.Time = setting.Value
+		case "vcs.modified":
+			resp.Modified = setting.Value == "true"
+		}
+	}
+	return resp
+}
+
+// healthResponse is the body of /healthz.
+type healthResponse struct {
+	Status string        `json:"status"`
+	Uptime string        `json:"uptime"`
+	Build  buildResponse `json:"build"`
+}
+
+// diagResponse is the body of /diagz.
+type diagResponse struct {
+	// Status is "ok", or "degraded" when a source failed its ping
+	Status  string               `json:"status"`
+	Uptime  string               `json:"uptime"`
+	Build   buildResponse        `json:"build"`
+	Cache   cacheResponse        `json:"cache"`
+	Sources []sourceDiagResponse `json:"sources"`
+}
+
+// cacheResponse describes the response cache shared by the readers.
+type cacheResponse struct {
+	Dir        string `json:"dir,omitempty"`
+	TTL        string `json:"ttl,omitempty"`
+	MemorySize int    `json:"memory_size"`
+	ServeStale bool   `json:"serve_stale"`
+	Hits       uint64 `json:"hits"`
+	Misses     uint64 `json:"misses"`
+}
+
+// sourceDiagResponse is the health and quota status of one source.
+type sourceDiagResponse struct {
+	Source string `json:"source"`
+	// Status is "ok", "error" or "unsupported" (no way to ping)
+	Status    string        `json:"status"`
+	Error     string        `json:"error,omitempty"`
+	LatencyMS int64         `json:"latency_ms"`
+	CheckedAt string        `json:"checked_at"`
+	Quota     quotaResponse `json:"quota"`
+}
+
+// quotaResponse reports a source's upstream limits and how much of them
+// the server used since it started.
+type quotaResponse struct {
+	APIKey bool `json:"api_key"`
+	// RateLimit is the configured upstream requests per second; 0 when
+	// unlimited
+	RateLimit float64 `json:"rate_limit"`
+	// PublishedLimit is the provider's published requests per second
+	PublishedLimit  float64 `json:"published_limit,omitempty"`
+	Requests        uint64  `json:"requests"`
+	Errors          uint64  `json:"errors"`
+	CacheHits       uint64  `json:"cache_hits"`
+	CacheMisses     uint64  `json:"cache_misses"`
+	RateLimited     uint64  `json:"rate_limited"`
+	LastRateLimited string  `json:"last_rate_limited,omitempty"`
+	RetryAfter      string  `json:"retry_after,omitempty"`
+}
+
+// handleHealth answers liveness and readiness probes without contacting
+// any upstream, so it may be polled at any rate.
+func (s *Server) handleHealth(w http.ResponseWriter, r *http.Request) {
+	writeJSON(w, http.StatusOK, healthResponse{
+		Status: "ok",
+		Uptime: time.Since(s.started).Round(time.Second).String(),
+		Build:  buildInfo(),
+	})
+}
+
+// handleDiag pings the upstream of every source in pingSources and
+// reports cache and quota status. It answers 503 when a ping fails.
+func (s *Server) handleDiag(w http.ResponseWriter, r *http.Request) {
+	names := s.pingSources()
+	results := make([]pingResult, len(names))
+	var wg sync.WaitGroup
+	for i, source := range names {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			results[i] = s.ping(r.Context(), source)
+		}()
+	}
+	wg.Wait()
+
+	resp := diagResponse{
+		Status:  "ok",
+		Uptime:  time.Since(s.started).Round(time.Second).String(),
+		Build:   buildInfo(),
+		Cache:   s.cacheStatus(),
+		Sources: make([]sourceDiagResponse, len(names)),
+	}
+	for i, source := range names {
+		res := results[i]
+		diag := sourceDiagResponse{
+			Source:    source,
+			Status:    "ok",
+			LatencyMS: res.latency.Milliseconds(),
+			CheckedAt: res.checkedAt.UTC().Format(time.RFC3339),
+			Quota:     s.quotaStatus(source),
+		}
+		switch {
+		case errors.Is(res.err, datareader.ErrPingNotSupported):
+			diag.Status = "unsupported"
+		case res.err != nil:
+			diag.Status = "error"
+			diag.Error = errorMessage(statusFor(res.err), res.err)
+			resp.Status = "degraded"
+		}
+		resp.Sources[i] = diag
+	}
+
+	status := http.StatusOK
+	if resp.Status != "ok" {
+		status = http.StatusServiceUnavailable
+	}
+	writeJSON(w, status, resp)
+}
+
+// pingSources returns the sources /diagz checks, sorted by name.
+func (s *Server) pingSources() []string {
+	if len(s.config.PingSources) > 0 {
+		return s.config.PingSources
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	seen := make(map[string]bool)
+	var names []string
+	for source, key := range s.config.SourceKeys {
+		if key != "" && !seen[source] {
+			seen[source] = true
+			names = append(names, source)
+		}
+	}
+	for source := range s.stats {
+		if !seen[source] {
+			seen[source] = true
+			names = append(names, source)
+		}
+	}
+	sort.Strings(names)
+	return names
+}
+
+// ping returns the result of pinging source, reusing a result younger
+// than Config.PingTTL. Pings use a reader without cache, so they reach the
+// upstream.
+func (s *Server) ping(ctx context.Context, source string) pingResult {
+	ttl := s.config.PingTTL
+	if ttl <= 0 {
+		ttl = defaultPingTTL
+	}
+	s.mu.Lock()
+	res, ok := s.pings[source]
+	s.mu.Unlock()
+	if ok && time.Since(res.checkedAt) < ttl {
+		return res
+	}
+
+	opts := s.options(nil, source)
+	opts.EnableCache = false
+	opts.CacheDir = ""
+	opts.MemoryCacheSize = 0
+
+	began := time.Now()
+	reader, err := s.newReader(source, opts)
+	if err == nil {
+		err = datareader.Ping(ctx, reader)
+		if c, ok := reader.(sources.Closer); ok {
+			_ = c.Close()
+		}
+	}
+	// A cancelled diagnostics request says nothing about the upstream
+	if ctx.Err() != nil {
+		return pingResult{err: ctx.Err(), checkedAt: began}
+	}
+	res = pingResult{err: err, latency: time.Since(began), checkedAt: began}
+
+	s.mu.Lock()
+	s.pings[source] = res
+	s.mu.Unlock()
+	return res
+}
+
+// cacheStatus returns the cache configuration and the hits and misses of
+// every source.
+func (s *Server) cacheStatus() cacheResponse {
+	var resp cacheResponse
+	if opts := s.config.Options; opts != nil {
+		resp.Dir = opts.CacheDir
+		if opts.CacheDir != "" {
+			resp.TTL = opts.CacheTTL.String()
+		}
+		resp.MemorySize = opts.MemoryCacheSize
+		resp.ServeStale = opts.ServeStaleOnError
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for _, st := range s.stats {
+		resp.Hits += st.cacheHits.Load()
+		resp.Misses += st.cacheMisses.Load()
+	}
+	return resp
+}
+
+// quotaStatus returns the upstream limits of source and the server's use
+// of them.
+func (s *Server) quotaStatus(source string) quotaResponse {
+	opts := s.options(nil, source)
+	resp := quotaResponse{
+		APIKey:    opts.APIKey != "",
+		RateLimit: opts.RateLimit,
+	}
+	if rl, ok := opts.RateLimits[source]; ok && rl.Rate > 0 {
+		resp.RateLimit = rl.Rate
+	}
+	if info, err := datareader.SourceInfo(source); err == nil {
+		resp.PublishedLimit = info.RateLimit
+	}
+
+	st := s.sourceStats(source)
+	resp.Requests = st.requests.Load()
+	resp.Errors = st.errors.Load()
+	resp.CacheHits = st.cacheHits.Load()
+	resp.CacheMisses = st.cacheMisses.Load()
+	resp.RateLimited = st.rateLimited.Load()
+	st.mu.Lock()
+	if !st.lastRateLimited.IsZero() {
+		resp.LastRateLimited = st.lastRateLimited.UTC().Format(time.RFC3339)
+	}
+	if st.retryAfter > 0 {
+		resp.RetryAfter = st.retryAfter.String()
+	}
+	st.mu.Unlock()
+	return resp
+}
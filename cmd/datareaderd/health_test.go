@@ -0,0 +1,140 @@
+package main
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+
+	datareader "github.com/julianshen/gonp-datareader"
+)
+
+func TestServer_Health(t *testing.T) {
+	// Probes carry no key, even when clients must authenticate
+	server := newTestServer(t, Config{ClientKeys: []string{"secret"}})
+
+	resp, err := http.Get(server.URL + "/healthz")
+	if err != nil {
+		t.Fatalf("GET error = %v", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		t.Fatalf("status = %d, want 200", resp.StatusCode)
+	}
+	var body healthResponse
+	if err := json.NewDecoder(resp.Body).Decode(&body); err != nil {
+		t.Fatalf("Decode() error = %v", err)
+	}
+	if body.Status != "ok" || body.Build.Go == "" {
+		t.Errorf("body = %+v, want status ok and the Go version", body)
+	}
+}
+
+// newDiagServer returns a Server reading Stooq from upstream through
+// datareader.DataReader, so that caching and hooks apply.
+func newDiagServer(t *testing.T, upstream http.HandlerFunc, config Config) *httptest.Server {
+	t.Helper()
+
+	stub := httptest.NewServer(upstream)
+	t.Cleanup(stub.Close)
+
+	opts := datareader.DefaultOptions()
+	opts.MemoryCacheSize = 10
+	opts.BaseURLOverrides = map[string]string{"stooq": stub.URL + "?s=%s"}
+	config.Options = opts
+
+	server := httptest.NewServer(NewServer(config).Handler())
+	t.Cleanup(server.Close)
+	return server
+}
+
+func getDiag(t *testing.T, url, key string) (int, diagResponse) {
+	t.Helper()
+
+	req, _ := http.NewRequest(http.MethodGet, url+"/diagz", nil)
+	req.Header.Set("X-API-Key", key)
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		t.Fatalf("GET /diagz error = %v", err)
+	}
+	defer resp.Body.Close()
+
+	var body diagResponse
+	if resp.StatusCode != http.StatusUnauthorized {
+		if err := json.NewDecoder(resp.Body).Decode(&body); err != nil {
+			t.Fatalf("Decode() error = %v", err)
+		}
+	}
+	return resp.StatusCode, body
+}
+
+func TestServer_Diag(t *testing.T) {
+	var calls atomic.Int32
+	server := newDiagServer(t, func(w http.ResponseWriter, r *http.Request) {
+		calls.Add(1)
+		w.Header().Set("Content-Type", "text/csv")
+		w.Write([]byte(stooqCSV))
+	}, Config{ClientKeys: []string{"secret"}, PingSources: []string{"stooq"}})
+
+	if status, _ := getDiag(t, server.URL, ""); status != http.StatusUnauthorized {
+		t.Errorf("status without key = %d, want 401", status)
+	}
+
+	// Two reads of the same range: one upstream request, one cache hit
+	for i := 0; i < 2; i++ {
+		req, _ := http.NewRequest(http.MethodGet, server.URL+"/v1/stooq/AAPL.US?start=2023-01-01&end=2023-01-31", nil)
+		req.Header.Set("X-API-Key", "secret")
+		resp, err := http.DefaultClient.Do(req)
+		if err != nil {
+			t.Fatalf("GET error = %v", err)
+		}
+		resp.Body.Close()
+	}
+	reads := calls.Load()
+
+	status, body := getDiag(t, server.URL, "secret")
+	if status != http.StatusOK || body.Status != "ok" {
+		t.Fatalf("status = %d %q, want 200 ok", status, body.Status)
+	}
+	if calls.Load() != reads+1 {
+		t.Errorf("upstream requests by ping = %d, want 1 (bypassing the cache)", calls.Load()-reads)
+	}
+	if len(body.Sources) != 1 || body.Sources[0].Source != "stooq" || body.Sources[0].Status != "ok" {
+		t.Fatalf("sources = %+v, want stooq ok", body.Sources)
+	}
+	quota := body.Sources[0].Quota
+	if quota.Requests != 2 || quota.CacheHits != 1 || quota.CacheMisses != 1 {
+		t.Errorf("quota = %+v, want 2 requests, 1 cache hit and 1 miss", quota)
+	}
+	if body.Cache.Hits != 1 || body.Cache.MemorySize != 10 {
+		t.Errorf("cache = %+v, want 1 hit and memory size 10", body.Cache)
+	}
+
+	// A second check within PingTTL reuses the result
+	getDiag(t, server.URL, "secret")
+	if calls.Load() != reads+1 {
+		t.Errorf("upstream requests after second /diagz = %d, want the ping reused", calls.Load()-reads)
+	}
+}
+
+func TestServer_DiagDegraded(t *testing.T) {
+	server := newDiagServer(t, func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusUnauthorized)
+	}, Config{PingSources: []string{"stooq", "nosuch"}})
+
+	status, body := getDiag(t, server.URL, "")
+	if status != http.StatusServiceUnavailable || body.Status != "degraded" {
+		t.Fatalf("status = %d %q, want 503 degraded", status, body.Status)
+	}
+	for _, source := range body.Sources {
+		if source.Status != "error" || source.Error == "" {
+			t.Errorf("%s = %+v, want an error", source.Source, source)
+		}
+		// Upstream failures may carry URLs with API keys and are not shown
+		if source.Source == "stooq" && source.Error != http.StatusText(http.StatusBadGateway) {
+			t.Errorf("stooq error = %q, want the generic upstream error", source.Error)
+		}
+	}
+}
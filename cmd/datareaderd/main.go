@@ -0,0 +1,171 @@
+// Command datareaderd serves the datareader sources over HTTP so that
+// non-Go clients (Python notebooks, JS dashboards) can share the same
+// reader infrastructure, cache and rate limits.
+//
+// # Usage
+//
+//	datareaderd -addr :8080 -cache-dir .cache/datareaderd -rate-limit 5
+//
+// Upstream API keys are read from the environment, using the same
+// variables as the examples: FRED_API_KEY, ALPHA_VANTAGE_API_KEY,
+// IEX_API_KEY, TIINGO_API_KEY and FINMIND_TOKEN. Client API keys are
+// given with -api-keys or DATAREADERD_API_KEYS (comma-separated); when
+// set, clients must send one in the X-API-Key header or as a Bearer token.
+//
+// # Tenants
+//
+// One deployment can serve several teams without quota interference. Pass
+// -tenants with a JSON file of tenants, each authenticated by its token
+// and given its own upstream API keys, request rate limits, readers and
+// cache namespace (<cache-dir>/tenants/<name>):
+//
+//	[
+//	  {"name": "research", "token": "r-secret", "source_keys": {"fred": "..."}, "rate": 20},
+//	  {"name": "dashboards", "token": "d-secret", "rate": 5, "rate_limit": 2}
+//	]
+//
+// # Endpoints
+//
+//	GET /v1/sources
+//	GET /v1/{source}/{symbol}?start=2024-01-01&end=2024-06-30&interval=1d&format=json
+//
+// Dates default to the last year. The interval parameter selects daily
+// bars ("1d", the default), "1wk" or "weekly", "1mo" or "monthly", or the
+// intraday "1m", "5m", "15m", "30m" and "1h", from sources that serve them
+// (see /v1/sources/{source}); others answer 400. With -max-rows, requests
+// for more rows (estimated from the exchange calendar, or counted) answer
+// 400. The format parameter selects "json" (default) or "csv"; binaries
+// built with -tags arrow also serve "arrow", an Apache Arrow IPC stream.
+// JSON responses hold the dates and one array per column, with missing
+// values as null:
+//
+//	{"source":"yahoo","symbol":"AAPL","dates":["2024-01-02"],
+//	 "columns":[{"name":"Close","values":[185.64]}]}
+//
+// Errors are returned as {"error": "..."}. Upstream and server failures
+// (5xx) carry only the status text and are logged, since their details may
+// include upstream URLs with the server's API keys.
+//
+// /healthz answers Kubernetes liveness and readiness probes with the
+// uptime and build info (set the version with -ldflags
+// "-X main.version=v1.2.3") without authentication or upstream requests.
+// /diagz pings the upstream of each source in -ping-sources (by default
+// those with an API key and those read from), reusing results for
+// -ping-ttl, and reports cache hits and misses and per-source quota use;
+// it answers 503 when a ping fails. Upstream ping errors are logged and
+// reported only as their status text. /metrics exports the per-source
+// read, error, rate-limit and cache counters of /diagz, and the uptime, in
+// the Prometheus text format, without authentication.
+//
+// Only HTTP/JSON is provided; a gRPC frontend would add a protobuf
+// dependency and can wrap Server in the same way.
+package main
+
+import (
+	"context"
+	"flag"
+	"log"
+	"net/http"
+	"os"
+	"os/signal"
+	"strings"
+	"syscall"
+	"time"
+
+	datareader "github.com/julianshen/gonp-datareader"
+)
+
+func main() {
+	addr := flag.String("addr", ":8080", "listen address")
+	cacheDir := flag.String("cache-dir", "", "directory for cached responses (disabled if empty)")
+	cacheTTL := flag.Duration("cache-ttl", 24*time.Hour, "how long cached responses remain valid")
+	memoryCache := flag.Int("memory-cache", 1000, "number of responses kept in memory (0 disables)")
+	rateLimit := flag.Float64("rate-limit", 0, "upstream requests per second per source (0 disables)")
+	maxRows := flag.Int("max-rows", 0, "rows served per request (0 disables)")
+	timeout := flag.Duration("timeout", 30*time.Second, "upstream request timeout")
+	apiKeys := flag.String("api-keys", os.Getenv("DATAREADERD_API_KEYS"), "comma-separated client API keys (disabled if empty)")
+	clientRate := flag.Float64("client-rate", 0, "requests per second per client (0 disables)")
+	clientBurst := flag.Int("client-burst", 10, "burst size per client")
+	tenants := flag.String("tenants", "", "JSON file listing isolated tenants (see Tenant)")
+	pingSources := flag.String("ping-sources", "", "comma-separated sources checked by /diagz (default: keyed and used sources)")
+	pingTTL := flag.Duration("ping-ttl", time.Minute, "how long /diagz reuses a ping result")
+	shutdownTimeout := flag.Duration("shutdown-timeout", 15*time.Second, "how long to drain in-flight requests on SIGINT/SIGTERM")
+	flag.Parse()
+
+	opts := datareader.DefaultOptions()
+	opts.CacheDir = *cacheDir
+	opts.CacheTTL = *cacheTTL
+	opts.ServeStaleOnError = *cacheDir != ""
+	opts.MemoryCacheSize = *memoryCache
+	opts.RateLimit = *rateLimit
+	opts.MaxRows = *maxRows
+	opts.Timeout = *timeout
+
+	config := Config{
+		Options:     opts,
+		SourceKeys:  make(map[string]string),
+		ClientRate:  *clientRate,
+		ClientBurst: *clientBurst,
+		PingTTL:     *pingTTL,
+	}
+	for _, source := range datareader.ListSources() {
+		if env := datareader.APIKeyEnv(source); env != "" {
+			if key := os.Getenv(env); key != "" {
+				config.SourceKeys[source] = key
+			}
+		}
+	}
+	for _, key := range strings.Split(*apiKeys, ",") {
+		if key = strings.TrimSpace(key); key != "" {
+			config.ClientKeys = append(config.ClientKeys, key)
+		}
+	}
+
+	for _, source := range strings.Split(*pingSources, ",") {
+		if source = strings.TrimSpace(source); source != "" {
+			config.PingSources = append(config.PingSources, source)
+		}
+	}
+
+	if *tenants != "" {
+		list, err := LoadTenants(*tenants)
+		if err != nil {
+			log.Fatal(err)
+		}
+		config.Tenants = list
+	}
+
+	service := NewServer(config)
+	server := &http.Server{
+		Addr:              *addr,
+		Handler:           service.Handler(),
+		ReadHeaderTimeout: 10 * time.Second,
+	}
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	errc := make(chan error, 1)
+	go func() {
+		log.Printf("datareaderd listening on %s", *addr)
+		errc <- server.ListenAndServe()
+	}()
+
+	select {
+	case err := <-errc:
+		log.Fatal(err)
+	case <-ctx.Done():
+	}
+
+	// Drain client requests first, then the upstream requests they made
+	log.Printf("shutting down, draining requests for up to %s", *shutdownTimeout)
+	shutdownCtx, cancel := context.WithTimeout(context.Background(), *shutdownTimeout)
+	defer cancel()
+
+	if err := server.Shutdown(shutdownCtx); err != nil {
+		log.Printf("http shutdown: %v", err)
+	}
+	if err := service.Shutdown(shutdownCtx); err != nil {
+		log.Printf("reader shutdown: %v", err)
+	}
+}
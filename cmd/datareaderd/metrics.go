@@ -0,0 +1,57 @@
+package main
+
+import (
+	"fmt"
+	"io"
+	"net/http"
+	"sort"
+	"time"
+)
+
+// metric is a per-source counter exported by /metrics.
+type metric struct {
+	name  string
+	help  string
+	value func(st *sourceStats) uint64
+}
+
+// metrics lists the counters of sourceStats exported by /metrics.
+var metrics = []metric{
+	{"datareaderd_requests_total", "Reads served, by source.", func(st *sourceStats) uint64 { return st.requests.Load() }},
+	{"datareaderd_errors_total", "Reads that failed, by source.", func(st *sourceStats) uint64 { return st.errors.Load() }},
+	{"datareaderd_rate_limited_total", "Reads the provider rate-limited, by source.", func(st *sourceStats) uint64 { return st.rateLimited.Load() }},
+	{"datareaderd_cache_hits_total", "Responses served from the cache, by source.", func(st *sourceStats) uint64 { return st.cacheHits.Load() }},
+	{"datareaderd_cache_misses_total", "Responses fetched from the provider, by source.", func(st *sourceStats) uint64 { return st.cacheMisses.Load() }},
+}
+
+// handleMetrics exports the per-source counters of /diagz in the
+// Prometheus text format. Like /healthz it is unauthenticated, so
+// Prometheus can scrape it without a client key; it reveals no API keys
+// or symbols.
+func (s *Server) handleMetrics(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	names := make([]string, 0, len(s.stats))
+	stats := make(map[string]*sourceStats, len(s.stats))
+	for source, st := range s.stats {
+		names = append(names, source)
+		stats[source] = st
+	}
+	s.mu.Unlock()
+	sort.Strings(names)
+
+	w.Header().Set("Content-Type", "text/plain; version=0.0.4; charset=utf-8")
+	writeMetrics(w, names, stats, time.Since(s.started))
+}
+
+// writeMetrics writes the counters of the sources in names, in order,
+// and the server uptime.
+func writeMetrics(w io.Writer, names []string, stats map[string]*sourceStats, uptime time.Duration) {
+	for _, m := range metrics {
+		fmt.Fprintf(w, "# HELP %s %s\n# TYPE %s counter\n", m.name, m.help, m.name)
+		for _, source := range names {
+			fmt.Fprintf(w, "%s{source=%q} %d\n", m.name, source, m.value(stats[source]))
+		}
+	}
+	fmt.Fprintf(w, "# HELP datareaderd_uptime_seconds Time since the server started.\n# TYPE datareaderd_uptime_seconds gauge\n")
+	fmt.Fprintf(w, "datareaderd_uptime_seconds %g\n", uptime.Seconds())
+}
@@ -0,0 +1,53 @@
+package main
+
+import (
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+)
+
+func TestServer_Metrics(t *testing.T) {
+	server := newDiagServer(t, func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "text/csv")
+		w.Write([]byte(stooqCSV))
+	}, Config{ClientKeys: []string{"secret"}})
+
+	// Two reads of the same range: one cache miss, one hit
+	for i := 0; i < 2; i++ {
+		req, _ := http.NewRequest(http.MethodGet, server.URL+"/v1/stooq/AAPL.US?start=2023-01-01&end=2023-01-31", nil)
+		req.Header.Set("X-API-Key", "secret")
+		resp, err := http.DefaultClient.Do(req)
+		if err != nil {
+			t.Fatalf("GET error = %v", err)
+		}
+		resp.Body.Close()
+	}
+
+	// Scrapes carry no key
+	resp, err := http.Get(server.URL + "/metrics")
+	if err != nil {
+		t.Fatalf("GET /metrics error = %v", err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		t.Fatalf("status = %d, want 200", resp.StatusCode)
+	}
+	if ct := resp.Header.Get("Content-Type"); !strings.HasPrefix(ct, "text/plain") {
+		t.Errorf("Content-Type = %q, want text/plain", ct)
+	}
+	body, _ := io.ReadAll(resp.Body)
+
+	for _, want := range []string{
+		"# TYPE datareaderd_requests_total counter\n",
+		`datareaderd_requests_total{source="stooq"} 2` + "\n",
+		`datareaderd_errors_total{source="stooq"} 0` + "\n",
+		`datareaderd_cache_hits_total{source="stooq"} 1` + "\n",
+		`datareaderd_cache_misses_total{source="stooq"} 1` + "\n",
+		"# TYPE datareaderd_uptime_seconds gauge\n",
+	} {
+		if !strings.Contains(string(body), want) {
+			t.Errorf("metrics missing %q:\n%s", want, body)
+		}
+	}
+}
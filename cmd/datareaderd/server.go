@@ -0,0 +1,580 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"log"
+	"math"
+	"net"
+	"net/http"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
+
+	"golang.org/x/time/rate"
+
+	datareader "github.com/julianshen/gonp-datareader"
+	"github.com/julianshen/gonp-datareader/dataset"
+	"github.com/julianshen/gonp-datareader/internal/utils"
+	"github.com/julianshen/gonp-datareader/sources"
+)
+
+// dateLayout is the layout used for the start/end query parameters and the
+// dates in responses.
+const dateLayout = "2006-01-02"
+
+// Client rate limiters unused for limiterIdle are evicted, at most once per
+// limiterSweepInterval, so clients keyed by remote address do not
+// accumulate for the life of the server.
+const (
+	limiterIdle          = 10 * time.Minute
+	limiterSweepInterval = time.Minute
+)
+
+// Config configures the data service.
+type Config struct {
+	// Options is the base configuration shared by every source reader,
+	// including caching and upstream rate limiting.
+	Options *datareader.Options
+
+	// SourceKeys maps a source name to the upstream API key used for it.
+	SourceKeys map[string]string
+
+	// ClientKeys lists the API keys accepted from clients. If empty,
+	// requests are not authenticated.
+	ClientKeys []string
+
+	// ClientRate is the number of requests per second allowed for each
+	// client (API key, or remote address when keys are disabled).
+	// Zero or negative values disable client rate limiting. The limits of
+	// clients idle for 10 minutes are dropped once their allowance has
+	// refilled.
+	ClientRate float64
+
+	// ClientBurst is the number of requests a client may burst above
+	// ClientRate. Values below 1 are treated as 1.
+	ClientBurst int
+
+	// Tenants lists isolated users of the deployment. When set, requests
+	// must carry a tenant token or one of ClientKeys.
+	Tenants []Tenant
+
+	// PingSources lists the sources /diagz checks with datareader.Ping.
+	// Default: the sources with a key in SourceKeys and those read from
+	// since the server started.
+	PingSources []string
+
+	// PingTTL is how long /diagz reuses a source's ping result, so
+	// frequent diagnostics do not spend upstream quota. Default: 1 minute
+	PingTTL time.Duration
+}
+
+// Server serves datareader sources over HTTP.
+type Server struct {
+	config     Config
+	clientKeys map[string]bool
+	tenants    map[string]*Tenant
+	newReader  func(source string, opts *datareader.Options) (sources.Reader, error)
+
+	started time.Time
+
+	mu        sync.Mutex
+	readers   map[string]sources.Reader
+	limiters  map[string]*clientLimiter
+	lastSweep time.Time
+	stats     map[string]*sourceStats
+	pings     map[string]pingResult
+}
+
+// clientLimiter is the rate limiter of one client and when it was last
+// used.
+type clientLimiter struct {
+	*rate.Limiter
+	lastSeen time.Time
+}
+
+// NewServer creates a Server from config.
+func NewServer(config Config) *Server {
+	s := &Server{
+		config:     config,
+		clientKeys: make(map[string]bool, len(config.ClientKeys)),
+		tenants:    make(map[string]*Tenant, len(config.Tenants)),
+		newReader:  datareader.DataReader,
+		started:    time.Now(),
+		readers:    make(map[string]sources.Reader),
+		limiters:   make(map[string]*clientLimiter),
+		stats:      make(map[string]*sourceStats),
+		pings:      make(map[string]pingResult),
+	}
+	for _, key := range config.ClientKeys {
+		if key != "" {
+			s.clientKeys[key] = true
+		}
+	}
+	for i := range config.Tenants {
+		t := &config.Tenants[i]
+		s.tenants[t.Token] = t
+	}
+	return s
+}
+
+// Shutdown gracefully shuts down every reader created by the server,
+// waiting for in-flight upstream requests until ctx expires.
+func (s *Server) Shutdown(ctx context.Context) error {
+	s.mu.Lock()
+	readers := make([]sources.Reader, 0, len(s.readers))
+	for _, r := range s.readers {
+		readers = append(readers, r)
+	}
+	s.mu.Unlock()
+
+	return datareader.Shutdown(ctx, readers...)
+}
+
+// Handler returns the HTTP handler exposing the service routes:
+//
+//	GET /healthz                    liveness and build info, unauthenticated
+//	GET /metrics                    per-source counters for Prometheus, unauthenticated
+//	GET /diagz                      upstream pings, cache and quota status
+//	GET /v1/sources                 list available sources
+//	GET /v1/sources/{source}        describe a source's capabilities
+//	GET /v1/{source}/{symbol}       fetch data (?start=&end=&interval=&format=)
+func (s *Server) Handler() http.Handler {
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /diagz", s.handleDiag)
+	mux.HandleFunc("GET /v1/sources", s.handleSources)
+	mux.HandleFunc("GET /v1/sources/{source}", s.handleSourceInfo)
+	mux.HandleFunc("GET /v1/{source}/{symbol}", s.handleRead)
+
+	// Probes and scrapes carry no API key and must not be rate limited
+	root := http.NewServeMux()
+	root.HandleFunc("GET /healthz", s.handleHealth)
+	root.HandleFunc("GET /metrics", s.handleMetrics)
+	root.Handle("/", s.middleware(mux))
+	return root
+}
+
+// middleware authenticates and rate limits every request.
+func (s *Server) middleware(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		client := clientAddr(r)
+		var tenant *Tenant
+		if len(s.clientKeys) > 0 || len(s.tenants) > 0 {
+			key := requestKey(r)
+			tenant = s.tenants[key]
+			if tenant == nil && !s.clientKeys[key] {
+				writeError(w, http.StatusUnauthorized, errors.New("missing or invalid API key"))
+				return
+			}
+			client = key
+		}
+
+		if limiter := s.limiter(client, tenant); limiter != nil && !limiter.Allow() {
+			w.Header().Set("Retry-After", "1")
+			writeError(w, http.StatusTooManyRequests, errors.New("rate limit exceeded"))
+			return
+		}
+
+		if tenant != nil {
+			r = r.WithContext(context.WithValue(r.Context(), tenantKey{}, tenant))
+		}
+		next.ServeHTTP(w, r)
+	})
+}
+
+// limiter returns the rate limiter for client, or nil when client rate
+// limiting is disabled. Tenant limits override the deployment-wide ones.
+func (s *Server) limiter(client string, tenant *Tenant) *rate.Limiter {
+	limit, burst := s.config.ClientRate, s.config.ClientBurst
+	if tenant != nil {
+		client = "tenant:" + tenant.Name
+		if tenant.Rate > 0 {
+			limit = tenant.Rate
+		}
+		if tenant.Burst > 0 {
+			burst = tenant.Burst
+		}
+	}
+	if limit <= 0 {
+		return nil
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	now := time.Now()
+	if now.Sub(s.lastSweep) >= limiterSweepInterval {
+		s.sweepLimiters(now)
+	}
+
+	l, ok := s.limiters[client]
+	if !ok {
+		if burst < 1 {
+			burst = 1
+		}
+		l = &clientLimiter{Limiter: rate.NewLimiter(rate.Limit(limit), burst)}
+		s.limiters[client] = l
+	}
+	l.lastSeen = now
+	return l.Limiter
+}
+
+// sweepLimiters evicts the client limiters unused for limiterIdle whose
+// bucket has refilled, so a returning client gets the same allowance from
+// a new limiter. s.mu must be held.
+func (s *Server) sweepLimiters(now time.Time) {
+	s.lastSweep = now
+	for client, l := range s.limiters {
+		if now.Sub(l.lastSeen) >= limiterIdle && l.TokensAt(now) >= float64(l.Burst()) {
+			delete(s.limiters, client)
+		}
+	}
+}
+
+// reader returns the reader for source and bar interval (empty for
+// daily), creating it on first use so its cache and upstream rate limiter
+// are shared across requests. Each tenant gets its own readers, with its
+// API keys and a cache directory under <CacheDir>/tenants/<name>.
+func (s *Server) reader(tenant *Tenant, source string, interval sources.Interval) (sources.Reader, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	id := source
+	if tenant != nil {
+		id = tenant.Name + "/" + source
+	}
+	if interval != "" {
+		id += "@" + string(interval)
+	}
+	if r, ok := s.readers[id]; ok {
+		return r, nil
+	}
+
+	opts := s.options(tenant, source)
+	opts.Interval = interval
+	stats := s.sourceStatsLocked(source)
+
+	// Count cache activity, chaining any configured hooks
+	var user datareader.Hooks
+	if opts.Hooks != nil {
+		user = *opts.Hooks
+	}
+	hooks := user
+	hooks.OnCacheHit = func(layer, key string) {
+		stats.cacheHits.Add(1)
+		if user.OnCacheHit != nil {
+			user.OnCacheHit(layer, key)
+		}
+	}
+	hooks.OnCacheMiss = func(key string) {
+		stats.cacheMisses.Add(1)
+		if user.OnCacheMiss != nil {
+			user.OnCacheMiss(key)
+		}
+	}
+	opts.Hooks = &hooks
+
+	r, err := s.newReader(source, opts)
+	if err != nil {
+		return nil, err
+	}
+	s.readers[id] = r
+	return r, nil
+}
+
+// options returns the reader options for source, applying the upstream
+// key and the settings of tenant, which may be nil.
+func (s *Server) options(tenant *Tenant, source string) *datareader.Options {
+	opts := datareader.DefaultOptions()
+	if s.config.Options != nil {
+		copied := *s.config.Options
+		opts = &copied
+	}
+	if key := s.config.SourceKeys[source]; key != "" {
+		opts.APIKey = key
+	}
+	if tenant != nil {
+		if key := tenant.SourceKeys[source]; key != "" {
+			opts.APIKey = key
+		}
+		if tenant.RateLimit > 0 {
+			opts.RateLimit = tenant.RateLimit
+		}
+		if opts.CacheDir != "" {
+			opts.CacheDir = filepath.Join(opts.CacheDir, "tenants", tenant.Name)
+		}
+	}
+	return opts
+}
+
+func (s *Server) handleSources(w http.ResponseWriter, r *http.Request) {
+	writeJSON(w, http.StatusOK, map[string][]string{"sources": datareader.ListSources()})
+}
+
+func (s *Server) handleSourceInfo(w http.ResponseWriter, r *http.Request) {
+	info, err := datareader.SourceInfo(r.PathValue("source"))
+	if err != nil {
+		writeError(w, statusFor(err), err)
+		return
+	}
+	writeJSON(w, http.StatusOK, newSourceResponse(info))
+}
+
+func (s *Server) handleRead(w http.ResponseWriter, r *http.Request) {
+	source := r.PathValue("source")
+	symbol := r.PathValue("symbol")
+	query := r.URL.Query()
+
+	end := time.Now().UTC()
+	start := end.AddDate(-1, 0, 0)
+	var err error
+	if v := query.Get("start"); v != "" {
+		if start, err = time.Parse(dateLayout, v); err != nil {
+			writeError(w, http.StatusBadRequest, fmt.Errorf("invalid start date: %w", err))
+			return
+		}
+	}
+	if v := query.Get("end"); v != "" {
+		if end, err = time.Parse(dateLayout, v); err != nil {
+			writeError(w, http.StatusBadRequest, fmt.Errorf("invalid end date: %w", err))
+			return
+		}
+	}
+
+	if err := utils.ValidateDateRange(start, end); err != nil {
+		writeError(w, http.StatusBadRequest, err)
+		return
+	}
+
+	interval, err := parseInterval(query.Get("interval"))
+	if err != nil {
+		writeError(w, http.StatusBadRequest, err)
+		return
+	}
+
+	format := query.Get("format")
+	enc, ok := formats[format]
+	if format != "" && format != "json" && !ok {
+		writeError(w, http.StatusBadRequest, fmt.Errorf("unsupported format %q", format))
+		return
+	}
+
+	reader, err := s.reader(tenantFrom(r.Context()), source, interval)
+	if err != nil {
+		writeError(w, statusFor(err), err)
+		return
+	}
+
+	data, err := reader.ReadSingle(r.Context(), symbol, start, end)
+	s.sourceStats(source).record(err)
+	if err != nil {
+		writeError(w, statusFor(err), err)
+		return
+	}
+
+	ds, err := datareader.ToDataset(symbol, data)
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, err)
+		return
+	}
+
+	if ok {
+		w.Header().Set("Content-Type", enc.contentType)
+		// Headers are already sent, so a write error cannot be reported
+		_ = enc.write(ds, w)
+		return
+	}
+	writeJSON(w, http.StatusOK, newDatasetResponse(ds))
+}
+
+// intervals maps the interval query parameter to a bar interval; daily
+// bars, each reader's default, map to the empty Interval.
+var intervals = map[string]sources.Interval{
+	"":        "",
+	"d":       "",
+	"1d":      "",
+	"daily":   "",
+	"wk":      sources.Interval1wk,
+	"1wk":     sources.Interval1wk,
+	"weekly":  sources.Interval1wk,
+	"mo":      sources.Interval1mo,
+	"1mo":     sources.Interval1mo,
+	"monthly": sources.Interval1mo,
+	"1m":      sources.Interval1m,
+	"5m":      sources.Interval5m,
+	"15m":     sources.Interval15m,
+	"30m":     sources.Interval30m,
+	"1h":      sources.Interval1h,
+}
+
+// parseInterval returns the bar interval named by the interval query
+// parameter. Whether the source serves it is checked by its reader.
+func parseInterval(v string) (sources.Interval, error) {
+	interval, ok := intervals[strings.ToLower(v)]
+	if !ok {
+		return "", fmt.Errorf("%w: %q", sources.ErrUnsupportedInterval, v)
+	}
+	return interval, nil
+}
+
+// encoder writes a dataset in a non-JSON output format.
+type encoder struct {
+	contentType string
+	write       func(ds *dataset.Dataset, w io.Writer) error
+}
+
+// formats maps the format query parameter to its encoder. Builds with the
+// arrow tag add "arrow".
+var formats = map[string]encoder{
+	"csv": {contentType: "text/csv", write: (*dataset.Dataset).WriteCSV},
+}
+
+// datasetResponse is the JSON representation of a dataset.Dataset.
+type datasetResponse struct {
+	Source  string            `json:"source"`
+	Symbol  string            `json:"symbol"`
+	Dates   []string          `json:"dates"`
+	Columns []columnResponse  `json:"columns"`
+	Flags   []string          `json:"flags,omitempty"`
+	Meta    map[string]string `json:"meta,omitempty"`
+}
+
+// sourceResponse is the JSON encoding of a source's metadata.
+type sourceResponse struct {
+	Name           string   `json:"name"`
+	Description    string   `json:"description,omitempty"`
+	RequiresAPIKey bool     `json:"requires_api_key"`
+	APIKeyOptional bool     `json:"api_key_optional"`
+	Intervals      []string `json:"intervals,omitempty"`
+	Frequencies    []string `json:"frequencies,omitempty"`
+	RateLimit      float64  `json:"rate_limit,omitempty"`
+	RateLimitNote  string   `json:"rate_limit_note,omitempty"`
+	SymbolPattern  string   `json:"symbol_pattern,omitempty"`
+	SymbolExamples []string `json:"symbol_examples,omitempty"`
+	CoverageStart  string   `json:"coverage_start,omitempty"`
+	CoverageNote   string   `json:"coverage_note,omitempty"`
+	Sandbox        bool     `json:"sandbox"`
+	APIVersion     string   `json:"api_version,omitempty"`
+	Registered     bool     `json:"registered,omitempty"`
+}
+
+func newSourceResponse(info datareader.SourceMetadata) sourceResponse {
+	resp := sourceResponse{
+		Name:           info.Name,
+		Description:    info.Description,
+		RequiresAPIKey: info.RequiresAPIKey,
+		APIKeyOptional: info.APIKeyOptional,
+		Intervals:      info.Intervals,
+		RateLimit:      info.RateLimit,
+		RateLimitNote:  info.RateLimitNote,
+		SymbolPattern:  info.SymbolPattern,
+		SymbolExamples: info.SymbolExamples,
+		CoverageNote:   info.CoverageNote,
+		Sandbox:        info.Sandbox,
+		APIVersion:     info.APIVersion,
+		Registered:     info.Registered,
+	}
+	for _, f := range info.Frequencies {
+		resp.Frequencies = append(resp.Frequencies, f.String())
+	}
+	if !info.CoverageStart.IsZero() {
+		resp.CoverageStart = info.CoverageStart.Format(dateLayout)
+	}
+	return resp
+}
+
+// columnResponse holds one column; missing values are encoded as null.
+type columnResponse struct {
+	Name   string     `json:"name"`
+	Values []*float64 `json:"values"`
+}
+
+func newDatasetResponse(ds *dataset.Dataset) datasetResponse {
+	resp := datasetResponse{
+		Source:  ds.Source,
+		Symbol:  ds.Symbol,
+		Dates:   make([]string, len(ds.Dates)),
+		Columns: make([]columnResponse, 0, len(ds.Columns)),
+		Flags:   ds.Flags,
+		Meta:    ds.Meta,
+	}
+	for i, d := range ds.Dates {
+		resp.Dates[i] = d.Format(dateLayout)
+	}
+	for _, c := range ds.Columns {
+		values := make([]*float64, len(c.Values))
+		for i := range c.Values {
+			if !math.IsNaN(c.Values[i]) && !math.IsInf(c.Values[i], 0) {
+				values[i] = &c.Values[i]
+			}
+		}
+		resp.Columns = append(resp.Columns, columnResponse{Name: c.Name, Values: values})
+	}
+	return resp
+}
+
+// statusFor maps a reader error to an HTTP status code.
+func statusFor(err error) int {
+	switch {
+	case errors.Is(err, datareader.ErrUnknownSource):
+		return http.StatusNotFound
+	case errors.Is(err, utils.ErrEmptySymbol),
+		errors.Is(err, utils.ErrInvalidSymbolFormat),
+		errors.Is(err, utils.ErrInvalidDateRange),
+		errors.Is(err, utils.ErrZeroTime),
+		errors.Is(err, datareader.ErrTooManyRows),
+		errors.Is(err, sources.ErrUnsupportedInterval):
+		return http.StatusBadRequest
+	case errors.Is(err, sources.ErrSymbolNotFound), errors.Is(err, sources.ErrNoData):
+		return http.StatusNotFound
+	case errors.Is(err, sources.ErrRateLimited):
+		// The provider, not the client, is over its limit
+		return http.StatusServiceUnavailable
+	default:
+		return http.StatusBadGateway
+	}
+}
+
+// requestKey returns the client API key from the X-API-Key header or an
+// "Authorization: Bearer" header.
+func requestKey(r *http.Request) string {
+	if key := r.Header.Get("X-API-Key"); key != "" {
+		return key
+	}
+	return strings.TrimPrefix(r.Header.Get("Authorization"), "Bearer ")
+}
+
+// clientAddr returns the remote host of the request.
+func clientAddr(r *http.Request) string {
+	host, _, err := net.SplitHostPort(r.RemoteAddr)
+	if err != nil {
+		return r.RemoteAddr
+	}
+	return host
+}
+
+func writeJSON(w http.ResponseWriter, status int, v interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	_ = json.NewEncoder(w).Encode(v)
+}
+
+func writeError(w http.ResponseWriter, status int, err error) {
+	writeJSON(w, status, map[string]string{"error": errorMessage(status, err)})
+}
+
+// errorMessage returns the message of err shown to clients. Server and
+// upstream errors (5xx) get a generic message and are logged instead,
+// since their text may include upstream request URLs carrying the
+// server's API keys.
+func errorMessage(status int, err error) string {
+	if status < http.StatusInternalServerError {
+		return err.Error()
+	}
+	log.Printf("%d %s: %v", status, http.StatusText(status), err)
+	return http.StatusText(status)
+}
@@ -0,0 +1,358 @@
+package main
+
+import (
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	datareader "github.com/julianshen/gonp-datareader"
+	"github.com/julianshen/gonp-datareader/sources"
+	"github.com/julianshen/gonp-datareader/sources/stooq"
+)
+
+const stooqCSV = `Date,Open,High,Low,Close,Volume
+2023-01-03,130,131,124,125.07,112117500
+2023-01-04,126,128,125,,89113600`
+
+// newTestServer returns a Server whose Stooq reader fetches from a mock
+// endpoint.
+func newTestServer(t *testing.T, config Config) *httptest.Server {
+	t.Helper()
+
+	upstream := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "text/csv")
+		w.Write([]byte(stooqCSV))
+	}))
+	t.Cleanup(upstream.Close)
+
+	s := NewServer(config)
+	s.newReader = func(source string, opts *datareader.Options) (sources.Reader, error) {
+		if source != "stooq" {
+			return datareader.DataReader(source, opts)
+		}
+		reader := stooq.NewStooqReaderWithBaseURL(nil, upstream.URL+"?s=%s")
+		if err := reader.SetInterval(opts.Interval); err != nil {
+			return nil, err
+		}
+		return reader, nil
+	}
+
+	server := httptest.NewServer(s.Handler())
+	t.Cleanup(server.Close)
+	return server
+}
+
+func TestServer_Read(t *testing.T) {
+	server := newTestServer(t, Config{})
+
+	resp, err := http.Get(server.URL + "/v1/stooq/AAPL.US?start=2023-01-01&end=2023-01-31")
+	if err != nil {
+		t.Fatalf("GET error = %v", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		t.Fatalf("status = %d, want 200", resp.StatusCode)
+	}
+
+	var body datasetResponse
+	if err := json.NewDecoder(resp.Body).Decode(&body); err != nil {
+		t.Fatalf("Decode() error = %v", err)
+	}
+
+	if body.Source != "stooq" || body.Symbol != "AAPL.US" {
+		t.Errorf("source/symbol = %s/%s, want stooq/AAPL.US", body.Source, body.Symbol)
+	}
+	if len(body.Dates) != 2 || body.Dates[0] != "2023-01-03" {
+		t.Errorf("Dates = %v", body.Dates)
+	}
+
+	var closes []*float64
+	for _, c := range body.Columns {
+		if c.Name == "Close" {
+			closes = c.Values
+		}
+	}
+	if len(closes) != 2 || closes[0] == nil || *closes[0] != 125.07 {
+		t.Fatalf("Close = %v, want [125.07 null]", closes)
+	}
+	if closes[1] != nil {
+		t.Errorf("Close[1] = %v, want null for missing value", *closes[1])
+	}
+}
+
+func TestServer_ReadInterval(t *testing.T) {
+	s := NewServer(Config{})
+	var got []sources.Interval
+	s.newReader = func(source string, opts *datareader.Options) (sources.Reader, error) {
+		got = append(got, opts.Interval)
+		return datareader.DataReader(source, opts)
+	}
+
+	for param, want := range map[string]sources.Interval{"weekly": sources.Interval1wk, "1d": "", "Monthly": sources.Interval1mo} {
+		if got, err := parseInterval(param); err != nil || got != want {
+			t.Errorf("parseInterval(%q) = %q, %v, want %q", param, got, err, want)
+		}
+	}
+	for _, interval := range []sources.Interval{sources.Interval1wk, sources.Interval1wk, "", sources.Interval1mo} {
+		if _, err := s.reader(nil, "yahoo", interval); err != nil {
+			t.Fatalf("reader(%q) error = %v", interval, err)
+		}
+	}
+
+	// One reader per interval, each with its interval set
+	want := []sources.Interval{sources.Interval1wk, "", sources.Interval1mo}
+	if len(got) != len(want) {
+		t.Fatalf("readers created with intervals %v, want %v", got, want)
+	}
+	for i := range want {
+		if got[i] != want[i] {
+			t.Errorf("reader %d interval = %q, want %q", i, got[i], want[i])
+		}
+	}
+}
+
+func TestServer_SourceInfo(t *testing.T) {
+	server := newTestServer(t, Config{})
+
+	resp, err := http.Get(server.URL + "/v1/sources/fred")
+	if err != nil {
+		t.Fatalf("GET error = %v", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		t.Fatalf("status = %d, want 200", resp.StatusCode)
+	}
+
+	var body sourceResponse
+	if err := json.NewDecoder(resp.Body).Decode(&body); err != nil {
+		t.Fatalf("Decode() error = %v", err)
+	}
+	if body.Name != "fred" || !body.RequiresAPIKey || len(body.Frequencies) == 0 || body.Frequencies[0] != "daily" {
+		t.Errorf("body = %+v, want fred requiring an API key with frequencies", body)
+	}
+
+	resp, err = http.Get(server.URL + "/v1/sources/nope")
+	if err != nil {
+		t.Fatalf("GET error = %v", err)
+	}
+	resp.Body.Close()
+	if resp.StatusCode != http.StatusNotFound {
+		t.Errorf("unknown source status = %d, want 404", resp.StatusCode)
+	}
+}
+
+func TestServer_ReadCSV(t *testing.T) {
+	server := newTestServer(t, Config{})
+
+	resp, err := http.Get(server.URL + "/v1/stooq/AAPL.US?start=2023-01-01&end=2023-01-31&format=csv")
+	if err != nil {
+		t.Fatalf("GET error = %v", err)
+	}
+	defer resp.Body.Close()
+
+	if ct := resp.Header.Get("Content-Type"); ct != "text/csv" {
+		t.Errorf("Content-Type = %q, want text/csv", ct)
+	}
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		t.Fatalf("read body error = %v", err)
+	}
+	if !strings.HasPrefix(string(body), "Date,Open,High,Low,Close,Volume\n") {
+		t.Errorf("unexpected CSV:\n%s", body)
+	}
+}
+
+func TestServer_Errors(t *testing.T) {
+	server := newTestServer(t, Config{})
+
+	tests := []struct {
+		name string
+		path string
+		want int
+	}{
+		{"unknown source", "/v1/unknown/AAPL", http.StatusNotFound},
+		{"invalid start", "/v1/stooq/AAPL.US?start=01/01/2023", http.StatusBadRequest},
+		{"invalid range", "/v1/stooq/AAPL.US?start=2023-02-01&end=2023-01-01", http.StatusBadRequest},
+		{"unknown interval", "/v1/stooq/AAPL.US?interval=fortnightly", http.StatusBadRequest},
+		{"interval not served by source", "/v1/stooq/AAPL.US?interval=1m", http.StatusBadRequest},
+		{"unsupported format", "/v1/stooq/AAPL.US?format=xml", http.StatusBadRequest},
+		{"wrong method", "/v1/sources", http.StatusMethodNotAllowed},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			method := http.MethodGet
+			if tt.want == http.StatusMethodNotAllowed {
+				method = http.MethodPost
+			}
+			req, _ := http.NewRequest(method, server.URL+tt.path, nil)
+			resp, err := http.DefaultClient.Do(req)
+			if err != nil {
+				t.Fatalf("request error = %v", err)
+			}
+			resp.Body.Close()
+
+			if resp.StatusCode != tt.want {
+				t.Errorf("status = %d, want %d", resp.StatusCode, tt.want)
+			}
+		})
+	}
+}
+
+func TestServer_MaxRows(t *testing.T) {
+	upstream := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "text/csv")
+		w.Write([]byte(stooqCSV))
+	}))
+	t.Cleanup(upstream.Close)
+
+	opts := datareader.DefaultOptions()
+	opts.MaxRows = 1
+	opts.BaseURLOverrides = map[string]string{"stooq": upstream.URL + "?s=%s"}
+	server := httptest.NewServer(NewServer(Config{Options: opts}).Handler())
+	t.Cleanup(server.Close)
+
+	resp, err := http.Get(server.URL + "/v1/stooq/AAPL.US?start=2023-01-01&end=2023-01-31")
+	if err != nil {
+		t.Fatalf("GET error = %v", err)
+	}
+	resp.Body.Close()
+
+	if resp.StatusCode != http.StatusBadRequest {
+		t.Errorf("status = %d, want 400 for more rows than MaxRows", resp.StatusCode)
+	}
+}
+
+func TestServer_HidesUpstreamErrors(t *testing.T) {
+	s := NewServer(Config{})
+	s.newReader = func(source string, opts *datareader.Options) (sources.Reader, error) {
+		return nil, errors.New(`Get "https://api.example.com/series?api_key=upstream-secret": dial tcp: timeout`)
+	}
+	server := httptest.NewServer(s.Handler())
+	t.Cleanup(server.Close)
+
+	resp, err := http.Get(server.URL + "/v1/fred/GDP")
+	if err != nil {
+		t.Fatalf("GET error = %v", err)
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		t.Fatalf("read body error = %v", err)
+	}
+	if resp.StatusCode != http.StatusBadGateway {
+		t.Errorf("status = %d, want 502", resp.StatusCode)
+	}
+	if strings.Contains(string(body), "upstream-secret") {
+		t.Errorf("body %s leaks the upstream API key", body)
+	}
+}
+
+func TestServer_ClientKeys(t *testing.T) {
+	server := newTestServer(t, Config{ClientKeys: []string{"secret"}})
+
+	tests := []struct {
+		name   string
+		header string
+		value  string
+		want   int
+	}{
+		{"missing key", "", "", http.StatusUnauthorized},
+		{"wrong key", "X-API-Key", "nope", http.StatusUnauthorized},
+		{"header key", "X-API-Key", "secret", http.StatusOK},
+		{"bearer token", "Authorization", "Bearer secret", http.StatusOK},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req, _ := http.NewRequest(http.MethodGet, server.URL+"/v1/sources", nil)
+			if tt.header != "" {
+				req.Header.Set(tt.header, tt.value)
+			}
+			resp, err := http.DefaultClient.Do(req)
+			if err != nil {
+				t.Fatalf("request error = %v", err)
+			}
+			resp.Body.Close()
+
+			if resp.StatusCode != tt.want {
+				t.Errorf("status = %d, want %d", resp.StatusCode, tt.want)
+			}
+		})
+	}
+}
+
+func TestServer_ClientRateLimit(t *testing.T) {
+	server := newTestServer(t, Config{ClientRate: 0.001, ClientBurst: 2})
+
+	var statuses []int
+	for i := 0; i < 3; i++ {
+		resp, err := http.Get(server.URL + "/v1/sources")
+		if err != nil {
+			t.Fatalf("GET error = %v", err)
+		}
+		resp.Body.Close()
+		statuses = append(statuses, resp.StatusCode)
+	}
+
+	if statuses[0] != http.StatusOK || statuses[1] != http.StatusOK || statuses[2] != http.StatusTooManyRequests {
+		t.Errorf("statuses = %v, want [200 200 429]", statuses)
+	}
+}
+
+func TestServer_EvictsIdleLimiters(t *testing.T) {
+	s := NewServer(Config{ClientRate: 0.001, ClientBurst: 1})
+	s.limiter("10.0.0.1", nil)
+	s.limiter("10.0.0.2", nil).Allow() // refills in 1000s
+	s.limiter("10.0.0.3", nil)
+
+	now := time.Now()
+	s.limiters["10.0.0.3"].lastSeen = now.Add(time.Minute)
+	s.sweepLimiters(now.Add(limiterIdle))
+
+	// Idle limiters are evicted once their bucket has refilled
+	for client, want := range map[string]bool{"10.0.0.1": false, "10.0.0.2": true, "10.0.0.3": true} {
+		if _, ok := s.limiters[client]; ok != want {
+			t.Errorf("limiter of %s kept = %v, want %v", client, ok, want)
+		}
+	}
+}
+
+func TestServer_ReusesReaders(t *testing.T) {
+	opts := datareader.DefaultOptions()
+	opts.MemoryCacheSize = 10
+	s := NewServer(Config{Options: opts, SourceKeys: map[string]string{"fred": "fred-key"}})
+
+	var created int
+	var gotKey string
+	s.newReader = func(source string, opts *datareader.Options) (sources.Reader, error) {
+		created++
+		gotKey = opts.APIKey
+		return datareader.DataReader(source, opts)
+	}
+
+	for i := 0; i < 2; i++ {
+		if _, err := s.reader(nil, "fred", ""); err != nil {
+			t.Fatalf("reader() error = %v", err)
+		}
+	}
+
+	if created != 1 {
+		t.Errorf("readers created = %d, want 1", created)
+	}
+	if gotKey != "fred-key" {
+		t.Errorf("APIKey = %q, want fred-key", gotKey)
+	}
+	if opts.APIKey != "" {
+		t.Errorf("base Options.APIKey modified to %q", opts.APIKey)
+	}
+}
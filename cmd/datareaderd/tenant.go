@@ -0,0 +1,65 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+)
+
+// Tenant is an isolated user of a shared deployment, identified by its
+// auth token. Each tenant gets its own readers, so upstream rate limits,
+// in-memory caches and API-key quotas are never shared with other tenants.
+type Tenant struct {
+	// Name identifies the tenant and namespaces its cache directory.
+	Name string `json:"name"`
+
+	// Token is the client API key that authenticates the tenant.
+	Token string `json:"token"`
+
+	// SourceKeys maps a source name to the tenant's own upstream API key.
+	// Sources without a tenant key use the deployment-wide key.
+	SourceKeys map[string]string `json:"source_keys,omitempty"`
+
+	// Rate and Burst limit the tenant's requests per second. Zero values
+	// use the deployment-wide ClientRate and ClientBurst.
+	Rate  float64 `json:"rate,omitempty"`
+	Burst int     `json:"burst,omitempty"`
+
+	// RateLimit overrides the upstream requests per second for the
+	// tenant's readers. Zero uses the deployment-wide setting.
+	RateLimit float64 `json:"rate_limit,omitempty"`
+}
+
+// LoadTenants reads a JSON array of tenants from path.
+func LoadTenants(path string) ([]Tenant, error) {
+	data, err := os.ReadFile(filepath.Clean(path))
+	if err != nil {
+		return nil, fmt.Errorf("read tenants: %w", err)
+	}
+
+	var tenants []Tenant
+	if err := json.Unmarshal(data, &tenants); err != nil {
+		return nil, fmt.Errorf("decode tenants: %w", err)
+	}
+	for i, t := range tenants {
+		if t.Name == "" || t.Token == "" {
+			return nil, fmt.Errorf("tenant %d: name and token are required", i)
+		}
+		if filepath.Base(t.Name) != t.Name || t.Name == "." || t.Name == ".." {
+			return nil, fmt.Errorf("tenant %d: invalid name %q", i, t.Name)
+		}
+	}
+	return tenants, nil
+}
+
+// tenantKey is the context key holding the request's *Tenant.
+type tenantKey struct{}
+
+// tenantFrom returns the tenant of the request, or nil for requests using
+// the shared configuration.
+func tenantFrom(ctx context.Context) *Tenant {
+	t, _ := ctx.Value(tenantKey{}).(*Tenant)
+	return t
+}
@@ -0,0 +1,129 @@
+package main
+
+import (
+	"net/http"
+	"os"
+	"path/filepath"
+	"testing"
+
+	datareader "github.com/julianshen/gonp-datareader"
+	"github.com/julianshen/gonp-datareader/sources"
+)
+
+func TestLoadTenants(t *testing.T) {
+	dir := t.TempDir()
+	write := func(name, content string) string {
+		path := filepath.Join(dir, name)
+		if err := os.WriteFile(path, []byte(content), 0600); err != nil {
+			t.Fatal(err)
+		}
+		return path
+	}
+
+	tenants, err := LoadTenants(write("ok.json", `[{"name": "research", "token": "r", "source_keys": {"fred": "k"}, "rate": 2}]`))
+	if err != nil {
+		t.Fatalf("LoadTenants() error = %v", err)
+	}
+	if len(tenants) != 1 || tenants[0].SourceKeys["fred"] != "k" || tenants[0].Rate != 2 {
+		t.Errorf("LoadTenants() = %+v", tenants)
+	}
+
+	for name, content := range map[string]string{
+		"missing-token.json": `[{"name": "research"}]`,
+		"bad-name.json":      `[{"name": "../x", "token": "t"}]`,
+		"bad-json.json":      `{`,
+	} {
+		if _, err := LoadTenants(write(name, content)); err == nil {
+			t.Errorf("LoadTenants(%s) expected error", name)
+		}
+	}
+}
+
+func TestServer_TenantIsolation(t *testing.T) {
+	opts := datareader.DefaultOptions()
+	opts.CacheDir = "/var/cache/datareaderd"
+
+	s := NewServer(Config{
+		Options:    opts,
+		SourceKeys: map[string]string{"fred": "shared", "tiingo": "shared"},
+		Tenants: []Tenant{
+			{Name: "research", Token: "r", SourceKeys: map[string]string{"fred": "research-key"}, RateLimit: 2},
+			{Name: "dashboards", Token: "d"},
+		},
+	})
+
+	created := map[string]*datareader.Options{}
+	s.newReader = func(source string, opts *datareader.Options) (sources.Reader, error) {
+		created[opts.CacheDir+"|"+source] = opts
+		return datareader.DataReader(source, opts)
+	}
+
+	research, dashboards := s.tenants["r"], s.tenants["d"]
+	for _, tenant := range []*Tenant{research, dashboards, nil} {
+		if _, err := s.reader(tenant, "fred", ""); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if _, err := s.reader(research, "tiingo", ""); err != nil {
+		t.Fatal(err)
+	}
+
+	if len(created) != 4 {
+		t.Fatalf("created %d readers, want 4 (one per tenant and source)", len(created))
+	}
+
+	got := created["/var/cache/datareaderd/tenants/research|fred"]
+	if got == nil || got.APIKey != "research-key" || got.RateLimit != 2 {
+		t.Errorf("research fred options = %+v, want own key, rate limit and cache dir", got)
+	}
+	if got := created["/var/cache/datareaderd/tenants/research|tiingo"]; got == nil || got.APIKey != "shared" {
+		t.Errorf("research tiingo options = %+v, want shared key fallback", got)
+	}
+	if got := created["/var/cache/datareaderd/tenants/dashboards|fred"]; got == nil || got.APIKey != "shared" {
+		t.Errorf("dashboards fred options = %+v", got)
+	}
+	if got := created["/var/cache/datareaderd|fred"]; got == nil {
+		t.Error("shared reader not created with the base cache dir")
+	}
+}
+
+func TestServer_TenantAuthAndRateLimit(t *testing.T) {
+	server := newTestServer(t, Config{
+		ClientKeys: []string{"shared"},
+		Tenants: []Tenant{
+			{Name: "limited", Token: "l", Rate: 0.001, Burst: 1},
+			{Name: "free", Token: "f"},
+		},
+	})
+
+	get := func(token string) int {
+		req, _ := http.NewRequest(http.MethodGet, server.URL+"/v1/sources", nil)
+		req.Header.Set("X-API-Key", token)
+		resp, err := http.DefaultClient.Do(req)
+		if err != nil {
+			t.Fatal(err)
+		}
+		resp.Body.Close()
+		return resp.StatusCode
+	}
+
+	if got := get("unknown"); got != http.StatusUnauthorized {
+		t.Errorf("unknown token status = %d, want 401", got)
+	}
+	if got := get("shared"); got != http.StatusOK {
+		t.Errorf("shared key status = %d, want 200", got)
+	}
+
+	// The limited tenant exhausts its own quota without affecting others
+	if got := get("l"); got != http.StatusOK {
+		t.Errorf("limited first status = %d, want 200", got)
+	}
+	if got := get("l"); got != http.StatusTooManyRequests {
+		t.Errorf("limited second status = %d, want 429", got)
+	}
+	for i := 0; i < 3; i++ {
+		if got := get("f"); got != http.StatusOK {
+			t.Errorf("free tenant status = %d, want 200", got)
+		}
+	}
+}
@@ -0,0 +1,320 @@
+package datareader
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math"
+	"math/big"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/julianshen/gonp-datareader/dataset"
+	"github.com/julianshen/gonp-datareader/internal/utils"
+	"github.com/julianshen/gonp-datareader/sources"
+	"github.com/julianshen/gonp-datareader/symbols"
+)
+
+// CompositeData is the result of a CompositeReader: the merged dataset
+// and the source of each row.
+type CompositeData struct {
+	*dataset.Dataset
+	// Provenance holds the source each row was taken from, aligned with
+	// Dates.
+	Provenance []string
+	// Errors holds the error of each source that failed, keyed by source
+	// name; the data comes from the remaining sources.
+	Errors map[string]error
+}
+
+// CompositeReader reads the same symbol from several sources and merges
+// the results on date, for symbols no single free source covers
+// completely. Each date is taken from the first source, in priority order,
+// with an observation on that date; the others only fill the dates it
+// lacks. Rows whose values are all NaN count as missing.
+//
+// CompositeReader implements sources.Reader: ReadSingle returns a
+// *CompositeData and Read a map[string]*CompositeData keyed by symbol.
+type CompositeReader struct {
+	readers []sources.Reader
+	symbols map[string]func(string) string
+	mode    NumericMode
+}
+
+// NewCompositeReader returns a reader merging the given readers, the
+// first being the primary source and the others filling its gaps in
+// order.
+//
+// # Example Usage
+//
+//	yahooReader, _ := datareader.DataReader("yahoo", nil)
+//	stooqReader, _ := datareader.DataReader("stooq", nil)
+//	composite := datareader.NewCompositeReader(yahooReader, stooqReader)
+//	composite.SetSymbolMapper("stooq", func(s string) string { return s + ".US" })
+//
+//	data, err := composite.ReadSingle(ctx, "AAPL", start, end)
+//	merged := data.(*datareader.CompositeData)
+//	fmt.Println(merged.Meta["composite_rows"]) // e.g., "yahoo:250,stooq:2"
+func NewCompositeReader(readers ...sources.Reader) *CompositeReader {
+	return &CompositeReader{
+		readers: readers,
+		symbols: make(map[string]func(string) string),
+	}
+}
+
+// SetSymbolMapper sets how a requested symbol is translated for the
+// reader of source, for sources with their own symbol conventions (e.g.,
+// "AAPL" is "AAPL.US" on Stooq). Symbols are passed unchanged otherwise.
+func (c *CompositeReader) SetSymbolMapper(source string, mapper func(string) string) {
+	c.symbols[source] = mapper
+}
+
+// SetSymbols sets the symbol mapper of every source from m, so symbols
+// are requested in canonical form (e.g., "AAPL", "2330"; see package
+// symbols) instead of with per-source mappers.
+func (c *CompositeReader) SetSymbols(m *symbols.Mapper) {
+	for _, r := range c.readers {
+		c.symbols[r.Source()] = m.Func(r.Source())
+	}
+}
+
+// SetNumericMode selects float64 or exact decimal values in the merged
+// dataset. Default: NumericFloat64
+func (c *CompositeReader) SetNumericMode(mode NumericMode) {
+	c.mode = mode
+}
+
+// Name returns the display name of the reader.
+func (c *CompositeReader) Name() string {
+	names := make([]string, len(c.readers))
+	for i, r := range c.readers {
+		names[i] = r.Name()
+	}
+	return "Composite (" + strings.Join(names, ", ") + ")"
+}
+
+// Source returns "composite".
+func (c *CompositeReader) Source() string {
+	return "composite"
+}
+
+// ValidateSymbol accepts a symbol that at least one source accepts.
+func (c *CompositeReader) ValidateSymbol(symbol string) error {
+	if len(c.readers) == 0 {
+		return fmt.Errorf("composite reader has no sources")
+	}
+	var errs []error
+	for _, r := range c.readers {
+		err := r.ValidateSymbol(c.symbolFor(r, symbol))
+		if err == nil {
+			return nil
+		}
+		errs = append(errs, fmt.Errorf("%s: %w", r.Source(), err))
+	}
+	return errors.Join(errs...)
+}
+
+// ReadSingle reads symbol from every source in parallel and merges the
+// results into a *CompositeData. It fails only when every source fails.
+func (c *CompositeReader) ReadSingle(ctx context.Context, symbol string, start, end time.Time) (interface{}, error) {
+	if err := utils.ValidateDateRange(start, end); err != nil {
+		return nil, fmt.Errorf("invalid date range: %w", err)
+	}
+	if err := c.ValidateSymbol(symbol); err != nil {
+		return nil, fmt.Errorf("invalid symbol: %w", err)
+	}
+
+	parts := make([]*dataset.Dataset, len(c.readers))
+	errs := make([]error, len(c.readers))
+	var wg sync.WaitGroup
+	for i, r := range c.readers {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			data, err := r.ReadSingle(ctx, c.symbolFor(r, symbol), start, end)
+			if err == nil {
+				parts[i], err = ToDatasetMode(symbol, data, c.mode)
+			}
+			if err != nil {
+				errs[i] = err
+				return
+			}
+			// Not every source filters by date, so trim to the range
+			parts[i] = parts[i].BetweenDays(start, end)
+		}()
+	}
+	wg.Wait()
+
+	result := &CompositeData{}
+	var names []string
+	var datasets []*dataset.Dataset
+	for i, r := range c.readers {
+		if errs[i] != nil {
+			if result.Errors == nil {
+				result.Errors = make(map[string]error)
+			}
+			result.Errors[r.Source()] = errs[i]
+			continue
+		}
+		names = append(names, r.Source())
+		datasets = append(datasets, parts[i])
+	}
+	if len(datasets) == 0 {
+		var all []error
+		for i, r := range c.readers {
+			all = append(all, fmt.Errorf("%s: %w", r.Source(), errs[i]))
+		}
+		return nil, fmt.Errorf("all sources failed for %s: %w", symbol, errors.Join(all...))
+	}
+
+	result.Dataset, result.Provenance = mergeByPriority(symbol, names, datasets)
+	return result, nil
+}
+
+// Read reads every symbol like ReadSingle, returning a
+// map[string]*CompositeData keyed by symbol. It fails with the error of
+// the first symbol, in request order, that could not be read.
+func (c *CompositeReader) Read(ctx context.Context, symbols []string, start, end time.Time) (interface{}, error) {
+	if len(symbols) == 0 {
+		return nil, fmt.Errorf("invalid symbols: %w", utils.ErrEmptySymbolList)
+	}
+
+	results := readMulti[*CompositeData](ctx, c, symbols, start, end)
+	out := make(map[string]*CompositeData, len(symbols))
+	for _, r := range results.Results {
+		if r.Err != nil {
+			return nil, fmt.Errorf("failed to read %s: %w", r.Symbol, r.Err)
+		}
+		out[r.Symbol] = r.Data
+	}
+	return out, nil
+}
+
+// symbolFor returns symbol as named by the source of r.
+func (c *CompositeReader) symbolFor(r sources.Reader, symbol string) string {
+	if mapper := c.symbols[r.Source()]; mapper != nil {
+		return mapper(symbol)
+	}
+	return symbol
+}
+
+// mergeByPriority merges datasets on date, taking each date from the
+// first dataset with an observation on it. names are the sources of the
+// datasets; the returned provenance holds the source of each row.
+func mergeByPriority(symbol string, names []string, datasets []*dataset.Dataset) (*dataset.Dataset, []string) {
+	// Pick the dataset and row of every date
+	type pick struct{ set, row int }
+	picks := make(map[int64]pick)
+	var dates []time.Time
+	for n, d := range datasets {
+		for i, t := range d.Dates {
+			if _, taken := picks[t.UnixNano()]; taken || !hasValue(d, i) {
+				continue
+			}
+			picks[t.UnixNano()] = pick{set: n, row: i}
+			dates = append(dates, t)
+		}
+	}
+	sort.Slice(dates, func(i, j int) bool { return dates[i].Before(dates[j]) })
+
+	out := dataset.New(symbol, strings.Join(names, ","), dates)
+	provenance := make([]string, len(dates))
+	rows := make([]int, len(names))
+	for i, t := range dates {
+		p := picks[t.UnixNano()]
+		provenance[i] = names[p.set]
+		rows[p.set]++
+	}
+
+	// Columns are the union of all sources' columns
+	var columns []string
+	for _, d := range datasets {
+		for _, name := range d.ColumnNames() {
+			if !containsName(columns, name) {
+				columns = append(columns, name)
+			}
+		}
+	}
+
+	for _, name := range columns {
+		col := dataset.Column{Name: name, Values: make([]float64, len(dates))}
+		for _, d := range datasets {
+			if col.Unit = d.Unit(name); col.Unit != "" {
+				break
+			}
+		}
+		exact := make([]*big.Rat, len(dates))
+		allExact := true
+		for i, t := range dates {
+			p := picks[t.UnixNano()]
+			values, ok := datasets[p.set].Column(name)
+			if !ok {
+				col.Values[i] = math.NaN()
+				allExact = false
+				continue
+			}
+			col.Values[i] = values[p.row]
+
+			rats, ok := datasets[p.set].ExactColumn(name)
+			allExact = allExact && ok
+			if ok {
+				exact[i] = rats[p.row]
+			}
+		}
+		if allExact {
+			col.Exact = exact
+		}
+		out.Columns = append(out.Columns, col)
+	}
+
+	// Carry upstream flags of the picked rows
+	for _, d := range datasets {
+		if d.Flags == nil {
+			continue
+		}
+		out.Flags = make([]string, len(dates))
+		for i, t := range dates {
+			p := picks[t.UnixNano()]
+			if flags := datasets[p.set].Flags; flags != nil {
+				out.Flags[i] = flags[p.row]
+			}
+		}
+		break
+	}
+
+	// Meta of lower-priority sources first, so the primary's wins
+	for n := len(datasets) - 1; n >= 0; n-- {
+		for k, v := range datasets[n].Meta {
+			out.Meta[k] = v
+		}
+	}
+	counts := make([]string, len(names))
+	for n, name := range names {
+		counts[n] = name + ":" + strconv.Itoa(rows[n])
+	}
+	out.Meta["composite_rows"] = strings.Join(counts, ",")
+	return out, provenance
+}
+
+// hasValue reports whether row i of d has at least one non-NaN value.
+func hasValue(d *dataset.Dataset, i int) bool {
+	for _, c := range d.Columns {
+		if !math.IsNaN(c.Values[i]) {
+			return true
+		}
+	}
+	return false
+}
+
+// containsName reports whether names contains name.
+func containsName(names []string, name string) bool {
+	for _, n := range names {
+		if n == name {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,177 @@
+package datareader_test
+
+import (
+	"context"
+	"errors"
+	"math"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	datareader "github.com/julianshen/gonp-datareader"
+	"github.com/julianshen/gonp-datareader/dataset"
+	"github.com/julianshen/gonp-datareader/sources"
+	"github.com/julianshen/gonp-datareader/symbols"
+)
+
+// datasetReader serves fixed closing prices, or fails with err.
+type datasetReader struct {
+	*sources.BaseSource
+	closes map[string]float64
+	err    error
+}
+
+func (r *datasetReader) ReadSingle(ctx context.Context, symbol string, start, end time.Time) (interface{}, error) {
+	if r.err != nil {
+		return nil, r.err
+	}
+	var dates []time.Time
+	var values []float64
+	for _, day := range []string{"2024-01-02", "2024-01-03", "2024-01-04", "2024-01-05"} {
+		if v, ok := r.closes[day]; ok {
+			d, _ := time.Parse("2006-01-02", day)
+			dates = append(dates, d)
+			values = append(values, v)
+		}
+	}
+	ds := dataset.New(symbol, r.Source(), dates)
+	if err := ds.AddColumn("Close", values); err != nil {
+		return nil, err
+	}
+	return &datareader.CompositeData{Dataset: ds}, nil
+}
+
+func (r *datasetReader) Read(ctx context.Context, symbols []string, start, end time.Time) (interface{}, error) {
+	return nil, errors.New("not implemented")
+}
+
+func TestCompositeReader_FillsGaps(t *testing.T) {
+	// Stooq, the primary, misses 2024-01-03 and has no values on 2024-01-05
+	var requested string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requested = r.URL.Query().Get("s")
+		w.Write([]byte("Date,Open,High,Low,Close,Volume\n2024-01-02,1,1,1,10,100\n2024-01-04,1,1,1,12,100\n2024-01-05,,,,,\n"))
+	}))
+	defer server.Close()
+
+	primary, err := datareader.DataReader("stooq", &datareader.Options{
+		Environment:     datareader.EnvironmentSandbox,
+		SandboxBaseURLs: map[string]string{"stooq": server.URL + "?s=%s"},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	secondary := &datasetReader{
+		BaseSource: sources.NewBaseSource("backup"),
+		closes:     map[string]float64{"2024-01-02": 99, "2024-01-03": 11, "2024-01-05": 13},
+	}
+
+	composite := datareader.NewCompositeReader(primary, secondary)
+	composite.SetSymbolMapper("stooq", func(s string) string { return s + ".US" })
+
+	start := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	end := time.Date(2024, 1, 31, 0, 0, 0, 0, time.UTC)
+	data, err := composite.ReadSingle(context.Background(), "AAPL", start, end)
+	if err != nil {
+		t.Fatalf("ReadSingle() error = %v", err)
+	}
+	if requested != "AAPL.US" {
+		t.Errorf("stooq requested %q, want AAPL.US", requested)
+	}
+
+	merged := data.(*datareader.CompositeData)
+	closes, _ := merged.Column("Close")
+	wantCloses := []float64{10, 11, 12, 13}
+	wantSources := []string{"stooq", "backup", "stooq", "backup"}
+	if len(closes) != len(wantCloses) {
+		t.Fatalf("Close = %v, want %v", closes, wantCloses)
+	}
+	for i := range wantCloses {
+		if closes[i] != wantCloses[i] || merged.Provenance[i] != wantSources[i] {
+			t.Errorf("row %d = %v from %s, want %v from %s", i, closes[i], merged.Provenance[i], wantCloses[i], wantSources[i])
+		}
+	}
+
+	// Columns only the primary has are NaN on filled rows
+	volume, _ := merged.Column("Volume")
+	if volume[0] != 100 || !math.IsNaN(volume[1]) {
+		t.Errorf("Volume = %v, want 100 then NaN", volume)
+	}
+	if got := merged.Meta["composite_rows"]; got != "stooq:2,backup:2" {
+		t.Errorf(`Meta["composite_rows"] = %q, want "stooq:2,backup:2"`, got)
+	}
+
+	// The merged data converts like any source's
+	ds, err := datareader.ToDataset("AAPL", merged)
+	if err != nil || ds.Len() != 4 {
+		t.Errorf("ToDataset() = %v, %v; want 4 rows", ds, err)
+	}
+}
+
+func TestCompositeReader_SetSymbols(t *testing.T) {
+	var requested string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requested = r.URL.Query().Get("s")
+		w.Write([]byte("Date,Open,High,Low,Close,Volume\n2024-01-02,1,1,1,10,100\n"))
+	}))
+	defer server.Close()
+
+	stooqReader, err := datareader.DataReader("stooq", &datareader.Options{
+		Environment:     datareader.EnvironmentSandbox,
+		SandboxBaseURLs: map[string]string{"stooq": server.URL + "?s=%s"},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	composite := datareader.NewCompositeReader(stooqReader)
+	composite.SetSymbols(symbols.Default)
+
+	start := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	end := time.Date(2024, 1, 31, 0, 0, 0, 0, time.UTC)
+	if _, err := composite.ReadSingle(context.Background(), "BRK.B", start, end); err != nil {
+		t.Fatalf("ReadSingle() error = %v", err)
+	}
+	if requested != "BRK-B.US" {
+		t.Errorf("stooq requested %q, want BRK-B.US", requested)
+	}
+}
+
+func TestCompositeReader_SourceErrors(t *testing.T) {
+	errDown := errors.New("service down")
+	up := &datasetReader{BaseSource: sources.NewBaseSource("up"), closes: map[string]float64{"2024-01-02": 1}}
+	down := &datasetReader{BaseSource: sources.NewBaseSource("down"), err: errDown}
+	start := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	end := time.Date(2024, 1, 31, 0, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name    string
+		readers []sources.Reader
+		wantErr bool
+	}{
+		{"primary fails", []sources.Reader{down, up}, false},
+		{"secondary fails", []sources.Reader{up, down}, false},
+		{"all fail", []sources.Reader{down}, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			composite := datareader.NewCompositeReader(tt.readers...)
+			data, err := composite.ReadSingle(context.Background(), "X", start, end)
+			if tt.wantErr {
+				if !errors.Is(err, errDown) {
+					t.Fatalf("ReadSingle() error = %v, want %v", err, errDown)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("ReadSingle() error = %v", err)
+			}
+			merged := data.(*datareader.CompositeData)
+			if !errors.Is(merged.Errors["down"], errDown) || merged.Len() != 1 || merged.Provenance[0] != "up" {
+				t.Errorf("ReadSingle() = %d rows from %v, errors %v; want 1 row from up and the down error", merged.Len(), merged.Provenance, merged.Errors)
+			}
+		})
+	}
+}
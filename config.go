@@ -1,6 +1,26 @@
 package datareader
 
-import "time"
+import (
+	"time"
+
+	"github.com/julianshen/gonp-datareader/calendar"
+	"github.com/julianshen/gonp-datareader/dataset"
+	"github.com/julianshen/gonp-datareader/sources"
+	"github.com/julianshen/gonp-datareader/tickers"
+)
+
+// NumericMode selects how numeric values are represented when data is
+// converted to a dataset.Dataset.
+type NumericMode int
+
+const (
+	// NumericFloat64 stores values as float64 only. This is the default.
+	NumericFloat64 NumericMode = iota
+	// NumericDecimal additionally keeps exact decimal values (big.Rat) in
+	// each column's Exact slice, for prices with more precision than a
+	// float64 holds (e.g., crypto with 8+ decimals) or for accounting.
+	NumericDecimal
+)
 
 // Options configures the behavior of a data reader.
 //
@@ -40,8 +60,16 @@ type Options struct {
 	// Required for: alphavantage, iex
 	// Optional for: fred (higher rate limits with key)
 	// Not used for: yahoo, worldbank, stooq
+	// When empty, the key is read from the source's environment variable
+	// (see APIKeyEnv and DisableEnvAPIKeys).
 	APIKey string
 
+	// DisableEnvAPIKeys stops DataReader from reading a source's API key
+	// from its environment variable (see APIKeyEnv, e.g., FRED_API_KEY)
+	// when APIKey is empty, so keys are only ever passed explicitly.
+	// Calls with nil Options always read them. Default: false
+	DisableEnvAPIKeys bool
+
 	// Timeout specifies the maximum duration for HTTP requests.
 	// Zero or negative values mean no timeout.
 	// Default: 30 seconds
@@ -57,6 +85,15 @@ type Options struct {
 	// Default: 1 second
 	RetryDelay time.Duration
 
+	// MaxRetryAfter caps the Retry-After wait honored on 429 and 503
+	// responses: shorter waits replace RetryDelay's backoff before the
+	// retry, while responses asking for longer are not retried, so a 429
+	// fails with a sources.RateLimitError carrying its RetryAfter.
+	// Negative never waits for Retry-After. Waits always end with the
+	// request context.
+	// Default: 0 (30 seconds)
+	MaxRetryAfter time.Duration
+
 	// EnableCache enables response caching (deprecated, use CacheDir instead).
 	// Caching is automatically enabled when CacheDir is set.
 	EnableCache bool
@@ -64,24 +101,288 @@ type Options struct {
 	// CacheDir specifies the directory for cached responses.
 	// If empty, caching is disabled.
 	// Cached responses are stored with SHA-256 hashed filenames.
+	// Under js/wasm, where there is no filesystem, responses are kept in
+	// the memory cache instead (256 entries unless MemoryCacheSize is set).
 	CacheDir string
 
 	// CacheTTL specifies how long cached responses remain valid.
 	// Zero means responses are cached indefinitely.
-	// Expired entries are automatically cleaned on access.
+	// Expired entries are automatically cleaned on access, unless
+	// ServeStaleOnError is set.
 	CacheTTL time.Duration
 
+	// ServeStaleOnError returns an expired cached response when the upstream
+	// request fails with a network error, timeout or 5xx status after all
+	// retries. Such data is marked with Meta["stale"] = "true" and
+	// Meta["cached_at"] on the returned ParsedData and Dataset.
+	// Requires CacheDir. Default: false
+	ServeStaleOnError bool
+
+	// MaxRedirects limits how many redirects a request may follow before it
+	// fails with sources.ErrTooManyRedirects, so redirect chains to consent
+	// or login pages fail fast. Negative disables redirects.
+	// Default: 0 (Go's default of 10)
+	MaxRedirects int
+
+	// MemoryCacheSize specifies the maximum number of responses kept in an
+	// in-process LRU cache in front of CacheDir, so hot symbols are served
+	// without disk reads. Works with or without CacheDir.
+	// Zero disables the memory cache. Default: 0
+	MemoryCacheSize int
+
+	// MemoryCacheTTL specifies how long responses remain in the memory cache.
+	// Zero means CacheTTL is used. Responses loaded from CacheDir leave the
+	// memory cache no later than they expire on disk.
+	MemoryCacheTTL time.Duration
+
+	// DecodedCacheSize specifies the maximum number of parsed responses kept
+	// in memory, keyed by the checksum of the response body. Repeated reads
+	// of cached content then skip JSON/CSV decoding entirely; an entry is
+	// only reused for byte-identical content, so it is invalidated together
+	// with the HTTP cache entry. Decoded values are shared, so returned
+	// ParsedData should be treated as read-only when this is set.
+	// Zero disables the decoded cache. Default: 0
+	DecodedCacheSize int
+
+	// NumericMode selects float64 or exact decimal values when data is
+	// converted to a Dataset by ReadDataset. Default: NumericFloat64
+	NumericMode NumericMode
+
+	// Fill selects how ReadDataset fills missing values, such as FRED's
+	// "." placeholders or World Bank years without a value: forward,
+	// backward, by linear interpolation, or by dropping incomplete rows.
+	// Meta["fill"] records the method. Default: dataset.FillNaN, which
+	// leaves them as NaN
+	Fill dataset.FillMethod
+
+	// Hooks holds optional instrumentation callbacks, such as cache
+	// hit/miss notifications.
+	Hooks *Hooks
+
 	// RateLimit specifies the maximum number of requests per second.
 	// Zero or negative values mean no rate limiting.
 	// Uses token bucket algorithm for smooth rate limiting.
 	RateLimit float64
 
+	// RateBurst specifies how many requests may be made at once when the
+	// rate limiter's bucket is full. Default: 1
+	RateBurst int
+
+	// RateInitialTokens specifies how many requests the rate limiter allows
+	// at once at startup, before pacing sets in; the bucket then refills to
+	// RateBurst at RateLimit. Negative starts with an empty bucket, so even
+	// the first request of a batch is paced, for providers with strict
+	// per-second limits. Default: 0 (a full bucket of RateBurst)
+	RateInitialTokens int
+
+	// RateLimits overrides RateLimit, RateBurst and RateInitialTokens per
+	// source name (e.g., {"finmind": {InitialTokens: -1}}). Zero fields of
+	// an entry fall back to the corresponding Options field.
+	RateLimits map[string]RateLimitConfig
+
+	// AdaptiveRateLimit lowers a reader's rate limit while its provider
+	// throttles, AIMD style: the rate is halved when the provider answers
+	// 429 or 503 or reports a used-up quota (Alpha Vantage notes, FinMind
+	// 402), down to 1/64 of the configured rate, and recovers by a
+	// twentieth of it per successful response. Copes with shared IPs and
+	// provider limits that change. Requires RateLimit (or a RateLimits
+	// entry). Default: false
+	AdaptiveRateLimit bool
+
+	// AdaptiveConcurrency tunes each reader's number of concurrent
+	// requests to what its provider tolerates, AIMD style: the limit is
+	// halved when the provider answers 429 or 503 (and requests pause for
+	// its Retry-After, up to MaxRetryAfter) or responds slower than
+	// LatencyTarget, then grows back by one per round of successful
+	// responses, up to MaxConcurrency.
+	// Suited to long ingestion jobs. Default: false
+	AdaptiveConcurrency bool
+
+	// MaxConcurrency caps the concurrent requests of a reader in adaptive
+	// mode. Default: 10
+	MaxConcurrency int
+
+	// LatencyTarget is the response time above which adaptive mode treats
+	// the provider as congested. Zero ignores latency. Default: 0
+	LatencyTarget time.Duration
+
+	// FallbackCharset is the charset assumed for text responses that
+	// declare none and are not valid UTF-8 (e.g., "big5", "iso-8859-1"),
+	// so names from legacy endpoints do not turn into mojibake. Responses
+	// declaring a charset in their Content-Type are always converted to
+	// UTF-8. DataReader rejects unknown charsets. Default: "" ("big5" for
+	// twse)
+	FallbackCharset string
+
+	// DisableBodyDecoding passes response bodies to the parsers as
+	// received, neither decompressed nor converted to UTF-8. Default: false
+	DisableBodyDecoding bool
+
+	// Environment selects production (the default) or a source's sandbox
+	// deployment. With EnvironmentSandbox, DataReader returns ErrNoSandbox
+	// for sources without a sandbox; see SandboxSources.
+	Environment Environment
+
+	// SandboxBaseURLs overrides or adds sandbox base URLs per source name,
+	// used when Environment is EnvironmentSandbox. Each URL uses the format
+	// of the source's NewXReaderWithBaseURL constructor, so a local stub or
+	// staging server can stand in for any source.
+	SandboxBaseURLs map[string]string
+
+	// BaseURLOverrides replaces the endpoint of a source per source name, in
+	// every environment, so requests can be routed through an internal API
+	// gateway or caching proxy. Each URL uses the format of the source's
+	// NewXReaderWithBaseURL constructor. An override takes precedence over
+	// the production, sandbox and pinned-version endpoints, but not over a
+	// SandboxBaseURLs entry when Environment is EnvironmentSandbox.
+	BaseURLOverrides map[string]string
+
+	// APIVersions pins the API version used per source name (e.g.,
+	// {"iex": "v1"}). Unset sources use their default version, so provider
+	// upgrades happen with library upgrades. DataReader returns an
+	// *UnsupportedVersionError when a pinned version is unknown or retired;
+	// see APIVersions for the versions each source supports.
+	APIVersions map[string]string
+
+	// DelistingMeta flags delisted symbols in the returned data's Meta
+	// ("delisted", "delisted_date") where the source reports delistings,
+	// so backtests can avoid survivorship bias. Supported by: tiingo,
+	// which also serves prices of delisted tickers. Costs one extra
+	// request per symbol. Default: false
+	DelistingMeta bool
+
+	// MergeSeries makes Read return all requested series as one frame
+	// aligned on a common date index instead of a map of per-series
+	// results. Supported by: fred, which returns a *fred.MultiSeries
+	// (convert it with ToDataset). Default: false
+	MergeSeries bool
+
+	// FrequencyMeta records each series' native frequency in the returned
+	// data's Meta ("frequency", "native_frequency", "date_convention"),
+	// so a Dataset can tell a monthly average from a point-in-time value
+	// (see dataset.Periods) and weekly series dated by their last day are
+	// aligned correctly, along with its units ("unit"). Supported by:
+	// fred. Costs one extra request per series. Default: false
+	FrequencyMeta bool
+
+	// EnglishNames adds each security's English short name, from the
+	// exchange's listed-company directory, to the returned data
+	// (Meta["english_name"] in a Dataset) alongside the Traditional Chinese
+	// name. Supported by: twse. Costs one extra request per call.
+	// Default: false
+	EnglishNames bool
+
+	// Format selects the response format requested from the source.
+	// Supported by: eurostat, "jsonstat" (the default) or "sdmx-csv",
+	// which is parsed as a stream and limited to the requested years, for
+	// large regional extracts. DataReader rejects unknown formats.
+	Format string
+
+	// StitchRenames makes ReadDataset follow ticker changes (e.g., FB →
+	// META): the history is fetched under every ticker the company used
+	// within the range and stitched into one continuous series. Either the
+	// old or the new ticker may be requested. Default: false
+	StitchRenames bool
+
+	// TickerHistory holds the ticker changes used by StitchRenames.
+	// Nil means tickers.Bundled().
+	TickerHistory *tickers.History
+
+	// ExpectedRowsTolerance enables row-count checks in ReadDataset: when
+	// more than this fraction of the sessions in the range are missing from
+	// a daily dataset (e.g., 0.02 for 2%), Meta["short"] is set to "true"
+	// and Meta["expected_rows"] to the session count. Zero disables the
+	// check. See CheckRowCount. Default: 0
+	ExpectedRowsTolerance float64
+
+	// Calendar is the exchange calendar used by ExpectedRowsTolerance and
+	// MaxRows.
+	// Nil means calendar.ForSource; sources without one are not checked.
+	Calendar *calendar.Calendar
+
+	// Interval is the bar interval of price sources (e.g.,
+	// sources.Interval1wk); empty means daily bars. Sources that do not
+	// serve the interval fail in DataReader with an error matching
+	// sources.ErrUnsupportedInterval; see SourceMetadata.Intervals.
+	// Supported by: yahoo, alphavantage (intraday, weekly, monthly),
+	// tiingo, stooq (weekly, monthly). Default: ""
+	Interval sources.Interval
+
+	// AdjustPrices selects split- and dividend-adjusted prices, so
+	// returns computed across corporate actions are continuous, and sets
+	// Meta["adjusted"] to "true". Supported by: yahoo (Open, High and Low
+	// scaled by Adj Close / Close, Close replaced by Adj Close, Volume
+	// unchanged), tiingo (adjOpen through adjVolume) and alphavantage (the
+	// *_ADJUSTED functions, scaled like yahoo; TIME_SERIES_DAILY_ADJUSTED
+	// is a premium endpoint). Other sources ignore it; stooq prices are
+	// already adjusted. The Adj Close column is returned either
+	// way where the source serves one. Default: false
+	AdjustPrices bool
+
+	// MaxRows caps the rows Read, ReadDataset and the readers of
+	// DataReader (and so Manager) return per symbol, so an accidental
+	// multi-decade request cannot exhaust memory in a shared service. Requests to sources with an exchange calendar (see Calendar)
+	// whose range has more sessions fail before they are sent; results
+	// with more rows fail after parsing. Both return a *TooManyRowsError
+	// matching ErrTooManyRows. Zero means no limit. Default: 0
+	MaxRows int
+
+	// DisableRowSorting returns results in the order the source sent them.
+	// By default Read, ReadDataset, Manager and readers from DataReader
+	// sort every result ascending by date and drop rows repeating a date,
+	// keeping the last (see sources.SortRows), since sources differ in
+	// order and snapshots can repeat rows. Default: false
+	DisableRowSorting bool
+
+	// ExcludeIncompleteBar drops bars whose period has not ended at the
+	// exchange, such as today's daily bar before the close, so ingestion
+	// pipelines do not persist values that change at the close. By default
+	// Read, ReadDataset, Manager and readers from DataReader keep such bars
+	// and flag them as provisional (see MetaProvisional). Default: false
+	ExcludeIncompleteBar bool
+
+	// BundlePath is the bundle file served by the "bundle" source, as
+	// written by BuildBundle. Required for: bundle
+	BundlePath string
+
 	// UserAgent specifies the User-Agent header for HTTP requests.
 	// Some sources (like Yahoo Finance) may require a valid browser User-Agent.
 	// Default: Chrome/Safari User-Agent string
 	UserAgent string
 }
 
+// RateLimitConfig configures the rate limiter of one source; see
+// Options.RateLimits.
+type RateLimitConfig struct {
+	// Rate is the maximum number of requests per second.
+	Rate float64
+	// Burst is how many requests may be made at once with a full bucket.
+	Burst int
+	// InitialTokens is how many tokens the bucket starts with; negative
+	// means empty.
+	InitialTokens int
+}
+
+// rateLimitFor returns the rate limiter configuration of source, applying
+// its Options.RateLimits entry over the global settings.
+func (o *Options) rateLimitFor(source string) RateLimitConfig {
+	cfg := RateLimitConfig{Rate: o.RateLimit, Burst: o.RateBurst, InitialTokens: o.RateInitialTokens}
+	override, ok := o.RateLimits[source]
+	if !ok {
+		return cfg
+	}
+	if override.Rate != 0 {
+		cfg.Rate = override.Rate
+	}
+	if override.Burst != 0 {
+		cfg.Burst = override.Burst
+	}
+	if override.InitialTokens != 0 {
+		cfg.InitialTokens = override.InitialTokens
+	}
+	return cfg
+}
+
 // DefaultOptions returns a new Options struct with recommended default values.
 //
 // Default values:
@@ -90,6 +391,9 @@ type Options struct {
 //   - RetryDelay: 1 second
 //   - UserAgent: Chrome browser User-Agent
 //
+// The non-zero fields of the options set by SetDefaultOptions replace
+// these values.
+//
 // # Example Usage
 //
 //	opts := datareader.DefaultOptions()
@@ -98,10 +402,14 @@ type Options struct {
 //
 //	reader, err := datareader.DataReader("fred", opts)
 func DefaultOptions() *Options {
-	return &Options{
+	opts := &Options{
 		Timeout:    30 * time.Second,
 		MaxRetries: 3,
 		RetryDelay: 1 * time.Second,
 		UserAgent:  "Mozilla/5.0 (Macintosh; Intel Mac OS X 10_15_7) AppleWebKit/537.36 (KHTML, like Gecko) Chrome/120.0.0.0 Safari/537.36",
 	}
+	if set := GetDefaultOptions(); set != nil {
+		overlay(opts, set)
+	}
+	return opts
 }
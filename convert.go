@@ -0,0 +1,504 @@
+package datareader
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"math/big"
+	"strconv"
+	"time"
+
+	"github.com/julianshen/gonp-datareader/dataset"
+	"github.com/julianshen/gonp-datareader/internal/numparse"
+	"github.com/julianshen/gonp-datareader/sources"
+	"github.com/julianshen/gonp-datareader/sources/alphavantage"
+	"github.com/julianshen/gonp-datareader/sources/bundle"
+	"github.com/julianshen/gonp-datareader/sources/ecb"
+	"github.com/julianshen/gonp-datareader/sources/eurostat"
+	"github.com/julianshen/gonp-datareader/sources/finmind"
+	"github.com/julianshen/gonp-datareader/sources/finra"
+	"github.com/julianshen/gonp-datareader/sources/fred"
+	"github.com/julianshen/gonp-datareader/sources/iex"
+	"github.com/julianshen/gonp-datareader/sources/oecd"
+	"github.com/julianshen/gonp-datareader/sources/stooq"
+	"github.com/julianshen/gonp-datareader/sources/tiingo"
+	"github.com/julianshen/gonp-datareader/sources/twse"
+	"github.com/julianshen/gonp-datareader/sources/worldbank"
+	"github.com/julianshen/gonp-datareader/sources/yahoo"
+)
+
+var (
+	// ErrUnsupportedData is returned when a value cannot be converted to a Dataset.
+	ErrUnsupportedData = errors.New("unsupported data type")
+)
+
+// ToDataset converts the result of a reader's ReadSingle into a source-agnostic
+// dataset.Dataset with a time index and float64 columns.
+//
+// Missing values (empty strings, "." in FRED, "null" in Yahoo) become NaN.
+// Non-numeric columns such as identifiers are dropped. Response metadata,
+// such as Meta["stale"] for data served from an expired cache entry, is
+// copied into the Dataset's Meta. Columns carry their unit where the
+// source makes it known: the currency of prices, shares for volume, and
+// the units of FRED series (with Options.FrequencyMeta) and World Bank
+// indicators.
+//
+// # Example Usage
+//
+//	data, err := datareader.Read(ctx, "GDP", "fred", start, end, opts)
+//	if err != nil {
+//		log.Fatal(err)
+//	}
+//	ds, err := datareader.ToDataset("GDP", data)
+func ToDataset(symbol string, data interface{}) (*dataset.Dataset, error) {
+	return ToDatasetMode(symbol, data, NumericFloat64)
+}
+
+// ToDatasetMode converts data like ToDataset, using mode to select the
+// numeric representation.
+//
+// In NumericDecimal mode every numeric column also carries exact decimal
+// values. Sources that return numbers as text (Yahoo, Stooq, FRED, ...) are
+// parsed exactly from the original digits. Sources that decode numbers into
+// float64 (TWSE, Tiingo, OECD, Eurostat) are converted through the shortest
+// decimal that round-trips the float64, which recovers the upstream value
+// for inputs with up to 15 significant digits.
+func ToDatasetMode(symbol string, data interface{}, mode NumericMode) (*dataset.Dataset, error) {
+	exact := mode == NumericDecimal
+
+	var (
+		ds   *dataset.Dataset
+		meta map[string]string
+		err  error
+	)
+
+	switch d := data.(type) {
+	case *yahoo.ParsedData:
+		ds, err = rowsToDataset(exact, symbol, "yahoo", "Date", d.Columns, d.Rows)
+		meta = d.Meta
+	case *stooq.ParsedData:
+		ds, err = rowsToDataset(exact, symbol, "stooq", "Date", d.Columns, d.Rows)
+		meta = d.Meta
+	case *alphavantage.ParsedData:
+		ds, err = rowsToDataset(exact, symbol, "alphavantage", "Date", d.Columns, d.Rows)
+		meta = d.Meta
+	case *iex.ParsedData:
+		ds, err = rowsToDataset(exact, symbol, "iex", "Date", d.Columns, d.Rows)
+		meta = d.Meta
+	case *finmind.ParsedData:
+		ds, err = rowsToDataset(exact, symbol, "finmind", "date", without(d.Columns, "stock_id"), d.Rows)
+		meta = d.Meta
+	case *fred.ParsedData:
+		ds, err = stringSeriesToDataset(exact, symbol, "fred", d.Dates, d.Values, nil)
+		meta = d.Meta
+	case *fred.MultiSeries:
+		ds, err = wideToDataset(exact, symbol, "fred", d.Dates, d.Series, d.Values)
+		meta = d.Meta
+	case *worldbank.ParsedData:
+		ds, err = stringSeriesToDataset(exact, symbol, "worldbank", d.Dates, d.Values, d.Flags)
+		meta = d.Meta
+	case *worldbank.BulkData:
+		ds, err = wideToDataset(exact, symbol, "worldbank", d.Years, d.Countries, d.Values)
+		meta = d.Meta
+	case *oecd.ParsedData:
+		ds, err = floatSeriesToDataset(exact, symbol, "oecd", d.Dates, d.Values)
+		meta = d.Meta
+	case *eurostat.ParsedData:
+		ds, err = floatSeriesToDataset(exact, symbol, "eurostat", d.Dates, d.Values)
+		meta = d.Meta
+	case *tiingo.ParsedData:
+		ds, err = tiingoToDataset(exact, symbol, d)
+		meta = d.Meta
+	case *twse.ParsedData:
+		ds, err = twseToDataset(exact, symbol, d)
+		meta = d.Meta
+	case *finra.ParsedData:
+		ds, err = finraToDataset(exact, symbol, d)
+		meta = d.Meta
+	case *ecb.ParsedData:
+		if ds, err = timeSeriesToDataset(exact, symbol, d); err == nil {
+			ds.Source = "ecb"
+		}
+		meta = d.Meta
+	case *bundle.ParsedData:
+		ds, err = bundleToDataset(exact, d)
+		meta = d.Meta
+	case *CompositeData:
+		if d == nil || d.Dataset == nil {
+			return nil, fmt.Errorf("%w: nil composite data", ErrUnsupportedData)
+		}
+		ds = d.Dataset.Clone()
+		ds.Symbol = symbol
+	case sources.TimeSeries:
+		// Data of sources added with RegisterSource
+		ds, err = timeSeriesToDataset(exact, symbol, d)
+	default:
+		return nil, fmt.Errorf("%w: %T", ErrUnsupportedData, data)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	// Carry response metadata (e.g., "stale") through to the Dataset
+	for k, v := range meta {
+		ds.Meta[k] = v
+	}
+	flagProvisionalRows(ds)
+	setUnits(ds, ds.Source)
+	return ds, nil
+}
+
+// ToVintageSeries converts FRED data returned by FREDReader.ReadVintages
+// into a dataset.VintageSeries, using each row's RealtimeStart as the date
+// the value became publicly known.
+//
+// # Example Usage
+//
+//	reader := fred.NewFREDReaderWithAPIKey(nil, apiKey)
+//	data, err := reader.ReadVintages(ctx, "CPIAUCSL", start, end)
+//	if err != nil {
+//		log.Fatal(err)
+//	}
+//	cpi, err := datareader.ToVintageSeries("CPIAUCSL", data)
+//	joined, err := dataset.AsOfJoin(prices, cpi)
+func ToVintageSeries(symbol string, data *fred.ParsedData) (*dataset.VintageSeries, error) {
+	if data == nil {
+		return nil, fmt.Errorf("%w: nil FRED data", ErrUnsupportedData)
+	}
+	if len(data.RealtimeStart) != len(data.Dates) {
+		return nil, fmt.Errorf("fred: series %s has no publication dates", symbol)
+	}
+
+	series := &dataset.VintageSeries{Symbol: symbol, Source: "fred"}
+	for i := range data.Dates {
+		value, err := numparse.ParseFloat(data.Values[i])
+		if errors.Is(err, numparse.ErrMissing) || data.Values[i] == "." {
+			continue
+		}
+		if err != nil {
+			return nil, fmt.Errorf("row %d: %w", i, err)
+		}
+
+		date, err := dataset.ParseDate(data.Dates[i])
+		if err != nil {
+			return nil, fmt.Errorf("row %d: %w", i, err)
+		}
+		knownAt, err := dataset.ParseDate(data.RealtimeStart[i])
+		if err != nil {
+			return nil, fmt.Errorf("row %d: %w", i, err)
+		}
+
+		series.Vintages = append(series.Vintages, dataset.Vintage{Date: date, KnownAt: knownAt, Value: value})
+	}
+
+	return series, nil
+}
+
+// rowsToDataset converts row-oriented string data into a Dataset.
+// Columns whose non-missing values are not all numeric are skipped.
+func rowsToDataset(exact bool, symbol, source, dateColumn string, columns []string, rows []map[string]string) (*dataset.Dataset, error) {
+	dates, err := parseDates(func(i int) string { return rows[i][dateColumn] }, len(rows))
+	if err != nil {
+		return nil, err
+	}
+
+	ds := dataset.New(symbol, source, dates)
+	for _, name := range columns {
+		if name == dateColumn {
+			continue
+		}
+
+		ok, err := addParsedColumn(ds, exact, name, func(i int) string { return rows[i][name] })
+		if err != nil {
+			return nil, err
+		}
+		if !ok {
+			continue
+		}
+	}
+
+	return ds, nil
+}
+
+// stringSeriesToDataset converts parallel date/value string slices into a Dataset.
+func stringSeriesToDataset(exact bool, symbol, source string, dateStrs, valueStrs, flags []string) (*dataset.Dataset, error) {
+	dates, err := parseDates(func(i int) string { return dateStrs[i] }, len(dateStrs))
+	if err != nil {
+		return nil, err
+	}
+
+	ds := dataset.New(symbol, source, dates)
+	ok, err := addParsedColumn(ds, exact, "Value", func(i int) string { return valueStrs[i] })
+	if err != nil {
+		return nil, err
+	}
+	if !ok {
+		return nil, fmt.Errorf("%s: non-numeric values in series %s", source, symbol)
+	}
+	ds.Flags = flags
+	return ds, nil
+}
+
+// wideToDataset converts string series aligned on dates, such as merged
+// FRED series or a World Bank bulk download, into a Dataset with one
+// column per series.
+func wideToDataset(exact bool, symbol, source string, dateStrs, columns []string, values map[string][]string) (*dataset.Dataset, error) {
+	dates, err := parseDates(func(i int) string { return dateStrs[i] }, len(dateStrs))
+	if err != nil {
+		return nil, err
+	}
+
+	ds := dataset.New(symbol, source, dates)
+	for _, name := range columns {
+		column := values[name]
+		ok, err := addParsedColumn(ds, exact, name, func(i int) string { return column[i] })
+		if err != nil {
+			return nil, err
+		}
+		if !ok {
+			return nil, fmt.Errorf("%s: non-numeric values in series %s", source, name)
+		}
+	}
+	return ds, nil
+}
+
+// floatSeriesToDataset converts date strings with float values into a Dataset.
+func floatSeriesToDataset(exact bool, symbol, source string, dateStrs []string, values []float64) (*dataset.Dataset, error) {
+	dates, err := parseDates(func(i int) string { return dateStrs[i] }, len(dateStrs))
+	if err != nil {
+		return nil, err
+	}
+
+	ds := dataset.New(symbol, source, dates)
+	if err := addFloatColumn(ds, exact, "Value", append([]float64(nil), values...)); err != nil {
+		return nil, err
+	}
+	return ds, nil
+}
+
+// timeSeriesToDataset converts data of other types through the
+// sources.TimeSeries interface, without metadata. The Dataset has no
+// Source; ReadDataset sets it to the source read.
+func timeSeriesToDataset(exact bool, symbol string, ts sources.TimeSeries) (*dataset.Dataset, error) {
+	dates, err := ts.DateIndex()
+	if err != nil {
+		return nil, err
+	}
+
+	ds := dataset.New(symbol, "", dates)
+	for _, name := range ts.ColumnNames() {
+		values, err := ts.Float64Column(name)
+		if err != nil {
+			return nil, fmt.Errorf("column %s: %w", name, err)
+		}
+		if err := addFloatColumn(ds, exact, name, values); err != nil {
+			return nil, err
+		}
+	}
+	return ds, nil
+}
+
+// finraToDataset converts FINRA weekly volume into a Dataset indexed by
+// the start of each week.
+func finraToDataset(exact bool, symbol string, d *finra.ParsedData) (*dataset.Dataset, error) {
+	dates, _ := d.DateIndex()
+	ds := dataset.New(symbol, "finra", dates)
+	for _, name := range d.ColumnNames() {
+		values, err := d.Float64Column(name)
+		if err != nil {
+			return nil, err
+		}
+		if err := addFloatColumn(ds, exact, name, values); err != nil {
+			return nil, err
+		}
+	}
+	return ds, nil
+}
+
+// tiingoToDataset converts Tiingo price records into a Dataset.
+func tiingoToDataset(exact bool, symbol string, d *tiingo.ParsedData) (*dataset.Dataset, error) {
+	dates, err := parseDates(func(i int) string { return d.Dates[i] }, len(d.Dates))
+	if err != nil {
+		return nil, err
+	}
+
+	n := len(d.Prices)
+	open, high, low, closes, volume, adjClose := make([]float64, n), make([]float64, n), make([]float64, n), make([]float64, n), make([]float64, n), make([]float64, n)
+	for i, p := range d.Prices {
+		open[i], high[i], low[i], closes[i], volume[i], adjClose[i] = p.Open, p.High, p.Low, p.Close, p.Volume, p.AdjClose
+	}
+
+	ds := dataset.New(symbol, "tiingo", dates)
+	for _, c := range []dataset.Column{
+		{Name: "Open", Values: open},
+		{Name: "High", Values: high},
+		{Name: "Low", Values: low},
+		{Name: "Close", Values: closes},
+		{Name: "Volume", Values: volume},
+		{Name: "Adj Close", Values: adjClose},
+	} {
+		if err := addFloatColumn(ds, exact, c.Name, c.Values); err != nil {
+			return nil, err
+		}
+	}
+	return ds, nil
+}
+
+// twseToDataset converts typed TWSE data into a Dataset.
+func twseToDataset(exact bool, symbol string, d *twse.ParsedData) (*dataset.Dataset, error) {
+	if symbol == "" {
+		symbol = d.Symbol
+	}
+
+	ds := dataset.New(symbol, "twse", append([]time.Time(nil), d.Date...))
+	for _, c := range []dataset.Column{
+		{Name: "Open", Values: d.Open},
+		{Name: "High", Values: d.High},
+		{Name: "Low", Values: d.Low},
+		{Name: "Close", Values: d.Close},
+		{Name: "Volume", Values: intsToFloats(d.Volume)},
+		{Name: "Transactions", Values: intsToFloats(d.Transactions)},
+		{Name: "Change", Values: d.Change},
+	} {
+		if err := addFloatColumn(ds, exact, c.Name, append([]float64(nil), c.Values...)); err != nil {
+			return nil, err
+		}
+	}
+	ds.Flags = d.Flags
+	if d.Name != "" {
+		ds.Meta["name"] = d.Name
+	}
+	if d.EnglishName != "" {
+		ds.Meta["english_name"] = d.EnglishName
+	}
+	return ds, nil
+}
+
+// bundleToDataset converts bundled data into a Dataset labelled with the
+// source and symbol the series was originally fetched with.
+func bundleToDataset(exact bool, d *bundle.ParsedData) (*dataset.Dataset, error) {
+	ds := dataset.New(d.Symbol, d.Source, append([]time.Time(nil), d.Dates...))
+	for _, c := range d.Columns {
+		if err := addFloatColumn(ds, exact, c.Name, append([]float64(nil), c.Values...)); err != nil {
+			return nil, err
+		}
+	}
+	return ds, nil
+}
+
+// parseDates parses n date strings obtained from get.
+func parseDates(get func(i int) string, n int) ([]time.Time, error) {
+	dates := make([]time.Time, n)
+	for i := 0; i < n; i++ {
+		t, err := dataset.ParseDate(get(i))
+		if err != nil {
+			return nil, fmt.Errorf("row %d: %w", i, err)
+		}
+		dates[i] = t
+	}
+	return dates, nil
+}
+
+// addParsedColumn parses the numeric strings obtained from get and adds
+// them to ds as a column, with exact decimal values when exact is set.
+// It returns false without adding the column if any value is not numeric.
+func addParsedColumn(ds *dataset.Dataset, exact bool, name string, get func(i int) string) (bool, error) {
+	if exact {
+		rats, ok := parseExactColumn(get, ds.Len())
+		if !ok {
+			return false, nil
+		}
+		return true, ds.AddExactColumn(name, rats)
+	}
+
+	values, ok := parseNumericColumn(get, ds.Len())
+	if !ok {
+		return false, nil
+	}
+	return true, ds.AddColumn(name, values)
+}
+
+// addFloatColumn adds float64 values to ds as a column. When exact is set,
+// exact values are derived from the shortest round-tripping decimal of
+// each float; NaN becomes a missing (nil) exact value.
+func addFloatColumn(ds *dataset.Dataset, exact bool, name string, values []float64) error {
+	if !exact {
+		return ds.AddColumn(name, values)
+	}
+
+	rats := make([]*big.Rat, len(values))
+	for i, v := range values {
+		if math.IsNaN(v) || math.IsInf(v, 0) {
+			continue
+		}
+		rats[i], _ = new(big.Rat).SetString(strconv.FormatFloat(v, 'g', -1, 64))
+	}
+	return ds.AddExactColumn(name, rats)
+}
+
+// parseExactColumn parses n numeric strings obtained from get into exact
+// decimal values. Missing values are nil. It returns false if any value
+// is not numeric.
+func parseExactColumn(get func(i int) string, n int) ([]*big.Rat, bool) {
+	rats := make([]*big.Rat, n)
+	for i := 0; i < n; i++ {
+		s := get(i)
+		if s == "." {
+			continue
+		}
+
+		r, err := numparse.ParseRat(s)
+		if errors.Is(err, numparse.ErrMissing) {
+			continue
+		}
+		if err != nil {
+			return nil, false
+		}
+		rats[i] = r
+	}
+	return rats, true
+}
+
+// parseNumericColumn parses n numeric strings obtained from get.
+// Missing values become NaN. It returns false if any value is not numeric.
+func parseNumericColumn(get func(i int) string, n int) ([]float64, bool) {
+	values := make([]float64, n)
+	for i := 0; i < n; i++ {
+		s := get(i)
+		if s == "." {
+			values[i] = math.NaN()
+			continue
+		}
+
+		f, err := numparse.ParseFloat(s)
+		if errors.Is(err, numparse.ErrMissing) {
+			values[i] = math.NaN()
+			continue
+		}
+		if err != nil {
+			return nil, false
+		}
+		values[i] = f
+	}
+	return values, true
+}
+
+// without returns columns with the named column removed.
+func without(columns []string, name string) []string {
+	out := make([]string, 0, len(columns))
+	for _, c := range columns {
+		if c != name {
+			out = append(out, c)
+		}
+	}
+	return out
+}
+
+// intsToFloats converts an int64 slice to float64.
+func intsToFloats(in []int64) []float64 {
+	out := make([]float64, len(in))
+	for i, v := range in {
+		out[i] = float64(v)
+	}
+	return out
+}
@@ -0,0 +1,386 @@
+package datareader_test
+
+import (
+	"errors"
+	"math"
+	"math/big"
+	"testing"
+	"time"
+
+	datareader "github.com/julianshen/gonp-datareader"
+	"github.com/julianshen/gonp-datareader/dataset"
+	"github.com/julianshen/gonp-datareader/sources/finmind"
+	"github.com/julianshen/gonp-datareader/sources/finra"
+	"github.com/julianshen/gonp-datareader/sources/fred"
+	"github.com/julianshen/gonp-datareader/sources/tiingo"
+	"github.com/julianshen/gonp-datareader/sources/twse"
+	"github.com/julianshen/gonp-datareader/sources/worldbank"
+	"github.com/julianshen/gonp-datareader/sources/yahoo"
+)
+
+func TestToDataset_Yahoo(t *testing.T) {
+	data := &yahoo.ParsedData{
+		Columns: []string{"Date", "Open", "Close", "Volume"},
+		Rows: []map[string]string{
+			{"Date": "2024-01-02", "Open": "185.5", "Close": "186.0", "Volume": "1000"},
+			{"Date": "2024-01-03", "Open": "null", "Close": "184.2", "Volume": "2000"},
+		},
+	}
+
+	ds, err := datareader.ToDataset("AAPL", data)
+	if err != nil {
+		t.Fatalf("ToDataset() error = %v", err)
+	}
+
+	if ds.Source != "yahoo" || ds.Symbol != "AAPL" || ds.Len() != 2 {
+		t.Errorf("unexpected dataset identity: %s/%s len %d", ds.Source, ds.Symbol, ds.Len())
+	}
+
+	open, _ := ds.Column("Open")
+	if open[0] != 185.5 || !math.IsNaN(open[1]) {
+		t.Errorf("Open = %v, want [185.5 NaN]", open)
+	}
+
+	if !ds.Dates[1].Equal(time.Date(2024, 1, 3, 0, 0, 0, 0, time.UTC)) {
+		t.Errorf("Dates[1] = %v", ds.Dates[1])
+	}
+}
+
+func TestToDataset_FRED(t *testing.T) {
+	data := &fred.ParsedData{
+		Dates:  []string{"2023-01-01", "2023-04-01"},
+		Values: []string{"26400.1", "."},
+	}
+
+	ds, err := datareader.ToDataset("GDP", data)
+	if err != nil {
+		t.Fatalf("ToDataset() error = %v", err)
+	}
+
+	values, ok := ds.Column("Value")
+	if !ok || values[0] != 26400.1 || !math.IsNaN(values[1]) {
+		t.Errorf("Value = %v", values)
+	}
+}
+
+func TestToDataset_FREDMultiSeries(t *testing.T) {
+	data := fred.Merge([]string{"GDP", "UNRATE"}, map[string]*fred.ParsedData{
+		"GDP":    {Dates: []string{"2023-01-01"}, Values: []string{"26400.1"}},
+		"UNRATE": {Dates: []string{"2023-01-01", "2023-02-01"}, Values: []string{"3.4", "3.6"}},
+	})
+
+	ds, err := datareader.ToDataset("macro", data)
+	if err != nil {
+		t.Fatalf("ToDataset() error = %v", err)
+	}
+
+	gdp, ok := ds.Column("GDP")
+	if !ok || gdp[0] != 26400.1 || !math.IsNaN(gdp[1]) {
+		t.Errorf("GDP = %v", gdp)
+	}
+	if unrate, ok := ds.Column("UNRATE"); !ok || unrate[1] != 3.6 {
+		t.Errorf("UNRATE = %v", unrate)
+	}
+}
+
+func TestToDataset_WorldBankBulk(t *testing.T) {
+	data := &worldbank.BulkData{
+		Years:     []string{"2020", "2021"},
+		Countries: []string{"USA", "JPN"},
+		Values:    map[string][]string{"USA": {"21.3e12", "23.6e12"}, "JPN": {"5.0e12", ""}},
+	}
+
+	ds, err := datareader.ToDataset("NY.GDP.MKTP.CD", data)
+	if err != nil {
+		t.Fatalf("ToDataset() error = %v", err)
+	}
+	if jpn, ok := ds.Column("JPN"); !ok || jpn[0] != 5e12 || !math.IsNaN(jpn[1]) {
+		t.Errorf("JPN = %v", jpn)
+	}
+	if ds.Dates[1].Year() != 2021 {
+		t.Errorf("Dates = %v", ds.Dates)
+	}
+}
+
+func TestToDataset_WorldBankFlags(t *testing.T) {
+	data := &worldbank.ParsedData{
+		Dates:  []string{"2021", "2022"},
+		Values: []string{"1", "2"},
+		Flags:  []string{"", "E"},
+	}
+
+	ds, err := datareader.ToDataset("USA/NY.GDP.MKTP.CD", data)
+	if err != nil {
+		t.Fatalf("ToDataset() error = %v", err)
+	}
+	if len(ds.Flags) != 2 || ds.Flags[1] != "E" {
+		t.Errorf("Flags = %v", ds.Flags)
+	}
+	if ds.Dates[0].Year() != 2021 {
+		t.Errorf("Dates[0] = %v", ds.Dates[0])
+	}
+}
+
+func TestToDataset_Tiingo(t *testing.T) {
+	data := &tiingo.ParsedData{
+		Dates:  []string{"2024-01-02"},
+		Prices: []tiingo.PriceData{{Open: 1, High: 2, Low: 0.5, Close: 1.5, Volume: 100}},
+	}
+
+	ds, err := datareader.ToDataset("AAPL", data)
+	if err != nil {
+		t.Fatalf("ToDataset() error = %v", err)
+	}
+	if vol, _ := ds.Column("Volume"); vol[0] != 100 {
+		t.Errorf("Volume = %v", vol)
+	}
+}
+
+func TestToDataset_TWSE(t *testing.T) {
+	data := &twse.ParsedData{
+		Symbol:       "2330",
+		Name:         "台積電",
+		Date:         []time.Time{time.Date(2025, 10, 31, 0, 0, 0, 0, time.UTC)},
+		Open:         []float64{1},
+		High:         []float64{1},
+		Low:          []float64{1},
+		Close:        []float64{1},
+		Volume:       []int64{10},
+		Transactions: []int64{2},
+		Change:       []float64{0.5},
+	}
+
+	ds, err := datareader.ToDataset("", data)
+	if err != nil {
+		t.Fatalf("ToDataset() error = %v", err)
+	}
+	if ds.Symbol != "2330" || ds.Meta["name"] != "台積電" {
+		t.Errorf("unexpected identity: %s %v", ds.Symbol, ds.Meta)
+	}
+}
+
+func TestToDataset_FINRA(t *testing.T) {
+	data := &finra.ParsedData{
+		Symbol: "AAPL",
+		Weeks: []finra.WeeklyVolume{
+			{WeekStart: time.Date(2024, 1, 8, 0, 0, 0, 0, time.UTC), ATSShares: 25000000, NonATSShares: 60000000},
+		},
+	}
+
+	ds, err := datareader.ToDataset("AAPL", data)
+	if err != nil {
+		t.Fatalf("ToDataset() error = %v", err)
+	}
+	if got := ds.ColumnNames(); len(got) != 4 || got[0] != "ATS Shares" {
+		t.Errorf("ColumnNames() = %v", got)
+	}
+	if shares, _ := ds.Column("Non-ATS Shares"); shares[0] != 60000000 {
+		t.Errorf("Non-ATS Shares = %v", shares)
+	}
+}
+
+func TestToDataset_FinMindSkipsIdentifiers(t *testing.T) {
+	data := &finmind.ParsedData{
+		Columns: []string{"date", "stock_id", "close"},
+		Rows:    []map[string]string{{"date": "2024-01-02", "stock_id": "2330", "close": "590"}},
+	}
+
+	ds, err := datareader.ToDataset("2330", data)
+	if err != nil {
+		t.Fatalf("ToDataset() error = %v", err)
+	}
+	if _, ok := ds.Column("stock_id"); ok {
+		t.Error("stock_id should not be converted to a numeric column")
+	}
+	if names := ds.ColumnNames(); len(names) != 1 || names[0] != "close" {
+		t.Errorf("ColumnNames() = %v", names)
+	}
+}
+
+func TestToDataset_Units(t *testing.T) {
+	rows := []map[string]string{{"Date": "2024-01-02", "Close": "1", "Volume": "10"}}
+	columns := []string{"Date", "Close", "Volume"}
+
+	tests := []struct {
+		name      string
+		symbol    string
+		data      interface{}
+		column    string
+		wantUnit  string
+		wantShare bool
+	}{
+		{"yahoo US", "AAPL", &yahoo.ParsedData{Columns: columns, Rows: rows}, "Close", "USD", true},
+		{"yahoo Taiwan", "2330.TW", &yahoo.ParsedData{Columns: columns, Rows: rows}, "Close", "TWD", true},
+		{"yahoo index", "^GSPC", &yahoo.ParsedData{Columns: columns, Rows: rows}, "Close", dataset.UnitIndex, true},
+		{"yahoo currency pair", "USDTWD=X", &yahoo.ParsedData{Columns: columns, Rows: rows}, "Close", "TWD", true},
+		{"twse", "2330", &twse.ParsedData{Date: []time.Time{time.Date(2024, 1, 2, 0, 0, 0, 0, time.UTC)}, Close: []float64{600}, Open: []float64{1}, High: []float64{1}, Low: []float64{1}, Change: []float64{1}, Volume: []int64{1}, Transactions: []int64{1}}, "Close", "TWD", true},
+		{"fred", "UNRATE", &fred.ParsedData{Dates: []string{"2024-01-01"}, Values: []string{"3.7"}, Meta: map[string]string{dataset.MetaUnit: "Percent"}}, "Value", "Percent", false},
+		{"unknown", "UNRATE", &fred.ParsedData{Dates: []string{"2024-01-01"}, Values: []string{"3.7"}}, "Value", "", false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ds, err := datareader.ToDataset(tt.symbol, tt.data)
+			if err != nil {
+				t.Fatalf("ToDataset() error = %v", err)
+			}
+			if got := ds.Unit(tt.column); got != tt.wantUnit {
+				t.Errorf("Unit(%s) = %q, want %q", tt.column, got, tt.wantUnit)
+			}
+			if tt.wantShare && ds.Unit("Volume") != dataset.UnitShares {
+				t.Errorf("Unit(Volume) = %q, want %q", ds.Unit("Volume"), dataset.UnitShares)
+			}
+		})
+	}
+}
+
+func TestToDataset_CopiesMeta(t *testing.T) {
+	data := &fred.ParsedData{
+		Dates:  []string{"2023-01-01"},
+		Values: []string{"1.5"},
+		Meta:   map[string]string{"stale": "true", "cached_at": "2024-01-02T03:04:05Z"},
+	}
+
+	ds, err := datareader.ToDataset("GDP", data)
+	if err != nil {
+		t.Fatalf("ToDataset() error = %v", err)
+	}
+
+	if ds.Meta["stale"] != "true" || ds.Meta["cached_at"] != "2024-01-02T03:04:05Z" {
+		t.Errorf("Meta = %v", ds.Meta)
+	}
+}
+
+func TestToDatasetMode_Decimal(t *testing.T) {
+	tests := []struct {
+		name   string
+		data   interface{}
+		column string
+		want   []string
+	}{
+		{
+			name: "text source keeps every digit",
+			data: &yahoo.ParsedData{
+				Columns: []string{"Date", "Close"},
+				Rows: []map[string]string{
+					{"Date": "2024-01-02", "Close": "0.123456789012345678"},
+					{"Date": "2024-01-03", "Close": "null"},
+				},
+			},
+			column: "Close",
+			want:   []string{"0.123456789012345678", ""},
+		},
+		{
+			name: "float source round-trips decimals",
+			data: &twse.ParsedData{
+				Symbol:       "2330",
+				Date:         []time.Time{time.Date(2024, 1, 2, 0, 0, 0, 0, time.UTC)},
+				Open:         []float64{593.1},
+				High:         []float64{595},
+				Low:          []float64{589},
+				Close:        []float64{0.1 + 0.2},
+				Volume:       []int64{1},
+				Transactions: []int64{1},
+				Change:       []float64{-1.5},
+			},
+			column: "Open",
+			want:   []string{"593.1"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ds, err := datareader.ToDatasetMode("SYM", tt.data, datareader.NumericDecimal)
+			if err != nil {
+				t.Fatalf("ToDatasetMode() error = %v", err)
+			}
+
+			exact, ok := ds.ExactColumn(tt.column)
+			if !ok {
+				t.Fatalf("expected exact values for %s", tt.column)
+			}
+			for i, want := range tt.want {
+				if want == "" {
+					if exact[i] != nil {
+						t.Errorf("exact[%d] = %v, want nil", i, exact[i])
+					}
+					continue
+				}
+				w, _ := new(big.Rat).SetString(want)
+				if exact[i] == nil || exact[i].Cmp(w) != 0 {
+					t.Errorf("exact[%d] = %v, want %s", i, exact[i], want)
+				}
+			}
+		})
+	}
+}
+
+func TestToDataset_FloatModeHasNoExactValues(t *testing.T) {
+	data := &fred.ParsedData{Dates: []string{"2023-01-01"}, Values: []string{"1.5"}}
+
+	ds, err := datareader.ToDataset("GDP", data)
+	if err != nil {
+		t.Fatalf("ToDataset() error = %v", err)
+	}
+	if _, ok := ds.ExactColumn("Value"); ok {
+		t.Error("expected no exact values in float64 mode")
+	}
+}
+
+func TestToVintageSeries(t *testing.T) {
+	data := &fred.ParsedData{
+		Dates:         []string{"2024-01-01", "2024-01-01"},
+		Values:        []string{"308.4", "308.6"},
+		RealtimeStart: []string{"2024-02-13", "2024-03-12"},
+		RealtimeEnd:   []string{"2024-03-11", "9999-12-31"},
+	}
+
+	series, err := datareader.ToVintageSeries("CPIAUCSL", data)
+	if err != nil {
+		t.Fatalf("ToVintageSeries() error = %v", err)
+	}
+
+	if len(series.Vintages) != 2 {
+		t.Fatalf("expected 2 vintages, got %d", len(series.Vintages))
+	}
+	v, ok := series.AsOf(time.Date(2024, 3, 1, 0, 0, 0, 0, time.UTC))
+	if !ok || v.Value != 308.4 {
+		t.Errorf("AsOf(2024-03-01) = %v, %v", v, ok)
+	}
+
+	if _, err := datareader.ToVintageSeries("X", &fred.ParsedData{Dates: []string{"2024-01-01"}, Values: []string{"1"}}); err == nil {
+		t.Error("expected error without publication dates")
+	}
+}
+
+func TestToDataset_Unsupported(t *testing.T) {
+	_, err := datareader.ToDataset("X", "not data")
+	if !errors.Is(err, datareader.ErrUnsupportedData) {
+		t.Errorf("expected ErrUnsupportedData, got %v", err)
+	}
+}
+
+func TestResample(t *testing.T) {
+	data := &yahoo.ParsedData{
+		Columns: []string{"Date", "Open", "High", "Low", "Close", "Volume"},
+		Rows: []map[string]string{
+			{"Date": "2024-01-30", "Open": "10", "High": "12", "Low": "9", "Close": "11", "Volume": "100"},
+			{"Date": "2024-01-31", "Open": "11", "High": "13", "Low": "10", "Close": "12", "Volume": "200"},
+			{"Date": "2024-02-01", "Open": "12", "High": "14", "Low": "11", "Close": "13", "Volume": "300"},
+		},
+	}
+
+	monthly, err := datareader.Resample("AAPL", data, dataset.FrequencyMonthly)
+	if err != nil {
+		t.Fatalf("Resample() error = %v", err)
+	}
+	if monthly.Len() != 2 || monthly.Symbol != "AAPL" {
+		t.Fatalf("Resample() = %s with %d rows, want AAPL with 2", monthly.Symbol, monthly.Len())
+	}
+	if row := monthly.Row(0); row["Open"] != 10 || row["High"] != 13 || row["Low"] != 9 || row["Close"] != 12 || row["Volume"] != 300 {
+		t.Errorf("January bar = %v", row)
+	}
+	if monthly.Unit("Close") != "USD" {
+		t.Errorf("Close unit = %q, want USD", monthly.Unit("Close"))
+	}
+}
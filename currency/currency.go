@@ -0,0 +1,346 @@
+// Package currency converts price and indicator series between
+// currencies, so holdings quoted in different currencies (e.g., TWSE
+// stocks in TWD and US stocks in USD) can be compared.
+//
+// Exchange rates come from a RateSource: ECB reads the European Central
+// Bank's euro reference rates and derives cross rates from them, Yahoo
+// reads Yahoo Finance currency pairs ("USDTWD=X"), and Chain tries
+// several sources in turn. The ECB publishes about 30 currencies, not
+// including TWD; use Yahoo, or Chain{ECB{}, Yahoo{}}, for the others.
+//
+// Each observation is converted at the latest rate dated on or before it,
+// so prices on days without a fixing (such as exchange holidays of the
+// rate source) use the previous fixing.
+//
+// # Example Usage
+//
+//	tsmc, _ := datareader.ReadDataset(ctx, "2330.TW", "yahoo", start, end, nil) // in TWD
+//	conv := currency.NewConverter(currency.Chain{currency.ECB{}, currency.Yahoo{}})
+//	usd, err := conv.Convert(ctx, tsmc, "TWD", "USD")
+//	if err != nil {
+//		log.Fatal(err)
+//	}
+//	fmt.Println(usd.Meta["currency"]) // "USD"
+package currency
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math"
+	"sort"
+	"strings"
+	"time"
+
+	datareader "github.com/julianshen/gonp-datareader"
+	"github.com/julianshen/gonp-datareader/dataset"
+)
+
+var (
+	// ErrInvalidCurrency is returned for codes that are not three
+	// letters.
+	ErrInvalidCurrency = errors.New("invalid currency code")
+	// ErrNoRates is returned when a rate source has no rates of a
+	// currency pair over the requested period.
+	ErrNoRates = errors.New("no exchange rates")
+)
+
+// RateColumn is the column of exchange rates in the datasets returned by
+// rate sources.
+const RateColumn = "Rate"
+
+// Lookback is how far before the first observation Converter fetches
+// rates, so an observation on a day without a fixing finds the previous
+// one.
+const Lookback = 7 * 24 * time.Hour
+
+// RateSource fetches exchange rates.
+type RateSource interface {
+	// Rates returns the units of quote per unit of base on each date
+	// between start and end, in a dataset holding RateColumn.
+	Rates(ctx context.Context, base, quote string, start, end time.Time) (*dataset.Dataset, error)
+}
+
+// ECB reads rates from the European Central Bank's euro reference rates
+// (the "ecb" source), computing cross rates as the ratio of two euro
+// rates on the dates both are published.
+type ECB struct {
+	// Options configures the "ecb" reader; nil uses the defaults
+	Options *datareader.Options
+}
+
+// Rates implements RateSource.
+func (e ECB) Rates(ctx context.Context, base, quote string, start, end time.Time) (*dataset.Dataset, error) {
+	base, quote, err := pair(base, quote)
+	if err != nil {
+		return nil, err
+	}
+
+	// Units of each currency per euro; nil for the euro itself
+	perEUR := make([]*dataset.Dataset, 2)
+	for i, code := range []string{base, quote} {
+		if code == "EUR" {
+			continue
+		}
+		ds, err := datareader.ReadDataset(ctx, code, "ecb", start, end, e.Options)
+		if err != nil {
+			return nil, fmt.Errorf("ECB rates of %s: %w", code, err)
+		}
+		perEUR[i] = ds
+	}
+
+	var dates []time.Time
+	var rates []float64
+	switch {
+	case perEUR[0] == nil:
+		// EUR/quote is published as is
+		dates = perEUR[1].Dates
+		rates, _ = perEUR[1].Column(RateColumn)
+	case perEUR[1] == nil:
+		dates = perEUR[0].Dates
+		values, _ := perEUR[0].Column(RateColumn)
+		for _, v := range values {
+			rates = append(rates, 1/v)
+		}
+	default:
+		baseRates, _ := perEUR[0].Column(RateColumn)
+		quoteRates, _ := perEUR[1].Column(RateColumn)
+		for i, t := range perEUR[0].Dates {
+			if j := perEUR[1].IndexOf(t); j >= 0 {
+				dates = append(dates, t)
+				rates = append(rates, quoteRates[j]/baseRates[i])
+			}
+		}
+	}
+	return rateDataset(base, quote, "ecb", dates, rates)
+}
+
+// Yahoo reads rates from Yahoo Finance currency pairs (e.g., "USDTWD=X"),
+// using the daily close.
+type Yahoo struct {
+	// Options configures the "yahoo" reader; nil uses the defaults
+	Options *datareader.Options
+}
+
+// Rates implements RateSource.
+func (y Yahoo) Rates(ctx context.Context, base, quote string, start, end time.Time) (*dataset.Dataset, error) {
+	base, quote, err := pair(base, quote)
+	if err != nil {
+		return nil, err
+	}
+
+	ds, err := datareader.ReadDataset(ctx, base+quote+"=X", "yahoo", start, end, y.Options)
+	if err != nil {
+		return nil, fmt.Errorf("Yahoo rates of %s%s: %w", base, quote, err)
+	}
+	closes, ok := ds.Column("Close")
+	if !ok {
+		return nil, fmt.Errorf("%w: Yahoo %s%s has no Close column", ErrNoRates, base, quote)
+	}
+	return rateDataset(base, quote, "yahoo", ds.Dates, closes)
+}
+
+// Chain is a RateSource trying each source in order until one returns
+// rates.
+type Chain []RateSource
+
+// Rates implements RateSource. It returns the errors of every source when
+// all fail.
+func (c Chain) Rates(ctx context.Context, base, quote string, start, end time.Time) (*dataset.Dataset, error) {
+	var errs []error
+	for _, source := range c {
+		ds, err := source.Rates(ctx, base, quote, start, end)
+		if err == nil {
+			return ds, nil
+		}
+		if ctx.Err() != nil {
+			return nil, err
+		}
+		errs = append(errs, err)
+	}
+	if len(errs) == 0 {
+		return nil, fmt.Errorf("%w: no rate sources", ErrNoRates)
+	}
+	return nil, errors.Join(errs...)
+}
+
+// pair validates and upper-cases a currency pair.
+func pair(base, quote string) (string, string, error) {
+	base, err := code(base)
+	if err != nil {
+		return "", "", err
+	}
+	quote, err = code(quote)
+	if err != nil {
+		return "", "", err
+	}
+	return base, quote, nil
+}
+
+// code validates and upper-cases a currency code.
+func code(s string) (string, error) {
+	c := strings.ToUpper(strings.TrimSpace(s))
+	if len(c) != 3 {
+		return "", fmt.Errorf("%w: %q", ErrInvalidCurrency, s)
+	}
+	for _, r := range c {
+		if r < 'A' || r > 'Z' {
+			return "", fmt.Errorf("%w: %q", ErrInvalidCurrency, s)
+		}
+	}
+	return c, nil
+}
+
+// rateDataset builds the dataset of a rate source, dropping missing
+// rates. It returns ErrNoRates when none are left.
+func rateDataset(base, quote, source string, dates []time.Time, rates []float64) (*dataset.Dataset, error) {
+	var keptDates []time.Time
+	var kept []float64
+	for i, r := range rates {
+		if !math.IsNaN(r) && !math.IsInf(r, 0) && r > 0 {
+			keptDates = append(keptDates, dates[i])
+			kept = append(kept, r)
+		}
+	}
+	if len(kept) == 0 {
+		return nil, fmt.Errorf("%w: %s/%s from %s", ErrNoRates, base, quote, source)
+	}
+
+	ds := dataset.New(base+quote, source, keptDates)
+	if err := ds.AddColumn(RateColumn, kept); err != nil {
+		return nil, err
+	}
+	ds.Meta["currency_base"] = base
+	ds.Meta["currency_quote"] = quote
+	return ds, nil
+}
+
+// Options configures Convert.
+type Options struct {
+	// Columns lists the columns to convert. Default: every column except
+	// quantities, whose names end in "Volume", "Shares" or "Trades"
+	Columns []string
+	// MaxAge is the oldest a rate may be relative to the observation it
+	// converts; older rates give NaN. Zero means no limit
+	MaxAge time.Duration
+}
+
+// Converter converts datasets with the rates of a RateSource.
+type Converter struct {
+	source RateSource
+	opts   Options
+}
+
+// NewConverter returns a Converter reading rates from source.
+func NewConverter(source RateSource) *Converter {
+	return &Converter{source: source}
+}
+
+// SetOptions sets the options of Convert.
+func (c *Converter) SetOptions(opts Options) {
+	c.opts = opts
+}
+
+// Convert returns a copy of ds with its monetary columns converted from
+// currency from to currency to, fetching the rates over the dates of ds.
+// Meta["currency"] of the result is set to to.
+func (c *Converter) Convert(ctx context.Context, ds *dataset.Dataset, from, to string) (*dataset.Dataset, error) {
+	from, to, err := pair(from, to)
+	if err != nil {
+		return nil, err
+	}
+	if ds == nil || ds.Len() == 0 || from == to {
+		out := ds.Clone()
+		if out != nil {
+			out.Meta[dataset.MetaCurrency] = to
+		}
+		return out, nil
+	}
+
+	start, end := ds.Dates[0], ds.Dates[0]
+	for _, t := range ds.Dates {
+		if t.Before(start) {
+			start = t
+		}
+		if t.After(end) {
+			end = t
+		}
+	}
+	rates, err := c.source.Rates(ctx, from, to, start.Add(-Lookback), end)
+	if err != nil {
+		return nil, err
+	}
+	return Convert(ds, rates, c.opts)
+}
+
+// Convert returns a copy of ds with its monetary columns multiplied by the
+// latest rate of rates dated on or before each observation; observations
+// before the first rate, or with a rate older than opts.MaxAge, are NaN.
+// Converted columns lose their exact decimal values. With rates returned
+// by the rate sources of this package, converted columns have the quote
+// currency as Unit, Meta["currency"] is set to it and
+// Meta["currency_rates"] to the rates' source.
+func Convert(ds *dataset.Dataset, rates *dataset.Dataset, opts Options) (*dataset.Dataset, error) {
+	values, ok := rates.Column(RateColumn)
+	if !ok {
+		return nil, fmt.Errorf("%w: rates have no %s column", ErrNoRates, RateColumn)
+	}
+
+	// Rates in date order
+	idx := make([]int, len(rates.Dates))
+	for i := range idx {
+		idx[i] = i
+	}
+	sort.SliceStable(idx, func(a, b int) bool { return rates.Dates[idx[a]].Before(rates.Dates[idx[b]]) })
+
+	// The rate of each observation
+	factor := make([]float64, ds.Len())
+	for i, t := range ds.Dates {
+		// Index in idx of the first rate after t
+		n := sort.Search(len(idx), func(k int) bool { return rates.Dates[idx[k]].After(t) })
+		if n == 0 || (opts.MaxAge > 0 && t.Sub(rates.Dates[idx[n-1]]) > opts.MaxAge) {
+			factor[i] = math.NaN()
+			continue
+		}
+		factor[i] = values[idx[n-1]]
+	}
+
+	out := ds.Clone()
+	for c := range out.Columns {
+		col := &out.Columns[c]
+		if !converts(col.Name, opts.Columns) {
+			continue
+		}
+		for i := range col.Values {
+			col.Values[i] *= factor[i]
+		}
+		col.Exact = nil
+		if quote := rates.Meta["currency_quote"]; quote != "" {
+			col.Unit = quote
+		}
+	}
+	if quote := rates.Meta["currency_quote"]; quote != "" {
+		out.Meta[dataset.MetaCurrency] = quote
+		out.Meta["currency_rates"] = rates.Source
+	}
+	return out, nil
+}
+
+// converts reports whether the column name is converted: it is in
+// columns, or columns is empty and name is not a quantity.
+func converts(name string, columns []string) bool {
+	if len(columns) > 0 {
+		for _, c := range columns {
+			if c == name {
+				return true
+			}
+		}
+		return false
+	}
+	for _, quantity := range []string{"Volume", "Shares", "Trades"} {
+		if strings.HasSuffix(name, quantity) {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,172 @@
+package currency_test
+
+import (
+	"context"
+	"errors"
+	"math"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	datareader "github.com/julianshen/gonp-datareader"
+	"github.com/julianshen/gonp-datareader/currency"
+	"github.com/julianshen/gonp-datareader/dataset"
+)
+
+func day(d int) time.Time {
+	return time.Date(2024, 1, d, 0, 0, 0, 0, time.UTC)
+}
+
+// fixedRates returns the same rates for every pair.
+type fixedRates struct {
+	dates []time.Time
+	rates []float64
+	err   error
+}
+
+func (f fixedRates) Rates(ctx context.Context, base, quote string, start, end time.Time) (*dataset.Dataset, error) {
+	if f.err != nil {
+		return nil, f.err
+	}
+	ds := dataset.New(base+quote, "fixed", f.dates)
+	ds.AddColumn(currency.RateColumn, f.rates)
+	ds.Meta["currency_quote"] = quote
+	return ds, nil
+}
+
+func TestConverter_Convert(t *testing.T) {
+	prices := dataset.New("2330", "twse", []time.Time{day(2), day(3), day(4), day(8)})
+	prices.AddColumn("Close", []float64{600, 610, 620, 630})
+	prices.AddColumn("Volume", []float64{10, 20, 30, 40})
+
+	// No fixing on Jan 3
+	rates := fixedRates{dates: []time.Time{day(1), day(2), day(4)}, rates: []float64{0.03, 0.032, 0.031}}
+	conv := currency.NewConverter(rates)
+	got, err := conv.Convert(context.Background(), prices, "twd", "usd")
+	if err != nil {
+		t.Fatalf("Convert() error = %v", err)
+	}
+
+	closes, _ := got.Column("Close")
+	want := []float64{600 * 0.032, 610 * 0.032, 620 * 0.031, 630 * 0.031}
+	for i := range want {
+		if math.Abs(closes[i]-want[i]) > 1e-9 {
+			t.Errorf("Close[%d] = %v, want %v", i, closes[i], want[i])
+		}
+	}
+	if got.Unit("Close") != "USD" || got.Unit("Volume") != "" {
+		t.Errorf("units = %q, %q; want Close in USD", got.Unit("Close"), got.Unit("Volume"))
+	}
+	if volume, _ := got.Column("Volume"); volume[0] != 10 {
+		t.Errorf("Volume = %v, want unconverted", volume)
+	}
+	if got.Meta["currency"] != "USD" {
+		t.Errorf(`Meta["currency"] = %q, want USD`, got.Meta["currency"])
+	}
+	if original, _ := prices.Column("Close"); original[0] != 600 {
+		t.Errorf("input modified: Close = %v", original)
+	}
+
+	// Rates older than MaxAge are not used
+	conv.SetOptions(currency.Options{MaxAge: 72 * time.Hour})
+	got, err = conv.Convert(context.Background(), prices, "TWD", "USD")
+	if err != nil {
+		t.Fatalf("Convert() error = %v", err)
+	}
+	if closes, _ := got.Column("Close"); !math.IsNaN(closes[3]) || math.IsNaN(closes[2]) {
+		t.Errorf("Close = %v, want NaN only on Jan 8", closes)
+	}
+
+	if _, err := conv.Convert(context.Background(), prices, "TW", "USD"); !errors.Is(err, currency.ErrInvalidCurrency) {
+		t.Errorf("Convert(TW) error = %v, want ErrInvalidCurrency", err)
+	}
+}
+
+func TestConvert_BeforeFirstRate(t *testing.T) {
+	prices := dataset.New("X", "", []time.Time{day(1), day(2)})
+	prices.AddColumn("Value", []float64{1, 2})
+	rates := dataset.New("USDEUR", "", []time.Time{day(2)})
+	rates.AddColumn(currency.RateColumn, []float64{0.5})
+
+	got, err := currency.Convert(prices, rates, currency.Options{})
+	if err != nil {
+		t.Fatalf("Convert() error = %v", err)
+	}
+	if values, _ := got.Column("Value"); !math.IsNaN(values[0]) || values[1] != 1 {
+		t.Errorf("Value = %v, want [NaN 1]", values)
+	}
+}
+
+func TestChain(t *testing.T) {
+	errDown := errors.New("down")
+	chain := currency.Chain{
+		fixedRates{err: errDown},
+		fixedRates{dates: []time.Time{day(2)}, rates: []float64{2}},
+	}
+	ds, err := chain.Rates(context.Background(), "USD", "TWD", day(1), day(3))
+	if err != nil || ds.Len() != 1 {
+		t.Fatalf("Rates() = %v, %v; want the second source's rates", ds, err)
+	}
+
+	_, err = currency.Chain{fixedRates{err: errDown}}.Rates(context.Background(), "USD", "TWD", day(1), day(3))
+	if !errors.Is(err, errDown) {
+		t.Errorf("Rates() error = %v, want %v", err, errDown)
+	}
+}
+
+func TestECB_Rates(t *testing.T) {
+	perEUR := map[string]string{
+		"USD": "2024-01-02,1.0956\n2024-01-03,1.0919\n",
+		"JPY": "2024-01-02,155.52\n",
+	}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		currencyCode := strings.Split(strings.TrimPrefix(r.URL.Path, "/D."), ".")[0]
+		rows, ok := perEUR[currencyCode]
+		if !ok {
+			http.Error(w, "No results found", http.StatusNotFound)
+			return
+		}
+		w.Header().Set("Content-Type", "application/vnd.sdmx.data+csv; version=1.0.0")
+		w.Write([]byte("KEY,TIME_PERIOD,OBS_VALUE\n"))
+		for _, row := range strings.Split(strings.TrimSpace(rows), "\n") {
+			w.Write([]byte("EXR.D." + currencyCode + ".EUR.SP00.A," + row + "\n"))
+		}
+	}))
+	defer server.Close()
+
+	ecb := currency.ECB{Options: &datareader.Options{BaseURLOverrides: map[string]string{"ecb": server.URL}}}
+	ctx := context.Background()
+
+	tests := []struct {
+		base, quote string
+		want        []float64
+	}{
+		{"EUR", "USD", []float64{1.0956, 1.0919}},
+		{"USD", "EUR", []float64{1 / 1.0956, 1 / 1.0919}},
+		// Cross rates on the dates both are published
+		{"USD", "JPY", []float64{155.52 / 1.0956}},
+	}
+	for _, tt := range tests {
+		ds, err := ecb.Rates(ctx, tt.base, tt.quote, day(1), day(5))
+		if err != nil {
+			t.Errorf("Rates(%s, %s) error = %v", tt.base, tt.quote, err)
+			continue
+		}
+		rates, _ := ds.Column(currency.RateColumn)
+		if len(rates) != len(tt.want) {
+			t.Errorf("Rates(%s, %s) = %v, want %v", tt.base, tt.quote, rates, tt.want)
+			continue
+		}
+		for i := range rates {
+			if math.Abs(rates[i]-tt.want[i]) > 1e-9 {
+				t.Errorf("Rates(%s, %s)[%d] = %v, want %v", tt.base, tt.quote, i, rates[i], tt.want[i])
+			}
+		}
+	}
+
+	if _, err := ecb.Rates(ctx, "TWD", "USD", day(1), day(5)); err == nil {
+		t.Error("Rates(TWD, USD) error = nil, want an error")
+	}
+}
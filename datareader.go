@@ -113,16 +113,22 @@
 //	}
 package datareader
 
+//go:generate go run ./internal/tools/schemagen -out docs/schema.json
+
 import (
 	"context"
+	"errors"
 	"fmt"
 	"time"
 
+	"github.com/julianshen/gonp-datareader/dataset"
 	internalhttp "github.com/julianshen/gonp-datareader/internal/http"
 	"github.com/julianshen/gonp-datareader/sources"
 	"github.com/julianshen/gonp-datareader/sources/alphavantage"
+	"github.com/julianshen/gonp-datareader/sources/ecb"
 	"github.com/julianshen/gonp-datareader/sources/eurostat"
 	"github.com/julianshen/gonp-datareader/sources/finmind"
+	"github.com/julianshen/gonp-datareader/sources/finra"
 	"github.com/julianshen/gonp-datareader/sources/fred"
 	"github.com/julianshen/gonp-datareader/sources/iex"
 	"github.com/julianshen/gonp-datareader/sources/oecd"
@@ -152,6 +158,11 @@ var (
 //   - "oecd": OECD - economic indicators and statistics (no API key required)
 //   - "eurostat": Eurostat - European statistics (no API key required)
 //   - "twse": Taiwan Stock Exchange - Taiwan stock market data (no API key required)
+//   - "finra": FINRA OTC Transparency - weekly ATS and non-ATS volume (no API key required)
+//   - "ecb": European Central Bank - euro foreign exchange reference rates (no API key required)
+//   - "bundle": an offline bundle file built by BuildBundle (requires opts.BundlePath)
+//
+// Sources added with RegisterSource are created by their factory.
 //
 // The opts parameter provides configuration for the reader. If nil, default options are used.
 // See the Options struct for available configuration settings.
@@ -177,38 +188,82 @@ var (
 // Returns ErrUnknownSource if the source is not recognized.
 // Use ListSources() to get a list of valid source names.
 func DataReader(source string, opts *Options) (sources.Reader, error) {
+	opts = withDefaults(opts)
+	reader, err := newReader(source, opts)
+	if err != nil {
+		return nil, err
+	}
+	withRows(reader, source, opts)
+	return withInterval(reader, opts)
+}
+
+// newReader implements DataReader before Options.Interval is applied.
+func newReader(source string, opts *Options) (sources.Reader, error) {
 	if source == "" {
 		return nil, fmt.Errorf("%w: source cannot be empty", ErrUnknownSource)
 	}
 
-	// Convert Options to ClientOptions
-	var clientOpts *internalhttp.ClientOptions
-	var apiKey string
-	if opts != nil {
-		clientOpts = &internalhttp.ClientOptions{
-			Timeout:    opts.Timeout,
-			UserAgent:  opts.UserAgent,
-			MaxRetries: opts.MaxRetries,
-			RetryDelay: opts.RetryDelay,
-			RateLimit:  opts.RateLimit,
-			CacheDir:   opts.CacheDir,
-			CacheTTL:   opts.CacheTTL,
+	// Bundles are read from a local file in every environment
+	if source == "bundle" {
+		return openBundle(opts)
+	}
+
+	// Third-party readers configure themselves from opts
+	if factory, ok := registeredSource(source); ok {
+		return factory(opts)
+	}
+
+	clientOpts, err := clientOptions(source, opts)
+	if err != nil {
+		return nil, err
+	}
+
+	// Resolve the pinned API version, then the sandbox base URL and API
+	// key, if requested, then any gateway override
+	versionURL, err := resolveVersion(source, opts)
+	if err != nil {
+		return nil, err
+	}
+	baseURL, apiKey, err := resolveEnvironment(source, opts)
+	if err != nil {
+		return nil, err
+	}
+	if versionURL != "" {
+		if _, override := opts.SandboxBaseURLs[source]; !override {
+			baseURL = versionURL
 		}
-		apiKey = opts.APIKey
+	}
+	if url, ok := baseURLOverride(source, opts); ok {
+		baseURL = url
+	}
+
+	// Sources compiled in with build tags create themselves
+	if create, ok := optionalSources[source]; ok {
+		return create(opts, clientOpts, baseURL)
+	}
+	if baseURL != "" {
+		return newReaderWithBaseURL(source, opts, clientOpts, apiKey, baseURL)
 	}
 
 	switch source {
 	case "yahoo":
-		return yahoo.NewYahooReader(clientOpts), nil
+		reader := yahoo.NewYahooReader(clientOpts)
+		reader.SetAdjustPrices(opts != nil && opts.AdjustPrices)
+		return reader, nil
 	case "fred":
+		reader := fred.NewFREDReader(clientOpts)
 		if apiKey != "" {
-			return fred.NewFREDReaderWithAPIKey(clientOpts, apiKey), nil
+			reader.SetAPIKey(apiKey)
 		}
-		return fred.NewFREDReader(clientOpts), nil
+		reader.SetMergeSeries(opts != nil && opts.MergeSeries)
+		reader.SetFrequencyMeta(opts != nil && opts.FrequencyMeta)
+		return reader, nil
 	case "worldbank":
 		return worldbank.NewWorldBankReader(clientOpts), nil
 	case "alphavantage":
-		return alphavantage.NewAlphaVantageReader(clientOpts, apiKey), nil
+		reader := alphavantage.NewAlphaVantageReader(clientOpts, apiKey)
+		reader.SetAdjustPrices(opts != nil && opts.AdjustPrices)
+		return reader, nil
 	case "stooq":
 		return stooq.NewStooqReader(clientOpts), nil
 	case "iex":
@@ -218,23 +273,193 @@ func DataReader(source string, opts *Options) (sources.Reader, error) {
 		if apiKey != "" {
 			reader.SetAPIKey(apiKey)
 		}
+		reader.SetDelistingMeta(opts != nil && opts.DelistingMeta)
+		reader.SetAdjustPrices(opts != nil && opts.AdjustPrices)
 		return reader, nil
 	case "oecd":
 		return oecd.NewOECDReader(clientOpts), nil
 	case "eurostat":
-		return eurostat.NewEurostatReader(clientOpts), nil
+		return eurostatWithFormat(eurostat.NewEurostatReader(clientOpts), opts)
 	case "twse":
-		return twse.NewTWSEReader(clientOpts), nil
+		reader := twse.NewTWSEReader(clientOpts)
+		reader.SetEnglishNames(opts != nil && opts.EnglishNames)
+		return reader, nil
 	case "finmind":
 		if apiKey != "" {
 			return finmind.NewFinMindReaderWithToken(clientOpts, apiKey), nil
 		}
 		return finmind.NewFinMindReader(clientOpts), nil
+	case "finra":
+		return finra.NewFINRAReader(clientOpts), nil
+	case "ecb":
+		return ecb.NewECBReader(clientOpts), nil
+	default:
+		return nil, fmt.Errorf("%w: %s", ErrUnknownSource, source)
+	}
+}
+
+// clientOptions converts the Options of source to ClientOptions; nil for
+// nil opts.
+func clientOptions(source string, opts *Options) (*internalhttp.ClientOptions, error) {
+	var clientOpts *internalhttp.ClientOptions
+	if opts != nil {
+		rateLimit := opts.rateLimitFor(source)
+		clientOpts = &internalhttp.ClientOptions{
+			Source:              source,
+			Timeout:             opts.Timeout,
+			UserAgent:           opts.UserAgent,
+			MaxRetries:          opts.MaxRetries,
+			RetryDelay:          opts.RetryDelay,
+			MaxRetryAfter:       opts.MaxRetryAfter,
+			RateLimit:           rateLimit.Rate,
+			RateBurst:           rateLimit.Burst,
+			RateInitialTokens:   rateLimit.InitialTokens,
+			AdaptiveRateLimit:   opts.AdaptiveRateLimit,
+			CacheDir:            opts.CacheDir,
+			CacheTTL:            opts.CacheTTL,
+			ServeStaleOnError:   opts.ServeStaleOnError,
+			MemoryCacheSize:     opts.MemoryCacheSize,
+			MemoryCacheTTL:      opts.MemoryCacheTTL,
+			DecodedCacheSize:    opts.DecodedCacheSize,
+			MaxRedirects:        opts.MaxRedirects,
+			AdaptiveConcurrency: opts.AdaptiveConcurrency,
+			MaxConcurrency:      opts.MaxConcurrency,
+			LatencyTarget:       opts.LatencyTarget,
+			FallbackCharset:     opts.FallbackCharset,
+			DisableBodyDecoding: opts.DisableBodyDecoding,
+		}
+		if opts.FallbackCharset != "" {
+			if err := internalhttp.CheckCharset(opts.FallbackCharset); err != nil {
+				return nil, fmt.Errorf("invalid options: %w", err)
+			}
+		}
+		if opts.Hooks != nil {
+			clientOpts.OnCacheHit = opts.Hooks.OnCacheHit
+			clientOpts.OnCacheMiss = opts.Hooks.OnCacheMiss
+		}
+	}
+	return clientOpts, nil
+}
+
+// newReaderWithBaseURL creates a reader for source that sends requests to
+// baseURL, in the format of the source's NewXReaderWithBaseURL constructor.
+func newReaderWithBaseURL(source string, opts *Options, clientOpts *internalhttp.ClientOptions, apiKey, baseURL string) (sources.Reader, error) {
+	switch source {
+	case "yahoo":
+		reader := yahoo.NewYahooReaderWithBaseURL(clientOpts, baseURL)
+		reader.SetAdjustPrices(opts != nil && opts.AdjustPrices)
+		return reader, nil
+	case "fred":
+		reader := fred.NewFREDReaderWithBaseURL(clientOpts, baseURL)
+		if apiKey != "" {
+			reader.SetAPIKey(apiKey)
+		}
+		reader.SetMergeSeries(opts != nil && opts.MergeSeries)
+		reader.SetFrequencyMeta(opts != nil && opts.FrequencyMeta)
+		return reader, nil
+	case "worldbank":
+		return worldbank.NewWorldBankReaderWithBaseURL(clientOpts, baseURL), nil
+	case "alphavantage":
+		reader := alphavantage.NewAlphaVantageReaderWithBaseURL(clientOpts, apiKey, baseURL)
+		reader.SetAdjustPrices(opts != nil && opts.AdjustPrices)
+		return reader, nil
+	case "stooq":
+		return stooq.NewStooqReaderWithBaseURL(clientOpts, baseURL), nil
+	case "iex":
+		return iex.NewIEXReaderWithBaseURL(clientOpts, apiKey, baseURL), nil
+	case "tiingo":
+		reader := tiingo.NewTiingoReaderWithBaseURL(clientOpts, baseURL)
+		if apiKey != "" {
+			reader.SetAPIKey(apiKey)
+		}
+		reader.SetDelistingMeta(opts != nil && opts.DelistingMeta)
+		reader.SetAdjustPrices(opts != nil && opts.AdjustPrices)
+		return reader, nil
+	case "oecd":
+		return oecd.NewOECDReaderWithBaseURL(clientOpts, baseURL), nil
+	case "eurostat":
+		return eurostatWithFormat(eurostat.NewEurostatReaderWithBaseURL(clientOpts, baseURL), opts)
+	case "twse":
+		reader := twse.NewTWSEReaderWithBaseURL(clientOpts, baseURL)
+		reader.SetEnglishNames(opts != nil && opts.EnglishNames)
+		return reader, nil
+	case "finmind":
+		return finmind.NewFinMindReaderWithTokenAndEndpoint(clientOpts, apiKey, baseURL), nil
+	case "finra":
+		return finra.NewFINRAReaderWithBaseURL(clientOpts, baseURL), nil
+	case "ecb":
+		return ecb.NewECBReaderWithBaseURL(clientOpts, baseURL), nil
 	default:
 		return nil, fmt.Errorf("%w: %s", ErrUnknownSource, source)
 	}
 }
 
+// eurostatWithFormat applies Options.Format to a Eurostat reader.
+func eurostatWithFormat(reader *eurostat.EurostatReader, opts *Options) (sources.Reader, error) {
+	if opts != nil && opts.Format != "" {
+		if err := reader.SetFormat(eurostat.Format(opts.Format)); err != nil {
+			return nil, err
+		}
+	}
+	return reader, nil
+}
+
+// finisher is implemented by readers embedding sources.BaseSource.
+type finisher interface {
+	SetFinish(func(ctx context.Context, symbol string, start, end time.Time, read sources.ReadFunc) (interface{}, error))
+}
+
+// withRows applies the row options (see readRows) to the reads of readers
+// with a SetFinish method, which all built-in readers have, so readers
+// from DataReader, and the Manager and services built on them, return
+// sorted rows and enforce Options.MaxRows like Read does.
+func withRows(reader sources.Reader, source string, opts *Options) {
+	if r, ok := reader.(finisher); ok {
+		r.SetFinish(func(ctx context.Context, symbol string, start, end time.Time, read sources.ReadFunc) (interface{}, error) {
+			return readRows(ctx, source, symbol, start, end, opts, read)
+		})
+	}
+}
+
+// readRows reads symbol with read, refusing requests expected to return
+// more than Options.MaxRows rows before they are sent, then applies
+// finishRows to the result and checks its rows against Options.MaxRows.
+func readRows(ctx context.Context, source, symbol string, start, end time.Time, opts *Options, read sources.ReadFunc) (interface{}, error) {
+	if err := checkRowEstimate(source, symbol, start, end, opts); err != nil {
+		return nil, err
+	}
+
+	data, err := read(ctx, symbol, start, end)
+	if err != nil {
+		return data, err
+	}
+	finishRows(data, source, opts)
+	if err := checkDataRows(source, symbol, data, opts); err != nil {
+		return nil, err
+	}
+	return data, nil
+}
+
+// withInterval applies Options.Interval to readers with a SetInterval
+// method. Other readers serve daily bars or economic series only.
+func withInterval(reader sources.Reader, opts *Options) (sources.Reader, error) {
+	if opts == nil || opts.Interval == "" {
+		return reader, nil
+	}
+
+	r, ok := reader.(interface{ SetInterval(sources.Interval) error })
+	if !ok {
+		if err := sources.CheckInterval(opts.Interval, []sources.Interval{sources.Interval1d}); err != nil {
+			return nil, fmt.Errorf("invalid options: %s: %w", reader.Source(), err)
+		}
+		return reader, nil
+	}
+	if err := r.SetInterval(opts.Interval); err != nil {
+		return nil, fmt.Errorf("invalid options: %s: %w", reader.Source(), err)
+	}
+	return reader, nil
+}
+
 // Read is a convenience function that creates a reader and fetches data for a single symbol.
 //
 // This is the simplest way to fetch data. It combines DataReader() and ReadSingle()
@@ -279,15 +504,180 @@ func DataReader(source string, opts *Options) (sources.Reader, error) {
 //	defer cancel()
 //	data, err := datareader.Read(ctx, "AAPL", "yahoo", start, end, nil)
 func Read(ctx context.Context, symbol string, source string, start, end time.Time, opts *Options) (interface{}, error) {
+	opts = withDefaults(opts)
+	if err := checkRowEstimate(source, symbol, start, end, opts); err != nil {
+		return nil, err
+	}
+
 	reader, err := DataReader(source, opts)
 	if err != nil {
 		return nil, err
 	}
 
-	return reader.ReadSingle(ctx, symbol, start, end)
+	ctx = labelCache(ctx, symbol, start, end)
+	if _, ok := reader.(finisher); ok {
+		// The reader applies the row options itself (see withRows)
+		return reader.ReadSingle(ctx, symbol, start, end)
+	}
+	return readRows(ctx, source, symbol, start, end, opts, reader.ReadSingle)
 }
 
-// ListSources returns a list of all available data source names.
+// ReadDataset fetches data for a single symbol and converts it to a
+// dataset.Dataset, using opts.NumericMode to select float64 or exact
+// decimal values.
+//
+// # Example Usage
+//
+//	opts := &datareader.Options{NumericMode: datareader.NumericDecimal}
+//	ds, err := datareader.ReadDataset(ctx, "AAPL", "yahoo", start, end, opts)
+//	if err != nil {
+//		log.Fatal(err)
+//	}
+//	closes, _ := ds.ExactColumn("Close")
+//
+// With opts.StitchRenames, the history of a renamed company is fetched
+// under each of its tickers and stitched into one series:
+//
+//	opts := &datareader.Options{StitchRenames: true}
+//	ds, err := datareader.ReadDataset(ctx, "META", "stooq", start, end, opts)
+//	// ds covers FB before 2022-06-09 and META afterwards
+//
+// With opts.ExpectedRowsTolerance, a daily dataset with fewer rows than its
+// exchange has sessions in the range is flagged with Meta["short"]:
+//
+//	opts := &datareader.Options{ExpectedRowsTolerance: 0.02}
+//	ds, err := datareader.ReadDataset(ctx, "AAPL", "yahoo", start, end, opts)
+//	if ds.Meta["short"] == "true" {
+//		log.Printf("%s: expected %s rows, got %d", ds.Symbol, ds.Meta["expected_rows"], ds.Len())
+//	}
+//
+// With opts.MaxRows, requests for more rows fail with ErrTooManyRows:
+//
+//	opts := &datareader.Options{MaxRows: 10000}
+//	_, err := datareader.ReadDataset(ctx, "AAPL", "yahoo", start, end, opts)
+//	if errors.Is(err, datareader.ErrTooManyRows) {
+//		log.Printf("range too large: %v", err)
+//	}
+//
+// With opts.Fill, missing values are filled after parsing, e.g. FRED's "."
+// placeholders on holidays:
+//
+//	opts := &datareader.Options{APIKey: key, Fill: dataset.FillForward}
+//	ds, err := datareader.ReadDataset(ctx, "DGS10", "fred", start, end, opts)
+//
+// Adjustments made instead of failing, such as an end date in the future
+// or a start before the history the source serves, are recorded as
+// structured warnings (see sources.Warnings) and passed to
+// opts.Hooks.OnWarning.
+func ReadDataset(ctx context.Context, symbol string, source string, start, end time.Time, opts *Options) (*dataset.Dataset, error) {
+	opts = withDefaults(opts)
+	read := readDataset
+	if opts != nil && opts.StitchRenames {
+		read = readStitched
+	}
+
+	if err := checkRowEstimate(source, symbol, start, end, opts); err != nil {
+		return nil, err
+	}
+
+	ds, err := read(ctx, symbol, source, start, end, opts)
+	if err != nil {
+		return nil, err
+	}
+	if opts != nil && opts.Fill != dataset.FillNaN {
+		ds = ds.Fill(opts.Fill)
+	}
+	if err := checkRows(source, symbol, ds.Len(), opts); err != nil {
+		return nil, err
+	}
+	if opts != nil && opts.ExpectedRowsTolerance > 0 {
+		annotateRowCount(ds, source, start, end, opts)
+	}
+	if end.After(time.Now()) {
+		ds.Meta = sources.AddWarning(ds.Meta, sources.Warning{
+			Code:    sources.WarnEndInFuture,
+			Message: fmt.Sprintf("end %s is in the future; data ends at the latest available session", end.Format("2006-01-02")),
+		})
+	}
+	if opts != nil && opts.Hooks != nil && opts.Hooks.OnWarning != nil {
+		for _, w := range sources.Warnings(ds.Meta) {
+			opts.Hooks.OnWarning(source, ds.Symbol, w)
+		}
+	}
+	return ds, nil
+}
+
+// readDataset implements ReadDataset for a single ticker.
+func readDataset(ctx context.Context, symbol string, source string, start, end time.Time, opts *Options) (*dataset.Dataset, error) {
+	reader, err := DataReader(source, opts)
+	if err != nil {
+		return nil, err
+	}
+
+	// Record the response bodies for the source's parse hooks
+	hooks := sourceParseHooks(source)
+	var bodies func() [][]byte
+	if len(hooks) > 0 {
+		ctx, bodies = recordBodies(ctx)
+	}
+
+	data, err := reader.ReadSingle(labelCache(ctx, symbol, start, end), symbol, start, end)
+	if err != nil {
+		return nil, err
+	}
+	finishRows(data, source, opts)
+
+	// Label the dataset with the symbol the reader used
+	if n, ok := reader.(interface{ NormalizeSymbol(string) string }); ok {
+		symbol = n.NormalizeSymbol(symbol)
+	}
+
+	mode := NumericFloat64
+	if opts != nil {
+		mode = opts.NumericMode
+	}
+	ds, err := ToDatasetMode(symbol, data, mode)
+	if err != nil {
+		return nil, err
+	}
+	if ds.Source == "" {
+		ds.Source = source
+	}
+	if len(hooks) == 0 {
+		return ds, nil
+	}
+	if err := runParseHooks(source, hooks, bodies(), ds); err != nil {
+		return nil, err
+	}
+	return ds, nil
+}
+
+// Shutdown gracefully shuts down readers created by DataReader: each stops
+// accepting requests and waits for its in-flight requests until ctx
+// expires, after which they are cancelled. Readers that hold no resources
+// are skipped. Errors are joined.
+//
+// # Example Usage
+//
+//	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+//	defer cancel()
+//	if err := datareader.Shutdown(ctx, yahooReader, fredReader); err != nil {
+//		log.Printf("shutdown: %v", err)
+//	}
+func Shutdown(ctx context.Context, readers ...sources.Reader) error {
+	var errs []error
+	for _, r := range readers {
+		if c, ok := r.(sources.Closer); ok {
+			if err := c.Shutdown(ctx); err != nil {
+				errs = append(errs, fmt.Errorf("%s: %w", r.Source(), err))
+			}
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// ListSources returns a list of all available data source names: the
+// built-in sources followed by those added with RegisterSource, sorted.
 //
 // This function is useful for discovering which sources are supported
 // and for validating user input.
@@ -313,17 +703,8 @@ func Read(ctx context.Context, symbol string, source string, start, end time.Tim
 //		log.Fatalf("Unknown source: %s", userSource)
 //	}
 func ListSources() []string {
-	return []string{
-		"yahoo",
-		"fred",
-		"worldbank",
-		"alphavantage",
-		"stooq",
-		"iex",
-		"tiingo",
-		"oecd",
-		"eurostat",
-		"twse",
-		"finmind",
-	}
+	registered := registeredSources()
+	names := make([]string, 0, len(builtinSources)+len(registered))
+	names = append(names, builtinSources...)
+	return append(names, registered...)
 }
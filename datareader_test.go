@@ -2,11 +2,17 @@ package datareader_test
 
 import (
 	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
 	"testing"
 	"time"
 
 	datareader "github.com/julianshen/gonp-datareader"
+	"github.com/julianshen/gonp-datareader/dataset"
 	"github.com/julianshen/gonp-datareader/sources"
+	"github.com/julianshen/gonp-datareader/sources/eurostat"
+	"github.com/julianshen/gonp-datareader/sources/stooq"
 )
 
 func TestDataReader(t *testing.T) {
@@ -355,3 +361,326 @@ func TestRead_FinMind(t *testing.T) {
 		}
 	}
 }
+
+func TestDataReader_Schema(t *testing.T) {
+	for _, source := range datareader.ListSources() {
+		t.Run(source, func(t *testing.T) {
+			reader, err := datareader.DataReader(source, nil)
+			if err != nil {
+				t.Fatalf("DataReader(%q) error = %v", source, err)
+			}
+
+			provider, ok := reader.(sources.SchemaProvider)
+			if !ok {
+				t.Fatalf("%s reader does not implement sources.SchemaProvider", source)
+			}
+
+			schema := provider.Schema()
+			if schema.Source != source {
+				t.Errorf("Schema().Source = %q, want %q", schema.Source, source)
+			}
+			if len(schema.Columns) == 0 {
+				t.Error("Schema() returned no columns")
+			}
+		})
+	}
+}
+
+func TestShutdown(t *testing.T) {
+	yahooReader, err := datareader.DataReader("yahoo", nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	wbReader, err := datareader.DataReader("worldbank", nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if err := datareader.Shutdown(context.Background(), yahooReader, wbReader); err != nil {
+		t.Fatalf("Shutdown() error = %v", err)
+	}
+
+	start := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	end := time.Date(2024, 1, 31, 0, 0, 0, 0, time.UTC)
+	if _, err := wbReader.ReadSingle(context.Background(), "USA/NY.GDP.MKTP.CD", start, end); !errors.Is(err, sources.ErrClosed) {
+		t.Errorf("ReadSingle() after Shutdown() error = %v, want ErrClosed", err)
+	}
+}
+
+func TestReadDataset_NormalizesSymbol(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte("Date,Open,High,Low,Close,Volume\n2024-01-02,1,1,1,1,10\n"))
+	}))
+	defer server.Close()
+
+	opts := &datareader.Options{
+		Environment:     datareader.EnvironmentSandbox,
+		SandboxBaseURLs: map[string]string{"stooq": server.URL + "?s=%s"},
+	}
+	start := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	end := time.Date(2024, 1, 31, 0, 0, 0, 0, time.UTC)
+
+	ds, err := datareader.ReadDataset(context.Background(), "nyse:ibm.us ", "stooq", start, end, opts)
+	if err != nil {
+		t.Fatalf("ReadDataset() error = %v", err)
+	}
+	if ds.Symbol != "IBM.US" || ds.Meta["symbol_input"] != "nyse:ibm.us " {
+		t.Errorf("Symbol = %q, Meta = %v", ds.Symbol, ds.Meta)
+	}
+}
+
+func TestReadDataset_Fill(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte("Date,Open,High,Low,Close,Volume\n2024-01-02,1,1,1,1,10\n2024-01-03,,,,,\n2024-01-04,3,3,3,3,30\n"))
+	}))
+	defer server.Close()
+
+	start := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	end := time.Date(2024, 1, 31, 0, 0, 0, 0, time.UTC)
+	opts := &datareader.Options{
+		BaseURLOverrides: map[string]string{"stooq": server.URL + "?s=%s"},
+		Fill:             dataset.FillLinear,
+	}
+
+	ds, err := datareader.ReadDataset(context.Background(), "IBM.US", "stooq", start, end, opts)
+	if err != nil {
+		t.Fatalf("ReadDataset() error = %v", err)
+	}
+	closes, _ := ds.Column("Close")
+	if len(closes) != 3 || closes[1] != 2 || ds.Meta[dataset.MetaFill] != "interpolate" {
+		t.Errorf("Close = %v, Meta = %v", closes, ds.Meta)
+	}
+}
+
+func TestRead_SortsRows(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte("Date,Open,High,Low,Close,Volume\n2024-01-03,2,2,2,2,20\n2024-01-02,1,1,1,1,10\n2024-01-03,3,3,3,3,30\n"))
+	}))
+	defer server.Close()
+
+	start := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	end := time.Date(2024, 1, 31, 0, 0, 0, 0, time.UTC)
+	opts := &datareader.Options{BaseURLOverrides: map[string]string{"stooq": server.URL + "?s=%s"}}
+
+	ds, err := datareader.ReadDataset(context.Background(), "IBM.US", "stooq", start, end, opts)
+	if err != nil {
+		t.Fatalf("ReadDataset() error = %v", err)
+	}
+	closes, _ := ds.Column("Close")
+	if ds.Len() != 2 || closes[0] != 1 || closes[1] != 3 {
+		t.Errorf("Close = %v, want [1 3] with the last row of 2024-01-03", closes)
+	}
+
+	opts.DisableRowSorting = true
+	data, err := datareader.Read(context.Background(), "IBM.US", "stooq", start, end, opts)
+	if err != nil {
+		t.Fatalf("Read() error = %v", err)
+	}
+	if rows := len(data.(*stooq.ParsedData).Rows); rows != 3 {
+		t.Errorf("rows with DisableRowSorting = %d, want 3", rows)
+	}
+}
+
+func TestDataReader_SortsRows(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte("Date,Open,High,Low,Close,Volume\n2024-01-03,2,2,2,2,20\n2024-01-02,1,1,1,1,10\n2024-01-03,3,3,3,3,30\n"))
+	}))
+	defer server.Close()
+
+	start := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	end := time.Date(2024, 1, 31, 0, 0, 0, 0, time.UTC)
+	reader, err := datareader.DataReader("stooq", &datareader.Options{BaseURLOverrides: map[string]string{"stooq": server.URL + "?s=%s"}})
+	if err != nil {
+		t.Fatalf("DataReader() error = %v", err)
+	}
+
+	data, err := reader.ReadSingle(context.Background(), "IBM.US", start, end)
+	if err != nil {
+		t.Fatalf("ReadSingle() error = %v", err)
+	}
+	rows := data.(*stooq.ParsedData).Rows
+	if len(rows) != 2 || rows[0]["Close"] != "1" || rows[1]["Close"] != "3" {
+		t.Errorf("ReadSingle() rows = %v, want 2024-01-02 then the last row of 2024-01-03", rows)
+	}
+
+	results, err := reader.Read(context.Background(), []string{"IBM.US"}, start, end)
+	if err != nil {
+		t.Fatalf("Read() error = %v", err)
+	}
+	if rows := results.(map[string]*stooq.ParsedData)["IBM.US"].Rows; len(rows) != 2 {
+		t.Errorf("Read() rows = %d, want 2", len(rows))
+	}
+}
+
+func TestDataReader_RateLimitsPerSource(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte("Date,Open,High,Low,Close,Volume\n2024-01-02,1,1,1,1,10\n"))
+	}))
+	defer server.Close()
+
+	tests := []struct {
+		name       string
+		rateLimits map[string]datareader.RateLimitConfig
+		wantPaced  bool
+	}{
+		{"full bucket", nil, false},
+		{"empty bucket for stooq", map[string]datareader.RateLimitConfig{"stooq": {InitialTokens: -1}}, true},
+		{"empty bucket for other source", map[string]datareader.RateLimitConfig{"yahoo": {InitialTokens: -1}}, false},
+	}
+
+	start := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	end := time.Date(2024, 1, 31, 0, 0, 0, 0, time.UTC)
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// 5 requests per second: a paced first request waits ~200ms
+			reader, err := datareader.DataReader("stooq", &datareader.Options{
+				Environment:     datareader.EnvironmentSandbox,
+				SandboxBaseURLs: map[string]string{"stooq": server.URL + "?s=%s"},
+				RateLimit:       5,
+				RateLimits:      tt.rateLimits,
+			})
+			if err != nil {
+				t.Fatalf("DataReader() error = %v", err)
+			}
+
+			began := time.Now()
+			if _, err := reader.ReadSingle(context.Background(), "IBM.US", start, end); err != nil {
+				t.Fatalf("ReadSingle() error = %v", err)
+			}
+			if paced := time.Since(began) >= 100*time.Millisecond; paced != tt.wantPaced {
+				t.Errorf("first request took %v, want paced: %v", time.Since(began), tt.wantPaced)
+			}
+		})
+	}
+}
+
+func TestDataReader_FallbackCharset(t *testing.T) {
+	if _, err := datareader.DataReader("twse", &datareader.Options{FallbackCharset: "windows-1252"}); err != nil {
+		t.Errorf("DataReader() with windows-1252 error = %v", err)
+	}
+	if _, err := datareader.DataReader("twse", &datareader.Options{FallbackCharset: "klingon"}); !errors.Is(err, sources.ErrUnknownCharset) {
+		t.Errorf("DataReader() with unknown charset error = %v, want ErrUnknownCharset", err)
+	}
+}
+
+func TestDataReader_Format(t *testing.T) {
+	reader, err := datareader.DataReader("eurostat", &datareader.Options{Format: "sdmx-csv"})
+	if err != nil {
+		t.Fatalf("DataReader() error = %v", err)
+	}
+	if got := reader.(*eurostat.EurostatReader).Format(); got != eurostat.FormatSDMXCSV {
+		t.Errorf("Format() = %q, want sdmx-csv", got)
+	}
+
+	if _, err := datareader.DataReader("eurostat", &datareader.Options{Format: "xml"}); !errors.Is(err, eurostat.ErrUnknownFormat) {
+		t.Errorf("DataReader() with unknown format error = %v, want ErrUnknownFormat", err)
+	}
+}
+
+func TestReadDataset_Warnings(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "text/csv")
+		w.Write([]byte("Date,Open,High,Low,Close,Volume\n2024-01-02,187.15,188.44,183.89,185.64,82488674\n"))
+	}))
+	defer server.Close()
+
+	var got []string
+	opts := &datareader.Options{
+		BaseURLOverrides: map[string]string{"stooq": server.URL + "?s=%s"},
+		Hooks: &datareader.Hooks{
+			OnWarning: func(source, symbol string, w sources.Warning) {
+				got = append(got, source+" "+symbol+" "+w.Code)
+			},
+		},
+	}
+
+	start := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	tests := []struct {
+		name string
+		end  time.Time
+		want []string
+	}{
+		{"past end", time.Date(2024, 1, 31, 0, 0, 0, 0, time.UTC), nil},
+		{"future end", time.Now().AddDate(1, 0, 0), []string{"stooq AAPL.US end_in_future"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got = nil
+			ds, err := datareader.ReadDataset(context.Background(), "AAPL.US", "stooq", start, tt.end, opts)
+			if err != nil {
+				t.Fatalf("ReadDataset() error = %v", err)
+			}
+			if len(got) != len(tt.want) || (len(got) > 0 && got[0] != tt.want[0]) {
+				t.Errorf("OnWarning calls = %v, want %v", got, tt.want)
+			}
+			if _, ok := ds.Meta["warning_"+sources.WarnEndInFuture]; ok != (tt.want != nil) {
+				t.Errorf("Meta = %v", ds.Meta)
+			}
+		})
+	}
+}
+
+func TestDataReader_Interval(t *testing.T) {
+	tests := []struct {
+		source   string
+		interval sources.Interval
+		wantErr  bool
+	}{
+		{"yahoo", sources.Interval1h, false},
+		{"stooq", sources.Interval1wk, false},
+		{"stooq", sources.Interval5m, true},
+		{"fred", sources.Interval1d, false},
+		{"fred", sources.Interval1wk, true},
+		{"yahoo", "2h", true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.source+"/"+string(tt.interval), func(t *testing.T) {
+			_, err := datareader.DataReader(tt.source, &datareader.Options{Interval: tt.interval})
+			if tt.wantErr != errors.Is(err, sources.ErrUnsupportedInterval) {
+				t.Errorf("DataReader() error = %v, want ErrUnsupportedInterval %v", err, tt.wantErr)
+			}
+			if !tt.wantErr && err != nil {
+				t.Errorf("DataReader() error = %v", err)
+			}
+		})
+	}
+}
+
+func TestReadDataset_AdjustPrices(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`[{"date": "2024-01-02T00:00:00.000Z", "open": 200, "high": 210, "low": 190, "close": 200, "volume": 1000,
+			"adjOpen": 100, "adjHigh": 105, "adjLow": 95, "adjClose": 100, "adjVolume": 2000}]`))
+	}))
+	defer server.Close()
+
+	start := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	end := time.Date(2024, 1, 31, 0, 0, 0, 0, time.UTC)
+	for _, adjust := range []bool{false, true} {
+		opts := &datareader.Options{
+			APIKey:           "key",
+			AdjustPrices:     adjust,
+			BaseURLOverrides: map[string]string{"tiingo": server.URL + "/%s/prices"},
+		}
+		ds, err := datareader.ReadDataset(context.Background(), "AAPL", "tiingo", start, end, opts)
+		if err != nil {
+			t.Fatalf("ReadDataset() error = %v", err)
+		}
+
+		want := 200.0
+		if adjust {
+			want = 100
+		}
+		if closes, ok := ds.Column("Close"); !ok || closes[0] != want {
+			t.Errorf("AdjustPrices=%v: Close = %v, want %v", adjust, closes, want)
+		}
+		if adjClose, ok := ds.Column("Adj Close"); !ok || adjClose[0] != 100 {
+			t.Errorf("AdjustPrices=%v: Adj Close = %v, want 100", adjust, adjClose)
+		}
+		if got := ds.Meta["adjusted"] == "true"; got != adjust {
+			t.Errorf("AdjustPrices=%v: Meta = %v", adjust, ds.Meta)
+		}
+	}
+}
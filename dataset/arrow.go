@@ -0,0 +1,113 @@
+//go:build arrow
+// +build arrow
+
+package dataset
+
+import (
+	"fmt"
+	"io"
+	"math"
+	"sort"
+
+	"github.com/apache/arrow-go/v18/arrow"
+	"github.com/apache/arrow-go/v18/arrow/array"
+	"github.com/apache/arrow-go/v18/arrow/ipc"
+	"github.com/apache/arrow-go/v18/arrow/memory"
+)
+
+// ArrowContentType is the media type of an Arrow IPC stream.
+const ArrowContentType = "application/vnd.apache.arrow.stream"
+
+// ArrowSchema returns the Arrow schema used by WriteArrow: a non-null
+// "Date" column (date32), one nullable float64 column per dataset column,
+// and a nullable "Flags" string column when flags are present. Symbol,
+// Source and Meta are stored as schema metadata.
+//
+// Only available when built with the arrow build tag.
+func (d *Dataset) ArrowSchema() *arrow.Schema {
+	fields := make([]arrow.Field, 0, len(d.Columns)+2)
+	fields = append(fields, arrow.Field{Name: "Date", Type: arrow.FixedWidthTypes.Date32})
+	for _, c := range d.Columns {
+		fields = append(fields, arrow.Field{Name: c.Name, Type: arrow.PrimitiveTypes.Float64, Nullable: true})
+	}
+	if d.Flags != nil {
+		fields = append(fields, arrow.Field{Name: "Flags", Type: arrow.BinaryTypes.String, Nullable: true})
+	}
+
+	keys := []string{"symbol", "source"}
+	values := []string{d.Symbol, d.Source}
+	metaKeys := make([]string, 0, len(d.Meta))
+	for k := range d.Meta {
+		metaKeys = append(metaKeys, k)
+	}
+	sort.Strings(metaKeys)
+	for _, k := range metaKeys {
+		keys = append(keys, k)
+		values = append(values, d.Meta[k])
+	}
+	md := arrow.NewMetadata(keys, values)
+
+	return arrow.NewSchema(fields, &md)
+}
+
+// WriteArrow writes the dataset to w as an Arrow IPC stream holding a
+// single record batch. Missing (NaN) values and empty flags are written as
+// nulls, so large frames can be loaded by Python (pyarrow, pandas) or R
+// clients without CSV/JSON parsing.
+//
+// Only available when built with the arrow build tag.
+func (d *Dataset) WriteArrow(w io.Writer) error {
+	mem := memory.NewGoAllocator()
+	schema := d.ArrowSchema()
+
+	rec := d.arrowRecord(mem, schema)
+	defer rec.Release()
+
+	writer := ipc.NewWriter(w, ipc.WithSchema(schema), ipc.WithAllocator(mem))
+	if err := writer.Write(rec); err != nil {
+		_ = writer.Close()
+		return fmt.Errorf("write arrow record: %w", err)
+	}
+	if err := writer.Close(); err != nil {
+		return fmt.Errorf("close arrow stream: %w", err)
+	}
+	return nil
+}
+
+// arrowRecord builds the dataset as a single record of schema, which must
+// be d.ArrowSchema(). The caller releases the record.
+func (d *Dataset) arrowRecord(mem memory.Allocator, schema *arrow.Schema) arrow.Record {
+	b := array.NewRecordBuilder(mem, schema)
+	defer b.Release()
+
+	dates := b.Field(0).(*array.Date32Builder)
+	dates.Reserve(len(d.Dates))
+	for _, t := range d.Dates {
+		dates.Append(arrow.Date32FromTime(t))
+	}
+
+	for i, c := range d.Columns {
+		fb := b.Field(i + 1).(*array.Float64Builder)
+		fb.Reserve(len(c.Values))
+		for _, v := range c.Values {
+			if math.IsNaN(v) {
+				fb.AppendNull()
+				continue
+			}
+			fb.Append(v)
+		}
+	}
+
+	if d.Flags != nil {
+		sb := b.Field(len(d.Columns) + 1).(*array.StringBuilder)
+		for _, f := range d.Flags {
+			if f == "" {
+				sb.AppendNull()
+				continue
+			}
+			sb.Append(f)
+		}
+	}
+
+	return b.NewRecord()
+}
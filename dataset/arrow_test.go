@@ -0,0 +1,74 @@
+//go:build arrow
+// +build arrow
+
+package dataset_test
+
+import (
+	"bytes"
+	"math"
+	"testing"
+	"time"
+
+	"github.com/apache/arrow-go/v18/arrow"
+	"github.com/apache/arrow-go/v18/arrow/array"
+	"github.com/apache/arrow-go/v18/arrow/ipc"
+
+	"github.com/julianshen/gonp-datareader/dataset"
+)
+
+func TestDataset_WriteArrow(t *testing.T) {
+	ds := dataset.New("GDP", "fred", []time.Time{day(1), day(2), day(3)})
+	if err := ds.AddColumn("Value", []float64{1.5, math.NaN(), 3}); err != nil {
+		t.Fatal(err)
+	}
+	ds.Flags = []string{"", "E", ""}
+	ds.Meta["stale"] = "true"
+
+	var buf bytes.Buffer
+	if err := ds.WriteArrow(&buf); err != nil {
+		t.Fatalf("WriteArrow() error = %v", err)
+	}
+
+	r, err := ipc.NewReader(&buf)
+	if err != nil {
+		t.Fatalf("ipc.NewReader() error = %v", err)
+	}
+	defer r.Release()
+
+	schema := r.Schema()
+	if got := schema.NumFields(); got != 3 {
+		t.Fatalf("NumFields() = %d, want 3", got)
+	}
+	for key, want := range map[string]string{"symbol": "GDP", "source": "fred", "stale": "true"} {
+		if got, _ := schema.Metadata().GetValue(key); got != want {
+			t.Errorf("metadata %q = %q, want %q", key, got, want)
+		}
+	}
+
+	if !r.Next() {
+		t.Fatalf("no record batch: %v", r.Err())
+	}
+	rec := r.Record()
+	if rec.NumRows() != 3 {
+		t.Fatalf("NumRows() = %d, want 3", rec.NumRows())
+	}
+
+	dates := rec.Column(0).(*array.Date32)
+	if got := dates.Value(1).ToTime(); !got.Equal(day(2)) {
+		t.Errorf("Date[1] = %v, want %v", got, day(2))
+	}
+
+	values := rec.Column(1).(*array.Float64)
+	if values.Value(0) != 1.5 || !values.IsNull(1) || values.Value(2) != 3 {
+		t.Errorf("Value = %v, want [1.5 (null) 3]", values)
+	}
+
+	flags := rec.Column(2).(*array.String)
+	if !flags.IsNull(0) || flags.Value(1) != "E" {
+		t.Errorf("Flags = %v, want [(null) E (null)]", flags)
+	}
+
+	if rec.Schema().Field(1).Type.ID() != arrow.FLOAT64 {
+		t.Errorf("Value type = %v, want float64", rec.Schema().Field(1).Type)
+	}
+}
@@ -0,0 +1,129 @@
+package dataset
+
+import (
+	"fmt"
+	"math"
+	"math/big"
+	"time"
+)
+
+// Concat appends datasets covering consecutive date ranges into one
+// dataset, e.g., the histories of a symbol before and after a ticker
+// change. Datasets must be given in chronological order: rows of a later
+// dataset on or before the last date already appended are dropped, so
+// overlapping ranges keep the earlier data.
+//
+// The result has the union of all columns, in order of appearance, with
+// NaN where a dataset lacks a column. Exact values are kept for columns
+// that carry them in every dataset. Symbol and Source are taken from the
+// last dataset and Meta entries are merged, later datasets winning.
+// Nil and empty datasets are skipped.
+func Concat(datasets ...*Dataset) (*Dataset, error) {
+	var inputs []*Dataset
+	for _, d := range datasets {
+		if d.Len() == 0 {
+			continue
+		}
+		for i := 1; i < len(d.Dates); i++ {
+			if !d.Dates[i-1].Before(d.Dates[i]) {
+				return nil, fmt.Errorf("%w: %s", ErrUnsorted, label(d))
+			}
+		}
+		inputs = append(inputs, d)
+	}
+	if len(inputs) == 0 {
+		return nil, fmt.Errorf("concat: no data")
+	}
+
+	// Select the rows of each input that come after the previous inputs
+	out := New(inputs[0].Symbol, inputs[0].Source, nil)
+	starts := make([]int, len(inputs))
+	var lastDate time.Time
+	hasFlags := false
+	for n, d := range inputs {
+		start := 0
+		if n > 0 {
+			for start < len(d.Dates) && !d.Dates[start].After(lastDate) {
+				start++
+			}
+		}
+		starts[n] = start
+		out.Dates = append(out.Dates, d.Dates[start:]...)
+		if len(d.Dates) > start {
+			lastDate = d.Dates[len(d.Dates)-1]
+		}
+		hasFlags = hasFlags || d.Flags != nil
+
+		out.Symbol, out.Source = d.Symbol, d.Source
+		for k, v := range d.Meta {
+			out.Meta[k] = v
+		}
+	}
+
+	for _, name := range unionColumnNames(inputs) {
+		values := make([]float64, 0, len(out.Dates))
+		var exact []*big.Rat
+		allExact := true
+		for n, d := range inputs {
+			rows := len(d.Dates) - starts[n]
+			col, ok := d.Column(name)
+			if !ok {
+				allExact = false
+				for i := 0; i < rows; i++ {
+					values = append(values, math.NaN())
+				}
+				continue
+			}
+			values = append(values, col[starts[n]:]...)
+
+			rats, ok := d.ExactColumn(name)
+			allExact = allExact && ok
+			if allExact {
+				exact = append(exact, rats[starts[n]:]...)
+			}
+		}
+
+		out.Columns = append(out.Columns, Column{Name: name, Values: values, Unit: firstUnit(inputs, name)})
+		if allExact {
+			out.Columns[len(out.Columns)-1].Exact = exact
+		}
+	}
+
+	if hasFlags {
+		out.Flags = make([]string, 0, len(out.Dates))
+		for n, d := range inputs {
+			if d.Flags == nil {
+				out.Flags = append(out.Flags, make([]string, len(d.Dates)-starts[n])...)
+				continue
+			}
+			out.Flags = append(out.Flags, d.Flags[starts[n]:]...)
+		}
+	}
+
+	return out, nil
+}
+
+// firstUnit returns the first known unit of the named column in
+// datasets.
+func firstUnit(datasets []*Dataset, name string) string {
+	for _, d := range datasets {
+		if unit := d.Unit(name); unit != "" {
+			return unit
+		}
+	}
+	return ""
+}
+
+// unionColumnNames returns the column names of datasets in order of first
+// appearance.
+func unionColumnNames(datasets []*Dataset) []string {
+	var names []string
+	for _, d := range datasets {
+		for _, c := range d.Columns {
+			if !containsString(names, c.Name) {
+				names = append(names, c.Name)
+			}
+		}
+	}
+	return names
+}
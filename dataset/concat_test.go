@@ -0,0 +1,57 @@
+package dataset_test
+
+import (
+	"errors"
+	"math"
+	"math/big"
+	"testing"
+
+	"github.com/julianshen/gonp-datareader/dataset"
+)
+
+func TestConcat(t *testing.T) {
+	fb := dataset.New("FB", "yahoo", dates("2006-01-02", "2022-06-07", "2022-06-08"))
+	if err := fb.AddExactColumn("Close", []*big.Rat{big.NewRat(195, 1), big.NewRat(196, 1)}); err != nil {
+		t.Fatal(err)
+	}
+	if err := fb.AddColumn("Volume", []float64{10, 20}); err != nil {
+		t.Fatal(err)
+	}
+	fb.Meta["stale"] = "true"
+
+	// META overlaps FB by one day; the earlier data wins
+	meta := dataset.New("META", "yahoo", dates("2006-01-02", "2022-06-08", "2022-06-09", "2022-06-10"))
+	if err := meta.AddExactColumn("Close", []*big.Rat{big.NewRat(999, 1), big.NewRat(197, 1), big.NewRat(175, 1)}); err != nil {
+		t.Fatal(err)
+	}
+	meta.Flags = []string{"", "renamed", ""}
+
+	got, err := dataset.Concat(fb, nil, meta)
+	if err != nil {
+		t.Fatalf("Concat() error = %v", err)
+	}
+
+	if got.Symbol != "META" || got.Meta["stale"] != "true" || got.Len() != 4 {
+		t.Errorf("Concat() = %s, meta %v, %d rows", got.Symbol, got.Meta, got.Len())
+	}
+	assertValues(t, got, "Close", []float64{195, 196, 197, 175})
+	assertValues(t, got, "Volume", []float64{10, 20, math.NaN(), math.NaN()})
+
+	if exact, ok := got.ExactColumn("Close"); !ok || exact[2].Cmp(big.NewRat(197, 1)) != 0 {
+		t.Errorf("ExactColumn(Close) = %v, %v", exact, ok)
+	}
+	if want := []string{"", "", "renamed", ""}; len(got.Flags) != 4 || got.Flags[2] != want[2] {
+		t.Errorf("Flags = %q, want %q", got.Flags, want)
+	}
+}
+
+func TestConcat_Errors(t *testing.T) {
+	if _, err := dataset.Concat(); err == nil {
+		t.Error("Concat() expected error for no data")
+	}
+
+	unsorted := dataset.New("X", "yahoo", dates("2006-01-02", "2024-01-02", "2024-01-01"))
+	if _, err := dataset.Concat(unsorted); !errors.Is(err, dataset.ErrUnsorted) {
+		t.Errorf("Concat() error = %v, want ErrUnsorted", err)
+	}
+}
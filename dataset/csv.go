@@ -0,0 +1,150 @@
+package dataset
+
+import (
+	"encoding/csv"
+	"fmt"
+	"io"
+	"math"
+	"math/big"
+	"strconv"
+	"time"
+)
+
+// WriteCSV writes the dataset as CSV with a "Date" column followed by each
+// data column and, when present, a "Flags" column.
+//
+// Dates are written as YYYY-MM-DD. Columns with exact decimal values are
+// written without loss of precision; other values use the shortest
+// representation that round-trips the float64. Missing values are empty.
+func (d *Dataset) WriteCSV(w io.Writer) error {
+	cw := csv.NewWriter(w)
+
+	header := append([]string{"Date"}, d.ColumnNames()...)
+	if d.Flags != nil {
+		header = append(header, "Flags")
+	}
+	if err := cw.Write(header); err != nil {
+		return err
+	}
+
+	record := make([]string, len(header))
+	for i := 0; i < d.Len(); i++ {
+		record[0] = d.Dates[i].Format("2006-01-02")
+		for j, c := range d.Columns {
+			record[j+1] = formatValue(c, i)
+		}
+		if d.Flags != nil {
+			record[len(record)-1] = d.Flags[i]
+		}
+		if err := cw.Write(record); err != nil {
+			return err
+		}
+	}
+
+	cw.Flush()
+	return cw.Error()
+}
+
+// ReadCSV reads a dataset written by WriteCSV: a "Date" column, data
+// columns and an optional trailing "Flags" column. Empty values are NaN.
+// Exact decimal values are not restored; Meta is empty.
+func ReadCSV(r io.Reader, symbol, source string) (*Dataset, error) {
+	records, err := csv.NewReader(r).ReadAll()
+	if err != nil {
+		return nil, fmt.Errorf("read CSV: %w", err)
+	}
+	if len(records) == 0 || len(records[0]) == 0 || records[0][0] != "Date" {
+		return nil, fmt.Errorf("read CSV: first column must be Date")
+	}
+
+	header := records[0]
+	names := header[1:]
+	hasFlags := len(names) > 0 && names[len(names)-1] == "Flags"
+	if hasFlags {
+		names = names[:len(names)-1]
+	}
+
+	rows := records[1:]
+	d := New(symbol, source, make([]time.Time, len(rows)))
+	columns := make([][]float64, len(names))
+	for j := range columns {
+		columns[j] = make([]float64, len(rows))
+	}
+	if hasFlags {
+		d.Flags = make([]string, len(rows))
+	}
+
+	for i, record := range rows {
+		if d.Dates[i], err = ParseDate(record[0]); err != nil {
+			return nil, fmt.Errorf("read CSV: row %d: %w", i+2, err)
+		}
+		for j := range names {
+			if record[j+1] == "" {
+				columns[j][i] = math.NaN()
+				continue
+			}
+			if columns[j][i], err = strconv.ParseFloat(record[j+1], 64); err != nil {
+				return nil, fmt.Errorf("read CSV: row %d column %q: %w", i+2, names[j], err)
+			}
+		}
+		if hasFlags {
+			d.Flags[i] = record[len(record)-1]
+		}
+	}
+
+	for j, name := range names {
+		if err := d.AddColumn(name, columns[j]); err != nil {
+			return nil, fmt.Errorf("read CSV: %w", err)
+		}
+	}
+	return d, nil
+}
+
+// FormatRat formats r as a decimal string. Terminating decimals are
+// written exactly ("0.12345678"); other values are rounded to 18 places.
+func FormatRat(r *big.Rat) string {
+	if r == nil {
+		return ""
+	}
+	if r.IsInt() {
+		return r.Num().String()
+	}
+
+	// A rational has a terminating decimal expansion iff its reduced
+	// denominator has no prime factors other than 2 and 5.
+	den := new(big.Int).Set(r.Denom())
+	two, five := big.NewInt(2), big.NewInt(5)
+	places := 0
+	for _, p := range []*big.Int{two, five} {
+		n := 0
+		mod := new(big.Int)
+		for {
+			q, m := new(big.Int).QuoRem(den, p, mod)
+			if m.Sign() != 0 {
+				break
+			}
+			den = q
+			n++
+		}
+		if n > places {
+			places = n
+		}
+	}
+	if den.Cmp(big.NewInt(1)) != 0 {
+		places = 18
+	}
+
+	return r.FloatString(places)
+}
+
+// formatValue formats row i of column c for export.
+func formatValue(c Column, i int) string {
+	if c.Exact != nil {
+		return FormatRat(c.Exact[i])
+	}
+	v := c.Values[i]
+	if math.IsNaN(v) {
+		return ""
+	}
+	return strconv.FormatFloat(v, 'f', -1, 64)
+}
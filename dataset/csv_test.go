@@ -0,0 +1,94 @@
+package dataset_test
+
+import (
+	"math"
+	"math/big"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/julianshen/gonp-datareader/dataset"
+)
+
+func TestDataset_WriteCSV(t *testing.T) {
+	ds := dataset.New("BTC", "test", []time.Time{day(1), day(2)})
+
+	price, _ := new(big.Rat).SetString("0.123456789012345678")
+	if err := ds.AddExactColumn("Close", []*big.Rat{price, nil}); err != nil {
+		t.Fatal(err)
+	}
+	if err := ds.AddColumn("Volume", []float64{1500, math.NaN()}); err != nil {
+		t.Fatal(err)
+	}
+	ds.Flags = []string{"", "E"}
+
+	var b strings.Builder
+	if err := ds.WriteCSV(&b); err != nil {
+		t.Fatalf("WriteCSV() error = %v", err)
+	}
+
+	want := "Date,Close,Volume,Flags\n" +
+		"2024-01-01,0.123456789012345678,1500,\n" +
+		"2024-01-02,,,E\n"
+	if b.String() != want {
+		t.Errorf("WriteCSV() =\n%s\nwant\n%s", b.String(), want)
+	}
+}
+
+func TestReadCSV(t *testing.T) {
+	ds := dataset.New("BTC", "test", []time.Time{day(1), day(2)})
+	if err := ds.AddColumn("Close", []float64{42.5, math.NaN()}); err != nil {
+		t.Fatal(err)
+	}
+	ds.Flags = []string{"", "E"}
+
+	var b strings.Builder
+	if err := ds.WriteCSV(&b); err != nil {
+		t.Fatal(err)
+	}
+
+	got, err := dataset.ReadCSV(strings.NewReader(b.String()), "BTC", "test")
+	if err != nil {
+		t.Fatalf("ReadCSV() error = %v", err)
+	}
+	if got.Len() != 2 || !got.Dates[1].Equal(day(2)) || got.Symbol != "BTC" {
+		t.Fatalf("ReadCSV() = %d rows %v of %q", got.Len(), got.Dates, got.Symbol)
+	}
+	closes, ok := got.Column("Close")
+	if !ok || closes[0] != 42.5 || !math.IsNaN(closes[1]) {
+		t.Errorf("Close = %v, want [42.5 NaN]", closes)
+	}
+	if got.Flags[1] != "E" {
+		t.Errorf("Flags = %q, want E on the second row", got.Flags)
+	}
+
+	for _, bad := range []string{"", "Close\n1\n", "Date,Close\n2024-01-01,x\n", "Date,Close\nyesterday,1\n"} {
+		if _, err := dataset.ReadCSV(strings.NewReader(bad), "BTC", "test"); err == nil {
+			t.Errorf("ReadCSV(%q) should fail", bad)
+		}
+	}
+}
+
+func TestFormatRat(t *testing.T) {
+	tests := []struct {
+		input string
+		want  string
+	}{
+		{"42", "42"},
+		{"-7/2", "-3.5"},
+		{"12345.12345678", "12345.12345678"},
+		{"1/8", "0.125"},
+		{"1/3", "0.333333333333333333"},
+	}
+
+	for _, tt := range tests {
+		r, _ := new(big.Rat).SetString(tt.input)
+		if got := dataset.FormatRat(r); got != tt.want {
+			t.Errorf("FormatRat(%s) = %q, want %q", tt.input, got, tt.want)
+		}
+	}
+
+	if dataset.FormatRat(nil) != "" {
+		t.Error("expected empty string for nil")
+	}
+}
@@ -0,0 +1,342 @@
+// Package dataset provides a source-agnostic, column-oriented representation
+// of time series data returned by the datareader sources.
+//
+// Every source returns its own ParsedData type; a Dataset normalizes those
+// into a date index plus named float64 columns so that analysis code
+// (diffing, joining, statistics) can work uniformly across sources.
+// Missing observations are represented as NaN.
+package dataset
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"math/big"
+	"time"
+
+	"github.com/julianshen/gonp-datareader/internal/utils"
+)
+
+var (
+	// ErrLengthMismatch is returned when a column's length does not match the date index.
+	ErrLengthMismatch = errors.New("column length does not match date index")
+	// ErrDuplicateColumn is returned when adding a column whose name already exists.
+	ErrDuplicateColumn = errors.New("duplicate column")
+	// ErrNoColumn is returned when a named column does not exist.
+	ErrNoColumn = errors.New("column not found")
+)
+
+// Common units of Column.Unit. Prices use ISO 4217 currency codes
+// ("USD", "TWD"); other units are kept as the source publishes them
+// (e.g., FRED's "Billions of Dollars").
+const (
+	// UnitPercent is a percentage or percentage rate.
+	UnitPercent = "%"
+	// UnitIndex is an index level in index points.
+	UnitIndex = "index points"
+	// UnitShares is a number of shares, as in volume columns.
+	UnitShares = "shares"
+	// UnitPersons is a number of persons.
+	UnitPersons = "persons"
+)
+
+const (
+	// MetaUnit is the Meta key of the unit of a single-series dataset,
+	// such as a FRED series or a World Bank indicator, as the source
+	// publishes it.
+	MetaUnit = "unit"
+	// MetaCurrency is the Meta key of the currency prices are quoted in.
+	MetaCurrency = "currency"
+)
+
+// Column is a named series of values aligned with a Dataset's date index.
+type Column struct {
+	Name   string
+	Values []float64
+	// Exact holds exact decimal values when the dataset was built in
+	// decimal mode; nil otherwise. Missing values are nil entries.
+	// Values always carries the float64 approximation.
+	Exact []*big.Rat
+	// Unit is the unit of the values, such as a currency code for prices
+	// ("USD"), UnitPercent or UnitShares; empty when unknown.
+	Unit string
+}
+
+// Dataset is a date-indexed table of numeric columns for a single symbol.
+type Dataset struct {
+	// Symbol is the symbol or series identifier the data belongs to.
+	Symbol string
+	// Source is the data source identifier (e.g., "yahoo", "fred").
+	Source string
+	// Dates is the row index. Sources return it in ascending order.
+	Dates []time.Time
+	// Columns holds the numeric columns in source order.
+	Columns []Column
+	// Flags holds optional per-row upstream quality flags; nil when absent.
+	Flags []string
+	// Meta holds free-form metadata about the dataset.
+	Meta map[string]string
+}
+
+// New creates an empty Dataset with the given date index.
+func New(symbol, source string, dates []time.Time) *Dataset {
+	return &Dataset{
+		Symbol: symbol,
+		Source: source,
+		Dates:  dates,
+		Meta:   make(map[string]string),
+	}
+}
+
+// Len returns the number of rows in the dataset.
+func (d *Dataset) Len() int {
+	if d == nil {
+		return 0
+	}
+	return len(d.Dates)
+}
+
+// AddColumn appends a named column. The values must align with Dates.
+func (d *Dataset) AddColumn(name string, values []float64) error {
+	if len(values) != len(d.Dates) {
+		return fmt.Errorf("%w: column %q has %d values, index has %d",
+			ErrLengthMismatch, name, len(values), len(d.Dates))
+	}
+	if _, ok := d.Column(name); ok {
+		return fmt.Errorf("%w: %q", ErrDuplicateColumn, name)
+	}
+
+	d.Columns = append(d.Columns, Column{Name: name, Values: values})
+	return nil
+}
+
+// AddExactColumn appends a named column of exact decimal values. The
+// float64 Values are derived from the exact values; nil entries are
+// missing and become NaN.
+func (d *Dataset) AddExactColumn(name string, exact []*big.Rat) error {
+	values := make([]float64, len(exact))
+	for i, r := range exact {
+		if r == nil {
+			values[i] = math.NaN()
+			continue
+		}
+		values[i], _ = r.Float64()
+	}
+
+	if err := d.AddColumn(name, values); err != nil {
+		return err
+	}
+	d.Columns[len(d.Columns)-1].Exact = exact
+	return nil
+}
+
+// ExactColumn returns the exact decimal values of the named column. It
+// returns false if the column does not exist or has no exact values.
+func (d *Dataset) ExactColumn(name string) ([]*big.Rat, bool) {
+	if d == nil {
+		return nil, false
+	}
+	for _, c := range d.Columns {
+		if c.Name == name && c.Exact != nil {
+			return c.Exact, true
+		}
+	}
+	return nil, false
+}
+
+// Column returns the values of the named column and whether it exists.
+func (d *Dataset) Column(name string) ([]float64, bool) {
+	if d == nil {
+		return nil, false
+	}
+	for _, c := range d.Columns {
+		if c.Name == name {
+			return c.Values, true
+		}
+	}
+	return nil, false
+}
+
+// ValidMask returns the validity mask of the named column: false where a
+// value is missing (NaN). The second result reports whether the column
+// exists.
+func (d *Dataset) ValidMask(name string) ([]bool, bool) {
+	values, ok := d.Column(name)
+	if !ok {
+		return nil, false
+	}
+	return ValidMask(values), true
+}
+
+// ValidMask returns the validity mask of values: true where a value is
+// present, false where it is missing (NaN).
+func ValidMask(values []float64) []bool {
+	valid := make([]bool, len(values))
+	for i, v := range values {
+		valid[i] = !math.IsNaN(v)
+	}
+	return valid
+}
+
+// ColumnNames returns the column names in order.
+func (d *Dataset) ColumnNames() []string {
+	if d == nil {
+		return nil
+	}
+	names := make([]string, len(d.Columns))
+	for i, c := range d.Columns {
+		names[i] = c.Name
+	}
+	return names
+}
+
+// Unit returns the unit of the named column; empty when the column does
+// not exist or its unit is unknown.
+func (d *Dataset) Unit(name string) string {
+	if d == nil {
+		return ""
+	}
+	for _, c := range d.Columns {
+		if c.Name == name {
+			return c.Unit
+		}
+	}
+	return ""
+}
+
+// SetUnit sets the unit of the named column.
+func (d *Dataset) SetUnit(name, unit string) error {
+	for i := range d.Columns {
+		if d.Columns[i].Name == name {
+			d.Columns[i].Unit = unit
+			return nil
+		}
+	}
+	return fmt.Errorf("%w: %q", ErrNoColumn, name)
+}
+
+// Row returns the values of every column at row i, keyed by column name.
+func (d *Dataset) Row(i int) map[string]float64 {
+	row := make(map[string]float64, len(d.Columns))
+	for _, c := range d.Columns {
+		row[c.Name] = c.Values[i]
+	}
+	return row
+}
+
+// IndexOf returns the row index of date, or -1 if it is not present.
+func (d *Dataset) IndexOf(date time.Time) int {
+	if d == nil {
+		return -1
+	}
+	for i, t := range d.Dates {
+		if t.Equal(date) {
+			return i
+		}
+	}
+	return -1
+}
+
+// Clone returns a deep copy of the dataset.
+func (d *Dataset) Clone() *Dataset {
+	if d == nil {
+		return nil
+	}
+
+	clone := New(d.Symbol, d.Source, append([]time.Time(nil), d.Dates...))
+	for _, c := range d.Columns {
+		clone.Columns = append(clone.Columns, Column{
+			Name:   c.Name,
+			Values: append([]float64(nil), c.Values...),
+			Exact:  cloneRats(c.Exact),
+			Unit:   c.Unit,
+		})
+	}
+	if d.Flags != nil {
+		clone.Flags = append([]string(nil), d.Flags...)
+	}
+	for k, v := range d.Meta {
+		clone.Meta[k] = v
+	}
+	return clone
+}
+
+// Between returns a copy of the rows dated from start through end,
+// inclusive.
+func (d *Dataset) Between(start, end time.Time) *Dataset {
+	if d == nil {
+		return nil
+	}
+
+	var rows []int
+	for i, t := range d.Dates {
+		if !t.Before(start) && !t.After(end) {
+			rows = append(rows, i)
+		}
+	}
+	return d.selectRows(rows)
+}
+
+// BetweenDays returns a copy of the rows dated on the calendar days from
+// start's date through end's date, whatever their time of day. Each date
+// counts on its own day in its own location, so exchange-local or intraday
+// timestamps on the end date are kept, unlike with Between.
+func (d *Dataset) BetweenDays(start, end time.Time) *Dataset {
+	if d == nil {
+		return nil
+	}
+
+	days := utils.NewDayRange(start, end)
+	var rows []int
+	for i, t := range d.Dates {
+		if days.Contains(t) {
+			rows = append(rows, i)
+		}
+	}
+	return d.selectRows(rows)
+}
+
+// selectRows returns a copy of the given rows, in that order.
+func (d *Dataset) selectRows(rows []int) *Dataset {
+	out := New(d.Symbol, d.Source, make([]time.Time, len(rows)))
+	for j, i := range rows {
+		out.Dates[j] = d.Dates[i]
+	}
+	for _, c := range d.Columns {
+		col := Column{Name: c.Name, Values: make([]float64, len(rows)), Unit: c.Unit}
+		if c.Exact != nil {
+			col.Exact = make([]*big.Rat, len(rows))
+		}
+		for j, i := range rows {
+			col.Values[j] = c.Values[i]
+			if c.Exact != nil && c.Exact[i] != nil {
+				col.Exact[j] = new(big.Rat).Set(c.Exact[i])
+			}
+		}
+		out.Columns = append(out.Columns, col)
+	}
+	if d.Flags != nil {
+		out.Flags = make([]string, len(rows))
+		for j, i := range rows {
+			out.Flags[j] = d.Flags[i]
+		}
+	}
+	for k, v := range d.Meta {
+		out.Meta[k] = v
+	}
+	return out
+}
+
+// cloneRats returns a deep copy of rats, preserving nil entries.
+func cloneRats(rats []*big.Rat) []*big.Rat {
+	if rats == nil {
+		return nil
+	}
+	out := make([]*big.Rat, len(rats))
+	for i, r := range rats {
+		if r != nil {
+			out[i] = new(big.Rat).Set(r)
+		}
+	}
+	return out
+}
@@ -0,0 +1,223 @@
+package dataset_test
+
+import (
+	"errors"
+	"math"
+	"math/big"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/julianshen/gonp-datareader/dataset"
+)
+
+func day(d int) time.Time {
+	return time.Date(2024, 1, d, 0, 0, 0, 0, time.UTC)
+}
+
+func TestDataset_AddColumn(t *testing.T) {
+	ds := dataset.New("AAPL", "yahoo", []time.Time{day(1), day(2)})
+
+	if err := ds.AddColumn("Close", []float64{1, 2}); err != nil {
+		t.Fatalf("AddColumn() error = %v", err)
+	}
+
+	if err := ds.AddColumn("Open", []float64{1}); !errors.Is(err, dataset.ErrLengthMismatch) {
+		t.Errorf("expected ErrLengthMismatch, got %v", err)
+	}
+
+	if err := ds.AddColumn("Close", []float64{3, 4}); !errors.Is(err, dataset.ErrDuplicateColumn) {
+		t.Errorf("expected ErrDuplicateColumn, got %v", err)
+	}
+
+	values, ok := ds.Column("Close")
+	if !ok || len(values) != 2 || values[1] != 2 {
+		t.Errorf("Column(Close) = %v, %v", values, ok)
+	}
+
+	if _, ok := ds.Column("Missing"); ok {
+		t.Error("expected missing column lookup to fail")
+	}
+
+	if ds.Len() != 2 {
+		t.Errorf("Len() = %d, want 2", ds.Len())
+	}
+}
+
+func TestDataset_RowAndIndexOf(t *testing.T) {
+	ds := dataset.New("AAPL", "yahoo", []time.Time{day(1), day(2)})
+	_ = ds.AddColumn("Open", []float64{10, 20})
+	_ = ds.AddColumn("Close", []float64{11, 21})
+
+	if idx := ds.IndexOf(day(2)); idx != 1 {
+		t.Errorf("IndexOf(day 2) = %d, want 1", idx)
+	}
+	if idx := ds.IndexOf(day(5)); idx != -1 {
+		t.Errorf("IndexOf(day 5) = %d, want -1", idx)
+	}
+
+	row := ds.Row(1)
+	if row["Open"] != 20 || row["Close"] != 21 {
+		t.Errorf("Row(1) = %v", row)
+	}
+
+	names := ds.ColumnNames()
+	if len(names) != 2 || names[0] != "Open" || names[1] != "Close" {
+		t.Errorf("ColumnNames() = %v", names)
+	}
+}
+
+func TestDataset_Clone(t *testing.T) {
+	ds := dataset.New("AAPL", "yahoo", []time.Time{day(1)})
+	_ = ds.AddColumn("Close", []float64{1})
+	ds.Flags = []string{"E"}
+	ds.Meta["k"] = "v"
+
+	clone := ds.Clone()
+	clone.Columns[0].Values[0] = 99
+	clone.Flags[0] = "X"
+	clone.Meta["k"] = "changed"
+
+	if ds.Columns[0].Values[0] != 1 || ds.Flags[0] != "E" || ds.Meta["k"] != "v" {
+		t.Error("Clone() shares state with the original")
+	}
+}
+
+func TestDataset_Unit(t *testing.T) {
+	ds := dataset.New("AAPL", "yahoo", []time.Time{day(1), day(2)})
+	_ = ds.AddColumn("Close", []float64{1, 2})
+
+	if err := ds.SetUnit("Close", "USD"); err != nil {
+		t.Fatalf("SetUnit() error = %v", err)
+	}
+	if err := ds.SetUnit("Open", "USD"); !errors.Is(err, dataset.ErrNoColumn) {
+		t.Errorf("SetUnit(Open) error = %v, want ErrNoColumn", err)
+	}
+
+	// Copies keep the unit
+	for name, d := range map[string]*dataset.Dataset{
+		"Clone":   ds.Clone(),
+		"Between": ds.Between(day(2), day(2)),
+	} {
+		if got := d.Unit("Close"); got != "USD" {
+			t.Errorf("%s: Unit(Close) = %q, want USD", name, got)
+		}
+	}
+	if got := ds.Unit("Open"); got != "" {
+		t.Errorf("Unit(Open) = %q, want empty", got)
+	}
+}
+
+func TestDataset_AddExactColumn(t *testing.T) {
+	ds := dataset.New("BTC", "test", []time.Time{day(1), day(2)})
+
+	price, _ := new(big.Rat).SetString("42123.12345678")
+	if err := ds.AddExactColumn("Close", []*big.Rat{price, nil}); err != nil {
+		t.Fatalf("AddExactColumn() error = %v", err)
+	}
+
+	values, _ := ds.Column("Close")
+	if values[0] != 42123.12345678 || !math.IsNaN(values[1]) {
+		t.Errorf("Values = %v", values)
+	}
+
+	exact, ok := ds.ExactColumn("Close")
+	if !ok || exact[0].Cmp(price) != 0 || exact[1] != nil {
+		t.Errorf("ExactColumn(Close) = %v, %v", exact, ok)
+	}
+
+	// Clone deep-copies exact values
+	clone := ds.Clone()
+	cloned, _ := clone.ExactColumn("Close")
+	cloned[0].SetInt64(1)
+	if exact[0].Cmp(price) != 0 {
+		t.Error("mutating clone changed original exact value")
+	}
+
+	if err := ds.AddColumn("Volume", []float64{1, 2}); err != nil {
+		t.Fatal(err)
+	}
+	if _, ok := ds.ExactColumn("Volume"); ok {
+		t.Error("expected float-only column to have no exact values")
+	}
+}
+
+func TestDataset_ValidMask(t *testing.T) {
+	ds := dataset.New("DGS10", "fred", []time.Time{day(1), day(2), day(3)})
+	if err := ds.AddColumn("Value", []float64{4.1, math.NaN(), 4.2}); err != nil {
+		t.Fatal(err)
+	}
+
+	valid, ok := ds.ValidMask("Value")
+	if !ok || !reflect.DeepEqual(valid, []bool{true, false, true}) {
+		t.Errorf("ValidMask() = %v, %v, want [true false true]", valid, ok)
+	}
+	if _, ok := ds.ValidMask("Close"); ok {
+		t.Error("ValidMask() of a missing column should report false")
+	}
+}
+
+func TestDataset_NilSafe(t *testing.T) {
+	var ds *dataset.Dataset
+
+	if ds.Len() != 0 {
+		t.Error("nil dataset should have length 0")
+	}
+	if _, ok := ds.Column("x"); ok {
+		t.Error("nil dataset should have no columns")
+	}
+	if ds.Clone() != nil {
+		t.Error("Clone() of nil should be nil")
+	}
+}
+
+func TestDataset_Between(t *testing.T) {
+	ds := dataset.New("AAPL", "yahoo", []time.Time{day(1), day(2), day(3), day(4)})
+	if err := ds.AddExactColumn("Close", []*big.Rat{big.NewRat(1, 1), nil, big.NewRat(3, 1), big.NewRat(4, 1)}); err != nil {
+		t.Fatal(err)
+	}
+	ds.Flags = []string{"a", "b", "c", "d"}
+
+	got := ds.Between(day(2), day(3))
+	if got.Len() != 2 || !got.Dates[0].Equal(day(2)) {
+		t.Fatalf("Between() dates = %v", got.Dates)
+	}
+	exact, _ := got.ExactColumn("Close")
+	if exact[0] != nil || exact[1].Cmp(big.NewRat(3, 1)) != 0 {
+		t.Errorf("Between() exact = %v", exact)
+	}
+	if got.Flags[1] != "c" {
+		t.Errorf("Between() flags = %v", got.Flags)
+	}
+	if ds.Between(day(5), day(6)).Len() != 0 {
+		t.Error("Between() outside the index should be empty")
+	}
+}
+
+func TestDataset_BetweenDays(t *testing.T) {
+	newYork, err := time.LoadLocation("America/New_York")
+	if err != nil {
+		t.Skipf("time zone data unavailable: %v", err)
+	}
+	// Closes in New York around the 2024-03-10 DST change, after 20:00
+	// local time so the UTC date is the next day
+	dates := []time.Time{
+		time.Date(2024, 3, 8, 20, 0, 0, 0, newYork),
+		time.Date(2024, 3, 11, 20, 0, 0, 0, newYork),
+		time.Date(2024, 3, 12, 20, 0, 0, 0, newYork),
+	}
+	ds := dataset.New("AAPL", "yahoo", dates)
+	if err := ds.AddColumn("Close", []float64{1, 2, 3}); err != nil {
+		t.Fatal(err)
+	}
+
+	start := time.Date(2024, 3, 8, 0, 0, 0, 0, time.UTC)
+	end := time.Date(2024, 3, 11, 0, 0, 0, 0, time.UTC)
+	got := ds.BetweenDays(start, end)
+	if got.Len() != 2 || !got.Dates[1].Equal(dates[1]) {
+		t.Errorf("BetweenDays() dates = %v, want the 8th and 11th", got.Dates)
+	}
+	if ds.Between(start, end).Len() != 1 {
+		t.Errorf("Between() should compare instants and drop the 11th")
+	}
+}
@@ -0,0 +1,78 @@
+package dataset
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// dateLayouts lists the date layouts returned by the supported sources,
+// tried in order.
+var dateLayouts = []string{
+	"2006-01-02",
+	time.RFC3339,
+	"2006-01-02T15:04:05.000Z",
+	"2006-01-02 15:04:05",
+	"2006-01-02 15:04:05-07:00",
+	"2006-01",
+	"2006",
+}
+
+// ParseDate parses a date string as returned by the supported sources.
+//
+// In addition to ISO dates and timestamps it accepts period notations used
+// by statistical sources: years ("2022"), months ("2022-03", "2022M03") and
+// quarters ("2022-Q1", "2022Q1"). Periods resolve to their first day in UTC.
+func ParseDate(s string) (time.Time, error) {
+	s = strings.TrimSpace(s)
+
+	for _, layout := range dateLayouts {
+		if t, err := time.Parse(layout, s); err == nil {
+			return t, nil
+		}
+	}
+
+	if t, ok := parsePeriod(s); ok {
+		return t, nil
+	}
+
+	return time.Time{}, fmt.Errorf("unrecognized date format: %q", s)
+}
+
+// parsePeriod parses quarterly ("2022-Q1", "2022Q1") and monthly ("2022M03") periods.
+func parsePeriod(s string) (time.Time, bool) {
+	if len(s) < 6 {
+		return time.Time{}, false
+	}
+
+	year, err := strconv.Atoi(s[:4])
+	if err != nil {
+		return time.Time{}, false
+	}
+
+	rest := strings.TrimPrefix(s[4:], "-")
+	if len(rest) < 2 {
+		return time.Time{}, false
+	}
+
+	n, err := strconv.Atoi(rest[1:])
+	if err != nil {
+		return time.Time{}, false
+	}
+
+	switch rest[0] {
+	case 'Q', 'q':
+		if n < 1 || n > 4 {
+			return time.Time{}, false
+		}
+		return time.Date(year, time.Month((n-1)*3+1), 1, 0, 0, 0, 0, time.UTC), true
+	case 'M', 'm':
+		if n < 1 || n > 12 {
+			return time.Time{}, false
+		}
+		return time.Date(year, time.Month(n), 1, 0, 0, 0, 0, time.UTC), true
+	default:
+		return time.Time{}, false
+	}
+}
@@ -0,0 +1,43 @@
+package dataset_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/julianshen/gonp-datareader/dataset"
+)
+
+func TestParseDate(t *testing.T) {
+	tests := []struct {
+		input   string
+		want    time.Time
+		wantErr bool
+	}{
+		{input: "2024-03-15", want: time.Date(2024, 3, 15, 0, 0, 0, 0, time.UTC)},
+		{input: "2024-03-15T00:00:00Z", want: time.Date(2024, 3, 15, 0, 0, 0, 0, time.UTC)},
+		{input: "2024-03-15T00:00:00.000Z", want: time.Date(2024, 3, 15, 0, 0, 0, 0, time.UTC)},
+		{input: "2024-03-15 09:30:00", want: time.Date(2024, 3, 15, 9, 30, 0, 0, time.UTC)},
+		{input: "2024-03", want: time.Date(2024, 3, 1, 0, 0, 0, 0, time.UTC)},
+		{input: "2024", want: time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)},
+		{input: "2024-Q3", want: time.Date(2024, 7, 1, 0, 0, 0, 0, time.UTC)},
+		{input: "2024Q4", want: time.Date(2024, 10, 1, 0, 0, 0, 0, time.UTC)},
+		{input: "2024M02", want: time.Date(2024, 2, 1, 0, 0, 0, 0, time.UTC)},
+		{input: " 2024-01-02 ", want: time.Date(2024, 1, 2, 0, 0, 0, 0, time.UTC)},
+		{input: "2024-Q5", wantErr: true},
+		{input: "2024M13", wantErr: true},
+		{input: "abc", wantErr: true},
+		{input: "", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.input, func(t *testing.T) {
+			got, err := dataset.ParseDate(tt.input)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("ParseDate(%q) error = %v, wantErr %v", tt.input, err, tt.wantErr)
+			}
+			if !tt.wantErr && !got.Equal(tt.want) {
+				t.Errorf("ParseDate(%q) = %v, want %v", tt.input, got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,137 @@
+package dataset
+
+import (
+	"math"
+	"sort"
+	"time"
+)
+
+// Change describes a single value that differs between two datasets.
+type Change struct {
+	// Date is the row the change applies to.
+	Date time.Time
+	// Column is the name of the changed column.
+	Column string
+	// Old is the previous value (NaN if the column was absent).
+	Old float64
+	// New is the current value (NaN if the column was removed).
+	New float64
+}
+
+// DiffReport describes the differences between two pulls of the same series.
+type DiffReport struct {
+	// Added lists dates present only in the new dataset.
+	Added []time.Time
+	// Removed lists dates present only in the old dataset.
+	Removed []time.Time
+	// Changed lists values that differ on dates present in both datasets.
+	Changed []Change
+}
+
+// Empty reports whether the two datasets were identical.
+func (r *DiffReport) Empty() bool {
+	return r == nil || (len(r.Added) == 0 && len(r.Removed) == 0 && len(r.Changed) == 0)
+}
+
+// Diff compares two datasets and reports added, removed and changed observations.
+//
+// It is intended for revision tracking: re-fetch a revisable series (e.g. GDP)
+// and compare it against the previously stored pull. Rows are matched by date
+// and values are compared column by column; two NaN values are considered equal.
+// Either argument may be nil, which is treated as an empty dataset.
+func Diff(old, new *Dataset) *DiffReport {
+	report := &DiffReport{}
+
+	oldIdx := dateIndex(old)
+	newIdx := dateIndex(new)
+
+	columns := unionColumns(old, new)
+
+	if new != nil {
+		for i, date := range new.Dates {
+			j, ok := oldIdx[date.UnixNano()]
+			if !ok {
+				report.Added = append(report.Added, date)
+				continue
+			}
+
+			for _, name := range columns {
+				before := valueAt(old, name, j)
+				after := valueAt(new, name, i)
+				if !sameValue(before, after) {
+					report.Changed = append(report.Changed, Change{
+						Date:   date,
+						Column: name,
+						Old:    before,
+						New:    after,
+					})
+				}
+			}
+		}
+	}
+
+	if old != nil {
+		for _, date := range old.Dates {
+			if _, ok := newIdx[date.UnixNano()]; !ok {
+				report.Removed = append(report.Removed, date)
+			}
+		}
+	}
+
+	sortDates(report.Added)
+	sortDates(report.Removed)
+	sort.SliceStable(report.Changed, func(i, j int) bool {
+		return report.Changed[i].Date.Before(report.Changed[j].Date)
+	})
+
+	return report
+}
+
+// dateIndex maps each date (as Unix nanoseconds) to its row.
+func dateIndex(d *Dataset) map[int64]int {
+	idx := make(map[int64]int, d.Len())
+	if d == nil {
+		return idx
+	}
+	for i, date := range d.Dates {
+		idx[date.UnixNano()] = i
+	}
+	return idx
+}
+
+// unionColumns returns the column names of a followed by those only in b.
+func unionColumns(a, b *Dataset) []string {
+	seen := make(map[string]bool)
+	var names []string
+	for _, d := range []*Dataset{a, b} {
+		for _, name := range d.ColumnNames() {
+			if !seen[name] {
+				seen[name] = true
+				names = append(names, name)
+			}
+		}
+	}
+	return names
+}
+
+// valueAt returns the value of column name at row i, or NaN if absent.
+func valueAt(d *Dataset, name string, i int) float64 {
+	values, ok := d.Column(name)
+	if !ok || i >= len(values) {
+		return math.NaN()
+	}
+	return values[i]
+}
+
+// sameValue compares two values treating NaN as equal to NaN.
+func sameValue(a, b float64) bool {
+	if math.IsNaN(a) || math.IsNaN(b) {
+		return math.IsNaN(a) && math.IsNaN(b)
+	}
+	return a == b
+}
+
+// sortDates sorts dates ascending in place.
+func sortDates(dates []time.Time) {
+	sort.Slice(dates, func(i, j int) bool { return dates[i].Before(dates[j]) })
+}
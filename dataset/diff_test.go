@@ -0,0 +1,74 @@
+package dataset_test
+
+import (
+	"math"
+	"testing"
+	"time"
+
+	"github.com/julianshen/gonp-datareader/dataset"
+)
+
+func series(dates []time.Time, values []float64) *dataset.Dataset {
+	ds := dataset.New("GDP", "fred", dates)
+	_ = ds.AddColumn("Value", values)
+	return ds
+}
+
+func TestDiff(t *testing.T) {
+	old := series([]time.Time{day(1), day(2), day(3)}, []float64{100, 200, math.NaN()})
+	updated := series([]time.Time{day(2), day(3), day(4)}, []float64{205, math.NaN(), 400})
+
+	report := dataset.Diff(old, updated)
+
+	if len(report.Added) != 1 || !report.Added[0].Equal(day(4)) {
+		t.Errorf("Added = %v, want [day 4]", report.Added)
+	}
+	if len(report.Removed) != 1 || !report.Removed[0].Equal(day(1)) {
+		t.Errorf("Removed = %v, want [day 1]", report.Removed)
+	}
+	if len(report.Changed) != 1 {
+		t.Fatalf("Changed = %+v, want 1 change", report.Changed)
+	}
+
+	change := report.Changed[0]
+	if !change.Date.Equal(day(2)) || change.Column != "Value" || change.Old != 200 || change.New != 205 {
+		t.Errorf("unexpected change: %+v", change)
+	}
+	if report.Empty() {
+		t.Error("Empty() = true for differing datasets")
+	}
+}
+
+func TestDiff_Identical(t *testing.T) {
+	a := series([]time.Time{day(1), day(2)}, []float64{1, math.NaN()})
+	b := a.Clone()
+
+	if report := dataset.Diff(a, b); !report.Empty() {
+		t.Errorf("expected empty diff, got %+v", report)
+	}
+}
+
+func TestDiff_NewColumn(t *testing.T) {
+	a := series([]time.Time{day(1)}, []float64{1})
+	b := a.Clone()
+	_ = b.AddColumn("Revised", []float64{2})
+
+	report := dataset.Diff(a, b)
+	if len(report.Changed) != 1 || report.Changed[0].Column != "Revised" || !math.IsNaN(report.Changed[0].Old) {
+		t.Errorf("unexpected changes: %+v", report.Changed)
+	}
+}
+
+func TestDiff_NilInputs(t *testing.T) {
+	b := series([]time.Time{day(1)}, []float64{1})
+
+	if report := dataset.Diff(nil, b); len(report.Added) != 1 {
+		t.Errorf("Diff(nil, b).Added = %v, want 1 date", report.Added)
+	}
+	if report := dataset.Diff(b, nil); len(report.Removed) != 1 {
+		t.Errorf("Diff(b, nil).Removed = %v, want 1 date", report.Removed)
+	}
+	if report := dataset.Diff(nil, nil); !report.Empty() {
+		t.Error("Diff(nil, nil) should be empty")
+	}
+}
@@ -0,0 +1,141 @@
+package dataset
+
+import (
+	"math"
+	"math/big"
+	"strings"
+	"time"
+)
+
+// MetaFill is the Meta key recording the FillMethod applied by Fill, so
+// filled values can be told from observations.
+const MetaFill = "fill"
+
+// String returns the method name: "nan", "ffill", "bfill", "interpolate"
+// or "drop".
+func (m FillMethod) String() string {
+	switch m {
+	case FillForward:
+		return "ffill"
+	case FillBackward:
+		return "bfill"
+	case FillLinear:
+		return "interpolate"
+	case FillDrop:
+		return "drop"
+	default:
+		return "nan"
+	}
+}
+
+// ParseFillMethod parses a method name as returned by String, also
+// accepting "forward", "backward", "linear" and "none". It reports false
+// for unknown names.
+func ParseFillMethod(s string) (FillMethod, bool) {
+	switch strings.ToLower(strings.TrimSpace(s)) {
+	case "", "nan", "none":
+		return FillNaN, true
+	case "ffill", "forward":
+		return FillForward, true
+	case "bfill", "backward":
+		return FillBackward, true
+	case "interpolate", "linear":
+		return FillLinear, true
+	case "drop":
+		return FillDrop, true
+	default:
+		return FillNaN, false
+	}
+}
+
+// Fill returns a copy of the dataset with its missing (NaN) values filled
+// by method, such as FRED's "." placeholders or World Bank years without
+// a value. Dates must be in ascending order. Each column is filled on its
+// own; FillDrop instead removes every row with a NaN in any column.
+// Filled values copy the exact decimal of their source value, or hold the
+// exact value of the float64 when interpolated. Meta[MetaFill] records
+// the method unless it is FillNaN.
+func (d *Dataset) Fill(method FillMethod) *Dataset {
+	if d == nil {
+		return nil
+	}
+
+	var out *Dataset
+	if method == FillDrop {
+		var rows []int
+		for i := range d.Dates {
+			complete := true
+			for _, c := range d.Columns {
+				if math.IsNaN(c.Values[i]) {
+					complete = false
+					break
+				}
+			}
+			if complete {
+				rows = append(rows, i)
+			}
+		}
+		out = d.selectRows(rows)
+	} else {
+		out = d.Clone()
+		for c := range out.Columns {
+			fillColumn(&out.Columns[c], out.Dates, method)
+		}
+	}
+
+	if method != FillNaN {
+		out.Meta[MetaFill] = method.String()
+	}
+	return out
+}
+
+// fillColumn fills the NaN values of c in place.
+func fillColumn(c *Column, dates []time.Time, method FillMethod) {
+	n := len(c.Values)
+	switch method {
+	case FillForward:
+		last := -1
+		for i := 0; i < n; i++ {
+			if !math.IsNaN(c.Values[i]) {
+				last = i
+			} else if last >= 0 {
+				copyValue(c, i, last)
+			}
+		}
+	case FillBackward:
+		next := -1
+		for i := n - 1; i >= 0; i-- {
+			if !math.IsNaN(c.Values[i]) {
+				next = i
+			} else if next >= 0 {
+				copyValue(c, i, next)
+			}
+		}
+	case FillLinear:
+		prev := -1
+		for i := 0; i < n; i++ {
+			if math.IsNaN(c.Values[i]) {
+				continue
+			}
+			if prev >= 0 && i > prev+1 {
+				span := float64(dates[i].Sub(dates[prev]))
+				for k := prev + 1; k < i; k++ {
+					w := float64(dates[k].Sub(dates[prev])) / span
+					c.Values[k] = c.Values[prev] + w*(c.Values[i]-c.Values[prev])
+					if c.Exact != nil {
+						c.Exact[k] = new(big.Rat).SetFloat64(c.Values[k])
+					}
+				}
+			}
+			prev = i
+		}
+	}
+}
+
+// copyValue sets row i of c to the value of row from.
+func copyValue(c *Column, i, from int) {
+	c.Values[i] = c.Values[from]
+	if c.Exact != nil && c.Exact[from] != nil {
+		c.Exact[i] = new(big.Rat).Set(c.Exact[from])
+	}
+}
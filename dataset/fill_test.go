@@ -0,0 +1,62 @@
+package dataset_test
+
+import (
+	"math"
+	"testing"
+
+	"github.com/julianshen/gonp-datareader/dataset"
+)
+
+func TestDataset_Fill(t *testing.T) {
+	nan := math.NaN()
+	ds := dataset.New("DGS10", "fred", dates("2006-01-02", "2024-01-01", "2024-01-02", "2024-01-03", "2024-01-05", "2024-01-06"))
+	ds.AddColumn("Value", []float64{nan, 4, nan, 5, nan})
+
+	tests := []struct {
+		method    dataset.FillMethod
+		want      []float64
+		wantDates int
+	}{
+		{dataset.FillNaN, []float64{nan, 4, nan, 5, nan}, 5},
+		{dataset.FillForward, []float64{nan, 4, 4, 5, 5}, 5},
+		{dataset.FillBackward, []float64{4, 4, 5, 5, nan}, 5},
+		// 2024-01-03 is a third of the way from 2024-01-02 to 2024-01-05
+		{dataset.FillLinear, []float64{nan, 4, 4 + 1.0/3, 5, nan}, 5},
+		{dataset.FillDrop, []float64{4, 5}, 2},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.method.String(), func(t *testing.T) {
+			filled := ds.Fill(tt.method)
+			got, _ := filled.Column("Value")
+			if filled.Len() != tt.wantDates || len(got) != len(tt.want) {
+				t.Fatalf("Fill() = %v with %d dates, want %v", got, filled.Len(), tt.want)
+			}
+			for i := range got {
+				if math.Abs(got[i]-tt.want[i]) > 1e-12 || math.IsNaN(got[i]) != math.IsNaN(tt.want[i]) {
+					t.Errorf("Fill() = %v, want %v", got, tt.want)
+					break
+				}
+			}
+			if wantMeta := map[bool]string{true: "", false: tt.method.String()}[tt.method == dataset.FillNaN]; filled.Meta[dataset.MetaFill] != wantMeta {
+				t.Errorf("Meta[fill] = %q, want %q", filled.Meta[dataset.MetaFill], wantMeta)
+			}
+		})
+	}
+
+	// The input is left unchanged
+	if v, _ := ds.Column("Value"); !math.IsNaN(v[2]) {
+		t.Errorf("Fill() modified its input: %v", v)
+	}
+}
+
+func TestParseFillMethod(t *testing.T) {
+	for _, m := range []dataset.FillMethod{dataset.FillNaN, dataset.FillForward, dataset.FillBackward, dataset.FillLinear, dataset.FillDrop} {
+		if got, ok := dataset.ParseFillMethod(m.String()); !ok || got != m {
+			t.Errorf("ParseFillMethod(%q) = %v, %v", m.String(), got, ok)
+		}
+	}
+	if _, ok := dataset.ParseFillMethod("mean"); ok {
+		t.Error("ParseFillMethod(mean) ok = true")
+	}
+}
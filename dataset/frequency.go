@@ -0,0 +1,253 @@
+package dataset
+
+import (
+	"sort"
+	"strings"
+	"time"
+)
+
+// Meta keys describing a source's native frequency. Sources that report
+// the frequency of a series set them; Frequency and Periods prefer them
+// over inference.
+const (
+	// MetaFrequency holds the native frequency name (e.g., "monthly").
+	MetaFrequency = "frequency"
+	// MetaDateConvention holds "start" or "end": whether each date stamps
+	// the first or the last day of its observation period.
+	MetaDateConvention = "date_convention"
+)
+
+// Frequency is the observation frequency of a series.
+type Frequency int
+
+const (
+	// FrequencyUnknown is used when the frequency cannot be inferred,
+	// e.g. for series with fewer than two observations.
+	FrequencyUnknown Frequency = iota
+	// FrequencyDaily covers daily and business-day series.
+	FrequencyDaily
+	// FrequencyWeekly covers weekly series.
+	FrequencyWeekly
+	// FrequencyMonthly covers monthly series.
+	FrequencyMonthly
+	// FrequencyQuarterly covers quarterly series.
+	FrequencyQuarterly
+	// FrequencyAnnual covers annual series.
+	FrequencyAnnual
+)
+
+// String returns the frequency name (e.g., "monthly").
+func (f Frequency) String() string {
+	switch f {
+	case FrequencyDaily:
+		return "daily"
+	case FrequencyWeekly:
+		return "weekly"
+	case FrequencyMonthly:
+		return "monthly"
+	case FrequencyQuarterly:
+		return "quarterly"
+	case FrequencyAnnual:
+		return "annual"
+	default:
+		return "unknown"
+	}
+}
+
+// ParseFrequency parses a frequency name as returned by String, a FRED
+// frequency (e.g., "Weekly, Ending Friday") or a FRED short code ("D",
+// "W", "M", "Q", "A"). Other values, such as "Biweekly", are
+// FrequencyUnknown.
+func ParseFrequency(s string) Frequency {
+	name, _, _ := strings.Cut(strings.TrimSpace(s), ",")
+	switch strings.ToLower(name) {
+	case "d", "daily":
+		return FrequencyDaily
+	case "w", "weekly":
+		return FrequencyWeekly
+	case "m", "monthly":
+		return FrequencyMonthly
+	case "q", "quarterly":
+		return FrequencyQuarterly
+	case "a", "annual", "yearly":
+		return FrequencyAnnual
+	default:
+		return FrequencyUnknown
+	}
+}
+
+// PeriodEnd returns the exclusive end of the period that starts at t.
+// Monthly CPI dated 2024-01-01, for example, covers [2024-01-01, 2024-02-01).
+// Daily and unknown frequencies cover a single day.
+func (f Frequency) PeriodEnd(t time.Time) time.Time {
+	switch f {
+	case FrequencyWeekly:
+		return t.AddDate(0, 0, 7)
+	case FrequencyMonthly:
+		return t.AddDate(0, 1, 0)
+	case FrequencyQuarterly:
+		return t.AddDate(0, 3, 0)
+	case FrequencyAnnual:
+		return t.AddDate(1, 0, 0)
+	default:
+		return t.AddDate(0, 0, 1)
+	}
+}
+
+// PeriodStart returns the start of the period that ends with the day t
+// (inclusive). Monthly data dated 2024-01-31, for example, covers
+// [2024-01-01, 2024-02-01). Daily and unknown frequencies cover a single day.
+func (f Frequency) PeriodStart(t time.Time) time.Time {
+	y, m, _ := t.Date()
+	switch f {
+	case FrequencyWeekly:
+		return t.AddDate(0, 0, -6)
+	case FrequencyMonthly:
+		return time.Date(y, m, 1, 0, 0, 0, 0, t.Location())
+	case FrequencyQuarterly:
+		return time.Date(y, m-(m-1)%3, 1, 0, 0, 0, 0, t.Location())
+	case FrequencyAnnual:
+		return time.Date(y, 1, 1, 0, 0, 0, 0, t.Location())
+	default:
+		return t
+	}
+}
+
+// DateConvention tells which day of its observation period a date stamps.
+type DateConvention int
+
+const (
+	// DateAtStart stamps the first day of the period, as FRED does for
+	// monthly, quarterly and annual series (CPI for January is 2024-01-01).
+	DateAtStart DateConvention = iota
+	// DateAtEnd stamps the last day of the period, as FRED does for weekly
+	// series (a week ending Saturday 2024-01-06 covers 2023-12-31 through
+	// 2024-01-06).
+	DateAtEnd
+)
+
+// String returns "start" or "end".
+func (c DateConvention) String() string {
+	if c == DateAtEnd {
+		return "end"
+	}
+	return "start"
+}
+
+// ParseDateConvention parses "start" or "end"; anything else is
+// DateAtStart.
+func ParseDateConvention(s string) DateConvention {
+	if strings.EqualFold(strings.TrimSpace(s), "end") {
+		return DateAtEnd
+	}
+	return DateAtStart
+}
+
+// Period is the span of time an observation covers, from Start up to but
+// excluding End. A point-in-time value, such as a daily close, covers a
+// single day; a monthly average covers its month.
+type Period struct {
+	Start time.Time
+	End   time.Time
+}
+
+// Period returns the period covered by an observation dated t.
+func (f Frequency) Period(t time.Time, c DateConvention) Period {
+	if c == DateAtEnd {
+		return Period{Start: f.PeriodStart(t), End: t.AddDate(0, 0, 1)}
+	}
+	return Period{Start: t, End: f.PeriodEnd(t)}
+}
+
+// InferFrequency infers the frequency of ascending dates from the median
+// spacing between consecutive observations. Business-day gaps (weekends,
+// holidays) are classified as daily.
+func InferFrequency(dates []time.Time) Frequency {
+	if len(dates) < 2 {
+		return FrequencyUnknown
+	}
+
+	gaps := make([]float64, 0, len(dates)-1)
+	for i := 1; i < len(dates); i++ {
+		gaps = append(gaps, dates[i].Sub(dates[i-1]).Hours()/24)
+	}
+	sort.Float64s(gaps)
+	median := gaps[len(gaps)/2]
+
+	switch {
+	case median <= 0:
+		return FrequencyUnknown
+	case median <= 4:
+		return FrequencyDaily
+	case median <= 10:
+		return FrequencyWeekly
+	case median <= 45:
+		return FrequencyMonthly
+	case median <= 135:
+		return FrequencyQuarterly
+	default:
+		return FrequencyAnnual
+	}
+}
+
+// Frequency returns the native frequency of the dataset as reported by
+// its source in Meta[MetaFrequency], or else the frequency inferred from
+// its date index.
+func (d *Dataset) Frequency() Frequency {
+	if d == nil {
+		return FrequencyUnknown
+	}
+	if f := ParseFrequency(d.Meta[MetaFrequency]); f != FrequencyUnknown {
+		return f
+	}
+	return InferFrequency(d.Dates)
+}
+
+// DateConvention returns the date convention reported by the dataset's
+// source in Meta[MetaDateConvention]; DateAtStart when there is none.
+func (d *Dataset) DateConvention() DateConvention {
+	if d == nil {
+		return DateAtStart
+	}
+	return ParseDateConvention(d.Meta[MetaDateConvention])
+}
+
+// Periods returns the observation period of each row, from the dataset's
+// frequency and date convention.
+func (d *Dataset) Periods() []Period {
+	if d == nil {
+		return nil
+	}
+	freq, conv := d.Frequency(), d.DateConvention()
+	periods := make([]Period, len(d.Dates))
+	for i, t := range d.Dates {
+		periods[i] = freq.Period(t, conv)
+	}
+	return periods
+}
+
+// WithDateConvention returns a copy of the dataset whose dates stamp the
+// start or the last day of each observation period, so series of
+// different conventions can be compared: weekly data dated by its ending
+// Saturday is restamped to the preceding Sunday with DateAtStart, and
+// monthly data dated 2024-01-01 to 2024-01-31 with DateAtEnd.
+// The frequency is recorded in Meta so it is no longer inferred.
+func (d *Dataset) WithDateConvention(c DateConvention) *Dataset {
+	if d == nil {
+		return nil
+	}
+	periods := d.Periods()
+	out := d.Clone()
+	for i, p := range periods {
+		if c == DateAtEnd {
+			out.Dates[i] = p.End.AddDate(0, 0, -1)
+		} else {
+			out.Dates[i] = p.Start
+		}
+	}
+	if f := d.Frequency(); f != FrequencyUnknown {
+		out.Meta[MetaFrequency] = f.String()
+	}
+	out.Meta[MetaDateConvention] = c.String()
+	return out
+}
@@ -0,0 +1,199 @@
+package dataset_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/julianshen/gonp-datareader/dataset"
+)
+
+func dates(layout string, values ...string) []time.Time {
+	out := make([]time.Time, len(values))
+	for i, v := range values {
+		t, err := time.Parse(layout, v)
+		if err != nil {
+			panic(err)
+		}
+		out[i] = t
+	}
+	return out
+}
+
+func TestInferFrequency(t *testing.T) {
+	tests := []struct {
+		name  string
+		dates []time.Time
+		want  dataset.Frequency
+	}{
+		{"business days", dates("2006-01-02", "2024-01-04", "2024-01-05", "2024-01-08", "2024-01-09"), dataset.FrequencyDaily},
+		{"weekly", dates("2006-01-02", "2024-01-05", "2024-01-12", "2024-01-19"), dataset.FrequencyWeekly},
+		{"monthly", dates("2006-01-02", "2024-01-01", "2024-02-01", "2024-03-01"), dataset.FrequencyMonthly},
+		{"quarterly", dates("2006-01-02", "2023-01-01", "2023-04-01", "2023-07-01"), dataset.FrequencyQuarterly},
+		{"annual", dates("2006", "2020", "2021", "2022"), dataset.FrequencyAnnual},
+		{"single", dates("2006", "2020"), dataset.FrequencyUnknown},
+		{"empty", nil, dataset.FrequencyUnknown},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := dataset.InferFrequency(tt.dates); got != tt.want {
+				t.Errorf("InferFrequency() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestFrequency_PeriodEnd(t *testing.T) {
+	start := time.Date(2024, 1, 31, 0, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		freq dataset.Frequency
+		want time.Time
+	}{
+		{dataset.FrequencyDaily, time.Date(2024, 2, 1, 0, 0, 0, 0, time.UTC)},
+		{dataset.FrequencyWeekly, time.Date(2024, 2, 7, 0, 0, 0, 0, time.UTC)},
+		{dataset.FrequencyQuarterly, time.Date(2024, 5, 1, 0, 0, 0, 0, time.UTC)},
+		{dataset.FrequencyAnnual, time.Date(2025, 1, 31, 0, 0, 0, 0, time.UTC)},
+		{dataset.FrequencyUnknown, time.Date(2024, 2, 1, 0, 0, 0, 0, time.UTC)},
+	}
+
+	for _, tt := range tests {
+		if got := tt.freq.PeriodEnd(start); !got.Equal(tt.want) {
+			t.Errorf("%v.PeriodEnd() = %v, want %v", tt.freq, got, tt.want)
+		}
+	}
+
+	if got := dataset.FrequencyMonthly.String(); got != "monthly" {
+		t.Errorf("String() = %q", got)
+	}
+}
+
+func TestParseFrequency(t *testing.T) {
+	tests := []struct {
+		in   string
+		want dataset.Frequency
+	}{
+		{"monthly", dataset.FrequencyMonthly},
+		{"Weekly, Ending Friday", dataset.FrequencyWeekly},
+		{"Daily, Close", dataset.FrequencyDaily},
+		{"Q", dataset.FrequencyQuarterly},
+		{"A", dataset.FrequencyAnnual},
+		{"Biweekly, Ending Wednesday", dataset.FrequencyUnknown},
+		{"", dataset.FrequencyUnknown},
+	}
+
+	for _, tt := range tests {
+		if got := dataset.ParseFrequency(tt.in); got != tt.want {
+			t.Errorf("ParseFrequency(%q) = %v, want %v", tt.in, got, tt.want)
+		}
+	}
+}
+
+func TestDataset_Periods(t *testing.T) {
+	day := func(s string) time.Time { return dates("2006-01-02", s)[0] }
+
+	tests := []struct {
+		name  string
+		dates []time.Time
+		meta  map[string]string
+		want  []dataset.Period
+	}{
+		{
+			name:  "inferred monthly dated at start",
+			dates: dates("2006-01-02", "2024-01-01", "2024-02-01"),
+			want: []dataset.Period{
+				{Start: day("2024-01-01"), End: day("2024-02-01")},
+				{Start: day("2024-02-01"), End: day("2024-03-01")},
+			},
+		},
+		{
+			name:  "weekly dated at end",
+			dates: dates("2006-01-02", "2024-01-06", "2024-01-13"),
+			meta:  map[string]string{dataset.MetaFrequency: "weekly", dataset.MetaDateConvention: "end"},
+			want: []dataset.Period{
+				{Start: day("2023-12-31"), End: day("2024-01-07")},
+				{Start: day("2024-01-07"), End: day("2024-01-14")},
+			},
+		},
+		{
+			name:  "native frequency of a single observation",
+			dates: dates("2006-01-02", "2024-04-01"),
+			meta:  map[string]string{dataset.MetaFrequency: "quarterly"},
+			want:  []dataset.Period{{Start: day("2024-04-01"), End: day("2024-07-01")}},
+		},
+		{
+			name:  "quarterly dated at end",
+			dates: dates("2006-01-02", "2024-06-30"),
+			meta:  map[string]string{dataset.MetaFrequency: "quarterly", dataset.MetaDateConvention: "end"},
+			want:  []dataset.Period{{Start: day("2024-04-01"), End: day("2024-07-01")}},
+		},
+		{
+			name:  "daily points",
+			dates: dates("2006-01-02", "2024-01-02", "2024-01-03"),
+			want: []dataset.Period{
+				{Start: day("2024-01-02"), End: day("2024-01-03")},
+				{Start: day("2024-01-03"), End: day("2024-01-04")},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ds := dataset.New("X", "test", tt.dates)
+			for k, v := range tt.meta {
+				ds.Meta[k] = v
+			}
+
+			got := ds.Periods()
+			if len(got) != len(tt.want) {
+				t.Fatalf("Periods() = %v, want %v", got, tt.want)
+			}
+			for i := range tt.want {
+				if !got[i].Start.Equal(tt.want[i].Start) || !got[i].End.Equal(tt.want[i].End) {
+					t.Errorf("Periods()[%d] = %v, want %v", i, got[i], tt.want[i])
+				}
+			}
+		})
+	}
+}
+
+func TestDataset_WithDateConvention(t *testing.T) {
+	weekly := dataset.New("ICSA", "fred", dates("2006-01-02", "2024-01-06", "2024-01-13"))
+	weekly.Meta[dataset.MetaFrequency] = "weekly"
+	weekly.Meta[dataset.MetaDateConvention] = "end"
+
+	start := weekly.WithDateConvention(dataset.DateAtStart)
+	want := dates("2006-01-02", "2023-12-31", "2024-01-07")
+	for i := range want {
+		if !start.Dates[i].Equal(want[i]) {
+			t.Errorf("Dates[%d] = %v, want %v", i, start.Dates[i], want[i])
+		}
+	}
+	if start.DateConvention() != dataset.DateAtStart || start.Frequency() != dataset.FrequencyWeekly {
+		t.Errorf("convention/frequency = %v/%v, want start/weekly", start.DateConvention(), start.Frequency())
+	}
+	if !weekly.Dates[0].Equal(dates("2006-01-02", "2024-01-06")[0]) {
+		t.Error("WithDateConvention modified the original dataset")
+	}
+
+	// Monthly data restamped to the last day of each month
+	cpi := dataset.New("CPIAUCSL", "fred", dates("2006-01-02", "2024-01-01", "2024-02-01"))
+	end := cpi.WithDateConvention(dataset.DateAtEnd)
+	want = dates("2006-01-02", "2024-01-31", "2024-02-29")
+	for i := range want {
+		if !end.Dates[i].Equal(want[i]) {
+			t.Errorf("Dates[%d] = %v, want %v", i, end.Dates[i], want[i])
+		}
+	}
+	if end.Meta[dataset.MetaFrequency] != "monthly" || end.Meta[dataset.MetaDateConvention] != "end" {
+		t.Errorf("Meta = %v, want monthly/end", end.Meta)
+	}
+
+	// Periods are unchanged by restamping
+	before, after := cpi.Periods(), end.Periods()
+	for i := range before {
+		if !before[i].Start.Equal(after[i].Start) || !before[i].End.Equal(after[i].End) {
+			t.Errorf("period %d = %v, want %v", i, after[i], before[i])
+		}
+	}
+}
@@ -0,0 +1,212 @@
+package dataset
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"math/big"
+	"sort"
+	"strings"
+	"time"
+)
+
+var (
+	// ErrUnsorted is returned when a dataset's dates are not in ascending order.
+	ErrUnsorted = errors.New("dates are not in ascending order")
+)
+
+// JoinMethod selects which dates appear in a joined dataset.
+type JoinMethod int
+
+const (
+	// JoinInner keeps only dates covered by every dataset.
+	JoinInner JoinMethod = iota
+	// JoinOuter keeps every date of every dataset.
+	JoinOuter
+)
+
+// FillMethod selects how missing values, and dates without a covering
+// observation, are filled.
+type FillMethod int
+
+const (
+	// FillNaN leaves uncovered values as NaN.
+	FillNaN FillMethod = iota
+	// FillForward carries the last known non-NaN value forward.
+	FillForward
+	// FillBackward carries the next known non-NaN value backward.
+	FillBackward
+	// FillLinear interpolates linearly in time between the known values
+	// around a gap; values before the first and after the last known value
+	// stay NaN.
+	FillLinear
+	// FillDrop removes the rows with a NaN value in any column.
+	FillDrop
+)
+
+// JoinOptions configures Join.
+type JoinOptions struct {
+	// Method selects inner or outer join. Default: JoinInner
+	Method JoinMethod
+	// Fill selects how missing values are filled; methods other than
+	// FillNaN and FillForward are applied to the joined dataset with
+	// Dataset.Fill. Default: FillNaN
+	Fill FillMethod
+}
+
+// Join aligns several datasets on a common date index, for example daily
+// equity prices with monthly CPI or quarterly GDP.
+//
+// Each dataset's frequency is taken from its source or inferred from its
+// dates, and every observation is expanded over its period (see Periods): a
+// monthly value dated 2024-01-01 applies to every date in January. An inner join keeps the dates of all datasets that
+// fall within a period of every dataset; an outer join keeps every date.
+// With FillForward, values are additionally carried forward across gaps and
+// NaN observations.
+//
+// Joined columns are named "<Symbol>.<Column>" (the Source is used when the
+// Symbol is empty). Exact decimal values are carried through. Upstream flags
+// are not. Dates of each dataset must be in ascending order.
+func Join(opts JoinOptions, datasets ...*Dataset) (*Dataset, error) {
+	var inputs []*Dataset
+	for _, d := range datasets {
+		if d == nil {
+			continue
+		}
+		if !sort.SliceIsSorted(d.Dates, func(i, j int) bool { return d.Dates[i].Before(d.Dates[j]) }) {
+			return nil, fmt.Errorf("%w: %s", ErrUnsorted, label(d))
+		}
+		inputs = append(inputs, d)
+	}
+
+	periods := make([][]Period, len(inputs))
+	for i, d := range inputs {
+		periods[i] = d.Periods()
+	}
+
+	// Build the target index from the union of all dates
+	seen := make(map[int64]bool)
+	var index []time.Time
+	for _, d := range inputs {
+		for _, t := range d.Dates {
+			if !seen[t.UnixNano()] {
+				seen[t.UnixNano()] = true
+				index = append(index, t)
+			}
+		}
+	}
+	sort.Slice(index, func(i, j int) bool { return index[i].Before(index[j]) })
+
+	if opts.Method == JoinInner {
+		kept := index[:0]
+		for _, t := range index {
+			covered := true
+			for _, p := range periods {
+				if coveringRow(p, t) < 0 {
+					covered = false
+					break
+				}
+			}
+			if covered {
+				kept = append(kept, t)
+			}
+		}
+		index = kept
+	}
+
+	symbols := make([]string, len(inputs))
+	sources := make([]string, 0, len(inputs))
+	for i, d := range inputs {
+		symbols[i] = d.Symbol
+		if !containsString(sources, d.Source) {
+			sources = append(sources, d.Source)
+		}
+	}
+
+	out := New(strings.Join(symbols, ","), strings.Join(sources, ","), index)
+	for i, d := range inputs {
+		rows := alignRows(periods[i], index)
+		for _, c := range d.Columns {
+			name := label(d) + "." + c.Name
+
+			values := make([]float64, len(index))
+			var exact []*big.Rat
+			if c.Exact != nil {
+				exact = make([]*big.Rat, len(index))
+			}
+
+			last := -1
+			for k, row := range rows {
+				if row >= 0 && !math.IsNaN(c.Values[row]) {
+					last = row
+				}
+
+				src := row
+				if opts.Fill == FillForward && (row < 0 || math.IsNaN(c.Values[row])) {
+					src = last
+				}
+
+				if src < 0 {
+					values[k] = math.NaN()
+					continue
+				}
+				values[k] = c.Values[src]
+				if exact != nil {
+					exact[k] = c.Exact[src]
+				}
+			}
+
+			if err := out.AddColumn(name, values); err != nil {
+				return nil, err
+			}
+			out.Columns[len(out.Columns)-1].Exact = exact
+			out.Columns[len(out.Columns)-1].Unit = c.Unit
+		}
+	}
+
+	if opts.Fill != FillNaN && opts.Fill != FillForward {
+		return out.Fill(opts.Fill), nil
+	}
+	return out, nil
+}
+
+// alignRows maps each date in index to the row whose period covers it, or
+// -1 when no observation covers the date.
+func alignRows(periods []Period, index []time.Time) []int {
+	rows := make([]int, len(index))
+	for k, t := range index {
+		rows[k] = coveringRow(periods, t)
+	}
+	return rows
+}
+
+// coveringRow returns the row whose period contains t, or -1.
+func coveringRow(periods []Period, t time.Time) int {
+	// Last period starting at or before t
+	i := sort.Search(len(periods), func(i int) bool { return periods[i].Start.After(t) }) - 1
+	if i < 0 {
+		return -1
+	}
+	if !t.Before(periods[i].End) {
+		return -1
+	}
+	return i
+}
+
+// label returns the column prefix used for d in joined output.
+func label(d *Dataset) string {
+	if d.Symbol != "" {
+		return d.Symbol
+	}
+	return d.Source
+}
+
+// containsString reports whether s is in list.
+func containsString(list []string, s string) bool {
+	for _, v := range list {
+		if v == s {
+			return true
+		}
+	}
+	return false
+}
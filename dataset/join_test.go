@@ -0,0 +1,188 @@
+package dataset_test
+
+import (
+	"errors"
+	"math"
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/julianshen/gonp-datareader/dataset"
+)
+
+// equityAndCPI returns business-day prices spanning a month boundary and
+// monthly CPI observations.
+func equityAndCPI(t *testing.T) (*dataset.Dataset, *dataset.Dataset) {
+	t.Helper()
+
+	equity := dataset.New("AAPL", "yahoo", dates("2006-01-02",
+		"2024-01-30", "2024-01-31", "2024-02-01", "2024-02-02", "2024-02-05"))
+	if err := equity.AddColumn("Close", []float64{1, 2, 3, 4, 5}); err != nil {
+		t.Fatal(err)
+	}
+
+	cpi := dataset.New("CPIAUCSL", "fred", dates("2006-01-02",
+		"2023-12-01", "2024-01-01", "2024-02-01"))
+	if err := cpi.AddColumn("Value", []float64{306.7, 308.4, 310.3}); err != nil {
+		t.Fatal(err)
+	}
+
+	return equity, cpi
+}
+
+func assertValues(t *testing.T, ds *dataset.Dataset, column string, want []float64) {
+	t.Helper()
+
+	got, ok := ds.Column(column)
+	if !ok {
+		t.Fatalf("column %q not found in %v", column, ds.ColumnNames())
+	}
+	if len(got) != len(want) {
+		t.Fatalf("%s = %v, want %v", column, got, want)
+	}
+	for i := range want {
+		if math.IsNaN(want[i]) != math.IsNaN(got[i]) || (!math.IsNaN(want[i]) && got[i] != want[i]) {
+			t.Errorf("%s = %v, want %v", column, got, want)
+			return
+		}
+	}
+}
+
+func TestJoin_InnerExpandsPeriods(t *testing.T) {
+	equity, cpi := equityAndCPI(t)
+
+	joined, err := dataset.Join(dataset.JoinOptions{}, equity, cpi)
+	if err != nil {
+		t.Fatalf("Join() error = %v", err)
+	}
+
+	if joined.Len() != 5 {
+		t.Fatalf("Len() = %d, want 5 trading days", joined.Len())
+	}
+	if joined.Symbol != "AAPL,CPIAUCSL" || joined.Source != "yahoo,fred" {
+		t.Errorf("unexpected identity %s/%s", joined.Source, joined.Symbol)
+	}
+	assertValues(t, joined, "AAPL.Close", []float64{1, 2, 3, 4, 5})
+	assertValues(t, joined, "CPIAUCSL.Value", []float64{308.4, 308.4, 310.3, 310.3, 310.3})
+}
+
+func TestJoin_Outer(t *testing.T) {
+	equity, cpi := equityAndCPI(t)
+
+	joined, err := dataset.Join(dataset.JoinOptions{Method: dataset.JoinOuter}, equity, cpi)
+	if err != nil {
+		t.Fatalf("Join() error = %v", err)
+	}
+
+	// 2023-12-01, 2024-01-01 and the five trading days
+	if joined.Len() != 7 {
+		t.Fatalf("Len() = %d, want 7", joined.Len())
+	}
+	nan := math.NaN()
+	assertValues(t, joined, "AAPL.Close", []float64{nan, nan, 1, 2, 3, 4, 5})
+	assertValues(t, joined, "CPIAUCSL.Value", []float64{306.7, 308.4, 308.4, 308.4, 310.3, 310.3, 310.3})
+}
+
+func TestJoin_FillForwardAcrossGaps(t *testing.T) {
+	a := dataset.New("A", "test", dates("2006-01-02", "2024-01-01", "2024-01-02", "2024-01-03", "2024-01-04"))
+	if err := a.AddColumn("X", []float64{1, 2, 3, 4}); err != nil {
+		t.Fatal(err)
+	}
+	b := dataset.New("B", "test", dates("2006-01-02", "2024-01-01", "2024-01-03", "2024-01-04"))
+	if err := b.AddColumn("Y", []float64{10, math.NaN(), 30}); err != nil {
+		t.Fatal(err)
+	}
+
+	nanJoin, err := dataset.Join(dataset.JoinOptions{Method: dataset.JoinOuter}, a, b)
+	if err != nil {
+		t.Fatal(err)
+	}
+	assertValues(t, nanJoin, "B.Y", []float64{10, math.NaN(), math.NaN(), 30})
+
+	ffill, err := dataset.Join(dataset.JoinOptions{Method: dataset.JoinOuter, Fill: dataset.FillForward}, a, b)
+	if err != nil {
+		t.Fatal(err)
+	}
+	assertValues(t, ffill, "B.Y", []float64{10, 10, 10, 30})
+}
+
+func TestJoin_FillLinear(t *testing.T) {
+	a := dataset.New("A", "test", dates("2006-01-02", "2024-01-01", "2024-01-02", "2024-01-03", "2024-01-04"))
+	if err := a.AddColumn("X", []float64{1, 2, 3, 4}); err != nil {
+		t.Fatal(err)
+	}
+	b := dataset.New("B", "test", dates("2006-01-02", "2024-01-01", "2024-01-03", "2024-01-04"))
+	if err := b.AddColumn("Y", []float64{10, math.NaN(), 40}); err != nil {
+		t.Fatal(err)
+	}
+
+	linear, err := dataset.Join(dataset.JoinOptions{Method: dataset.JoinOuter, Fill: dataset.FillLinear}, a, b)
+	if err != nil {
+		t.Fatal(err)
+	}
+	assertValues(t, linear, "B.Y", []float64{10, 20, 30, 40})
+}
+
+func TestJoin_QuarterlyAndExact(t *testing.T) {
+	gdp := dataset.New("GDP", "fred", dates("2006-01-02", "2023-10-01", "2024-01-01"))
+	exact := []*big.Rat{big.NewRat(277, 10), big.NewRat(281, 10)}
+	if err := gdp.AddExactColumn("Value", exact); err != nil {
+		t.Fatal(err)
+	}
+
+	monthly := dataset.New("M", "test", dates("2006-01-02", "2023-11-01", "2023-12-01", "2024-01-01", "2024-02-01"))
+	if err := monthly.AddColumn("V", []float64{1, 2, 3, 4}); err != nil {
+		t.Fatal(err)
+	}
+
+	joined, err := dataset.Join(dataset.JoinOptions{}, monthly, gdp)
+	if err != nil {
+		t.Fatalf("Join() error = %v", err)
+	}
+
+	assertValues(t, joined, "GDP.Value", []float64{27.7, 27.7, 28.1, 28.1})
+	got, ok := joined.ExactColumn("GDP.Value")
+	if !ok || got[3].Cmp(exact[1]) != 0 {
+		t.Errorf("ExactColumn(GDP.Value) = %v, %v", got, ok)
+	}
+}
+
+func TestJoin_Errors(t *testing.T) {
+	unsorted := dataset.New("U", "test", dates("2006-01-02", "2024-01-02", "2024-01-01"))
+	if _, err := dataset.Join(dataset.JoinOptions{}, unsorted); !errors.Is(err, dataset.ErrUnsorted) {
+		t.Errorf("expected ErrUnsorted, got %v", err)
+	}
+
+	a := dataset.New("A", "test", []time.Time{day(1)})
+	if err := a.AddColumn("X", []float64{1}); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := dataset.Join(dataset.JoinOptions{}, a, a); !errors.Is(err, dataset.ErrDuplicateColumn) {
+		t.Errorf("expected ErrDuplicateColumn, got %v", err)
+	}
+
+	empty, err := dataset.Join(dataset.JoinOptions{}, nil)
+	if err != nil || empty.Len() != 0 {
+		t.Errorf("Join(nil) = %v, %v", empty, err)
+	}
+}
+
+func TestJoin_EndDatedWeekly(t *testing.T) {
+	equity, _ := equityAndCPI(t)
+
+	// Weekly claims dated by the Saturday ending each week
+	claims := dataset.New("ICSA", "fred", dates("2006-01-02", "2024-02-03", "2024-02-10"))
+	claims.Meta[dataset.MetaFrequency] = "weekly"
+	claims.Meta[dataset.MetaDateConvention] = "end"
+	if err := claims.AddColumn("Value", []float64{224, 218}); err != nil {
+		t.Fatal(err)
+	}
+
+	joined, err := dataset.Join(dataset.JoinOptions{Method: dataset.JoinOuter}, equity, claims)
+	if err != nil {
+		t.Fatalf("Join() error = %v", err)
+	}
+
+	// The week ending 2024-02-03 covers Jan 28 - Feb 3, the next one Feb 4 - 10
+	assertValues(t, joined, "ICSA.Value", []float64{224, 224, 224, 224, 224, 218, 218})
+}
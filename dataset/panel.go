@@ -0,0 +1,155 @@
+package dataset
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"math/big"
+	"sort"
+	"time"
+)
+
+// ErrDuplicateSymbol is returned by NewPanel when two datasets have the
+// same symbol.
+var ErrDuplicateSymbol = errors.New("duplicate symbol")
+
+// Panel holds several symbols' datasets aligned on a common date index.
+// Every dataset in Data has Dates as its index; values a symbol has no
+// row for are NaN (nil exact values, empty flags).
+type Panel struct {
+	// Dates is the union of every symbol's dates, in ascending order.
+	Dates []time.Time
+	// Symbols lists the symbols in the order they were given.
+	Symbols []string
+	// Data holds the aligned dataset of each symbol.
+	Data map[string]*Dataset
+}
+
+// NewPanel aligns datasets on the union of their dates. Unlike Join, which
+// expands observations over their periods, rows are matched by exact date,
+// so a day a symbol did not trade is NaN rather than carried over. Each
+// dataset keeps its own columns, Source and Meta. Datasets are keyed by
+// Symbol (or Source when the Symbol is empty), which must be unique; nil
+// datasets are skipped. Dates of each dataset must be in ascending order.
+func NewPanel(datasets ...*Dataset) (*Panel, error) {
+	var inputs []*Dataset
+	for _, d := range datasets {
+		if d == nil {
+			continue
+		}
+		for i := 1; i < len(d.Dates); i++ {
+			if !d.Dates[i-1].Before(d.Dates[i]) {
+				return nil, fmt.Errorf("%w: %s", ErrUnsorted, label(d))
+			}
+		}
+		inputs = append(inputs, d)
+	}
+
+	// Build the index from the union of all dates
+	seen := make(map[int64]bool)
+	var index []time.Time
+	for _, d := range inputs {
+		for _, t := range d.Dates {
+			if !seen[t.UnixNano()] {
+				seen[t.UnixNano()] = true
+				index = append(index, t)
+			}
+		}
+	}
+	sort.Slice(index, func(i, j int) bool { return index[i].Before(index[j]) })
+
+	p := &Panel{Dates: index, Data: make(map[string]*Dataset, len(inputs))}
+	for _, d := range inputs {
+		symbol := label(d)
+		if _, ok := p.Data[symbol]; ok {
+			return nil, fmt.Errorf("%w: %s", ErrDuplicateSymbol, symbol)
+		}
+		p.Symbols = append(p.Symbols, symbol)
+		p.Data[symbol] = reindex(d, index)
+	}
+	return p, nil
+}
+
+// reindex returns a copy of d on index, which must contain every date of
+// d, with NaN rows for the dates d lacks.
+func reindex(d *Dataset, index []time.Time) *Dataset {
+	rows := make([]int, len(index))
+	j := 0
+	for k, t := range index {
+		rows[k] = -1
+		if j < len(d.Dates) && d.Dates[j].Equal(t) {
+			rows[k] = j
+			j++
+		}
+	}
+
+	out := New(d.Symbol, d.Source, append([]time.Time(nil), index...))
+	for _, c := range d.Columns {
+		values := make([]float64, len(index))
+		var exact []*big.Rat
+		if c.Exact != nil {
+			exact = make([]*big.Rat, len(index))
+		}
+		for k, row := range rows {
+			if row < 0 {
+				values[k] = math.NaN()
+				continue
+			}
+			values[k] = c.Values[row]
+			if exact != nil {
+				exact[k] = c.Exact[row]
+			}
+		}
+		out.Columns = append(out.Columns, Column{Name: c.Name, Values: values, Exact: exact, Unit: c.Unit})
+	}
+	if d.Flags != nil {
+		out.Flags = make([]string, len(index))
+		for k, row := range rows {
+			if row >= 0 {
+				out.Flags[k] = d.Flags[row]
+			}
+		}
+	}
+	for k, v := range d.Meta {
+		out.Meta[k] = v
+	}
+	return out
+}
+
+// Len returns the number of dates in the panel.
+func (p *Panel) Len() int {
+	if p == nil {
+		return 0
+	}
+	return len(p.Dates)
+}
+
+// Get returns the aligned dataset of symbol and whether it is in the
+// panel.
+func (p *Panel) Get(symbol string) (*Dataset, bool) {
+	if p == nil {
+		return nil, false
+	}
+	d, ok := p.Data[symbol]
+	return d, ok
+}
+
+// Field returns one column of every symbol as a dataset on the panel's
+// index with a column per symbol, in Symbols order, e.g., the closes of
+// every symbol for computing returns side by side. Symbols without the
+// column are NaN.
+func (p *Panel) Field(name string) *Dataset {
+	out := New(name, "", append([]time.Time(nil), p.Dates...))
+	for _, symbol := range p.Symbols {
+		values, ok := p.Data[symbol].Column(name)
+		unit := p.Data[symbol].Unit(name)
+		if !ok {
+			values = make([]float64, len(p.Dates))
+			for i := range values {
+				values[i] = math.NaN()
+			}
+		}
+		out.Columns = append(out.Columns, Column{Name: symbol, Values: append([]float64(nil), values...), Unit: unit})
+	}
+	return out
+}
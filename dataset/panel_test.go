@@ -0,0 +1,89 @@
+package dataset_test
+
+import (
+	"errors"
+	"math"
+	"math/big"
+	"testing"
+
+	"github.com/julianshen/gonp-datareader/dataset"
+)
+
+func TestNewPanel(t *testing.T) {
+	aapl := dataset.New("AAPL", "yahoo", dates("2006-01-02", "2024-01-02", "2024-01-03", "2024-01-05"))
+	if err := aapl.AddExactColumn("Close", []*big.Rat{big.NewRat(1, 1), big.NewRat(2, 1), big.NewRat(3, 1)}); err != nil {
+		t.Fatal(err)
+	}
+	aapl.Flags = []string{"", "halted", ""}
+
+	tsm := dataset.New("2330", "twse", dates("2006-01-02", "2024-01-03", "2024-01-04"))
+	if err := tsm.AddColumn("Close", []float64{10, 20}); err != nil {
+		t.Fatal(err)
+	}
+	if err := tsm.AddColumn("Change", []float64{1, 2}); err != nil {
+		t.Fatal(err)
+	}
+
+	panel, err := dataset.NewPanel(aapl, nil, tsm)
+	if err != nil {
+		t.Fatalf("NewPanel() error = %v", err)
+	}
+
+	if panel.Len() != 4 {
+		t.Fatalf("Len() = %d, want 4", panel.Len())
+	}
+	if len(panel.Symbols) != 2 || panel.Symbols[0] != "AAPL" || panel.Symbols[1] != "2330" {
+		t.Errorf("Symbols = %v, want [AAPL 2330]", panel.Symbols)
+	}
+
+	a, ok := panel.Get("AAPL")
+	if !ok {
+		t.Fatal("Get(AAPL) not found")
+	}
+	assertValues(t, a, "Close", []float64{1, 2, math.NaN(), 3})
+	if exact, _ := a.ExactColumn("Close"); exact[2] != nil || exact[3].Cmp(big.NewRat(3, 1)) != 0 {
+		t.Errorf("exact Close = %v, want nil on the missing day", exact)
+	}
+	if a.Flags[1] != "halted" || a.Flags[2] != "" {
+		t.Errorf("Flags = %q, want halted on 2024-01-03 only", a.Flags)
+	}
+
+	b, _ := panel.Get("2330")
+	assertValues(t, b, "Change", []float64{math.NaN(), 1, 2, math.NaN()})
+	if b.Source != "twse" {
+		t.Errorf("Source = %q, want twse", b.Source)
+	}
+
+	closes := panel.Field("Close")
+	assertValues(t, closes, "AAPL", []float64{1, 2, math.NaN(), 3})
+	assertValues(t, closes, "2330", []float64{math.NaN(), 10, 20, math.NaN()})
+	assertValues(t, panel.Field("Change"), "AAPL", []float64{math.NaN(), math.NaN(), math.NaN(), math.NaN()})
+
+	// The input datasets are not modified
+	if aapl.Len() != 3 {
+		t.Errorf("input modified, Len() = %d", aapl.Len())
+	}
+}
+
+func TestNewPanel_Errors(t *testing.T) {
+	unsorted := dataset.New("A", "yahoo", dates("2006-01-02", "2024-01-03", "2024-01-02"))
+	a := dataset.New("A", "yahoo", dates("2006-01-02", "2024-01-02"))
+	dup := dataset.New("A", "stooq", dates("2006-01-02", "2024-01-02"))
+
+	tests := []struct {
+		name     string
+		datasets []*dataset.Dataset
+		wantErr  error
+	}{
+		{"unsorted", []*dataset.Dataset{unsorted}, dataset.ErrUnsorted},
+		{"duplicate symbol", []*dataset.Dataset{a, dup}, dataset.ErrDuplicateSymbol},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := dataset.NewPanel(tt.datasets...); !errors.Is(err, tt.wantErr) {
+				t.Errorf("NewPanel() error = %v, want %v", err, tt.wantErr)
+			}
+		})
+	}
+}
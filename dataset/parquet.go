@@ -0,0 +1,50 @@
+//go:build arrow
+// +build arrow
+
+package dataset
+
+import (
+	"fmt"
+	"io"
+
+	"github.com/apache/arrow-go/v18/arrow/memory"
+	"github.com/apache/arrow-go/v18/parquet"
+	"github.com/apache/arrow-go/v18/parquet/compress"
+	"github.com/apache/arrow-go/v18/parquet/pqarrow"
+)
+
+// ParquetContentType is the media type of a Parquet file.
+const ParquetContentType = "application/vnd.apache.parquet"
+
+// WriteParquet writes the dataset to w as a Snappy-compressed Parquet file
+// with the columns of ArrowSchema. Missing (NaN) values and empty flags
+// are written as nulls, and the Arrow schema, including Symbol, Source and
+// Meta, is stored in the file so pyarrow and pandas restore it.
+//
+// Only available when built with the arrow build tag.
+func (d *Dataset) WriteParquet(w io.Writer) error {
+	mem := memory.NewGoAllocator()
+	schema := d.ArrowSchema()
+
+	rec := d.arrowRecord(mem, schema)
+	defer rec.Release()
+
+	props := parquet.NewWriterProperties(
+		parquet.WithCompression(compress.Codecs.Snappy),
+		parquet.WithAllocator(mem),
+	)
+	// Hide any Close method of w: closing the file writer closes its sink
+	writer, err := pqarrow.NewFileWriter(schema, struct{ io.Writer }{w}, props,
+		pqarrow.NewArrowWriterProperties(pqarrow.WithStoreSchema(), pqarrow.WithAllocator(mem)))
+	if err != nil {
+		return fmt.Errorf("create parquet writer: %w", err)
+	}
+	if err := writer.Write(rec); err != nil {
+		_ = writer.Close()
+		return fmt.Errorf("write parquet record: %w", err)
+	}
+	if err := writer.Close(); err != nil {
+		return fmt.Errorf("close parquet file: %w", err)
+	}
+	return nil
+}
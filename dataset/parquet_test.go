@@ -0,0 +1,51 @@
+//go:build arrow
+// +build arrow
+
+package dataset_test
+
+import (
+	"bytes"
+	"context"
+	"math"
+	"testing"
+	"time"
+
+	"github.com/apache/arrow-go/v18/arrow/array"
+	"github.com/apache/arrow-go/v18/arrow/memory"
+	"github.com/apache/arrow-go/v18/parquet"
+	"github.com/apache/arrow-go/v18/parquet/pqarrow"
+
+	"github.com/julianshen/gonp-datareader/dataset"
+)
+
+func TestDataset_WriteParquet(t *testing.T) {
+	ds := dataset.New("GDP", "fred", []time.Time{day(1), day(2), day(3)})
+	if err := ds.AddColumn("Value", []float64{1.5, math.NaN(), 3}); err != nil {
+		t.Fatal(err)
+	}
+	ds.Meta["stale"] = "true"
+
+	var buf bytes.Buffer
+	if err := ds.WriteParquet(&buf); err != nil {
+		t.Fatalf("WriteParquet() error = %v", err)
+	}
+
+	table, err := pqarrow.ReadTable(context.Background(), bytes.NewReader(buf.Bytes()),
+		parquet.NewReaderProperties(memory.DefaultAllocator), pqarrow.ArrowReadProperties{}, memory.DefaultAllocator)
+	if err != nil {
+		t.Fatalf("ReadTable() error = %v", err)
+	}
+	defer table.Release()
+
+	if table.NumRows() != 3 || table.NumCols() != 2 {
+		t.Fatalf("table is %dx%d, want 3x2", table.NumRows(), table.NumCols())
+	}
+	if got, _ := table.Schema().Metadata().GetValue("symbol"); got != "GDP" {
+		t.Errorf("metadata symbol = %q, want GDP", got)
+	}
+
+	values := table.Column(1).Data().Chunk(0).(*array.Float64)
+	if values.Value(0) != 1.5 || !values.IsNull(1) || values.Value(2) != 3 {
+		t.Errorf("Value column = %v, want [1.5 (null) 3]", values)
+	}
+}
@@ -0,0 +1,195 @@
+package dataset
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"sort"
+	"time"
+)
+
+// ErrInvalidFrequency is returned when resampling to FrequencyUnknown.
+var ErrInvalidFrequency = errors.New("invalid frequency")
+
+// Aggregation reduces the observations of a period to one value. NaN
+// values are skipped; a period without values is NaN.
+type Aggregation int
+
+const (
+	// AggAuto picks the aggregation from the column name: First for
+	// opens, Max for highs, Min for lows, Last for closes, Sum for volumes
+	// and Mean for other columns.
+	AggAuto Aggregation = iota
+	// AggMean is the average of the values.
+	AggMean
+	// AggLast is the last value, as for closing prices or end-of-period
+	// levels.
+	AggLast
+	// AggFirst is the first value, as for opening prices.
+	AggFirst
+	// AggMax is the largest value.
+	AggMax
+	// AggMin is the smallest value.
+	AggMin
+	// AggSum is the total of the values, as for volumes.
+	AggSum
+)
+
+// String returns the aggregation name (e.g., "mean").
+func (a Aggregation) String() string {
+	switch a {
+	case AggMean:
+		return "mean"
+	case AggLast:
+		return "last"
+	case AggFirst:
+		return "first"
+	case AggMax:
+		return "max"
+	case AggMin:
+		return "min"
+	case AggSum:
+		return "sum"
+	default:
+		return "auto"
+	}
+}
+
+// columnAggregations are the AggAuto aggregations of OHLCV columns, in
+// the spellings of the sources.
+var columnAggregations = map[string]Aggregation{
+	"Open": AggFirst, "open": AggFirst,
+	"High": AggMax, "high": AggMax, "max": AggMax,
+	"Low": AggMin, "low": AggMin, "min": AggMin,
+	"Close": AggLast, "close": AggLast, "Adj Close": AggLast,
+	"Volume": AggSum, "volume": AggSum, "Trading_Volume": AggSum,
+	"Trading_money": AggSum, "Trading_turnover": AggSum,
+	"Dividends": AggSum,
+}
+
+// ResampleOptions configures Resample.
+type ResampleOptions struct {
+	// Aggregations sets the aggregation of columns by name
+	Aggregations map[string]Aggregation
+	// Default is the aggregation of the other columns. Default: AggAuto
+	Default Aggregation
+	// Convention stamps each period with its first day (DateAtStart) or
+	// its last day (DateAtEnd). Default: DateAtStart
+	Convention DateConvention
+}
+
+// Resample returns the dataset downsampled to freq: the observations of
+// each calendar period (Monday-to-Sunday weeks, months, quarters or
+// years) are reduced to one row. Daily OHLCV bars become weekly or
+// monthly bars with the first open, highest high, lowest low, last close
+// and total volume; economic series are averaged unless opts selects
+// another aggregation (e.g., AggLast for end-of-period levels).
+//
+// Periods without observations are left out. The result records freq and
+// opts.Convention in Meta, keeps column units and drops exact decimal
+// values and flags.
+func (d *Dataset) Resample(freq Frequency, opts ResampleOptions) (*Dataset, error) {
+	if freq == FrequencyUnknown {
+		return nil, fmt.Errorf("%w: %s", ErrInvalidFrequency, freq)
+	}
+	if d == nil {
+		return nil, nil
+	}
+
+	// Rows in date order, grouped into runs of the same period
+	order := make([]int, len(d.Dates))
+	for i := range order {
+		order[i] = i
+	}
+	sort.SliceStable(order, func(a, b int) bool { return d.Dates[order[a]].Before(d.Dates[order[b]]) })
+
+	var starts []time.Time
+	var groups [][]int
+	for _, i := range order {
+		start := periodFloor(freq, d.Dates[i])
+		if n := len(starts); n == 0 || !starts[n-1].Equal(start) {
+			starts = append(starts, start)
+			groups = append(groups, nil)
+		}
+		groups[len(groups)-1] = append(groups[len(groups)-1], i)
+	}
+
+	dates := make([]time.Time, len(starts))
+	for i, start := range starts {
+		dates[i] = start
+		if opts.Convention == DateAtEnd {
+			dates[i] = freq.PeriodEnd(start).AddDate(0, 0, -1)
+		}
+	}
+
+	out := New(d.Symbol, d.Source, dates)
+	for k, v := range d.Meta {
+		out.Meta[k] = v
+	}
+	out.Meta[MetaFrequency] = freq.String()
+	out.Meta[MetaDateConvention] = opts.Convention.String()
+
+	for _, c := range d.Columns {
+		agg, ok := opts.Aggregations[c.Name]
+		if !ok {
+			agg = opts.Default
+		}
+		if agg == AggAuto {
+			if agg, ok = columnAggregations[c.Name]; !ok {
+				agg = AggMean
+			}
+		}
+
+		values := make([]float64, len(groups))
+		for g, rows := range groups {
+			values[g] = aggregate(agg, c.Values, rows)
+		}
+		out.Columns = append(out.Columns, Column{Name: c.Name, Values: values, Unit: c.Unit})
+	}
+	return out, nil
+}
+
+// periodFloor returns the first day of the calendar period of freq that
+// contains t: the Monday of its week, or the first day of its month,
+// quarter or year. Daily periods start at midnight.
+func periodFloor(freq Frequency, t time.Time) time.Time {
+	y, m, day := t.Date()
+	switch freq {
+	case FrequencyWeekly:
+		// Days since Monday
+		back := (int(t.Weekday()) + 6) % 7
+		return time.Date(y, m, day-back, 0, 0, 0, 0, t.Location())
+	case FrequencyMonthly, FrequencyQuarterly, FrequencyAnnual:
+		return freq.PeriodStart(time.Date(y, m, day, 0, 0, 0, 0, t.Location()))
+	default:
+		return time.Date(y, m, day, 0, 0, 0, 0, t.Location())
+	}
+}
+
+// aggregate reduces values at rows, which are in date order, skipping
+// NaN.
+func aggregate(agg Aggregation, values []float64, rows []int) float64 {
+	result := math.NaN()
+	n := 0
+	for _, i := range rows {
+		v := values[i]
+		if math.IsNaN(v) {
+			continue
+		}
+		n++
+		switch {
+		case n == 1 || agg == AggLast:
+			result = v
+		case agg == AggMax:
+			result = math.Max(result, v)
+		case agg == AggMin:
+			result = math.Min(result, v)
+		case agg == AggSum, agg == AggMean:
+			result += v
+		}
+	}
+	if agg == AggMean && n > 0 {
+		result /= float64(n)
+	}
+	return result
+}
@@ -0,0 +1,93 @@
+package dataset_test
+
+import (
+	"errors"
+	"math"
+	"reflect"
+	"testing"
+
+	"github.com/julianshen/gonp-datareader/dataset"
+)
+
+func TestResample_OHLCV(t *testing.T) {
+	// Thursday 2024-01-04 through Tuesday 2024-01-09, out of order
+	ds := dataset.New("AAPL", "yahoo", dates("2006-01-02", "2024-01-05", "2024-01-04", "2024-01-08", "2024-01-09"))
+	ds.AddColumn("Open", []float64{11, 10, 20, 21})
+	ds.AddColumn("High", []float64{15, 12, 22, 25})
+	ds.AddColumn("Low", []float64{9, 8, 19, math.NaN()})
+	ds.AddColumn("Close", []float64{14, 11, 21, 24})
+	ds.AddColumn("Volume", []float64{200, 100, 300, 400})
+	ds.SetUnit("Close", "USD")
+
+	weekly, err := ds.Resample(dataset.FrequencyWeekly, dataset.ResampleOptions{})
+	if err != nil {
+		t.Fatalf("Resample() error = %v", err)
+	}
+
+	if want := dates("2006-01-02", "2024-01-01", "2024-01-08"); !reflect.DeepEqual(weekly.Dates, want) {
+		t.Errorf("Dates = %v, want %v", weekly.Dates, want)
+	}
+	want := map[string][]float64{
+		"Open":   {10, 20},
+		"High":   {15, 25},
+		"Low":    {8, 19},
+		"Close":  {14, 24},
+		"Volume": {300, 700},
+	}
+	for name, values := range want {
+		if got, _ := weekly.Column(name); !reflect.DeepEqual(got, values) {
+			t.Errorf("%s = %v, want %v", name, got, values)
+		}
+	}
+	if weekly.Unit("Close") != "USD" || weekly.Meta[dataset.MetaFrequency] != "weekly" {
+		t.Errorf("Resample() unit %q, frequency %q", weekly.Unit("Close"), weekly.Meta[dataset.MetaFrequency])
+	}
+
+	end, _ := ds.Resample(dataset.FrequencyWeekly, dataset.ResampleOptions{Convention: dataset.DateAtEnd})
+	if want := dates("2006-01-02", "2024-01-07", "2024-01-14"); !reflect.DeepEqual(end.Dates, want) {
+		t.Errorf("DateAtEnd dates = %v, want %v", end.Dates, want)
+	}
+}
+
+func TestResample_Economic(t *testing.T) {
+	ds := dataset.New("DGS10", "fred", dates("2006-01-02", "2024-01-02", "2024-01-31", "2024-02-01", "2024-04-01"))
+	ds.AddColumn("Value", []float64{4, 5, math.NaN(), 3})
+
+	tests := []struct {
+		name string
+		opts dataset.ResampleOptions
+		want []float64
+	}{
+		{"mean", dataset.ResampleOptions{}, []float64{4.5, math.NaN(), 3}},
+		{"last", dataset.ResampleOptions{Default: dataset.AggLast}, []float64{5, math.NaN(), 3}},
+		{"per column", dataset.ResampleOptions{Aggregations: map[string]dataset.Aggregation{"Value": dataset.AggMin}}, []float64{4, math.NaN(), 3}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			monthly, err := ds.Resample(dataset.FrequencyMonthly, tt.opts)
+			if err != nil {
+				t.Fatalf("Resample() error = %v", err)
+			}
+			got, _ := monthly.Column("Value")
+			if len(got) != len(tt.want) {
+				t.Fatalf("Value = %v, want %v", got, tt.want)
+			}
+			for i := range got {
+				if got[i] != tt.want[i] && !(math.IsNaN(got[i]) && math.IsNaN(tt.want[i])) {
+					t.Errorf("Value = %v, want %v", got, tt.want)
+					break
+				}
+			}
+		})
+	}
+
+	quarterly, _ := ds.Resample(dataset.FrequencyQuarterly, dataset.ResampleOptions{})
+	if want := dates("2006-01-02", "2024-01-01", "2024-04-01"); !reflect.DeepEqual(quarterly.Dates, want) {
+		t.Errorf("quarterly dates = %v, want %v", quarterly.Dates, want)
+	}
+
+	if _, err := ds.Resample(dataset.FrequencyUnknown, dataset.ResampleOptions{}); !errors.Is(err, dataset.ErrInvalidFrequency) {
+		t.Errorf("Resample(unknown) error = %v, want ErrInvalidFrequency", err)
+	}
+}
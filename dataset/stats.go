@@ -0,0 +1,154 @@
+package dataset
+
+import (
+	"fmt"
+	"math"
+	"sort"
+	"strings"
+	"text/tabwriter"
+	"time"
+)
+
+// ColumnStats holds descriptive statistics of one column, computed over
+// its non-NaN values. They are NaN when the column has no values.
+type ColumnStats struct {
+	// Name is the column name.
+	Name string
+	// Count is the number of non-NaN values.
+	Count int
+	// NaN is the number of missing values.
+	NaN int
+	// Min, Max, Mean and Median describe the values.
+	Min    float64
+	Max    float64
+	Mean   float64
+	Median float64
+	// Std is the sample standard deviation (NaN for fewer than two values).
+	Std float64
+}
+
+// Stats summarizes a dataset's date index and columns for quick sanity
+// checks.
+type Stats struct {
+	// Rows is the number of rows.
+	Rows int
+	// First and Last are the earliest and latest dates; zero when empty.
+	First time.Time
+	Last  time.Time
+	// LargestGapDays is the largest number of days between consecutive
+	// dates, and GapStart the date the gap starts after; zero with fewer
+	// than two rows.
+	LargestGapDays int
+	GapStart       time.Time
+	// Columns holds the statistics of each column, in dataset order.
+	Columns []ColumnStats
+}
+
+// Stats computes the row count, date range, largest date gap and
+// descriptive statistics of every column.
+func (d *Dataset) Stats() *Stats {
+	s := &Stats{}
+	if d == nil {
+		return s
+	}
+
+	s.Rows = len(d.Dates)
+	dates := append([]time.Time(nil), d.Dates...)
+	sortDates(dates)
+	if len(dates) > 0 {
+		s.First, s.Last = dates[0], dates[len(dates)-1]
+	}
+	for i := 1; i < len(dates); i++ {
+		days := int(math.Round(dates[i].Sub(dates[i-1]).Hours() / 24))
+		if days > s.LargestGapDays {
+			s.LargestGapDays = days
+			s.GapStart = dates[i-1]
+		}
+	}
+
+	for _, c := range d.Columns {
+		s.Columns = append(s.Columns, columnStats(c))
+	}
+	return s
+}
+
+// Column returns the statistics of the named column.
+func (s *Stats) Column(name string) (ColumnStats, bool) {
+	for _, c := range s.Columns {
+		if c.Name == name {
+			return c, true
+		}
+	}
+	return ColumnStats{}, false
+}
+
+// String formats the statistics as a table, one line per column.
+func (s *Stats) String() string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "rows: %d", s.Rows)
+	if s.Rows > 0 {
+		fmt.Fprintf(&b, ", %s to %s", s.First.Format("2006-01-02"), s.Last.Format("2006-01-02"))
+	}
+	if s.LargestGapDays > 0 {
+		fmt.Fprintf(&b, ", largest gap: %d days after %s", s.LargestGapDays, s.GapStart.Format("2006-01-02"))
+	}
+	b.WriteString("\n")
+
+	w := tabwriter.NewWriter(&b, 0, 0, 2, ' ', tabwriter.AlignRight)
+	fmt.Fprintln(w, "column\tcount\tnan\tmin\tmax\tmean\tmedian\tstd\t")
+	for _, c := range s.Columns {
+		fmt.Fprintf(w, "%s\t%d\t%d\t%.4g\t%.4g\t%.4g\t%.4g\t%.4g\t\n",
+			c.Name, c.Count, c.NaN, c.Min, c.Max, c.Mean, c.Median, c.Std)
+	}
+	w.Flush()
+	return b.String()
+}
+
+// columnStats computes the statistics of c.
+func columnStats(c Column) ColumnStats {
+	values := make([]float64, 0, len(c.Values))
+	for _, v := range c.Values {
+		if !math.IsNaN(v) {
+			values = append(values, v)
+		}
+	}
+
+	cs := ColumnStats{
+		Name:   c.Name,
+		Count:  len(values),
+		NaN:    len(c.Values) - len(values),
+		Min:    math.NaN(),
+		Max:    math.NaN(),
+		Mean:   math.NaN(),
+		Median: math.NaN(),
+		Std:    math.NaN(),
+	}
+	if len(values) == 0 {
+		return cs
+	}
+
+	sort.Float64s(values)
+	cs.Min, cs.Max = values[0], values[len(values)-1]
+
+	var sum float64
+	for _, v := range values {
+		sum += v
+	}
+	cs.Mean = sum / float64(len(values))
+
+	mid := len(values) / 2
+	if len(values)%2 == 0 {
+		cs.Median = (values[mid-1] + values[mid]) / 2
+	} else {
+		cs.Median = values[mid]
+	}
+
+	if len(values) > 1 {
+		var squares float64
+		for _, v := range values {
+			squares += (v - cs.Mean) * (v - cs.Mean)
+		}
+		cs.Std = math.Sqrt(squares / float64(len(values)-1))
+	}
+	return cs
+}
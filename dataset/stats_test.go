@@ -0,0 +1,89 @@
+package dataset_test
+
+import (
+	"math"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/julianshen/gonp-datareader/dataset"
+)
+
+func TestDataset_Stats(t *testing.T) {
+	nan := math.NaN()
+	ds := dataset.New("AAPL", "yahoo", dates("2006-01-02",
+		"2024-01-02", "2024-01-03", "2024-01-04", "2024-01-12", "2024-01-15"))
+	if err := ds.AddColumn("Close", []float64{1, 2, nan, 4, 5}); err != nil {
+		t.Fatal(err)
+	}
+	if err := ds.AddColumn("Empty", []float64{nan, nan, nan, nan, nan}); err != nil {
+		t.Fatal(err)
+	}
+
+	s := ds.Stats()
+	if s.Rows != 5 {
+		t.Errorf("Rows = %d, want 5", s.Rows)
+	}
+	if !s.First.Equal(ds.Dates[0]) || !s.Last.Equal(ds.Dates[4]) {
+		t.Errorf("First/Last = %v/%v, want %v/%v", s.First, s.Last, ds.Dates[0], ds.Dates[4])
+	}
+	if s.LargestGapDays != 8 || !s.GapStart.Equal(ds.Dates[2]) {
+		t.Errorf("largest gap = %d days after %v, want 8 days after %v", s.LargestGapDays, s.GapStart, ds.Dates[2])
+	}
+
+	closeStats, ok := s.Column("Close")
+	if !ok {
+		t.Fatal("Column(Close) not found")
+	}
+	want := dataset.ColumnStats{Name: "Close", Count: 4, NaN: 1, Min: 1, Max: 5, Mean: 3, Median: 3, Std: math.Sqrt(10.0 / 3)}
+	if closeStats != want {
+		t.Errorf("Close stats = %+v, want %+v", closeStats, want)
+	}
+
+	empty, _ := s.Column("Empty")
+	if empty.Count != 0 || empty.NaN != 5 || !math.IsNaN(empty.Mean) || !math.IsNaN(empty.Min) {
+		t.Errorf("Empty stats = %+v, want no values and NaN statistics", empty)
+	}
+
+	out := s.String()
+	for _, want := range []string{"rows: 5, 2024-01-02 to 2024-01-15", "largest gap: 8 days after 2024-01-04", "Close"} {
+		if !strings.Contains(out, want) {
+			t.Errorf("String() = %q, want it to contain %q", out, want)
+		}
+	}
+}
+
+func TestDataset_Stats_Edges(t *testing.T) {
+	tests := []struct {
+		name    string
+		ds      *dataset.Dataset
+		rows    int
+		gapDays int
+	}{
+		{"nil", nil, 0, 0},
+		{"empty", dataset.New("X", "test", nil), 0, 0},
+		{"single row", dataset.New("X", "test", dates("2006-01-02", "2024-01-02")), 1, 0},
+		{"unsorted", dataset.New("X", "test", dates("2006-01-02", "2024-03-01", "2024-01-01", "2024-02-01")), 3, 31},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := tt.ds.Stats()
+			if s.Rows != tt.rows || s.LargestGapDays != tt.gapDays {
+				t.Errorf("Stats() = %d rows, %d-day gap; want %d rows, %d-day gap", s.Rows, s.LargestGapDays, tt.rows, tt.gapDays)
+			}
+		})
+	}
+}
+
+func TestColumnStats_SingleValue(t *testing.T) {
+	ds := dataset.New("X", "test", []time.Time{time.Date(2024, 1, 2, 0, 0, 0, 0, time.UTC)})
+	if err := ds.AddColumn("Value", []float64{7}); err != nil {
+		t.Fatal(err)
+	}
+
+	c := ds.Stats().Columns[0]
+	if c.Mean != 7 || c.Median != 7 || !math.IsNaN(c.Std) {
+		t.Errorf("stats = %+v, want mean and median 7, NaN std", c)
+	}
+}
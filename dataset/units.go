@@ -0,0 +1,223 @@
+package dataset
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// ErrIncompatibleUnits is returned when series with different known units,
+// such as a percentage and a dollar amount, are combined.
+var ErrIncompatibleUnits = errors.New("incompatible units")
+
+// UnitKind classifies units by what their values measure.
+type UnitKind int
+
+const (
+	// UnitKindUnknown is an empty or unrecognized unit.
+	UnitKindUnknown UnitKind = iota
+	// UnitKindPercent is a percentage or rate (e.g., "%", "Percent",
+	// "% of GDP").
+	UnitKindPercent
+	// UnitKindMoney is an amount of money (e.g., "USD", "current US$",
+	// "Billions of Dollars").
+	UnitKindMoney
+	// UnitKindIndex is an index level (e.g., "index points", "Index
+	// 2015=100").
+	UnitKindIndex
+	// UnitKindCount is a number of things (e.g., "shares", "persons",
+	// "Thousands of Persons").
+	UnitKindCount
+)
+
+// String returns "percent", "money", "index", "count" or "unknown".
+func (k UnitKind) String() string {
+	switch k {
+	case UnitKindPercent:
+		return "percent"
+	case UnitKindMoney:
+		return "money"
+	case UnitKindIndex:
+		return "index"
+	case UnitKindCount:
+		return "count"
+	default:
+		return "unknown"
+	}
+}
+
+// ClassifyUnit returns the kind of a unit as sources publish it.
+func ClassifyUnit(unit string) UnitKind {
+	u := strings.ToLower(strings.TrimSpace(unit))
+	switch {
+	case u == "":
+		return UnitKindUnknown
+	case strings.HasPrefix(u, "%") || strings.HasPrefix(u, "percent"):
+		return UnitKindPercent
+	case strings.HasPrefix(u, "index"):
+		return UnitKindIndex
+	case isCurrencyCode(unit) || strings.Contains(u, "$") || strings.Contains(u, "dollar") ||
+		strings.Contains(u, "euro") || strings.Contains(u, "yen"):
+		return UnitKindMoney
+	case strings.Contains(u, "shares") || strings.Contains(u, "persons") || strings.Contains(u, "number"):
+		return UnitKindCount
+	default:
+		return UnitKindUnknown
+	}
+}
+
+// isCurrencyCode reports whether unit is an ISO 4217 style code, such as
+// "USD".
+func isCurrencyCode(unit string) bool {
+	if len(unit) != 3 {
+		return false
+	}
+	for _, r := range unit {
+		if r < 'A' || r > 'Z' {
+			return false
+		}
+	}
+	return true
+}
+
+// normalizeUnit returns the comparable form of a unit: "Percent" and "%"
+// are the same unit.
+func normalizeUnit(unit string) string {
+	u := strings.TrimSpace(unit)
+	if strings.EqualFold(u, "percent") {
+		return UnitPercent
+	}
+	return u
+}
+
+// CheckUnits returns an error matching ErrIncompatibleUnits unless every
+// known unit of units is the same. Empty units are unknown and accepted,
+// so series without unit metadata can always be combined.
+func CheckUnits(units ...string) error {
+	common := ""
+	for _, unit := range units {
+		u := normalizeUnit(unit)
+		switch {
+		case u == "":
+		case common == "":
+			common = u
+		case u != common:
+			return fmt.Errorf("%w: %q (%s) and %q (%s)", ErrIncompatibleUnits,
+				common, ClassifyUnit(common), u, ClassifyUnit(u))
+		}
+	}
+	return nil
+}
+
+// CombineOptions configures Sum and Mean.
+type CombineOptions struct {
+	// Column is the column taken from each dataset. Default: its first
+	// column
+	Column string
+	// Name is the name of the result column. Default: "Sum" or "Mean"
+	Name string
+	// Force combines series whose units are incompatible; the result
+	// then has no unit
+	Force bool
+	// Join aligns the datasets. Default: an inner join
+	Join JoinOptions
+}
+
+// Sum adds one column of each dataset, aligned with Join, into a dataset
+// with a single column. A date with a NaN value in any series is NaN. It
+// returns an error matching ErrIncompatibleUnits when the columns have
+// different known units, such as a rate in percent and an amount in
+// dollars, unless opts.Force is set.
+func Sum(opts CombineOptions, datasets ...*Dataset) (*Dataset, error) {
+	if opts.Name == "" {
+		opts.Name = "Sum"
+	}
+	return combine(opts, datasets, func(values []float64) float64 {
+		var sum float64
+		for _, v := range values {
+			sum += v
+		}
+		return sum
+	})
+}
+
+// Mean averages one column of each dataset like Sum, with the same unit
+// checks.
+func Mean(opts CombineOptions, datasets ...*Dataset) (*Dataset, error) {
+	if opts.Name == "" {
+		opts.Name = "Mean"
+	}
+	return combine(opts, datasets, func(values []float64) float64 {
+		var sum float64
+		for _, v := range values {
+			sum += v
+		}
+		return sum / float64(len(values))
+	})
+}
+
+// combine aligns the selected column of each dataset and reduces every
+// row with reduce.
+func combine(opts CombineOptions, datasets []*Dataset, reduce func([]float64) float64) (*Dataset, error) {
+	if len(datasets) == 0 {
+		return nil, fmt.Errorf("no datasets to combine")
+	}
+
+	selected := make([]*Dataset, len(datasets))
+	units := make([]string, len(datasets))
+	symbols := make([]string, len(datasets))
+	sources := make([]string, 0, len(datasets))
+	for i, d := range datasets {
+		if d == nil {
+			return nil, fmt.Errorf("dataset %d is nil", i)
+		}
+		name := opts.Column
+		if name == "" && len(d.Columns) > 0 {
+			name = d.Columns[0].Name
+		}
+		values, ok := d.Column(name)
+		if !ok {
+			return nil, fmt.Errorf("%w: %q in %s", ErrNoColumn, name, label(d))
+		}
+		units[i] = d.Unit(name)
+		symbols[i] = d.Symbol
+		if !containsString(sources, d.Source) {
+			sources = append(sources, d.Source)
+		}
+
+		// Numbered so datasets of the same symbol do not collide
+		s := New(strconv.Itoa(i), d.Source, d.Dates)
+		s.Meta = d.Meta
+		s.Columns = []Column{{Name: name, Values: values}}
+		selected[i] = s
+	}
+
+	unit := ""
+	if err := CheckUnits(units...); err != nil {
+		if !opts.Force {
+			return nil, err
+		}
+	} else {
+		for i := 0; i < len(units) && unit == ""; i++ {
+			unit = normalizeUnit(units[i])
+		}
+	}
+
+	joined, err := Join(opts.Join, selected...)
+	if err != nil {
+		return nil, err
+	}
+
+	out := New(strings.Join(symbols, ","), strings.Join(sources, ","), joined.Dates)
+	values := make([]float64, len(joined.Dates))
+	row := make([]float64, len(joined.Columns))
+	for i := range values {
+		for c, col := range joined.Columns {
+			row[c] = col.Values[i]
+		}
+		values[i] = reduce(row)
+	}
+	out.Columns = []Column{{Name: opts.Name, Values: values, Unit: unit}}
+	return out, nil
+}
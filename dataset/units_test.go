@@ -0,0 +1,92 @@
+package dataset_test
+
+import (
+	"errors"
+	"math"
+	"testing"
+
+	"github.com/julianshen/gonp-datareader/dataset"
+)
+
+func TestClassifyUnit(t *testing.T) {
+	tests := []struct {
+		unit string
+		want dataset.UnitKind
+	}{
+		{"%", dataset.UnitKindPercent},
+		{"Percent Change from Year Ago", dataset.UnitKindPercent},
+		{"% of total labor force", dataset.UnitKindPercent},
+		{"USD", dataset.UnitKindMoney},
+		{"current US$", dataset.UnitKindMoney},
+		{"Billions of Dollars", dataset.UnitKindMoney},
+		{"Index 2015=100", dataset.UnitKindIndex},
+		{dataset.UnitIndex, dataset.UnitKindIndex},
+		{dataset.UnitShares, dataset.UnitKindCount},
+		{"Thousands of Persons", dataset.UnitKindCount},
+		{"", dataset.UnitKindUnknown},
+		{"Lin", dataset.UnitKindUnknown},
+	}
+
+	for _, tt := range tests {
+		if got := dataset.ClassifyUnit(tt.unit); got != tt.want {
+			t.Errorf("ClassifyUnit(%q) = %v, want %v", tt.unit, got, tt.want)
+		}
+	}
+}
+
+func TestCheckUnits(t *testing.T) {
+	if err := dataset.CheckUnits("Percent", "", "%"); err != nil {
+		t.Errorf("CheckUnits(Percent, \"\", %%) error = %v", err)
+	}
+	if err := dataset.CheckUnits("%", "Billions of Dollars"); !errors.Is(err, dataset.ErrIncompatibleUnits) {
+		t.Errorf("CheckUnits(%%, Billions of Dollars) error = %v, want ErrIncompatibleUnits", err)
+	}
+}
+
+func TestSum(t *testing.T) {
+	a := dataset.New("A", "fred", dates("2006-01-02", "2024-01-01", "2024-02-01"))
+	a.AddColumn("Value", []float64{1, 2})
+	a.SetUnit("Value", "Billions of Dollars")
+	b := dataset.New("B", "fred", dates("2006-01-02", "2024-01-01", "2024-02-01"))
+	b.AddColumn("Value", []float64{10, math.NaN()})
+	b.SetUnit("Value", "Billions of Dollars")
+
+	sum, err := dataset.Sum(dataset.CombineOptions{}, a, b)
+	if err != nil {
+		t.Fatalf("Sum() error = %v", err)
+	}
+	values, _ := sum.Column("Sum")
+	if len(values) != 2 || values[0] != 11 || !math.IsNaN(values[1]) {
+		t.Errorf("Sum = %v, want [11 NaN]", values)
+	}
+	if sum.Unit("Sum") != "Billions of Dollars" || sum.Symbol != "A,B" {
+		t.Errorf("Sum() = %s in %q, want A,B in Billions of Dollars", sum.Symbol, sum.Unit("Sum"))
+	}
+
+	mean, err := dataset.Mean(dataset.CombineOptions{Name: "Avg"}, a, a)
+	if err != nil {
+		t.Fatalf("Mean() error = %v", err)
+	}
+	if values, _ := mean.Column("Avg"); values[1] != 2 {
+		t.Errorf("Mean = %v, want [1 2]", values)
+	}
+
+	// A rate and an amount are refused unless forced
+	rate := dataset.New("UNRATE", "fred", a.Dates)
+	rate.AddColumn("Value", []float64{3.7, 3.9})
+	rate.SetUnit("Value", "Percent")
+	if _, err := dataset.Sum(dataset.CombineOptions{}, a, rate); !errors.Is(err, dataset.ErrIncompatibleUnits) {
+		t.Errorf("Sum(amount, rate) error = %v, want ErrIncompatibleUnits", err)
+	}
+	forced, err := dataset.Sum(dataset.CombineOptions{Force: true}, a, rate)
+	if err != nil {
+		t.Fatalf("Sum(Force) error = %v", err)
+	}
+	if forced.Unit("Sum") != "" {
+		t.Errorf("Sum(Force) unit = %q, want none", forced.Unit("Sum"))
+	}
+
+	if _, err := dataset.Sum(dataset.CombineOptions{Column: "Close"}, a); !errors.Is(err, dataset.ErrNoColumn) {
+		t.Errorf("Sum(Close) error = %v, want ErrNoColumn", err)
+	}
+}
@@ -0,0 +1,119 @@
+package dataset
+
+import (
+	"fmt"
+	"math"
+	"sort"
+	"time"
+)
+
+// Vintage is one published value of a revisable series: the observation
+// for reference period Date as it was known from KnownAt onwards.
+type Vintage struct {
+	// Date is the reference period of the observation (e.g., 2024-01-01
+	// for January CPI).
+	Date time.Time
+	// KnownAt is the date the value was published.
+	KnownAt time.Time
+	// Value is the published value.
+	Value float64
+}
+
+// VintageSeries holds every published value of a series, including
+// revisions, for point-in-time analysis.
+type VintageSeries struct {
+	// Symbol is the series identifier (e.g., "CPIAUCSL").
+	Symbol string
+	// Source is the data source identifier (e.g., "fred").
+	Source string
+	// Vintages lists published values in any order.
+	Vintages []Vintage
+}
+
+// AsOf returns the latest observation publicly known at t: among the values
+// published on or before t, the one with the most recent reference Date, in
+// its most recent revision. It returns false if nothing was known at t.
+func (s *VintageSeries) AsOf(t time.Time) (Vintage, bool) {
+	var best Vintage
+	found := false
+	if s == nil {
+		return best, false
+	}
+
+	for _, v := range s.Vintages {
+		if v.KnownAt.After(t) {
+			continue
+		}
+		if !found || v.Date.After(best.Date) || (v.Date.Equal(best.Date) && v.KnownAt.After(best.KnownAt)) {
+			best = v
+			found = true
+		}
+	}
+	return best, found
+}
+
+// AsOfJoin aligns macro series to base by publication date rather than
+// reference period, preventing look-ahead bias in backtests.
+//
+// For every date in base, each series contributes the latest value that had
+// been published on or before that date (see VintageSeries.AsOf). Publication
+// dates are inclusive: a value released during the trading day on its
+// KnownAt date is visible at that date's row. Dates with no published value
+// are NaN.
+//
+// The result is a copy of base with one column per series named
+// "<Symbol>.Value". Dates of base must be in ascending order.
+func AsOfJoin(base *Dataset, series ...*VintageSeries) (*Dataset, error) {
+	if base == nil {
+		return nil, fmt.Errorf("base dataset is nil")
+	}
+	if !sort.SliceIsSorted(base.Dates, func(i, j int) bool { return base.Dates[i].Before(base.Dates[j]) }) {
+		return nil, fmt.Errorf("%w: %s", ErrUnsorted, label(base))
+	}
+
+	out := base.Clone()
+	for _, s := range series {
+		if s == nil {
+			continue
+		}
+
+		vintages := append([]Vintage(nil), s.Vintages...)
+		sort.SliceStable(vintages, func(i, j int) bool { return vintages[i].KnownAt.Before(vintages[j].KnownAt) })
+
+		// Sweep base dates in order, applying vintages as they are published.
+		// Later publications for the same reference date are revisions.
+		known := make(map[int64]float64)
+		var latest time.Time
+		haveLatest := false
+		next := 0
+
+		values := make([]float64, base.Len())
+		for i, t := range base.Dates {
+			for next < len(vintages) && !vintages[next].KnownAt.After(t) {
+				v := vintages[next]
+				known[v.Date.UnixNano()] = v.Value
+				if !haveLatest || v.Date.After(latest) {
+					latest = v.Date
+					haveLatest = true
+				}
+				next++
+			}
+
+			if !haveLatest {
+				values[i] = math.NaN()
+				continue
+			}
+			values[i] = known[latest.UnixNano()]
+		}
+
+		name := s.Symbol
+		if name == "" {
+			name = s.Source
+		}
+		if err := out.AddColumn(name+".Value", values); err != nil {
+			return nil, err
+		}
+	}
+
+	return out, nil
+}
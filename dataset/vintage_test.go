@@ -0,0 +1,97 @@
+package dataset_test
+
+import (
+	"math"
+	"testing"
+	"time"
+
+	"github.com/julianshen/gonp-datareader/dataset"
+)
+
+// cpiVintages returns January and February CPI, where January was first
+// published mid-February and revised in mid-March.
+func cpiVintages() *dataset.VintageSeries {
+	d := func(s string) time.Time {
+		t, _ := time.Parse("2006-01-02", s)
+		return t
+	}
+
+	return &dataset.VintageSeries{
+		Symbol: "CPIAUCSL",
+		Source: "fred",
+		Vintages: []dataset.Vintage{
+			{Date: d("2024-02-01"), KnownAt: d("2024-03-12"), Value: 310.3},
+			{Date: d("2024-01-01"), KnownAt: d("2024-02-13"), Value: 308.4},
+			{Date: d("2024-01-01"), KnownAt: d("2024-03-12"), Value: 308.6},
+		},
+	}
+}
+
+func TestVintageSeries_AsOf(t *testing.T) {
+	series := cpiVintages()
+
+	tests := []struct {
+		date      string
+		wantOK    bool
+		wantValue float64
+		wantRef   string
+	}{
+		{"2024-02-12", false, 0, ""},
+		{"2024-02-13", true, 308.4, "2024-01-01"},
+		{"2024-03-11", true, 308.4, "2024-01-01"},
+		{"2024-03-12", true, 310.3, "2024-02-01"},
+	}
+
+	for _, tt := range tests {
+		at, _ := time.Parse("2006-01-02", tt.date)
+		v, ok := series.AsOf(at)
+		if ok != tt.wantOK {
+			t.Errorf("AsOf(%s) ok = %v, want %v", tt.date, ok, tt.wantOK)
+			continue
+		}
+		if !ok {
+			continue
+		}
+		if v.Value != tt.wantValue || v.Date.Format("2006-01-02") != tt.wantRef {
+			t.Errorf("AsOf(%s) = %v, want %v for %s", tt.date, v, tt.wantValue, tt.wantRef)
+		}
+	}
+
+	var nilSeries *dataset.VintageSeries
+	if _, ok := nilSeries.AsOf(time.Now()); ok {
+		t.Error("expected nil series to have no value")
+	}
+}
+
+func TestAsOfJoin(t *testing.T) {
+	base := dataset.New("SPY", "yahoo", dates("2006-01-02",
+		"2024-01-31", "2024-02-13", "2024-02-14", "2024-03-12"))
+	if err := base.AddColumn("Close", []float64{1, 2, 3, 4}); err != nil {
+		t.Fatal(err)
+	}
+
+	joined, err := dataset.AsOfJoin(base, cpiVintages())
+	if err != nil {
+		t.Fatalf("AsOfJoin() error = %v", err)
+	}
+
+	// January CPI is not visible on 2024-01-31 even though its reference
+	// period has passed; on 2024-03-12 February CPI becomes the latest value
+	assertValues(t, joined, "CPIAUCSL.Value", []float64{math.NaN(), 308.4, 308.4, 310.3})
+	assertValues(t, joined, "Close", []float64{1, 2, 3, 4})
+
+	if _, ok := base.Column("CPIAUCSL.Value"); ok {
+		t.Error("AsOfJoin modified the base dataset")
+	}
+}
+
+func TestAsOfJoin_Errors(t *testing.T) {
+	if _, err := dataset.AsOfJoin(nil, cpiVintages()); err == nil {
+		t.Error("expected error for nil base")
+	}
+
+	unsorted := dataset.New("U", "test", dates("2006-01-02", "2024-01-02", "2024-01-01"))
+	if _, err := dataset.AsOfJoin(unsorted, cpiVintages()); err == nil {
+		t.Error("expected error for unsorted base")
+	}
+}
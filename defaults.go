@@ -0,0 +1,104 @@
+package datareader
+
+import (
+	"reflect"
+	"sync"
+)
+
+var (
+	defaultsMu sync.RWMutex
+	// defaults holds the options set by SetDefaultOptions; nil when unset
+	defaults *Options
+)
+
+// SetDefaultOptions sets organization-wide defaults, such as UserAgent,
+// CacheDir or API keys, applied to every function of the package taking
+// Options, so they need not be threaded into each call site. Non-zero
+// fields of the Options passed to a call override the defaults field by
+// field; a nil Options uses DefaultOptions, which includes them.
+//
+// opts is copied, including its maps, so later changes to it have no
+// effect; pointer fields such as Hooks and Calendar are shared. Passing nil
+// clears the defaults. SetDefaultOptions is safe for concurrent use, but
+// readers already created keep the options they were created with.
+//
+// # Example Usage
+//
+//	datareader.SetDefaultOptions(&datareader.Options{
+//		UserAgent: "acme-research/1.0",
+//		CacheDir:  "/var/cache/datareader",
+//		CacheTTL:  24 * time.Hour,
+//	})
+//
+//	// Cached with the defaults, plus a per-call API key
+//	data, err := datareader.Read(ctx, "GDP", "fred", start, end, &datareader.Options{APIKey: key})
+//
+// Because only non-zero fields override, a call cannot switch off a
+// boolean the defaults turn on; clear or replace the defaults instead.
+func SetDefaultOptions(opts *Options) {
+	var copied *Options
+	if opts != nil {
+		copied = cloneOptions(opts)
+	}
+
+	defaultsMu.Lock()
+	defer defaultsMu.Unlock()
+	defaults = copied
+}
+
+// GetDefaultOptions returns a copy of the options set by SetDefaultOptions,
+// or nil when none are set.
+func GetDefaultOptions() *Options {
+	defaultsMu.RLock()
+	defer defaultsMu.RUnlock()
+
+	if defaults == nil {
+		return nil
+	}
+	return cloneOptions(defaults)
+}
+
+// withDefaults returns the options a call with opts uses: opts itself when
+// no defaults are set, DefaultOptions for nil opts, or a copy of the
+// defaults with the non-zero fields of opts applied.
+func withDefaults(opts *Options) *Options {
+	set := GetDefaultOptions()
+	if set == nil {
+		return opts
+	}
+	if opts == nil {
+		return DefaultOptions()
+	}
+	overlay(set, opts)
+	return set
+}
+
+// overlay sets the fields of dst to the non-zero fields of src.
+func overlay(dst, src *Options) {
+	d := reflect.ValueOf(dst).Elem()
+	s := reflect.ValueOf(src).Elem()
+	for i := 0; i < s.NumField(); i++ {
+		if field := s.Field(i); !field.IsZero() {
+			d.Field(i).Set(field)
+		}
+	}
+}
+
+// cloneOptions returns a copy of opts whose map fields are copies too.
+func cloneOptions(opts *Options) *Options {
+	copied := *opts
+	v := reflect.ValueOf(&copied).Elem()
+	for i := 0; i < v.NumField(); i++ {
+		field := v.Field(i)
+		if field.Kind() != reflect.Map || field.IsNil() {
+			continue
+		}
+		m := reflect.MakeMapWithSize(field.Type(), field.Len())
+		iter := field.MapRange()
+		for iter.Next() {
+			m.SetMapIndex(iter.Key(), iter.Value())
+		}
+		field.Set(m)
+	}
+	return &copied
+}
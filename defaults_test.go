@@ -0,0 +1,103 @@
+package datareader_test
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+
+	datareader "github.com/julianshen/gonp-datareader"
+)
+
+// setDefaults sets the default options for the duration of the test.
+func setDefaults(t *testing.T, opts *datareader.Options) {
+	t.Helper()
+	datareader.SetDefaultOptions(opts)
+	t.Cleanup(func() { datareader.SetDefaultOptions(nil) })
+}
+
+func TestSetDefaultOptions(t *testing.T) {
+	var userAgent, apiKey string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		userAgent = r.UserAgent()
+		apiKey = r.URL.Query().Get("api_key")
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"observations":[{"date":"2024-01-02","value":"3.91"}]}`))
+	}))
+	defer server.Close()
+
+	setDefaults(t, &datareader.Options{
+		UserAgent:        "acme/1.0",
+		APIKey:           "org-key",
+		BaseURLOverrides: map[string]string{"fred": server.URL},
+	})
+	start := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	end := time.Date(2024, 1, 31, 0, 0, 0, 0, time.UTC)
+
+	// nil options use the defaults
+	if _, err := datareader.Read(context.Background(), "DGS10", "fred", start, end, nil); err != nil {
+		t.Fatalf("Read(nil) error = %v", err)
+	}
+	if userAgent != "acme/1.0" || apiKey != "org-key" {
+		t.Errorf("Read(nil) sent User-Agent %q, key %q, want the defaults", userAgent, apiKey)
+	}
+
+	// Non-zero per-call fields override the defaults field by field
+	if _, err := datareader.ReadDataset(context.Background(), "DGS10", "fred", start, end, &datareader.Options{APIKey: "call-key"}); err != nil {
+		t.Fatalf("ReadDataset() error = %v", err)
+	}
+	if userAgent != "acme/1.0" || apiKey != "call-key" {
+		t.Errorf("ReadDataset() sent User-Agent %q, key %q, want the default agent and the call's key", userAgent, apiKey)
+	}
+}
+
+func TestGetDefaultOptions_Copies(t *testing.T) {
+	if got := datareader.GetDefaultOptions(); got != nil {
+		t.Fatalf("GetDefaultOptions() = %+v, want nil when unset", got)
+	}
+
+	opts := &datareader.Options{CacheDir: "/tmp/a", BaseURLOverrides: map[string]string{"fred": "http://a"}}
+	setDefaults(t, opts)
+
+	// Changes to the passed or returned options do not leak into the defaults
+	opts.CacheDir = "/tmp/b"
+	opts.BaseURLOverrides["fred"] = "http://b"
+	got := datareader.GetDefaultOptions()
+	got.BaseURLOverrides["fred"] = "http://c"
+
+	again := datareader.GetDefaultOptions()
+	if again.CacheDir != "/tmp/a" || again.BaseURLOverrides["fred"] != "http://a" {
+		t.Errorf("GetDefaultOptions() = %+v, want the options as set", again)
+	}
+
+	// DefaultOptions includes them over the built-in values
+	def := datareader.DefaultOptions()
+	if def.CacheDir != "/tmp/a" || def.Timeout != 30*time.Second {
+		t.Errorf("DefaultOptions() CacheDir = %q, Timeout = %v", def.CacheDir, def.Timeout)
+	}
+}
+
+func TestSetDefaultOptions_Concurrent(t *testing.T) {
+	t.Cleanup(func() { datareader.SetDefaultOptions(nil) })
+
+	var wg sync.WaitGroup
+	for i := 0; i < 8; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for j := 0; j < 100; j++ {
+				datareader.SetDefaultOptions(&datareader.Options{RateLimits: map[string]datareader.RateLimitConfig{"fred": {Rate: 1}}})
+				if opts := datareader.GetDefaultOptions(); opts != nil {
+					opts.RateLimits["fred"] = datareader.RateLimitConfig{Rate: 2}
+				}
+				if _, err := datareader.DataReader("stooq", nil); err != nil {
+					t.Errorf("DataReader() error = %v", err)
+					return
+				}
+			}
+		}()
+	}
+	wg.Wait()
+}
@@ -0,0 +1,34 @@
+package datareader
+
+import (
+	"github.com/julianshen/gonp-datareader/sources/edgar"
+)
+
+// NewEDGARReader creates an SEC EDGAR full-text search reader configured by
+// opts, as DataReader configures the readers of time series sources. EDGAR
+// serves filings rather than time series, so it is not one of the sources
+// of DataReader. The SEC requires Options.UserAgent to name the requester
+// and a contact email. A BaseURLOverrides entry for "edgar" replaces the
+// search endpoint.
+//
+// # Example Usage
+//
+//	reader, err := datareader.NewEDGARReader(&datareader.Options{
+//		UserAgent: "Example Research research@example.com",
+//	})
+//	if err != nil {
+//		log.Fatal(err)
+//	}
+//	defer reader.Close()
+//	result, err := reader.SearchFilings(ctx, edgar.Query{Query: `"going concern"`})
+func NewEDGARReader(opts *Options) (*edgar.EDGARReader, error) {
+	opts = withDefaults(opts)
+	clientOpts, err := clientOptions("edgar", opts)
+	if err != nil {
+		return nil, err
+	}
+	if url, ok := baseURLOverride("edgar", opts); ok {
+		return edgar.NewEDGARReaderWithBaseURL(clientOpts, url), nil
+	}
+	return edgar.NewEDGARReader(clientOpts), nil
+}
@@ -0,0 +1,41 @@
+package datareader_test
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	datareader "github.com/julianshen/gonp-datareader"
+	"github.com/julianshen/gonp-datareader/sources/edgar"
+)
+
+func TestNewEDGARReader(t *testing.T) {
+	var userAgent string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		userAgent = r.Header.Get("User-Agent")
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"hits":{"total":{"value":1},"hits":[{"_id":"0000320193-20-000096:a10-k.htm","_source":{"ciks":["0000320193"],"display_names":["Apple Inc.  (AAPL)  (CIK 0000320193)"],"form":"10-K","file_date":"2020-10-30","adsh":"0000320193-20-000096"}}]}}`))
+	}))
+	defer server.Close()
+
+	reader, err := datareader.NewEDGARReader(&datareader.Options{
+		UserAgent:        "Example Research research@example.com",
+		BaseURLOverrides: map[string]string{"edgar": server.URL},
+	})
+	if err != nil {
+		t.Fatalf("NewEDGARReader() error = %v", err)
+	}
+	defer reader.Close()
+
+	result, err := reader.SearchFilings(context.Background(), edgar.Query{Query: "iphone"})
+	if err != nil {
+		t.Fatalf("SearchFilings() error = %v", err)
+	}
+	if len(result.Filings) != 1 || result.Filings[0].CompanyName != "Apple Inc." {
+		t.Errorf("SearchFilings() = %+v, want one Apple Inc. filing", result.Filings)
+	}
+	if userAgent != "Example Research research@example.com" {
+		t.Errorf("User-Agent = %q, want Options.UserAgent", userAgent)
+	}
+}
@@ -0,0 +1,127 @@
+package datareader
+
+import (
+	"errors"
+	"fmt"
+	"os"
+)
+
+// Environment selects which upstream deployment a reader talks to.
+type Environment string
+
+const (
+	// EnvironmentProduction uses each source's production API. This is the
+	// default; the zero value of Environment means production.
+	EnvironmentProduction Environment = "production"
+	// EnvironmentSandbox uses a source's sandbox or test deployment, so
+	// end-to-end flows can be exercised without consuming production quota
+	// or using real API keys. Sandbox data is often randomized.
+	EnvironmentSandbox Environment = "sandbox"
+)
+
+// ErrNoSandbox is returned by DataReader when EnvironmentSandbox is requested
+// for a source that has no known sandbox and no Options.SandboxBaseURLs entry.
+var ErrNoSandbox = errors.New("source has no sandbox environment")
+
+// sandbox describes a source's sandbox deployment. An empty baseURL keeps
+// the production endpoint; apiKey is used when Options.APIKey is empty.
+type sandbox struct {
+	baseURL string
+	apiKey  string
+}
+
+// sandboxes lists the sandbox deployments known for built-in sources.
+var sandboxes = map[string]sandbox{
+	// IEX Cloud served randomized data from its sandbox host to test
+	// ("Tpk_"/"Tsk_") tokens.
+	"iex": {baseURL: "https://sandbox.iexapis.com/stable/stock/%s/chart/%s?token=%s"},
+	// Alpha Vantage answers the "demo" key for a fixed set of symbols
+	// (e.g., IBM) without counting against any account's quota.
+	"alphavantage": {apiKey: "demo"},
+}
+
+// SandboxSources returns the names of the built-in sources with a known
+// sandbox environment, in the order returned by ListSources.
+func SandboxSources() []string {
+	var names []string
+	for _, name := range ListSources() {
+		if _, ok := sandboxes[name]; ok {
+			names = append(names, name)
+		}
+	}
+	return names
+}
+
+// apiKeyEnv maps the sources that take an API key to the environment
+// variable DataReader reads it from when Options.APIKey is empty.
+var apiKeyEnv = map[string]string{
+	"fred":         "FRED_API_KEY",
+	"alphavantage": "ALPHA_VANTAGE_API_KEY",
+	"iex":          "IEX_API_KEY",
+	"tiingo":       "TIINGO_API_KEY",
+	"finmind":      "FINMIND_TOKEN",
+}
+
+// APIKeyEnv returns the environment variable holding the API key or token
+// of source, such as FRED_API_KEY for "fred", or "" for sources without
+// one. DataReader uses it when Options.APIKey is empty, unless
+// Options.DisableEnvAPIKeys is set.
+func APIKeyEnv(source string) string {
+	return apiKeyEnv[source]
+}
+
+// resolveEnvironment returns the base URL and API key a reader for source
+// should use. An empty base URL means the source's production endpoint.
+func resolveEnvironment(source string, opts *Options) (baseURL, apiKey string, err error) {
+	if opts == nil {
+		return "", envAPIKey(source), nil
+	}
+	apiKey = opts.APIKey
+
+	switch opts.Environment {
+	case "", EnvironmentProduction:
+		if apiKey == "" && !opts.DisableEnvAPIKeys {
+			apiKey = envAPIKey(source)
+		}
+		return "", apiKey, nil
+	case EnvironmentSandbox:
+	default:
+		return "", "", fmt.Errorf("unknown environment %q", opts.Environment)
+	}
+
+	sb, known := sandboxes[source]
+	if apiKey == "" {
+		apiKey = sb.apiKey
+	}
+	if url, ok := opts.SandboxBaseURLs[source]; ok {
+		return url, apiKey, nil
+	}
+	if !known {
+		return "", "", fmt.Errorf("%w: %s", ErrNoSandbox, source)
+	}
+	return sb.baseURL, apiKey, nil
+}
+
+// envAPIKey returns the API key of source from its APIKeyEnv variable.
+// Sandbox readers never use it, as production keys do not apply there.
+func envAPIKey(source string) string {
+	if name := apiKeyEnv[source]; name != "" {
+		return os.Getenv(name)
+	}
+	return ""
+}
+
+// baseURLOverride returns the Options.BaseURLOverrides entry for source,
+// unless a SandboxBaseURLs entry applies in the sandbox environment.
+func baseURLOverride(source string, opts *Options) (string, bool) {
+	if opts == nil {
+		return "", false
+	}
+	if opts.Environment == EnvironmentSandbox {
+		if _, ok := opts.SandboxBaseURLs[source]; ok {
+			return "", false
+		}
+	}
+	url, ok := opts.BaseURLOverrides[source]
+	return url, ok && url != ""
+}
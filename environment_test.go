@@ -0,0 +1,209 @@
+package datareader_test
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"testing"
+	"time"
+
+	datareader "github.com/julianshen/gonp-datareader"
+)
+
+func TestDataReader_Environment(t *testing.T) {
+	tests := []struct {
+		name    string
+		source  string
+		opts    *datareader.Options
+		wantErr error
+	}{
+		{"production by default", "yahoo", &datareader.Options{}, nil},
+		{"explicit production", "yahoo", &datareader.Options{Environment: datareader.EnvironmentProduction}, nil},
+		{"iex sandbox", "iex", &datareader.Options{Environment: datareader.EnvironmentSandbox}, nil},
+		{"alphavantage sandbox", "alphavantage", &datareader.Options{Environment: datareader.EnvironmentSandbox}, nil},
+		{"no sandbox", "yahoo", &datareader.Options{Environment: datareader.EnvironmentSandbox}, datareader.ErrNoSandbox},
+		{"sandbox override", "yahoo", &datareader.Options{
+			Environment:     datareader.EnvironmentSandbox,
+			SandboxBaseURLs: map[string]string{"yahoo": "http://localhost/%s"},
+		}, nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			reader, err := datareader.DataReader(tt.source, tt.opts)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("DataReader() error = %v, want %v", err, tt.wantErr)
+			}
+			if tt.wantErr == nil && reader == nil {
+				t.Error("DataReader() returned nil reader")
+			}
+		})
+	}
+
+	_, err := datareader.DataReader("yahoo", &datareader.Options{Environment: "staging"})
+	if err == nil {
+		t.Error("DataReader() with unknown environment should fail")
+	}
+}
+
+func TestDataReader_SandboxBaseURL(t *testing.T) {
+	var gotKey string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		gotKey = r.URL.Query().Get("apikey")
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"Meta Data":{"2. Symbol":"IBM"},"Time Series (Daily)":{"2024-01-02":{"1. open":"162.83","2. high":"163.29","3. low":"160.38","4. close":"161.50","5. volume":"3825045"}}}`))
+	}))
+	defer server.Close()
+
+	reader, err := datareader.DataReader("alphavantage", &datareader.Options{
+		Environment: datareader.EnvironmentSandbox,
+		SandboxBaseURLs: map[string]string{
+			"alphavantage": server.URL + "?function=TIME_SERIES_DAILY&symbol=%s&apikey=%s",
+		},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	start := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	end := time.Date(2024, 1, 31, 0, 0, 0, 0, time.UTC)
+	if _, err := reader.ReadSingle(context.Background(), "IBM", start, end); err != nil {
+		t.Fatalf("ReadSingle() error = %v", err)
+	}
+	if gotKey != "demo" {
+		t.Errorf("apikey = %q, want demo", gotKey)
+	}
+}
+
+func TestDataReader_BaseURLOverrides(t *testing.T) {
+	newServer := func(hits *int) *httptest.Server {
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			*hits++
+			w.Write([]byte("Date,Open,High,Low,Close,Volume\n2024-01-02,1,1,1,10,100\n"))
+		}))
+		t.Cleanup(server.Close)
+		return server
+	}
+	var gatewayHits, sandboxHits int
+	gateway := newServer(&gatewayHits)
+	sandbox := newServer(&sandboxHits)
+
+	tests := []struct {
+		name        string
+		opts        *datareader.Options
+		wantGateway int
+		wantSandbox int
+	}{
+		{"production", &datareader.Options{
+			BaseURLOverrides: map[string]string{"stooq": gateway.URL + "?s=%s"},
+		}, 1, 0},
+		{"sandbox URL wins", &datareader.Options{
+			Environment:      datareader.EnvironmentSandbox,
+			SandboxBaseURLs:  map[string]string{"stooq": sandbox.URL + "?s=%s"},
+			BaseURLOverrides: map[string]string{"stooq": gateway.URL + "?s=%s"},
+		}, 0, 1},
+	}
+
+	start := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	end := time.Date(2024, 1, 31, 0, 0, 0, 0, time.UTC)
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			gatewayHits, sandboxHits = 0, 0
+			reader, err := datareader.DataReader("stooq", tt.opts)
+			if err != nil {
+				t.Fatalf("DataReader() error = %v", err)
+			}
+			if _, err := reader.ReadSingle(context.Background(), "AAA.US", start, end); err != nil {
+				t.Fatalf("ReadSingle() error = %v", err)
+			}
+			if gatewayHits != tt.wantGateway || sandboxHits != tt.wantSandbox {
+				t.Errorf("gateway hits = %d, sandbox hits = %d, want %d and %d",
+					gatewayHits, sandboxHits, tt.wantGateway, tt.wantSandbox)
+			}
+		})
+	}
+}
+
+func TestSandboxSources(t *testing.T) {
+	want := []string{"alphavantage", "iex"}
+	if got := datareader.SandboxSources(); !reflect.DeepEqual(got, want) {
+		t.Errorf("SandboxSources() = %v, want %v", got, want)
+	}
+}
+
+func TestDataReader_EnvAPIKey(t *testing.T) {
+	t.Setenv("FRED_API_KEY", "env-key")
+
+	var gotKey string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		gotKey = r.URL.Query().Get("api_key")
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"observations":[{"date":"2024-01-02","value":"3.91"}]}`))
+	}))
+	defer server.Close()
+
+	start := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	end := time.Date(2024, 1, 31, 0, 0, 0, 0, time.UTC)
+	read := func(opts *datareader.Options) string {
+		t.Helper()
+		gotKey = ""
+		opts.BaseURLOverrides = map[string]string{"fred": server.URL}
+		if _, err := datareader.Read(context.Background(), "DGS10", "fred", start, end, opts); err != nil {
+			t.Fatalf("Read() error = %v", err)
+		}
+		return gotKey
+	}
+
+	if got := read(&datareader.Options{}); got != "env-key" {
+		t.Errorf("api_key = %q, want the FRED_API_KEY value", got)
+	}
+	if got := read(&datareader.Options{APIKey: "explicit"}); got != "explicit" {
+		t.Errorf("api_key = %q, want Options.APIKey over the environment", got)
+	}
+
+	// Without the environment FRED has no key and makes no request
+	gotKey = ""
+	opts := &datareader.Options{DisableEnvAPIKeys: true, BaseURLOverrides: map[string]string{"fred": server.URL}}
+	if _, err := datareader.Read(context.Background(), "DGS10", "fred", start, end, opts); err == nil || gotKey != "" {
+		t.Errorf("Read() with DisableEnvAPIKeys error = %v, api_key = %q, want a missing key error", err, gotKey)
+	}
+}
+
+func TestDataReader_EnvAPIKeySandbox(t *testing.T) {
+	// Production keys are not sent to sandboxes
+	t.Setenv("ALPHA_VANTAGE_API_KEY", "production-key")
+
+	var gotKey string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		gotKey = r.URL.Query().Get("apikey")
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"Meta Data":{"2. Symbol":"IBM"},"Time Series (Daily)":{"2024-01-02":{"1. open":"162.83","2. high":"163.29","3. low":"160.38","4. close":"161.50","5. volume":"3825045"}}}`))
+	}))
+	defer server.Close()
+
+	reader, err := datareader.DataReader("alphavantage", &datareader.Options{
+		Environment:     datareader.EnvironmentSandbox,
+		SandboxBaseURLs: map[string]string{"alphavantage": server.URL + "?symbol=%s&apikey=%s"},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	start := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	if _, err := reader.ReadSingle(context.Background(), "IBM", start, start.AddDate(0, 1, 0)); err != nil {
+		t.Fatalf("ReadSingle() error = %v", err)
+	}
+	if gotKey != "demo" {
+		t.Errorf("apikey = %q, want demo", gotKey)
+	}
+}
+
+func TestAPIKeyEnv(t *testing.T) {
+	if got := datareader.APIKeyEnv("tiingo"); got != "TIINGO_API_KEY" {
+		t.Errorf("APIKeyEnv(tiingo) = %q", got)
+	}
+	if got := datareader.APIKeyEnv("yahoo"); got != "" {
+		t.Errorf("APIKeyEnv(yahoo) = %q, want none", got)
+	}
+}
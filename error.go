@@ -1,6 +1,10 @@
 package datareader
 
-import "fmt"
+import (
+	"fmt"
+
+	"github.com/julianshen/gonp-datareader/sources"
+)
 
 // ErrorType represents the type of error that occurred.
 type ErrorType int
@@ -47,8 +51,22 @@ func (e *DataReaderError) Unwrap() error {
 	return e.Cause
 }
 
-// Is implements error matching for errors.Is.
+// errorKinds maps error types to the sentinel errors readers wrap, so a
+// DataReaderError matches the same errors.Is checks as reader errors.
+var errorKinds = map[ErrorType]error{
+	ErrInvalidDateRange:     sources.ErrInvalidDateRange,
+	ErrAPILimit:             sources.ErrRateLimited,
+	ErrAuthenticationFailed: sources.ErrAuthRequired,
+	ErrDataNotFound:         sources.ErrNoData,
+}
+
+// Is implements error matching for errors.Is. Besides an equal
+// DataReaderError, an error matches the sources sentinel of its type, e.g.
+// an ErrAPILimit error matches sources.ErrRateLimited.
 func (e *DataReaderError) Is(target error) bool {
+	if kind, ok := errorKinds[e.Type]; ok && target == kind {
+		return true
+	}
 	t, ok := target.(*DataReaderError)
 	if !ok {
 		return false
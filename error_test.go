@@ -5,6 +5,7 @@ import (
 	"testing"
 
 	datareader "github.com/julianshen/gonp-datareader"
+	"github.com/julianshen/gonp-datareader/sources"
 )
 
 func TestDataReaderError_Error(t *testing.T) {
@@ -99,6 +100,28 @@ func TestDataReaderError_Is(t *testing.T) {
 	}
 }
 
+func TestDataReaderError_IsSentinel(t *testing.T) {
+	tests := []struct {
+		errType datareader.ErrorType
+		want    error
+	}{
+		{datareader.ErrInvalidDateRange, sources.ErrInvalidDateRange},
+		{datareader.ErrAPILimit, sources.ErrRateLimited},
+		{datareader.ErrAuthenticationFailed, sources.ErrAuthRequired},
+		{datareader.ErrDataNotFound, sources.ErrNoData},
+	}
+
+	for _, tt := range tests {
+		err := datareader.NewDataReaderError(tt.errType, "yahoo", "failed", nil)
+		if !errors.Is(err, tt.want) {
+			t.Errorf("errors.Is(type %d, %v) = false, want true", tt.errType, tt.want)
+		}
+		if errors.Is(err, sources.ErrSymbolNotFound) {
+			t.Errorf("errors.Is(type %d, ErrSymbolNotFound) = true, want false", tt.errType)
+		}
+	}
+}
+
 func TestErrorTypes(t *testing.T) {
 	// Test that error type constants are defined
 	errorTypes := []datareader.ErrorType{
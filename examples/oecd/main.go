@@ -42,9 +42,8 @@ func main() {
 	fmt.Println("\nNote: OECD API requires specific dataset identifiers.")
 	fmt.Println("Examples shown use simplified formats for demonstration.")
 	fmt.Println("\nTo find real dataset IDs:")
-	fmt.Println("1. Visit https://data.oecd.org")
-	fmt.Println("2. Select a dataset")
-	fmt.Println("3. Click 'Developer API' to see the correct format")
+	fmt.Println("1. List datasets with reader.ListDataflows(ctx)")
+	fmt.Println("2. Inspect a dataset's key dimensions with reader.DescribeDataflow(ctx, id)")
 
 	// Example 2: Using the factory pattern
 	fmt.Println("\n--- Example 2: Factory Pattern ---")
@@ -144,11 +143,9 @@ func main() {
 	fmt.Println("  • Some datasets have limited country coverage")
 
 	fmt.Println("\nHow to Find Dataset IDs:")
-	fmt.Println("  1. Go to https://data.oecd.org")
-	fmt.Println("  2. Browse or search for your indicator")
-	fmt.Println("  3. Click on the dataset")
-	fmt.Println("  4. Click 'Developer API' button")
-	fmt.Println("  5. Copy the dataset ID from the API query")
+	fmt.Println("  1. Call ListDataflows to enumerate datasets (ID, agency, name)")
+	fmt.Println("  2. Call DescribeDataflow to get each key dimension and its codes")
+	fmt.Println("  3. Join one code per dimension with '.' to build a data key")
 
 	fmt.Println("\nLinks:")
 	fmt.Println("  • Data Explorer: https://data.oecd.org")
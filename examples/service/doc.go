@@ -0,0 +1,3 @@
+// Package service holds a docker-compose deployment of datareaderd and the
+// integration tests run against it; see README.md.
+package service
@@ -0,0 +1,103 @@
+//go:build integration
+// +build integration
+
+package service_test
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"os"
+	"strings"
+	"testing"
+	"time"
+)
+
+// serviceURL returns the deployment under test, skipping the test when
+// DATAREADERD_URL is not set.
+func serviceURL(t *testing.T) string {
+	url := os.Getenv("DATAREADERD_URL")
+	if url == "" {
+		t.Skip("DATAREADERD_URL not set; start the stack with docker compose up")
+	}
+	return url
+}
+
+// get fetches path from the deployment and decodes the JSON response.
+func get(t *testing.T, path string, v interface{}) {
+	t.Helper()
+	client := &http.Client{Timeout: 30 * time.Second}
+	req, err := http.NewRequest("GET", serviceURL(t)+path, nil)
+	if err != nil {
+		t.Fatalf("create request: %v", err)
+	}
+	if key := os.Getenv("DATAREADERD_API_KEY"); key != "" {
+		req.Header.Set("X-API-Key", key)
+	}
+	resp, err := client.Do(req)
+	if err != nil {
+		t.Fatalf("GET %s: %v", path, err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		t.Fatalf("GET %s: status %d", path, resp.StatusCode)
+	}
+	if err := json.NewDecoder(resp.Body).Decode(v); err != nil {
+		t.Fatalf("GET %s: decode: %v", path, err)
+	}
+}
+
+func TestService_Sources(t *testing.T) {
+	var body struct {
+		Sources []string `json:"sources"`
+	}
+	get(t, "/v1/sources", &body)
+
+	for _, source := range body.Sources {
+		if source == "yahoo" {
+			return
+		}
+	}
+	t.Errorf("sources = %v, want yahoo listed", body.Sources)
+}
+
+func TestService_Read(t *testing.T) {
+	var body struct {
+		Source  string   `json:"source"`
+		Symbol  string   `json:"symbol"`
+		Dates   []string `json:"dates"`
+		Columns []struct {
+			Name string `json:"name"`
+		} `json:"columns"`
+	}
+	get(t, "/v1/stooq/AAPL.US?start=2024-01-02&end=2024-01-31", &body)
+
+	if body.Source != "stooq" || len(body.Dates) == 0 || len(body.Columns) == 0 {
+		t.Errorf("response = %+v, want stooq rows", body)
+	}
+}
+
+func TestService_Metrics(t *testing.T) {
+	// Make sure stooq has been read at least once
+	var body struct{}
+	get(t, "/v1/stooq/AAPL.US?start=2024-01-02&end=2024-01-31", &body)
+
+	// Scrapes carry no key
+	client := &http.Client{Timeout: 30 * time.Second}
+	resp, err := client.Get(serviceURL(t) + "/metrics")
+	if err != nil {
+		t.Fatalf("GET /metrics: %v", err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		t.Fatalf("GET /metrics: status %d", resp.StatusCode)
+	}
+	metrics, err := io.ReadAll(resp.Body)
+	if err != nil {
+		t.Fatalf("read /metrics: %v", err)
+	}
+
+	if !strings.Contains(string(metrics), `datareaderd_requests_total{source="stooq"}`) {
+		t.Errorf("metrics = %s, want stooq requests counted", metrics)
+	}
+}
@@ -0,0 +1,82 @@
+// Command updater keeps a local CSV store of daily bars up to date, as the
+// scheduled job of the service example. At startup and then every -every
+// it runs recipes.UpdateLocalDB, which fetches only the days after each
+// file's last date:
+//
+//	updater -source stooq -symbols AAPL.US,MSFT.US -dir /data -every 24h
+//
+// Files are written to <dir>/<source>/<symbol>.csv. Upstream API keys are
+// read from the environment (e.g., FRED_API_KEY, TIINGO_API_KEY).
+package main
+
+import (
+	"context"
+	"flag"
+	"log"
+	"os"
+	"os/signal"
+	"strings"
+	"syscall"
+	"time"
+
+	datareader "github.com/julianshen/gonp-datareader"
+	"github.com/julianshen/gonp-datareader/recipes"
+	"github.com/julianshen/gonp-datareader/sources"
+)
+
+func main() {
+	source := flag.String("source", "stooq", "source to read from")
+	symbols := flag.String("symbols", "", "comma-separated symbols to keep up to date")
+	dir := flag.String("dir", "data", "directory of the CSV store")
+	start := flag.String("start", "2020-01-01", "first date of symbols without a file (YYYY-MM-DD)")
+	every := flag.Duration("every", 24*time.Hour, "interval between updates")
+	rateLimit := flag.Float64("rate-limit", 1, "upstream requests per second (0 disables)")
+	flag.Parse()
+
+	var list []string
+	for _, symbol := range strings.Split(*symbols, ",") {
+		if symbol = strings.TrimSpace(symbol); symbol != "" {
+			list = append(list, symbol)
+		}
+	}
+	if len(list) == 0 {
+		log.Fatal("no symbols; set -symbols")
+	}
+	from, err := time.Parse("2006-01-02", *start)
+	if err != nil {
+		log.Fatalf("invalid -start: %v", err)
+	}
+
+	opts := datareader.DefaultOptions()
+	opts.RateLimit = *rateLimit
+	reader, err := datareader.DataReader(*source, opts)
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	ticker := time.NewTicker(*every)
+	defer ticker.Stop()
+	for {
+		update(ctx, reader, list, from, *dir)
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// update brings the store up to date, logging rather than exiting on
+// failure so the next run retries.
+func update(ctx context.Context, reader sources.Reader, symbols []string, start time.Time, dir string) {
+	began := time.Now()
+	paths, err := recipes.UpdateLocalDB(ctx, reader, symbols, start, time.Now(), dir)
+	if err != nil {
+		log.Printf("update failed after %d of %d symbols: %v", len(paths), len(symbols), err)
+		return
+	}
+	log.Printf("updated %d symbols in %s", len(paths), time.Since(began).Round(time.Millisecond))
+}
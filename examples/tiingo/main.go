@@ -64,7 +64,7 @@ func main() {
 		}
 		for i := startIdx; i < len(data.Prices); i++ {
 			price := data.Prices[i]
-			fmt.Printf("  %s: Open=$%.2f, High=$%.2f, Low=$%.2f, Close=$%.2f, Volume=%d\n",
+			fmt.Printf("  %s: Open=$%.2f, High=$%.2f, Low=$%.2f, Close=$%.2f, Volume=%.0f\n",
 				data.Dates[i], price.Open, price.High, price.Low, price.Close, price.Volume)
 		}
 	}
@@ -103,7 +103,7 @@ func main() {
 		if len(stockData.Prices) > 0 {
 			lastIdx := len(stockData.Prices) - 1
 			lastPrice := stockData.Prices[lastIdx]
-			fmt.Printf("  Latest (%s): Close=$%.2f, Volume=%d\n",
+			fmt.Printf("  Latest (%s): Close=$%.2f, Volume=%.0f\n",
 				stockData.Dates[lastIdx], lastPrice.Close, lastPrice.Volume)
 		}
 	}
@@ -128,7 +128,7 @@ func main() {
 		fmt.Printf("  High: $%.2f\n", price.High)
 		fmt.Printf("  Low: $%.2f\n", price.Low)
 		fmt.Printf("  Close: $%.2f\n", price.Close)
-		fmt.Printf("  Volume: %d\n", price.Volume)
+		fmt.Printf("  Volume: %.0f\n", price.Volume)
 	}
 
 	// Tiingo information
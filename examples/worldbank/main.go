@@ -102,9 +102,17 @@ func main() {
 		inflationData := inflationResult.(*worldbank.ParsedData)
 		fmt.Printf("✓ Fetched inflation data (%d observations)\n", len(inflationData.Dates))
 
-		if len(inflationData.Dates) > 0 {
-			lastIdx := len(inflationData.Dates) - 1
-			fmt.Printf("  Latest (%s): %s%%\n", inflationData.Dates[lastIdx], inflationData.Values[lastIdx])
+		// Recent years are missing until the World Bank publishes them
+		values, valid, err := inflationData.Floats()
+		if err != nil {
+			fmt.Printf("✗ Failed to parse inflation data for %s: %v\n", country, err)
+			continue
+		}
+		for i := len(values) - 1; i >= 0; i-- {
+			if valid[i] {
+				fmt.Printf("  Latest (%s): %.2f%%\n", inflationData.Dates[i], values[i])
+				break
+			}
 		}
 	}
 
@@ -0,0 +1,104 @@
+package feed
+
+import (
+	"sort"
+	"sync"
+	"time"
+)
+
+// Clock abstracts time so replays and live-data consumers can run against
+// simulated time.
+type Clock interface {
+	Now() time.Time
+	After(d time.Duration) <-chan time.Time
+}
+
+// RealClock is a Clock backed by the time package.
+type RealClock struct{}
+
+// Now implements Clock.
+func (RealClock) Now() time.Time {
+	return time.Now()
+}
+
+// After implements Clock.
+func (RealClock) After(d time.Duration) <-chan time.Time {
+	return time.After(d)
+}
+
+// SimClock is a Clock that only moves when Advance or Set is called, for
+// deterministic tests.
+type SimClock struct {
+	mu      sync.Mutex
+	now     time.Time
+	waiters []simWaiter
+}
+
+type simWaiter struct {
+	at time.Time
+	ch chan time.Time
+}
+
+// NewSimClock creates a SimClock starting at start.
+func NewSimClock(start time.Time) *SimClock {
+	return &SimClock{now: start}
+}
+
+// Now implements Clock.
+func (c *SimClock) Now() time.Time {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.now
+}
+
+// After implements Clock. The channel fires once the clock has been moved
+// d past the current time.
+func (c *SimClock) After(d time.Duration) <-chan time.Time {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	ch := make(chan time.Time, 1)
+	at := c.now.Add(d)
+	if d <= 0 {
+		ch <- c.now
+		return ch
+	}
+	c.waiters = append(c.waiters, simWaiter{at: at, ch: ch})
+	return ch
+}
+
+// Advance moves the clock forward by d, firing due timers.
+func (c *SimClock) Advance(d time.Duration) {
+	c.Set(c.Now().Add(d))
+}
+
+// Set moves the clock to t, firing due timers in time order. Moving
+// backwards is ignored.
+func (c *SimClock) Set(t time.Time) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if t.Before(c.now) {
+		return
+	}
+	c.now = t
+
+	sort.Slice(c.waiters, func(i, j int) bool { return c.waiters[i].at.Before(c.waiters[j].at) })
+	pending := c.waiters[:0]
+	for _, w := range c.waiters {
+		if w.at.After(t) {
+			pending = append(pending, w)
+			continue
+		}
+		w.ch <- t
+	}
+	c.waiters = pending
+}
+
+// Waiters returns the number of pending After timers, so tests can wait
+// for a consumer to block before advancing.
+func (c *SimClock) Waiters() int {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return len(c.waiters)
+}
@@ -0,0 +1,154 @@
+// Package feed adapts datasets and live bar streams to a minimal
+// iterator interface that Go backtesting frameworks can consume directly.
+//
+// # Example Usage
+//
+//	aapl, _ := datareader.ReadDataset(ctx, "AAPL", "yahoo", start, end, nil)
+//	msft, _ := datareader.ReadDataset(ctx, "MSFT", "yahoo", start, end, nil)
+//
+//	a, err := feed.FromDataset(aapl)
+//	m, err := feed.FromDataset(msft)
+//	bars := feed.Merge(a, m) // time-ordered across symbols
+//
+//	for bar, ok := bars.Next(); ok; bar, ok = bars.Next() {
+//		strategy.OnBar(bar)
+//	}
+package feed
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"slices"
+	"time"
+
+	"github.com/julianshen/gonp-datareader/dataset"
+	"github.com/julianshen/gonp-datareader/ohlcv"
+)
+
+// ErrNoClose indicates a dataset has no close (or value) column to build
+// bars from.
+var ErrNoClose = errors.New("no close column")
+
+// Bar is one OHLCV observation. Fields missing from the source are NaN.
+type Bar struct {
+	Symbol   string
+	Time     time.Time
+	Open     float64
+	High     float64
+	Low      float64
+	Close    float64
+	Volume   float64
+	AdjClose float64
+}
+
+// BarFeed iterates over bars in time order.
+type BarFeed interface {
+	// Next returns the next bar, or false when the feed is exhausted.
+	Next() (Bar, bool)
+	// Reset rewinds the feed to its first bar.
+	Reset()
+}
+
+// columnAliases lists the column names each Bar field is read from, in
+// order of preference: those of ohlcv.Columns, and "Value" of
+// single-series sources as the close.
+var columnAliases = struct {
+	open, high, low, close, volume, adjClose []string
+}{
+	open:     ohlcv.Columns.Open,
+	high:     ohlcv.Columns.High,
+	low:      ohlcv.Columns.Low,
+	close:    append(slices.Clip(ohlcv.Columns.Close), "Value"),
+	volume:   ohlcv.Columns.Volume,
+	adjClose: ohlcv.Columns.AdjClose,
+}
+
+// DatasetFeed is a BarFeed over a dataset.Dataset.
+type DatasetFeed struct {
+	bars []Bar
+	pos  int
+}
+
+// FromDataset builds a feed from ds. Bars are built from the OHLCV
+// columns (using each source's naming) and rows with a missing close are
+// skipped. Single-series data such as FRED uses its "Value" column as the
+// close. Dates must be in ascending order.
+func FromDataset(ds *dataset.Dataset) (*DatasetFeed, error) {
+	closes := column(ds, columnAliases.close)
+	if closes == nil {
+		return nil, fmt.Errorf("%s: %w (have %v)", ds.Symbol, ErrNoClose, ds.ColumnNames())
+	}
+	for i := 1; i < len(ds.Dates); i++ {
+		if ds.Dates[i].Before(ds.Dates[i-1]) {
+			return nil, fmt.Errorf("%s: %w", ds.Symbol, dataset.ErrUnsorted)
+		}
+	}
+
+	open := column(ds, columnAliases.open)
+	high := column(ds, columnAliases.high)
+	low := column(ds, columnAliases.low)
+	volume := column(ds, columnAliases.volume)
+	adjClose := column(ds, columnAliases.adjClose)
+
+	bars := make([]Bar, 0, len(ds.Dates))
+	for i, t := range ds.Dates {
+		if math.IsNaN(closes[i]) {
+			continue
+		}
+		bars = append(bars, Bar{
+			Symbol:   ds.Symbol,
+			Time:     t,
+			Open:     at(open, i),
+			High:     at(high, i),
+			Low:      at(low, i),
+			Close:    closes[i],
+			Volume:   at(volume, i),
+			AdjClose: at(adjClose, i),
+		})
+	}
+	return &DatasetFeed{bars: bars}, nil
+}
+
+// FromBars builds a feed over bars, which must be in time order.
+func FromBars(bars []Bar) *DatasetFeed {
+	return &DatasetFeed{bars: append([]Bar(nil), bars...)}
+}
+
+// Next implements BarFeed.
+func (f *DatasetFeed) Next() (Bar, bool) {
+	if f.pos >= len(f.bars) {
+		return Bar{}, false
+	}
+	b := f.bars[f.pos]
+	f.pos++
+	return b, true
+}
+
+// Reset implements BarFeed.
+func (f *DatasetFeed) Reset() {
+	f.pos = 0
+}
+
+// Len returns the total number of bars.
+func (f *DatasetFeed) Len() int {
+	return len(f.bars)
+}
+
+// column returns the first column of ds matching one of names, or nil.
+func column(ds *dataset.Dataset, names []string) []float64 {
+	for _, name := range names {
+		if values, ok := ds.Column(name); ok {
+			return values
+		}
+	}
+	return nil
+}
+
+// at returns values[i], or NaN when the column is absent.
+func at(values []float64, i int) float64 {
+	if values == nil {
+		return math.NaN()
+	}
+	return values[i]
+}
@@ -0,0 +1,158 @@
+package feed_test
+
+import (
+	"context"
+	"errors"
+	"math"
+	"testing"
+	"time"
+
+	"github.com/julianshen/gonp-datareader/dataset"
+	"github.com/julianshen/gonp-datareader/feed"
+)
+
+func day(d int) time.Time {
+	return time.Date(2024, 1, d, 0, 0, 0, 0, time.UTC)
+}
+
+func newDataset(t *testing.T, symbol string, dates []time.Time, columns map[string][]float64) *dataset.Dataset {
+	t.Helper()
+	ds := dataset.New(symbol, "test", dates)
+	for _, name := range []string{"Open", "High", "Low", "Close", "Volume", "Adj Close", "max", "Value"} {
+		if values, ok := columns[name]; ok {
+			if err := ds.AddColumn(name, values); err != nil {
+				t.Fatal(err)
+			}
+		}
+	}
+	return ds
+}
+
+// drain reads every bar from f.
+func drain(f feed.BarFeed) []feed.Bar {
+	var bars []feed.Bar
+	for b, ok := f.Next(); ok; b, ok = f.Next() {
+		bars = append(bars, b)
+	}
+	return bars
+}
+
+func TestFromDataset(t *testing.T) {
+	ds := newDataset(t, "AAPL", []time.Time{day(2), day(3), day(4)}, map[string][]float64{
+		"Open":      {10, 11, 12},
+		"High":      {12, 13, 14},
+		"Low":       {9, 10, 11},
+		"Close":     {11, math.NaN(), 13},
+		"Volume":    {100, 200, 300},
+		"Adj Close": {10.5, 11.5, 12.5},
+	})
+
+	f, err := feed.FromDataset(ds)
+	if err != nil {
+		t.Fatalf("FromDataset() error = %v", err)
+	}
+	if f.Len() != 2 {
+		t.Fatalf("Len() = %d, want 2 (missing close skipped)", f.Len())
+	}
+
+	bars := drain(f)
+	want := feed.Bar{Symbol: "AAPL", Time: day(4), Open: 12, High: 14, Low: 11, Close: 13, Volume: 300, AdjClose: 12.5}
+	if len(bars) != 2 || bars[1] != want {
+		t.Errorf("bars = %+v, want second bar %+v", bars, want)
+	}
+
+	f.Reset()
+	if b, ok := f.Next(); !ok || !b.Time.Equal(day(2)) {
+		t.Errorf("Next() after Reset() = %+v, %v, want first bar", b, ok)
+	}
+}
+
+func TestFromDataset_SingleSeries(t *testing.T) {
+	ds := newDataset(t, "GDP", []time.Time{day(1)}, map[string][]float64{"Value": {27000}})
+
+	f, err := feed.FromDataset(ds)
+	if err != nil {
+		t.Fatalf("FromDataset() error = %v", err)
+	}
+	b, _ := f.Next()
+	if b.Close != 27000 || !math.IsNaN(b.Open) || !math.IsNaN(b.Volume) {
+		t.Errorf("bar = %+v, want Close 27000 and NaN Open/Volume", b)
+	}
+}
+
+func TestFromDataset_Aliases(t *testing.T) {
+	ds := newDataset(t, "2330", []time.Time{day(1)}, map[string][]float64{"max": {600}, "Close": {590}})
+
+	f, err := feed.FromDataset(ds)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if b, _ := f.Next(); b.High != 600 {
+		t.Errorf("High = %v, want 600 from FinMind \"max\" column", b.High)
+	}
+}
+
+func TestFromDataset_Errors(t *testing.T) {
+	noClose := newDataset(t, "X", []time.Time{day(1)}, map[string][]float64{"Open": {1}})
+	if _, err := feed.FromDataset(noClose); !errors.Is(err, feed.ErrNoClose) {
+		t.Errorf("FromDataset() error = %v, want ErrNoClose", err)
+	}
+
+	unsorted := newDataset(t, "X", []time.Time{day(2), day(1)}, map[string][]float64{"Close": {1, 2}})
+	if _, err := feed.FromDataset(unsorted); !errors.Is(err, dataset.ErrUnsorted) {
+		t.Errorf("FromDataset() error = %v, want ErrUnsorted", err)
+	}
+}
+
+func TestMerge(t *testing.T) {
+	a := feed.FromBars([]feed.Bar{{Symbol: "A", Time: day(1)}, {Symbol: "A", Time: day(3)}})
+	b := feed.FromBars([]feed.Bar{{Symbol: "B", Time: day(1)}, {Symbol: "B", Time: day(2)}})
+
+	m := feed.Merge(a, b)
+	var got []string
+	for _, bar := range drain(m) {
+		got = append(got, bar.Symbol+bar.Time.Format("02"))
+	}
+	want := []string{"A01", "B01", "B02", "A03"}
+	if len(got) != len(want) {
+		t.Fatalf("merged = %v, want %v", got, want)
+	}
+	for i := range want {
+		if got[i] != want[i] {
+			t.Fatalf("merged = %v, want %v", got, want)
+		}
+	}
+
+	m.Reset()
+	if len(drain(m)) != 4 {
+		t.Error("Reset() did not rewind the merged feed")
+	}
+}
+
+func TestFromStream(t *testing.T) {
+	ch := make(chan feed.Bar, 2)
+	ch <- feed.Bar{Symbol: "A", Time: day(1)}
+	ch <- feed.Bar{Symbol: "A", Time: day(2)}
+	close(ch)
+
+	f := feed.FromStream(context.Background(), ch)
+	if got := len(drain(f)); got != 2 {
+		t.Fatalf("drained %d bars, want 2", got)
+	}
+
+	// Reset replays the bars received so far
+	f.Reset()
+	if got := len(drain(f)); got != 2 {
+		t.Errorf("replayed %d bars, want 2", got)
+	}
+}
+
+func TestFromStream_Cancel(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	f := feed.FromStream(ctx, make(chan feed.Bar))
+
+	cancel()
+	if _, ok := f.Next(); ok {
+		t.Error("Next() returned a bar after cancellation")
+	}
+}
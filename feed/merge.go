@@ -0,0 +1,52 @@
+package feed
+
+// MergedFeed interleaves several feeds into a single time-ordered feed.
+// Bars with equal times are returned in the order the feeds were given.
+type MergedFeed struct {
+	feeds   []BarFeed
+	heads   []Bar
+	hasHead []bool
+	started bool
+}
+
+// Merge combines feeds, e.g. one per symbol, into one feed ordered by bar
+// time.
+func Merge(feeds ...BarFeed) *MergedFeed {
+	return &MergedFeed{
+		feeds:   feeds,
+		heads:   make([]Bar, len(feeds)),
+		hasHead: make([]bool, len(feeds)),
+	}
+}
+
+// Next implements BarFeed.
+func (m *MergedFeed) Next() (Bar, bool) {
+	if !m.started {
+		for i, f := range m.feeds {
+			m.heads[i], m.hasHead[i] = f.Next()
+		}
+		m.started = true
+	}
+
+	best := -1
+	for i := range m.feeds {
+		if m.hasHead[i] && (best < 0 || m.heads[i].Time.Before(m.heads[best].Time)) {
+			best = i
+		}
+	}
+	if best < 0 {
+		return Bar{}, false
+	}
+
+	b := m.heads[best]
+	m.heads[best], m.hasHead[best] = m.feeds[best].Next()
+	return b, true
+}
+
+// Reset implements BarFeed by resetting every underlying feed.
+func (m *MergedFeed) Reset() {
+	for _, f := range m.feeds {
+		f.Reset()
+	}
+	m.started = false
+}
@@ -0,0 +1,55 @@
+package feed
+
+import (
+	"context"
+	"time"
+)
+
+// ReplayOptions configures Replay.
+type ReplayOptions struct {
+	// Speed is the number of market-time seconds replayed per clock second
+	// (e.g., 86400 replays one trading day per second). Zero or negative
+	// values replay without delay.
+	Speed float64
+
+	// Clock paces the replay. Nil means RealClock; use a SimClock for
+	// deterministic tests.
+	Clock Clock
+}
+
+// Replay streams the bars of f on the returned channel in accelerated real
+// time: the delay between two bars is their time difference divided by
+// Speed. The channel is closed when f is exhausted or ctx is cancelled.
+// Wrap the channel with FromStream to consume it as a BarFeed, exactly like
+// live data.
+func Replay(ctx context.Context, f BarFeed, opts ReplayOptions) <-chan Bar {
+	clock := opts.Clock
+	if clock == nil {
+		clock = RealClock{}
+	}
+
+	out := make(chan Bar)
+	go func() {
+		defer close(out)
+
+		var prev time.Time
+		for b, ok := f.Next(); ok; b, ok = f.Next() {
+			if opts.Speed > 0 && !prev.IsZero() && b.Time.After(prev) {
+				delay := time.Duration(float64(b.Time.Sub(prev)) / opts.Speed)
+				select {
+				case <-ctx.Done():
+					return
+				case <-clock.After(delay):
+				}
+			}
+			prev = b.Time
+
+			select {
+			case <-ctx.Done():
+				return
+			case out <- b:
+			}
+		}
+	}()
+	return out
+}
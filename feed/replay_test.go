@@ -0,0 +1,116 @@
+package feed_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/julianshen/gonp-datareader/feed"
+)
+
+func TestSimClock(t *testing.T) {
+	start := time.Date(2024, 1, 1, 9, 30, 0, 0, time.UTC)
+	clock := feed.NewSimClock(start)
+
+	late := clock.After(2 * time.Minute)
+	early := clock.After(time.Minute)
+
+	clock.Advance(time.Minute)
+	select {
+	case got := <-early:
+		if !got.Equal(start.Add(time.Minute)) {
+			t.Errorf("early fired at %v", got)
+		}
+	default:
+		t.Fatal("early timer did not fire")
+	}
+	select {
+	case <-late:
+		t.Fatal("late timer fired too soon")
+	default:
+	}
+
+	clock.Set(start) // moving backwards is ignored
+	clock.Advance(time.Minute)
+	<-late
+	if clock.Waiters() != 0 {
+		t.Errorf("Waiters() = %d, want 0", clock.Waiters())
+	}
+}
+
+func TestReplay_SimClock(t *testing.T) {
+	clock := feed.NewSimClock(time.Unix(0, 0))
+	bars := feed.FromBars([]feed.Bar{
+		{Symbol: "A", Time: day(1)},
+		{Symbol: "A", Time: day(2)},
+		{Symbol: "A", Time: day(4)},
+	})
+
+	// One market day per simulated second
+	ch := feed.Replay(context.Background(), bars, feed.ReplayOptions{Speed: 86400, Clock: clock})
+	live := feed.FromStream(context.Background(), ch)
+
+	// The first bar is delivered immediately
+	if b, ok := live.Next(); !ok || !b.Time.Equal(day(1)) {
+		t.Fatalf("first bar = %+v, %v", b, ok)
+	}
+
+	waitForTimer(t, clock)
+	clock.Advance(time.Second)
+	if b, _ := live.Next(); !b.Time.Equal(day(2)) {
+		t.Fatalf("second bar = %+v", b)
+	}
+
+	// Two market days need two simulated seconds
+	waitForTimer(t, clock)
+	clock.Advance(time.Second)
+	if clock.Waiters() != 1 {
+		t.Fatal("third bar released too early")
+	}
+	clock.Advance(time.Second)
+	if b, _ := live.Next(); !b.Time.Equal(day(4)) {
+		t.Fatalf("third bar = %+v", b)
+	}
+
+	if _, ok := live.Next(); ok {
+		t.Error("replay did not end after the last bar")
+	}
+}
+
+func TestReplay_NoDelay(t *testing.T) {
+	bars := feed.FromBars([]feed.Bar{{Time: day(1)}, {Time: day(2)}, {Time: day(3)}})
+
+	n := 0
+	for range feed.Replay(context.Background(), bars, feed.ReplayOptions{}) {
+		n++
+	}
+	if n != 3 {
+		t.Errorf("replayed %d bars, want 3", n)
+	}
+}
+
+func TestReplay_Cancel(t *testing.T) {
+	clock := feed.NewSimClock(time.Unix(0, 0))
+	bars := feed.FromBars([]feed.Bar{{Time: day(1)}, {Time: day(2)}})
+
+	ctx, cancel := context.WithCancel(context.Background())
+	ch := feed.Replay(ctx, bars, feed.ReplayOptions{Speed: 1, Clock: clock})
+	<-ch
+	cancel()
+
+	if _, ok := <-ch; ok {
+		t.Error("replay delivered a bar after cancellation")
+	}
+}
+
+// waitForTimer waits until the replay goroutine is blocked on the clock.
+func waitForTimer(t *testing.T, clock *feed.SimClock) {
+	t.Helper()
+	deadline := time.Now().Add(time.Second)
+	for clock.Waiters() == 0 {
+		if time.Now().After(deadline) {
+			t.Fatal("replay never waited on the clock")
+		}
+		time.Sleep(time.Millisecond)
+	}
+}
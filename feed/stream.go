@@ -0,0 +1,58 @@
+package feed
+
+import (
+	"context"
+	"sync"
+)
+
+// StreamFeed adapts a channel of live bars to a BarFeed. Next blocks until
+// a bar arrives, the channel is closed or the context is cancelled.
+// Received bars are recorded, so Reset replays them from the start before
+// continuing with the stream.
+type StreamFeed struct {
+	ctx context.Context
+	ch  <-chan Bar
+
+	mu       sync.Mutex
+	received []Bar
+	pos      int
+}
+
+// FromStream creates a feed reading bars from ch until it is closed or ctx
+// is cancelled.
+func FromStream(ctx context.Context, ch <-chan Bar) *StreamFeed {
+	return &StreamFeed{ctx: ctx, ch: ch}
+}
+
+// Next implements BarFeed.
+func (f *StreamFeed) Next() (Bar, bool) {
+	f.mu.Lock()
+	if f.pos < len(f.received) {
+		b := f.received[f.pos]
+		f.pos++
+		f.mu.Unlock()
+		return b, true
+	}
+	f.mu.Unlock()
+
+	select {
+	case <-f.ctx.Done():
+		return Bar{}, false
+	case b, ok := <-f.ch:
+		if !ok {
+			return Bar{}, false
+		}
+		f.mu.Lock()
+		f.received = append(f.received, b)
+		f.pos = len(f.received)
+		f.mu.Unlock()
+		return b, true
+	}
+}
+
+// Reset implements BarFeed by replaying the bars received so far.
+func (f *StreamFeed) Reset() {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.pos = 0
+}
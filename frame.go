@@ -0,0 +1,85 @@
+package datareader
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/julianshen/gonp-datareader/dataset"
+	"github.com/julianshen/gonp-datareader/internal/utils"
+)
+
+// ReadFrame fetches several symbols from one source and returns them as a
+// single Dataset on a common date index, so downstream numeric code works
+// the same whatever source the data came from. ReadDataset returns the
+// frame of a single symbol.
+//
+// Each symbol is read as by ReadDataset, and the results are outer-joined
+// with dataset.Join: the index holds every date of every symbol, columns
+// are named "<Symbol>.<Column>" (e.g., "AAPL.Close"), and values a symbol
+// lacks on a date are NaN. Read the symbols with ReadDataset and join them
+// with other dataset.JoinOptions for an inner join or forward filling.
+//
+// # Example Usage
+//
+//	frame, err := datareader.ReadFrame(ctx, []string{"AAPL", "MSFT"}, "yahoo", start, end, nil)
+//	if err != nil {
+//		log.Fatal(err)
+//	}
+//	closes, _ := frame.Column("MSFT.Close")
+func ReadFrame(ctx context.Context, symbols []string, source string, start, end time.Time, opts *Options) (*dataset.Dataset, error) {
+	if len(symbols) == 0 {
+		return nil, fmt.Errorf("invalid symbols: %w", utils.ErrEmptySymbolList)
+	}
+
+	datasets := make([]*dataset.Dataset, 0, len(symbols))
+	for _, symbol := range symbols {
+		ds, err := ReadDataset(ctx, symbol, source, start, end, opts)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read %s: %w", symbol, err)
+		}
+		datasets = append(datasets, ds)
+	}
+
+	frame, err := dataset.Join(dataset.JoinOptions{Method: dataset.JoinOuter}, datasets...)
+	if err != nil {
+		return nil, fmt.Errorf("join %s: %w", source, err)
+	}
+	return frame, nil
+}
+
+// ReadPanel fetches several symbols from one source and aligns them on
+// the union of their dates, keeping each symbol's columns apart, so
+// callers need not align a map of ragged results themselves. A date a
+// symbol has no row for is NaN in that symbol's columns; rows are matched
+// by exact date (see dataset.NewPanel), unlike ReadFrame, which expands
+// lower-frequency observations over their periods.
+//
+// # Example Usage
+//
+//	panel, err := datareader.ReadPanel(ctx, []string{"AAPL", "MSFT"}, "yahoo", start, end, nil)
+//	if err != nil {
+//		log.Fatal(err)
+//	}
+//	closes := panel.Field("Close") // columns "AAPL" and "MSFT"
+//	msft, _ := panel.Get("MSFT")   // Open, High, Low, Close, ...
+func ReadPanel(ctx context.Context, symbols []string, source string, start, end time.Time, opts *Options) (*dataset.Panel, error) {
+	if len(symbols) == 0 {
+		return nil, fmt.Errorf("invalid symbols: %w", utils.ErrEmptySymbolList)
+	}
+
+	datasets := make([]*dataset.Dataset, 0, len(symbols))
+	for _, symbol := range symbols {
+		ds, err := ReadDataset(ctx, symbol, source, start, end, opts)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read %s: %w", symbol, err)
+		}
+		datasets = append(datasets, ds)
+	}
+
+	panel, err := dataset.NewPanel(datasets...)
+	if err != nil {
+		return nil, fmt.Errorf("align %s: %w", source, err)
+	}
+	return panel, nil
+}
@@ -0,0 +1,163 @@
+package datareader_test
+
+import (
+	"context"
+	"errors"
+	"math"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	datareader "github.com/julianshen/gonp-datareader"
+	"github.com/julianshen/gonp-datareader/dataset"
+	"github.com/julianshen/gonp-datareader/internal/utils"
+)
+
+func TestReadFrame(t *testing.T) {
+	bodies := map[string]string{
+		"AAA.US": "Date,Open,High,Low,Close,Volume\n2024-01-02,1,1,1,10,100\n2024-01-03,1,1,1,11,100\n",
+		"BBB.US": "Date,Open,High,Low,Close,Volume\n2024-01-03,2,2,2,20,200\n2024-01-04,2,2,2,21,200\n",
+	}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(bodies[r.URL.Query().Get("s")]))
+	}))
+	defer server.Close()
+
+	opts := &datareader.Options{
+		Environment:     datareader.EnvironmentSandbox,
+		SandboxBaseURLs: map[string]string{"stooq": server.URL + "?s=%s"},
+	}
+	start := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	end := time.Date(2024, 1, 31, 0, 0, 0, 0, time.UTC)
+
+	frame, err := datareader.ReadFrame(context.Background(), []string{"aaa.us", "BBB.US"}, "stooq", start, end, opts)
+	if err != nil {
+		t.Fatalf("ReadFrame() error = %v", err)
+	}
+
+	if frame.Len() != 3 {
+		t.Fatalf("Len() = %d, want 3 (outer join)", frame.Len())
+	}
+
+	tests := []struct {
+		column string
+		want   []float64
+	}{
+		{"AAA.US.Close", []float64{10, 11, math.NaN()}},
+		{"BBB.US.Close", []float64{math.NaN(), 20, 21}},
+		{"BBB.US.Volume", []float64{math.NaN(), 200, 200}},
+	}
+	for _, tt := range tests {
+		got, ok := frame.Column(tt.column)
+		if !ok {
+			t.Errorf("Column(%q) missing, have %v", tt.column, frame.ColumnNames())
+			continue
+		}
+		for i, w := range tt.want {
+			if got[i] != w && !(math.IsNaN(got[i]) && math.IsNaN(w)) {
+				t.Errorf("%s[%d] = %v, want %v", tt.column, i, got[i], w)
+			}
+		}
+	}
+}
+
+func TestReadFrame_Errors(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte("Date,Open,High,Low,Close,Volume\n2024-01-02,1,1,1,1,10\n"))
+	}))
+	defer server.Close()
+
+	opts := &datareader.Options{
+		Environment:     datareader.EnvironmentSandbox,
+		SandboxBaseURLs: map[string]string{"stooq": server.URL + "?s=%s"},
+	}
+	start := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	end := time.Date(2024, 1, 31, 0, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name    string
+		symbols []string
+		source  string
+		wantErr error
+	}{
+		{"no symbols", nil, "stooq", utils.ErrEmptySymbolList},
+		{"invalid symbol", []string{"AAA.US", "BAD SYMBOL"}, "stooq", utils.ErrInvalidSymbolFormat},
+		{"unknown source", []string{"AAA.US"}, "nosuch", datareader.ErrUnknownSource},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			readOpts := opts
+			if tt.source != "stooq" {
+				readOpts = nil
+			}
+			_, err := datareader.ReadFrame(context.Background(), tt.symbols, tt.source, start, end, readOpts)
+			if !errors.Is(err, tt.wantErr) {
+				t.Errorf("ReadFrame() error = %v, want %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestReadPanel(t *testing.T) {
+	bodies := map[string]string{
+		"AAA.US": "Date,Open,High,Low,Close,Volume\n2024-01-02,1,1,1,10,100\n2024-01-03,1,1,1,11,100\n",
+		"BBB.US": "Date,Open,High,Low,Close,Volume\n2024-01-03,2,2,2,20,200\n2024-01-05,2,2,2,21,200\n",
+	}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(bodies[r.URL.Query().Get("s")]))
+	}))
+	defer server.Close()
+
+	opts := &datareader.Options{
+		Environment:     datareader.EnvironmentSandbox,
+		SandboxBaseURLs: map[string]string{"stooq": server.URL + "?s=%s"},
+	}
+	start := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	end := time.Date(2024, 1, 31, 0, 0, 0, 0, time.UTC)
+
+	panel, err := datareader.ReadPanel(context.Background(), []string{"aaa.us", "BBB.US"}, "stooq", start, end, opts)
+	if err != nil {
+		t.Fatalf("ReadPanel() error = %v", err)
+	}
+
+	if panel.Len() != 3 {
+		t.Fatalf("Len() = %d, want 3 (union of dates)", panel.Len())
+	}
+
+	bbb, ok := panel.Get("BBB.US")
+	if !ok {
+		t.Fatalf("Get(BBB.US) missing, have %v", panel.Symbols)
+	}
+	closes := panel.Field("Close")
+
+	tests := []struct {
+		name string
+		got  []float64
+		want []float64
+	}{
+		{"BBB.US Volume", mustColumn(t, bbb, "Volume"), []float64{math.NaN(), 200, 200}},
+		{"Close AAA.US", mustColumn(t, closes, "AAA.US"), []float64{10, 11, math.NaN()}},
+		{"Close BBB.US", mustColumn(t, closes, "BBB.US"), []float64{math.NaN(), 20, 21}},
+	}
+	for _, tt := range tests {
+		for i, w := range tt.want {
+			if tt.got[i] != w && !(math.IsNaN(tt.got[i]) && math.IsNaN(w)) {
+				t.Errorf("%s[%d] = %v, want %v", tt.name, i, tt.got[i], w)
+			}
+		}
+	}
+
+	if _, err := datareader.ReadPanel(context.Background(), nil, "stooq", start, end, opts); !errors.Is(err, utils.ErrEmptySymbolList) {
+		t.Errorf("ReadPanel(nil) error = %v, want %v", err, utils.ErrEmptySymbolList)
+	}
+}
+
+func mustColumn(t *testing.T, ds *dataset.Dataset, name string) []float64 {
+	t.Helper()
+	values, ok := ds.Column(name)
+	if !ok {
+		t.Fatalf("Column(%q) missing, have %v", name, ds.ColumnNames())
+	}
+	return values
+}
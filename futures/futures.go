@@ -0,0 +1,248 @@
+// Package futures makes commodity and index futures discoverable: a
+// curated map from common names ("crude", "gold", "corn", "es") to Stooq's
+// continuous futures symbols, with contract specifications and roll notes.
+//
+// Stooq's continuous series (e.g., "CL.F") follow the front-month contract
+// and are not back-adjusted, so prices jump at each roll by the spread
+// between the expiring and the next contract. Read records the roll
+// schedule in the dataset's Meta so returns computed across a roll can be
+// checked against it.
+//
+// # Example Usage
+//
+//	ds, err := futures.Read(ctx, "crude", start, end, nil)
+//	if err != nil {
+//		log.Fatal(err)
+//	}
+//	closes, _ := ds.Column("Close")
+//	fmt.Println(ds.Symbol, ds.Meta["futures_roll"]) // CL.F, rolls monthly ...
+package futures
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+	"unicode"
+
+	datareader "github.com/julianshen/gonp-datareader"
+	"github.com/julianshen/gonp-datareader/dataset"
+)
+
+var (
+	// ErrUnknownContract is returned for names that are not in the
+	// curated map and are not futures symbols.
+	ErrUnknownContract = errors.New("unknown futures contract")
+	// ErrInvalidSymbol is returned for symbols without the ".F" suffix of
+	// Stooq's continuous futures or with an invalid root.
+	ErrInvalidSymbol = errors.New("invalid futures symbol")
+)
+
+// Suffix is the suffix of Stooq's continuous futures symbols.
+const Suffix = ".F"
+
+// Category groups contracts by underlying.
+type Category string
+
+const (
+	// Energy covers crude oil, natural gas and refined products.
+	Energy Category = "energy"
+	// Metals covers precious and industrial metals.
+	Metals Category = "metals"
+	// Agriculture covers grains and oilseeds.
+	Agriculture Category = "agriculture"
+	// EquityIndex covers stock index futures.
+	EquityIndex Category = "equity_index"
+)
+
+// Contract describes a futures contract and its continuous Stooq series.
+type Contract struct {
+	// Symbol is the Stooq continuous series (e.g., "CL.F").
+	Symbol string
+	// Root is the exchange root symbol (e.g., "CL").
+	Root string
+	// Name is the contract name (e.g., "WTI Crude Oil").
+	Name     string
+	Exchange string
+	Category Category
+	// Unit is the unit prices are quoted in (e.g., "USD/bbl").
+	Unit string
+	// Months lists the listed contract months as futures month codes
+	// (F=Jan, G=Feb, H=Mar, J=Apr, K=May, M=Jun, N=Jul, Q=Aug, U=Sep,
+	// V=Oct, X=Nov, Z=Dec).
+	Months string
+	// Roll describes when the front-month contract expires, which is when
+	// the continuous series rolls to the next contract.
+	Roll string
+	// Aliases lists the lowercase names Lookup accepts besides Symbol and
+	// Root.
+	Aliases []string
+}
+
+// contracts is the curated map, in display order.
+var contracts = []Contract{
+	{Symbol: "CL.F", Root: "CL", Name: "WTI Crude Oil", Exchange: "NYMEX", Category: Energy, Unit: "USD/bbl",
+		Months: "FGHJKMNQUVXZ", Roll: "rolls monthly; trading ends 3 business days before the 25th of the month before delivery",
+		Aliases: []string{"crude", "crude oil", "oil", "wti"}},
+	{Symbol: "NG.F", Root: "NG", Name: "Henry Hub Natural Gas", Exchange: "NYMEX", Category: Energy, Unit: "USD/MMBtu",
+		Months: "FGHJKMNQUVXZ", Roll: "rolls monthly; trading ends 3 business days before the first day of the delivery month",
+		Aliases: []string{"natural gas", "natgas", "gas"}},
+	{Symbol: "GC.F", Root: "GC", Name: "Gold", Exchange: "COMEX", Category: Metals, Unit: "USD/troy oz",
+		Months: "GJMQVZ", Roll: "front month follows the active months Feb, Apr, Jun, Aug, Oct and Dec; trading ends 3 business days before the end of the month",
+		Aliases: []string{"gold"}},
+	{Symbol: "SI.F", Root: "SI", Name: "Silver", Exchange: "COMEX", Category: Metals, Unit: "USD/troy oz",
+		Months: "HKNUZ", Roll: "front month follows the active months Mar, May, Jul, Sep and Dec; trading ends 3 business days before the end of the month",
+		Aliases: []string{"silver"}},
+	{Symbol: "HG.F", Root: "HG", Name: "Copper", Exchange: "COMEX", Category: Metals, Unit: "USD/lb",
+		Months: "HKNUZ", Roll: "front month follows the active months Mar, May, Jul, Sep and Dec; trading ends 3 business days before the end of the month",
+		Aliases: []string{"copper"}},
+	{Symbol: "ZC.F", Root: "ZC", Name: "Corn", Exchange: "CBOT", Category: Agriculture, Unit: "USc/bu",
+		Months: "HKNUZ", Roll: "rolls five times a year (Mar, May, Jul, Sep, Dec); trading ends the business day before the 15th of the month",
+		Aliases: []string{"corn"}},
+	{Symbol: "ZW.F", Root: "ZW", Name: "Chicago SRW Wheat", Exchange: "CBOT", Category: Agriculture, Unit: "USc/bu",
+		Months: "HKNUZ", Roll: "rolls five times a year (Mar, May, Jul, Sep, Dec); trading ends the business day before the 15th of the month",
+		Aliases: []string{"wheat"}},
+	{Symbol: "ZS.F", Root: "ZS", Name: "Soybeans", Exchange: "CBOT", Category: Agriculture, Unit: "USc/bu",
+		Months: "FHKNQUX", Roll: "rolls seven times a year (Jan, Mar, May, Jul, Aug, Sep, Nov); trading ends the business day before the 15th of the month",
+		Aliases: []string{"soybeans", "soy"}},
+	{Symbol: "ES.F", Root: "ES", Name: "E-mini S&P 500", Exchange: "CME", Category: EquityIndex, Unit: "index points",
+		Months: "HMUZ", Roll: "rolls quarterly (Mar, Jun, Sep, Dec); trading ends the third Friday of the month, volume moves about a week earlier",
+		Aliases: []string{"s&p 500", "sp500", "e-mini s&p"}},
+	{Symbol: "NQ.F", Root: "NQ", Name: "E-mini Nasdaq-100", Exchange: "CME", Category: EquityIndex, Unit: "index points",
+		Months: "HMUZ", Roll: "rolls quarterly (Mar, Jun, Sep, Dec); trading ends the third Friday of the month, volume moves about a week earlier",
+		Aliases: []string{"nasdaq 100", "nasdaq-100", "e-mini nasdaq"}},
+	{Symbol: "YM.F", Root: "YM", Name: "E-mini Dow", Exchange: "CBOT", Category: EquityIndex, Unit: "index points",
+		Months: "HMUZ", Roll: "rolls quarterly (Mar, Jun, Sep, Dec); trading ends the third Friday of the month, volume moves about a week earlier",
+		Aliases: []string{"dow", "e-mini dow"}},
+}
+
+// Contracts returns the curated contracts, in display order.
+func Contracts() []Contract {
+	out := make([]Contract, len(contracts))
+	for i, c := range contracts {
+		c.Aliases = append([]string(nil), c.Aliases...)
+		out[i] = c
+	}
+	return out
+}
+
+// ByCategory returns the curated contracts of category, in display order.
+func ByCategory(category Category) []Contract {
+	var out []Contract
+	for _, c := range Contracts() {
+		if c.Category == category {
+			out = append(out, c)
+		}
+	}
+	return out
+}
+
+// Categories returns the categories of the curated contracts, sorted.
+func Categories() []Category {
+	seen := make(map[Category]bool)
+	var out []Category
+	for _, c := range contracts {
+		if !seen[c.Category] {
+			seen[c.Category] = true
+			out = append(out, c.Category)
+		}
+	}
+	sort.Slice(out, func(i, j int) bool { return out[i] < out[j] })
+	return out
+}
+
+// Lookup returns the curated contract named by name: an alias (e.g.,
+// "crude"), a root ("CL") or a Stooq symbol ("CL.F"), case-insensitively.
+func Lookup(name string) (Contract, bool) {
+	key := strings.ToLower(strings.TrimSpace(name))
+	for _, c := range Contracts() {
+		if key == strings.ToLower(c.Symbol) || key == strings.ToLower(c.Root) {
+			return c, true
+		}
+		for _, alias := range c.Aliases {
+			if key == alias {
+				return c, true
+			}
+		}
+	}
+	return Contract{}, false
+}
+
+// ValidateSymbol checks that symbol is a Stooq continuous futures symbol:
+// a root of one to four letters or digits followed by ".F" (e.g., "CL.F",
+// "6E.F"). Errors match ErrInvalidSymbol.
+func ValidateSymbol(symbol string) error {
+	upper := strings.ToUpper(strings.TrimSpace(symbol))
+	root, ok := strings.CutSuffix(upper, Suffix)
+	if !ok {
+		return fmt.Errorf("%w: %q does not end in %s", ErrInvalidSymbol, symbol, Suffix)
+	}
+	if root == "" || len(root) > 4 {
+		return fmt.Errorf("%w: %q has no root of 1 to 4 characters", ErrInvalidSymbol, symbol)
+	}
+	for _, r := range root {
+		if !unicode.IsLetter(r) && !unicode.IsDigit(r) {
+			return fmt.Errorf("%w: %q has an invalid root", ErrInvalidSymbol, symbol)
+		}
+	}
+	return nil
+}
+
+// Resolve returns the Stooq symbol of name: the Symbol of a curated
+// contract, or name itself, uppercased, when it is a valid futures symbol
+// outside the curated map.
+func Resolve(name string) (string, error) {
+	if c, ok := Lookup(name); ok {
+		return c.Symbol, nil
+	}
+	if err := ValidateSymbol(name); err != nil {
+		return "", fmt.Errorf("%w: %q (see futures.Contracts): %w", ErrUnknownContract, name, err)
+	}
+	return strings.ToUpper(strings.TrimSpace(name)), nil
+}
+
+// Meta returns the metadata Read records for c: "futures_root",
+// "futures_name", "exchange", "unit", "futures_months", "futures_roll"
+// and "continuous", which notes that the series is the unadjusted front
+// month.
+func (c Contract) Meta() map[string]string {
+	return map[string]string{
+		"futures_root":   c.Root,
+		"futures_name":   c.Name,
+		"exchange":       c.Exchange,
+		"unit":           c.Unit,
+		"futures_months": c.Months,
+		"futures_roll":   c.Roll,
+		"continuous":     "front month, not back-adjusted; prices jump at rolls",
+	}
+}
+
+// Read fetches the continuous series of the contract named by name (see
+// Resolve) from Stooq with datareader.ReadDataset and records the
+// contract's roll notes in the dataset's Meta. Symbols outside the curated
+// map get only the "continuous" note. opts configures the reader as for
+// ReadDataset.
+func Read(ctx context.Context, name string, start, end time.Time, opts *datareader.Options) (*dataset.Dataset, error) {
+	symbol, err := Resolve(name)
+	if err != nil {
+		return nil, err
+	}
+
+	ds, err := datareader.ReadDataset(ctx, symbol, "stooq", start, end, opts)
+	if err != nil {
+		return nil, err
+	}
+
+	meta := Contract{}.Meta()
+	if c, ok := Lookup(symbol); ok {
+		meta = c.Meta()
+	}
+	for k, v := range meta {
+		if v != "" {
+			ds.Meta[k] = v
+		}
+	}
+	return ds, nil
+}
@@ -0,0 +1,146 @@
+package futures_test
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	datareader "github.com/julianshen/gonp-datareader"
+	"github.com/julianshen/gonp-datareader/futures"
+)
+
+func TestLookup(t *testing.T) {
+	tests := []struct {
+		name   string
+		want   string
+		wantOK bool
+	}{
+		{"crude", "CL.F", true},
+		{" Gold ", "GC.F", true},
+		{"corn", "ZC.F", true},
+		{"ES", "ES.F", true},
+		{"es.f", "ES.F", true},
+		{"NG", "NG.F", true},
+		{"lumber", "", false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c, ok := futures.Lookup(tt.name)
+			if ok != tt.wantOK || c.Symbol != tt.want {
+				t.Errorf("Lookup(%q) = %q, %v, want %q, %v", tt.name, c.Symbol, ok, tt.want, tt.wantOK)
+			}
+		})
+	}
+}
+
+func TestContracts(t *testing.T) {
+	seen := make(map[string]bool)
+	for _, c := range futures.Contracts() {
+		if err := futures.ValidateSymbol(c.Symbol); err != nil {
+			t.Errorf("%s: %v", c.Symbol, err)
+		}
+		if c.Root+futures.Suffix != c.Symbol || c.Name == "" || c.Months == "" || c.Roll == "" {
+			t.Errorf("incomplete contract %+v", c)
+		}
+		for _, alias := range append([]string{c.Root}, c.Aliases...) {
+			if seen[alias] {
+				t.Errorf("alias %q is used twice", alias)
+			}
+			seen[alias] = true
+		}
+	}
+
+	if got := futures.ByCategory(futures.EquityIndex); len(got) == 0 || got[0].Symbol != "ES.F" {
+		t.Errorf("ByCategory(EquityIndex) = %v, want ES.F first", got)
+	}
+	if got := futures.Categories(); len(got) != 4 {
+		t.Errorf("Categories() = %v, want 4 categories", got)
+	}
+}
+
+func TestValidateSymbol(t *testing.T) {
+	tests := []struct {
+		symbol  string
+		wantErr bool
+	}{
+		{"CL.F", false},
+		{"cl.f", false},
+		{"6E.F", false},
+		{"CL", true},
+		{"CL.US", true},
+		{".F", true},
+		{"CRUDE.F", true},
+		{"C-L.F", true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.symbol, func(t *testing.T) {
+			err := futures.ValidateSymbol(tt.symbol)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("ValidateSymbol(%q) error = %v, wantErr %v", tt.symbol, err, tt.wantErr)
+			}
+			if err != nil && !errors.Is(err, futures.ErrInvalidSymbol) {
+				t.Errorf("ValidateSymbol(%q) error = %v, want ErrInvalidSymbol", tt.symbol, err)
+			}
+		})
+	}
+}
+
+func TestResolve(t *testing.T) {
+	if got, err := futures.Resolve("wti"); err != nil || got != "CL.F" {
+		t.Errorf("Resolve(wti) = %q, %v, want CL.F", got, err)
+	}
+	if got, err := futures.Resolve("lb.f"); err != nil || got != "LB.F" {
+		t.Errorf("Resolve(lb.f) = %q, %v, want LB.F", got, err)
+	}
+	if _, err := futures.Resolve("lumber"); !errors.Is(err, futures.ErrUnknownContract) {
+		t.Errorf("Resolve(lumber) error = %v, want ErrUnknownContract", err)
+	}
+}
+
+func TestRead(t *testing.T) {
+	var requested string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requested = r.URL.Query().Get("s")
+		w.Write([]byte("Date,Open,High,Low,Close,Volume\n2024-01-02,71,72,70,70.4,300000\n2024-01-03,70.5,73,70,72.7,310000\n"))
+	}))
+	defer server.Close()
+
+	opts := &datareader.Options{
+		Environment:     datareader.EnvironmentSandbox,
+		SandboxBaseURLs: map[string]string{"stooq": server.URL + "?s=%s"},
+	}
+	start := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	end := time.Date(2024, 1, 31, 0, 0, 0, 0, time.UTC)
+
+	ds, err := futures.Read(context.Background(), "crude", start, end, opts)
+	if err != nil {
+		t.Fatalf("Read() error = %v", err)
+	}
+	if requested != "CL.F" {
+		t.Errorf("requested %q, want CL.F", requested)
+	}
+	if closes, _ := ds.Column("Close"); len(closes) != 2 || closes[1] != 72.7 {
+		t.Errorf("Close = %v, want [70.4 72.7]", closes)
+	}
+	if ds.Meta["futures_root"] != "CL" || ds.Meta["futures_roll"] == "" || ds.Meta["continuous"] == "" {
+		t.Errorf("Meta = %v, want the CL roll notes", ds.Meta)
+	}
+
+	// Futures symbols outside the curated map get the continuous note only
+	ds, err = futures.Read(context.Background(), "LB.F", start, end, opts)
+	if err != nil {
+		t.Fatalf("Read(LB.F) error = %v", err)
+	}
+	if _, ok := ds.Meta["futures_root"]; ok || ds.Meta["continuous"] == "" {
+		t.Errorf("Meta = %v, want the continuous note only", ds.Meta)
+	}
+
+	if _, err := futures.Read(context.Background(), "AAPL.US", start, end, opts); !errors.Is(err, futures.ErrUnknownContract) {
+		t.Errorf("Read(AAPL.US) error = %v, want ErrUnknownContract", err)
+	}
+}
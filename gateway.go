@@ -0,0 +1,45 @@
+//go:build gateway
+// +build gateway
+
+package datareader
+
+import (
+	"fmt"
+
+	internalhttp "github.com/julianshen/gonp-datareader/internal/http"
+	"github.com/julianshen/gonp-datareader/sources"
+	"github.com/julianshen/gonp-datareader/sources/gateway"
+)
+
+// Builds with the gateway tag add the "gateway" source, which reads
+// historical bars from a local broker gateway (see package gateway). A
+// BaseURLOverrides entry for "gateway" sets the gateway's URL (default
+// gateway.DefaultURL) and Options.Format its protocol: "generic" (the
+// default) or "ibkr".
+func init() {
+	builtinSources = append(builtinSources, "gateway")
+	optionalSources["gateway"] = newGatewayReader
+	versions["gateway"] = sourceVersions{def: "v1"}
+	sourceMetadata["gateway"] = SourceMetadata{
+		Description:   "Local broker gateway (IBKR Client Portal or generic REST bridge)",
+		Intervals:     intervalNames(gateway.Intervals),
+		SymbolPattern: tickerPattern,
+		// Numeric symbols are IBKR contract IDs
+		SymbolExamples: []string{"AAPL", "265598"},
+		CoverageNote:   "Broker- and entitlement-dependent",
+	}
+}
+
+// newGatewayReader creates the reader of the "gateway" source.
+func newGatewayReader(opts *Options, clientOpts *internalhttp.ClientOptions, baseURL string) (sources.Reader, error) {
+	if baseURL == "" {
+		baseURL = gateway.DefaultURL
+	}
+	reader := gateway.NewGatewayReaderWithBaseURL(clientOpts, baseURL)
+	if opts != nil {
+		if err := reader.SetProtocol(gateway.Protocol(opts.Format)); err != nil {
+			return nil, fmt.Errorf("invalid options: %w", err)
+		}
+	}
+	return reader, nil
+}
@@ -0,0 +1,51 @@
+//go:build gateway
+// +build gateway
+
+package datareader_test
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	datareader "github.com/julianshen/gonp-datareader"
+)
+
+func TestReadDataset_Gateway(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		switch r.URL.Path {
+		case "/v1/api/iserver/secdef/search":
+			w.Write([]byte(`[{"conid":265598}]`))
+		case "/v1/api/iserver/marketdata/history":
+			w.Write([]byte(`{"data":[{"o":185,"h":186,"l":183,"c":185.6,"v":1000,"t":1704205800000}]}`))
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	defer server.Close()
+
+	opts := &datareader.Options{
+		BaseURLOverrides: map[string]string{"gateway": server.URL},
+		Format:           "ibkr",
+	}
+	start := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	end := time.Date(2024, 1, 31, 0, 0, 0, 0, time.UTC)
+
+	ds, err := datareader.ReadDataset(context.Background(), "AAPL", "gateway", start, end, opts)
+	if err != nil {
+		t.Fatalf("ReadDataset() error = %v", err)
+	}
+	if ds.Source != "gateway" {
+		t.Errorf("Source = %q, want gateway", ds.Source)
+	}
+	if closes, _ := ds.Column("Close"); len(closes) != 1 || closes[0] != 185.6 {
+		t.Errorf("Close = %v, want [185.6]", closes)
+	}
+
+	if _, err := datareader.DataReader("gateway", &datareader.Options{Format: "fix"}); err == nil {
+		t.Error("DataReader(gateway, Format fix) error = nil, want an error")
+	}
+}
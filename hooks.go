@@ -0,0 +1,32 @@
+package datareader
+
+import "github.com/julianshen/gonp-datareader/sources"
+
+// Hooks holds optional callbacks for instrumenting readers, for example to
+// export cache metrics. All fields are optional; nil callbacks are skipped.
+// Callbacks may be invoked concurrently and should return quickly.
+//
+// # Example Usage
+//
+//	var hits, misses atomic.Int64
+//	opts := &datareader.Options{
+//		MemoryCacheSize: 256,
+//		Hooks: &datareader.Hooks{
+//			OnCacheHit:  func(layer, key string) { hits.Add(1) },
+//			OnCacheMiss: func(key string) { misses.Add(1) },
+//		},
+//	}
+type Hooks struct {
+	// OnCacheHit is called when a response is served from cache.
+	// Layer is "memory" for the in-process cache or "disk" for CacheDir.
+	OnCacheHit func(layer, key string)
+
+	// OnCacheMiss is called when no cache layer holds a fresh response
+	// and the request goes to the upstream.
+	OnCacheMiss func(key string)
+
+	// OnWarning is called by ReadDataset for each adjustment made on the
+	// caller's behalf, such as a clamped date range, also recorded in the
+	// dataset's Meta (see sources.Warnings).
+	OnWarning func(source, symbol string, w sources.Warning)
+}
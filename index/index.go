@@ -0,0 +1,292 @@
+// Package index constructs custom index series, such as benchmarks, from
+// the prices of their constituents.
+//
+// An index starts at a base level and moves each period by the weighted
+// average of its constituents' returns, with weights taken as of the
+// previous period: fixed weights (Weighted) or market capitalizations
+// (CapWeighted). Price-return indexes use the Close column; total-return
+// indexes use Adj Close, which includes dividends for Yahoo and Tiingo.
+//
+// # Example Usage
+//
+//	weights := map[string]float64{"AAPL": 0.6, "MSFT": 0.4}
+//	ds, err := index.Read(ctx, "yahoo", weights, start, end, index.Options{Variant: index.TotalReturn}, nil)
+//	if err != nil {
+//		log.Fatal(err)
+//	}
+//	levels, _ := ds.Column(index.LevelColumn)
+package index
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math"
+	"sort"
+	"strings"
+	"time"
+
+	datareader "github.com/julianshen/gonp-datareader"
+	"github.com/julianshen/gonp-datareader/dataset"
+	"github.com/julianshen/gonp-datareader/sources/finmind"
+)
+
+var (
+	// ErrNoConstituents is returned when an index has no constituents.
+	ErrNoConstituents = errors.New("index has no constituents")
+	// ErrInvalidWeight is returned for a weight that is not a positive
+	// number.
+	ErrInvalidWeight = errors.New("invalid weight")
+	// ErrMissingConstituent is returned when a constituent has no prices,
+	// market caps or price column.
+	ErrMissingConstituent = errors.New("missing constituent data")
+)
+
+// LevelColumn is the column of index levels in the datasets returned by
+// this package.
+const LevelColumn = "Level"
+
+// Variant selects which returns an index compounds.
+type Variant int
+
+const (
+	// PriceReturn compounds price changes only.
+	PriceReturn Variant = iota
+	// TotalReturn compounds price changes and reinvested dividends.
+	TotalReturn
+)
+
+// String returns "price" or "total_return".
+func (v Variant) String() string {
+	if v == TotalReturn {
+		return "total_return"
+	}
+	return "price"
+}
+
+// Options configures index construction.
+type Options struct {
+	// Name is the Symbol of the returned dataset. Default: "INDEX"
+	Name string
+	// Variant selects price or total return. Default: PriceReturn
+	Variant Variant
+	// Column is the price column of the constituents. Default: "Close"
+	// for PriceReturn and "Adj Close" for TotalReturn
+	Column string
+	// CapColumn is the market cap column of CapWeighted. Default:
+	// "MarketCap", as returned by finmind.FinMindReader.ReadCapital
+	CapColumn string
+	// Base is the level on the first date. Default: 100
+	Base float64
+}
+
+// column returns the price column selected by o.
+func (o Options) column() string {
+	switch {
+	case o.Column != "":
+		return o.Column
+	case o.Variant == TotalReturn:
+		return "Adj Close"
+	default:
+		return "Close"
+	}
+}
+
+// Weighted constructs an index of the constituents in weights from their
+// prices, rebalanced to the weights every period. Weights need not sum to
+// one; they are normalized over the constituents priced on both dates of
+// a period, so a constituent joins on its first price and a missing price
+// defers its return to its next price.
+func Weighted(prices *dataset.Panel, weights map[string]float64, opts Options) (*dataset.Dataset, error) {
+	if len(weights) == 0 {
+		return nil, ErrNoConstituents
+	}
+
+	symbols := make([]string, 0, len(weights))
+	for symbol, w := range weights {
+		if !(w > 0) || math.IsInf(w, 1) {
+			return nil, fmt.Errorf("%w: %s has weight %v", ErrInvalidWeight, symbol, w)
+		}
+		symbols = append(symbols, symbol)
+	}
+	sort.Strings(symbols)
+
+	return build(prices, symbols, opts, "fixed", func(symbol string) ([]float64, error) {
+		w := make([]float64, prices.Len())
+		for i := range w {
+			w[i] = weights[symbol]
+		}
+		return w, nil
+	})
+}
+
+// CapWeighted constructs an index of every symbol of prices weighted by
+// its market capitalization as of the previous period, from the CapColumn
+// of caps. A cap is carried forward to the dates caps lacks, so monthly or
+// lagging cap data can weight daily prices.
+func CapWeighted(prices, caps *dataset.Panel, opts Options) (*dataset.Dataset, error) {
+	if prices.Len() == 0 || len(prices.Symbols) == 0 {
+		return nil, ErrNoConstituents
+	}
+
+	capColumn := opts.CapColumn
+	if capColumn == "" {
+		capColumn = "MarketCap"
+	}
+
+	return build(prices, prices.Symbols, opts, "market_cap", func(symbol string) ([]float64, error) {
+		ds, ok := caps.Get(symbol)
+		if !ok {
+			return nil, fmt.Errorf("%w: no market caps of %s", ErrMissingConstituent, symbol)
+		}
+		values, ok := ds.Column(capColumn)
+		if !ok {
+			return nil, fmt.Errorf("%w: %s has no %q column (have %v)", ErrMissingConstituent, symbol, capColumn, ds.ColumnNames())
+		}
+		return asOf(prices.Dates, ds.Dates, values), nil
+	})
+}
+
+// build compounds the weighted returns of symbols into index levels, with
+// the weights of each symbol at every date of prices given by weightsOf.
+func build(prices *dataset.Panel, symbols []string, opts Options, weighting string, weightsOf func(symbol string) ([]float64, error)) (*dataset.Dataset, error) {
+	column := opts.column()
+
+	series := make([][]float64, len(symbols))
+	weights := make([][]float64, len(symbols))
+	for i, symbol := range symbols {
+		ds, ok := prices.Get(symbol)
+		if !ok {
+			return nil, fmt.Errorf("%w: no prices of %s", ErrMissingConstituent, symbol)
+		}
+		values, ok := ds.Column(column)
+		if !ok {
+			return nil, fmt.Errorf("%w: %s has no %q column (have %v)", ErrMissingConstituent, symbol, column, ds.ColumnNames())
+		}
+		w, err := weightsOf(symbol)
+		if err != nil {
+			return nil, err
+		}
+		series[i], weights[i] = values, w
+	}
+
+	base := opts.Base
+	if base == 0 {
+		base = 100
+	}
+
+	// Track each constituent's last price and weight, so a gap defers its
+	// return to the next price
+	lastPrice := make([]float64, len(symbols))
+	lastWeight := make([]float64, len(symbols))
+	for i := range symbols {
+		lastPrice[i], lastWeight[i] = math.NaN(), math.NaN()
+	}
+
+	levels := make([]float64, prices.Len())
+	level := base
+	for t := range levels {
+		var sum, total float64
+		for i := range symbols {
+			p := series[i][t]
+			if math.IsNaN(p) || p <= 0 {
+				if !math.IsNaN(weights[i][t]) {
+					lastWeight[i] = weights[i][t]
+				}
+				continue
+			}
+			if w := lastWeight[i]; !math.IsNaN(lastPrice[i]) && w > 0 {
+				sum += w * (p/lastPrice[i] - 1)
+				total += w
+			}
+			lastPrice[i] = p
+			if !math.IsNaN(weights[i][t]) {
+				lastWeight[i] = weights[i][t]
+			}
+		}
+		if total > 0 {
+			level *= 1 + sum/total
+		}
+		levels[t] = level
+	}
+
+	name := opts.Name
+	if name == "" {
+		name = "INDEX"
+	}
+	out := dataset.New(name, "index", append([]time.Time(nil), prices.Dates...))
+	if err := out.AddColumn(LevelColumn, levels); err != nil {
+		return nil, err
+	}
+	out.Meta["variant"] = opts.Variant.String()
+	out.Meta["weighting"] = weighting
+	out.Meta["constituents"] = strings.Join(symbols, ",")
+	return out, nil
+}
+
+// asOf returns the value of values, dated by dates, as of each date of
+// index: the last value dated on or before it that is not NaN, or NaN.
+func asOf(index, dates []time.Time, values []float64) []float64 {
+	out := make([]float64, len(index))
+	j := 0
+	last := math.NaN()
+	for k, t := range index {
+		for j < len(dates) && !dates[j].After(t) {
+			if !math.IsNaN(values[j]) {
+				last = values[j]
+			}
+			j++
+		}
+		out[k] = last
+	}
+	return out
+}
+
+// Read fetches the prices of the constituents in weights from source with
+// datareader.ReadPanel and constructs their Weighted index. readOpts
+// configures the reader as for ReadPanel.
+func Read(ctx context.Context, source string, weights map[string]float64, start, end time.Time, opts Options, readOpts *datareader.Options) (*dataset.Dataset, error) {
+	if len(weights) == 0 {
+		return nil, ErrNoConstituents
+	}
+
+	symbols := make([]string, 0, len(weights))
+	for symbol := range weights {
+		symbols = append(symbols, symbol)
+	}
+	sort.Strings(symbols)
+
+	prices, err := datareader.ReadPanel(ctx, symbols, source, start, end, readOpts)
+	if err != nil {
+		return nil, err
+	}
+
+	// The panel holds the symbols as normalized by the source, in order
+	normalized := make(map[string]float64, len(weights))
+	for i, symbol := range prices.Symbols {
+		normalized[symbol] = weights[symbols[i]]
+	}
+	return Weighted(prices, normalized, opts)
+}
+
+// ReadCaps fetches the market caps of symbols with ReadCapital, for
+// CapWeighted, aligned on the union of their dates.
+func ReadCaps(ctx context.Context, reader *finmind.FinMindReader, symbols []string, start, end time.Time) (*dataset.Panel, error) {
+	if len(symbols) == 0 {
+		return nil, ErrNoConstituents
+	}
+
+	datasets := make([]*dataset.Dataset, 0, len(symbols))
+	for _, symbol := range symbols {
+		data, err := reader.ReadCapital(ctx, symbol, start, end)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read %s: %w", symbol, err)
+		}
+		ds, err := datareader.ToDataset(data.Symbol, data)
+		if err != nil {
+			return nil, fmt.Errorf("convert %s: %w", symbol, err)
+		}
+		datasets = append(datasets, ds)
+	}
+	return dataset.NewPanel(datasets...)
+}
@@ -0,0 +1,239 @@
+package index_test
+
+import (
+	"context"
+	"errors"
+	"math"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	datareader "github.com/julianshen/gonp-datareader"
+	"github.com/julianshen/gonp-datareader/dataset"
+	"github.com/julianshen/gonp-datareader/index"
+	internalhttp "github.com/julianshen/gonp-datareader/internal/http"
+	"github.com/julianshen/gonp-datareader/sources/finmind"
+)
+
+var days = []time.Time{
+	time.Date(2024, 1, 2, 0, 0, 0, 0, time.UTC),
+	time.Date(2024, 1, 3, 0, 0, 0, 0, time.UTC),
+	time.Date(2024, 1, 4, 0, 0, 0, 0, time.UTC),
+}
+
+// series returns a dataset of symbol with one column on dates.
+func series(t *testing.T, symbol, column string, dates []time.Time, values ...float64) *dataset.Dataset {
+	t.Helper()
+	ds := dataset.New(symbol, "test", dates)
+	if err := ds.AddColumn(column, values); err != nil {
+		t.Fatal(err)
+	}
+	return ds
+}
+
+func panel(t *testing.T, datasets ...*dataset.Dataset) *dataset.Panel {
+	t.Helper()
+	p, err := dataset.NewPanel(datasets...)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return p
+}
+
+func assertLevels(t *testing.T, ds *dataset.Dataset, want []float64) {
+	t.Helper()
+	got, ok := ds.Column(index.LevelColumn)
+	if !ok {
+		t.Fatalf("no %s column, have %v", index.LevelColumn, ds.ColumnNames())
+	}
+	if len(got) != len(want) {
+		t.Fatalf("levels = %v, want %v", got, want)
+	}
+	for i := range want {
+		if math.Abs(got[i]-want[i]) > 1e-9 {
+			t.Errorf("levels = %v, want %v", got, want)
+			return
+		}
+	}
+}
+
+func TestWeighted(t *testing.T) {
+	tests := []struct {
+		name    string
+		prices  *dataset.Panel
+		weights map[string]float64
+		opts    index.Options
+		want    []float64
+	}{
+		{
+			name: "equal weights",
+			prices: panel(t,
+				series(t, "A", "Close", days, 10, 11, 12.1),
+				series(t, "B", "Close", days, 20, 20, 22)),
+			weights: map[string]float64{"A": 1, "B": 1},
+			want:    []float64{100, 105, 115.5},
+		},
+		{
+			name: "unequal weights and base",
+			prices: panel(t,
+				series(t, "A", "Close", days, 10, 11, 11),
+				series(t, "B", "Close", days, 20, 10, 10)),
+			weights: map[string]float64{"A": 0.75, "B": 0.25},
+			opts:    index.Options{Base: 1000},
+			want:    []float64{1000, 950, 950},
+		},
+		{
+			name: "gap defers return",
+			prices: panel(t,
+				series(t, "A", "Close", days, 10, 11, 12.1),
+				series(t, "B", "Close", days, 20, math.NaN(), 22)),
+			weights: map[string]float64{"A": 1, "B": 1},
+			want:    []float64{100, 110, 121},
+		},
+		{
+			name: "late constituent joins on first price",
+			prices: panel(t,
+				series(t, "A", "Close", days, 10, 11, 11),
+				series(t, "B", "Close", days[1:], 20, 30)),
+			weights: map[string]float64{"A": 1, "B": 1},
+			want:    []float64{100, 110, 137.5},
+		},
+		{
+			name: "total return uses Adj Close",
+			prices: panel(t,
+				series(t, "A", "Adj Close", days, 10, 12, 12)),
+			weights: map[string]float64{"A": 1},
+			opts:    index.Options{Variant: index.TotalReturn},
+			want:    []float64{100, 120, 120},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ds, err := index.Weighted(tt.prices, tt.weights, tt.opts)
+			if err != nil {
+				t.Fatalf("Weighted() error = %v", err)
+			}
+			assertLevels(t, ds, tt.want)
+			if ds.Meta["weighting"] != "fixed" || ds.Meta["variant"] != tt.opts.Variant.String() {
+				t.Errorf("Meta = %v", ds.Meta)
+			}
+		})
+	}
+}
+
+func TestWeighted_Errors(t *testing.T) {
+	prices := panel(t, series(t, "A", "Close", days, 10, 11, 12))
+
+	tests := []struct {
+		name    string
+		weights map[string]float64
+		opts    index.Options
+		wantErr error
+	}{
+		{"no constituents", nil, index.Options{}, index.ErrNoConstituents},
+		{"zero weight", map[string]float64{"A": 0}, index.Options{}, index.ErrInvalidWeight},
+		{"NaN weight", map[string]float64{"A": math.NaN()}, index.Options{}, index.ErrInvalidWeight},
+		{"unknown symbol", map[string]float64{"B": 1}, index.Options{}, index.ErrMissingConstituent},
+		{"missing column", map[string]float64{"A": 1}, index.Options{Variant: index.TotalReturn}, index.ErrMissingConstituent},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := index.Weighted(prices, tt.weights, tt.opts); !errors.Is(err, tt.wantErr) {
+				t.Errorf("Weighted() error = %v, want %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestCapWeighted(t *testing.T) {
+	prices := panel(t,
+		series(t, "A", "close", days, 10, 11, 12.1),
+		series(t, "B", "close", days, 20, 20, 22))
+	// B's cap is carried forward from its only observation
+	caps := panel(t,
+		series(t, "A", "MarketCap", days[:2], 1, 1),
+		series(t, "B", "MarketCap", days[:1], 3))
+
+	ds, err := index.CapWeighted(prices, caps, index.Options{Column: "close"})
+	if err != nil {
+		t.Fatalf("CapWeighted() error = %v", err)
+	}
+	// (1*0.1 + 3*0)/4 = 2.5%, then (1*0.1 + 3*0.1)/4 = 10%
+	assertLevels(t, ds, []float64{100, 102.5, 112.75})
+	if ds.Meta["weighting"] != "market_cap" {
+		t.Errorf(`Meta["weighting"] = %q, want market_cap`, ds.Meta["weighting"])
+	}
+
+	missing := panel(t, series(t, "A", "MarketCap", days, 1, 1, 1))
+	if _, err := index.CapWeighted(prices, missing, index.Options{Column: "close"}); !errors.Is(err, index.ErrMissingConstituent) {
+		t.Errorf("CapWeighted() error = %v, want %v", err, index.ErrMissingConstituent)
+	}
+}
+
+func TestRead(t *testing.T) {
+	bodies := map[string]string{
+		"AAA.US": "Date,Open,High,Low,Close,Volume\n2024-01-02,1,1,1,10,100\n2024-01-03,1,1,1,11,100\n",
+		"BBB.US": "Date,Open,High,Low,Close,Volume\n2024-01-02,2,2,2,20,200\n2024-01-03,2,2,2,18,200\n",
+	}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(bodies[r.URL.Query().Get("s")]))
+	}))
+	defer server.Close()
+
+	readOpts := &datareader.Options{
+		Environment:     datareader.EnvironmentSandbox,
+		SandboxBaseURLs: map[string]string{"stooq": server.URL + "?s=%s"},
+	}
+	start := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	end := time.Date(2024, 1, 31, 0, 0, 0, 0, time.UTC)
+
+	weights := map[string]float64{"aaa.us": 3, "BBB.US": 1}
+	ds, err := index.Read(context.Background(), "stooq", weights, start, end, index.Options{Name: "MIX"}, readOpts)
+	if err != nil {
+		t.Fatalf("Read() error = %v", err)
+	}
+	// (3*0.1 + 1*-0.1)/4 = 5%
+	assertLevels(t, ds, []float64{100, 105})
+	if ds.Symbol != "MIX" || ds.Meta["constituents"] != "AAA.US,BBB.US" {
+		t.Errorf("Symbol = %q, Meta = %v", ds.Symbol, ds.Meta)
+	}
+}
+
+func TestReadCaps(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		symbol := r.URL.Query().Get("data_id")
+		switch r.URL.Query().Get("dataset") {
+		case finmind.ShareholdingDataset:
+			w.Write([]byte(`{"data":[]}`))
+		case finmind.MarketValueDataset:
+			if symbol == "2330" {
+				w.Write([]byte(`{"data":[{"date":"2024-01-02","stock_id":"2330","market_value":300},
+					{"date":"2024-01-03","stock_id":"2330","market_value":330}]}`))
+			} else {
+				w.Write([]byte(`{"data":[{"date":"2024-01-03","stock_id":"2317","market_value":100}]}`))
+			}
+		}
+	}))
+	defer server.Close()
+
+	reader := finmind.NewFinMindReaderWithEndpoint(&internalhttp.ClientOptions{RateLimit: 100}, server.URL)
+	start := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	end := time.Date(2024, 1, 4, 0, 0, 0, 0, time.UTC)
+
+	caps, err := index.ReadCaps(context.Background(), reader, []string{"2330", "2317"}, start, end)
+	if err != nil {
+		t.Fatalf("ReadCaps() error = %v", err)
+	}
+	if caps.Len() != 2 || len(caps.Symbols) != 2 {
+		t.Fatalf("ReadCaps() = %d dates of %v, want 2 dates of 2 symbols", caps.Len(), caps.Symbols)
+	}
+	values := caps.Field("MarketCap")
+	got, _ := values.Column("2317")
+	if !math.IsNaN(got[0]) || got[1] != 100 {
+		t.Errorf("2317 MarketCap = %v, want [NaN 100]", got)
+	}
+}
@@ -2,8 +2,6 @@
 package cache
 
 import (
-	"crypto/sha256"
-	"encoding/hex"
 	"encoding/json"
 	"errors"
 	"os"
@@ -16,10 +14,32 @@ var (
 	ErrNilCache = errors.New("cache is nil")
 )
 
-// cacheEntry represents a cached item with metadata.
-type cacheEntry struct {
-	Data      []byte    `json:"data"`
+// Entry represents a cached item with metadata.
+type Entry struct {
+	// Data is the cached value
+	Data []byte `json:"data"`
+	// StoredAt is when the value was written (zero for legacy entries)
+	StoredAt time.Time `json:"stored_at,omitempty"`
+	// ExpiresAt is when the value expires (zero means no expiration)
 	ExpiresAt time.Time `json:"expires_at"`
+	// Label describes the request the value answers (nil for legacy
+	// entries and requests made without one)
+	Label *Label `json:"label,omitempty"`
+}
+
+// Label describes a cached response by the read it was fetched for, since
+// the cache key itself is only a hash of the request URL. Fields the
+// request did not carry are left empty.
+type Label struct {
+	Source string    `json:"source,omitempty"`
+	Symbol string    `json:"symbol,omitempty"`
+	Start  time.Time `json:"start,omitempty"`
+	End    time.Time `json:"end,omitempty"`
+}
+
+// Expired reports whether the entry has passed its expiration time.
+func (e Entry) Expired() bool {
+	return !e.ExpiresAt.IsZero() && time.Now().After(e.ExpiresAt)
 }
 
 // FileCache implements file-based caching.
@@ -37,38 +57,65 @@ func NewFileCache(dir string) *FileCache {
 // Get retrieves a value from the cache.
 // Returns the value and true if found and not expired, or nil and false otherwise.
 func (c *FileCache) Get(key string) ([]byte, bool) {
+	entry, found := c.GetFresh(key)
+	return entry.Data, found
+}
+
+// GetFresh retrieves an unexpired cache entry, including its expiration
+// time. Expired entries are deleted and reported as not found.
+func (c *FileCache) GetFresh(key string) (Entry, bool) {
 	if c == nil {
-		return nil, false
+		return Entry{}, false
 	}
 
-	filename := c.filename(key)
+	entry, found := c.GetEntry(key)
+	if !found {
+		return Entry{}, false
+	}
+
+	// Check expiration (zero time means no expiration)
+	if entry.Expired() {
+		// Expired, delete it (ignore error as cleanup is best-effort)
+		_ = os.Remove(c.filename(key))
+		return Entry{}, false
+	}
+
+	return entry, true
+}
+
+// GetEntry retrieves a cache entry without checking or enforcing expiration.
+// Expired entries are returned as-is and are not deleted, which allows
+// callers to fall back to stale data when the upstream is unavailable.
+func (c *FileCache) GetEntry(key string) (Entry, bool) {
+	if c == nil {
+		return Entry{}, false
+	}
 
 	// Read file
 	// #nosec G304 - File path is constructed from hashed key, scoped to cache directory
-	data, err := os.ReadFile(filename)
+	data, err := os.ReadFile(c.filename(key))
 	if err != nil {
-		return nil, false
+		return Entry{}, false
 	}
 
 	// Decode entry
-	var entry cacheEntry
+	var entry Entry
 	if err := json.Unmarshal(data, &entry); err != nil {
-		return nil, false
+		return Entry{}, false
 	}
 
-	// Check expiration (zero time means no expiration)
-	if !entry.ExpiresAt.IsZero() && time.Now().After(entry.ExpiresAt) {
-		// Expired, delete it (ignore error as cleanup is best-effort)
-		_ = os.Remove(filename)
-		return nil, false
-	}
-
-	return entry.Data, true
+	return entry, true
 }
 
 // Set stores a value in the cache with the specified TTL.
 // A TTL of 0 means no expiration.
 func (c *FileCache) Set(key string, value []byte, ttl time.Duration) error {
+	return c.SetLabeled(key, value, ttl, nil)
+}
+
+// SetLabeled stores a value like Set, recording label in the entry so it
+// can be listed by List.
+func (c *FileCache) SetLabeled(key string, value []byte, ttl time.Duration, label *Label) error {
 	if c == nil {
 		return ErrNilCache
 	}
@@ -86,9 +133,11 @@ func (c *FileCache) Set(key string, value []byte, ttl time.Duration) error {
 	}
 	// Zero time for no expiration
 
-	entry := cacheEntry{
+	entry := Entry{
 		Data:      value,
+		StoredAt:  time.Now(),
 		ExpiresAt: expiresAt,
+		Label:     label,
 	}
 
 	// Encode entry
@@ -119,7 +168,5 @@ func (c *FileCache) Delete(key string) error {
 
 // filename generates a safe filename for the given key by hashing it.
 func (c *FileCache) filename(key string) string {
-	hash := sha256.Sum256([]byte(key))
-	hashStr := hex.EncodeToString(hash[:])
-	return filepath.Join(c.dir, hashStr+".cache")
+	return filepath.Join(c.dir, Checksum([]byte(key))+".cache")
 }
@@ -89,6 +89,42 @@ func TestFileCache_TTLExpired(t *testing.T) {
 	}
 }
 
+func TestFileCache_GetEntryExpired(t *testing.T) {
+	c := cache.NewFileCache(t.TempDir())
+
+	key := "test-key"
+	value := []byte("test data")
+
+	before := time.Now()
+	if err := c.Set(key, value, 50*time.Millisecond); err != nil {
+		t.Fatalf("Set failed: %v", err)
+	}
+
+	time.Sleep(100 * time.Millisecond)
+
+	// GetEntry returns expired entries without deleting them
+	for i := 0; i < 2; i++ {
+		entry, found := c.GetEntry(key)
+		if !found {
+			t.Fatal("Expected GetEntry to return expired entry")
+		}
+		if !entry.Expired() {
+			t.Error("Expected entry to be expired")
+		}
+		if string(entry.Data) != string(value) {
+			t.Errorf("Expected %q, got %q", value, entry.Data)
+		}
+		if entry.StoredAt.Before(before) {
+			t.Errorf("Expected StoredAt after %v, got %v", before, entry.StoredAt)
+		}
+	}
+
+	// Get still enforces expiration
+	if _, found := c.Get(key); found {
+		t.Error("Expected Get to treat entry as expired")
+	}
+}
+
 func TestFileCache_Delete(t *testing.T) {
 	tmpDir, err := os.MkdirTemp("", "cache-test-*")
 	if err != nil {
@@ -0,0 +1,65 @@
+package cache
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"time"
+)
+
+// DecodedCache holds values decoded from response bodies, keyed by the
+// checksum of the body they were decoded from. Because the key is derived
+// from the content, an entry is only reused for byte-identical responses:
+// when the HTTP cache entry is refreshed or replaced, its decoded value is
+// no longer reachable and ages out of the LRU.
+//
+// Cached values are shared between callers and must be treated as read-only.
+type DecodedCache struct {
+	lru *lru[interface{}]
+}
+
+// NewDecodedCache creates a new decoded-value cache holding at most
+// maxEntries values for up to ttl (0 = until evicted). A maxEntries of 0
+// or less returns nil, which disables caching.
+func NewDecodedCache(maxEntries int, ttl time.Duration) *DecodedCache {
+	if maxEntries <= 0 {
+		return nil
+	}
+
+	return &DecodedCache{lru: newLRU[interface{}](maxEntries, ttl)}
+}
+
+// Decode returns the cached value for body under kind, calling decode and
+// caching its result on a miss. Decode errors are not cached. The kind
+// distinguishes decoders that may see the same bytes (e.g., "csv" vs "json").
+// A nil DecodedCache always calls decode.
+func (c *DecodedCache) Decode(kind string, body []byte, decode func([]byte) (interface{}, error)) (interface{}, error) {
+	if c == nil {
+		return decode(body)
+	}
+
+	key := kind + ":" + Checksum(body)
+	if v, ok := c.lru.get(key); ok {
+		return v, nil
+	}
+
+	v, err := decode(body)
+	if err != nil {
+		return nil, err
+	}
+	c.lru.set(key, v)
+	return v, nil
+}
+
+// Len returns the number of decoded values currently held.
+func (c *DecodedCache) Len() int {
+	if c == nil {
+		return 0
+	}
+	return c.lru.len()
+}
+
+// Checksum returns the hex-encoded SHA-256 checksum of data.
+func Checksum(data []byte) string {
+	sum := sha256.Sum256(data)
+	return hex.EncodeToString(sum[:])
+}
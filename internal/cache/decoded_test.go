@@ -0,0 +1,90 @@
+package cache_test
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/julianshen/gonp-datareader/internal/cache"
+)
+
+func TestDecodedCache_Decode(t *testing.T) {
+	c := cache.NewDecodedCache(10, time.Hour)
+
+	calls := 0
+	decode := func(b []byte) (interface{}, error) {
+		calls++
+		return string(b), nil
+	}
+
+	for i := 0; i < 3; i++ {
+		v, err := c.Decode("text", []byte("body"), decode)
+		if err != nil {
+			t.Fatalf("Decode failed: %v", err)
+		}
+		if v.(string) != "body" {
+			t.Errorf("Expected 'body', got %v", v)
+		}
+	}
+	if calls != 1 {
+		t.Errorf("Expected 1 decode call, got %d", calls)
+	}
+
+	// Different content or kind is decoded separately
+	c.Decode("text", []byte("other"), decode)
+	c.Decode("json", []byte("body"), decode)
+	if calls != 3 {
+		t.Errorf("Expected 3 decode calls, got %d", calls)
+	}
+	if c.Len() != 3 {
+		t.Errorf("Expected 3 entries, got %d", c.Len())
+	}
+}
+
+func TestDecodedCache_ErrorsNotCached(t *testing.T) {
+	c := cache.NewDecodedCache(10, 0)
+	errBad := errors.New("bad body")
+
+	calls := 0
+	decode := func(b []byte) (interface{}, error) {
+		calls++
+		return nil, errBad
+	}
+
+	for i := 0; i < 2; i++ {
+		if _, err := c.Decode("text", []byte("body"), decode); !errors.Is(err, errBad) {
+			t.Errorf("Expected errBad, got %v", err)
+		}
+	}
+	if calls != 2 {
+		t.Errorf("Expected errors not to be cached, got %d calls", calls)
+	}
+}
+
+func TestDecodedCache_Disabled(t *testing.T) {
+	c := cache.NewDecodedCache(0, time.Hour)
+	if c != nil {
+		t.Fatal("Expected nil cache for zero size")
+	}
+
+	calls := 0
+	decode := func(b []byte) (interface{}, error) {
+		calls++
+		return len(b), nil
+	}
+	c.Decode("text", []byte("abc"), decode)
+	c.Decode("text", []byte("abc"), decode)
+	if calls != 2 {
+		t.Errorf("Expected nil cache to always decode, got %d calls", calls)
+	}
+}
+
+func TestChecksum(t *testing.T) {
+	a := cache.Checksum([]byte("abc"))
+	if a != "ba7816bf8f01cfea414140de5dae2223b00361a396177a9cb410ff61f20015ad" {
+		t.Errorf("Unexpected checksum %s", a)
+	}
+	if cache.Checksum([]byte("abd")) == a {
+		t.Error("Expected different content to have different checksums")
+	}
+}
@@ -0,0 +1,86 @@
+package cache
+
+import (
+	"encoding/json"
+	"errors"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+)
+
+// Listing describes one file of a FileCache directory.
+type Listing struct {
+	// Path is the entry's file
+	Path string
+	// Size is the file size in bytes
+	Size int64
+	// StoredAt is when the entry was written; the file's modification
+	// time for legacy entries
+	StoredAt time.Time
+	// ExpiresAt is when the entry expires (zero means no expiration)
+	ExpiresAt time.Time
+	// Label describes the request the entry answers; zero when unknown or
+	// when the file cannot be decoded
+	Label Label
+}
+
+// Expired reports whether the entry has passed its expiration time.
+func (l Listing) Expired() bool {
+	return Entry{ExpiresAt: l.ExpiresAt}.Expired()
+}
+
+// List returns the entries of the file cache in dir and its subdirectories
+// (such as per-tenant caches), sorted by path. A missing dir has no
+// entries. Files that cannot be decoded are listed without a label, so
+// they can still be pruned.
+func List(dir string) ([]Listing, error) {
+	var listings []Listing
+	err := filepath.WalkDir(dir, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			if path == dir && errors.Is(err, fs.ErrNotExist) {
+				return filepath.SkipDir
+			}
+			return err
+		}
+		if d.IsDir() || !strings.HasSuffix(path, ".cache") {
+			return nil
+		}
+
+		info, err := d.Info()
+		if err != nil {
+			return err
+		}
+		listing := Listing{Path: path, Size: info.Size(), StoredAt: info.ModTime()}
+
+		// #nosec G304 - Path is a cache file found under the cache directory
+		data, err := os.ReadFile(path)
+		if err != nil {
+			return err
+		}
+		var entry struct {
+			StoredAt  time.Time `json:"stored_at"`
+			ExpiresAt time.Time `json:"expires_at"`
+			Label     *Label    `json:"label"`
+		}
+		if json.Unmarshal(data, &entry) == nil {
+			if !entry.StoredAt.IsZero() {
+				listing.StoredAt = entry.StoredAt
+			}
+			listing.ExpiresAt = entry.ExpiresAt
+			if entry.Label != nil {
+				listing.Label = *entry.Label
+			}
+		}
+		listings = append(listings, listing)
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	sort.Slice(listings, func(i, j int) bool { return listings[i].Path < listings[j].Path })
+	return listings, nil
+}
@@ -0,0 +1,64 @@
+package cache_test
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/julianshen/gonp-datareader/internal/cache"
+)
+
+func TestList(t *testing.T) {
+	dir := t.TempDir()
+	start := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	end := time.Date(2024, 1, 31, 0, 0, 0, 0, time.UTC)
+
+	label := &cache.Label{Source: "yahoo", Symbol: "AAPL", Start: start, End: end}
+	if err := cache.NewFileCache(dir).SetLabeled("labeled", []byte("data"), time.Hour, label); err != nil {
+		t.Fatal(err)
+	}
+	// Entries in subdirectories, such as per-tenant caches, are listed too
+	if err := cache.NewFileCache(filepath.Join(dir, "tenants", "a")).Set("legacy", []byte("data"), 0); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(dir, "broken.cache"), []byte("{"), 0600); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(dir, "notes.txt"), []byte("not a cache file"), 0600); err != nil {
+		t.Fatal(err)
+	}
+
+	listings, err := cache.List(dir)
+	if err != nil {
+		t.Fatalf("List() error = %v", err)
+	}
+	if len(listings) != 3 {
+		t.Fatalf("List() = %d entries, want 3", len(listings))
+	}
+
+	var labeled, unlabeled int
+	for _, l := range listings {
+		if l.Size == 0 || l.StoredAt.IsZero() {
+			t.Errorf("%s: Size = %d, StoredAt = %v", l.Path, l.Size, l.StoredAt)
+		}
+		if l.Label == (cache.Label{}) {
+			unlabeled++
+			continue
+		}
+		labeled++
+		if l.Label != *label || l.ExpiresAt.IsZero() || l.Expired() {
+			t.Errorf("labeled entry = %+v", l)
+		}
+	}
+	if labeled != 1 || unlabeled != 2 {
+		t.Errorf("List() labeled %d, unlabeled %d, want 1 and 2", labeled, unlabeled)
+	}
+}
+
+func TestList_MissingDir(t *testing.T) {
+	listings, err := cache.List(filepath.Join(t.TempDir(), "missing"))
+	if err != nil || len(listings) != 0 {
+		t.Errorf("List(missing) = %v, %v, want no entries", listings, err)
+	}
+}
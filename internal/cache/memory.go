@@ -0,0 +1,65 @@
+package cache
+
+import "time"
+
+// MemoryCache implements an in-process LRU cache bounded by entry count
+// and TTL. It is safe for concurrent use.
+type MemoryCache struct {
+	lru *lru[[]byte]
+}
+
+// NewMemoryCache creates a new LRU cache holding at most maxEntries values.
+// Values expire after ttl; a ttl of 0 means values only leave the cache
+// when evicted. A maxEntries of 0 or less returns nil, which disables caching.
+func NewMemoryCache(maxEntries int, ttl time.Duration) *MemoryCache {
+	if maxEntries <= 0 {
+		return nil
+	}
+
+	return &MemoryCache{lru: newLRU[[]byte](maxEntries, ttl)}
+}
+
+// Get retrieves a value from the cache and marks it as recently used.
+// Returns the value and true if found and not expired, or nil and false otherwise.
+func (c *MemoryCache) Get(key string) ([]byte, bool) {
+	if c == nil {
+		return nil, false
+	}
+	return c.lru.get(key)
+}
+
+// Set stores a value in the cache, evicting the least recently used
+// value if the cache is full.
+func (c *MemoryCache) Set(key string, value []byte) {
+	if c == nil {
+		return
+	}
+	c.lru.set(key, value)
+}
+
+// SetUntil stores a value like Set, but expires it at deadline if that
+// comes before the cache's TTL, so values copied from another cache do not
+// outlive their source entry. A zero deadline behaves like Set.
+func (c *MemoryCache) SetUntil(key string, value []byte, deadline time.Time) {
+	if c == nil {
+		return
+	}
+	c.lru.setUntil(key, value, deadline)
+}
+
+// Delete removes a value from the cache.
+func (c *MemoryCache) Delete(key string) {
+	if c == nil {
+		return
+	}
+	c.lru.remove(key)
+}
+
+// Len returns the number of values currently held, including expired
+// values that have not been accessed since expiring.
+func (c *MemoryCache) Len() int {
+	if c == nil {
+		return 0
+	}
+	return c.lru.len()
+}
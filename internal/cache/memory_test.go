@@ -0,0 +1,138 @@
+package cache_test
+
+import (
+	"fmt"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/julianshen/gonp-datareader/internal/cache"
+)
+
+func TestMemoryCache_SetAndGet(t *testing.T) {
+	c := cache.NewMemoryCache(10, time.Hour)
+
+	c.Set("key", []byte("value"))
+
+	got, found := c.Get("key")
+	if !found {
+		t.Fatal("Expected to find value")
+	}
+	if string(got) != "value" {
+		t.Errorf("Expected 'value', got %q", got)
+	}
+
+	if _, found := c.Get("missing"); found {
+		t.Error("Expected missing key not to be found")
+	}
+}
+
+func TestMemoryCache_EvictsLeastRecentlyUsed(t *testing.T) {
+	c := cache.NewMemoryCache(2, 0)
+
+	c.Set("a", []byte("1"))
+	c.Set("b", []byte("2"))
+
+	// Touch "a" so that "b" becomes least recently used
+	c.Get("a")
+	c.Set("c", []byte("3"))
+
+	if c.Len() != 2 {
+		t.Errorf("Expected 2 entries, got %d", c.Len())
+	}
+	if _, found := c.Get("b"); found {
+		t.Error("Expected 'b' to be evicted")
+	}
+	for _, key := range []string{"a", "c"} {
+		if _, found := c.Get(key); !found {
+			t.Errorf("Expected %q to remain cached", key)
+		}
+	}
+}
+
+func TestMemoryCache_TTLExpired(t *testing.T) {
+	c := cache.NewMemoryCache(10, 50*time.Millisecond)
+
+	c.Set("key", []byte("value"))
+	time.Sleep(100 * time.Millisecond)
+
+	if _, found := c.Get("key"); found {
+		t.Error("Expected value to be expired")
+	}
+	if c.Len() != 0 {
+		t.Errorf("Expected expired entry to be removed, got %d entries", c.Len())
+	}
+}
+
+func TestMemoryCache_SetUntil(t *testing.T) {
+	c := cache.NewMemoryCache(10, time.Hour)
+
+	c.SetUntil("capped", []byte("value"), time.Now().Add(50*time.Millisecond))
+	c.SetUntil("uncapped", []byte("value"), time.Time{})
+	time.Sleep(100 * time.Millisecond)
+
+	if _, found := c.Get("capped"); found {
+		t.Error("Expected value to expire at its deadline before the TTL")
+	}
+	if _, found := c.Get("uncapped"); !found {
+		t.Error("Expected value without deadline to keep the TTL")
+	}
+}
+
+func TestMemoryCache_OverwriteAndDelete(t *testing.T) {
+	c := cache.NewMemoryCache(10, 0)
+
+	c.Set("key", []byte("old"))
+	c.Set("key", []byte("new"))
+
+	if got, _ := c.Get("key"); string(got) != "new" {
+		t.Errorf("Expected 'new', got %q", got)
+	}
+	if c.Len() != 1 {
+		t.Errorf("Expected 1 entry, got %d", c.Len())
+	}
+
+	c.Delete("key")
+	if _, found := c.Get("key"); found {
+		t.Error("Expected value to be deleted")
+	}
+}
+
+func TestMemoryCache_Disabled(t *testing.T) {
+	c := cache.NewMemoryCache(0, time.Hour)
+	if c != nil {
+		t.Fatal("Expected nil cache for zero size")
+	}
+
+	// Nil cache should not panic
+	c.Set("key", []byte("value"))
+	c.Delete("key")
+	if _, found := c.Get("key"); found {
+		t.Error("Nil cache should return not found")
+	}
+	if c.Len() != 0 {
+		t.Error("Nil cache should be empty")
+	}
+}
+
+func TestMemoryCache_Concurrent(t *testing.T) {
+	c := cache.NewMemoryCache(50, time.Hour)
+
+	var wg sync.WaitGroup
+	for i := 0; i < 10; i++ {
+		wg.Add(1)
+		go func(n int) {
+			defer wg.Done()
+			for j := 0; j < 100; j++ {
+				key := fmt.Sprintf("key-%d", (n*100+j)%75)
+				c.Set(key, []byte(key))
+				c.Get(key)
+			}
+		}(i)
+	}
+	wg.Wait()
+
+	if c.Len() > 50 {
+		t.Errorf("Expected at most 50 entries, got %d", c.Len())
+	}
+}
@@ -0,0 +1,137 @@
+package http
+
+import (
+	"context"
+	"net/http"
+	"sync"
+	"time"
+)
+
+// defaultMaxConcurrency caps concurrent requests in adaptive mode when
+// ClientOptions.MaxConcurrency is not set.
+const defaultMaxConcurrency = 10
+
+// adaptiveLimiter tunes how many requests a client makes at once, AIMD
+// style: the limit is halved when the server throttles or slows down, and
+// grows back by one for every limit's worth of successful responses.
+type adaptiveLimiter struct {
+	mu            sync.Mutex
+	limit         float64
+	max           float64
+	latencyTarget time.Duration
+	inflight      int
+	// pausedUntil holds back new requests after a throttled response, for
+	// at most maxPause (negative: no pause)
+	pausedUntil time.Time
+	maxPause    time.Duration
+	// decreasedAt is when the limit was last reduced; responses to
+	// requests sent before then do not reduce it again
+	decreasedAt time.Time
+	// changed is closed and replaced when a slot frees up
+	changed chan struct{}
+}
+
+// newAdaptiveLimiter returns a limiter starting at, and capped to, max
+// concurrent requests (0 = defaultMaxConcurrency). Responses slower than
+// latencyTarget count as congestion; 0 ignores latency. Retry-After pauses
+// are capped at maxPause, like the waits between retries, so one response
+// cannot stall the client indefinitely; negative disables them.
+func newAdaptiveLimiter(max int, latencyTarget, maxPause time.Duration) *adaptiveLimiter {
+	if max <= 0 {
+		max = defaultMaxConcurrency
+	}
+	return &adaptiveLimiter{
+		limit:         float64(max),
+		max:           float64(max),
+		latencyTarget: latencyTarget,
+		maxPause:      maxPause,
+		changed:       make(chan struct{}),
+	}
+}
+
+// Limit returns the current number of concurrent requests allowed.
+func (l *adaptiveLimiter) Limit() int {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	return int(l.limit)
+}
+
+// acquire waits for a request slot, which must be given back with
+// release or abort.
+func (l *adaptiveLimiter) acquire(ctx context.Context) error {
+	for {
+		l.mu.Lock()
+		pause := time.Until(l.pausedUntil)
+		if pause <= 0 && l.inflight < int(l.limit) {
+			l.inflight++
+			l.mu.Unlock()
+			return nil
+		}
+		changed := l.changed
+		l.mu.Unlock()
+
+		if pause > 0 {
+			if err := sleep(ctx, pause); err != nil {
+				return err
+			}
+			continue
+		}
+		select {
+		case <-changed:
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+	}
+}
+
+// release frees the slot of a request sent at began and adjusts the limit
+// to its outcome: resp is nil when the request failed without a response.
+func (l *adaptiveLimiter) release(began time.Time, resp *http.Response) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	now := time.Now()
+	limited := throttled(resp)
+	slow := l.latencyTarget > 0 && now.Sub(began) > l.latencyTarget
+
+	switch {
+	case limited || slow:
+		// Back off once per round of requests in flight at the time
+		if began.After(l.decreasedAt) {
+			l.limit /= 2
+			if l.limit < 1 {
+				l.limit = 1
+			}
+			l.decreasedAt = now
+		}
+		if limited && l.maxPause >= 0 {
+			wait := min(RetryAfter(resp.Header, now), l.maxPause)
+			if wait > 0 && now.Add(wait).After(l.pausedUntil) {
+				l.pausedUntil = now.Add(wait)
+			}
+		}
+	case resp != nil && resp.StatusCode < http.StatusInternalServerError:
+		// Grow by one slot per window of successful responses
+		l.limit += 1 / l.limit
+		if l.limit > l.max {
+			l.limit = l.max
+		}
+	}
+
+	l.free()
+}
+
+// abort frees the slot of a request that was never sent.
+func (l *adaptiveLimiter) abort() {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.free()
+}
+
+// free gives back a slot and wakes the requests waiting for one; l.mu
+// must be held.
+func (l *adaptiveLimiter) free() {
+	l.inflight--
+	close(l.changed)
+	l.changed = make(chan struct{})
+}
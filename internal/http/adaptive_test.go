@@ -0,0 +1,205 @@
+package http
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"testing"
+	"time"
+)
+
+func response(status int, retryAfter string) *http.Response {
+	resp := &http.Response{StatusCode: status, Header: make(http.Header)}
+	if retryAfter != "" {
+		resp.Header.Set("Retry-After", retryAfter)
+	}
+	return resp
+}
+
+func TestAdaptiveLimiter_AIMD(t *testing.T) {
+	tests := []struct {
+		name      string
+		max       int
+		latency   time.Duration
+		responses []*http.Response
+		sentAgo   time.Duration
+		wantLimit int
+	}{
+		{
+			name:      "default max",
+			wantLimit: defaultMaxConcurrency,
+		},
+		{
+			name:      "429 halves the limit",
+			max:       8,
+			responses: []*http.Response{response(http.StatusTooManyRequests, "")},
+			wantLimit: 4,
+		},
+		{
+			name:      "503 halves the limit",
+			max:       8,
+			responses: []*http.Response{response(http.StatusServiceUnavailable, "")},
+			wantLimit: 4,
+		},
+		{
+			name: "limit does not drop below one",
+			max:  2,
+			responses: []*http.Response{
+				response(http.StatusTooManyRequests, ""),
+				response(http.StatusTooManyRequests, ""),
+				response(http.StatusTooManyRequests, ""),
+			},
+			wantLimit: 1,
+		},
+		{
+			name:      "slow response halves the limit",
+			max:       8,
+			latency:   time.Second,
+			responses: []*http.Response{response(http.StatusOK, "")},
+			sentAgo:   2 * time.Second,
+			wantLimit: 4,
+		},
+		{
+			name:      "fast response keeps the limit",
+			max:       8,
+			latency:   time.Second,
+			responses: []*http.Response{response(http.StatusOK, "")},
+			wantLimit: 8,
+		},
+		{
+			name:      "server errors and failed requests leave the limit",
+			max:       8,
+			responses: []*http.Response{response(http.StatusInternalServerError, ""), nil},
+			wantLimit: 8,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			l := newAdaptiveLimiter(tt.max, tt.latency, defaultMaxRetryAfter)
+			for _, resp := range tt.responses {
+				if err := l.acquire(context.Background()); err != nil {
+					t.Fatalf("acquire() error = %v", err)
+				}
+				l.release(time.Now().Add(-tt.sentAgo), resp)
+			}
+			if got := l.Limit(); got != tt.wantLimit {
+				t.Errorf("Limit() = %d, want %d", got, tt.wantLimit)
+			}
+		})
+	}
+}
+
+func TestAdaptiveLimiter_BacksOffOncePerRound(t *testing.T) {
+	l := newAdaptiveLimiter(8, 0, defaultMaxRetryAfter)
+
+	// Four requests in flight are all throttled
+	began := time.Now()
+	for i := 0; i < 4; i++ {
+		if err := l.acquire(context.Background()); err != nil {
+			t.Fatalf("acquire() error = %v", err)
+		}
+	}
+	for i := 0; i < 4; i++ {
+		l.release(began, response(http.StatusTooManyRequests, ""))
+	}
+	if got := l.Limit(); got != 4 {
+		t.Fatalf("Limit() = %d after one round, want 4", got)
+	}
+
+	// Requests sent after the back-off count again
+	l.acquire(context.Background())
+	l.release(time.Now(), response(http.StatusTooManyRequests, ""))
+	if got := l.Limit(); got != 2 {
+		t.Errorf("Limit() = %d after two rounds, want 2", got)
+	}
+}
+
+func TestAdaptiveLimiter_Recovers(t *testing.T) {
+	l := newAdaptiveLimiter(4, 0, defaultMaxRetryAfter)
+	l.acquire(context.Background())
+	l.release(time.Now(), response(http.StatusTooManyRequests, ""))
+	if got := l.Limit(); got != 2 {
+		t.Fatalf("Limit() = %d after throttling, want 2", got)
+	}
+
+	// Additive increase: each slot takes about limit successes
+	for i := 0; i < 6; i++ {
+		l.acquire(context.Background())
+		l.release(time.Now(), response(http.StatusOK, ""))
+	}
+	if got := l.Limit(); got != 4 {
+		t.Errorf("Limit() = %d after recovering, want 4", got)
+	}
+
+	// Never above max
+	for i := 0; i < 10; i++ {
+		l.acquire(context.Background())
+		l.release(time.Now(), response(http.StatusOK, ""))
+	}
+	if got := l.Limit(); got != 4 {
+		t.Errorf("Limit() = %d, want max of 4", got)
+	}
+}
+
+func TestAdaptiveLimiter_WaitsForSlot(t *testing.T) {
+	l := newAdaptiveLimiter(1, 0, defaultMaxRetryAfter)
+	if err := l.acquire(context.Background()); err != nil {
+		t.Fatalf("acquire() error = %v", err)
+	}
+
+	// A second request waits until the first is released
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+	if err := l.acquire(ctx); !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatalf("acquire() on a full limiter error = %v, want context.DeadlineExceeded", err)
+	}
+
+	acquired := make(chan error, 1)
+	go func() { acquired <- l.acquire(context.Background()) }()
+	time.Sleep(10 * time.Millisecond)
+	l.release(time.Now(), response(http.StatusOK, ""))
+
+	select {
+	case err := <-acquired:
+		if err != nil {
+			t.Errorf("acquire() error = %v", err)
+		}
+	case <-time.After(time.Second):
+		t.Fatal("acquire() still waiting after release")
+	}
+}
+
+func TestAdaptiveLimiter_PausesForRetryAfter(t *testing.T) {
+	l := newAdaptiveLimiter(4, 0, defaultMaxRetryAfter)
+	l.acquire(context.Background())
+	l.release(time.Now(), response(http.StatusTooManyRequests, "1"))
+
+	// Slots are free, but requests wait out the Retry-After
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+	if err := l.acquire(ctx); !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatalf("acquire() during pause error = %v, want context.DeadlineExceeded", err)
+	}
+
+	start := time.Now()
+	if err := l.acquire(context.Background()); err != nil {
+		t.Fatalf("acquire() error = %v", err)
+	}
+	if waited := time.Since(start); waited < 500*time.Millisecond {
+		t.Errorf("acquire() returned after %v, want the Retry-After pause", waited)
+	}
+}
+
+func TestAdaptiveLimiter_CapsRetryAfterPause(t *testing.T) {
+	l := newAdaptiveLimiter(4, 0, 50*time.Millisecond)
+	l.acquire(context.Background())
+	l.release(time.Now(), response(http.StatusTooManyRequests, "86400"))
+
+	// The day-long Retry-After is cut to the 50ms cap
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+	if err := l.acquire(ctx); err != nil {
+		t.Fatalf("acquire() error = %v, want the pause capped", err)
+	}
+}
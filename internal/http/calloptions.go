@@ -0,0 +1,86 @@
+package http
+
+import (
+	"context"
+	"time"
+
+	"github.com/julianshen/gonp-datareader/internal/cache"
+)
+
+// CallOptions overrides reader settings for one call. It travels in the
+// context, so it reaches every request the call makes, including the
+// parallel requests of a multi-symbol read.
+type CallOptions struct {
+	// APIKey replaces the reader's API key or token.
+	APIKey string
+	// Dataset replaces the reader's dataset.
+	Dataset string
+	// Timeout bounds each request, including retries and reading the
+	// response body, on top of the client's per-attempt timeout; 0 adds
+	// no bound.
+	Timeout time.Duration
+	// CacheTTL, when set, is the maximum age of cached responses the call
+	// accepts; older ones are refetched. Responses are still stored with
+	// the client's TTL. A TTL of 0 bypasses the cache entirely.
+	CacheTTL *time.Duration
+	// OnBody, when set, receives the body of each successful (2xx)
+	// response of the call, after charset decoding, when the body is
+	// closed. The slice is only valid during the call.
+	OnBody func(body []byte)
+}
+
+// callOptionsKey is the context key of CallOptions.
+type callOptionsKey struct{}
+
+// WithCallOptions returns a copy of ctx carrying opts.
+func WithCallOptions(ctx context.Context, opts CallOptions) context.Context {
+	return context.WithValue(ctx, callOptionsKey{}, opts)
+}
+
+// CallOptionsFrom returns the CallOptions carried by ctx; the zero value
+// when there are none.
+func CallOptionsFrom(ctx context.Context) CallOptions {
+	opts, _ := ctx.Value(callOptionsKey{}).(CallOptions)
+	return opts
+}
+
+// APIKey returns the call's API key override, or key when there is none.
+func APIKey(ctx context.Context, key string) string {
+	if override := CallOptionsFrom(ctx).APIKey; override != "" {
+		return override
+	}
+	return key
+}
+
+// Dataset returns the call's dataset override, or dataset when there is
+// none.
+func Dataset(ctx context.Context, dataset string) string {
+	if override := CallOptionsFrom(ctx).Dataset; override != "" {
+		return override
+	}
+	return dataset
+}
+
+// cacheLabelKey is the context key of the cache label.
+type cacheLabelKey struct{}
+
+// WithCacheLabel returns a copy of ctx whose cached responses are labeled
+// with label, so cache listings can tell which read they belong to. The
+// label's Source is replaced by the client's ClientOptions.Source when
+// set, as readers wrapping others may pass the context on.
+func WithCacheLabel(ctx context.Context, label cache.Label) context.Context {
+	return context.WithValue(ctx, cacheLabelKey{}, label)
+}
+
+// cacheLabel returns the label of responses cached by a request with ctx
+// for a client of source.
+func cacheLabel(ctx context.Context, source string) *cache.Label {
+	label, _ := ctx.Value(cacheLabelKey{}).(cache.Label)
+	if source != "" {
+		label.Source = source
+	}
+	if label == (cache.Label{}) {
+		return nil
+	}
+	return &label
+}
@@ -2,6 +2,7 @@
 package http
 
 import (
+	"fmt"
 	"net/http"
 	"time"
 )
@@ -20,14 +21,92 @@ type ClientOptions struct {
 	// RetryDelay specifies the delay between retry attempts
 	RetryDelay time.Duration
 
+	// MaxRetryAfter specifies the longest Retry-After wait of 429 and 503
+	// responses that is waited out before retrying (0 = 30s, negative =
+	// none); responses asking for longer are not retried
+	MaxRetryAfter time.Duration
+
 	// RateLimit specifies requests per second limit (0 = unlimited)
 	RateLimit float64
 
-	// CacheDir specifies the directory for caching responses (empty = no cache)
+	// RateBurst specifies how many requests may be made at once when the
+	// rate limiter's bucket is full (0 = 1)
+	RateBurst int
+
+	// RateInitialTokens specifies how many tokens the rate limiter's bucket
+	// starts with (0 = full bucket, negative = empty), so the first requests
+	// of a batch can be paced too
+	RateInitialTokens int
+
+	// CacheDir specifies the directory for caching responses (empty = no cache).
+	// Ignored under js/wasm, which falls back to the memory cache.
 	CacheDir string
 
 	// CacheTTL specifies the cache time-to-live (0 = no expiration)
 	CacheTTL time.Duration
+
+	// ServeStaleOnError returns an expired cache entry when the upstream
+	// request fails with a network error or 5xx status after all retries
+	ServeStaleOnError bool
+
+	// MemoryCacheSize specifies the maximum number of responses held in an
+	// in-process LRU cache in front of the file cache (0 = disabled)
+	MemoryCacheSize int
+
+	// MemoryCacheTTL specifies the in-memory cache time-to-live
+	// (0 = use CacheTTL)
+	MemoryCacheTTL time.Duration
+
+	// DecodedCacheSize specifies the maximum number of parsed responses kept
+	// in memory, keyed by response checksum, so identical bodies are only
+	// decoded once (0 = disabled)
+	DecodedCacheSize int
+
+	// Source names the data source in the labels of file cache entries
+	// (see WithCacheLabel)
+	Source string
+
+	// OnCacheHit is called when a response is served from cache; layer is
+	// "memory" or "disk"
+	OnCacheHit func(layer, key string)
+
+	// OnCacheMiss is called when no cache layer holds a fresh response
+	OnCacheMiss func(key string)
+
+	// MaxRedirects specifies how many redirects a request may follow
+	// (0 = Go's default of 10, negative = none); requests exceeding it
+	// fail with ErrTooManyRedirects
+	MaxRedirects int
+
+	// AdaptiveRateLimit lowers RateLimit AIMD style while the provider
+	// throttles: the rate is halved on 429/503 responses and quota
+	// messages reported with Throttled, and recovers by a twentieth of
+	// RateLimit per successful response
+	AdaptiveRateLimit bool
+
+	// AdaptiveConcurrency tunes the number of concurrent requests AIMD
+	// style: it is halved on 429/503 responses (which also pause requests
+	// for their Retry-After, capped like MaxRetryAfter) and on responses
+	// slower than LatencyTarget, and grows back by one per round of
+	// successful responses
+	AdaptiveConcurrency bool
+
+	// MaxConcurrency caps concurrent requests in adaptive mode
+	// (0 = 10)
+	MaxConcurrency int
+
+	// LatencyTarget is the response time above which adaptive mode backs
+	// off (0 = latency is ignored)
+	LatencyTarget time.Duration
+
+	// FallbackCharset is the charset of text responses that declare none
+	// and are not valid UTF-8 (e.g., "big5"); empty leaves them unchanged.
+	// See CheckCharset for the accepted names.
+	FallbackCharset string
+
+	// DisableBodyDecoding returns response bodies as received, neither
+	// decompressed (when the transport did not) nor converted to UTF-8
+	DisableBodyDecoding bool
 }
 
 // DefaultClientOptions returns default HTTP client options.
@@ -48,13 +127,24 @@ func NewHTTPClient(opts *ClientOptions) *http.Client {
 	}
 
 	client := &http.Client{
-		Timeout: opts.Timeout,
-		Transport: &http.Transport{
-			MaxIdleConns:        100,
-			MaxIdleConnsPerHost: 10,
-			IdleConnTimeout:     90 * time.Second,
-		},
+		Timeout:       opts.Timeout,
+		Transport:     newTransport(),
+		CheckRedirect: redirectPolicy(opts.MaxRedirects),
 	}
 
 	return client
 }
+
+// redirectPolicy returns a CheckRedirect func allowing at most max
+// redirects (0 = 10, negative = none).
+func redirectPolicy(max int) func(req *http.Request, via []*http.Request) error {
+	if max == 0 {
+		max = 10
+	}
+	return func(req *http.Request, via []*http.Request) error {
+		if len(via) > max {
+			return fmt.Errorf("%w: stopped after %d redirects at %s", ErrTooManyRedirects, max, req.URL.Redacted())
+		}
+		return nil
+	}
+}
@@ -0,0 +1,142 @@
+package http
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"mime"
+	"net/http"
+	"strings"
+	"unicode/utf8"
+)
+
+// ErrUnexpectedContentType is matched by errors.Is for every
+// *UnexpectedContentTypeError.
+var ErrUnexpectedContentType = errors.New("unexpected content type")
+
+// ErrTooManyRedirects is returned when a request is redirected more often
+// than ClientOptions.MaxRedirects allows.
+var ErrTooManyRedirects = errors.New("too many redirects")
+
+// snippetLen is the number of body bytes kept in UnexpectedContentTypeError.
+const snippetLen = 200
+
+// UnexpectedContentTypeError is returned when a response is not of the
+// type the reader parses, typically an HTML consent, login or error page
+// served with status 200.
+type UnexpectedContentTypeError struct {
+	// URL is the final URL of the response, after redirects, without its
+	// query string, which may carry API keys
+	URL string
+	// ContentType is the response's Content-Type header
+	ContentType string
+	// Expected lists the media types the reader accepts
+	Expected []string
+	// Snippet holds the start of the response body
+	Snippet string
+}
+
+// Error implements the error interface.
+func (e *UnexpectedContentTypeError) Error() string {
+	contentType := e.ContentType
+	if contentType == "" {
+		contentType = "none"
+	}
+	return fmt.Sprintf("unexpected content type %s from %s (expected %s): %q",
+		contentType, e.URL, strings.Join(e.Expected, " or "), e.Snippet)
+}
+
+// Is implements error matching for errors.Is.
+func (e *UnexpectedContentTypeError) Is(target error) bool {
+	return target == ErrUnexpectedContentType
+}
+
+// CheckContentType verifies that a response body can be parsed as one of
+// the expected media types (e.g., "text/csv", "application/json") and
+// returns an *UnexpectedContentTypeError otherwise.
+//
+// HTML is always rejected. Vendor JSON types ("application/vnd.x+json")
+// match "application/json", and the generic types text/plain and
+// application/octet-stream, like a missing Content-Type (as on cached
+// responses), are accepted unless the body looks like HTML.
+func CheckContentType(resp *http.Response, body []byte, expected ...string) error {
+	header := resp.Header.Get("Content-Type")
+	mediaType, _, err := mime.ParseMediaType(header)
+	if err != nil {
+		mediaType = ""
+	}
+
+	ok := false
+	switch {
+	case mediaType == "text/html" || mediaType == "application/xhtml+xml":
+	case mediaType == "", mediaType == "text/plain", mediaType == "application/octet-stream":
+		ok = !looksLikeHTML(body)
+	default:
+		for _, want := range expected {
+			if mediaType == want || (want == "application/json" && isJSONType(mediaType)) {
+				ok = true
+				break
+			}
+		}
+	}
+	if ok {
+		return nil
+	}
+
+	url := ""
+	if resp.Request != nil && resp.Request.URL != nil {
+		u := *resp.Request.URL
+		u.RawQuery, u.ForceQuery, u.Fragment = "", false, ""
+		url = u.Redacted()
+	}
+	return &UnexpectedContentTypeError{
+		URL:         url,
+		ContentType: header,
+		Expected:    expected,
+		Snippet:     snippet(body),
+	}
+}
+
+// contentTypeKey is the context key of a request's expected media types.
+type contentTypeKey struct{}
+
+// WithContentType returns a copy of ctx whose requests expect a response of
+// one of the media types (see CheckContentType). RetryableClient.Do checks
+// responses with status 200 before caching them and returns an
+// *UnexpectedContentTypeError instead, so HTML consent, login or error
+// pages are neither parsed nor cached.
+func WithContentType(ctx context.Context, expected ...string) context.Context {
+	return context.WithValue(ctx, contentTypeKey{}, expected)
+}
+
+// expectedContentType returns the media types expected by a request with
+// ctx; nil when any type is accepted.
+func expectedContentType(ctx context.Context) []string {
+	expected, _ := ctx.Value(contentTypeKey{}).([]string)
+	return expected
+}
+
+// isJSONType reports whether a media type is JSON, including vendor types
+// such as "application/vnd.sdmx.data+json".
+func isJSONType(mediaType string) bool {
+	return strings.HasSuffix(mediaType, "/json") || strings.HasSuffix(mediaType, "+json")
+}
+
+// looksLikeHTML reports whether body starts like an HTML document.
+func looksLikeHTML(body []byte) bool {
+	start := bytes.ToLower(bytes.TrimSpace(body[:min(len(body), 64)]))
+	return bytes.HasPrefix(start, []byte("<!doctype html")) || bytes.HasPrefix(start, []byte("<html"))
+}
+
+// snippet returns the start of body with whitespace collapsed, cut at a
+// rune boundary.
+func snippet(body []byte) string {
+	if len(body) > snippetLen {
+		body = body[:snippetLen]
+		for len(body) > 0 && !utf8.Valid(body) {
+			body = body[:len(body)-1]
+		}
+	}
+	return strings.Join(strings.Fields(string(body)), " ")
+}
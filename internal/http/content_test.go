@@ -0,0 +1,173 @@
+package http_test
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+
+	internalhttp "github.com/julianshen/gonp-datareader/internal/http"
+)
+
+func response(contentType string) *http.Response {
+	resp := &http.Response{Header: make(http.Header)}
+	if contentType != "" {
+		resp.Header.Set("Content-Type", contentType)
+	}
+	return resp
+}
+
+func TestCheckContentType(t *testing.T) {
+	const html = "<!DOCTYPE html><html><body>Before you continue to Yahoo</body></html>"
+
+	tests := []struct {
+		name        string
+		contentType string
+		body        string
+		expected    []string
+		wantErr     bool
+	}{
+		{"csv", "text/csv; charset=utf-8", "Date,Close\n", []string{"text/csv"}, false},
+		{"json", "application/json", "{}", []string{"application/json"}, false},
+		{"vendor json", "application/vnd.sdmx.data+json; version=1.0", "{}", []string{"application/json"}, false},
+		{"html", "text/html; charset=utf-8", html, []string{"text/csv"}, true},
+		{"html without content type", "", html, []string{"application/json"}, true},
+		{"cached response without content type", "", "{}", []string{"application/json"}, false},
+		{"text/plain csv", "text/plain", "Date,Close\n", []string{"text/csv"}, false},
+		{"text/plain html", "text/plain", "  <html><head>", []string{"text/csv"}, true},
+		{"wrong type", "application/xml", "<x/>", []string{"application/json"}, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := internalhttp.CheckContentType(response(tt.contentType), []byte(tt.body), tt.expected...)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("CheckContentType() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if err != nil && !errors.Is(err, internalhttp.ErrUnexpectedContentType) {
+				t.Errorf("error %v does not match ErrUnexpectedContentType", err)
+			}
+		})
+	}
+}
+
+func TestCheckContentType_Snippet(t *testing.T) {
+	body := "<html>\n  <title>Consent</title>" + strings.Repeat("x", 500)
+	err := internalhttp.CheckContentType(response("text/html"), []byte(body), "text/csv")
+
+	var ctErr *internalhttp.UnexpectedContentTypeError
+	if !errors.As(err, &ctErr) {
+		t.Fatalf("error = %v, want *UnexpectedContentTypeError", err)
+	}
+	if !strings.HasPrefix(ctErr.Snippet, "<html> <title>Consent</title>") || len(ctErr.Snippet) > 200 {
+		t.Errorf("Snippet = %q", ctErr.Snippet)
+	}
+	if ctErr.ContentType != "text/html" {
+		t.Errorf("ContentType = %q, want text/html", ctErr.ContentType)
+	}
+}
+
+func TestCheckContentType_RedactsQuery(t *testing.T) {
+	resp := response("text/html")
+	resp.Request, _ = http.NewRequest("GET", "https://api.example.com/series?id=GDP&api_key=secret", nil)
+
+	err := internalhttp.CheckContentType(resp, []byte("<html></html>"), "application/json")
+
+	var ctErr *internalhttp.UnexpectedContentTypeError
+	if !errors.As(err, &ctErr) {
+		t.Fatalf("error = %v, want *UnexpectedContentTypeError", err)
+	}
+	if ctErr.URL != "https://api.example.com/series" {
+		t.Errorf("URL = %q, want it without the query string", ctErr.URL)
+	}
+	if strings.Contains(err.Error(), "secret") {
+		t.Errorf("error %q leaks the API key", err)
+	}
+}
+
+func TestRetryableClient_WithContentType(t *testing.T) {
+	var requests atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if requests.Add(1) == 1 {
+			w.Header().Set("Content-Type", "text/html")
+			w.Write([]byte("<html><title>Consent</title></html>"))
+			return
+		}
+		w.Header().Set("Content-Type", "text/csv")
+		w.Write([]byte("Date,Close\n2024-01-02,1\n"))
+	}))
+	defer server.Close()
+
+	client := internalhttp.NewRetryableClient(&internalhttp.ClientOptions{CacheDir: t.TempDir()})
+	get := func() (string, error) {
+		ctx := internalhttp.WithContentType(context.Background(), "text/csv")
+		req, _ := http.NewRequestWithContext(ctx, "GET", server.URL, nil)
+		resp, err := client.Do(req)
+		if err != nil {
+			return "", err
+		}
+		defer resp.Body.Close()
+		body, err := io.ReadAll(resp.Body)
+		return string(body), err
+	}
+
+	if _, err := get(); !errors.Is(err, internalhttp.ErrUnexpectedContentType) {
+		t.Fatalf("Do() error = %v, want ErrUnexpectedContentType", err)
+	}
+
+	// The rejected page was not cached, so the next request is sent
+	body, err := get()
+	if err != nil || !strings.HasPrefix(body, "Date,Close") {
+		t.Fatalf("Do() = %q, %v, want the CSV response", body, err)
+	}
+	if requests.Load() != 2 {
+		t.Errorf("requests = %d, want 2", requests.Load())
+	}
+}
+
+func TestRetryableClient_MaxRedirects(t *testing.T) {
+	var requests atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests.Add(1)
+		if r.URL.Path == "/final" {
+			w.Write([]byte("ok"))
+			return
+		}
+		http.Redirect(w, r, "/final", http.StatusFound)
+	}))
+	defer server.Close()
+
+	tests := []struct {
+		name         string
+		maxRedirects int
+		wantErr      bool
+	}{
+		{"default", 0, false},
+		{"within limit", 1, false},
+		{"disabled", -1, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			requests.Store(0)
+			client := internalhttp.NewRetryableClient(&internalhttp.ClientOptions{MaxRetries: 3, MaxRedirects: tt.maxRedirects})
+
+			req, _ := http.NewRequestWithContext(context.Background(), "GET", server.URL+"/start", nil)
+			resp, err := client.Do(req)
+			if resp != nil {
+				resp.Body.Close()
+			}
+
+			if got := errors.Is(err, internalhttp.ErrTooManyRedirects); got != tt.wantErr {
+				t.Fatalf("Do() error = %v, want ErrTooManyRedirects = %v", err, tt.wantErr)
+			}
+			if tt.wantErr && requests.Load() != 1 {
+				t.Errorf("requests = %d, want 1 (redirect errors are not retried)", requests.Load())
+			}
+		})
+	}
+}
@@ -0,0 +1,174 @@
+package http
+
+import (
+	"bufio"
+	"compress/flate"
+	"compress/gzip"
+	"compress/zlib"
+	"errors"
+	"fmt"
+	"io"
+	"mime"
+	"net/http"
+	"strings"
+	"unicode/utf8"
+
+	"golang.org/x/text/encoding"
+	"golang.org/x/text/encoding/htmlindex"
+	"golang.org/x/text/encoding/unicode"
+)
+
+// ErrUnknownCharset is returned for a charset name that is not a known
+// encoding label.
+var ErrUnknownCharset = errors.New("unknown charset")
+
+// sniffLen is the number of body bytes inspected for a byte order mark
+// and, without a declared charset, for invalid UTF-8.
+const sniffLen = 4096
+
+// charsetAliases maps vendor charset names that are not WHATWG labels to
+// the encoding they denote; TWSE pages declare Microsoft's Big5 variant.
+var charsetAliases = map[string]string{
+	"ms950":         "big5",
+	"cp950":         "big5",
+	"windows-950":   "big5",
+	"x-windows-950": "big5",
+}
+
+// lookupCharset returns the encoding of a charset label or alias.
+func lookupCharset(name string) (encoding.Encoding, error) {
+	if alias, ok := charsetAliases[strings.ToLower(strings.TrimSpace(name))]; ok {
+		name = alias
+	}
+	return htmlindex.Get(name)
+}
+
+// CheckCharset verifies that name is a known encoding label (e.g., "big5",
+// "iso-8859-1", "windows-1252"), as accepted by
+// ClientOptions.FallbackCharset.
+func CheckCharset(name string) error {
+	if _, err := lookupCharset(name); err != nil {
+		return fmt.Errorf("%w: %s", ErrUnknownCharset, name)
+	}
+	return nil
+}
+
+// decodeBody makes the body of resp read as decompressed UTF-8: a gzip or
+// deflate Content-Encoding the transport left in place is removed, and
+// text in another charset is converted while it is read. The charset is
+// taken from a byte order mark or the Content-Type header; a body
+// declaring none whose start is not valid UTF-8 is read as fallback, if
+// set. Unknown charsets and binary media types are left unchanged.
+func decodeBody(resp *http.Response, fallback string) error {
+	var body io.Reader = resp.Body
+	switch strings.ToLower(strings.TrimSpace(resp.Header.Get("Content-Encoding"))) {
+	case "gzip", "x-gzip":
+		zr, err := gzip.NewReader(resp.Body)
+		if err != nil {
+			return fmt.Errorf("failed to decompress gzip response: %w", err)
+		}
+		body = zr
+	case "deflate":
+		body = newDeflateReader(resp.Body)
+	}
+	if body != resp.Body {
+		resp.Header.Del("Content-Encoding")
+		resp.Header.Del("Content-Length")
+		resp.ContentLength = -1
+		resp.Uncompressed = true
+	}
+
+	mediaType, params, err := mime.ParseMediaType(resp.Header.Get("Content-Type"))
+	if err != nil {
+		mediaType, params = "", nil
+	}
+	if isText(mediaType) {
+		br := bufio.NewReaderSize(body, sniffLen)
+		head, _ := br.Peek(sniffLen) //nolint:errcheck // Short bodies are inspected as is
+		enc, bom := detectCharset(head, params["charset"], fallback)
+		if bom > 0 {
+			_, _ = br.Discard(bom)
+		}
+		body = br
+		if enc != nil {
+			body = enc.NewDecoder().Reader(br)
+		}
+		if (enc != nil || bom > 0) && mediaType != "" {
+			params["charset"] = "utf-8"
+			resp.Header.Set("Content-Type", mime.FormatMediaType(mediaType, params))
+		}
+	}
+
+	resp.Body = struct {
+		io.Reader
+		io.Closer
+	}{body, resp.Body}
+	return nil
+}
+
+// detectCharset returns the encoding to convert a body starting with head
+// from, nil for UTF-8 or an unknown charset, and the length of a UTF-8
+// byte order mark to skip. A byte order mark takes precedence over the
+// declared charset.
+func detectCharset(head []byte, declared, fallback string) (encoding.Encoding, int) {
+	name := declared
+	switch {
+	case len(head) >= 3 && head[0] == 0xEF && head[1] == 0xBB && head[2] == 0xBF:
+		return nil, 3
+	case len(head) >= 2 && (head[0] == 0xFE && head[1] == 0xFF || head[0] == 0xFF && head[1] == 0xFE):
+		return unicode.UTF16(unicode.BigEndian, unicode.ExpectBOM), 0
+	case declared == "" && fallback != "" && !validUTF8Prefix(head):
+		name = fallback
+	}
+	if name == "" {
+		return nil, 0
+	}
+
+	enc, err := lookupCharset(name)
+	if err != nil || enc == unicode.UTF8 {
+		return nil, 0
+	}
+	return enc, 0
+}
+
+// validUTF8Prefix reports whether head is valid UTF-8, allowing a rune cut
+// off at its end.
+func validUTF8Prefix(head []byte) bool {
+	for i := len(head) - 1; i >= 0 && i >= len(head)-utf8.UTFMax; i-- {
+		if utf8.RuneStart(head[i]) {
+			if !utf8.FullRune(head[i:]) {
+				head = head[:i]
+			}
+			break
+		}
+	}
+	return utf8.Valid(head)
+}
+
+// isText reports whether a media type holds text that may need charset
+// conversion. A missing Content-Type (as on some CSV endpoints) counts as
+// text.
+func isText(mediaType string) bool {
+	switch {
+	case mediaType == "", strings.HasPrefix(mediaType, "text/"):
+		return true
+	case isJSONType(mediaType), strings.HasSuffix(mediaType, "/xml"), strings.HasSuffix(mediaType, "+xml"):
+		return true
+	case mediaType == "application/csv", mediaType == "application/javascript":
+		return true
+	}
+	return false
+}
+
+// newDeflateReader returns a reader of a "deflate" body, which servers send
+// either zlib-wrapped, as the standard says, or as raw DEFLATE data.
+func newDeflateReader(r io.Reader) io.Reader {
+	br := bufio.NewReader(r)
+	header, _ := br.Peek(2) //nolint:errcheck // A short body is read as raw DEFLATE
+	if len(header) == 2 && header[0]&0x0F == 8 && (uint16(header[0])<<8|uint16(header[1]))%31 == 0 {
+		if zr, err := zlib.NewReader(br); err == nil {
+			return zr
+		}
+	}
+	return flate.NewReader(br)
+}
@@ -0,0 +1,155 @@
+package http_test
+
+import (
+	"bytes"
+	"compress/zlib"
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"golang.org/x/text/encoding/charmap"
+	"golang.org/x/text/encoding/traditionalchinese"
+
+	internalhttp "github.com/julianshen/gonp-datareader/internal/http"
+)
+
+func TestRetryableClient_BodyDecoding(t *testing.T) {
+	big5, _ := traditionalchinese.Big5.NewEncoder().String("台積電")
+	latin1, _ := charmap.ISO8859_1.NewEncoder().String("Zürich")
+	var deflated bytes.Buffer
+	zw := zlib.NewWriter(&deflated)
+	zw.Write([]byte(`{"name":"ok"}`))
+	zw.Close()
+
+	tests := []struct {
+		name            string
+		contentType     string
+		contentEncoding string
+		body            []byte
+		opts            internalhttp.ClientOptions
+		want            string
+		wantType        string
+	}{
+		{
+			name:        "declared Big5",
+			contentType: "text/csv; charset=big5",
+			body:        []byte(big5),
+			want:        "台積電",
+			wantType:    "text/csv; charset=utf-8",
+		},
+		{
+			name:        "declared MS950 alias",
+			contentType: "text/html;charset=MS950",
+			body:        []byte(big5),
+			want:        "台積電",
+			wantType:    "text/html; charset=utf-8",
+		},
+		{
+			name:        "declared ISO-8859-1",
+			contentType: "application/json; charset=ISO-8859-1",
+			body:        []byte(latin1),
+			want:        "Zürich",
+			wantType:    "application/json; charset=utf-8",
+		},
+		{
+			name:        "undeclared with fallback",
+			contentType: "application/json",
+			body:        []byte(big5),
+			opts:        internalhttp.ClientOptions{FallbackCharset: "big5"},
+			want:        "台積電",
+			wantType:    "application/json; charset=utf-8",
+		},
+		{
+			name:        "valid UTF-8 ignores fallback",
+			contentType: "application/json",
+			body:        []byte("台積電"),
+			opts:        internalhttp.ClientOptions{FallbackCharset: "big5"},
+			want:        "台積電",
+			wantType:    "application/json",
+		},
+		{
+			name:        "undeclared without fallback",
+			contentType: "application/json",
+			body:        []byte(big5),
+			want:        big5,
+			wantType:    "application/json",
+		},
+		{
+			name:        "UTF-8 byte order mark",
+			contentType: "text/csv",
+			body:        []byte("\xEF\xBB\xBFDate,Close"),
+			want:        "Date,Close",
+			wantType:    "text/csv; charset=utf-8",
+		},
+		{
+			name:        "binary",
+			contentType: "application/zip",
+			body:        []byte(big5),
+			opts:        internalhttp.ClientOptions{FallbackCharset: "big5"},
+			want:        big5,
+			wantType:    "application/zip",
+		},
+		{
+			name:            "deflate",
+			contentType:     "application/json",
+			contentEncoding: "deflate",
+			body:            deflated.Bytes(),
+			want:            `{"name":"ok"}`,
+			wantType:        "application/json",
+		},
+		{
+			name:        "decoding disabled",
+			contentType: "text/csv; charset=big5",
+			body:        []byte(big5),
+			opts:        internalhttp.ClientOptions{DisableBodyDecoding: true},
+			want:        big5,
+			wantType:    "text/csv; charset=big5",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				w.Header().Set("Content-Type", tt.contentType)
+				if tt.contentEncoding != "" {
+					w.Header().Set("Content-Encoding", tt.contentEncoding)
+				}
+				w.Write(tt.body)
+			}))
+			defer server.Close()
+
+			client := internalhttp.NewRetryableClient(&tt.opts)
+			req, _ := http.NewRequestWithContext(context.Background(), http.MethodGet, server.URL, nil)
+			resp, err := client.Do(req)
+			if err != nil {
+				t.Fatalf("Do() error = %v", err)
+			}
+			defer resp.Body.Close()
+
+			body, err := io.ReadAll(resp.Body)
+			if err != nil {
+				t.Fatalf("ReadAll() error = %v", err)
+			}
+			if string(body) != tt.want {
+				t.Errorf("body = %q, want %q", body, tt.want)
+			}
+			if got := resp.Header.Get("Content-Type"); got != tt.wantType {
+				t.Errorf("Content-Type = %q, want %q", got, tt.wantType)
+			}
+		})
+	}
+}
+
+func TestCheckCharset(t *testing.T) {
+	for _, name := range []string{"big5", "Big5", "iso-8859-1", "windows-1252", "utf-8", "shift_jis", "MS950"} {
+		if err := internalhttp.CheckCharset(name); err != nil {
+			t.Errorf("CheckCharset(%q) error = %v", name, err)
+		}
+	}
+	if err := internalhttp.CheckCharset("klingon"); !errors.Is(err, internalhttp.ErrUnknownCharset) {
+		t.Errorf("CheckCharset(klingon) error = %v, want ErrUnknownCharset", err)
+	}
+}
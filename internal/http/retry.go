@@ -2,23 +2,110 @@ package http
 
 import (
 	"bytes"
+	"context"
+	"errors"
+	"fmt"
 	"io"
 	"net/http"
+	"strconv"
+	"strings"
+	"sync"
 	"time"
 
 	"github.com/julianshen/gonp-datareader/internal/cache"
 	"github.com/julianshen/gonp-datareader/internal/ratelimit"
 )
 
+// ErrClientClosed is returned by requests made after Close or Shutdown.
+var ErrClientClosed = errors.New("client is closed")
+
+// ErrRetriesExhausted is matched by errors.Is for every *RetryError.
+var ErrRetriesExhausted = errors.New("retries exhausted")
+
+// RetryError is returned when a request still fails with a network error
+// or 5xx status after all attempts, recording how the attempts went so
+// transient flakiness can be told apart from a provider outage.
+type RetryError struct {
+	// Attempts is the number of requests made, including the first
+	Attempts int
+	// StatusCodes holds the status code of each attempt, 0 for attempts
+	// that failed without a response
+	StatusCodes []int
+	// Elapsed is the time from the first attempt to giving up, including
+	// backoff waits
+	Elapsed time.Duration
+	// Err is the error of the last attempt; nil when it returned a status
+	Err error
+}
+
+// Error implements the error interface.
+func (e *RetryError) Error() string {
+	statuses := make([]string, len(e.StatusCodes))
+	for i, code := range e.StatusCodes {
+		statuses[i] = "error"
+		if code != 0 {
+			statuses[i] = strconv.Itoa(code)
+		}
+	}
+	plural := "s"
+	if e.Attempts == 1 {
+		plural = ""
+	}
+	msg := fmt.Sprintf("retries exhausted after %d attempt%s in %s (status %s)",
+		e.Attempts, plural, e.Elapsed.Round(time.Millisecond), strings.Join(statuses, ", "))
+	if e.Err != nil {
+		msg += ": " + e.Err.Error()
+	}
+	return msg
+}
+
+// Is implements error matching for errors.Is.
+func (e *RetryError) Is(target error) bool {
+	return target == ErrRetriesExhausted
+}
+
+// Unwrap returns the error of the last attempt.
+func (e *RetryError) Unwrap() error {
+	return e.Err
+}
+
+// LastStatus returns the status code of the last attempt, or 0 when it
+// failed without a response.
+func (e *RetryError) LastStatus() int {
+	if len(e.StatusCodes) == 0 {
+		return 0
+	}
+	return e.StatusCodes[len(e.StatusCodes)-1]
+}
+
 // RetryableClient wraps an http.Client with retry logic.
 type RetryableClient struct {
-	client      *http.Client
-	maxRetries  int
-	retryDelay  time.Duration
-	userAgent   string
-	rateLimiter *ratelimit.RateLimiter
-	cache       *cache.FileCache
-	cacheTTL    time.Duration
+	client     *http.Client
+	maxRetries int
+	retryDelay time.Duration
+	userAgent  string
+	// maxRetryAfter is the longest Retry-After wait honored; negative
+	// honors none
+	maxRetryAfter time.Duration
+	rateLimiter   *ratelimit.RateLimiter
+	adaptive      *adaptiveLimiter
+	cache         *cache.FileCache
+	cacheTTL      time.Duration
+	serveStale    bool
+	memCache      *cache.MemoryCache
+	decoded       *cache.DecodedCache
+	onCacheHit    func(layer, key string)
+	onCacheMiss   func(key string)
+	source        string
+	fallback      string
+	rawBodies     bool
+
+	// closing is cancelled to abort in-flight requests on shutdown
+	closing   context.Context
+	cancelAll context.CancelFunc
+	mu        sync.Mutex
+	closed    bool
+	inflight  sync.WaitGroup
 }
 
 // NewRetryableClient creates a new HTTP client with retry logic.
@@ -27,107 +114,511 @@ func NewRetryableClient(opts *ClientOptions) *RetryableClient {
 		opts = DefaultClientOptions()
 	}
 
+	maxRetryAfter := opts.MaxRetryAfter
+	if maxRetryAfter == 0 {
+		maxRetryAfter = defaultMaxRetryAfter
+	}
+
 	// Create rate limiter if rate limit is configured
 	var limiter *ratelimit.RateLimiter
 	if opts.RateLimit > 0 {
-		// Use burst of 1 for strict rate limiting
-		limiter = ratelimit.NewRateLimiter(opts.RateLimit, 1)
+		// Default to a burst of 1 for strict rate limiting
+		burst := opts.RateBurst
+		if burst <= 0 {
+			burst = 1
+		}
+		initial := burst
+		if opts.RateInitialTokens != 0 {
+			initial = opts.RateInitialTokens
+		}
+		if opts.AdaptiveRateLimit {
+			limiter = ratelimit.NewAdaptiveRateLimiter(opts.RateLimit, burst, initial)
+		} else {
+			limiter = ratelimit.NewRateLimiterWithTokens(opts.RateLimit, burst, initial)
+		}
+	}
+
+	// Create the concurrency tuner if adaptive mode is enabled
+	var adaptive *adaptiveLimiter
+	if opts.AdaptiveConcurrency {
+		adaptive = newAdaptiveLimiter(opts.MaxConcurrency, opts.LatencyTarget, maxRetryAfter)
 	}
 
 	// Create cache if cache directory is configured
 	var fileCache *cache.FileCache
+	memSize := opts.MemoryCacheSize
 	if opts.CacheDir != "" {
-		fileCache = cache.NewFileCache(opts.CacheDir)
+		if fileCacheSupported {
+			fileCache = cache.NewFileCache(opts.CacheDir)
+		} else if memSize == 0 {
+			// No filesystem (js/wasm): keep responses in memory instead
+			memSize = fallbackMemoryCacheSize
+		}
 	}
 
+	// Create in-memory cache if a size is configured
+	memTTL := opts.MemoryCacheTTL
+	if memTTL == 0 {
+		memTTL = opts.CacheTTL
+	}
+	memCache := cache.NewMemoryCache(memSize, memTTL)
+
+	closing, cancelAll := context.WithCancel(context.Background())
+
 	return &RetryableClient{
-		client:      NewHTTPClient(opts),
-		maxRetries:  opts.MaxRetries,
-		retryDelay:  opts.RetryDelay,
-		userAgent:   opts.UserAgent,
-		rateLimiter: limiter,
-		cache:       fileCache,
-		cacheTTL:    opts.CacheTTL,
+		client:        NewHTTPClient(opts),
+		maxRetries:    opts.MaxRetries,
+		retryDelay:    opts.RetryDelay,
+		maxRetryAfter: maxRetryAfter,
+		userAgent:     opts.UserAgent,
+		rateLimiter:   limiter,
+		adaptive:      adaptive,
+		cache:         fileCache,
+		cacheTTL:      opts.CacheTTL,
+		serveStale:    opts.ServeStaleOnError,
+		memCache:      memCache,
+		decoded:       cache.NewDecodedCache(opts.DecodedCacheSize, opts.CacheTTL),
+		onCacheHit:    opts.OnCacheHit,
+		onCacheMiss:   opts.OnCacheMiss,
+		source:        opts.Source,
+		fallback:      opts.FallbackCharset,
+		rawBodies:     opts.DisableBodyDecoding,
+		closing:       closing,
+		cancelAll:     cancelAll,
 	}
 }
 
 // Do executes an HTTP request with retry logic.
+//
+// The request is tracked until its response body is closed, so Shutdown
+// can wait for it, and is cancelled if the client shuts down first.
 func (c *RetryableClient) Do(req *http.Request) (*http.Response, error) {
+	ctx, finish, err := c.begin(req.Context())
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := c.do(req.WithContext(ctx))
+	if err != nil || resp == nil {
+		finish()
+		return resp, err
+	}
+	resp.Body = &finishOnClose{ReadCloser: resp.Body, finish: finish}
+	if onBody := CallOptionsFrom(ctx).OnBody; onBody != nil && resp.StatusCode/100 == 2 {
+		resp.Body = &recordOnClose{ReadCloser: resp.Body, onBody: onBody}
+	}
+	return resp, nil
+}
+
+// Shutdown stops accepting requests and waits for in-flight requests to
+// finish (their response bodies to be closed). If ctx expires first, the
+// remaining requests are cancelled and ctx.Err() is returned. Idle
+// connections are closed in both cases. Responses are written to the file
+// cache synchronously, so there is nothing left to flush.
+func (c *RetryableClient) Shutdown(ctx context.Context) error {
+	c.mu.Lock()
+	c.closed = true
+	c.mu.Unlock()
+
+	drained := make(chan struct{})
+	go func() {
+		c.inflight.Wait()
+		close(drained)
+	}()
+
+	var err error
+	select {
+	case <-drained:
+	case <-ctx.Done():
+		err = ctx.Err()
+	}
+
+	c.cancelAll()
+	c.client.CloseIdleConnections()
+	return err
+}
+
+// Close cancels in-flight requests and releases idle connections
+// immediately. Later requests fail with ErrClientClosed.
+func (c *RetryableClient) Close() error {
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	if err := c.Shutdown(ctx); err != nil && !errors.Is(err, context.Canceled) {
+		return err
+	}
+	return nil
+}
+
+// begin registers an in-flight request, returning its context (cancelled
+// on shutdown or after the call's Timeout) and the func that releases it.
+func (c *RetryableClient) begin(parent context.Context) (context.Context, func(), error) {
+	c.mu.Lock()
+	if c.closed {
+		c.mu.Unlock()
+		return nil, nil, ErrClientClosed
+	}
+	c.inflight.Add(1)
+	c.mu.Unlock()
+
+	var ctx context.Context
+	var cancel context.CancelFunc
+	if timeout := CallOptionsFrom(parent).Timeout; timeout > 0 {
+		ctx, cancel = context.WithTimeout(parent, timeout)
+	} else {
+		ctx, cancel = context.WithCancel(parent)
+	}
+	stop := context.AfterFunc(c.closing, cancel)
+
+	var once sync.Once
+	finish := func() {
+		once.Do(func() {
+			stop()
+			cancel()
+			c.inflight.Done()
+		})
+	}
+	return ctx, finish, nil
+}
+
+// finishOnClose releases an in-flight request when its body is closed.
+type finishOnClose struct {
+	io.ReadCloser
+	finish func()
+}
+
+func (b *finishOnClose) Close() error {
+	err := b.ReadCloser.Close()
+	b.finish()
+	return err
+}
+
+// recordOnClose passes the bytes read from a body to CallOptions.OnBody
+// when the body is closed.
+type recordOnClose struct {
+	io.ReadCloser
+	onBody func([]byte)
+	buf    bytes.Buffer
+	once   sync.Once
+}
+
+func (b *recordOnClose) Read(p []byte) (int, error) {
+	n, err := b.ReadCloser.Read(p)
+	b.buf.Write(p[:n])
+	return n, err
+}
+
+func (b *recordOnClose) Close() error {
+	err := b.ReadCloser.Close()
+	b.once.Do(func() { b.onBody(b.buf.Bytes()) })
+	return err
+}
+
+// do executes req with caching and retries.
+func (c *RetryableClient) do(req *http.Request) (*http.Response, error) {
+	cacheable := req.Method == "GET" && (c.cache != nil || c.memCache != nil)
+	cacheKey := req.URL.String()
+
+	// A per-call cache TTL limits the age of cached responses the call
+	// accepts; 0 bypasses the cache
+	override := CallOptionsFrom(req.Context()).CacheTTL
+	if override != nil && *override <= 0 {
+		cacheable = false
+	}
+
 	// Check cache for GET requests
-	if c.cache != nil && req.Method == "GET" {
-		cacheKey := req.URL.String()
-		if data, found := c.cache.Get(cacheKey); found {
-			// Construct response from cached data
-			return &http.Response{
-				StatusCode: 200,
-				Body:       io.NopCloser(bytes.NewReader(data)),
-				Header:     make(http.Header),
-				Request:    req,
-			}, nil
+	var stale *cache.Entry
+	if cacheable && override != nil {
+		// The memory cache does not record when entries were stored, so
+		// only disk entries young enough for the call's TTL are served
+		if entry, found := c.cache.GetEntry(cacheKey); found {
+			if !entry.StoredAt.IsZero() && time.Since(entry.StoredAt) < *override && !entry.Expired() {
+				c.cacheHit("disk", cacheKey)
+				return cachedResponse(req, entry.Data), nil
+			}
+			if c.serveStale {
+				stale = &entry
+			}
+		}
+
+		if c.onCacheMiss != nil {
+			c.onCacheMiss(cacheKey)
+		}
+	} else if cacheable {
+		if data, found := c.memCache.Get(cacheKey); found {
+			c.cacheHit("memory", cacheKey)
+			return cachedResponse(req, data), nil
+		}
+
+		if c.serveStale {
+			// Keep expired entries around as a fallback for upstream failures
+			if entry, found := c.cache.GetEntry(cacheKey); found {
+				if !entry.Expired() {
+					c.memCache.SetUntil(cacheKey, entry.Data, entry.ExpiresAt)
+					c.cacheHit("disk", cacheKey)
+					return cachedResponse(req, entry.Data), nil
+				}
+				stale = &entry
+			}
+		} else if entry, found := c.cache.GetFresh(cacheKey); found {
+			// A promoted entry expires from memory when it does on disk
+			c.memCache.SetUntil(cacheKey, entry.Data, entry.ExpiresAt)
+			c.cacheHit("disk", cacheKey)
+			return cachedResponse(req, entry.Data), nil
+		}
+
+		if c.onCacheMiss != nil {
+			c.onCacheMiss(cacheKey)
 		}
 	}
 
 	var resp *http.Response
 	var err error
 
+	began := time.Now()
+	statuses := make([]int, 0, c.maxRetries+1)
+	cancelled := false
 	for attempt := 0; attempt <= c.maxRetries; attempt++ {
+		var body io.ReadCloser
+		if attempt > 0 && req.GetBody != nil {
+			if body, err = req.GetBody(); err != nil {
+				return nil, fmt.Errorf("replay request body: %w", err)
+			}
+		}
+
+		// Wait for a request slot in adaptive mode
+		if c.adaptive != nil {
+			if err := c.adaptive.acquire(req.Context()); err != nil {
+				return nil, err
+			}
+		}
+
 		// Apply rate limiting before making request
 		if c.rateLimiter != nil {
 			if err := c.rateLimiter.Wait(req.Context()); err != nil {
+				if c.adaptive != nil {
+					c.adaptive.abort()
+				}
 				return nil, err
 			}
 		}
 
-		// Clone the request for retry attempts
+		// Clone the request for retry attempts, replaying the body of
+		// requests such as POST queries
 		reqClone := req.Clone(req.Context())
+		if attempt > 0 && req.GetBody != nil {
+			reqClone.Body = body
+		}
 
 		// Set User-Agent header if configured
 		if c.userAgent != "" {
 			reqClone.Header.Set("User-Agent", c.userAgent)
 		}
 
+		sent := time.Now()
 		resp, err = c.client.Do(reqClone)
+		if c.adaptive != nil {
+			c.adaptive.release(sent, resp)
+		}
+		if throttled(resp) {
+			c.rateLimiter.Throttle(sent)
+		} else if resp != nil && resp.StatusCode < http.StatusInternalServerError {
+			c.rateLimiter.Recover()
+		}
+		if resp != nil {
+			statuses = append(statuses, resp.StatusCode)
+		} else {
+			statuses = append(statuses, 0)
+		}
 
 		// Check if we should retry
 		if !ShouldRetry(resp, err) {
 			break
 		}
+		delay, ok := c.retryWait(resp, attempt)
+		if !ok {
+			break
+		}
 
 		// Don't sleep after the last attempt
 		if attempt < c.maxRetries {
-			time.Sleep(c.retryDelay * time.Duration(attempt+1))
+			if waitErr := sleep(req.Context(), delay); waitErr != nil {
+				if resp != nil {
+					_ = resp.Body.Close()
+				}
+				resp, err = nil, waitErr
+				cancelled = true
+				break
+			}
+			// Release the connection of the response being retried
+			if resp != nil {
+				_ = resp.Body.Close()
+			}
+		}
+	}
+
+	// A final 429 is returned as is, so readers report it as a
+	// RateLimitError carrying its Retry-After
+	failed := ShouldRetry(resp, err) && (resp == nil || resp.StatusCode != http.StatusTooManyRequests)
+
+	// Fall back to the expired cache entry if the upstream is unavailable
+	if stale != nil && failed {
+		if resp != nil {
+			_ = resp.Body.Close()
+		}
+		staleResp := cachedResponse(req, stale.Data)
+		staleResp.Header.Set(StaleHeader, "true")
+		if !stale.StoredAt.IsZero() {
+			staleResp.Header.Set(CachedAtHeader, stale.StoredAt.UTC().Format(time.RFC3339))
 		}
+		return staleResp, nil
 	}
 
-	// Store successful GET responses in cache
-	if c.cache != nil && err == nil && resp != nil && resp.StatusCode == 200 && req.Method == "GET" {
+	// Report the attempts once the upstream still fails after all of them;
+	// a cancelled backoff wait above is returned as is
+	if !cancelled && failed {
+		if resp != nil {
+			_ = resp.Body.Close()
+		}
+		return nil, &RetryError{
+			Attempts:    len(statuses),
+			StatusCodes: statuses,
+			Elapsed:     time.Since(began),
+			Err:         err,
+		}
+	}
+
+	// Decompress and convert the body to UTF-8 before it is cached
+	if err == nil && resp != nil && !c.rawBodies {
+		if decodeErr := decodeBody(resp, c.fallback); decodeErr != nil {
+			_ = resp.Body.Close()
+			return nil, decodeErr
+		}
+	}
+
+	// Check the type of successful responses and store GET responses in
+	// cache
+	expected := expectedContentType(req.Context())
+	if (cacheable || len(expected) > 0) && err == nil && resp != nil && resp.StatusCode == 200 {
 		// Read the response body
 		body, readErr := io.ReadAll(resp.Body)
 		_ = resp.Body.Close() // Ignore close error as we've already read the body
+		if readErr != nil {
+			return nil, readErr
+		}
 
-		if readErr == nil {
-			// Store in cache (ignore error as cache is best-effort)
-			cacheKey := req.URL.String()
-			//nolint:errcheck // Cache is best-effort, errors are acceptable
-			c.cache.Set(cacheKey, body, c.cacheTTL)
+		// Reject HTML consent, login or error pages before they are
+		// cached or parsed
+		if len(expected) > 0 {
+			if typeErr := CheckContentType(resp, body, expected...); typeErr != nil {
+				return nil, typeErr
+			}
+		}
 
-			// Replace body with new reader for caller
-			resp.Body = io.NopCloser(bytes.NewReader(body))
-		} else {
-			// If we couldn't read the body, return the error
-			return nil, readErr
+		if cacheable {
+			// Store in cache (ignore error as cache is best-effort)
+			c.memCache.Set(cacheKey, body)
+			if c.cache != nil {
+				//nolint:errcheck // Cache is best-effort, errors are acceptable
+				c.cache.SetLabeled(cacheKey, body, c.cacheTTL, cacheLabel(req.Context(), c.source))
+			}
 		}
+
+		// Replace body with new reader for caller
+		resp.Body = io.NopCloser(bytes.NewReader(body))
 	}
 
 	// Return the last response/error
 	return resp, err
 }
 
+// Decode returns the value decoded from body, reusing the result of an
+// earlier decode of byte-identical content when DecodedCacheSize is set.
+// The kind names the decoder. Returned values may be shared between calls
+// and must be treated as read-only.
+func (c *RetryableClient) Decode(kind string, body []byte, decode func([]byte) (interface{}, error)) (interface{}, error) {
+	return c.decoded.Decode(kind, body, decode)
+}
+
+// cacheHit reports a cache hit to the configured hook.
+func (c *RetryableClient) cacheHit(layer, key string) {
+	if c.onCacheHit != nil {
+		c.onCacheHit(layer, key)
+	}
+}
+
+// cachedResponse constructs a 200 response from cached data.
+func cachedResponse(req *http.Request, data []byte) *http.Response {
+	return &http.Response{
+		StatusCode: 200,
+		Body:       io.NopCloser(bytes.NewReader(data)),
+		Header:     make(http.Header),
+		Request:    req,
+	}
+}
+
+// Throttled reports that the provider throttled a request sent at sent
+// with a successful status, such as a quota message in the body, so an
+// adaptive rate limiter (ClientOptions.AdaptiveRateLimit) backs off as it
+// does for 429 responses.
+func (c *RetryableClient) Throttled(sent time.Time) {
+	c.rateLimiter.Throttle(sent)
+}
+
+// RateLimit returns the client's current rate limit in requests per
+// second, which changes under ClientOptions.AdaptiveRateLimit; +Inf when
+// unlimited.
+func (c *RetryableClient) RateLimit() float64 {
+	return c.rateLimiter.Limit()
+}
+
+// throttled reports whether resp rejects a request for its rate: a 429 or
+// 503 status.
+func throttled(resp *http.Response) bool {
+	return resp != nil &&
+		(resp.StatusCode == http.StatusTooManyRequests || resp.StatusCode == http.StatusServiceUnavailable)
+}
+
+// defaultMaxRetryAfter is the longest Retry-After wait honored by default.
+const defaultMaxRetryAfter = 30 * time.Second
+
+// retryWait returns how long to wait before retrying after attempt: the
+// Retry-After of 429 and 503 responses, otherwise a backoff growing with
+// each attempt. It returns false when the server asks for a longer wait
+// than the client's MaxRetryAfter, so the response is returned instead.
+func (c *RetryableClient) retryWait(resp *http.Response, attempt int) (time.Duration, bool) {
+	backoff := c.retryDelay * time.Duration(attempt+1)
+	if !throttled(resp) {
+		return backoff, true
+	}
+
+	wait := RetryAfter(resp.Header, time.Now())
+	if wait <= 0 {
+		return backoff, true
+	}
+	if c.maxRetryAfter < 0 || wait > c.maxRetryAfter {
+		return 0, false
+	}
+	return wait, true
+}
+
+// sleep waits for d or until ctx is done.
+func sleep(ctx context.Context, d time.Duration) error {
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-timer.C:
+		return nil
+	}
+}
+
 // ShouldRetry determines if a request should be retried based on the response or error.
 func ShouldRetry(resp *http.Response, err error) bool {
-	// Retry on network errors
+	// Retry on network errors, but not on redirect loops
 	if err != nil {
-		return true
+		return !errors.Is(err, ErrTooManyRedirects)
 	}
 
 	// Retry on nil response (shouldn't happen but be defensive)
@@ -135,11 +626,11 @@ func ShouldRetry(resp *http.Response, err error) bool {
 		return true
 	}
 
-	// Retry on 5xx server errors
-	if resp.StatusCode >= 500 && resp.StatusCode < 600 {
+	// Retry on 5xx server errors and rate limiting
+	if resp.StatusCode >= 500 && resp.StatusCode < 600 || resp.StatusCode == http.StatusTooManyRequests {
 		return true
 	}
 
-	// Don't retry on success or client errors (4xx)
+	// Don't retry on success or other client errors (4xx)
 	return false
 }
@@ -3,10 +3,13 @@ package http_test
 import (
 	"context"
 	"errors"
+	"fmt"
 	"io"
 	"net/http"
 	"net/http/httptest"
 	"os"
+	"strings"
+	"sync"
 	"sync/atomic"
 	"testing"
 	"time"
@@ -89,6 +92,44 @@ func TestRetryableClient_RetryOnError(t *testing.T) {
 	}
 }
 
+func TestRetryableClient_RetryReplaysBody(t *testing.T) {
+	var attempts atomic.Int32
+	var bodies []string
+
+	// Server that fails once, then succeeds, recording each request body
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		b, _ := io.ReadAll(r.Body)
+		bodies = append(bodies, string(b))
+		if attempts.Add(1) < 2 {
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	client := internalhttp.NewRetryableClient(&internalhttp.ClientOptions{
+		Timeout:    5 * time.Second,
+		MaxRetries: 3,
+		RetryDelay: 10 * time.Millisecond,
+	})
+
+	req, err := http.NewRequestWithContext(context.Background(), "POST", server.URL, strings.NewReader(`{"limit":10}`))
+	if err != nil {
+		t.Fatalf("Failed to create request: %v", err)
+	}
+
+	resp, err := client.Do(req)
+	if err != nil {
+		t.Fatalf("Request failed after retries: %v", err)
+	}
+	defer resp.Body.Close()
+
+	if len(bodies) != 2 || bodies[0] != `{"limit":10}` || bodies[1] != bodies[0] {
+		t.Errorf("request bodies = %q, want the body sent on every attempt", bodies)
+	}
+}
+
 func TestRetryableClient_MaxRetriesExceeded(t *testing.T) {
 	var attempts atomic.Int32
 
@@ -112,15 +153,22 @@ func TestRetryableClient_MaxRetriesExceeded(t *testing.T) {
 		t.Fatalf("Failed to create request: %v", err)
 	}
 
-	resp, err := client.Do(req)
-	if err != nil {
-		t.Fatalf("Request failed: %v", err)
-	}
-	defer resp.Body.Close()
+	_, err = client.Do(req)
 
-	// Should still return last response even after retries exhausted
-	if resp.StatusCode != http.StatusInternalServerError {
-		t.Errorf("Expected status 500, got %d", resp.StatusCode)
+	// Should report the attempts once retries are exhausted
+	var retryErr *internalhttp.RetryError
+	if !errors.As(err, &retryErr) {
+		t.Fatalf("Expected *RetryError, got %v", err)
+	}
+	if !errors.Is(err, internalhttp.ErrRetriesExhausted) {
+		t.Errorf("errors.Is(err, ErrRetriesExhausted) = false")
+	}
+	if retryErr.Attempts != 3 || len(retryErr.StatusCodes) != 3 || retryErr.LastStatus() != http.StatusInternalServerError {
+		t.Errorf("RetryError = %+v, want 3 attempts with status 500", retryErr)
+	}
+	// Backoff waits of 10ms and 20ms are included in the elapsed time
+	if retryErr.Elapsed < 30*time.Millisecond {
+		t.Errorf("Elapsed = %v, want >= 30ms", retryErr.Elapsed)
 	}
 
 	// Should attempt: initial + 2 retries = 3 total
@@ -317,6 +365,162 @@ func TestRetryableClient_NoRateLimiter(t *testing.T) {
 	}
 }
 
+func TestRetryableClient_HonorsRetryAfter(t *testing.T) {
+	for _, status := range []int{http.StatusTooManyRequests, http.StatusServiceUnavailable} {
+		t.Run(http.StatusText(status), func(t *testing.T) {
+			var attempts atomic.Int32
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				if attempts.Add(1) == 1 {
+					w.Header().Set("Retry-After", "1")
+					w.WriteHeader(status)
+					return
+				}
+				w.Write([]byte("ok"))
+			}))
+			defer server.Close()
+
+			client := internalhttp.NewRetryableClient(&internalhttp.ClientOptions{
+				Timeout:    5 * time.Second,
+				MaxRetries: 2,
+				RetryDelay: time.Millisecond,
+			})
+			req, _ := http.NewRequest("GET", server.URL, nil)
+
+			began := time.Now()
+			resp, err := client.Do(req)
+			if err != nil {
+				t.Fatalf("Do() error = %v", err)
+			}
+			resp.Body.Close()
+
+			if resp.StatusCode != http.StatusOK || attempts.Load() != 2 {
+				t.Errorf("status %d after %d attempts, want 200 after 2", resp.StatusCode, attempts.Load())
+			}
+			if elapsed := time.Since(began); elapsed < time.Second {
+				t.Errorf("retried after %v, want the 1s Retry-After instead of RetryDelay", elapsed)
+			}
+		})
+	}
+}
+
+func TestRetryableClient_RetryAfterTooLong(t *testing.T) {
+	var attempts atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		attempts.Add(1)
+		w.Header().Set("Retry-After", time.Now().Add(time.Hour).UTC().Format(http.TimeFormat))
+		w.WriteHeader(http.StatusTooManyRequests)
+	}))
+	defer server.Close()
+
+	client := internalhttp.NewRetryableClient(&internalhttp.ClientOptions{
+		Timeout:       5 * time.Second,
+		MaxRetries:    3,
+		RetryDelay:    time.Millisecond,
+		MaxRetryAfter: time.Minute,
+	})
+	req, _ := http.NewRequest("GET", server.URL, nil)
+	resp, err := client.Do(req)
+	if err != nil {
+		t.Fatalf("Do() error = %v, want the 429 response", err)
+	}
+	resp.Body.Close()
+
+	// The response is returned at once so readers can report its wait
+	if resp.StatusCode != http.StatusTooManyRequests || attempts.Load() != 1 {
+		t.Errorf("status %d after %d attempts, want 429 after 1", resp.StatusCode, attempts.Load())
+	}
+	if wait := internalhttp.RetryAfter(resp.Header, time.Now()); wait < 59*time.Minute {
+		t.Errorf("RetryAfter() = %v, want about an hour", wait)
+	}
+}
+
+func TestRetryableClient_RetryAfterContext(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Retry-After", "20")
+		w.WriteHeader(http.StatusTooManyRequests)
+	}))
+	defer server.Close()
+
+	client := internalhttp.NewRetryableClient(&internalhttp.ClientOptions{
+		Timeout:    5 * time.Second,
+		MaxRetries: 3,
+	})
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+	req, _ := http.NewRequestWithContext(ctx, "GET", server.URL, nil)
+
+	began := time.Now()
+	_, err := client.Do(req)
+	if !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("Do() error = %v, want the context's deadline", err)
+	}
+	if elapsed := time.Since(began); elapsed > 5*time.Second {
+		t.Errorf("Do() returned after %v, want the Retry-After wait cut short", elapsed)
+	}
+}
+
+func TestRetryableClient_RateLimitedAfterRetries(t *testing.T) {
+	var attempts atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		attempts.Add(1)
+		w.WriteHeader(http.StatusTooManyRequests)
+	}))
+	defer server.Close()
+
+	client := internalhttp.NewRetryableClient(&internalhttp.ClientOptions{
+		Timeout:    5 * time.Second,
+		MaxRetries: 2,
+		RetryDelay: time.Millisecond,
+	})
+	req, _ := http.NewRequest("GET", server.URL, nil)
+	resp, err := client.Do(req)
+	if err != nil {
+		t.Fatalf("Do() error = %v, want the last 429 response", err)
+	}
+	resp.Body.Close()
+	if resp.StatusCode != http.StatusTooManyRequests || attempts.Load() != 3 {
+		t.Errorf("status %d after %d attempts, want 429 after 3", resp.StatusCode, attempts.Load())
+	}
+}
+
+func TestRetryableClient_AdaptiveRateLimit(t *testing.T) {
+	var attempts atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if attempts.Add(1) == 1 {
+			w.WriteHeader(http.StatusTooManyRequests)
+			return
+		}
+		w.Write([]byte("ok"))
+	}))
+	defer server.Close()
+
+	client := internalhttp.NewRetryableClient(&internalhttp.ClientOptions{
+		Timeout:           5 * time.Second,
+		MaxRetries:        1,
+		RetryDelay:        time.Millisecond,
+		RateLimit:         100,
+		AdaptiveRateLimit: true,
+	})
+	req, _ := http.NewRequest("GET", server.URL, nil)
+	resp, err := client.Do(req)
+	if err != nil {
+		t.Fatalf("Do() error = %v", err)
+	}
+	resp.Body.Close()
+
+	// Halved by the 429, then raised by a step for the 200
+	if got := client.RateLimit(); got != 55 {
+		t.Errorf("RateLimit() = %v, want 55", got)
+	}
+
+	// Quota messages in successful responses back off too
+	time.Sleep(time.Millisecond)
+	client.Throttled(time.Now())
+	if got := client.RateLimit(); got != 27.5 {
+		t.Errorf("RateLimit() after Throttled = %v, want 27.5", got)
+	}
+}
+
 func TestShouldRetry(t *testing.T) {
 	tests := []struct {
 		name       string
@@ -344,6 +548,11 @@ func TestShouldRetry(t *testing.T) {
 			statusCode: http.StatusGatewayTimeout,
 			want:       true,
 		},
+		{
+			name:       "retry on 429",
+			statusCode: http.StatusTooManyRequests,
+			want:       true,
+		},
 		{
 			name:       "no retry on 404",
 			statusCode: http.StatusNotFound,
@@ -599,3 +808,574 @@ func TestRetryableClient_CacheTTL(t *testing.T) {
 		t.Errorf("Expected 2 requests (cache expired), got %d", requestCount.Load())
 	}
 }
+
+func TestRetryableClient_ServeStaleOnError(t *testing.T) {
+	var failing atomic.Bool
+	var requestCount atomic.Int32
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requestCount.Add(1)
+		if failing.Load() {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		w.WriteHeader(http.StatusOK)
+		w.Write([]byte("fresh response"))
+	}))
+	defer server.Close()
+
+	tests := []struct {
+		name       string
+		serveStale bool
+		wantStatus int
+		wantStale  bool
+	}{
+		{name: "disabled", serveStale: false, wantStatus: http.StatusServiceUnavailable},
+		{name: "enabled", serveStale: true, wantStatus: http.StatusOK, wantStale: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			failing.Store(false)
+
+			client := internalhttp.NewRetryableClient(&internalhttp.ClientOptions{
+				Timeout:           5 * time.Second,
+				MaxRetries:        0,
+				CacheDir:          t.TempDir(),
+				CacheTTL:          50 * time.Millisecond,
+				ServeStaleOnError: tt.serveStale,
+			})
+
+			// Populate the cache, then let the entry expire
+			req, _ := http.NewRequestWithContext(context.Background(), "GET", server.URL+"/"+tt.name, nil)
+			resp, err := client.Do(req)
+			if err != nil {
+				t.Fatalf("First request failed: %v", err)
+			}
+			resp.Body.Close()
+			time.Sleep(100 * time.Millisecond)
+
+			failing.Store(true)
+			before := requestCount.Load()
+
+			req, _ = http.NewRequestWithContext(context.Background(), "GET", server.URL+"/"+tt.name, nil)
+			resp, err = client.Do(req)
+
+			if requestCount.Load() != before+1 {
+				t.Errorf("Expected upstream to be tried once, got %d requests", requestCount.Load()-before)
+			}
+			if !tt.wantStale {
+				// Without a stale entry the failure is reported as a RetryError
+				var retryErr *internalhttp.RetryError
+				if !errors.As(err, &retryErr) || retryErr.LastStatus() != tt.wantStatus {
+					t.Errorf("Second request error = %v, want RetryError with status %d", err, tt.wantStatus)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Second request failed: %v", err)
+			}
+			body, _ := io.ReadAll(resp.Body)
+			resp.Body.Close()
+
+			if resp.StatusCode != tt.wantStatus {
+				t.Errorf("Expected status %d, got %d", tt.wantStatus, resp.StatusCode)
+			}
+			if !internalhttp.IsStale(resp) {
+				t.Error("IsStale() = false, want true")
+			}
+
+			if string(body) != "fresh response" {
+				t.Errorf("Expected stale body 'fresh response', got %q", string(body))
+			}
+			meta := internalhttp.StaleMeta(resp)
+			if meta["stale"] != "true" || meta["cached_at"] == "" {
+				t.Errorf("Unexpected stale meta: %v", meta)
+			}
+		})
+	}
+}
+
+func TestRetryableClient_ServeStaleNetworkError(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+		w.Write([]byte("cached"))
+	}))
+
+	client := internalhttp.NewRetryableClient(&internalhttp.ClientOptions{
+		Timeout:           time.Second,
+		MaxRetries:        0,
+		CacheDir:          t.TempDir(),
+		CacheTTL:          time.Nanosecond,
+		ServeStaleOnError: true,
+	})
+
+	url := server.URL + "/data"
+	req, _ := http.NewRequestWithContext(context.Background(), "GET", url, nil)
+	resp, err := client.Do(req)
+	if err != nil {
+		t.Fatalf("First request failed: %v", err)
+	}
+	resp.Body.Close()
+
+	// Shut down the upstream so the next request fails at the network level
+	server.Close()
+
+	req, _ = http.NewRequestWithContext(context.Background(), "GET", url, nil)
+	resp, err = client.Do(req)
+	if err != nil {
+		t.Fatalf("Expected stale response, got error: %v", err)
+	}
+	defer resp.Body.Close()
+
+	if !internalhttp.IsStale(resp) {
+		t.Error("Expected response to be marked stale")
+	}
+}
+
+func TestStaleMeta_FreshResponse(t *testing.T) {
+	resp := &http.Response{Header: make(http.Header)}
+	if meta := internalhttp.StaleMeta(resp); meta != nil {
+		t.Errorf("Expected nil meta for fresh response, got %v", meta)
+	}
+	if internalhttp.StaleMeta(nil) != nil {
+		t.Error("Expected nil meta for nil response")
+	}
+}
+
+func TestRetryableClient_MemoryCache(t *testing.T) {
+	var requestCount atomic.Int32
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requestCount.Add(1)
+		w.WriteHeader(http.StatusOK)
+		w.Write([]byte("response"))
+	}))
+	defer server.Close()
+
+	var mu sync.Mutex
+	var events []string
+
+	cacheDir := t.TempDir()
+	newClient := func() *internalhttp.RetryableClient {
+		return internalhttp.NewRetryableClient(&internalhttp.ClientOptions{
+			Timeout:         5 * time.Second,
+			CacheDir:        cacheDir,
+			CacheTTL:        time.Hour,
+			MemoryCacheSize: 10,
+			OnCacheHit: func(layer, key string) {
+				mu.Lock()
+				events = append(events, "hit:"+layer)
+				mu.Unlock()
+			},
+			OnCacheMiss: func(key string) {
+				mu.Lock()
+				events = append(events, "miss")
+				mu.Unlock()
+			},
+		})
+	}
+
+	get := func(client *internalhttp.RetryableClient) {
+		t.Helper()
+		req, _ := http.NewRequestWithContext(context.Background(), "GET", server.URL+"/test", nil)
+		resp, err := client.Do(req)
+		if err != nil {
+			t.Fatalf("Request failed: %v", err)
+		}
+		body, _ := io.ReadAll(resp.Body)
+		resp.Body.Close()
+		if string(body) != "response" {
+			t.Errorf("Expected 'response', got %q", string(body))
+		}
+	}
+
+	client := newClient()
+	get(client) // upstream
+	get(client) // memory
+
+	// A new client shares the disk cache but starts with an empty memory cache
+	other := newClient()
+	get(other) // disk
+	get(other) // memory
+
+	if requestCount.Load() != 1 {
+		t.Errorf("Expected 1 server request, got %d", requestCount.Load())
+	}
+
+	want := []string{"miss", "hit:memory", "hit:disk", "hit:memory"}
+	mu.Lock()
+	defer mu.Unlock()
+	if len(events) != len(want) {
+		t.Fatalf("Expected events %v, got %v", want, events)
+	}
+	for i := range want {
+		if events[i] != want[i] {
+			t.Errorf("Event %d: expected %q, got %q", i, want[i], events[i])
+		}
+	}
+}
+
+func TestRetryableClient_MemoryCachePromotionKeepsDiskExpiry(t *testing.T) {
+	var requestCount atomic.Int32
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requestCount.Add(1)
+		w.WriteHeader(http.StatusOK)
+		w.Write([]byte("response"))
+	}))
+	defer server.Close()
+
+	cacheDir := t.TempDir()
+	newClient := func() *internalhttp.RetryableClient {
+		return internalhttp.NewRetryableClient(&internalhttp.ClientOptions{
+			Timeout:         5 * time.Second,
+			CacheDir:        cacheDir,
+			CacheTTL:        200 * time.Millisecond,
+			MemoryCacheSize: 10,
+			MemoryCacheTTL:  time.Hour,
+		})
+	}
+
+	get := func(client *internalhttp.RetryableClient) {
+		t.Helper()
+		req, _ := http.NewRequestWithContext(context.Background(), "GET", server.URL+"/test", nil)
+		resp, err := client.Do(req)
+		if err != nil {
+			t.Fatalf("Request failed: %v", err)
+		}
+		resp.Body.Close()
+	}
+
+	get(newClient()) // upstream, cached on disk
+
+	// Promoted from disk shortly before the disk entry expires
+	other := newClient()
+	time.Sleep(100 * time.Millisecond)
+	get(other)
+	time.Sleep(150 * time.Millisecond)
+	get(other)
+
+	if requestCount.Load() != 2 {
+		t.Errorf("Expected 2 server requests (promoted entry expired with the disk entry), got %d", requestCount.Load())
+	}
+}
+
+func TestRetryableClient_MemoryCacheWithoutDisk(t *testing.T) {
+	var requestCount atomic.Int32
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requestCount.Add(1)
+		w.WriteHeader(http.StatusOK)
+		w.Write([]byte("response"))
+	}))
+	defer server.Close()
+
+	client := internalhttp.NewRetryableClient(&internalhttp.ClientOptions{
+		Timeout:         5 * time.Second,
+		MemoryCacheSize: 1,
+	})
+
+	for _, path := range []string{"/a", "/a", "/b", "/a"} {
+		req, _ := http.NewRequestWithContext(context.Background(), "GET", server.URL+path, nil)
+		resp, err := client.Do(req)
+		if err != nil {
+			t.Fatalf("Request failed: %v", err)
+		}
+		resp.Body.Close()
+	}
+
+	// "/a" is cached once, then evicted by "/b"
+	if requestCount.Load() != 3 {
+		t.Errorf("Expected 3 server requests, got %d", requestCount.Load())
+	}
+}
+
+func TestRetryableClient_Decode(t *testing.T) {
+	tests := []struct {
+		name      string
+		size      int
+		wantCalls int
+	}{
+		{name: "disabled", size: 0, wantCalls: 3},
+		{name: "enabled", size: 10, wantCalls: 1},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			client := internalhttp.NewRetryableClient(&internalhttp.ClientOptions{
+				DecodedCacheSize: tt.size,
+			})
+
+			calls := 0
+			decode := func(b []byte) (interface{}, error) {
+				calls++
+				return string(b), nil
+			}
+
+			for i := 0; i < 3; i++ {
+				v, err := client.Decode("test", []byte("body"), decode)
+				if err != nil {
+					t.Fatalf("Decode failed: %v", err)
+				}
+				if v.(string) != "body" {
+					t.Errorf("Expected 'body', got %v", v)
+				}
+			}
+
+			if calls != tt.wantCalls {
+				t.Errorf("Expected %d decode calls, got %d", tt.wantCalls, calls)
+			}
+		})
+	}
+}
+
+func TestRetryableClient_Shutdown_DrainsInFlight(t *testing.T) {
+	release := make(chan struct{})
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		<-release
+		w.Write([]byte("done"))
+	}))
+	defer server.Close()
+
+	client := internalhttp.NewRetryableClient(&internalhttp.ClientOptions{Timeout: 5 * time.Second})
+
+	type result struct {
+		body string
+		err  error
+	}
+	results := make(chan result, 1)
+	go func() {
+		req, _ := http.NewRequest("GET", server.URL, nil)
+		resp, err := client.Do(req)
+		if err != nil {
+			results <- result{err: err}
+			return
+		}
+		defer resp.Body.Close()
+		body, err := io.ReadAll(resp.Body)
+		results <- result{body: string(body), err: err}
+	}()
+
+	// Give the request time to reach the server before shutting down
+	time.Sleep(50 * time.Millisecond)
+
+	shutdownDone := make(chan error, 1)
+	go func() { shutdownDone <- client.Shutdown(context.Background()) }()
+
+	select {
+	case <-shutdownDone:
+		t.Fatal("Shutdown() returned before the in-flight request finished")
+	case <-time.After(50 * time.Millisecond):
+	}
+
+	close(release)
+	if r := <-results; r.err != nil || r.body != "done" {
+		t.Errorf("in-flight request = %q, %v, want done", r.body, r.err)
+	}
+	if err := <-shutdownDone; err != nil {
+		t.Errorf("Shutdown() error = %v", err)
+	}
+
+	req, _ := http.NewRequest("GET", server.URL, nil)
+	if _, err := client.Do(req); !errors.Is(err, internalhttp.ErrClientClosed) {
+		t.Errorf("Do() after Shutdown() error = %v, want ErrClientClosed", err)
+	}
+}
+
+func TestRetryableClient_Shutdown_CancelsOnTimeout(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		<-r.Context().Done()
+	}))
+	defer server.Close()
+
+	client := internalhttp.NewRetryableClient(&internalhttp.ClientOptions{Timeout: 10 * time.Second})
+
+	errc := make(chan error, 1)
+	go func() {
+		req, _ := http.NewRequest("GET", server.URL, nil)
+		resp, err := client.Do(req)
+		if err == nil {
+			resp.Body.Close()
+		}
+		errc <- err
+	}()
+	time.Sleep(50 * time.Millisecond)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+	if err := client.Shutdown(ctx); !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("Shutdown() error = %v, want DeadlineExceeded", err)
+	}
+
+	select {
+	case err := <-errc:
+		if err == nil {
+			t.Error("in-flight request succeeded, want cancellation")
+		}
+	case <-time.After(2 * time.Second):
+		t.Fatal("in-flight request was not cancelled")
+	}
+}
+
+func TestRetryableClient_Close_AbortsRetryWait(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}))
+	defer server.Close()
+
+	client := internalhttp.NewRetryableClient(&internalhttp.ClientOptions{
+		MaxRetries: 3,
+		RetryDelay: 10 * time.Second,
+	})
+
+	errc := make(chan error, 1)
+	go func() {
+		req, _ := http.NewRequest("GET", server.URL, nil)
+		_, err := client.Do(req)
+		errc <- err
+	}()
+	time.Sleep(50 * time.Millisecond)
+
+	if err := client.Close(); err != nil {
+		t.Fatalf("Close() error = %v", err)
+	}
+
+	select {
+	case err := <-errc:
+		if !errors.Is(err, context.Canceled) {
+			t.Errorf("Do() error = %v, want context.Canceled", err)
+		}
+	case <-time.After(2 * time.Second):
+		t.Fatal("Close() did not abort the retry wait")
+	}
+}
+
+func TestRetryError_NetworkError(t *testing.T) {
+	// A closed server refuses connections
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+	url := server.URL
+	server.Close()
+
+	client := internalhttp.NewRetryableClient(&internalhttp.ClientOptions{
+		Timeout:    time.Second,
+		MaxRetries: 1,
+		RetryDelay: time.Millisecond,
+	})
+
+	req, _ := http.NewRequest("GET", url, nil)
+	_, err := client.Do(req)
+
+	var retryErr *internalhttp.RetryError
+	if !errors.As(err, &retryErr) {
+		t.Fatalf("Expected *RetryError, got %v", err)
+	}
+	if retryErr.Err == nil || errors.Unwrap(err) != retryErr.Err {
+		t.Errorf("Unwrap() = %v, want the last network error", errors.Unwrap(err))
+	}
+
+	tests := []struct {
+		name string
+		got  interface{}
+		want interface{}
+	}{
+		{"Attempts", retryErr.Attempts, 2},
+		{"StatusCodes", fmt.Sprint(retryErr.StatusCodes), "[0 0]"},
+		{"LastStatus", retryErr.LastStatus(), 0},
+		{"message", strings.HasPrefix(err.Error(), "retries exhausted after 2 attempts in "), true},
+		{"statuses in message", strings.Contains(err.Error(), "(status error, error): "), true},
+	}
+	for _, tt := range tests {
+		if tt.got != tt.want {
+			t.Errorf("%s = %v, want %v", tt.name, tt.got, tt.want)
+		}
+	}
+}
+
+func TestRetryableClient_AdaptiveConcurrency(t *testing.T) {
+	var inflight, peak, throttled atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		n := inflight.Add(1)
+		defer inflight.Add(-1)
+		for {
+			p := peak.Load()
+			if n <= p || peak.CompareAndSwap(p, n) {
+				break
+			}
+		}
+		time.Sleep(10 * time.Millisecond)
+
+		// Throttle while more than two requests are in flight
+		if n > 2 {
+			throttled.Add(1)
+			w.WriteHeader(http.StatusTooManyRequests)
+			return
+		}
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	client := internalhttp.NewRetryableClient(&internalhttp.ClientOptions{
+		Timeout:             5 * time.Second,
+		AdaptiveConcurrency: true,
+		MaxConcurrency:      4,
+	})
+
+	var wg sync.WaitGroup
+	for i := 0; i < 20; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			req, _ := http.NewRequest("GET", server.URL, nil)
+			resp, err := client.Do(req)
+			if err != nil {
+				t.Errorf("Do() error = %v", err)
+				return
+			}
+			resp.Body.Close()
+		}()
+	}
+	wg.Wait()
+
+	if got := peak.Load(); got > 4 {
+		t.Errorf("peak concurrency = %d, want at most MaxConcurrency 4", got)
+	}
+	if got := throttled.Load(); got == 0 || got >= 10 {
+		t.Errorf("%d of 20 requests throttled, want some but fewer than at a fixed concurrency of 4", got)
+	}
+}
+
+func TestRetryableClient_OnBody(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/missing" {
+			w.WriteHeader(http.StatusNotFound)
+		}
+		w.Write([]byte("body of " + r.URL.Path))
+	}))
+	defer server.Close()
+
+	client := internalhttp.NewRetryableClient(&internalhttp.ClientOptions{Timeout: 5 * time.Second})
+
+	var got []string
+	ctx := internalhttp.WithCallOptions(context.Background(), internalhttp.CallOptions{
+		OnBody: func(body []byte) { got = append(got, string(body)) },
+	})
+
+	for _, path := range []string{"/ok", "/missing"} {
+		req, err := http.NewRequestWithContext(ctx, "GET", server.URL+path, nil)
+		if err != nil {
+			t.Fatalf("Failed to create request: %v", err)
+		}
+		resp, err := client.Do(req)
+		if err != nil {
+			t.Fatalf("Request failed: %v", err)
+		}
+		io.ReadAll(resp.Body)
+		resp.Body.Close()
+		resp.Body.Close()
+	}
+
+	if len(got) != 1 || got[0] != "body of /ok" {
+		t.Errorf("OnBody calls = %q, want the successful body once", got)
+	}
+}
@@ -0,0 +1,34 @@
+package http
+
+import "net/http"
+
+const (
+	// StaleHeader is set to "true" on responses served from an expired
+	// cache entry because the upstream request failed.
+	StaleHeader = "X-Datareader-Stale"
+
+	// CachedAtHeader holds the RFC 3339 time a stale response was cached.
+	CachedAtHeader = "X-Datareader-Cached-At"
+)
+
+// IsStale reports whether resp was served from an expired cache entry.
+func IsStale(resp *http.Response) bool {
+	return resp != nil && resp.Header.Get(StaleHeader) == "true"
+}
+
+// StaleMeta returns metadata describing a stale response, suitable for a
+// ParsedData Meta field. It returns nil for fresh responses.
+//
+// The returned map contains "stale" set to "true" and, when known,
+// "cached_at" with the time the response was originally cached.
+func StaleMeta(resp *http.Response) map[string]string {
+	if !IsStale(resp) {
+		return nil
+	}
+
+	meta := map[string]string{"stale": "true"}
+	if cachedAt := resp.Header.Get(CachedAtHeader); cachedAt != "" {
+		meta["cached_at"] = cachedAt
+	}
+	return meta
+}
@@ -0,0 +1,106 @@
+package http
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+	"time"
+)
+
+var (
+	// ErrSymbolNotFound is matched by errors.Is when the provider does not
+	// know the requested symbol, series or dataset.
+	ErrSymbolNotFound = errors.New("symbol not found")
+
+	// ErrAuthRequired is matched by errors.Is when the provider rejects the
+	// request for a missing or invalid API key or session.
+	ErrAuthRequired = errors.New("authentication required")
+
+	// ErrRateLimited is matched by errors.Is for every *RateLimitError.
+	ErrRateLimited = errors.New("rate limited")
+)
+
+// RateLimitError is returned when the provider rejects a request for
+// exceeding its rate limit or quota.
+type RateLimitError struct {
+	// StatusCode is the response status, usually 429; 0 when the limit was
+	// reported in the body of a successful response
+	StatusCode int
+	// RetryAfter is how long the provider asked to wait before retrying,
+	// from the Retry-After header; 0 when it did not say
+	RetryAfter time.Duration
+	// Err describes the rejection
+	Err error
+}
+
+// Error implements the error interface.
+func (e *RateLimitError) Error() string {
+	msg := "rate limited"
+	if e.Err != nil {
+		msg = e.Err.Error()
+	}
+	if e.RetryAfter > 0 {
+		msg += fmt.Sprintf(" (retry after %s)", e.RetryAfter)
+	}
+	return msg
+}
+
+// Is implements error matching for errors.Is.
+func (e *RateLimitError) Is(target error) bool {
+	return target == ErrRateLimited
+}
+
+// Unwrap returns the underlying error.
+func (e *RateLimitError) Unwrap() error {
+	return e.Err
+}
+
+// statusError keeps a reader's error message while matching the error
+// kind of the response status.
+type statusError struct {
+	err  error
+	kind error
+}
+
+func (e *statusError) Error() string   { return e.err.Error() }
+func (e *statusError) Unwrap() []error { return []error{e.err, e.kind} }
+
+// StatusError classifies err, a reader's error for the non-200 response
+// resp, so errors.Is matches ErrAuthRequired (401, 403), ErrSymbolNotFound
+// (404) or ErrRateLimited (429, as a *RateLimitError). The message of err
+// is kept; other statuses return err unchanged.
+func StatusError(resp *http.Response, err error) error {
+	switch resp.StatusCode {
+	case http.StatusUnauthorized, http.StatusForbidden:
+		return &statusError{err: err, kind: ErrAuthRequired}
+	case http.StatusNotFound:
+		return &statusError{err: err, kind: ErrSymbolNotFound}
+	case http.StatusTooManyRequests:
+		return &RateLimitError{
+			StatusCode: resp.StatusCode,
+			RetryAfter: RetryAfter(resp.Header, time.Now()),
+			Err:        err,
+		}
+	}
+	return err
+}
+
+// RetryAfter parses the Retry-After header, given either in seconds or
+// as an HTTP date, into a wait from now; 0 when absent or invalid.
+func RetryAfter(header http.Header, now time.Time) time.Duration {
+	value := header.Get("Retry-After")
+	if value == "" {
+		return 0
+	}
+	if seconds, err := strconv.Atoi(value); err == nil {
+		if seconds < 0 {
+			return 0
+		}
+		return time.Duration(seconds) * time.Second
+	}
+	if date, err := http.ParseTime(value); err == nil && date.After(now) {
+		return date.Sub(now)
+	}
+	return 0
+}
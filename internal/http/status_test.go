@@ -0,0 +1,93 @@
+package http_test
+
+import (
+	"errors"
+	"net/http"
+	"testing"
+	"time"
+
+	internalhttp "github.com/julianshen/gonp-datareader/internal/http"
+)
+
+func TestStatusError(t *testing.T) {
+	tests := []struct {
+		name   string
+		status int
+		want   error
+	}{
+		{name: "unauthorized", status: http.StatusUnauthorized, want: internalhttp.ErrAuthRequired},
+		{name: "forbidden", status: http.StatusForbidden, want: internalhttp.ErrAuthRequired},
+		{name: "not found", status: http.StatusNotFound, want: internalhttp.ErrSymbolNotFound},
+		{name: "too many requests", status: http.StatusTooManyRequests, want: internalhttp.ErrRateLimited},
+		{name: "bad request", status: http.StatusBadRequest},
+	}
+
+	kinds := []error{internalhttp.ErrAuthRequired, internalhttp.ErrSymbolNotFound, internalhttp.ErrRateLimited}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cause := errors.New("provider returned an error")
+			err := internalhttp.StatusError(&http.Response{StatusCode: tt.status, Header: http.Header{}}, cause)
+
+			if !errors.Is(err, cause) {
+				t.Errorf("StatusError() = %v, want it to wrap the reader's error", err)
+			}
+			for _, kind := range kinds {
+				if got := errors.Is(err, kind); got != (kind == tt.want) {
+					t.Errorf("errors.Is(err, %v) = %v, want %v", kind, got, kind == tt.want)
+				}
+			}
+			if tt.want != internalhttp.ErrRateLimited && err.Error() != cause.Error() {
+				t.Errorf("Error() = %q, want %q", err.Error(), cause.Error())
+			}
+		})
+	}
+}
+
+func TestStatusError_RetryAfter(t *testing.T) {
+	resp := &http.Response{
+		StatusCode: http.StatusTooManyRequests,
+		Header:     http.Header{"Retry-After": []string{"30"}},
+	}
+
+	err := internalhttp.StatusError(resp, errors.New("HTTP 429"))
+
+	var rateErr *internalhttp.RateLimitError
+	if !errors.As(err, &rateErr) {
+		t.Fatalf("StatusError() = %T, want *RateLimitError", err)
+	}
+	if rateErr.StatusCode != http.StatusTooManyRequests || rateErr.RetryAfter != 30*time.Second {
+		t.Errorf("RateLimitError = %+v, want status 429 and RetryAfter 30s", rateErr)
+	}
+	if err.Error() != "HTTP 429 (retry after 30s)" {
+		t.Errorf("Error() = %q", err.Error())
+	}
+}
+
+func TestRetryAfter(t *testing.T) {
+	now := time.Date(2025, 1, 2, 15, 4, 5, 0, time.UTC)
+
+	tests := []struct {
+		name  string
+		value string
+		want  time.Duration
+	}{
+		{name: "absent", value: "", want: 0},
+		{name: "seconds", value: "120", want: 2 * time.Minute},
+		{name: "negative seconds", value: "-5", want: 0},
+		{name: "HTTP date", value: now.Add(90 * time.Second).Format(http.TimeFormat), want: 90 * time.Second},
+		{name: "past HTTP date", value: now.Add(-time.Minute).Format(http.TimeFormat), want: 0},
+		{name: "invalid", value: "soon", want: 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			header := http.Header{}
+			if tt.value != "" {
+				header.Set("Retry-After", tt.value)
+			}
+			if got := internalhttp.RetryAfter(header, now); got != tt.want {
+				t.Errorf("RetryAfter(%q) = %v, want %v", tt.value, got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,26 @@
+//go:build !(js && wasm)
+
+package http
+
+import (
+	"net/http"
+	"time"
+)
+
+// fileCacheSupported reports whether CacheDir can be used on this platform.
+const fileCacheSupported = true
+
+// fallbackMemoryCacheSize is the memory cache size used in place of an
+// unsupported file cache; unused where the file cache is available.
+const fallbackMemoryCacheSize = 0
+
+// newTransport returns a pooled transport for native platforms, which
+// multiplexes concurrent requests to a host over HTTP/2 where supported.
+func newTransport() http.RoundTripper {
+	return &http.Transport{
+		ForceAttemptHTTP2:   true,
+		MaxIdleConns:        100,
+		MaxIdleConnsPerHost: 10,
+		IdleConnTimeout:     90 * time.Second,
+	}
+}
@@ -0,0 +1,19 @@
+//go:build js && wasm
+
+package http
+
+import "net/http"
+
+// fileCacheSupported reports whether CacheDir can be used on this platform.
+// Browsers have no filesystem, so CacheDir is ignored under js/wasm.
+const fileCacheSupported = false
+
+// fallbackMemoryCacheSize is the memory cache size used when CacheDir is
+// set but MemoryCacheSize is not, so caching stays enabled in the browser.
+const fallbackMemoryCacheSize = 256
+
+// newTransport returns a transport that sends requests through the
+// browser's Fetch API. Connection pooling is left to the browser.
+func newTransport() http.RoundTripper {
+	return &http.Transport{}
+}
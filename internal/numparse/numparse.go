@@ -0,0 +1,212 @@
+// Package numparse provides locale-tolerant parsing of numeric strings.
+//
+// Several upstream APIs (TWSE, Stooq regional files, HTML-derived endpoints)
+// return numbers formatted for display rather than for machines, e.g.
+// "1,234,567", "＋１２．５", "▲0.35" or "--" for a missing value. The helpers
+// in this package normalize such strings before handing them to strconv.
+package numparse
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"math/big"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+var (
+	// ErrMissing is returned when the input represents a missing value,
+	// such as an empty string or a dash placeholder ("-", "--", "—").
+	ErrMissing = errors.New("missing value")
+
+	// ErrOutOfRange is returned when a value does not fit the target type.
+	ErrOutOfRange = errors.New("value out of range")
+)
+
+// missingTokens lists placeholder strings that upstream sources use for
+// values that are not available (after whitespace trimming).
+var missingTokens = map[string]bool{
+	"":     true,
+	"-":    true,
+	"--":   true,
+	"---":  true,
+	"—":    true,
+	"——":   true,
+	"－":    true,
+	"－－":   true,
+	"N/A":  true,
+	"n/a":  true,
+	"NaN":  true,
+	"null": true,
+	"X":    true,
+}
+
+// grouped matches an unsigned number whose integer part is grouped in
+// thousands, after the separators are normalized to commas.
+var grouped = regexp.MustCompile(`^\d{1,3}(,\d{3})+(\.\d+)?$`)
+
+// ParseFloat parses a display-formatted number into a float64.
+//
+// It accepts:
+//   - thousands separators ("1,234.5", "1 234.5", "1，234.5"); separators
+//     elsewhere, such as the decimal comma of "1,5", are rejected rather
+//     than dropped
+//   - full-width digits, signs and decimal points ("１２３．４５", "－５")
+//   - leading sign markers including arrows ("▲0.35", "▼1.2", "+3", "−4")
+//   - scientific notation ("1.5e9")
+//
+// Empty strings and dash placeholders return ErrMissing.
+func ParseFloat(s string) (float64, error) {
+	norm, err := normalize(s)
+	if err != nil {
+		return 0, err
+	}
+
+	f, err := strconv.ParseFloat(norm, 64)
+	if err != nil {
+		return 0, fmt.Errorf("invalid float %q: %w", s, err)
+	}
+	return f, nil
+}
+
+// ParseInt parses a display-formatted integer into an int64.
+//
+// It accepts the same formatting as ParseFloat, except that the value must
+// be integral; "12.34" is rejected. Empty strings and dash placeholders
+// return ErrMissing.
+func ParseInt(s string) (int64, error) {
+	norm, err := normalize(s)
+	if err != nil {
+		return 0, err
+	}
+
+	i, err := strconv.ParseInt(norm, 10, 64)
+	if err != nil {
+		return 0, fmt.Errorf("invalid int %q: %w", s, err)
+	}
+	return i, nil
+}
+
+// ParseCount parses an integral quantity such as a trading volume.
+//
+// Unlike ParseInt it also accepts integral values written with a decimal
+// point or in scientific notation ("1234.0", "1.5e9"), which some upstream
+// APIs emit for large volumes. Values with a fractional part are rejected,
+// and values that do not fit in an int64 return ErrOutOfRange rather than
+// silently wrapping. Empty strings and dash placeholders return ErrMissing.
+func ParseCount(s string) (int64, error) {
+	i, err := ParseInt(s)
+	if err == nil || errors.Is(err, ErrMissing) {
+		return i, err
+	}
+
+	f, ferr := ParseFloat(s)
+	if ferr != nil {
+		return 0, err
+	}
+	if f != math.Trunc(f) {
+		return 0, fmt.Errorf("invalid count %q: not an integer", s)
+	}
+	// float64(math.MaxInt64) rounds up to 2^63, so compare with >=
+	if f >= math.MaxInt64 || f < math.MinInt64 {
+		return 0, fmt.Errorf("count %q: %w", s, ErrOutOfRange)
+	}
+	return int64(f), nil
+}
+
+// ParseRat parses a display-formatted number into an exact rational value.
+//
+// It accepts the same formatting as ParseFloat but keeps every decimal
+// digit, which makes it suitable for prices with more precision than a
+// float64 can represent. Empty strings and dash placeholders return ErrMissing.
+func ParseRat(s string) (*big.Rat, error) {
+	norm, err := normalize(s)
+	if err != nil {
+		return nil, err
+	}
+
+	r, ok := new(big.Rat).SetString(norm)
+	if !ok {
+		return nil, fmt.Errorf("invalid decimal %q", s)
+	}
+	return r, nil
+}
+
+// IsMissing reports whether s is a recognized missing-value placeholder.
+func IsMissing(s string) bool {
+	return missingTokens[strings.TrimSpace(s)]
+}
+
+// normalize converts s into a plain ASCII number acceptable to strconv.
+func normalize(s string) (string, error) {
+	s = strings.TrimSpace(s)
+	if missingTokens[s] {
+		return "", ErrMissing
+	}
+
+	var b strings.Builder
+	b.Grow(len(s))
+
+	for _, r := range s {
+		switch {
+		case r >= '０' && r <= '９':
+			b.WriteRune('0' + (r - '０'))
+		case r == ',' || r == '，' || r == ' ' || r == '\u00a0' || r == '\u3000' || r == '_':
+			// Thousands separators are checked and dropped below
+			b.WriteByte(',')
+		case r == '．':
+			b.WriteByte('.')
+		case r == '＋' || r == '▲' || r == '△' || r == '↑':
+			b.WriteByte('+')
+		case r == '－' || r == '−' || r == '▼' || r == '▽' || r == '↓':
+			b.WriteByte('-')
+		case r == 'Ｅ' || r == 'ｅ':
+			b.WriteByte('e')
+		default:
+			b.WriteRune(r)
+		}
+	}
+
+	norm := b.String()
+
+	// Arrow markers are often followed by an explicit sign ("▲+0.35");
+	// collapse duplicated sign prefixes to a single sign.
+	norm = collapseSigns(norm)
+
+	if norm == "" || norm == "+" || norm == "-" {
+		return "", ErrMissing
+	}
+
+	// Drop thousands separators only where they group the integer part,
+	// so "1,5" is not read as 15
+	if strings.Contains(norm, ",") {
+		if !grouped.MatchString(strings.TrimLeft(norm, "+-")) {
+			return "", fmt.Errorf("invalid digit grouping in %q", s)
+		}
+		norm = strings.ReplaceAll(norm, ",", "")
+	}
+
+	return norm, nil
+}
+
+// collapseSigns reduces a run of leading '+'/'-' characters to one sign.
+// Any '-' in the run makes the result negative.
+func collapseSigns(s string) string {
+	i := 0
+	negative := false
+	for i < len(s) && (s[i] == '+' || s[i] == '-') {
+		if s[i] == '-' {
+			negative = true
+		}
+		i++
+	}
+	if i <= 1 {
+		return s
+	}
+	if negative {
+		return "-" + s[i:]
+	}
+	return "+" + s[i:]
+}
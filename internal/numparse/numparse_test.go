@@ -0,0 +1,221 @@
+package numparse_test
+
+import (
+	"errors"
+	"math"
+	"math/big"
+	"testing"
+
+	"github.com/julianshen/gonp-datareader/internal/numparse"
+)
+
+func TestParseFloat(t *testing.T) {
+	tests := []struct {
+		name        string
+		input       string
+		want        float64
+		wantErr     bool
+		wantMissing bool
+	}{
+		{name: "plain integer", input: "100", want: 100},
+		{name: "plain decimal", input: "64.75", want: 64.75},
+		{name: "negative", input: "-10.5", want: -10.5},
+		{name: "explicit plus", input: "+3.2", want: 3.2},
+		{name: "thousands separators", input: "1,234,567", want: 1234567},
+		{name: "thousands with decimals", input: "1,234,567.89", want: 1234567.89},
+		{name: "space separators", input: "1 234 567", want: 1234567},
+		{name: "non-breaking space separators", input: "1\u00a0234", want: 1234},
+		{name: "surrounding whitespace", input: "  42.5\t", want: 42.5},
+		{name: "full-width digits", input: "１２３４", want: 1234},
+		{name: "full-width decimal point", input: "１２．５", want: 12.5},
+		{name: "full-width comma separator", input: "１，２３４", want: 1234},
+		{name: "full-width plus", input: "＋５", want: 5},
+		{name: "full-width minus", input: "－５．２", want: -5.2},
+		{name: "unicode minus sign", input: "−4", want: -4},
+		{name: "up arrow", input: "▲0.35", want: 0.35},
+		{name: "down arrow", input: "▼1.20", want: -1.2},
+		{name: "hollow down arrow", input: "▽2", want: -2},
+		{name: "arrow followed by sign", input: "▼-1.5", want: -1.5},
+		{name: "up arrow followed by plus", input: "▲+0.5", want: 0.5},
+		{name: "scientific notation", input: "1.5e9", want: 1.5e9},
+		{name: "scientific notation upper", input: "2.5E-3", want: 2.5e-3},
+		{name: "zero", input: "0", want: 0},
+		{name: "empty string", input: "", wantErr: true, wantMissing: true},
+		{name: "whitespace only", input: "   ", wantErr: true, wantMissing: true},
+		{name: "single dash", input: "-", wantErr: true, wantMissing: true},
+		{name: "double dash", input: "--", wantErr: true, wantMissing: true},
+		{name: "triple dash", input: "---", wantErr: true, wantMissing: true},
+		{name: "em dash", input: "—", wantErr: true, wantMissing: true},
+		{name: "full-width dashes", input: "－－", wantErr: true, wantMissing: true},
+		{name: "N/A", input: "N/A", wantErr: true, wantMissing: true},
+		{name: "bare arrow", input: "▲", wantErr: true, wantMissing: true},
+		{name: "letters", input: "abc", wantErr: true},
+		{name: "two decimal points", input: "12.34.56", wantErr: true},
+		{name: "trailing garbage", input: "12x", wantErr: true},
+		{name: "negative thousands", input: "-1,234.5", want: -1234.5},
+		{name: "decimal comma", input: "1,5", wantErr: true},
+		{name: "decimal comma with decimals", input: "12,34", wantErr: true},
+		{name: "misplaced separator", input: "1,2345", wantErr: true},
+		{name: "separator in decimals", input: "1.234,5", wantErr: true},
+		{name: "leading separator", input: ",123", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := numparse.ParseFloat(tt.input)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("ParseFloat(%q) error = %v, wantErr %v", tt.input, err, tt.wantErr)
+			}
+			if errors.Is(err, numparse.ErrMissing) != tt.wantMissing {
+				t.Errorf("ParseFloat(%q) missing = %v, want %v", tt.input, errors.Is(err, numparse.ErrMissing), tt.wantMissing)
+			}
+			if !tt.wantErr && math.Abs(got-tt.want) > 1e-12 {
+				t.Errorf("ParseFloat(%q) = %v, want %v", tt.input, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestParseInt(t *testing.T) {
+	tests := []struct {
+		name        string
+		input       string
+		want        int64
+		wantErr     bool
+		wantMissing bool
+	}{
+		{name: "plain integer", input: "12345", want: 12345},
+		{name: "large volume", input: "55956524", want: 55956524},
+		{name: "thousands separators", input: "55,956,524", want: 55956524},
+		{name: "full-width digits", input: "５５９５６５２４", want: 55956524},
+		{name: "full-width separators", input: "５５，９５６", want: 55956},
+		{name: "negative", input: "-42", want: -42},
+		{name: "down arrow", input: "▼42", want: -42},
+		{name: "max int64", input: "9,223,372,036,854,775,807", want: math.MaxInt64},
+		{name: "empty string", input: "", wantErr: true, wantMissing: true},
+		{name: "dash placeholder", input: "--", wantErr: true, wantMissing: true},
+		{name: "decimal number", input: "12.34", wantErr: true},
+		{name: "decimal comma", input: "1,5", wantErr: true},
+		{name: "overflow", input: "9,223,372,036,854,775,808", wantErr: true},
+		{name: "letters", input: "abc", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := numparse.ParseInt(tt.input)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("ParseInt(%q) error = %v, wantErr %v", tt.input, err, tt.wantErr)
+			}
+			if errors.Is(err, numparse.ErrMissing) != tt.wantMissing {
+				t.Errorf("ParseInt(%q) missing = %v, want %v", tt.input, errors.Is(err, numparse.ErrMissing), tt.wantMissing)
+			}
+			if !tt.wantErr && got != tt.want {
+				t.Errorf("ParseInt(%q) = %v, want %v", tt.input, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestParseCount(t *testing.T) {
+	tests := []struct {
+		name        string
+		input       string
+		want        int64
+		wantErr     error
+		wantInvalid bool
+	}{
+		{name: "plain", input: "55956524", want: 55956524},
+		{name: "separators", input: "55,956,524", want: 55956524},
+		{name: "max int64", input: "9223372036854775807", want: math.MaxInt64},
+		{name: "integral decimal", input: "1234.0", want: 1234},
+		{name: "scientific notation", input: "1.5e9", want: 1500000000},
+		{name: "large scientific", input: "9.2e18", want: 9200000000000000000},
+		{name: "negative", input: "-1e3", want: -1000},
+		{name: "overflow digits", input: "9223372036854775808", wantErr: numparse.ErrOutOfRange},
+		{name: "overflow scientific", input: "1e19", wantErr: numparse.ErrOutOfRange},
+		{name: "huge crypto volume", input: "3.4e38", wantErr: numparse.ErrOutOfRange},
+		{name: "fractional", input: "12.5", wantInvalid: true},
+		{name: "letters", input: "abc", wantInvalid: true},
+		{name: "missing", input: "--", wantErr: numparse.ErrMissing},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := numparse.ParseCount(tt.input)
+			switch {
+			case tt.wantErr != nil:
+				if !errors.Is(err, tt.wantErr) {
+					t.Errorf("ParseCount(%q) error = %v, want %v", tt.input, err, tt.wantErr)
+				}
+			case tt.wantInvalid:
+				if err == nil {
+					t.Errorf("ParseCount(%q) expected error, got %d", tt.input, got)
+				}
+			default:
+				if err != nil {
+					t.Fatalf("ParseCount(%q) error = %v", tt.input, err)
+				}
+				if got != tt.want {
+					t.Errorf("ParseCount(%q) = %d, want %d", tt.input, got, tt.want)
+				}
+			}
+		})
+	}
+}
+
+func TestParseRat(t *testing.T) {
+	tests := []struct {
+		name        string
+		input       string
+		want        string
+		wantErr     bool
+		wantMissing bool
+	}{
+		{name: "plain decimal", input: "64.75", want: "259/4"},
+		{name: "many decimals", input: "0.123456789012345678", want: "61728394506172839/500000000000000000"},
+		{name: "thousands separators", input: "1,234,567.01", want: "123456701/100"},
+		{name: "full-width", input: "－１２．５", want: "-25/2"},
+		{name: "scientific notation", input: "1.5e3", want: "1500/1"},
+		{name: "dash placeholder", input: "--", wantErr: true, wantMissing: true},
+		{name: "letters", input: "abc", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := numparse.ParseRat(tt.input)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("ParseRat(%q) error = %v, wantErr %v", tt.input, err, tt.wantErr)
+			}
+			if errors.Is(err, numparse.ErrMissing) != tt.wantMissing {
+				t.Errorf("ParseRat(%q) missing = %v, want %v", tt.input, errors.Is(err, numparse.ErrMissing), tt.wantMissing)
+			}
+			if tt.wantErr {
+				return
+			}
+			want, _ := new(big.Rat).SetString(tt.want)
+			if got.Cmp(want) != 0 {
+				t.Errorf("ParseRat(%q) = %v, want %v", tt.input, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestIsMissing(t *testing.T) {
+	tests := []struct {
+		input string
+		want  bool
+	}{
+		{"", true},
+		{" -- ", true},
+		{"—", true},
+		{"0", false},
+		{"-1", false},
+		{"1,000", false},
+	}
+
+	for _, tt := range tests {
+		if got := numparse.IsMissing(tt.input); got != tt.want {
+			t.Errorf("IsMissing(%q) = %v, want %v", tt.input, got, tt.want)
+		}
+	}
+}
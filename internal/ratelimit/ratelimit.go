@@ -3,13 +3,30 @@ package ratelimit
 
 import (
 	"context"
+	"sync"
+	"time"
 
 	"golang.org/x/time/rate"
 )
 
+// minRateFraction is the lowest fraction of its configured rate an
+// adaptive limiter backs off to.
+const minRateFraction = 1.0 / 64
+
+// recoverySteps is how many successful requests an adaptive limiter takes
+// to climb from zero back to its configured rate.
+const recoverySteps = 20
+
 // RateLimiter controls the rate of requests.
 type RateLimiter struct {
 	limiter *rate.Limiter
+
+	// adaptive limiters adjust their rate AIMD style between max and
+	// max*minRateFraction; mu guards decreasedAt
+	adaptive    bool
+	max         rate.Limit
+	mu          sync.Mutex
+	decreasedAt time.Time
 }
 
 // NewRateLimiter creates a new rate limiter with the specified rate and burst.
@@ -28,6 +45,92 @@ func NewRateLimiter(rps float64, burst int) *RateLimiter {
 	}
 }
 
+// NewRateLimiterWithTokens creates a rate limiter like NewRateLimiter
+// whose bucket starts with initial tokens instead of a full burst, so the
+// first requests of a batch are paced as well. The bucket refills to burst
+// at the configured rate. initial is clamped to [0, burst].
+func NewRateLimiterWithTokens(rps float64, burst, initial int) *RateLimiter {
+	r := NewRateLimiter(rps, burst)
+	if rps <= 0 {
+		return r
+	}
+
+	if initial < 0 {
+		initial = 0
+	}
+	if drain := burst - initial; drain > 0 {
+		// A new limiter starts full; consume the tokens above initial
+		r.limiter.AllowN(time.Now(), drain)
+	}
+	return r
+}
+
+// NewAdaptiveRateLimiter creates a rate limiter like
+// NewRateLimiterWithTokens whose rate adapts to throttling, AIMD style:
+// Throttle halves it, down to 1/64 of rps, and every Recover raises it by
+// 1/20 of rps, back up to rps. A rate of 0 means unlimited and does not
+// adapt.
+func NewAdaptiveRateLimiter(rps float64, burst, initial int) *RateLimiter {
+	r := NewRateLimiterWithTokens(rps, burst, initial)
+	if rps > 0 {
+		r.adaptive = true
+		r.max = rate.Limit(rps)
+	}
+	return r
+}
+
+// Throttle halves the rate of an adaptive limiter after the provider
+// throttled a request sent at sent. Throttled responses to requests sent
+// before the last decrease are ignored, so one burst of rejections halves
+// the rate once. Other limiters are unaffected.
+func (r *RateLimiter) Throttle(sent time.Time) {
+	if r == nil || !r.adaptive {
+		return
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if !sent.After(r.decreasedAt) {
+		return
+	}
+	r.decreasedAt = time.Now()
+	limit := r.limiter.Limit() / 2
+	if floor := r.max * minRateFraction; limit < floor {
+		limit = floor
+	}
+	r.limiter.SetLimit(limit)
+}
+
+// Recover raises the rate of an adaptive limiter by a step toward its
+// configured rate after a request succeeded. Other limiters are
+// unaffected.
+func (r *RateLimiter) Recover() {
+	if r == nil || !r.adaptive {
+		return
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	limit := r.limiter.Limit()
+	if limit >= r.max {
+		return
+	}
+	limit += r.max / recoverySteps
+	if limit > r.max {
+		limit = r.max
+	}
+	r.limiter.SetLimit(limit)
+}
+
+// Limit returns the current rate in requests per second; +Inf when
+// unlimited.
+func (r *RateLimiter) Limit() float64 {
+	if r == nil || r.limiter == nil {
+		return float64(rate.Inf)
+	}
+	return float64(r.limiter.Limit())
+}
+
 // Wait blocks until the rate limiter allows the request to proceed.
 // It returns an error if the context is cancelled.
 func (r *RateLimiter) Wait(ctx context.Context) error {
@@ -158,3 +158,95 @@ func TestRateLimiter_NilLimiter(t *testing.T) {
 		t.Errorf("Nil limiter should allow requests, got error: %v", err)
 	}
 }
+
+func TestNewRateLimiterWithTokens(t *testing.T) {
+	tests := []struct {
+		name      string
+		burst     int
+		initial   int
+		immediate int
+	}{
+		{"full bucket", 3, 3, 3},
+		{"partial bucket", 3, 1, 1},
+		{"empty bucket", 3, 0, 0},
+		{"negative is empty", 3, -1, 0},
+		{"initial above burst", 2, 5, 2},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// 10 requests per second: each paced request waits ~100ms
+			limiter := ratelimit.NewRateLimiterWithTokens(10.0, tt.burst, tt.initial)
+			ctx := context.Background()
+
+			start := time.Now()
+			for i := 0; i < tt.immediate; i++ {
+				if err := limiter.Wait(ctx); err != nil {
+					t.Fatalf("Request %d failed: %v", i, err)
+				}
+			}
+			if elapsed := time.Since(start); elapsed > 50*time.Millisecond {
+				t.Errorf("%d initial requests took %v, want immediate", tt.immediate, elapsed)
+			}
+
+			start = time.Now()
+			if err := limiter.Wait(ctx); err != nil {
+				t.Fatalf("Paced request failed: %v", err)
+			}
+			if elapsed := time.Since(start); elapsed < 50*time.Millisecond {
+				t.Errorf("Request after initial tokens took %v, want it paced", elapsed)
+			}
+		})
+	}
+}
+
+func TestAdaptiveRateLimiter(t *testing.T) {
+	limiter := ratelimit.NewAdaptiveRateLimiter(10, 1, 0)
+
+	sent := time.Now()
+	time.Sleep(time.Millisecond)
+	limiter.Throttle(sent)
+	if got := limiter.Limit(); got != 5 {
+		t.Fatalf("Limit() after Throttle = %v, want 5", got)
+	}
+
+	// Rejections of requests sent before the decrease do not halve again
+	limiter.Throttle(sent)
+	if got := limiter.Limit(); got != 5 {
+		t.Errorf("Limit() after a stale Throttle = %v, want 5", got)
+	}
+
+	for i := 0; i < 20; i++ {
+		time.Sleep(time.Millisecond)
+		limiter.Throttle(time.Now())
+	}
+	if got := limiter.Limit(); got != 10.0/64 {
+		t.Errorf("Limit() after repeated Throttle = %v, want the 10/64 floor", got)
+	}
+
+	limiter.Recover()
+	if got := limiter.Limit(); got != 10.0/64+0.5 {
+		t.Errorf("Limit() after Recover = %v, want a step of 0.5", got)
+	}
+	for i := 0; i < 30; i++ {
+		limiter.Recover()
+	}
+	if got := limiter.Limit(); got != 10 {
+		t.Errorf("Limit() after recovering = %v, want the configured 10", got)
+	}
+}
+
+func TestRateLimiter_ThrottleStatic(t *testing.T) {
+	limiter := ratelimit.NewRateLimiter(10, 1)
+	limiter.Throttle(time.Now())
+	if got := limiter.Limit(); got != 10 {
+		t.Errorf("Limit() = %v, want static limiters unaffected", got)
+	}
+
+	// Unlimited limiters do not adapt
+	unlimited := ratelimit.NewAdaptiveRateLimiter(0, 1, 0)
+	unlimited.Throttle(time.Now())
+	if err := unlimited.Wait(context.Background()); err != nil {
+		t.Errorf("Wait() error = %v", err)
+	}
+}
@@ -0,0 +1,59 @@
+// Command schemagen writes a machine-readable JSON description of the
+// columns returned by every data source.
+//
+// It is invoked via go generate from the repository root:
+//
+//	go generate ./...
+//
+// and writes docs/schema.json by default.
+package main
+
+import (
+	"encoding/json"
+	"flag"
+	"fmt"
+	"log"
+	"os"
+
+	datareader "github.com/julianshen/gonp-datareader"
+	"github.com/julianshen/gonp-datareader/sources"
+)
+
+func main() {
+	out := flag.String("out", "docs/schema.json", "output file path")
+	flag.Parse()
+
+	schemas, err := collectSchemas()
+	if err != nil {
+		log.Fatalf("schemagen: %v", err)
+	}
+
+	data, err := json.MarshalIndent(schemas, "", "  ")
+	if err != nil {
+		log.Fatalf("schemagen: encode schema: %v", err)
+	}
+	data = append(data, '\n')
+
+	// #nosec G306 - Generated documentation is intended to be world-readable
+	if err := os.WriteFile(*out, data, 0644); err != nil {
+		log.Fatalf("schemagen: write %s: %v", *out, err)
+	}
+}
+
+// collectSchemas builds the schema of every registered source.
+func collectSchemas() ([]sources.Schema, error) {
+	var schemas []sources.Schema
+	for _, name := range datareader.ListSources() {
+		reader, err := datareader.DataReader(name, nil)
+		if err != nil {
+			return nil, fmt.Errorf("create %s reader: %w", name, err)
+		}
+
+		provider, ok := reader.(sources.SchemaProvider)
+		if !ok {
+			return nil, fmt.Errorf("%s reader does not provide a schema", name)
+		}
+		schemas = append(schemas, provider.Schema())
+	}
+	return schemas, nil
+}
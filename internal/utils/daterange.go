@@ -0,0 +1,32 @@
+package utils
+
+import "time"
+
+// DayRange is an inclusive range of calendar days for filtering rows by
+// date. Each timestamp counts on its own calendar day, in its own
+// location, whatever its time of day: a bar at 16:00 New York time on the
+// end date is in range, as is a Tiingo bar at 14:30Z or a TWSE bar at
+// midnight Taipei time (16:00Z the day before). Days are compared by
+// year, month and day, never by adding 24 hours, so days lengthened or
+// shortened by daylight-saving changes have no effect.
+type DayRange struct {
+	first, last int
+}
+
+// NewDayRange returns the calendar days from start's date through end's
+// date, each taken in its own location.
+func NewDayRange(start, end time.Time) DayRange {
+	return DayRange{first: dayKey(start), last: dayKey(end)}
+}
+
+// Contains reports whether t's calendar date, in t's location, falls in r.
+func (r DayRange) Contains(t time.Time) bool {
+	k := dayKey(t)
+	return k >= r.first && k <= r.last
+}
+
+// dayKey returns the calendar date of t as yyyymmdd, for ordering.
+func dayKey(t time.Time) int {
+	y, m, d := t.Date()
+	return y*10000 + int(m)*100 + d
+}
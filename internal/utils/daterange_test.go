@@ -0,0 +1,49 @@
+package utils_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/julianshen/gonp-datareader/internal/utils"
+)
+
+func TestDayRange(t *testing.T) {
+	newYork, err := time.LoadLocation("America/New_York")
+	if err != nil {
+		t.Skipf("time zone data unavailable: %v", err)
+	}
+	taipei := time.FixedZone("CST", 8*3600)
+	utc := func(y int, m time.Month, d int) time.Time { return time.Date(y, m, d, 0, 0, 0, 0, time.UTC) }
+
+	tests := []struct {
+		name       string
+		start, end time.Time
+		t          time.Time
+		want       bool
+	}{
+		{"midnight on start", utc(2024, 1, 2), utc(2024, 1, 5), utc(2024, 1, 2), true},
+		{"intraday on end", utc(2024, 1, 2), utc(2024, 1, 5), time.Date(2024, 1, 5, 14, 30, 0, 0, time.UTC), true},
+		{"day after end", utc(2024, 1, 2), utc(2024, 1, 5), utc(2024, 1, 6), false},
+		{"day before start", utc(2024, 1, 2), utc(2024, 1, 5), time.Date(2024, 1, 1, 23, 59, 59, 0, time.UTC), false},
+		// 16:00 New York is 21:00Z, the same day; 20:00 is the next day in UTC
+		{"exchange close on end", utc(2024, 1, 2), utc(2024, 1, 5), time.Date(2024, 1, 5, 20, 0, 0, 0, newYork), true},
+		// Midnight Taipei is 16:00Z the day before
+		{"exchange midnight on start", utc(2024, 1, 2), utc(2024, 1, 5), time.Date(2024, 1, 2, 0, 0, 0, 0, taipei), true},
+		{"exchange midnight after end", utc(2024, 1, 2), utc(2024, 1, 5), time.Date(2024, 1, 6, 0, 0, 0, 0, taipei), false},
+		{"bounds with time of day", time.Date(2024, 1, 2, 18, 0, 0, 0, time.UTC), time.Date(2024, 1, 5, 1, 0, 0, 0, time.UTC), time.Date(2024, 1, 2, 9, 30, 0, 0, newYork), true},
+		// Spring forward: 2024-03-10 has 23 hours in New York
+		{"DST start day", time.Date(2024, 3, 10, 0, 0, 0, 0, newYork), time.Date(2024, 3, 10, 0, 0, 0, 0, newYork), time.Date(2024, 3, 10, 23, 30, 0, 0, newYork), true},
+		{"after DST start day", time.Date(2024, 3, 9, 0, 0, 0, 0, newYork), time.Date(2024, 3, 10, 0, 0, 0, 0, newYork), time.Date(2024, 3, 11, 0, 0, 0, 0, newYork), false},
+		// Fall back: 2024-11-03 has 25 hours in New York
+		{"DST end day", time.Date(2024, 11, 3, 0, 0, 0, 0, newYork), time.Date(2024, 11, 3, 0, 0, 0, 0, newYork), time.Date(2024, 11, 3, 23, 30, 0, 0, newYork), true},
+		{"before DST end day", time.Date(2024, 11, 3, 0, 0, 0, 0, newYork), time.Date(2024, 11, 4, 0, 0, 0, 0, newYork), time.Date(2024, 11, 2, 23, 30, 0, 0, newYork), false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := utils.NewDayRange(tt.start, tt.end).Contains(tt.t); got != tt.want {
+				t.Errorf("NewDayRange(%v, %v).Contains(%v) = %v, want %v", tt.start, tt.end, tt.t, got, tt.want)
+			}
+		})
+	}
+}
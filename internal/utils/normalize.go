@@ -0,0 +1,46 @@
+package utils
+
+import "strings"
+
+// exchangePrefixes lists the exchange qualifiers accepted in front of a
+// symbol, as in "NASDAQ:AAPL" (the TradingView and Google Finance form).
+var exchangePrefixes = map[string]bool{
+	"NASDAQ":       true,
+	"NYSE":         true,
+	"NYSEARCA":     true,
+	"NYSEAMERICAN": true,
+	"AMEX":         true,
+	"ARCA":         true,
+	"BATS":         true,
+	"CBOE":         true,
+	"OTC":          true,
+	"OTCMKTS":      true,
+	"TWSE":         true,
+	"TPEX":         true,
+	"TSE":          true,
+	"TYO":          true,
+	"LSE":          true,
+	"TSX":          true,
+	"HKEX":         true,
+	"XETRA":        true,
+	"FRA":          true,
+	"EPA":          true,
+	"ASX":          true,
+}
+
+// NormalizeSymbol cleans up user input before validation: surrounding
+// whitespace is trimmed and a known exchange prefix ("NASDAQ:AAPL") is
+// removed. When upper is true, the symbol is also uppercased; sources
+// whose identifiers are case-sensitive pass false.
+func NormalizeSymbol(symbol string, upper bool) string {
+	symbol = strings.TrimSpace(symbol)
+
+	if prefix, rest, ok := strings.Cut(symbol, ":"); ok && exchangePrefixes[strings.ToUpper(strings.TrimSpace(prefix))] {
+		symbol = strings.TrimSpace(rest)
+	}
+
+	if upper {
+		symbol = strings.ToUpper(symbol)
+	}
+	return symbol
+}
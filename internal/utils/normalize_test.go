@@ -0,0 +1,32 @@
+package utils_test
+
+import (
+	"testing"
+
+	"github.com/julianshen/gonp-datareader/internal/utils"
+)
+
+func TestNormalizeSymbol(t *testing.T) {
+	tests := []struct {
+		name   string
+		symbol string
+		upper  bool
+		want   string
+	}{
+		{"trim", "  AAPL\t", true, "AAPL"},
+		{"uppercase", "aapl", true, "AAPL"},
+		{"exchange prefix", "NASDAQ:AAPL", true, "AAPL"},
+		{"lowercase prefix with spaces", " nyse: brk-b ", true, "BRK-B"},
+		{"unknown prefix kept", "FOO:BAR", true, "FOO:BAR"},
+		{"case preserved", " nama_10_gdp ", false, "nama_10_gdp"},
+		{"inner space kept", "AA PL", true, "AA PL"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := utils.NormalizeSymbol(tt.symbol, tt.upper); got != tt.want {
+				t.Errorf("NormalizeSymbol(%q, %v) = %q, want %q", tt.symbol, tt.upper, got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,57 @@
+package datareader
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/julianshen/gonp-datareader/dataset"
+	"github.com/julianshen/gonp-datareader/sources"
+)
+
+// ErrLatestNotSupported is returned by ReadLatest for sources that do not
+// implement sources.LatestReader.
+var ErrLatestNotSupported = errors.New("source does not support latest observations")
+
+// ReadLatest fetches the most recent bar or observation of symbol without
+// a date range, from the source's cheapest endpoint, and converts it to a
+// one-row dataset.Dataset. Supported sources are "yahoo" (quote endpoint;
+// the current day's bar is incomplete during trading hours), "twse"
+// (latest trading day snapshot) and "fred" (most recent reported
+// observation). A bar whose session has not closed is flagged as
+// provisional, or dropped with opts.ExcludeIncompleteBar (see
+// MetaProvisional).
+//
+// # Example Usage
+//
+//	latest, err := datareader.ReadLatest(ctx, "fred", "DGS10", &datareader.Options{APIKey: key})
+//	if err != nil {
+//		log.Fatal(err)
+//	}
+//	values, _ := latest.Column("Value")
+//	fmt.Println(latest.Dates[0].Format("2006-01-02"), values[0])
+func ReadLatest(ctx context.Context, source, symbol string, opts *Options) (*dataset.Dataset, error) {
+	opts = withDefaults(opts)
+	reader, err := DataReader(source, opts)
+	if err != nil {
+		return nil, err
+	}
+
+	latestReader, ok := reader.(sources.LatestReader)
+	if !ok {
+		return nil, fmt.Errorf("%w: %s", ErrLatestNotSupported, source)
+	}
+
+	data, err := latestReader.ReadLatest(labelCache(ctx, symbol, time.Time{}, time.Time{}), symbol)
+	if err != nil {
+		return nil, err
+	}
+	markIncomplete(data, source, opts, time.Now())
+
+	mode := NumericFloat64
+	if opts != nil {
+		mode = opts.NumericMode
+	}
+	return ToDatasetMode(symbol, data, mode)
+}
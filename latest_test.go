@@ -0,0 +1,68 @@
+package datareader_test
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	datareader "github.com/julianshen/gonp-datareader"
+	"github.com/julianshen/gonp-datareader/sources"
+	"github.com/julianshen/gonp-datareader/sources/fred"
+	"github.com/julianshen/gonp-datareader/sources/twse"
+	"github.com/julianshen/gonp-datareader/sources/yahoo"
+)
+
+// Compile-time checks that the latest sources implement sources.LatestReader
+var (
+	_ sources.LatestReader = (*yahoo.YahooReader)(nil)
+	_ sources.LatestReader = (*twse.TWSEReader)(nil)
+	_ sources.LatestReader = (*fred.FREDReader)(nil)
+)
+
+func TestReadLatest(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"observations":[{"date":"2024-01-03","value":"3.91"}]}`))
+	}))
+	defer server.Close()
+
+	opts := &datareader.Options{
+		APIKey:           "key",
+		BaseURLOverrides: map[string]string{"fred": server.URL},
+	}
+
+	ds, err := datareader.ReadLatest(context.Background(), "fred", "DGS10", opts)
+	if err != nil {
+		t.Fatalf("ReadLatest() error = %v", err)
+	}
+	values, ok := ds.Column("Value")
+	if ds.Len() != 1 || !ok || values[0] != 3.91 {
+		t.Fatalf("ReadLatest() = %d rows %v, want one row of 3.91", ds.Len(), values)
+	}
+	if want := time.Date(2024, 1, 3, 0, 0, 0, 0, time.UTC); !ds.Dates[0].Equal(want) {
+		t.Errorf("date = %v, want %v", ds.Dates[0], want)
+	}
+}
+
+func TestReadLatest_Errors(t *testing.T) {
+	tests := []struct {
+		name    string
+		source  string
+		wantErr error
+	}{
+		{"not supported", "stooq", datareader.ErrLatestNotSupported},
+		{"unknown source", "unknown", datareader.ErrUnknownSource},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := datareader.ReadLatest(context.Background(), tt.source, "AAPL", nil)
+			if !errors.Is(err, tt.wantErr) {
+				t.Errorf("ReadLatest() error = %v, want %v", err, tt.wantErr)
+			}
+		})
+	}
+}
@@ -0,0 +1,41 @@
+package datareader
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/julianshen/gonp-datareader/sources"
+)
+
+// ErrListingNotSupported is returned by ListSymbols for sources that do
+// not implement sources.Lister.
+var ErrListingNotSupported = errors.New("source does not support symbol listing")
+
+// ListSymbols enumerates every symbol source serves, for screeners and
+// full-universe backfills. Supported sources are "twse" (ISIN listing with
+// ISIN and listing date; English names with Options.EnglishNames),
+// "stooq" (tickers of the latest bulk file, without names) and "yahoo"
+// (US stocks and ETFs from Nasdaq Trader's symbol directory files).
+//
+// # Example Usage
+//
+//	infos, err := datareader.ListSymbols(ctx, "twse", nil)
+//	if err != nil {
+//		log.Fatal(err)
+//	}
+//	for _, info := range infos {
+//		fmt.Println(info.Symbol, info.Name, info.ISIN, info.ListingDate.Format("2006-01-02"))
+//	}
+func ListSymbols(ctx context.Context, source string, opts *Options) ([]sources.SymbolInfo, error) {
+	reader, err := DataReader(source, opts)
+	if err != nil {
+		return nil, err
+	}
+
+	lister, ok := reader.(sources.Lister)
+	if !ok {
+		return nil, fmt.Errorf("%w: %s", ErrListingNotSupported, source)
+	}
+	return lister.ListSymbols(ctx)
+}
@@ -0,0 +1,40 @@
+package datareader_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	datareader "github.com/julianshen/gonp-datareader"
+	"github.com/julianshen/gonp-datareader/sources"
+	"github.com/julianshen/gonp-datareader/sources/stooq"
+	"github.com/julianshen/gonp-datareader/sources/twse"
+	"github.com/julianshen/gonp-datareader/sources/yahoo"
+)
+
+// Compile-time checks that the listing sources implement sources.Lister
+var (
+	_ sources.Lister = (*twse.TWSEReader)(nil)
+	_ sources.Lister = (*stooq.StooqReader)(nil)
+	_ sources.Lister = (*yahoo.YahooReader)(nil)
+)
+
+func TestListSymbols_Errors(t *testing.T) {
+	tests := []struct {
+		name    string
+		source  string
+		wantErr error
+	}{
+		{"not supported", "fred", datareader.ErrListingNotSupported},
+		{"unknown source", "unknown", datareader.ErrUnknownSource},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := datareader.ListSymbols(context.Background(), tt.source, nil)
+			if !errors.Is(err, tt.wantErr) {
+				t.Errorf("ListSymbols(%q) error = %v, want %v", tt.source, err, tt.wantErr)
+			}
+		})
+	}
+}
@@ -0,0 +1,223 @@
+package datareader
+
+import (
+	"context"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/julianshen/gonp-datareader/sources"
+)
+
+// Manager lazily creates and reuses one configured reader per source, so
+// servers share each source's HTTP connections, rate limiter and caches
+// across requests instead of building them per call. It is safe for
+// concurrent use.
+//
+// # Example Usage
+//
+//	m := datareader.NewManager(&datareader.Options{MemoryCacheSize: 1000, RateLimit: 5})
+//	m.SetAPIKey("fred", os.Getenv("FRED_API_KEY"))
+//	defer m.Shutdown(context.Background())
+//
+//	data, err := m.ReadSingle(ctx, "fred", "GDP", start, end)
+//
+//	stats := m.Stats()
+//	fmt.Println(stats["fred"].Requests, stats["fred"].CacheHits)
+type Manager struct {
+	// Factory creates readers; nil means DataReader. Set it before the
+	// first read to plug in custom readers.
+	Factory func(source string, opts *Options) (sources.Reader, error)
+
+	opts *Options
+
+	mu      sync.Mutex
+	apiKeys map[string]string
+	readers map[string]sources.Reader
+	stats   map[string]*sourceStats
+	closed  bool
+}
+
+// SourceStats holds a source's usage counters, as reported by
+// Manager.Stats.
+type SourceStats struct {
+	// Requests counts Read and ReadSingle calls made through the Manager.
+	Requests uint64
+	// Errors counts the calls that returned an error.
+	Errors uint64
+	// CacheHits and CacheMisses count HTTP cache lookups.
+	CacheHits   uint64
+	CacheMisses uint64
+	// TotalLatency is the summed duration of all calls.
+	TotalLatency time.Duration
+	// CreatedAt is when the source's reader was created.
+	CreatedAt time.Time
+}
+
+// sourceStats holds the live counters behind SourceStats.
+type sourceStats struct {
+	requests, errors, hits, misses atomic.Uint64
+	latency                        atomic.Int64
+	createdAt                      time.Time
+}
+
+// NewManager creates a Manager whose readers use opts, which may be nil
+// for defaults. The options are copied.
+func NewManager(opts *Options) *Manager {
+	opts = withDefaults(opts)
+	base := DefaultOptions()
+	if opts != nil {
+		copied := *opts
+		base = &copied
+	}
+	return &Manager{
+		opts:    base,
+		apiKeys: make(map[string]string),
+		readers: make(map[string]sources.Reader),
+		stats:   make(map[string]*sourceStats),
+	}
+}
+
+// SetAPIKey sets the API key used for source, overriding Options.APIKey.
+// It only affects readers created afterwards.
+func (m *Manager) SetAPIKey(source, key string) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.apiKeys[source] = key
+}
+
+// Reader returns the shared reader for source, creating it on first use.
+// Calls on the returned reader are not counted in Stats; use Read or
+// ReadSingle for that.
+func (m *Manager) Reader(source string) (sources.Reader, error) {
+	r, _, err := m.reader(source)
+	return r, err
+}
+
+// ReadSingle fetches one symbol with the shared reader for source.
+func (m *Manager) ReadSingle(ctx context.Context, source, symbol string, start, end time.Time) (interface{}, error) {
+	r, stats, err := m.reader(source)
+	if err != nil {
+		return nil, err
+	}
+
+	began := time.Now()
+	data, err := r.ReadSingle(labelCache(ctx, symbol, start, end), symbol, start, end)
+	stats.record(began, err)
+	if err == nil {
+		finishRows(data, source, m.opts)
+	}
+	return data, err
+}
+
+// Read fetches several symbols with the shared reader for source.
+func (m *Manager) Read(ctx context.Context, source string, symbols []string, start, end time.Time) (interface{}, error) {
+	r, stats, err := m.reader(source)
+	if err != nil {
+		return nil, err
+	}
+
+	began := time.Now()
+	data, err := r.Read(ctx, symbols, start, end)
+	stats.record(began, err)
+	if err == nil {
+		finishRows(data, source, m.opts)
+	}
+	return data, err
+}
+
+// Stats returns a snapshot of the counters of every source used so far.
+func (m *Manager) Stats() map[string]SourceStats {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	out := make(map[string]SourceStats, len(m.stats))
+	for source, s := range m.stats {
+		out[source] = SourceStats{
+			Requests:     s.requests.Load(),
+			Errors:       s.errors.Load(),
+			CacheHits:    s.hits.Load(),
+			CacheMisses:  s.misses.Load(),
+			TotalLatency: time.Duration(s.latency.Load()),
+			CreatedAt:    s.createdAt,
+		}
+	}
+	return out
+}
+
+// Shutdown stops the Manager and gracefully shuts down every reader it
+// created (see datareader.Shutdown). Later calls fail with
+// sources.ErrClosed.
+func (m *Manager) Shutdown(ctx context.Context) error {
+	m.mu.Lock()
+	m.closed = true
+	readers := make([]sources.Reader, 0, len(m.readers))
+	for _, r := range m.readers {
+		readers = append(readers, r)
+	}
+	m.mu.Unlock()
+
+	return Shutdown(ctx, readers...)
+}
+
+// reader returns the reader and counters for source, creating them on
+// first use.
+func (m *Manager) reader(source string) (sources.Reader, *sourceStats, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if m.closed {
+		return nil, nil, sources.ErrClosed
+	}
+	if r, ok := m.readers[source]; ok {
+		return r, m.stats[source], nil
+	}
+
+	stats := &sourceStats{createdAt: time.Now()}
+	opts := *m.opts
+	if key, ok := m.apiKeys[source]; ok {
+		opts.APIKey = key
+	}
+
+	// Count cache activity, chaining any user hooks
+	var user Hooks
+	if opts.Hooks != nil {
+		user = *opts.Hooks
+	}
+	hooks := user
+	hooks.OnCacheHit = func(layer, key string) {
+		stats.hits.Add(1)
+		if user.OnCacheHit != nil {
+			user.OnCacheHit(layer, key)
+		}
+	}
+	hooks.OnCacheMiss = func(key string) {
+		stats.misses.Add(1)
+		if user.OnCacheMiss != nil {
+			user.OnCacheMiss(key)
+		}
+	}
+	opts.Hooks = &hooks
+
+	factory := m.Factory
+	if factory == nil {
+		factory = DataReader
+	}
+	r, err := factory(source, &opts)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	m.readers[source] = r
+	m.stats[source] = stats
+	return r, stats, nil
+}
+
+// record counts a completed call.
+func (s *sourceStats) record(began time.Time, err error) {
+	s.requests.Add(1)
+	s.latency.Add(int64(time.Since(began)))
+	if err != nil {
+		s.errors.Add(1)
+	}
+}
@@ -0,0 +1,123 @@
+package datareader_test
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	datareader "github.com/julianshen/gonp-datareader"
+	internalhttp "github.com/julianshen/gonp-datareader/internal/http"
+	"github.com/julianshen/gonp-datareader/sources"
+	"github.com/julianshen/gonp-datareader/sources/stooq"
+)
+
+func TestManager_ReusesReaders(t *testing.T) {
+	m := datareader.NewManager(nil)
+	m.SetAPIKey("fred", "fred-key")
+
+	var created int32
+	var gotKey string
+	m.Factory = func(source string, opts *datareader.Options) (sources.Reader, error) {
+		atomic.AddInt32(&created, 1)
+		gotKey = opts.APIKey
+		return datareader.DataReader(source, opts)
+	}
+
+	var wg sync.WaitGroup
+	for i := 0; i < 20; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			if _, err := m.Reader("fred"); err != nil {
+				t.Error(err)
+			}
+		}()
+	}
+	wg.Wait()
+
+	if created != 1 {
+		t.Errorf("readers created = %d, want 1", created)
+	}
+	if gotKey != "fred-key" {
+		t.Errorf("APIKey = %q, want fred-key", gotKey)
+	}
+
+	if _, err := m.Reader("unknown"); !errors.Is(err, datareader.ErrUnknownSource) {
+		t.Errorf("Reader(unknown) error = %v, want ErrUnknownSource", err)
+	}
+}
+
+func TestManager_Stats(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Query().Get("s") == "FAIL" {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		w.Write([]byte("Date,Open,High,Low,Close,Volume\n2023-01-03,130,131,124,125.07,112117500\n"))
+	}))
+	defer server.Close()
+
+	m := datareader.NewManager(&datareader.Options{MemoryCacheSize: 10})
+	m.Factory = func(source string, opts *datareader.Options) (sources.Reader, error) {
+		return stooq.NewStooqReaderWithBaseURL(&internalhttp.ClientOptions{
+			MemoryCacheSize: opts.MemoryCacheSize,
+			OnCacheHit:      opts.Hooks.OnCacheHit,
+			OnCacheMiss:     opts.Hooks.OnCacheMiss,
+		}, server.URL+"?s=%s"), nil
+	}
+
+	ctx := context.Background()
+	start := time.Date(2023, 1, 1, 0, 0, 0, 0, time.UTC)
+	end := time.Date(2023, 1, 31, 0, 0, 0, 0, time.UTC)
+	for _, symbol := range []string{"AAPL.US", "AAPL.US", "FAIL"} {
+		_, _ = m.ReadSingle(ctx, "stooq", symbol, start, end)
+	}
+
+	stats := m.Stats()["stooq"]
+	if stats.Requests != 3 || stats.Errors != 1 {
+		t.Errorf("Requests/Errors = %d/%d, want 3/1", stats.Requests, stats.Errors)
+	}
+	if stats.CacheHits != 1 || stats.CacheMisses != 2 {
+		t.Errorf("CacheHits/CacheMisses = %d/%d, want 1/2", stats.CacheHits, stats.CacheMisses)
+	}
+	if stats.TotalLatency <= 0 || stats.CreatedAt.IsZero() {
+		t.Errorf("TotalLatency = %v, CreatedAt = %v", stats.TotalLatency, stats.CreatedAt)
+	}
+}
+
+func TestManager_KeepsUserHooks(t *testing.T) {
+	onWarning := func(source, symbol string, w sources.Warning) {}
+	m := datareader.NewManager(&datareader.Options{Hooks: &datareader.Hooks{OnWarning: onWarning}})
+
+	var got *datareader.Hooks
+	m.Factory = func(source string, opts *datareader.Options) (sources.Reader, error) {
+		got = opts.Hooks
+		return datareader.DataReader(source, opts)
+	}
+	if _, err := m.Reader("yahoo"); err != nil {
+		t.Fatal(err)
+	}
+
+	if got == nil || got.OnWarning == nil || got.OnCacheHit == nil || got.OnCacheMiss == nil {
+		t.Errorf("Hooks = %+v, want OnWarning kept alongside the cache counters", got)
+	}
+}
+
+func TestManager_Shutdown(t *testing.T) {
+	m := datareader.NewManager(nil)
+	if _, err := m.Reader("yahoo"); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := m.Shutdown(context.Background()); err != nil {
+		t.Fatalf("Shutdown() error = %v", err)
+	}
+	if _, err := m.Reader("yahoo"); !errors.Is(err, sources.ErrClosed) {
+		t.Errorf("Reader() after Shutdown() error = %v, want ErrClosed", err)
+	}
+}
@@ -0,0 +1,88 @@
+package datareader
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/julianshen/gonp-datareader/calendar"
+)
+
+// ErrTooManyRows is matched by errors.Is for every *TooManyRowsError.
+var ErrTooManyRows = errors.New("too many rows")
+
+// TooManyRowsError is returned by Read, ReadDataset and the readers of
+// DataReader when a result, or the rows a request is expected to return,
+// exceeds Options.MaxRows.
+type TooManyRowsError struct {
+	// Symbol is the requested symbol
+	Symbol string
+	// Source is the source name
+	Source string
+	// Rows is the number of rows returned, or expected when Estimated
+	Rows int
+	// MaxRows is the configured limit
+	MaxRows int
+	// Estimated reports that the request was refused before it was sent,
+	// from the sessions the exchange calendar has in the range and the
+	// bar interval
+	Estimated bool
+}
+
+// Error implements the error interface.
+func (e *TooManyRowsError) Error() string {
+	rows := "rows"
+	if e.Estimated {
+		rows = "rows expected"
+	}
+	return fmt.Sprintf("%s (%s): %d %s exceed MaxRows %d; narrow the date range, use a longer interval or raise Options.MaxRows",
+		e.Symbol, e.Source, e.Rows, rows, e.MaxRows)
+}
+
+// Is implements error matching for errors.Is.
+func (e *TooManyRowsError) Is(target error) bool {
+	return target == ErrTooManyRows
+}
+
+// checkRowEstimate implements Options.MaxRows before a request is sent:
+// it fails when the exchange calendar of source has more sessions in the
+// range than allowed, scaled by the bars of Options.Interval per session.
+// Sources without a calendar are not checked.
+func checkRowEstimate(source, symbol string, start, end time.Time, opts *Options) error {
+	if opts == nil || opts.MaxRows <= 0 {
+		return nil
+	}
+	cal := opts.Calendar
+	if cal == nil {
+		cal = calendar.ForSource(source)
+	}
+	if cal == nil {
+		return nil
+	}
+
+	if rows := opts.Interval.Bars(cal.TradingDays(start, end)); rows > opts.MaxRows {
+		return &TooManyRowsError{Symbol: symbol, Source: source, Rows: rows, MaxRows: opts.MaxRows, Estimated: true}
+	}
+	return nil
+}
+
+// checkDataRows implements Options.MaxRows for data as returned by a
+// reader. Results of types ToDataset does not know are not counted.
+func checkDataRows(source, symbol string, data interface{}, opts *Options) error {
+	if opts == nil || opts.MaxRows <= 0 {
+		return nil
+	}
+	ds, err := ToDataset(symbol, data)
+	if err != nil {
+		return nil
+	}
+	return checkRows(source, symbol, ds.Len(), opts)
+}
+
+// checkRows implements Options.MaxRows for a result of rows rows.
+func checkRows(source, symbol string, rows int, opts *Options) error {
+	if opts == nil || opts.MaxRows <= 0 || rows <= opts.MaxRows {
+		return nil
+	}
+	return &TooManyRowsError{Symbol: symbol, Source: source, Rows: rows, MaxRows: opts.MaxRows}
+}
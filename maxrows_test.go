@@ -0,0 +1,91 @@
+package datareader_test
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	datareader "github.com/julianshen/gonp-datareader"
+)
+
+func TestMaxRows(t *testing.T) {
+	var requests int
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		w.Header().Set("Content-Type", "text/csv")
+		w.Write([]byte("Date,Open,High,Low,Close,Volume\n" +
+			"2024-01-02,187.15,188.44,183.89,185.64,82488674\n" +
+			"2024-01-03,184.22,185.88,183.43,184.25,58414460\n" +
+			"2024-01-04,182.15,183.09,180.88,181.91,71983570\n"))
+	}))
+	defer server.Close()
+
+	start := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	tests := []struct {
+		name         string
+		maxRows      int
+		end          time.Time
+		wantErr      bool
+		wantEstimate bool
+		wantRequests int
+	}{
+		{"no limit", 0, time.Date(2034, 1, 1, 0, 0, 0, 0, time.UTC), false, false, 1},
+		{"within limit", 10, time.Date(2024, 1, 5, 0, 0, 0, 0, time.UTC), false, false, 1},
+		{"estimate refused", 10, time.Date(2034, 1, 1, 0, 0, 0, 0, time.UTC), true, true, 0},
+		{"result too large", 2, time.Date(2024, 1, 2, 0, 0, 0, 0, time.UTC), true, false, 1},
+	}
+
+	for _, tt := range tests {
+		for _, read := range []struct {
+			name string
+			fn   func(opts *datareader.Options) error
+		}{
+			{"Read", func(opts *datareader.Options) error {
+				_, err := datareader.Read(context.Background(), "AAPL.US", "stooq", start, tt.end, opts)
+				return err
+			}},
+			{"ReadDataset", func(opts *datareader.Options) error {
+				_, err := datareader.ReadDataset(context.Background(), "AAPL.US", "stooq", start, tt.end, opts)
+				return err
+			}},
+			{"DataReader", func(opts *datareader.Options) error {
+				reader, err := datareader.DataReader("stooq", opts)
+				if err != nil {
+					return err
+				}
+				_, err = reader.ReadSingle(context.Background(), "AAPL.US", start, tt.end)
+				return err
+			}},
+		} {
+			t.Run(tt.name+"/"+read.name, func(t *testing.T) {
+				requests = 0
+				err := read.fn(&datareader.Options{
+					MaxRows:          tt.maxRows,
+					BaseURLOverrides: map[string]string{"stooq": server.URL + "?s=%s"},
+				})
+
+				if got := errors.Is(err, datareader.ErrTooManyRows); got != tt.wantErr {
+					t.Fatalf("error = %v, want ErrTooManyRows %v", err, tt.wantErr)
+				}
+				var tooMany *datareader.TooManyRowsError
+				if errors.As(err, &tooMany) && tooMany.Estimated != tt.wantEstimate {
+					t.Errorf("Estimated = %v, want %v", tooMany.Estimated, tt.wantEstimate)
+				}
+				if requests != tt.wantRequests {
+					t.Errorf("requests = %d, want %d", requests, tt.wantRequests)
+				}
+			})
+		}
+	}
+}
+
+func TestTooManyRowsError(t *testing.T) {
+	err := &datareader.TooManyRowsError{Symbol: "AAPL", Source: "yahoo", Rows: 2520, MaxRows: 1000, Estimated: true}
+	want := "AAPL (yahoo): 2520 rows expected exceed MaxRows 1000; narrow the date range, use a longer interval or raise Options.MaxRows"
+	if err.Error() != want {
+		t.Errorf("Error() = %q, want %q", err.Error(), want)
+	}
+}
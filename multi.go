@@ -0,0 +1,153 @@
+package datareader
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/julianshen/gonp-datareader/sources"
+)
+
+// ErrNotRequested is returned by MultiResult.Get for a symbol that was not
+// part of the request.
+var ErrNotRequested = errors.New("symbol not requested")
+
+// maxMultiWorkers limits how many symbols ReadMulti fetches at once.
+const maxMultiWorkers = 10
+
+// Result is the outcome of reading one symbol in a MultiResult.
+type Result[T any] struct {
+	// Symbol is the symbol as requested.
+	Symbol string
+	// Data holds the symbol's data; the zero value of T when Err is set.
+	Data T
+	// Err is the error reading the symbol, if any.
+	Err error
+}
+
+// MultiResult holds the results of reading several symbols, in request
+// order, with one entry per requested symbol. Unlike a reader's Read, a
+// failing symbol does not discard the others: its error is kept in its
+// entry.
+type MultiResult[T any] struct {
+	// Results holds one entry per requested symbol, in request order.
+	Results []Result[T]
+}
+
+// ReadMulti reads symbols from reader in parallel with ReadSingle, so
+// every source returns the same ordered, per-symbol result.
+//
+// # Example Usage
+//
+//	reader, err := datareader.DataReader("stooq", nil)
+//	results := datareader.ReadMulti(ctx, reader, []string{"AAPL.US", "MSFT.US"}, start, end)
+//	for _, r := range results.Results {
+//		if r.Err != nil {
+//			log.Printf("%s: %v", r.Symbol, r.Err)
+//			continue
+//		}
+//		data := r.Data.(*stooq.ParsedData)
+//	}
+func ReadMulti(ctx context.Context, reader sources.Reader, symbols []string, start, end time.Time) *MultiResult[interface{}] {
+	return readMulti[interface{}](ctx, reader, symbols, start, end)
+}
+
+// ReadMulti reads symbols like the package-level ReadMulti, with each
+// entry's Data typed as T.
+func (r *Reader[T]) ReadMulti(ctx context.Context, symbols []string, start, end time.Time) *MultiResult[T] {
+	return readMulti[T](ctx, r.Reader, symbols, start, end)
+}
+
+// readMulti fetches symbols in parallel, storing each result at the
+// symbol's position in the request.
+func readMulti[T any](ctx context.Context, reader sources.Reader, symbols []string, start, end time.Time) *MultiResult[T] {
+	m := &MultiResult[T]{Results: make([]Result[T], len(symbols))}
+
+	workers := maxMultiWorkers
+	if len(symbols) < workers {
+		workers = len(symbols)
+	}
+	semaphore := make(chan struct{}, workers)
+	done := make(chan struct{}, len(symbols))
+
+	for i, symbol := range symbols {
+		go func() {
+			semaphore <- struct{}{}
+			defer func() { <-semaphore }()
+
+			res := Result[T]{Symbol: symbol}
+			data, err := reader.ReadSingle(ctx, symbol, start, end)
+			if err == nil {
+				res.Data, err = as[T](reader.Source(), data)
+			}
+			res.Err = err
+			m.Results[i] = res
+			done <- struct{}{}
+		}()
+	}
+	for range symbols {
+		<-done
+	}
+	return m
+}
+
+// Len returns the number of entries.
+func (m *MultiResult[T]) Len() int {
+	return len(m.Results)
+}
+
+// Symbols returns the requested symbols in request order.
+func (m *MultiResult[T]) Symbols() []string {
+	symbols := make([]string, len(m.Results))
+	for i, r := range m.Results {
+		symbols[i] = r.Symbol
+	}
+	return symbols
+}
+
+// Lookup returns the entry of symbol, as requested, and whether it was
+// requested. For a symbol requested twice, the first entry is returned.
+func (m *MultiResult[T]) Lookup(symbol string) (Result[T], bool) {
+	for _, r := range m.Results {
+		if r.Symbol == symbol {
+			return r, true
+		}
+	}
+	return Result[T]{}, false
+}
+
+// Get returns the data of symbol and its read error, or ErrNotRequested
+// when symbol was not part of the request.
+func (m *MultiResult[T]) Get(symbol string) (T, error) {
+	r, ok := m.Lookup(symbol)
+	if !ok {
+		var zero T
+		return zero, fmt.Errorf("%w: %s", ErrNotRequested, symbol)
+	}
+	return r.Data, r.Err
+}
+
+// Map returns the data of the symbols read successfully, keyed by symbol,
+// in the form returned by a reader's Read.
+func (m *MultiResult[T]) Map() map[string]T {
+	data := make(map[string]T, len(m.Results))
+	for _, r := range m.Results {
+		if r.Err == nil {
+			data[r.Symbol] = r.Data
+		}
+	}
+	return data
+}
+
+// Err joins the errors of all failed symbols, each prefixed with its
+// symbol; nil when every symbol was read.
+func (m *MultiResult[T]) Err() error {
+	var errs []error
+	for _, r := range m.Results {
+		if r.Err != nil {
+			errs = append(errs, fmt.Errorf("%s: %w", r.Symbol, r.Err))
+		}
+	}
+	return errors.Join(errs...)
+}
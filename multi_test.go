@@ -0,0 +1,63 @@
+package datareader_test
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"testing"
+	"time"
+
+	datareader "github.com/julianshen/gonp-datareader"
+	"github.com/julianshen/gonp-datareader/sources/stooq"
+)
+
+func TestReadMulti(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Query().Get("s") == "BAD.US" {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		w.Write([]byte("Date,Open,High,Low,Close,Volume\n2024-01-02,1,1,1,10,100\n"))
+	}))
+	defer server.Close()
+
+	start := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	end := time.Date(2024, 1, 31, 0, 0, 0, 0, time.UTC)
+	symbols := []string{"CCC.US", "BAD.US", "AAA.US", "BBB.US"}
+
+	reader := stooq.NewStooqReaderWithBaseURL(nil, server.URL+"?s=%s")
+	results := datareader.ReadMulti(context.Background(), reader, symbols, start, end)
+
+	if !reflect.DeepEqual(results.Symbols(), symbols) {
+		t.Errorf("Symbols() = %v, want %v", results.Symbols(), symbols)
+	}
+	if results.Results[1].Err == nil {
+		t.Error("BAD.US entry has no error")
+	}
+	if err := results.Err(); err == nil {
+		t.Error("Err() = nil, want the BAD.US error")
+	}
+	if data, ok := results.Results[0].Data.(*stooq.ParsedData); !ok || len(data.Rows) != 1 {
+		t.Errorf("CCC.US data = %#v", results.Results[0].Data)
+	}
+	if m := results.Map(); len(m) != 3 {
+		t.Errorf("Map() has %d entries, want 3", len(m))
+	}
+
+	typed := datareader.Typed[*stooq.ParsedData](reader).ReadMulti(context.Background(), symbols, start, end)
+	data, err := typed.Get("AAA.US")
+	if err != nil || len(data.Rows) != 1 {
+		t.Errorf("Get(AAA.US) = %v, %v", data, err)
+	}
+	if _, err := typed.Get("BAD.US"); err == nil {
+		t.Error("Get(BAD.US) returned nil error")
+	}
+	if _, err := typed.Get("ZZZ.US"); !errors.Is(err, datareader.ErrNotRequested) {
+		t.Errorf("Get(ZZZ.US) error = %v, want ErrNotRequested", err)
+	}
+	if r, ok := typed.Lookup("BBB.US"); !ok || r.Symbol != "BBB.US" {
+		t.Errorf("Lookup(BBB.US) = %v, %v", r, ok)
+	}
+}
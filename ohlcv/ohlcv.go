@@ -0,0 +1,171 @@
+// Package ohlcv provides Bars, one typed representation of the price bars
+// returned by every equity source, so code handling Yahoo, Stooq, Alpha
+// Vantage, IEX, Tiingo, TWSE or FinMind data needs neither per-source
+// column names nor string-to-float parsing.
+//
+// # Example Usage
+//
+//	data, _ := reader.ReadSingle(ctx, "AAPL", start, end)
+//	bars, err := data.(ohlcv.Provider).Bars()
+//	for i, date := range bars.Date {
+//		fmt.Println(date, bars.Close[i], bars.Volume[i])
+//	}
+package ohlcv
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"slices"
+	"time"
+
+	"github.com/julianshen/gonp-datareader/dataset"
+	"github.com/julianshen/gonp-datareader/sources"
+)
+
+// ErrNoClose indicates data has no close column to build bars from.
+var ErrNoClose = errors.New("no close column")
+
+// Bars is a series of OHLCV bars in columns aligned with Date. Missing
+// prices are NaN and missing volumes 0. Volumes are rounded to whole
+// units.
+type Bars struct {
+	Date  []time.Time
+	Open  []float64
+	High  []float64
+	Low   []float64
+	Close []float64
+	// AdjClose holds the adjusted close of sources publishing one, such as
+	// Yahoo, Tiingo and Alpha Vantage's adjusted series; nil otherwise.
+	AdjClose []float64
+	Volume   []int64
+}
+
+// Provider is implemented by the ParsedData of every equity source.
+type Provider interface {
+	// Bars returns the data as Bars.
+	Bars() (*Bars, error)
+}
+
+// Len returns the number of bars.
+func (b *Bars) Len() int {
+	if b == nil {
+		return 0
+	}
+	return len(b.Date)
+}
+
+// Columns lists the column names each Bars field is read from, in order
+// of preference, covering the naming of every source.
+var Columns = struct {
+	Open, High, Low, Close, Volume, AdjClose []string
+}{
+	Open:     []string{"Open", "open"},
+	High:     []string{"High", "high", "max"},
+	Low:      []string{"Low", "low", "min"},
+	Close:    []string{"Close", "close"},
+	Volume:   []string{"Volume", "volume", "Trading_Volume"},
+	AdjClose: []string{"Adj Close", "adjClose", "AdjClose"},
+}
+
+// FromTimeSeries builds bars from the ParsedData of any source, reading
+// the columns named in Columns. Bar fields without a column are NaN (0 for
+// Volume), except AdjClose, which is nil. It returns an error wrapping
+// ErrNoClose when ts has no close column.
+func FromTimeSeries(ts sources.TimeSeries) (*Bars, error) {
+	dates, err := ts.DateIndex()
+	if err != nil {
+		return nil, err
+	}
+	names := make(map[string]bool)
+	for _, name := range ts.ColumnNames() {
+		names[name] = true
+	}
+	return build(dates, ts.ColumnNames(), func(name string) ([]float64, error) {
+		if !names[name] {
+			return nil, nil
+		}
+		return ts.Float64Column(name)
+	})
+}
+
+// FromDataset builds bars from a copy of the columns of ds as
+// FromTimeSeries does.
+func FromDataset(ds *dataset.Dataset) (*Bars, error) {
+	if ds == nil {
+		return nil, fmt.Errorf("%w (have no columns)", ErrNoClose)
+	}
+	dates := append([]time.Time(nil), ds.Dates...)
+	return build(dates, ds.ColumnNames(), func(name string) ([]float64, error) {
+		values, _ := ds.Column(name)
+		return slices.Clone(values), nil
+	})
+}
+
+// build assembles bars from the columns returned by column, which returns
+// nil for absent columns.
+func build(dates []time.Time, have []string, column func(string) ([]float64, error)) (*Bars, error) {
+	lookup := func(names []string) ([]float64, error) {
+		for _, name := range names {
+			values, err := column(name)
+			if err != nil {
+				return nil, fmt.Errorf("column %s: %w", name, err)
+			}
+			if values != nil {
+				return values, nil
+			}
+		}
+		return nil, nil
+	}
+
+	closes, err := lookup(Columns.Close)
+	if err != nil {
+		return nil, err
+	}
+	if closes == nil {
+		return nil, fmt.Errorf("%w (have %v)", ErrNoClose, have)
+	}
+
+	bars := &Bars{Date: dates, Close: closes}
+	for _, field := range []struct {
+		names []string
+		dst   *[]float64
+	}{
+		{Columns.Open, &bars.Open},
+		{Columns.High, &bars.High},
+		{Columns.Low, &bars.Low},
+		{Columns.AdjClose, &bars.AdjClose},
+	} {
+		values, err := lookup(field.names)
+		if err != nil {
+			return nil, err
+		}
+		*field.dst = values
+	}
+	for _, prices := range []*[]float64{&bars.Open, &bars.High, &bars.Low} {
+		if *prices == nil {
+			*prices = nans(len(dates))
+		}
+	}
+
+	volumes, err := lookup(Columns.Volume)
+	if err != nil {
+		return nil, err
+	}
+	bars.Volume = make([]int64, len(dates))
+	for i, v := range volumes {
+		if !math.IsNaN(v) {
+			bars.Volume[i] = int64(math.Round(v))
+		}
+	}
+	return bars, nil
+}
+
+// nans returns n NaN values.
+func nans(n int) []float64 {
+	values := make([]float64, n)
+	for i := range values {
+		values[i] = math.NaN()
+	}
+	return values
+}
@@ -0,0 +1,127 @@
+package ohlcv_test
+
+import (
+	"errors"
+	"math"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/julianshen/gonp-datareader/dataset"
+	"github.com/julianshen/gonp-datareader/ohlcv"
+	"github.com/julianshen/gonp-datareader/sources/alphavantage"
+	"github.com/julianshen/gonp-datareader/sources/finmind"
+	"github.com/julianshen/gonp-datareader/sources/fred"
+	"github.com/julianshen/gonp-datareader/sources/iex"
+	"github.com/julianshen/gonp-datareader/sources/stooq"
+	"github.com/julianshen/gonp-datareader/sources/tiingo"
+	"github.com/julianshen/gonp-datareader/sources/twse"
+	"github.com/julianshen/gonp-datareader/sources/yahoo"
+)
+
+// Compile-time checks that the equity sources implement ohlcv.Provider
+var (
+	_ ohlcv.Provider = (*yahoo.ParsedData)(nil)
+	_ ohlcv.Provider = (*stooq.ParsedData)(nil)
+	_ ohlcv.Provider = (*alphavantage.ParsedData)(nil)
+	_ ohlcv.Provider = (*iex.ParsedData)(nil)
+	_ ohlcv.Provider = (*tiingo.ParsedData)(nil)
+	_ ohlcv.Provider = (*twse.ParsedData)(nil)
+	_ ohlcv.Provider = (*finmind.ParsedData)(nil)
+)
+
+func TestProvider_AllSources(t *testing.T) {
+	day1 := time.Date(2024, 1, 2, 0, 0, 0, 0, time.UTC)
+	day2 := time.Date(2024, 1, 3, 0, 0, 0, 0, time.UTC)
+	rows := []map[string]string{
+		{"Date": "2024-01-02", "Open": "1.5", "High": "2", "Low": "1", "Close": "2", "Adj Close": "1.9", "Volume": "100"},
+		{"Date": "2024-01-03", "Open": "null", "High": "3", "Low": "2", "Close": "3", "Adj Close": "2.9", "Volume": ""},
+	}
+	columns := []string{"Date", "Open", "High", "Low", "Close", "Adj Close", "Volume"}
+	unadjusted := []string{"Date", "Open", "High", "Low", "Close", "Volume"}
+
+	tests := []struct {
+		name         string
+		data         ohlcv.Provider
+		wantAdjClose bool
+	}{
+		{"yahoo", &yahoo.ParsedData{Columns: columns, Rows: rows}, true},
+		{"stooq", &stooq.ParsedData{Columns: unadjusted, Rows: rows}, false},
+		{"alphavantage", &alphavantage.ParsedData{Columns: columns, Rows: rows}, true},
+		{"iex", &iex.ParsedData{Columns: unadjusted, Rows: rows}, false},
+		{"tiingo", &tiingo.ParsedData{
+			Dates: []string{"2024-01-02", "2024-01-03"},
+			Prices: []tiingo.PriceData{
+				{Open: 1.5, High: 2, Low: 1, Close: 2, AdjClose: 1.9, Volume: 100},
+				{Open: math.NaN(), High: 3, Low: 2, Close: 3, AdjClose: 2.9, Volume: math.NaN()},
+			},
+		}, true},
+		{"finmind", &finmind.ParsedData{
+			Columns: []string{"date", "stock_id", "open", "max", "min", "close", "Trading_Volume"},
+			Rows: []map[string]string{
+				{"date": "2024-01-02", "stock_id": "2330", "open": "1.5", "max": "2", "min": "1", "close": "2", "Trading_Volume": "100"},
+				{"date": "2024-01-03", "stock_id": "2330", "open": "", "max": "3", "min": "2", "close": "3", "Trading_Volume": ""},
+			},
+		}, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			bars, err := tt.data.Bars()
+			if err != nil {
+				t.Fatalf("Bars() error = %v", err)
+			}
+			if bars.Len() != 2 || !bars.Date[0].Equal(day1) || !bars.Date[1].Equal(day2) {
+				t.Fatalf("Date = %v", bars.Date)
+			}
+			if bars.Open[0] != 1.5 || !math.IsNaN(bars.Open[1]) {
+				t.Errorf("Open = %v, want [1.5 NaN]", bars.Open)
+			}
+			if !reflect.DeepEqual(bars.High, []float64{2, 3}) || !reflect.DeepEqual(bars.Low, []float64{1, 2}) || !reflect.DeepEqual(bars.Close, []float64{2, 3}) {
+				t.Errorf("High, Low, Close = %v %v %v", bars.High, bars.Low, bars.Close)
+			}
+			if !reflect.DeepEqual(bars.Volume, []int64{100, 0}) {
+				t.Errorf("Volume = %v, want [100 0]", bars.Volume)
+			}
+			if got := bars.AdjClose != nil; got != tt.wantAdjClose {
+				t.Errorf("AdjClose = %v, want present %v", bars.AdjClose, tt.wantAdjClose)
+			}
+		})
+	}
+}
+
+func TestFromTimeSeries_NoClose(t *testing.T) {
+	data := &fred.ParsedData{Dates: []string{"2024-01-02"}, Values: []string{"3.9"}}
+	if _, err := ohlcv.FromTimeSeries(data); !errors.Is(err, ohlcv.ErrNoClose) {
+		t.Errorf("FromTimeSeries(fred) error = %v, want ErrNoClose", err)
+	}
+}
+
+func TestFromDataset(t *testing.T) {
+	dates := []time.Time{time.Date(2024, 1, 2, 0, 0, 0, 0, time.UTC)}
+	ds := dataset.New("AAPL", "yahoo", dates)
+	if err := ds.AddColumn("Close", []float64{185.6}); err != nil {
+		t.Fatal(err)
+	}
+	if err := ds.AddColumn("Volume", []float64{1.6}); err != nil {
+		t.Fatal(err)
+	}
+
+	bars, err := ohlcv.FromDataset(ds)
+	if err != nil {
+		t.Fatalf("FromDataset() error = %v", err)
+	}
+	if bars.Close[0] != 185.6 || !math.IsNaN(bars.Open[0]) || bars.Volume[0] != 2 || bars.AdjClose != nil {
+		t.Errorf("FromDataset() = %+v", bars)
+	}
+
+	// The bars do not share the dataset's columns
+	bars.Close[0] = 0
+	if closes, _ := ds.Column("Close"); closes[0] != 185.6 {
+		t.Error("FromDataset() modified the dataset")
+	}
+
+	if _, err := ohlcv.FromDataset(nil); !errors.Is(err, ohlcv.ErrNoClose) {
+		t.Errorf("FromDataset(nil) error = %v, want ErrNoClose", err)
+	}
+}
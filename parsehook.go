@@ -0,0 +1,112 @@
+package datareader
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"sync"
+
+	"github.com/julianshen/gonp-datareader/dataset"
+	internalhttp "github.com/julianshen/gonp-datareader/internal/http"
+)
+
+// ParseHook extracts vendor-specific fields the built-in parser of a
+// source ignores. It receives the raw body of a response, after charset
+// decoding, and the dataset parsed from it, which it may modify, for
+// example by adding columns. Returning an error fails the read.
+type ParseHook func(raw []byte, ds *dataset.Dataset) error
+
+// parseHooks holds the hooks added with RegisterParseHook, by source.
+var parseHooks = struct {
+	sync.RWMutex
+	hooks map[string][]ParseHook
+}{hooks: make(map[string][]ParseHook)}
+
+// RegisterParseHook adds a hook that ReadDataset runs after parsing data
+// from source, without forking the source's parser. ReadDataset calls each
+// hook of the source, in registration order, once for every successful
+// response the read received: one per symbol for most sources, more when
+// opt-in metadata such as Options.DelistingMeta costs extra requests.
+// Readers used directly are not affected.
+//
+// RegisterParseHook is typically called from a package's init function. It
+// is safe for concurrent use.
+//
+// # Example Usage
+//
+//	err := datareader.RegisterParseHook("tiingo", func(raw []byte, ds *dataset.Dataset) error {
+//		var rows []struct {
+//			DivCash float64 `json:"divCash"`
+//		}
+//		if err := json.Unmarshal(raw, &rows); err != nil || len(rows) != ds.Len() {
+//			return nil // not a price response
+//		}
+//		dividends := make([]float64, len(rows))
+//		for i, r := range rows {
+//			dividends[i] = r.DivCash
+//		}
+//		return ds.AddColumn("Dividend", dividends)
+//	})
+func RegisterParseHook(source string, hook ParseHook) error {
+	if source == "" {
+		return fmt.Errorf("%w: source cannot be empty", ErrUnknownSource)
+	}
+	if hook == nil {
+		return fmt.Errorf("register %s parse hook: nil hook", source)
+	}
+
+	parseHooks.Lock()
+	defer parseHooks.Unlock()
+	parseHooks.hooks[source] = append(parseHooks.hooks[source], hook)
+	return nil
+}
+
+// UnregisterParseHooks removes the hooks added for source with
+// RegisterParseHook and reports whether there were any. It is mainly meant
+// for tests, which can undo their registrations with t.Cleanup.
+func UnregisterParseHooks(source string) bool {
+	parseHooks.Lock()
+	defer parseHooks.Unlock()
+	_, ok := parseHooks.hooks[source]
+	delete(parseHooks.hooks, source)
+	return ok
+}
+
+// sourceParseHooks returns the hooks registered for source.
+func sourceParseHooks(source string) []ParseHook {
+	parseHooks.RLock()
+	defer parseHooks.RUnlock()
+	return append([]ParseHook(nil), parseHooks.hooks[source]...)
+}
+
+// recordBodies returns a copy of ctx that records the body of each
+// successful response, and a func returning the bodies recorded so far.
+func recordBodies(ctx context.Context) (context.Context, func() [][]byte) {
+	var mu sync.Mutex
+	var bodies [][]byte
+
+	call := internalhttp.CallOptionsFrom(ctx)
+	call.OnBody = func(body []byte) {
+		mu.Lock()
+		defer mu.Unlock()
+		bodies = append(bodies, bytes.Clone(body))
+	}
+	return internalhttp.WithCallOptions(ctx, call), func() [][]byte {
+		mu.Lock()
+		defer mu.Unlock()
+		return bodies
+	}
+}
+
+// runParseHooks implements RegisterParseHook for a dataset read from
+// source, given the bodies of the read.
+func runParseHooks(source string, hooks []ParseHook, bodies [][]byte, ds *dataset.Dataset) error {
+	for _, body := range bodies {
+		for _, hook := range hooks {
+			if err := hook(body, ds); err != nil {
+				return fmt.Errorf("%s parse hook: %w", source, err)
+			}
+		}
+	}
+	return nil
+}
@@ -0,0 +1,107 @@
+package datareader_test
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	datareader "github.com/julianshen/gonp-datareader"
+	"github.com/julianshen/gonp-datareader/dataset"
+)
+
+func TestRegisterParseHook(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`[{"date": "2024-01-02T00:00:00.000Z", "close": 100, "divCash": 0},
+			{"date": "2024-01-03T00:00:00.000Z", "close": 101, "divCash": 0.24}]`))
+	}))
+	defer server.Close()
+
+	errHook := errors.New("hook failed")
+	tests := []struct {
+		name    string
+		hook    datareader.ParseHook
+		want    []float64
+		wantErr error
+	}{
+		{
+			name: "adds column",
+			hook: func(raw []byte, ds *dataset.Dataset) error {
+				var rows []struct {
+					DivCash float64 `json:"divCash"`
+				}
+				if err := json.Unmarshal(raw, &rows); err != nil {
+					return err
+				}
+				dividends := make([]float64, len(rows))
+				for i, r := range rows {
+					dividends[i] = r.DivCash
+				}
+				return ds.AddColumn("Dividend", dividends)
+			},
+			want: []float64{0, 0.24},
+		},
+		{
+			name:    "fails read",
+			hook:    func(raw []byte, ds *dataset.Dataset) error { return errHook },
+			wantErr: errHook,
+		},
+	}
+
+	opts := &datareader.Options{
+		APIKey:           "key",
+		BaseURLOverrides: map[string]string{"tiingo": server.URL + "/%s/prices"},
+	}
+	start := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	end := time.Date(2024, 1, 31, 0, 0, 0, 0, time.UTC)
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := datareader.RegisterParseHook("tiingo", tt.hook); err != nil {
+				t.Fatalf("RegisterParseHook() error = %v", err)
+			}
+			t.Cleanup(func() { datareader.UnregisterParseHooks("tiingo") })
+
+			ds, err := datareader.ReadDataset(context.Background(), "AAPL", "tiingo", start, end, opts)
+			if tt.wantErr != nil {
+				if !errors.Is(err, tt.wantErr) {
+					t.Errorf("ReadDataset() error = %v, want %v", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("ReadDataset() error = %v", err)
+			}
+			got, ok := ds.Column("Dividend")
+			if !ok || len(got) != len(tt.want) || got[0] != tt.want[0] || got[1] != tt.want[1] {
+				t.Errorf("Dividend = %v, want %v", got, tt.want)
+			}
+		})
+	}
+
+	// Hooks are removed, so the column is gone
+	ds, err := datareader.ReadDataset(context.Background(), "AAPL", "tiingo", start, end, opts)
+	if err != nil {
+		t.Fatalf("ReadDataset() error = %v", err)
+	}
+	if _, ok := ds.Column("Dividend"); ok {
+		t.Error("Dividend column present after UnregisterParseHooks")
+	}
+}
+
+func TestRegisterParseHook_Invalid(t *testing.T) {
+	hook := func(raw []byte, ds *dataset.Dataset) error { return nil }
+	if err := datareader.RegisterParseHook("", hook); !errors.Is(err, datareader.ErrUnknownSource) {
+		t.Errorf("RegisterParseHook(\"\") error = %v, want ErrUnknownSource", err)
+	}
+	if err := datareader.RegisterParseHook("tiingo", nil); err == nil {
+		t.Error("RegisterParseHook(nil) should fail")
+	}
+	if datareader.UnregisterParseHooks("tiingo") {
+		t.Error("UnregisterParseHooks() = true without hooks")
+	}
+}
@@ -0,0 +1,50 @@
+package datareader
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/julianshen/gonp-datareader/sources"
+)
+
+// ErrPingNotSupported is returned by Ping for readers that neither
+// implement sources.Pinger nor have a sample symbol to read.
+var ErrPingNotSupported = errors.New("source does not support ping")
+
+// pingWindow is the date range Ping reads for readers without a Ping
+// method.
+const pingWindow = 30 * 24 * time.Hour
+
+// Ping checks that the upstream of reader answers and accepts its
+// credentials, for health checks. Readers implementing sources.Pinger are
+// asked directly; others read the last 30 days of the source's sample
+// symbol (see SampleSymbol), which costs one request of quota. A response
+// without observations in the window counts as healthy, as economic
+// series are published less often. Responses served from the reader's
+// cache do not reach the upstream, so ping with a reader created without
+// CacheDir and MemoryCacheSize.
+//
+// # Example Usage
+//
+//	reader, _ := datareader.DataReader("fred", &datareader.Options{APIKey: key})
+//	if err := datareader.Ping(ctx, reader); err != nil {
+//		log.Printf("fred unhealthy: %v", err) // e.g. authentication required
+//	}
+func Ping(ctx context.Context, reader sources.Reader) error {
+	if p, ok := reader.(sources.Pinger); ok {
+		return p.Ping(ctx)
+	}
+
+	symbol := SampleSymbol(reader.Source())
+	if symbol == "" {
+		return fmt.Errorf("%w: %s", ErrPingNotSupported, reader.Source())
+	}
+	end := time.Now()
+	_, err := reader.ReadSingle(ctx, symbol, end.Add(-pingWindow), end)
+	if errors.Is(err, sources.ErrNoData) {
+		return nil
+	}
+	return err
+}
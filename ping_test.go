@@ -0,0 +1,71 @@
+package datareader_test
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	datareader "github.com/julianshen/gonp-datareader"
+	"github.com/julianshen/gonp-datareader/sources"
+	"github.com/julianshen/gonp-datareader/sources/stooq"
+)
+
+// pingingReader is a fakeReader with its own health check.
+type pingingReader struct {
+	fakeReader
+	err error
+}
+
+func (r *pingingReader) Ping(ctx context.Context) error {
+	return r.err
+}
+
+func TestPing(t *testing.T) {
+	tests := []struct {
+		name    string
+		status  int
+		body    string
+		wantErr error
+	}{
+		{"data", http.StatusOK, "Date,Open,High,Low,Close,Volume\n2024-01-02,1,1,1,1,10\n", nil},
+		{"no data in window", http.StatusOK, "No data", nil},
+		{"rejected", http.StatusUnauthorized, "", sources.ErrAuthRequired},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var gotSymbol string
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				gotSymbol = r.URL.Query().Get("s")
+				w.WriteHeader(tt.status)
+				w.Write([]byte(tt.body))
+			}))
+			defer server.Close()
+
+			reader := stooq.NewStooqReaderWithBaseURL(nil, server.URL+"?s=%s")
+			err := datareader.Ping(context.Background(), reader)
+			if tt.wantErr == nil && err != nil || tt.wantErr != nil && !errors.Is(err, tt.wantErr) {
+				t.Errorf("Ping() error = %v, want %v", err, tt.wantErr)
+			}
+			if want := datareader.SampleSymbol("stooq"); gotSymbol != want {
+				t.Errorf("Ping() read %q, want the sample symbol %q", gotSymbol, want)
+			}
+		})
+	}
+}
+
+func TestPing_Pinger(t *testing.T) {
+	down := errors.New("status page says down")
+	reader := &pingingReader{fakeReader: fakeReader{BaseSource: sources.NewBaseSource("custom")}, err: down}
+	if err := datareader.Ping(context.Background(), reader); !errors.Is(err, down) {
+		t.Errorf("Ping() error = %v, want the reader's Ping error", err)
+	}
+
+	// Without a Ping method or a sample symbol there is nothing to read
+	plain := &fakeReader{BaseSource: sources.NewBaseSource("custom")}
+	if err := datareader.Ping(context.Background(), plain); !errors.Is(err, datareader.ErrPingNotSupported) {
+		t.Errorf("Ping() error = %v, want ErrPingNotSupported", err)
+	}
+}
@@ -0,0 +1,195 @@
+package datareader
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+	"time"
+
+	"github.com/julianshen/gonp-datareader/dataset"
+	"github.com/julianshen/gonp-datareader/sources"
+)
+
+// MetaProvisional is the Meta key listing the dates of provisional bars,
+// bars whose period had not ended at the exchange when they were read,
+// such as today's daily bar during trading hours. Dates are RFC 3339 and
+// comma-separated. ToDataset also marks these rows "provisional" in
+// Dataset.Flags. See Options.ExcludeIncompleteBar.
+const MetaProvisional = "provisional"
+
+// flagProvisional is the Dataset.Flags value of provisional rows.
+const flagProvisional = "provisional"
+
+// sessionClose is the end of an exchange's daily session.
+type sessionClose struct {
+	// location is the exchange's time zone, and offset its standard UTC
+	// offset in seconds, used when the time zone database is unavailable
+	location string
+	offset   int
+
+	hour, minute int
+
+	// periodEnd reports whether the source dates weekly and monthly bars
+	// at the last trading day of their period rather than at its start
+	periodEnd bool
+}
+
+// nyseClose is the close of the New York Stock Exchange.
+var nyseClose = sessionClose{location: "America/New_York", offset: -5 * 3600, hour: 16}
+
+// datedAtPeriodEnd returns c for a source dating weekly and monthly bars
+// at the last trading day of their period.
+func (c sessionClose) datedAtPeriodEnd() sessionClose {
+	c.periodEnd = true
+	return c
+}
+
+// sessionCloses maps the price sources to the close of their exchange.
+// Sources covering several exchanges (yahoo, stooq) use New York's close,
+// which follows the close of the same day in Asia and Europe. Yahoo dates
+// weekly and monthly bars at the start of the period; Tiingo, Alpha
+// Vantage and Stooq at its last trading day.
+var sessionCloses = map[string]sessionClose{
+	"yahoo":        nyseClose,
+	"tiingo":       nyseClose.datedAtPeriodEnd(),
+	"iex":          nyseClose,
+	"alphavantage": nyseClose.datedAtPeriodEnd(),
+	"stooq":        nyseClose.datedAtPeriodEnd(),
+	"twse":         {location: "Asia/Taipei", offset: 8 * 3600, hour: 13, minute: 30},
+	"finmind":      {location: "Asia/Taipei", offset: 8 * 3600, hour: 13, minute: 30},
+}
+
+// on returns the close of the session on day's calendar date.
+func (c sessionClose) on(day time.Time) time.Time {
+	loc, err := time.LoadLocation(c.location)
+	if err != nil {
+		// The standard offset gives the later close in UTC, so bars are
+		// provisional rather than final around daylight saving changes
+		loc = time.FixedZone(c.location, c.offset)
+	}
+	y, m, d := day.Date()
+	return time.Date(y, m, d, c.hour, c.minute, 0, 0, loc)
+}
+
+// barEnd returns the time the bar dated date ends: after its intraday
+// length, or at the close of the last day of its day, week or month.
+// Weekly and monthly bars of sources dating them at the period's last
+// trading day end at the close of that day; those dated at the period's
+// start end at the close of the Sunday ending the ISO week or of the last
+// day of the month.
+func barEnd(date time.Time, interval sources.Interval, session sessionClose) time.Time {
+	switch interval = interval.OrDefault(); {
+	case interval.IsIntraday():
+		return date.Add(interval.Duration())
+	case session.periodEnd:
+		return session.on(date)
+	case interval == sources.Interval1wk:
+		return session.on(date.AddDate(0, 0, (7-int(date.Weekday()))%7))
+	case interval == sources.Interval1mo:
+		y, m, _ := date.Date()
+		return session.on(time.Date(y, m+1, 0, 0, 0, 0, 0, time.UTC))
+	default:
+		return session.on(date)
+	}
+}
+
+// markIncomplete implements Options.ExcludeIncompleteBar for one result of
+// a price source: it drops the bars that have not ended at now, or records
+// them in Meta. Results without dates or a Meta field are left as is.
+func markIncomplete(data interface{}, source string, opts *Options, now time.Time) {
+	session, ok := sessionCloses[source]
+	if !ok {
+		return
+	}
+	rs, ok := data.(sources.RowSelector)
+	if !ok {
+		return
+	}
+	dates, err := rs.DateIndex()
+	if err != nil {
+		return
+	}
+
+	var interval sources.Interval
+	if opts != nil {
+		interval = opts.Interval
+	}
+	var kept []int
+	var provisional []string
+	for i, date := range dates {
+		if now.Before(barEnd(date, interval, session)) {
+			provisional = append(provisional, date.Format(time.RFC3339))
+		} else {
+			kept = append(kept, i)
+		}
+	}
+	if len(provisional) == 0 {
+		return
+	}
+
+	list := strings.Join(provisional, ",")
+	if opts != nil && opts.ExcludeIncompleteBar {
+		rs.SelectRows(kept)
+		addMeta(data, func(meta map[string]string) map[string]string {
+			return sources.AddWarning(meta, sources.Warning{
+				Code:    sources.WarnProvisional,
+				Message: fmt.Sprintf("excluded provisional bars of %s", list),
+			})
+		})
+		return
+	}
+	addMeta(data, func(meta map[string]string) map[string]string {
+		// Already flagged, e.g. by the reader's own finish
+		if meta[MetaProvisional] == list {
+			return meta
+		}
+		meta = sources.AddWarning(meta, sources.Warning{
+			Code:    sources.WarnProvisional,
+			Message: fmt.Sprintf("bars of %s are provisional until the session closes", list),
+		})
+		meta[MetaProvisional] = list
+		return meta
+	})
+}
+
+// addMeta replaces the Meta field of data, a pointer to a ParsedData
+// struct, with update applied to it.
+func addMeta(data interface{}, update func(map[string]string) map[string]string) {
+	v := reflect.ValueOf(data)
+	if v.Kind() != reflect.Pointer || v.IsNil() || v.Elem().Kind() != reflect.Struct {
+		return
+	}
+	field := v.Elem().FieldByName("Meta")
+	if !field.IsValid() || !field.CanSet() || field.Type() != reflect.TypeOf(map[string]string(nil)) {
+		return
+	}
+	meta := update(field.Interface().(map[string]string))
+	field.Set(reflect.ValueOf(meta))
+}
+
+// flagProvisionalRows marks the rows of ds listed in
+// Meta[MetaProvisional] in ds.Flags.
+func flagProvisionalRows(ds *dataset.Dataset) {
+	list := ds.Meta[MetaProvisional]
+	if list == "" {
+		return
+	}
+	provisional := make(map[string]bool)
+	for _, date := range strings.Split(list, ",") {
+		provisional[date] = true
+	}
+
+	for i, date := range ds.Dates {
+		if !provisional[date.Format(time.RFC3339)] {
+			continue
+		}
+		if ds.Flags == nil {
+			ds.Flags = make([]string, len(ds.Dates))
+		}
+		if ds.Flags[i] == "" {
+			ds.Flags[i] = flagProvisional
+		} else {
+			ds.Flags[i] += "," + flagProvisional
+		}
+	}
+}
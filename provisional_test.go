@@ -0,0 +1,172 @@
+package datareader_test
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	datareader "github.com/julianshen/gonp-datareader"
+	"github.com/julianshen/gonp-datareader/sources"
+	"github.com/julianshen/gonp-datareader/sources/stooq"
+)
+
+// provisionalServer serves Stooq daily bars for a closed session a week ago
+// and for tomorrow, whose session has not closed anywhere yet.
+func provisionalServer(t *testing.T) (*httptest.Server, time.Time, time.Time) {
+	t.Helper()
+
+	today := time.Now().UTC()
+	closed := time.Date(today.Year(), today.Month(), today.Day()-7, 0, 0, 0, 0, time.UTC)
+	open := time.Date(today.Year(), today.Month(), today.Day()+1, 0, 0, 0, 0, time.UTC)
+	csv := fmt.Sprintf("Date,Open,High,Low,Close,Volume\n%s,1,2,1,1.5,100\n%s,2,3,1,2.5,200\n",
+		closed.Format("2006-01-02"), open.Format("2006-01-02"))
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "text/csv")
+		w.Write([]byte(csv))
+	}))
+	t.Cleanup(server.Close)
+	return server, closed, open
+}
+
+func TestRead_ProvisionalBar(t *testing.T) {
+	server, _, open := provisionalServer(t)
+	opts := &datareader.Options{BaseURLOverrides: map[string]string{"stooq": server.URL + "?s=%s"}}
+	start := open.AddDate(0, 0, -30)
+
+	data, err := datareader.Read(context.Background(), "AAPL.US", "stooq", start, open, opts)
+	if err != nil {
+		t.Fatalf("Read() error = %v", err)
+	}
+	parsed := data.(*stooq.ParsedData)
+	if len(parsed.Rows) != 2 {
+		t.Fatalf("Read() rows = %d, want the provisional bar kept", len(parsed.Rows))
+	}
+	if got := parsed.Meta[datareader.MetaProvisional]; got != open.Format(time.RFC3339) {
+		t.Errorf("Meta[provisional] = %q, want %s", got, open.Format(time.RFC3339))
+	}
+	if warnings := sources.Warnings(parsed.Meta); len(warnings) != 1 || warnings[0].Code != sources.WarnProvisional {
+		t.Errorf("Warnings() = %v, want a provisional warning", warnings)
+	}
+
+	ds, err := datareader.ReadDataset(context.Background(), "AAPL.US", "stooq", start, open, opts)
+	if err != nil {
+		t.Fatalf("ReadDataset() error = %v", err)
+	}
+	if ds.Len() != 2 || ds.Flags == nil || ds.Flags[0] != "" || ds.Flags[1] != "provisional" {
+		t.Errorf("ReadDataset() Flags = %q, want the last row provisional", ds.Flags)
+	}
+}
+
+func TestRead_ExcludeIncompleteBar(t *testing.T) {
+	server, closed, open := provisionalServer(t)
+	opts := &datareader.Options{
+		BaseURLOverrides:     map[string]string{"stooq": server.URL + "?s=%s"},
+		ExcludeIncompleteBar: true,
+	}
+
+	ds, err := datareader.ReadDataset(context.Background(), "AAPL.US", "stooq", open.AddDate(0, 0, -30), open, opts)
+	if err != nil {
+		t.Fatalf("ReadDataset() error = %v", err)
+	}
+	if ds.Len() != 1 || !ds.Dates[0].Equal(closed) {
+		t.Errorf("ReadDataset() dates = %v, want only %v", ds.Dates, closed)
+	}
+	if ds.Flags != nil || ds.Meta[datareader.MetaProvisional] != "" {
+		t.Errorf("Flags = %q, Meta = %v, want no provisional rows", ds.Flags, ds.Meta)
+	}
+	if !strings.Contains(ds.Meta["warning_"+sources.WarnProvisional], "excluded") {
+		t.Errorf("Meta = %v, want the exclusion recorded", ds.Meta)
+	}
+}
+
+func TestRead_ProvisionalBar_EconomicSource(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		tomorrow := time.Now().AddDate(0, 0, 1).Format("2006-01-02")
+		fmt.Fprintf(w, `{"observations":[{"date":%q,"value":"3.91"}]}`, tomorrow)
+	}))
+	defer server.Close()
+
+	// Economic series have no session, so nothing is provisional
+	opts := &datareader.Options{APIKey: "key", BaseURLOverrides: map[string]string{"fred": server.URL}, ExcludeIncompleteBar: true}
+	end := time.Now().AddDate(0, 0, 1)
+	ds, err := datareader.ReadDataset(context.Background(), "DGS10", "fred", end.AddDate(0, 0, -30), end, opts)
+	if err != nil {
+		t.Fatalf("ReadDataset() error = %v", err)
+	}
+	if ds.Len() != 1 || ds.Flags != nil {
+		t.Errorf("ReadDataset() = %d rows, Flags %q, want the observation unflagged", ds.Len(), ds.Flags)
+	}
+}
+
+func TestRead_ProvisionalBar_PeriodEndDated(t *testing.T) {
+	// Tiingo dates weekly and monthly bars at the period's last trading
+	// day, so yesterday's bar has ended and tomorrow's has not
+	today := time.Now().UTC()
+	ended := time.Date(today.Year(), today.Month(), today.Day()-1, 0, 0, 0, 0, time.UTC)
+	open := time.Date(today.Year(), today.Month(), today.Day()+1, 0, 0, 0, 0, time.UTC)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		fmt.Fprintf(w, `[{"date": %q, "open": 1, "high": 2, "low": 1, "close": 1.5, "volume": 100},
+			{"date": %q, "open": 2, "high": 3, "low": 1, "close": 2.5, "volume": 200}]`,
+			ended.Format(time.RFC3339), open.Format(time.RFC3339))
+	}))
+	defer server.Close()
+
+	for _, interval := range []sources.Interval{sources.Interval1wk, sources.Interval1mo} {
+		opts := &datareader.Options{
+			APIKey:           "key",
+			Interval:         interval,
+			BaseURLOverrides: map[string]string{"tiingo": server.URL + "/%s/prices"},
+		}
+		ds, err := datareader.ReadDataset(context.Background(), "AAPL", "tiingo", open.AddDate(0, -3, 0), open, opts)
+		if err != nil {
+			t.Fatalf("%s: ReadDataset() error = %v", interval, err)
+		}
+		if ds.Len() != 2 || ds.Flags == nil || ds.Flags[0] != "" || ds.Flags[1] != "provisional" {
+			t.Errorf("%s: ReadDataset() Flags = %q, want only the last row provisional", interval, ds.Flags)
+		}
+	}
+}
+
+func TestRead_ProvisionalBar_PeriodStartDated(t *testing.T) {
+	// Yahoo dates weekly and monthly bars at the period's start, so the bar
+	// of the period including tomorrow has not ended
+	today := time.Now().UTC()
+	tomorrow := time.Date(today.Year(), today.Month(), today.Day()+1, 0, 0, 0, 0, time.UTC)
+	thisWeek := tomorrow.AddDate(0, 0, -(int(tomorrow.Weekday())+6)%7)
+	thisMonth := time.Date(tomorrow.Year(), tomorrow.Month(), 1, 0, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		interval    sources.Interval
+		ended, open time.Time
+	}{
+		{sources.Interval1wk, thisWeek.AddDate(0, 0, -14), thisWeek},
+		{sources.Interval1mo, thisMonth.AddDate(0, -2, 0), thisMonth},
+	}
+	for _, tt := range tests {
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.Header().Set("Content-Type", "text/csv")
+			fmt.Fprintf(w, "Date,Open,High,Low,Close,Adj Close,Volume\n%s,1,2,1,1.5,1.5,100\n%s,2,3,1,2.5,2.5,200\n",
+				tt.ended.Format("2006-01-02"), tt.open.Format("2006-01-02"))
+		}))
+
+		opts := &datareader.Options{
+			Interval:         tt.interval,
+			BaseURLOverrides: map[string]string{"yahoo": server.URL + "/%s"},
+		}
+		ds, err := datareader.ReadDataset(context.Background(), "AAPL", "yahoo", tt.ended, tomorrow, opts)
+		server.Close()
+		if err != nil {
+			t.Fatalf("%s: ReadDataset() error = %v", tt.interval, err)
+		}
+		if ds.Len() != 2 || ds.Flags == nil || ds.Flags[0] != "" || ds.Flags[1] != "provisional" {
+			t.Errorf("%s: ReadDataset() Flags = %q, want only the last row provisional", tt.interval, ds.Flags)
+		}
+	}
+}
@@ -0,0 +1,85 @@
+package recipes
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"time"
+
+	"github.com/julianshen/gonp-datareader/dataset"
+	"github.com/julianshen/gonp-datareader/sources"
+)
+
+// Quote identifies a symbol on a specific source. The same instrument is
+// often spelled differently per source (e.g., "AAPL" on Yahoo, "AAPL.US"
+// on Stooq).
+type Quote struct {
+	Reader sources.Reader
+	Symbol string
+}
+
+// Comparison summarizes how closely two sources agree on a column.
+type Comparison struct {
+	// Joined holds both series on their common dates, with columns named
+	// "<source>.<column>".
+	Joined *dataset.Dataset
+	// MaxAbsDiff is the largest absolute difference on a common date.
+	MaxAbsDiff float64
+	// MeanAbsDiff is the mean absolute difference over common dates.
+	MeanAbsDiff float64
+	// Compared is the number of dates where both values were present.
+	Compared int
+}
+
+// CompareSources reads the same instrument from two sources and reports how
+// much the chosen column differs on their common dates. It is useful to
+// validate a free source against a reference one. It returns an error
+// matching dataset.ErrIncompatibleUnits when the two columns have
+// different known units, such as prices in different currencies.
+func CompareSources(ctx context.Context, a, b Quote, column string, start, end time.Time) (*Comparison, error) {
+	var series [2]*dataset.Dataset
+	for i, q := range []Quote{a, b} {
+		ds, err := fetchDataset(ctx, q.Reader, q.Symbol, start, end)
+		if err != nil {
+			return nil, err
+		}
+
+		col, err := selectColumn(ds, column)
+		if err != nil {
+			return nil, err
+		}
+		// Label columns by source so identical symbols do not collide
+		col.Symbol = q.Reader.Source()
+		series[i] = col
+	}
+
+	// Differences between values in different units are meaningless
+	if err := dataset.CheckUnits(series[0].Unit(column), series[1].Unit(column)); err != nil {
+		return nil, fmt.Errorf("compare %s: %w", column, err)
+	}
+
+	joined, err := dataset.Join(dataset.JoinOptions{Method: dataset.JoinInner}, series[0], series[1])
+	if err != nil {
+		return nil, err
+	}
+
+	left, _ := joined.Column(fmt.Sprintf("%s.%s", a.Reader.Source(), column))
+	right, _ := joined.Column(fmt.Sprintf("%s.%s", b.Reader.Source(), column))
+
+	cmp := &Comparison{Joined: joined}
+	var sum float64
+	for i := range left {
+		if math.IsNaN(left[i]) || math.IsNaN(right[i]) {
+			continue
+		}
+		diff := math.Abs(left[i] - right[i])
+		sum += diff
+		cmp.MaxAbsDiff = math.Max(cmp.MaxAbsDiff, diff)
+		cmp.Compared++
+	}
+	if cmp.Compared > 0 {
+		cmp.MeanAbsDiff = sum / float64(cmp.Compared)
+	}
+
+	return cmp, nil
+}
@@ -0,0 +1,69 @@
+//go:build integration
+// +build integration
+
+package recipes_test
+
+import (
+	"context"
+	"os"
+	"testing"
+	"time"
+
+	datareader "github.com/julianshen/gonp-datareader"
+	"github.com/julianshen/gonp-datareader/recipes"
+)
+
+// Run the recipes against the real APIs with:
+//
+//	go test -tags=integration ./recipes
+
+func TestIntegration_FetchPortfolio(t *testing.T) {
+	if testing.Short() {
+		t.Skip("Skipping real API test in short mode")
+	}
+
+	reader, err := datareader.DataReader("stooq", nil)
+	if err != nil {
+		t.Fatalf("DataReader() error = %v", err)
+	}
+
+	start := time.Date(2023, 1, 1, 0, 0, 0, 0, time.UTC)
+	end := time.Date(2023, 1, 31, 0, 0, 0, 0, time.UTC)
+
+	portfolio, err := recipes.FetchPortfolio(context.Background(), reader, []string{"AAPL.US", "MSFT.US"}, "Close", start, end)
+	if err != nil {
+		t.Logf("Real API test failed (expected due to rate limiting): %v", err)
+		t.Skip("Skipping due to API error - this is normal")
+	}
+
+	t.Logf("✓ Joined %d common trading days: %v", portfolio.Len(), portfolio.ColumnNames())
+}
+
+func TestIntegration_MacroDashboard(t *testing.T) {
+	if testing.Short() {
+		t.Skip("Skipping real API test in short mode")
+	}
+
+	apiKey := os.Getenv("FRED_API_KEY")
+	if apiKey == "" {
+		t.Skip("FRED_API_KEY not set")
+	}
+
+	reader, err := datareader.DataReader("fred", &datareader.Options{APIKey: apiKey})
+	if err != nil {
+		t.Fatalf("DataReader() error = %v", err)
+	}
+
+	start := time.Date(2022, 1, 1, 0, 0, 0, 0, time.UTC)
+	end := time.Date(2023, 12, 31, 0, 0, 0, 0, time.UTC)
+
+	board, err := recipes.MacroDashboard(context.Background(), reader, []string{"GDP", "CPIAUCSL", "UNRATE"}, start, end)
+	if err != nil {
+		t.Logf("Real API test failed: %v", err)
+		t.Skip("Skipping due to API error - this is normal")
+	}
+
+	for id, v := range board.Latest {
+		t.Logf("✓ %s = %v as of %s", id, v, board.LatestDate[id].Format("2006-01-02"))
+	}
+}
@@ -0,0 +1,163 @@
+package recipes
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/julianshen/gonp-datareader/dataset"
+	"github.com/julianshen/gonp-datareader/sources"
+)
+
+// BuildLocalDB downloads every symbol and stores it as a CSV file under
+// dir/<source>/<symbol>.csv, returning the written paths.
+//
+// The files use dataset.WriteCSV, so they can be reloaded with any CSV
+// reader or diffed between runs to spot revisions.
+func BuildLocalDB(ctx context.Context, reader sources.Reader, symbols []string, start, end time.Time, dir string) ([]string, error) {
+	sourceDir := filepath.Join(dir, reader.Source())
+	// #nosec G301 - Output directory is chosen by the caller
+	if err := os.MkdirAll(sourceDir, 0755); err != nil {
+		return nil, fmt.Errorf("create %s: %w", sourceDir, err)
+	}
+
+	paths := make([]string, 0, len(symbols))
+	for _, symbol := range symbols {
+		ds, err := fetchDataset(ctx, reader, symbol, start, end)
+		if err != nil {
+			return paths, err
+		}
+
+		path := filepath.Join(sourceDir, safeFilename(symbol)+".csv")
+		if err := writeCSVFile(path, ds.WriteCSV); err != nil {
+			return paths, err
+		}
+		paths = append(paths, path)
+	}
+
+	return paths, nil
+}
+
+// UpdateLocalDB brings the files of BuildLocalDB up to date: for each
+// symbol with a file under dir/<source>/<symbol>.csv, only the days after
+// its last date through end are fetched and appended (see FetchSince);
+// symbols without a file are read from start. Daily jobs therefore
+// download a few rows per symbol instead of the full history. It returns
+// the written paths.
+func UpdateLocalDB(ctx context.Context, reader sources.Reader, symbols []string, start, end time.Time, dir string) ([]string, error) {
+	sourceDir := filepath.Join(dir, reader.Source())
+	// #nosec G301 - Output directory is chosen by the caller
+	if err := os.MkdirAll(sourceDir, 0755); err != nil {
+		return nil, fmt.Errorf("create %s: %w", sourceDir, err)
+	}
+
+	paths := make([]string, 0, len(symbols))
+	for _, symbol := range symbols {
+		path := filepath.Join(sourceDir, safeFilename(symbol)+".csv")
+		existing, err := readCSVFile(path, symbol, reader.Source())
+		if err != nil {
+			return paths, err
+		}
+
+		ds, err := FetchSince(ctx, reader, symbol, existing, start, end)
+		if err != nil {
+			return paths, err
+		}
+
+		if err := writeCSVFile(path, ds.WriteCSV); err != nil {
+			return paths, err
+		}
+		paths = append(paths, path)
+	}
+
+	return paths, nil
+}
+
+// FetchSince returns existing with the rows of symbol dated after its last
+// date through end appended, fetching only those days from reader. An
+// empty existing dataset is read from start. When existing already
+// reaches end, or the source has no newer rows (sources.ErrNoData, e.g.,
+// over a weekend), existing is returned without changes. Rows the source
+// returns on or before the last existing date are dropped, as by
+// dataset.Concat.
+func FetchSince(ctx context.Context, reader sources.Reader, symbol string, existing *dataset.Dataset, start, end time.Time) (*dataset.Dataset, error) {
+	if existing.Len() == 0 {
+		return fetchDataset(ctx, reader, symbol, start, end)
+	}
+
+	from := existing.Dates[existing.Len()-1].AddDate(0, 0, 1)
+	if from.After(end) {
+		return existing, nil
+	}
+
+	fresh, err := fetchDataset(ctx, reader, symbol, from, end)
+	if errors.Is(err, sources.ErrNoData) {
+		return existing, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return dataset.Concat(existing, fresh)
+}
+
+// readCSVFile loads a dataset written by writeCSVFile; nil when path does
+// not exist.
+func readCSVFile(path, symbol, source string) (*dataset.Dataset, error) {
+	f, err := os.Open(filepath.Clean(path))
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("open %s: %w", path, err)
+	}
+	defer f.Close()
+
+	ds, err := dataset.ReadCSV(f, symbol, source)
+	if err != nil {
+		return nil, fmt.Errorf("load %s: %w", path, err)
+	}
+	return ds, nil
+}
+
+// writeCSVFile fills path using write. The data is written to a temporary
+// file that replaces path once complete, so a failed write keeps the
+// previous file.
+func writeCSVFile(path string, write func(w io.Writer) error) error {
+	tmp := filepath.Clean(path) + ".tmp"
+	f, err := os.Create(tmp)
+	if err != nil {
+		return fmt.Errorf("create %s: %w", path, err)
+	}
+
+	if err := write(f); err != nil {
+		_ = f.Close()
+		_ = os.Remove(tmp)
+		return fmt.Errorf("write %s: %w", path, err)
+	}
+	if err := f.Close(); err != nil {
+		_ = os.Remove(tmp)
+		return fmt.Errorf("write %s: %w", path, err)
+	}
+	if err := os.Rename(tmp, path); err != nil {
+		_ = os.Remove(tmp)
+		return fmt.Errorf("write %s: %w", path, err)
+	}
+	return nil
+}
+
+// safeFilename replaces characters that are unsafe in file names.
+func safeFilename(symbol string) string {
+	return strings.Map(func(r rune) rune {
+		switch r {
+		case '/', '\\', ':', '*', '?', '"', '<', '>', '|':
+			return '_'
+		}
+		return r
+	}, symbol)
+}
@@ -0,0 +1,62 @@
+package recipes
+
+import (
+	"context"
+	"math"
+	"time"
+
+	"github.com/julianshen/gonp-datareader/dataset"
+	"github.com/julianshen/gonp-datareader/sources"
+)
+
+// Dashboard holds a set of macro series aligned on one date index.
+type Dashboard struct {
+	// Data holds every series, forward-filled on the union of their dates,
+	// with columns named "<series>.Value".
+	Data *dataset.Dataset
+	// Latest maps each series to its most recent non-missing value.
+	Latest map[string]float64
+	// LatestDate maps each series to the date of its Latest value.
+	LatestDate map[string]time.Time
+}
+
+// MacroDashboard reads several macro series (e.g., FRED "GDP", "CPIAUCSL",
+// "UNRATE") of possibly different frequencies and aligns them with an outer,
+// forward-filled join so each row shows the latest level of every series.
+func MacroDashboard(ctx context.Context, reader sources.Reader, series []string, start, end time.Time) (*Dashboard, error) {
+	board := &Dashboard{
+		Latest:     make(map[string]float64),
+		LatestDate: make(map[string]time.Time),
+	}
+
+	all := make([]*dataset.Dataset, 0, len(series))
+	for _, id := range series {
+		ds, err := fetchDataset(ctx, reader, id, start, end)
+		if err != nil {
+			return nil, err
+		}
+
+		col, err := selectColumn(ds, "Value")
+		if err != nil {
+			return nil, err
+		}
+		all = append(all, col)
+
+		values, _ := col.Column("Value")
+		for i := len(values) - 1; i >= 0; i-- {
+			if !math.IsNaN(values[i]) {
+				board.Latest[id] = values[i]
+				board.LatestDate[id] = col.Dates[i]
+				break
+			}
+		}
+	}
+
+	data, err := dataset.Join(dataset.JoinOptions{Method: dataset.JoinOuter, Fill: dataset.FillForward}, all...)
+	if err != nil {
+		return nil, err
+	}
+	board.Data = data
+
+	return board, nil
+}
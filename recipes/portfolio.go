@@ -0,0 +1,31 @@
+package recipes
+
+import (
+	"context"
+	"time"
+
+	"github.com/julianshen/gonp-datareader/dataset"
+	"github.com/julianshen/gonp-datareader/sources"
+)
+
+// FetchPortfolio reads column (e.g., "Close" or "Adj Close") for every symbol
+// and joins the series on the dates all symbols traded.
+//
+// The result has one column per symbol named "<symbol>.<column>".
+func FetchPortfolio(ctx context.Context, reader sources.Reader, symbols []string, column string, start, end time.Time) (*dataset.Dataset, error) {
+	series := make([]*dataset.Dataset, 0, len(symbols))
+	for _, symbol := range symbols {
+		ds, err := fetchDataset(ctx, reader, symbol, start, end)
+		if err != nil {
+			return nil, err
+		}
+
+		col, err := selectColumn(ds, column)
+		if err != nil {
+			return nil, err
+		}
+		series = append(series, col)
+	}
+
+	return dataset.Join(dataset.JoinOptions{Method: dataset.JoinInner}, series...)
+}
@@ -0,0 +1,55 @@
+// Package recipes provides small, runnable building blocks that combine the
+// datareader sources with the dataset utilities.
+//
+// Each recipe takes ready-made readers rather than source names, so the same
+// function runs against live APIs or against mock servers in tests:
+//
+//	reader, err := datareader.DataReader("yahoo", nil)
+//	if err != nil {
+//		log.Fatal(err)
+//	}
+//	closes, err := recipes.FetchPortfolio(ctx, reader, []string{"AAPL", "MSFT"}, "Close", start, end)
+package recipes
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	datareader "github.com/julianshen/gonp-datareader"
+	"github.com/julianshen/gonp-datareader/dataset"
+	"github.com/julianshen/gonp-datareader/sources"
+)
+
+// fetchDataset reads a single symbol and converts it to a Dataset. Cached
+// responses are labeled for datareader.CacheInventory.
+func fetchDataset(ctx context.Context, reader sources.Reader, symbol string, start, end time.Time) (*dataset.Dataset, error) {
+	data, err := datareader.ReadSingleWith(ctx, reader, symbol, start, end)
+	if err != nil {
+		return nil, fmt.Errorf("%s %s: %w", reader.Source(), symbol, err)
+	}
+
+	ds, err := datareader.ToDataset(symbol, data)
+	if err != nil {
+		return nil, fmt.Errorf("%s %s: %w", reader.Source(), symbol, err)
+	}
+	return ds, nil
+}
+
+// selectColumn returns a copy of ds containing only the named column.
+func selectColumn(ds *dataset.Dataset, column string) (*dataset.Dataset, error) {
+	values, ok := ds.Column(column)
+	if !ok {
+		return nil, fmt.Errorf("%s %s: column %q not found (have %v)", ds.Source, ds.Symbol, column, ds.ColumnNames())
+	}
+
+	out := dataset.New(ds.Symbol, ds.Source, ds.Dates)
+	if err := out.AddColumn(column, values); err != nil {
+		return nil, err
+	}
+	out.Columns[0].Unit = ds.Unit(column)
+	for k, v := range ds.Meta {
+		out.Meta[k] = v
+	}
+	return out, nil
+}
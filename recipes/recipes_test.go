@@ -0,0 +1,291 @@
+package recipes_test
+
+import (
+	"context"
+	"math"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/julianshen/gonp-datareader/dataset"
+	"github.com/julianshen/gonp-datareader/recipes"
+	"github.com/julianshen/gonp-datareader/sources/fred"
+	"github.com/julianshen/gonp-datareader/sources/stooq"
+	"github.com/julianshen/gonp-datareader/sources/yahoo"
+)
+
+var (
+	start = time.Date(2023, 1, 1, 0, 0, 0, 0, time.UTC)
+	end   = time.Date(2023, 12, 31, 0, 0, 0, 0, time.UTC)
+)
+
+// newCSVServer serves the CSV registered for the symbol found by key.
+func newCSVServer(t *testing.T, key func(r *http.Request) string, bodies map[string]string) *httptest.Server {
+	t.Helper()
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, ok := bodies[key(r)]
+		if !ok {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		w.Header().Set("Content-Type", "text/csv")
+		w.Write([]byte(body))
+	}))
+	t.Cleanup(server.Close)
+	return server
+}
+
+func newStooqReader(t *testing.T, bodies map[string]string) *stooq.StooqReader {
+	server := newCSVServer(t, func(r *http.Request) string { return r.URL.Query().Get("s") }, bodies)
+	return stooq.NewStooqReaderWithBaseURL(nil, server.URL+"?s=%s")
+}
+
+func newYahooReader(t *testing.T, bodies map[string]string) *yahoo.YahooReader {
+	server := newCSVServer(t, func(r *http.Request) string { return strings.TrimPrefix(r.URL.Path, "/") }, bodies)
+	return yahoo.NewYahooReaderWithBaseURL(nil, server.URL+"/%s")
+}
+
+func newFREDReader(t *testing.T, bodies map[string]string) *fred.FREDReader {
+	t.Helper()
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, ok := bodies[r.URL.Query().Get("series_id")]
+		if !ok {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(body))
+	}))
+	t.Cleanup(server.Close)
+
+	reader := fred.NewFREDReaderWithBaseURL(nil, server.URL)
+	reader.SetAPIKey("test-api-key")
+	return reader
+}
+
+func TestFetchPortfolio(t *testing.T) {
+	reader := newStooqReader(t, map[string]string{
+		"AAPL.US": `Date,Open,High,Low,Close,Volume
+2023-01-03,130,131,124,125.07,112117500
+2023-01-04,126,128,125,126.36,89113600
+2023-01-05,127,127,124,125.02,80962700`,
+		"MSFT.US": `Date,Open,High,Low,Close,Volume
+2023-01-03,243,246,237,239.58,25740000
+2023-01-05,227,228,221,222.31,39585600`,
+	})
+
+	got, err := recipes.FetchPortfolio(context.Background(), reader, []string{"AAPL.US", "MSFT.US"}, "Close", start, end)
+	if err != nil {
+		t.Fatalf("FetchPortfolio() error = %v", err)
+	}
+
+	// Only the dates both symbols traded remain
+	if got.Len() != 2 {
+		t.Fatalf("Len() = %d, want 2", got.Len())
+	}
+
+	msft, ok := got.Column("MSFT.US.Close")
+	if !ok {
+		t.Fatalf("missing MSFT.US.Close column, have %v", got.ColumnNames())
+	}
+	if msft[1] != 222.31 {
+		t.Errorf("MSFT.US.Close[1] = %v, want 222.31", msft[1])
+	}
+}
+
+func TestFetchPortfolio_MissingColumn(t *testing.T) {
+	reader := newStooqReader(t, map[string]string{
+		"AAPL.US": `Date,Open,High,Low,Close,Volume
+2023-01-03,130,131,124,125.07,112117500`,
+	})
+
+	_, err := recipes.FetchPortfolio(context.Background(), reader, []string{"AAPL.US"}, "Adj Close", start, end)
+	if err == nil {
+		t.Fatal("FetchPortfolio() expected error for missing column")
+	}
+}
+
+func TestCompareSources(t *testing.T) {
+	y := newYahooReader(t, map[string]string{
+		"AAPL": `Date,Open,High,Low,Close,Adj Close,Volume
+2023-01-03,130,131,124,125.07,124.2,112117500
+2023-01-04,126,128,125,126.36,125.5,89113600
+2023-01-05,127,127,124,125.02,124.1,80962700`,
+	})
+	s := newStooqReader(t, map[string]string{
+		"AAPL.US": `Date,Open,High,Low,Close,Volume
+2023-01-03,130,131,124,125.07,112117500
+2023-01-05,127,127,124,125.12,80962700`,
+	})
+
+	cmp, err := recipes.CompareSources(context.Background(),
+		recipes.Quote{Reader: y, Symbol: "AAPL"},
+		recipes.Quote{Reader: s, Symbol: "AAPL.US"},
+		"Close", start, end)
+	if err != nil {
+		t.Fatalf("CompareSources() error = %v", err)
+	}
+
+	if cmp.Compared != 2 {
+		t.Errorf("Compared = %d, want 2", cmp.Compared)
+	}
+	if math.Abs(cmp.MaxAbsDiff-0.1) > 1e-9 {
+		t.Errorf("MaxAbsDiff = %v, want 0.1", cmp.MaxAbsDiff)
+	}
+	if math.Abs(cmp.MeanAbsDiff-0.05) > 1e-9 {
+		t.Errorf("MeanAbsDiff = %v, want 0.05", cmp.MeanAbsDiff)
+	}
+	if _, ok := cmp.Joined.Column("yahoo.Close"); !ok {
+		t.Errorf("missing yahoo.Close column, have %v", cmp.Joined.ColumnNames())
+	}
+}
+
+func TestBuildLocalDB(t *testing.T) {
+	reader := newStooqReader(t, map[string]string{
+		"AAPL.US": `Date,Open,High,Low,Close,Volume
+2023-01-03,130,131,124,125.07,112117500`,
+	})
+	dir := t.TempDir()
+
+	paths, err := recipes.BuildLocalDB(context.Background(), reader, []string{"AAPL.US"}, start, end, dir)
+	if err != nil {
+		t.Fatalf("BuildLocalDB() error = %v", err)
+	}
+
+	want := filepath.Join(dir, "stooq", "AAPL.US.csv")
+	if len(paths) != 1 || paths[0] != want {
+		t.Fatalf("paths = %v, want [%s]", paths, want)
+	}
+
+	content, err := os.ReadFile(want)
+	if err != nil {
+		t.Fatalf("ReadFile() error = %v", err)
+	}
+	if !strings.HasPrefix(string(content), "Date,Open,High,Low,Close,Volume\n2023-01-03,130,") {
+		t.Errorf("unexpected CSV content:\n%s", content)
+	}
+}
+
+func TestBuildLocalDB_FetchError(t *testing.T) {
+	reader := newStooqReader(t, map[string]string{})
+
+	_, err := recipes.BuildLocalDB(context.Background(), reader, []string{"AAPL.US"}, start, end, t.TempDir())
+	if err == nil {
+		t.Fatal("BuildLocalDB() expected error for failed fetch")
+	}
+}
+
+func TestUpdateLocalDB(t *testing.T) {
+	var requested []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requested = append(requested, r.URL.Query().Get("observation_start"))
+		w.Header().Set("Content-Type", "application/json")
+		switch r.URL.Query().Get("observation_start") {
+		case "2023-01-01":
+			w.Write([]byte(`{"observations":[{"date":"2023-01-01","value":"1.5"},{"date":"2023-02-01","value":"1.6"}]}`))
+		case "2023-02-02":
+			w.Write([]byte(`{"observations":[{"date":"2023-03-01","value":"1.7"}]}`))
+		default:
+			t.Errorf("unexpected observation_start %q", r.URL.Query().Get("observation_start"))
+		}
+	}))
+	defer server.Close()
+
+	reader := fred.NewFREDReaderWithBaseURL(nil, server.URL)
+	reader.SetAPIKey("test-api-key")
+	dir := t.TempDir()
+	path := filepath.Join(dir, "fred", "DFF.csv")
+
+	steps := []struct {
+		name    string
+		end     time.Time
+		want    string
+		request string
+	}{
+		{"initial", time.Date(2023, 2, 1, 0, 0, 0, 0, time.UTC), "Date,Value\n2023-01-01,1.5\n2023-02-01,1.6\n", "2023-01-01"},
+		{"append missing", end, "Date,Value\n2023-01-01,1.5\n2023-02-01,1.6\n2023-03-01,1.7\n", "2023-02-02"},
+		{"up to date", time.Date(2023, 3, 1, 0, 0, 0, 0, time.UTC), "Date,Value\n2023-01-01,1.5\n2023-02-01,1.6\n2023-03-01,1.7\n", ""},
+	}
+	for _, step := range steps {
+		requested = nil
+		paths, err := recipes.UpdateLocalDB(context.Background(), reader, []string{"DFF"}, start, step.end, dir)
+		if err != nil {
+			t.Fatalf("%s: UpdateLocalDB() error = %v", step.name, err)
+		}
+		if len(paths) != 1 || paths[0] != path {
+			t.Fatalf("%s: paths = %v, want [%s]", step.name, paths, path)
+		}
+
+		content, err := os.ReadFile(path)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if string(content) != step.want {
+			t.Errorf("%s: content =\n%s\nwant\n%s", step.name, content, step.want)
+		}
+
+		var want []string
+		if step.request != "" {
+			want = []string{step.request}
+		}
+		if strings.Join(requested, ",") != strings.Join(want, ",") {
+			t.Errorf("%s: requested %v, want %v", step.name, requested, want)
+		}
+	}
+}
+
+func TestFetchSince_NoNewData(t *testing.T) {
+	reader := newStooqReader(t, map[string]string{"AAPL.US": "No data"})
+	existing := dataset.New("AAPL.US", "stooq", []time.Time{time.Date(2023, 12, 29, 0, 0, 0, 0, time.UTC)})
+	if err := existing.AddColumn("Close", []float64{192.53}); err != nil {
+		t.Fatal(err)
+	}
+
+	ds, err := recipes.FetchSince(context.Background(), reader, "AAPL.US", existing, start, end)
+	if err != nil {
+		t.Fatalf("FetchSince() error = %v", err)
+	}
+	if ds.Len() != 1 {
+		t.Errorf("Len() = %d, want the existing row only", ds.Len())
+	}
+}
+
+func TestMacroDashboard(t *testing.T) {
+	reader := newFREDReader(t, map[string]string{
+		"GDP": `{"observations": [
+			{"date": "2023-01-01", "value": "26813.6"},
+			{"date": "2023-04-01", "value": "27063.0"}
+		]}`,
+		"UNRATE": `{"observations": [
+			{"date": "2023-01-01", "value": "3.4"},
+			{"date": "2023-02-01", "value": "3.6"},
+			{"date": "2023-03-01", "value": "3.5"},
+			{"date": "2023-04-01", "value": "3.4"},
+			{"date": "2023-05-01", "value": "."}
+		]}`,
+	})
+
+	board, err := recipes.MacroDashboard(context.Background(), reader, []string{"GDP", "UNRATE"}, start, end)
+	if err != nil {
+		t.Fatalf("MacroDashboard() error = %v", err)
+	}
+
+	if board.Latest["GDP"] != 27063.0 {
+		t.Errorf("Latest[GDP] = %v, want 27063", board.Latest["GDP"])
+	}
+	// The missing May value is skipped
+	if board.Latest["UNRATE"] != 3.4 {
+		t.Errorf("Latest[UNRATE] = %v, want 3.4", board.Latest["UNRATE"])
+	}
+	if want := time.Date(2023, 4, 1, 0, 0, 0, 0, time.UTC); !board.LatestDate["UNRATE"].Equal(want) {
+		t.Errorf("LatestDate[UNRATE] = %v, want %v", board.LatestDate["UNRATE"], want)
+	}
+
+	if _, ok := board.Data.Column("GDP.Value"); !ok {
+		t.Errorf("missing GDP.Value column, have %v", board.Data.ColumnNames())
+	}
+}
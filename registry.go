@@ -0,0 +1,127 @@
+package datareader
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"sync"
+
+	internalhttp "github.com/julianshen/gonp-datareader/internal/http"
+	"github.com/julianshen/gonp-datareader/sources"
+)
+
+// ErrSourceConflict is returned by RegisterSource when the name is already
+// taken by a built-in or registered source.
+var ErrSourceConflict = errors.New("source already registered")
+
+// builtinSources lists the sources DataReader creates itself, in the order
+// returned by ListSources.
+var builtinSources = []string{
+	"yahoo",
+	"fred",
+	"worldbank",
+	"alphavantage",
+	"stooq",
+	"iex",
+	"tiingo",
+	"oecd",
+	"eurostat",
+	"twse",
+	"finmind",
+	"finra",
+	"ecb",
+}
+
+// optionalSources holds the built-in sources compiled in with build tags
+// (e.g., "gateway"), which add themselves to builtinSources and this map
+// from init functions. Each creates its reader from the resolved client
+// options and base URL, "" meaning the reader's default.
+var optionalSources = map[string]func(opts *Options, clientOpts *internalhttp.ClientOptions, baseURL string) (sources.Reader, error){}
+
+// registry holds the sources added with RegisterSource.
+var registry = struct {
+	sync.RWMutex
+	factories map[string]func(*Options) (sources.Reader, error)
+}{factories: make(map[string]func(*Options) (sources.Reader, error))}
+
+// RegisterSource plugs a third-party reader into DataReader and ListSources
+// under name. DataReader calls factory with the Options it was given, which
+// may be nil. Registering a name used by a built-in source, by the "bundle"
+// source or by an earlier registration fails with ErrSourceConflict.
+//
+// RegisterSource is typically called from a package's init function. It is
+// safe for concurrent use.
+//
+// # Example Usage
+//
+//	func init() {
+//		err := datareader.RegisterSource("mybroker", func(opts *datareader.Options) (sources.Reader, error) {
+//			return mybroker.NewReader(opts.APIKey), nil
+//		})
+//		if err != nil {
+//			panic(err)
+//		}
+//	}
+//
+//	reader, err := datareader.DataReader("mybroker", opts)
+func RegisterSource(name string, factory func(*Options) (sources.Reader, error)) error {
+	if name == "" {
+		return fmt.Errorf("%w: source cannot be empty", ErrUnknownSource)
+	}
+	if factory == nil {
+		return fmt.Errorf("register %s: nil factory", name)
+	}
+	if isBuiltinSource(name) || name == "bundle" {
+		return fmt.Errorf("%w: %s is a built-in source", ErrSourceConflict, name)
+	}
+
+	registry.Lock()
+	defer registry.Unlock()
+	if _, ok := registry.factories[name]; ok {
+		return fmt.Errorf("%w: %s", ErrSourceConflict, name)
+	}
+	registry.factories[name] = factory
+	return nil
+}
+
+// UnregisterSource removes a source added with RegisterSource and reports
+// whether it was registered. Built-in sources cannot be removed. It is
+// mainly meant for tests, which can undo their registrations with
+// t.Cleanup.
+func UnregisterSource(name string) bool {
+	registry.Lock()
+	defer registry.Unlock()
+	_, ok := registry.factories[name]
+	delete(registry.factories, name)
+	return ok
+}
+
+// registeredSource returns the factory registered under name.
+func registeredSource(name string) (func(*Options) (sources.Reader, error), bool) {
+	registry.RLock()
+	defer registry.RUnlock()
+	factory, ok := registry.factories[name]
+	return factory, ok
+}
+
+// registeredSources returns the names added with RegisterSource, sorted.
+func registeredSources() []string {
+	registry.RLock()
+	defer registry.RUnlock()
+	names := make([]string, 0, len(registry.factories))
+	for name := range registry.factories {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// isBuiltinSource reports whether DataReader creates name itself.
+func isBuiltinSource(name string) bool {
+	for _, builtin := range builtinSources {
+		if builtin == name {
+			return true
+		}
+	}
+	return false
+}
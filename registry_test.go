@@ -0,0 +1,152 @@
+package datareader_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	datareader "github.com/julianshen/gonp-datareader"
+	"github.com/julianshen/gonp-datareader/sources"
+)
+
+// fakeReader is a minimal third-party reader.
+type fakeReader struct {
+	*sources.BaseSource
+	apiKey string
+}
+
+func (r *fakeReader) ReadSingle(ctx context.Context, symbol string, start, end time.Time) (interface{}, error) {
+	return symbol + ":" + r.apiKey, nil
+}
+
+func (r *fakeReader) Read(ctx context.Context, symbols []string, start, end time.Time) (interface{}, error) {
+	return symbols, nil
+}
+
+func registerFake(t *testing.T, name string) {
+	t.Helper()
+	err := datareader.RegisterSource(name, func(opts *datareader.Options) (sources.Reader, error) {
+		reader := &fakeReader{BaseSource: sources.NewBaseSource(name)}
+		if opts != nil {
+			reader.apiKey = opts.APIKey
+		}
+		return reader, nil
+	})
+	if err != nil {
+		t.Fatalf("RegisterSource(%q) error = %v", name, err)
+	}
+	t.Cleanup(func() { datareader.UnregisterSource(name) })
+}
+
+func TestRegisterSource(t *testing.T) {
+	registerFake(t, "zeta")
+	registerFake(t, "alpha")
+
+	names := datareader.ListSources()
+	if got := names[len(names)-2:]; got[0] != "alpha" || got[1] != "zeta" {
+		t.Errorf("ListSources() ends with %v, want [alpha zeta]", got)
+	}
+
+	reader, err := datareader.DataReader("zeta", &datareader.Options{APIKey: "key"})
+	if err != nil {
+		t.Fatalf("DataReader(zeta) error = %v", err)
+	}
+	data, err := reader.ReadSingle(context.Background(), "X", time.Time{}, time.Time{})
+	if err != nil || data != "X:key" {
+		t.Errorf("ReadSingle() = %v, %v, want X:key", data, err)
+	}
+}
+
+func TestRegisterSource_Errors(t *testing.T) {
+	registerFake(t, "custom")
+	factory := func(*datareader.Options) (sources.Reader, error) { return nil, nil }
+
+	tests := []struct {
+		name    string
+		source  string
+		factory func(*datareader.Options) (sources.Reader, error)
+		wantErr error
+	}{
+		{"built-in", "yahoo", factory, datareader.ErrSourceConflict},
+		{"bundle", "bundle", factory, datareader.ErrSourceConflict},
+		{"registered", "custom", factory, datareader.ErrSourceConflict},
+		{"empty name", "", factory, datareader.ErrUnknownSource},
+		{"nil factory", "other", nil, nil},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := datareader.RegisterSource(tt.source, tt.factory)
+			if err == nil {
+				datareader.UnregisterSource(tt.source)
+				t.Fatal("RegisterSource() returned nil error")
+			}
+			if tt.wantErr != nil && !errors.Is(err, tt.wantErr) {
+				t.Errorf("RegisterSource() error = %v, want %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestUnregisterSource(t *testing.T) {
+	registerFake(t, "temp")
+
+	if !datareader.UnregisterSource("temp") {
+		t.Error("UnregisterSource(temp) = false, want true")
+	}
+	if datareader.UnregisterSource("temp") {
+		t.Error("second UnregisterSource(temp) = true, want false")
+	}
+	if datareader.UnregisterSource("yahoo") {
+		t.Error("UnregisterSource(yahoo) = true, want false")
+	}
+	if _, err := datareader.DataReader("temp", nil); !errors.Is(err, datareader.ErrUnknownSource) {
+		t.Errorf("DataReader(temp) error = %v, want ErrUnknownSource", err)
+	}
+}
+
+// fakeSeries is third-party data implementing sources.TimeSeries.
+type fakeSeries struct {
+	dates  []time.Time
+	values []float64
+}
+
+func (s *fakeSeries) DateIndex() ([]time.Time, error) { return s.dates, nil }
+func (s *fakeSeries) ColumnNames() []string           { return []string{"Close"} }
+func (s *fakeSeries) Float64Column(name string) ([]float64, error) {
+	if name != "Close" {
+		return nil, sources.NoColumn(name)
+	}
+	return s.values, nil
+}
+
+// seriesReader is a third-party reader returning fakeSeries.
+type seriesReader struct {
+	fakeReader
+}
+
+func (r *seriesReader) ReadSingle(ctx context.Context, symbol string, start, end time.Time) (interface{}, error) {
+	return &fakeSeries{dates: []time.Time{start, end}, values: []float64{1, 2}}, nil
+}
+
+func TestReadDataset_RegisteredSource(t *testing.T) {
+	err := datareader.RegisterSource("series", func(opts *datareader.Options) (sources.Reader, error) {
+		return &seriesReader{fakeReader{BaseSource: sources.NewBaseSource("series")}}, nil
+	})
+	if err != nil {
+		t.Fatalf("RegisterSource() error = %v", err)
+	}
+	t.Cleanup(func() { datareader.UnregisterSource("series") })
+
+	start := time.Date(2024, 1, 2, 0, 0, 0, 0, time.UTC)
+	ds, err := datareader.ReadDataset(context.Background(), "X", "series", start, start.AddDate(0, 0, 1), nil)
+	if err != nil {
+		t.Fatalf("ReadDataset() error = %v", err)
+	}
+	if ds.Source != "series" || ds.Symbol != "X" || ds.Len() != 2 {
+		t.Errorf("ReadDataset() = %s %s with %d rows, want series X with 2", ds.Source, ds.Symbol, ds.Len())
+	}
+	if closes, _ := ds.Column("Close"); closes[1] != 2 {
+		t.Errorf("Close = %v, want [1 2]", closes)
+	}
+}
@@ -0,0 +1,210 @@
+package report
+
+import (
+	htmltemplate "html/template"
+	"io"
+	"math"
+	"sort"
+	"text/template"
+	"time"
+
+	"github.com/julianshen/gonp-datareader/dataset"
+)
+
+var (
+	marketMarkdownTemplate = template.Must(template.New("market.md.tmpl").Funcs(funcs).ParseFS(templateFS, "templates/market.md.tmpl"))
+	marketHTMLTemplate     = htmltemplate.Must(htmltemplate.New("market.html.tmpl").Funcs(htmltemplate.FuncMap(funcs)).ParseFS(templateFS, "templates/market.html.tmpl"))
+)
+
+// Mover is one symbol's performance on a snapshot day.
+type Mover struct {
+	Symbol    string
+	Close     float64
+	PrevClose float64
+	Change    float64
+	ChangePct float64
+	// Volume is NaN when the snapshot has no Volume column.
+	Volume float64
+}
+
+// Breadth counts the symbols that rose, fell or were unchanged.
+type Breadth struct {
+	Advancers int
+	Decliners int
+	Unchanged int
+}
+
+// Ratio returns the advance/decline ratio: +Inf when nothing declined and
+// NaN when nothing moved.
+func (b Breadth) Ratio() float64 {
+	if b.Decliners == 0 {
+		if b.Advancers == 0 {
+			return math.NaN()
+		}
+		return math.Inf(1)
+	}
+	return float64(b.Advancers) / float64(b.Decliners)
+}
+
+// add counts one symbol with the given daily change.
+func (b *Breadth) add(change float64) {
+	switch {
+	case change > 0:
+		b.Advancers++
+	case change < 0:
+		b.Decliners++
+	default:
+		b.Unchanged++
+	}
+}
+
+// MarketSummary summarizes a whole-market snapshot, as returned by
+// datareader.ReadMarketSnapshot, and is the data passed to market report
+// templates.
+type MarketSummary struct {
+	Title       string
+	GeneratedAt time.Time
+
+	// Source and Date identify the snapshot.
+	Source string
+	Date   time.Time
+
+	// Movers holds every symbol whose daily change could be computed,
+	// sorted by symbol.
+	Movers []Mover
+
+	// Breadth counts advancers, decliners and unchanged symbols.
+	Breadth Breadth
+
+	// TotalVolume is the sum of all symbols' volumes.
+	TotalVolume float64
+
+	// Gainers and Losers hold the movers with the largest rise and fall in
+	// ChangePct; VolumeLeaders those with the largest Volume.
+	Gainers       []Mover
+	Losers        []Mover
+	VolumeLeaders []Mover
+
+	// Sectors holds per-sector performance when set by the caller, e.g.,
+	// with SectorPerformance; it is rendered when not empty.
+	Sectors []SectorStat
+}
+
+// SummarizeMarket computes the daily change of every symbol in snapshot
+// and ranks up to n gainers, losers and volume leaders.
+//
+// A symbol's previous close is the last Close in previous, which may be
+// nil, or else Close minus the snapshot's Change column (TWSE). Symbols
+// without a positive close or a previous close are left out. ErrNoData is
+// returned when no symbol's change can be computed.
+func SummarizeMarket(title string, snapshot, previous map[string]*dataset.Dataset, n int) (*MarketSummary, error) {
+	s := &MarketSummary{Title: title, GeneratedAt: time.Now()}
+
+	for symbol, ds := range snapshot {
+		m, date, ok := mover(symbol, ds, previous[symbol])
+		if !ok {
+			continue
+		}
+		if date.After(s.Date) {
+			s.Date = date
+			s.Source = ds.Source
+		}
+		s.Movers = append(s.Movers, m)
+
+		s.Breadth.add(m.Change)
+		if !math.IsNaN(m.Volume) {
+			s.TotalVolume += m.Volume
+		}
+	}
+	if len(s.Movers) == 0 {
+		return nil, ErrNoData
+	}
+	sort.Slice(s.Movers, func(i, j int) bool { return s.Movers[i].Symbol < s.Movers[j].Symbol })
+
+	s.Gainers = rank(s.Movers, n, func(a, b Mover) bool { return a.ChangePct > b.ChangePct }, func(m Mover) bool { return m.Change > 0 })
+	s.Losers = rank(s.Movers, n, func(a, b Mover) bool { return a.ChangePct < b.ChangePct }, func(m Mover) bool { return m.Change < 0 })
+	s.VolumeLeaders = rank(s.Movers, n, func(a, b Mover) bool { return a.Volume > b.Volume }, func(m Mover) bool { return m.Volume > 0 })
+	return s, nil
+}
+
+// mover computes the daily change of the last row of ds.
+func mover(symbol string, ds, previous *dataset.Dataset) (Mover, time.Time, bool) {
+	if ds == nil || ds.Len() == 0 {
+		return Mover{}, time.Time{}, false
+	}
+	last := ds.Len() - 1
+
+	closes, ok := ds.Column("Close")
+	if !ok || !(closes[last] > 0) {
+		return Mover{}, time.Time{}, false
+	}
+	m := Mover{Symbol: symbol, Close: closes[last], PrevClose: math.NaN(), Volume: math.NaN()}
+
+	if previous != nil {
+		if prev, ok := previous.Column("Close"); ok && len(prev) > 0 {
+			m.PrevClose = prev[len(prev)-1]
+		}
+	}
+	if math.IsNaN(m.PrevClose) {
+		if change, ok := ds.Column("Change"); ok {
+			m.PrevClose = m.Close - change[last]
+		}
+	}
+	if !(m.PrevClose > 0) {
+		return Mover{}, time.Time{}, false
+	}
+
+	m.Change = m.Close - m.PrevClose
+	m.ChangePct = m.Change / m.PrevClose * 100
+	if volume, ok := ds.Column("Volume"); ok {
+		m.Volume = volume[last]
+	}
+	return m, ds.Dates[last], true
+}
+
+// rank returns up to n movers accepted by keep, ordered by less. Ties are
+// broken by symbol.
+func rank(movers []Mover, n int, less func(a, b Mover) bool, keep func(Mover) bool) []Mover {
+	var out []Mover
+	for _, m := range movers {
+		if keep(m) {
+			out = append(out, m)
+		}
+	}
+	sort.SliceStable(out, func(i, j int) bool { return less(out[i], out[j]) })
+	if n >= 0 && len(out) > n {
+		out = out[:n]
+	}
+	return out
+}
+
+// MoverSection is a titled list of movers rendered by the market report
+// templates.
+type MoverSection struct {
+	Title  string
+	Movers []Mover
+}
+
+// Sections returns the gainers, losers and volume leaders in report order.
+func (s *MarketSummary) Sections() []MoverSection {
+	return []MoverSection{
+		{Title: "Top Gainers", Movers: s.Gainers},
+		{Title: "Top Losers", Movers: s.Losers},
+		{Title: "Volume Leaders", Movers: s.VolumeLeaders},
+	}
+}
+
+// Render executes tmpl with the summary as its data.
+func (s *MarketSummary) Render(w io.Writer, tmpl Executor) error {
+	return render(w, tmpl, s)
+}
+
+// WriteMarkdown renders the summary as Markdown.
+func (s *MarketSummary) WriteMarkdown(w io.Writer) error {
+	return s.Render(w, marketMarkdownTemplate)
+}
+
+// WriteHTML renders the summary as a standalone HTML page.
+func (s *MarketSummary) WriteHTML(w io.Writer) error {
+	return s.Render(w, marketHTMLTemplate)
+}
@@ -0,0 +1,166 @@
+package report_test
+
+import (
+	"errors"
+	"math"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/julianshen/gonp-datareader/dataset"
+	"github.com/julianshen/gonp-datareader/report"
+)
+
+// bar builds a one-row snapshot dataset; change is omitted when NaN.
+func bar(t *testing.T, symbol string, close, change, volume float64) *dataset.Dataset {
+	t.Helper()
+	ds := dataset.New(symbol, "twse", []time.Time{time.Date(2025, 10, 28, 0, 0, 0, 0, time.UTC)})
+	for _, c := range []struct {
+		name  string
+		value float64
+	}{{"Close", close}, {"Change", change}, {"Volume", volume}} {
+		if c.name == "Change" && math.IsNaN(c.value) {
+			continue
+		}
+		if err := ds.AddColumn(c.name, []float64{c.value}); err != nil {
+			t.Fatal(err)
+		}
+	}
+	return ds
+}
+
+func symbols(movers []report.Mover) []string {
+	out := make([]string, len(movers))
+	for i, m := range movers {
+		out[i] = m.Symbol
+	}
+	return out
+}
+
+func TestSummarizeMarket(t *testing.T) {
+	snapshot := map[string]*dataset.Dataset{
+		"2330": bar(t, "2330", 110, 10, 5000),
+		"2317": bar(t, "2317", 95, -5, 9000),
+		"2454": bar(t, "2454", 200, 0, 1000),
+		"1101": bar(t, "1101", 52, 2, 3000),
+		"0000": bar(t, "0000", 0, 0, 0), // no trade
+		"9999": bar(t, "9999", 40, math.NaN(), 100),
+	}
+	previous := map[string]*dataset.Dataset{
+		"9999": series(t, "9999", 30, 50),
+	}
+
+	s, err := report.SummarizeMarket("Market", snapshot, previous, 2)
+	if err != nil {
+		t.Fatalf("SummarizeMarket() error = %v", err)
+	}
+
+	if want := (report.Breadth{Advancers: 2, Decliners: 2, Unchanged: 1}); s.Breadth != want {
+		t.Errorf("Breadth = %+v, want %+v", s.Breadth, want)
+	}
+	if got := s.Breadth.Ratio(); got != 1 {
+		t.Errorf("Ratio() = %v, want 1", got)
+	}
+	if s.TotalVolume != 18100 {
+		t.Errorf("TotalVolume = %v, want 18100", s.TotalVolume)
+	}
+	if s.Source != "twse" || !s.Date.Equal(time.Date(2025, 10, 28, 0, 0, 0, 0, time.UTC)) {
+		t.Errorf("Source/Date = %s/%v", s.Source, s.Date)
+	}
+	if len(s.Movers) != 5 {
+		t.Errorf("len(Movers) = %d, want 5", len(s.Movers))
+	}
+
+	tests := []struct {
+		name string
+		got  []report.Mover
+		want []string
+	}{
+		{"gainers", s.Gainers, []string{"2330", "1101"}},
+		{"losers", s.Losers, []string{"9999", "2317"}},
+		{"volume leaders", s.VolumeLeaders, []string{"2317", "2330"}},
+	}
+	for _, tt := range tests {
+		if got := symbols(tt.got); !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("%s = %v, want %v", tt.name, got, tt.want)
+		}
+	}
+
+	if got := s.Losers[0].ChangePct; got != -20 {
+		t.Errorf("9999 ChangePct = %v, want -20 (from previous close)", got)
+	}
+}
+
+func TestSummarizeMarket_NoData(t *testing.T) {
+	snapshot := map[string]*dataset.Dataset{"AAPL.US": bar(t, "AAPL.US", 185, math.NaN(), 100)}
+	if _, err := report.SummarizeMarket("Market", snapshot, nil, 5); !errors.Is(err, report.ErrNoData) {
+		t.Errorf("SummarizeMarket() error = %v, want ErrNoData", err)
+	}
+}
+
+func TestBreadth_Ratio(t *testing.T) {
+	if got := (report.Breadth{Advancers: 3}).Ratio(); !math.IsInf(got, 1) {
+		t.Errorf("Ratio() with no decliners = %v, want +Inf", got)
+	}
+	if got := (report.Breadth{Unchanged: 3}).Ratio(); !math.IsNaN(got) {
+		t.Errorf("Ratio() with no movers = %v, want NaN", got)
+	}
+}
+
+func TestMarketSummary_Write(t *testing.T) {
+	snapshot := map[string]*dataset.Dataset{
+		"2330": bar(t, "2330", 1100, 100, 25000000),
+		"2317": bar(t, "2317", 95, -5, 9000),
+	}
+	s, err := report.SummarizeMarket("<Market>", snapshot, nil, 5)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var md strings.Builder
+	if err := s.WriteMarkdown(&md); err != nil {
+		t.Fatalf("WriteMarkdown() error = %v", err)
+	}
+	for _, want := range []string{
+		"_twse snapshot of 2025-10-28",
+		"| 1 | 1 | 0 | 1.00 | 25,009,000 |",
+		"## Top Gainers",
+		"| 2330 | 1,100.00 | +10.00% | 25,000,000 |",
+		"## Top Losers",
+		"| 2317 | 95.00 | -5.00% | 9,000 |",
+		"## Volume Leaders",
+	} {
+		if !strings.Contains(md.String(), want) {
+			t.Errorf("markdown missing %q:\n%s", want, md.String())
+		}
+	}
+
+	var html strings.Builder
+	if err := s.WriteHTML(&html); err != nil {
+		t.Fatalf("WriteHTML() error = %v", err)
+	}
+	for _, want := range []string{"&lt;Market&gt;", "<h2>Top Losers</h2>", `<td class="num down">-5.00%</td>`} {
+		if !strings.Contains(html.String(), want) {
+			t.Errorf("html missing %q:\n%s", want, html.String())
+		}
+	}
+}
+
+func TestSectorPerformance(t *testing.T) {
+	movers := []report.Mover{
+		{Symbol: "2330", Change: 10, ChangePct: 1, Volume: 100},
+		{Symbol: "2454", Change: -4, ChangePct: -2, Volume: 50},
+		{Symbol: "1101", Change: 3, ChangePct: 3, Volume: math.NaN()},
+	}
+	sectors := map[string]string{"2330": "Semiconductors", "2454": "Semiconductors", "1101": "Cement"}
+
+	got := report.SectorPerformance(movers, func(symbol string) string { return sectors[symbol] })
+	want := []report.SectorStat{
+		{Sector: "Cement", Symbols: 1, Breadth: report.Breadth{Advancers: 1}, MeanChangePct: 3},
+		{Sector: "Semiconductors", Symbols: 2, Breadth: report.Breadth{Advancers: 1, Decliners: 1}, MeanChangePct: -0.5, TotalVolume: 150},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("SectorPerformance() = %+v, want %+v", got, want)
+	}
+}
@@ -0,0 +1,329 @@
+// Package report renders fetched datasets into shareable HTML or Markdown
+// market reports using Go templates.
+//
+// # Example Usage
+//
+//	aapl, _ := datareader.ReadDataset(ctx, "AAPL", "yahoo", start, end, nil)
+//	msft, _ := datareader.ReadDataset(ctx, "MSFT", "yahoo", start, end, nil)
+//
+//	r, err := report.New("Daily Market Report", "Close", aapl, msft)
+//	if err != nil {
+//		log.Fatal(err)
+//	}
+//	if err := r.WriteMarkdown(os.Stdout); err != nil {
+//		log.Fatal(err)
+//	}
+//
+// Custom layouts can be rendered with Render, which passes the *Report to
+// any text/template or html/template template.
+package report
+
+import (
+	"embed"
+	"errors"
+	"fmt"
+	htmltemplate "html/template"
+	"io"
+	"math"
+	"sort"
+	"strings"
+	"text/template"
+	"time"
+
+	"github.com/julianshen/gonp-datareader/dataset"
+)
+
+// SparklinePoints is the maximum number of points kept in a sparkline.
+const SparklinePoints = 30
+
+// DefaultTopMovers is the number of top movers listed by New.
+const DefaultTopMovers = 5
+
+// ErrNoData indicates a dataset has no usable values in the report column.
+var ErrNoData = errors.New("no data to report")
+
+//go:embed templates/*
+var templateFS embed.FS
+
+var (
+	markdownTemplate = template.Must(template.New("report.md.tmpl").Funcs(funcs).ParseFS(templateFS, "templates/report.md.tmpl"))
+	htmlTemplate     = htmltemplate.Must(htmltemplate.New("report.html.tmpl").Funcs(htmltemplate.FuncMap(funcs)).ParseFS(templateFS, "templates/report.html.tmpl"))
+)
+
+// funcs are the helper functions available to report templates.
+var funcs = template.FuncMap{
+	"date":    func(t time.Time) string { return t.Format("2006-01-02") },
+	"number":  func(v float64) string { return formatNumber(v) },
+	"percent": func(v float64) string { return fmt.Sprintf("%+.2f%%", v) },
+	"ratio":   func(v float64) string { return formatRatio(v) },
+	"count":   func(v float64) string { return formatCount(v) },
+}
+
+// Summary holds the summary statistics of one series.
+type Summary struct {
+	Symbol string
+	Source string
+	Column string
+
+	// Start and End are the dates of the first and last values.
+	Start time.Time
+	End   time.Time
+
+	First     float64
+	Last      float64
+	Change    float64
+	ChangePct float64
+	High      float64
+	Low       float64
+	Mean      float64
+
+	// Observations is the number of non-missing values.
+	Observations int
+
+	// Sparkline holds up to SparklinePoints evenly spaced values, suitable
+	// for drawing a small price chart.
+	Sparkline []float64
+}
+
+// SparklineText renders the sparkline with Unicode block characters.
+func (s Summary) SparklineText() string {
+	const blocks = "▁▂▃▄▅▆▇█"
+	levels := []rune(blocks)
+
+	lo, hi := bounds(s.Sparkline)
+
+	var b strings.Builder
+	for _, v := range s.Sparkline {
+		level := 0
+		if hi > lo {
+			level = int((v - lo) / (hi - lo) * float64(len(levels)-1))
+		}
+		b.WriteRune(levels[level])
+	}
+	return b.String()
+}
+
+// SparklinePoints returns the sparkline as SVG polyline points scaled to
+// a width x height box, with higher values drawn nearer the top.
+func (s Summary) SparklinePoints(width, height float64) string {
+	lo, hi := bounds(s.Sparkline)
+
+	points := make([]string, len(s.Sparkline))
+	for i, v := range s.Sparkline {
+		x := 0.0
+		if len(s.Sparkline) > 1 {
+			x = float64(i) / float64(len(s.Sparkline)-1) * width
+		}
+		y := height / 2
+		if hi > lo {
+			y = height - (v-lo)/(hi-lo)*height
+		}
+		points[i] = fmt.Sprintf("%.1f,%.1f", x, y)
+	}
+	return strings.Join(points, " ")
+}
+
+// Summarize computes the summary of column in ds, ignoring missing values.
+func Summarize(ds *dataset.Dataset, column string) (Summary, error) {
+	values, ok := ds.Column(column)
+	if !ok {
+		return Summary{}, fmt.Errorf("%s: column %q not found", label(ds), column)
+	}
+
+	s := Summary{
+		Symbol: ds.Symbol,
+		Source: ds.Source,
+		Column: column,
+		High:   math.Inf(-1),
+		Low:    math.Inf(1),
+	}
+
+	var sum float64
+	points := make([]float64, 0, len(values))
+	for i, v := range values {
+		if math.IsNaN(v) {
+			continue
+		}
+		if s.Observations == 0 {
+			s.Start = ds.Dates[i]
+			s.First = v
+		}
+		s.End = ds.Dates[i]
+		s.Last = v
+		s.High = math.Max(s.High, v)
+		s.Low = math.Min(s.Low, v)
+		sum += v
+		s.Observations++
+		points = append(points, v)
+	}
+	if s.Observations == 0 {
+		return Summary{}, fmt.Errorf("%s: %w", label(ds), ErrNoData)
+	}
+
+	s.Mean = sum / float64(s.Observations)
+	s.Change = s.Last - s.First
+	if s.First != 0 {
+		s.ChangePct = s.Change / math.Abs(s.First) * 100
+	}
+	s.Sparkline = downsample(points, SparklinePoints)
+
+	return s, nil
+}
+
+// Report is the data passed to report templates.
+type Report struct {
+	Title       string
+	GeneratedAt time.Time
+
+	// Summaries holds one summary per dataset, in input order.
+	Summaries []Summary
+
+	// TopMovers holds the summaries with the largest absolute ChangePct,
+	// largest first.
+	TopMovers []Summary
+}
+
+// New summarizes column of every dataset into a Report, listing up to
+// DefaultTopMovers top movers.
+func New(title, column string, datasets ...*dataset.Dataset) (*Report, error) {
+	r := &Report{
+		Title:       title,
+		GeneratedAt: time.Now(),
+		Summaries:   make([]Summary, 0, len(datasets)),
+	}
+
+	for _, ds := range datasets {
+		s, err := Summarize(ds, column)
+		if err != nil {
+			return nil, err
+		}
+		r.Summaries = append(r.Summaries, s)
+	}
+
+	r.TopMovers = TopMovers(r.Summaries, DefaultTopMovers)
+	return r, nil
+}
+
+// TopMovers returns up to n summaries with the largest absolute ChangePct,
+// largest first.
+func TopMovers(summaries []Summary, n int) []Summary {
+	movers := make([]Summary, len(summaries))
+	copy(movers, summaries)
+	sort.SliceStable(movers, func(i, j int) bool {
+		return math.Abs(movers[i].ChangePct) > math.Abs(movers[j].ChangePct)
+	})
+	if n >= 0 && len(movers) > n {
+		movers = movers[:n]
+	}
+	return movers
+}
+
+// Executor is implemented by both text/template and html/template
+// templates.
+type Executor interface {
+	Execute(w io.Writer, data interface{}) error
+}
+
+// Render executes tmpl with the report as its data.
+func (r *Report) Render(w io.Writer, tmpl Executor) error {
+	return render(w, tmpl, r)
+}
+
+// render executes tmpl with data.
+func render(w io.Writer, tmpl Executor, data interface{}) error {
+	if err := tmpl.Execute(w, data); err != nil {
+		return fmt.Errorf("render report: %w", err)
+	}
+	return nil
+}
+
+// WriteMarkdown renders the report as Markdown.
+func (r *Report) WriteMarkdown(w io.Writer) error {
+	return r.Render(w, markdownTemplate)
+}
+
+// WriteHTML renders the report as a standalone HTML page.
+func (r *Report) WriteHTML(w io.Writer) error {
+	return r.Render(w, htmlTemplate)
+}
+
+// Funcs returns the helper functions used by the built-in templates
+// (date, number, percent, ratio, count), for use in custom templates.
+func Funcs() template.FuncMap {
+	out := make(template.FuncMap, len(funcs))
+	for k, v := range funcs {
+		out[k] = v
+	}
+	return out
+}
+
+// downsample returns at most n evenly spaced values, always keeping the
+// first and last.
+func downsample(values []float64, n int) []float64 {
+	if len(values) <= n {
+		return values
+	}
+
+	out := make([]float64, n)
+	step := float64(len(values)-1) / float64(n-1)
+	for i := range out {
+		out[i] = values[int(math.Round(float64(i)*step))]
+	}
+	return out
+}
+
+// bounds returns the minimum and maximum of values.
+func bounds(values []float64) (lo, hi float64) {
+	lo, hi = math.Inf(1), math.Inf(-1)
+	for _, v := range values {
+		lo = math.Min(lo, v)
+		hi = math.Max(hi, v)
+	}
+	return lo, hi
+}
+
+// formatNumber formats v with two decimals and thousands separators.
+func formatNumber(v float64) string {
+	s := fmt.Sprintf("%.2f", math.Abs(v))
+	intPart, frac := s[:len(s)-3], s[len(s)-3:]
+
+	var b strings.Builder
+	if v < 0 {
+		b.WriteByte('-')
+	}
+	for i, c := range intPart {
+		if i > 0 && (len(intPart)-i)%3 == 0 {
+			b.WriteByte(',')
+		}
+		b.WriteRune(c)
+	}
+	b.WriteString(frac)
+	return b.String()
+}
+
+// formatCount formats v as a whole number with thousands separators, or
+// "n/a" when it is NaN.
+func formatCount(v float64) string {
+	if math.IsNaN(v) {
+		return "n/a"
+	}
+	s := formatNumber(math.Round(v))
+	return s[:len(s)-3]
+}
+
+// formatRatio formats a ratio with two decimals, or "n/a" when it is
+// undefined or infinite.
+func formatRatio(v float64) string {
+	if math.IsNaN(v) || math.IsInf(v, 0) {
+		return "n/a"
+	}
+	return fmt.Sprintf("%.2f", v)
+}
+
+// label names a dataset in error messages.
+func label(ds *dataset.Dataset) string {
+	if ds.Symbol != "" {
+		return ds.Symbol
+	}
+	return ds.Source
+}
@@ -0,0 +1,167 @@
+package report_test
+
+import (
+	"errors"
+	"math"
+	"strings"
+	"testing"
+	"text/template"
+	"time"
+
+	"github.com/julianshen/gonp-datareader/dataset"
+	"github.com/julianshen/gonp-datareader/report"
+)
+
+func series(t *testing.T, symbol string, values ...float64) *dataset.Dataset {
+	t.Helper()
+	dates := make([]time.Time, len(values))
+	for i := range dates {
+		dates[i] = time.Date(2024, 1, 2+i, 0, 0, 0, 0, time.UTC)
+	}
+	ds := dataset.New(symbol, "yahoo", dates)
+	if err := ds.AddColumn("Close", values); err != nil {
+		t.Fatal(err)
+	}
+	return ds
+}
+
+func TestSummarize(t *testing.T) {
+	ds := series(t, "AAPL", math.NaN(), 100, 120, 90, math.NaN(), 110)
+
+	s, err := report.Summarize(ds, "Close")
+	if err != nil {
+		t.Fatalf("Summarize() error = %v", err)
+	}
+
+	if s.Observations != 4 {
+		t.Errorf("Observations = %d, want 4", s.Observations)
+	}
+	if s.First != 100 || s.Last != 110 || s.High != 120 || s.Low != 90 {
+		t.Errorf("First/Last/High/Low = %v/%v/%v/%v, want 100/110/120/90", s.First, s.Last, s.High, s.Low)
+	}
+	if s.Change != 10 || s.ChangePct != 10 {
+		t.Errorf("Change/ChangePct = %v/%v, want 10/10", s.Change, s.ChangePct)
+	}
+	if s.Mean != 105 {
+		t.Errorf("Mean = %v, want 105", s.Mean)
+	}
+	if want := time.Date(2024, 1, 3, 0, 0, 0, 0, time.UTC); !s.Start.Equal(want) {
+		t.Errorf("Start = %v, want %v", s.Start, want)
+	}
+	if got := s.SparklineText(); got != "▃█▁▅" {
+		t.Errorf("SparklineText() = %q, want %q", got, "▃█▁▅")
+	}
+}
+
+func TestSummarize_Errors(t *testing.T) {
+	ds := series(t, "AAPL", math.NaN())
+
+	if _, err := report.Summarize(ds, "Open"); err == nil {
+		t.Error("Summarize() expected error for missing column")
+	}
+	if _, err := report.Summarize(ds, "Close"); !errors.Is(err, report.ErrNoData) {
+		t.Errorf("Summarize() error = %v, want ErrNoData", err)
+	}
+}
+
+func TestSummarize_DownsamplesSparkline(t *testing.T) {
+	values := make([]float64, 100)
+	for i := range values {
+		values[i] = float64(i)
+	}
+
+	s, err := report.Summarize(series(t, "X", values...), "Close")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(s.Sparkline) != report.SparklinePoints {
+		t.Fatalf("len(Sparkline) = %d, want %d", len(s.Sparkline), report.SparklinePoints)
+	}
+	if s.Sparkline[0] != 0 || s.Sparkline[len(s.Sparkline)-1] != 99 {
+		t.Errorf("Sparkline endpoints = %v, %v, want 0, 99", s.Sparkline[0], s.Sparkline[len(s.Sparkline)-1])
+	}
+}
+
+func TestTopMovers(t *testing.T) {
+	summaries := []report.Summary{
+		{Symbol: "A", ChangePct: 1},
+		{Symbol: "B", ChangePct: -5},
+		{Symbol: "C", ChangePct: 3},
+	}
+
+	got := report.TopMovers(summaries, 2)
+	if len(got) != 2 || got[0].Symbol != "B" || got[1].Symbol != "C" {
+		t.Errorf("TopMovers() = %v, want [B C]", got)
+	}
+	if summaries[0].Symbol != "A" {
+		t.Error("TopMovers() modified its input")
+	}
+}
+
+func TestReport_WriteMarkdown(t *testing.T) {
+	r, err := report.New("Daily Report", "Close",
+		series(t, "AAPL", 100, 110),
+		series(t, "MSFT", 2000, 1900))
+	if err != nil {
+		t.Fatalf("New() error = %v", err)
+	}
+
+	var b strings.Builder
+	if err := r.WriteMarkdown(&b); err != nil {
+		t.Fatalf("WriteMarkdown() error = %v", err)
+	}
+
+	out := b.String()
+	for _, want := range []string{
+		"# Daily Report",
+		"| AAPL | yahoo | 110.00 | +10.00% | 110.00 | 100.00 | ▁█ |",
+		"| MSFT | yahoo | 1,900.00 | -5.00% |",
+		"- **AAPL** +10.00% (100.00 → 110.00, 2024-01-02 to 2024-01-03)",
+	} {
+		if !strings.Contains(out, want) {
+			t.Errorf("markdown missing %q:\n%s", want, out)
+		}
+	}
+}
+
+func TestReport_WriteHTML(t *testing.T) {
+	r, err := report.New("<Daily>", "Close", series(t, "AAPL", 100, 90))
+	if err != nil {
+		t.Fatalf("New() error = %v", err)
+	}
+
+	var b strings.Builder
+	if err := r.WriteHTML(&b); err != nil {
+		t.Fatalf("WriteHTML() error = %v", err)
+	}
+
+	out := b.String()
+	for _, want := range []string{
+		"<title>&lt;Daily&gt;</title>",
+		`class="num down">-10.00%`,
+		`<polyline points="0.0,0.0 120.0,24.0"/>`,
+	} {
+		if !strings.Contains(out, want) {
+			t.Errorf("HTML missing %q:\n%s", want, out)
+		}
+	}
+}
+
+func TestReport_Render(t *testing.T) {
+	r, err := report.New("Custom", "Close", series(t, "AAPL", 100, 105))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tmpl := template.Must(template.New("custom").Funcs(report.Funcs()).Parse(
+		`{{range .Summaries}}{{.Symbol}} {{percent .ChangePct}}{{end}}`))
+
+	var b strings.Builder
+	if err := r.Render(&b, tmpl); err != nil {
+		t.Fatalf("Render() error = %v", err)
+	}
+	if b.String() != "AAPL +5.00%" {
+		t.Errorf("Render() = %q, want %q", b.String(), "AAPL +5.00%")
+	}
+}
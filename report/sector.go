@@ -0,0 +1,53 @@
+package report
+
+import (
+	"math"
+	"sort"
+)
+
+// SectorStat aggregates the movers of one sector.
+type SectorStat struct {
+	Sector  string
+	Symbols int
+	Breadth Breadth
+	// MeanChangePct is the equal-weighted average daily change.
+	MeanChangePct float64
+	TotalVolume   float64
+}
+
+// SectorPerformance groups movers by the sector returned by sectorOf, such
+// as classification.Map.Sector, and returns one stat per sector, best
+// MeanChangePct first.
+func SectorPerformance(movers []Mover, sectorOf func(symbol string) string) []SectorStat {
+	index := make(map[string]int)
+	var stats []SectorStat
+
+	for _, m := range movers {
+		sector := sectorOf(m.Symbol)
+		i, ok := index[sector]
+		if !ok {
+			i = len(stats)
+			index[sector] = i
+			stats = append(stats, SectorStat{Sector: sector})
+		}
+
+		s := &stats[i]
+		s.Symbols++
+		s.MeanChangePct += m.ChangePct
+		s.Breadth.add(m.Change)
+		if !math.IsNaN(m.Volume) {
+			s.TotalVolume += m.Volume
+		}
+	}
+
+	for i := range stats {
+		stats[i].MeanChangePct /= float64(stats[i].Symbols)
+	}
+	sort.SliceStable(stats, func(i, j int) bool {
+		if stats[i].MeanChangePct != stats[j].MeanChangePct {
+			return stats[i].MeanChangePct > stats[j].MeanChangePct
+		}
+		return stats[i].Sector < stats[j].Sector
+	})
+	return stats
+}
@@ -0,0 +1,31 @@
+package datareader
+
+import (
+	"github.com/julianshen/gonp-datareader/dataset"
+)
+
+// Resample converts data, as returned by Read for symbol, to a Dataset
+// downsampled to freq with the default aggregations of
+// dataset.Dataset.Resample: OHLCV bars take the first open, highest
+// high, lowest low, last close and total volume of each period, and
+// other columns, such as economic series, their mean. data may also be a
+// *dataset.Dataset. Use dataset.Dataset.Resample to choose the
+// aggregation of each column.
+//
+// # Example Usage
+//
+//	data, err := datareader.Read(ctx, "AAPL", "yahoo", start, end, nil)
+//	if err != nil {
+//		log.Fatal(err)
+//	}
+//	weekly, err := datareader.Resample("AAPL", data, dataset.FrequencyWeekly)
+func Resample(symbol string, data interface{}, freq dataset.Frequency) (*dataset.Dataset, error) {
+	ds, ok := data.(*dataset.Dataset)
+	if !ok {
+		var err error
+		if ds, err = ToDataset(symbol, data); err != nil {
+			return nil, err
+		}
+	}
+	return ds.Resample(freq, dataset.ResampleOptions{})
+}
@@ -0,0 +1,81 @@
+package datareader
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/julianshen/gonp-datareader/calendar"
+	"github.com/julianshen/gonp-datareader/dataset"
+)
+
+// ErrShortDataset is matched by errors.Is for every *ShortDatasetError.
+var ErrShortDataset = errors.New("dataset has fewer rows than expected")
+
+// ShortDatasetError is returned by CheckRowCount when a dataset holds fewer
+// rows than the exchange calendar has sessions, beyond the tolerance.
+type ShortDatasetError struct {
+	// Symbol is the dataset's symbol
+	Symbol string
+	// Calendar is the name of the calendar used
+	Calendar string
+	// Expected is the number of sessions in the range
+	Expected int
+	// Got is the number of rows within the range
+	Got int
+}
+
+// Error implements the error interface.
+func (e *ShortDatasetError) Error() string {
+	return fmt.Sprintf("%s: %d of %d expected rows (%s calendar)", e.Symbol, e.Got, e.Expected, e.Calendar)
+}
+
+// Is implements error matching for errors.Is.
+func (e *ShortDatasetError) Is(target error) bool {
+	return target == ErrShortDataset
+}
+
+// CheckRowCount compares the rows of a daily dataset within [start, end]
+// with the sessions cal has in that range, catching providers that
+// silently truncate history. It returns a *ShortDatasetError when more
+// than tolerance (a fraction, e.g. 0.02 for 2%) of the expected rows are
+// missing. Sessions after the current time are not expected.
+//
+// # Example Usage
+//
+//	err := datareader.CheckRowCount(ds, calendar.NYSE(), start, end, 0.02)
+//	if errors.Is(err, datareader.ErrShortDataset) {
+//		log.Printf("truncated: %v", err)
+//	}
+func CheckRowCount(ds *dataset.Dataset, cal *calendar.Calendar, start, end time.Time, tolerance float64) error {
+	if now := time.Now(); end.After(now) {
+		end = now
+	}
+
+	expected := cal.TradingDays(start, end)
+	got := ds.BetweenDays(start, end).Len()
+	missing := expected - got
+	if missing > 0 && float64(missing) > tolerance*float64(expected) {
+		return &ShortDatasetError{Symbol: ds.Symbol, Calendar: cal.Name(), Expected: expected, Got: got}
+	}
+	return nil
+}
+
+// annotateRowCount implements Options.ExpectedRowsTolerance for ReadDataset.
+func annotateRowCount(ds *dataset.Dataset, source string, start, end time.Time, opts *Options) {
+	cal := opts.Calendar
+	if cal == nil {
+		cal = calendar.ForSource(source)
+	}
+	if cal == nil {
+		return
+	}
+
+	err := CheckRowCount(ds, cal, start, end, opts.ExpectedRowsTolerance)
+	var short *ShortDatasetError
+	if errors.As(err, &short) {
+		ds.Meta["short"] = "true"
+		ds.Meta["expected_rows"] = strconv.Itoa(short.Expected)
+	}
+}
@@ -0,0 +1,115 @@
+package datareader_test
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	datareader "github.com/julianshen/gonp-datareader"
+	"github.com/julianshen/gonp-datareader/calendar"
+	"github.com/julianshen/gonp-datareader/dataset"
+)
+
+// weekdays returns a dataset with one row per weekday from start, n rows.
+func weekdays(start time.Time, n int) *dataset.Dataset {
+	var dates []time.Time
+	for d := start; len(dates) < n; d = d.AddDate(0, 0, 1) {
+		if d.Weekday() != time.Saturday && d.Weekday() != time.Sunday {
+			dates = append(dates, d)
+		}
+	}
+	ds := dataset.New("X", "stooq", dates)
+	ds.AddColumn("Close", make([]float64, n))
+	return ds
+}
+
+func TestCheckRowCount(t *testing.T) {
+	start := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC) // Monday
+	end := time.Date(2024, 1, 31, 0, 0, 0, 0, time.UTC)  // 23 weekdays
+	cal := calendar.Weekdays()
+
+	tests := []struct {
+		name      string
+		rows      int
+		tolerance float64
+		wantShort bool
+	}{
+		{"complete", 23, 0, false},
+		{"within tolerance", 22, 0.05, false},
+		{"short", 20, 0.05, true},
+		{"strict", 22, 0, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := datareader.CheckRowCount(weekdays(start, tt.rows), cal, start, end, tt.tolerance)
+			if got := errors.Is(err, datareader.ErrShortDataset); got != tt.wantShort {
+				t.Fatalf("CheckRowCount() error = %v, want short = %v", err, tt.wantShort)
+			}
+
+			var short *datareader.ShortDatasetError
+			if tt.wantShort && (!errors.As(err, &short) || short.Expected != 23 || short.Got != tt.rows) {
+				t.Errorf("CheckRowCount() error = %#v", err)
+			}
+		})
+	}
+}
+
+func TestCheckRowCount_IgnoresRowsOutsideRange(t *testing.T) {
+	// Sources such as Stooq return full history regardless of the range
+	start := time.Date(2024, 1, 15, 0, 0, 0, 0, time.UTC)
+	end := time.Date(2024, 1, 19, 0, 0, 0, 0, time.UTC)
+	ds := weekdays(time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC), 17)
+
+	if err := datareader.CheckRowCount(ds, calendar.Weekdays(), start, end, 0); err != nil {
+		t.Errorf("CheckRowCount() error = %v", err)
+	}
+	if err := datareader.CheckRowCount(ds, calendar.NYSE(), start, end, 0); err != nil {
+		t.Errorf("CheckRowCount() with holiday error = %v", err)
+	}
+}
+
+func TestReadDataset_ExpectedRowsTolerance(t *testing.T) {
+	// Five sessions requested, two returned
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte("Date,Open,High,Low,Close,Volume\n2024-01-08,1,1,1,1,10\n2024-01-09,1,1,1,1,10\n"))
+	}))
+	defer server.Close()
+
+	start := time.Date(2024, 1, 8, 0, 0, 0, 0, time.UTC)
+	end := time.Date(2024, 1, 12, 0, 0, 0, 0, time.UTC)
+	base := datareader.Options{
+		Environment:     datareader.EnvironmentSandbox,
+		SandboxBaseURLs: map[string]string{"stooq": server.URL + "?s=%s"},
+	}
+
+	tests := []struct {
+		name      string
+		tolerance float64
+		want      map[string]string
+	}{
+		{"disabled", 0, map[string]string{}},
+		{"short", 0.1, map[string]string{"short": "true", "expected_rows": "5"}},
+		{"tolerated", 0.7, map[string]string{}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			opts := base
+			opts.ExpectedRowsTolerance = tt.tolerance
+
+			ds, err := datareader.ReadDataset(context.Background(), "X", "stooq", start, end, &opts)
+			if err != nil {
+				t.Fatalf("ReadDataset() error = %v", err)
+			}
+			for _, key := range []string{"short", "expected_rows"} {
+				if ds.Meta[key] != tt.want[key] {
+					t.Errorf("Meta[%q] = %q, want %q", key, ds.Meta[key], tt.want[key])
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,38 @@
+package datareader
+
+import (
+	"reflect"
+	"time"
+
+	"github.com/julianshen/gonp-datareader/sources"
+)
+
+// finishRows applies the row options to data as returned by a reader's
+// ReadSingle, or by Read as a map of results by symbol: it sorts the rows
+// of each result unless Options.DisableRowSorting is set, then flags or
+// drops its incomplete bars (Options.ExcludeIncompleteBar). Results whose
+// dates cannot be parsed are left as returned; ToDataset reports them.
+// Readers from DataReader apply it to their own results (see withRows);
+// applying it again leaves data unchanged.
+func finishRows(data interface{}, source string, opts *Options) {
+	now := time.Now()
+	finish := func(result interface{}) {
+		if opts == nil || !opts.DisableRowSorting {
+			_, _ = sources.SortRows(result)
+		}
+		markIncomplete(result, source, opts, now)
+	}
+
+	if _, ok := data.(sources.RowSelector); ok {
+		finish(data)
+		return
+	}
+	v := reflect.ValueOf(data)
+	if v.Kind() != reflect.Map {
+		return
+	}
+	iter := v.MapRange()
+	for iter.Next() {
+		finish(iter.Value().Interface())
+	}
+}
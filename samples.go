@@ -0,0 +1,153 @@
+package datareader
+
+import (
+	"bytes"
+	"embed"
+	"errors"
+	"fmt"
+	"sort"
+
+	"github.com/julianshen/gonp-datareader/dataset"
+	"github.com/julianshen/gonp-datareader/sources/alphavantage"
+	"github.com/julianshen/gonp-datareader/sources/ecb"
+	"github.com/julianshen/gonp-datareader/sources/eurostat"
+	"github.com/julianshen/gonp-datareader/sources/finmind"
+	"github.com/julianshen/gonp-datareader/sources/finra"
+	"github.com/julianshen/gonp-datareader/sources/fred"
+	"github.com/julianshen/gonp-datareader/sources/iex"
+	"github.com/julianshen/gonp-datareader/sources/oecd"
+	"github.com/julianshen/gonp-datareader/sources/stooq"
+	"github.com/julianshen/gonp-datareader/sources/tiingo"
+	"github.com/julianshen/gonp-datareader/sources/twse"
+	"github.com/julianshen/gonp-datareader/sources/worldbank"
+	"github.com/julianshen/gonp-datareader/sources/yahoo"
+)
+
+// ErrNoSample is returned by SampleData and SampleDataset for sources
+// without an embedded sample.
+var ErrNoSample = errors.New("no sample for source")
+
+// MetaSample is the Meta key set to "true" on datasets returned by
+// SampleDataset, so sample data is not mistaken for a download.
+const MetaSample = "sample"
+
+//go:embed samples/*
+var sampleFiles embed.FS
+
+// sample is an embedded upstream response of a source.
+type sample struct {
+	file   string
+	symbol string
+	parse  func(body []byte) (interface{}, error)
+}
+
+// samples holds the sample of each built-in source: a few rows of a
+// well-known symbol in the format the source's API returns.
+var samples = map[string]sample{
+	"yahoo": {"yahoo.csv", "AAPL", func(b []byte) (interface{}, error) {
+		return yahoo.ParseCSV(bytes.NewReader(b))
+	}},
+	"fred": {"fred.json", "GDP", func(b []byte) (interface{}, error) {
+		return fred.ParseJSON(bytes.NewReader(b))
+	}},
+	"worldbank": {"worldbank.json", "USA/NY.GDP.MKTP.CD", func(b []byte) (interface{}, error) {
+		return worldbank.ParseResponse(b)
+	}},
+	"alphavantage": {"alphavantage.json", "IBM", func(b []byte) (interface{}, error) {
+		return alphavantage.ParseResponse(b)
+	}},
+	"stooq": {"stooq.csv", "AAPL.US", func(b []byte) (interface{}, error) {
+		return stooq.ParseCSV(b)
+	}},
+	"iex": {"iex.json", "MSFT", func(b []byte) (interface{}, error) {
+		return iex.ParseResponse(b)
+	}},
+	"tiingo": {"tiingo.json", "GOOGL", func(b []byte) (interface{}, error) {
+		return tiingo.ParseJSON(bytes.NewReader(b))
+	}},
+	"oecd": {"oecd.json", "QNA/USA.GDP", func(b []byte) (interface{}, error) {
+		return oecd.ParseJSON(bytes.NewReader(b))
+	}},
+	"eurostat": {"eurostat.csv", "DEMO_R_D3DENS", func(b []byte) (interface{}, error) {
+		return eurostat.ParseSDMXCSV(bytes.NewReader(b))
+	}},
+	"twse": {"twse.json", "2330", func(b []byte) (interface{}, error) {
+		return twse.ParseResponse(b, "2330")
+	}},
+	"finmind": {"finmind.json", "2330", func(b []byte) (interface{}, error) {
+		return finmind.ParseFinMindResponse(b)
+	}},
+	"finra": {"finra.json", "AAPL", func(b []byte) (interface{}, error) {
+		return finra.ParseResponse(b)
+	}},
+	"ecb": {"ecb.csv", "USD", func(b []byte) (interface{}, error) {
+		data, err := ecb.ParseCSV(b)
+		if err != nil {
+			return nil, err
+		}
+		data.Symbol = "USD"
+		return data, nil
+	}},
+}
+
+// SampleSources returns the sources with an embedded sample, sorted by
+// name.
+func SampleSources() []string {
+	names := make([]string, 0, len(samples))
+	for name := range samples {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// SampleSymbol returns the symbol of the sample of source, or "" when
+// there is none.
+func SampleSymbol(source string) string {
+	return samples[source].symbol
+}
+
+// SampleData parses the embedded sample of source with the source's own
+// parser and returns it as the reader would, for example a
+// *yahoo.ParsedData for "yahoo". Samples are a few rows of a well-known
+// symbol (see SampleSymbol) in the format the source's API returns; the
+// values are illustrative and not meant for analysis.
+func SampleData(source string) (interface{}, error) {
+	s, ok := samples[source]
+	if !ok {
+		return nil, fmt.Errorf("%w: %q", ErrNoSample, source)
+	}
+	body, err := sampleFiles.ReadFile("samples/" + s.file)
+	if err != nil {
+		return nil, fmt.Errorf("read sample of %s: %w", source, err)
+	}
+	data, err := s.parse(body)
+	if err != nil {
+		return nil, fmt.Errorf("parse sample of %s: %w", source, err)
+	}
+	return data, nil
+}
+
+// SampleDataset returns the embedded sample of source converted with
+// ToDataset, so the parse and convert pipeline can be tried offline and
+// without API keys. Meta[MetaSample] of the result is "true".
+//
+// # Example Usage
+//
+//	ds, err := datareader.SampleDataset("yahoo")
+//	if err != nil {
+//		log.Fatal(err)
+//	}
+//	fmt.Println(ds.Symbol, ds.ColumnNames()) // AAPL [Open High Low Close Adj Close Volume]
+func SampleDataset(source string) (*dataset.Dataset, error) {
+	data, err := SampleData(source)
+	if err != nil {
+		return nil, err
+	}
+	ds, err := ToDataset(samples[source].symbol, data)
+	if err != nil {
+		return nil, fmt.Errorf("convert sample of %s: %w", source, err)
+	}
+	ds.Meta[MetaSample] = "true"
+	return ds, nil
+}
@@ -0,0 +1,45 @@
+package datareader_test
+
+import (
+	"errors"
+	"testing"
+
+	datareader "github.com/julianshen/gonp-datareader"
+)
+
+func TestSampleDataset(t *testing.T) {
+	listed := make(map[string]bool)
+	for _, source := range datareader.ListSources() {
+		listed[source] = true
+	}
+
+	for _, source := range datareader.SampleSources() {
+		t.Run(source, func(t *testing.T) {
+			if !listed[source] {
+				t.Errorf("sample of %q, which ListSources does not list", source)
+			}
+			ds, err := datareader.SampleDataset(source)
+			if err != nil {
+				t.Fatalf("SampleDataset(%q) error = %v", source, err)
+			}
+			if ds.Source != source || ds.Symbol != datareader.SampleSymbol(source) {
+				t.Errorf("SampleDataset(%q) = %s/%s", source, ds.Source, ds.Symbol)
+			}
+			if ds.Len() == 0 || len(ds.Columns) == 0 {
+				t.Errorf("SampleDataset(%q) has %d rows and %d columns", source, ds.Len(), len(ds.Columns))
+			}
+			if ds.Meta[datareader.MetaSample] != "true" {
+				t.Errorf("SampleDataset(%q) Meta = %v, want %s", source, ds.Meta, datareader.MetaSample)
+			}
+		})
+	}
+
+	ds, _ := datareader.SampleDataset("yahoo")
+	if ds.Unit("Close") != "USD" || ds.Len() != 7 {
+		t.Errorf("yahoo sample: %d rows, Close in %q", ds.Len(), ds.Unit("Close"))
+	}
+
+	if _, err := datareader.SampleDataset("nope"); !errors.Is(err, datareader.ErrNoSample) {
+		t.Errorf("SampleDataset(nope) error = %v, want ErrNoSample", err)
+	}
+}
@@ -0,0 +1,40 @@
+package datareader
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/julianshen/gonp-datareader/sources"
+)
+
+// ErrSearchNotSupported is returned by SearchSymbols for sources that do
+// not implement sources.Searcher.
+var ErrSearchNotSupported = errors.New("source does not support symbol search")
+
+// SearchSymbols looks up the symbols of source matching query, a company
+// name, keyword or partial ticker, most relevant first. Supported sources
+// are "yahoo" (autocomplete), "alphavantage" (SYMBOL_SEARCH), "tiingo"
+// and "fred" (series search); the latter three need an API key.
+//
+// # Example Usage
+//
+//	matches, err := datareader.SearchSymbols(ctx, "fred", "unemployment rate", opts)
+//	if err != nil {
+//		log.Fatal(err)
+//	}
+//	for _, m := range matches {
+//		fmt.Printf("%-10s %s (%s)\n", m.Symbol, m.Name, m.Description)
+//	}
+func SearchSymbols(ctx context.Context, source, query string, opts *Options) ([]sources.SymbolInfo, error) {
+	reader, err := DataReader(source, opts)
+	if err != nil {
+		return nil, err
+	}
+
+	searcher, ok := reader.(sources.Searcher)
+	if !ok {
+		return nil, fmt.Errorf("%w: %s", ErrSearchNotSupported, source)
+	}
+	return searcher.SearchSymbols(ctx, query)
+}
@@ -0,0 +1,92 @@
+package datareader_test
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	datareader "github.com/julianshen/gonp-datareader"
+	"github.com/julianshen/gonp-datareader/sources"
+)
+
+func TestSearchSymbols(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		switch {
+		case r.URL.Query().Get("function") == "SYMBOL_SEARCH":
+			if r.URL.Query().Get("keywords") != "tesco" || r.URL.Query().Get("apikey") != "key" {
+				t.Errorf("unexpected query %s", r.URL.RawQuery)
+			}
+			w.Write([]byte(`{"bestMatches":[{"1. symbol":"TSCO.LON","2. name":"Tesco PLC","3. type":"Equity","4. region":"United Kingdom","8. currency":"GBX","9. matchScore":"0.7273"}]}`))
+		case r.URL.Path == "/fred/series/search":
+			if r.URL.Query().Get("search_text") != "unemployment rate" {
+				t.Errorf("search_text = %q", r.URL.Query().Get("search_text"))
+			}
+			w.Write([]byte(`{"seriess":[{"id":"UNRATE","title":"Unemployment Rate","frequency":"Monthly","units":"Percent","seasonal_adjustment":"Seasonally Adjusted"}]}`))
+		default:
+			t.Errorf("unexpected request %s", r.URL)
+		}
+	}))
+	defer server.Close()
+
+	opts := &datareader.Options{
+		APIKey: "key",
+		BaseURLOverrides: map[string]string{
+			"alphavantage": server.URL + "/query?function=TIME_SERIES_DAILY&symbol=%s&apikey=%s",
+			"fred":         server.URL + "/fred/series/observations",
+		},
+	}
+
+	tests := []struct {
+		source string
+		query  string
+		want   sources.SymbolInfo
+	}{
+		{
+			source: "alphavantage",
+			query:  "tesco",
+			want:   sources.SymbolInfo{Symbol: "TSCO.LON", Name: "Tesco PLC", Market: "United Kingdom", Type: "Equity", Description: "Currency: GBX"},
+		},
+		{
+			source: "fred",
+			query:  " unemployment rate ",
+			want:   sources.SymbolInfo{Symbol: "UNRATE", Name: "Unemployment Rate", Type: "series", Description: "Monthly; Percent; Seasonally Adjusted"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.source, func(t *testing.T) {
+			matches, err := datareader.SearchSymbols(context.Background(), tt.source, tt.query, opts)
+			if err != nil {
+				t.Fatalf("SearchSymbols() error = %v", err)
+			}
+			if len(matches) != 1 || matches[0] != tt.want {
+				t.Errorf("SearchSymbols() = %+v, want [%+v]", matches, tt.want)
+			}
+		})
+	}
+}
+
+func TestSearchSymbols_Errors(t *testing.T) {
+	tests := []struct {
+		name    string
+		source  string
+		query   string
+		wantErr error
+	}{
+		{"not supported", "stooq", "apple", datareader.ErrSearchNotSupported},
+		{"unknown source", "unknown", "apple", datareader.ErrUnknownSource},
+		{"empty query", "yahoo", "  ", sources.ErrEmptyQuery},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := datareader.SearchSymbols(context.Background(), tt.source, tt.query, nil)
+			if !errors.Is(err, tt.wantErr) {
+				t.Errorf("SearchSymbols(%q) error = %v, want %v", tt.source, err, tt.wantErr)
+			}
+		})
+	}
+}
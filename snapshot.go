@@ -0,0 +1,75 @@
+package datareader
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/julianshen/gonp-datareader/dataset"
+	"github.com/julianshen/gonp-datareader/sources"
+	"github.com/julianshen/gonp-datareader/sources/stooq"
+	"github.com/julianshen/gonp-datareader/sources/twse"
+)
+
+// ErrSnapshotNotSupported is returned by ReadMarketSnapshot for sources
+// that do not implement sources.SnapshotReader.
+var ErrSnapshotNotSupported = errors.New("source does not support market snapshots")
+
+// ReadMarketSnapshot fetches one trading day of every symbol listed on
+// source in a single request and converts each symbol's data to a
+// dataset.Dataset keyed by symbol. Supported sources are "twse" (latest
+// trading day only; pass a zero date) and "stooq" (bulk file per date).
+//
+// # Example Usage
+//
+//	day := time.Date(2024, 1, 2, 0, 0, 0, 0, time.UTC)
+//	market, err := datareader.ReadMarketSnapshot(ctx, "stooq", day, nil)
+//	if err != nil {
+//		log.Fatal(err)
+//	}
+//	closes, _ := market["AAPL.US"].Column("Close")
+func ReadMarketSnapshot(ctx context.Context, source string, date time.Time, opts *Options) (map[string]*dataset.Dataset, error) {
+	opts = withDefaults(opts)
+	reader, err := DataReader(source, opts)
+	if err != nil {
+		return nil, err
+	}
+
+	snapshotter, ok := reader.(sources.SnapshotReader)
+	if !ok {
+		return nil, fmt.Errorf("%w: %s", ErrSnapshotNotSupported, source)
+	}
+
+	data, err := snapshotter.ReadMarketSnapshot(ctx, date)
+	if err != nil {
+		return nil, err
+	}
+
+	mode := NumericFloat64
+	if opts != nil {
+		mode = opts.NumericMode
+	}
+
+	switch d := data.(type) {
+	case map[string]*twse.ParsedData:
+		return snapshotToDatasets(d, mode)
+	case map[string]*stooq.ParsedData:
+		return snapshotToDatasets(d, mode)
+	default:
+		return nil, fmt.Errorf("unsupported snapshot type %T", data)
+	}
+}
+
+// snapshotToDatasets converts every symbol of a snapshot to a dataset.
+func snapshotToDatasets[T any](snapshot map[string]T, mode NumericMode) (map[string]*dataset.Dataset, error) {
+	out := make(map[string]*dataset.Dataset, len(snapshot))
+	for symbol, data := range snapshot {
+		ds, err := ToDatasetMode(symbol, data, mode)
+		if err != nil {
+			return nil, fmt.Errorf("convert %s: %w", symbol, err)
+		}
+		out[symbol] = ds
+	}
+	return out, nil
+}
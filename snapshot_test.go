@@ -0,0 +1,22 @@
+package datareader_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	datareader "github.com/julianshen/gonp-datareader"
+)
+
+func TestReadMarketSnapshot_NotSupported(t *testing.T) {
+	_, err := datareader.ReadMarketSnapshot(context.Background(), "yahoo", time.Time{}, nil)
+	if !errors.Is(err, datareader.ErrSnapshotNotSupported) {
+		t.Errorf("ReadMarketSnapshot(yahoo) error = %v, want ErrSnapshotNotSupported", err)
+	}
+
+	_, err = datareader.ReadMarketSnapshot(context.Background(), "unknown", time.Time{}, nil)
+	if !errors.Is(err, datareader.ErrUnknownSource) {
+		t.Errorf("ReadMarketSnapshot(unknown) error = %v, want ErrUnknownSource", err)
+	}
+}
@@ -0,0 +1,241 @@
+package datareader
+
+import (
+	"fmt"
+	"regexp"
+	"time"
+
+	"github.com/julianshen/gonp-datareader/dataset"
+	"github.com/julianshen/gonp-datareader/sources"
+	"github.com/julianshen/gonp-datareader/sources/alphavantage"
+	"github.com/julianshen/gonp-datareader/sources/finmind"
+	"github.com/julianshen/gonp-datareader/sources/stooq"
+	"github.com/julianshen/gonp-datareader/sources/tiingo"
+	"github.com/julianshen/gonp-datareader/sources/yahoo"
+)
+
+// SourceMetadata describes what a data source offers, so applications can
+// list sources and validate input without hard-coding provider details.
+type SourceMetadata struct {
+	// Name is the source name passed to DataReader (e.g., "fred")
+	Name string
+	// Description is a one-line description of the provider
+	Description string
+	// RequiresAPIKey reports whether reads fail without Options.APIKey
+	RequiresAPIKey bool
+	// APIKeyOptional reports whether an API key is accepted but not
+	// required, typically raising the rate limit
+	APIKeyOptional bool
+	// Intervals lists the bar intervals of price sources (e.g., "1d");
+	// nil for economic sources
+	Intervals []string
+	// Frequencies lists the native frequencies of economic series; nil
+	// for price sources
+	Frequencies []dataset.Frequency
+	// RateLimit is the provider's published limit in requests per second
+	// for the free tier, suitable for Options.RateLimits; 0 when the
+	// provider publishes none
+	RateLimit float64
+	// RateLimitNote states the limit as the provider does (e.g., "120
+	// requests per minute")
+	RateLimitNote string
+	// SymbolPattern is a regular expression matching the symbols the
+	// source accepts, after normalization (see MatchSymbol)
+	SymbolPattern string
+	// SymbolExamples lists typical symbols
+	SymbolExamples []string
+	// CoverageStart is the earliest date the provider serves data for;
+	// individual symbols usually start later. Zero when unknown.
+	CoverageStart time.Time
+	// CoverageNote describes the data coverage
+	CoverageNote string
+	// Sandbox reports whether EnvironmentSandbox is available without a
+	// SandboxBaseURLs entry
+	Sandbox bool
+	// APIVersion is the provider API version used by default
+	APIVersion string
+	// Registered reports a source added with RegisterSource, which is
+	// described by its Name only
+	Registered bool
+}
+
+// MatchSymbol reports whether symbol, as normalized by the source's
+// reader, has the source's symbol format.
+func (m SourceMetadata) MatchSymbol(symbol string) bool {
+	if m.SymbolPattern == "" {
+		return true
+	}
+	return regexp.MustCompile(m.SymbolPattern).MatchString(symbol)
+}
+
+// Symbol formats accepted by the built-in readers.
+const (
+	// tickerPattern is the common validation rule of sources.BaseSource:
+	// letters, digits, dots and hyphens
+	tickerPattern = `^[\p{L}\p{N}.\-]+$`
+	// identifierPattern accepts any identifier without whitespace
+	identifierPattern = `^\S+$`
+)
+
+// dailyBars is the bar interval of the built-in price sources without
+// Options.Interval support.
+var dailyBars = []string{"1d"}
+
+// intervalNames returns the names of intervals.
+func intervalNames(intervals []sources.Interval) []string {
+	names := make([]string, len(intervals))
+	for i, interval := range intervals {
+		names[i] = string(interval)
+	}
+	return names
+}
+
+// sourceMetadata describes the built-in sources. Sandbox and APIVersion
+// are filled in from sandboxes and versions.
+var sourceMetadata = map[string]SourceMetadata{
+	"yahoo": {
+		Description:    "Yahoo Finance stock prices (OHLCV)",
+		Intervals:      intervalNames(yahoo.Intervals),
+		SymbolPattern:  tickerPattern,
+		SymbolExamples: []string{"AAPL", "MSFT", "BRK-B"},
+		CoverageStart:  time.Date(1962, 1, 2, 0, 0, 0, 0, time.UTC),
+		CoverageNote:   "Daily history from each symbol's listing; intraday bars for up to 730 days",
+	},
+	"fred": {
+		Description: "Federal Reserve Economic Data",
+		Frequencies: []dataset.Frequency{
+			dataset.FrequencyDaily, dataset.FrequencyWeekly, dataset.FrequencyMonthly,
+			dataset.FrequencyQuarterly, dataset.FrequencyAnnual,
+		},
+		RequiresAPIKey: true,
+		RateLimit:      2,
+		RateLimitNote:  "120 requests per minute per API key",
+		SymbolPattern:  tickerPattern,
+		SymbolExamples: []string{"GDP", "UNRATE", "CPIAUCSL"},
+		CoverageNote:   "Series-dependent; revision history (vintages) through ALFRED",
+	},
+	"worldbank": {
+		Description:    "World Bank World Development Indicators",
+		Frequencies:    []dataset.Frequency{dataset.FrequencyAnnual},
+		SymbolPattern:  identifierPattern,
+		SymbolExamples: []string{"USA/NY.GDP.MKTP.CD", "USA;CHN/SP.POP.TOTL"},
+		CoverageStart:  time.Date(1960, 1, 1, 0, 0, 0, 0, time.UTC),
+		CoverageNote:   "Annual observations from 1960",
+	},
+	"alphavantage": {
+		Description:    "Alpha Vantage stock prices",
+		Intervals:      intervalNames(alphavantage.Intervals),
+		RequiresAPIKey: true,
+		RateLimit:      25.0 / (24 * 3600),
+		RateLimitNote:  "25 requests per day on the free tier",
+		SymbolPattern:  tickerPattern,
+		SymbolExamples: []string{"IBM", "AAPL"},
+		CoverageStart:  time.Date(1999, 11, 1, 0, 0, 0, 0, time.UTC),
+		CoverageNote:   "20+ years of daily history",
+	},
+	"stooq": {
+		Description:    "Stooq international stock and index prices",
+		Intervals:      intervalNames(stooq.Intervals),
+		SymbolPattern:  tickerPattern,
+		SymbolExamples: []string{"AAPL.US", "SPY.US", "CL.F"},
+		CoverageNote:   "Daily history, often spanning decades; continuous futures (see package futures)",
+	},
+	"iex": {
+		Description:    "IEX Cloud stock prices",
+		Intervals:      dailyBars,
+		RequiresAPIKey: true,
+		SymbolPattern:  tickerPattern,
+		SymbolExamples: []string{"AAPL", "MSFT"},
+		CoverageNote:   "Up to 15 years of daily history; IEX Cloud was retired in 2024",
+	},
+	"tiingo": {
+		Description:    "Tiingo end-of-day stock prices, including delisted tickers",
+		Intervals:      intervalNames(tiingo.Intervals),
+		RequiresAPIKey: true,
+		RateLimit:      50.0 / 3600,
+		RateLimitNote:  "50 requests per hour and 1,000 per day on the free tier",
+		SymbolPattern:  tickerPattern,
+		SymbolExamples: []string{"AAPL", "MSFT"},
+		CoverageNote:   "Daily history from each symbol's listing",
+	},
+	"oecd": {
+		Description: "OECD economic indicators (SDMX)",
+		Frequencies: []dataset.Frequency{
+			dataset.FrequencyMonthly, dataset.FrequencyQuarterly, dataset.FrequencyAnnual,
+		},
+		SymbolPattern:  identifierPattern,
+		SymbolExamples: []string{"MEI/USA", "QNA/AUS.GDP"},
+		CoverageNote:   "Dataflow-dependent; see OECDReader.ListDataflows",
+	},
+	"eurostat": {
+		Description: "Eurostat European Union statistics",
+		Frequencies: []dataset.Frequency{
+			dataset.FrequencyMonthly, dataset.FrequencyQuarterly, dataset.FrequencyAnnual,
+		},
+		SymbolPattern:  identifierPattern,
+		SymbolExamples: []string{"DEMO_R_D3DENS", "nama_10_gdp"},
+		CoverageNote:   "Dataset-dependent",
+	},
+	"twse": {
+		Description:    "Taiwan Stock Exchange daily trading data",
+		Intervals:      dailyBars,
+		SymbolPattern:  `^[0-9]{4}$|^[0-9]{6}$`,
+		SymbolExamples: []string{"2330", "0050"},
+		CoverageNote:   "Latest trading day only (TWSE Open API)",
+	},
+	"finmind": {
+		Description:    "FinMind Taiwan and international financial data (50+ datasets)",
+		Intervals:      dailyBars,
+		APIKeyOptional: true,
+		RateLimit:      finmind.DefaultRateLimit,
+		RateLimitNote:  "300 requests per hour, 600 with a token",
+		SymbolPattern:  tickerPattern,
+		SymbolExamples: []string{"2330", "0050"},
+		CoverageNote:   "Dataset-dependent",
+	},
+	"finra": {
+		Description:    "FINRA OTC Transparency weekly ATS and non-ATS volume",
+		Frequencies:    []dataset.Frequency{dataset.FrequencyWeekly},
+		SymbolPattern:  tickerPattern,
+		SymbolExamples: []string{"AAPL", "TSLA"},
+		CoverageNote:   "Weekly from mid-2014 (non-ATS from 2016), published 2-4 weeks after the week",
+	},
+	"ecb": {
+		Description:    "European Central Bank euro foreign exchange reference rates",
+		Frequencies:    []dataset.Frequency{dataset.FrequencyDaily},
+		SymbolPattern:  `^[A-Z]{3}$`,
+		SymbolExamples: []string{"USD", "JPY"},
+		CoverageNote:   "TARGET business days from 1999 (later for some currencies), published around 16:00 CET",
+	},
+}
+
+// SourceInfo returns the metadata of a source: whether it needs an API
+// key, its intervals or frequencies, published rate limit, symbol format
+// and data coverage. Sources added with RegisterSource are described by
+// name only. Unknown sources return ErrUnknownSource.
+//
+// # Example Usage
+//
+//	for _, name := range datareader.ListSources() {
+//		info, _ := datareader.SourceInfo(name)
+//		fmt.Printf("%-12s key required: %-5v %s\n", name, info.RequiresAPIKey, info.Description)
+//	}
+func SourceInfo(source string) (SourceMetadata, error) {
+	info, ok := sourceMetadata[source]
+	if !ok {
+		if _, registered := registeredSource(source); registered {
+			return SourceMetadata{Name: source, Registered: true}, nil
+		}
+		return SourceMetadata{}, fmt.Errorf("%w: %s", ErrUnknownSource, source)
+	}
+
+	info.Name = source
+	_, info.Sandbox = sandboxes[source]
+	info.APIVersion = versions[source].def
+
+	// Copy the slices so callers cannot modify the table
+	info.Intervals = append([]string(nil), info.Intervals...)
+	info.Frequencies = append([]dataset.Frequency(nil), info.Frequencies...)
+	info.SymbolExamples = append([]string(nil), info.SymbolExamples...)
+	return info, nil
+}
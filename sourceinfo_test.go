@@ -0,0 +1,135 @@
+package datareader_test
+
+import (
+	"errors"
+	"testing"
+
+	datareader "github.com/julianshen/gonp-datareader"
+)
+
+func TestSourceInfo_BuiltinSources(t *testing.T) {
+	for _, name := range datareader.ListSources() {
+		t.Run(name, func(t *testing.T) {
+			info, err := datareader.SourceInfo(name)
+			if err != nil {
+				t.Fatalf("SourceInfo(%q) error = %v", name, err)
+			}
+			if info.Name != name || info.Description == "" || info.APIVersion == "" {
+				t.Errorf("SourceInfo(%q) = %+v, want name, description and API version", name, info)
+			}
+			if (info.Intervals == nil) == (info.Frequencies == nil) {
+				t.Errorf("SourceInfo(%q) has intervals %v and frequencies %v, want exactly one", name, info.Intervals, info.Frequencies)
+			}
+
+			// Examples have the documented format and pass the reader's validation
+			reader, err := datareader.DataReader(name, nil)
+			if err != nil {
+				t.Fatalf("DataReader(%q) error = %v", name, err)
+			}
+			if len(info.SymbolExamples) == 0 {
+				t.Error("no symbol examples")
+			}
+			for _, symbol := range info.SymbolExamples {
+				if !info.MatchSymbol(symbol) {
+					t.Errorf("example %q does not match %s", symbol, info.SymbolPattern)
+				}
+				if err := reader.ValidateSymbol(symbol); err != nil {
+					t.Errorf("example %q rejected by reader: %v", symbol, err)
+				}
+			}
+		})
+	}
+}
+
+func TestSourceInfo(t *testing.T) {
+	tests := []struct {
+		name     string
+		source   string
+		check    func(t *testing.T, info datareader.SourceMetadata)
+		wantErr  error
+		register bool
+	}{
+		{
+			name:   "API key required",
+			source: "fred",
+			check: func(t *testing.T, info datareader.SourceMetadata) {
+				if !info.RequiresAPIKey || info.RateLimit != 2 {
+					t.Errorf("fred = %+v, want a required key and 2 requests/second", info)
+				}
+			},
+		},
+		{
+			name:   "API key optional",
+			source: "finmind",
+			check: func(t *testing.T, info datareader.SourceMetadata) {
+				if info.RequiresAPIKey || !info.APIKeyOptional {
+					t.Errorf("finmind = %+v, want an optional key", info)
+				}
+			},
+		},
+		{
+			name:   "sandbox",
+			source: "alphavantage",
+			check: func(t *testing.T, info datareader.SourceMetadata) {
+				if !info.Sandbox {
+					t.Error("alphavantage Sandbox = false, want true")
+				}
+			},
+		},
+		{
+			name:   "symbol format",
+			source: "twse",
+			check: func(t *testing.T, info datareader.SourceMetadata) {
+				if info.MatchSymbol("AAPL") || !info.MatchSymbol("2330") {
+					t.Errorf("twse pattern %s, want 4 or 6 digits", info.SymbolPattern)
+				}
+			},
+		},
+		{
+			name:     "registered source",
+			source:   "mybroker",
+			register: true,
+			check: func(t *testing.T, info datareader.SourceMetadata) {
+				if !info.Registered || info.Name != "mybroker" {
+					t.Errorf("mybroker = %+v, want a registered source", info)
+				}
+			},
+		},
+		{
+			name:    "unknown source",
+			source:  "nope",
+			wantErr: datareader.ErrUnknownSource,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if tt.register {
+				registerFake(t, tt.source)
+			}
+
+			info, err := datareader.SourceInfo(tt.source)
+			if tt.wantErr != nil {
+				if !errors.Is(err, tt.wantErr) {
+					t.Fatalf("SourceInfo(%q) error = %v, want %v", tt.source, err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("SourceInfo(%q) error = %v", tt.source, err)
+			}
+			tt.check(t, info)
+		})
+	}
+}
+
+func TestSourceInfo_ReturnsCopies(t *testing.T) {
+	info, _ := datareader.SourceInfo("yahoo")
+	info.SymbolExamples[0] = "CHANGED"
+	info.Intervals[0] = "CHANGED"
+
+	again, _ := datareader.SourceInfo("yahoo")
+	if again.SymbolExamples[0] == "CHANGED" || again.Intervals[0] == "CHANGED" {
+		t.Error("modifying a SourceInfo result changed the source table")
+	}
+}
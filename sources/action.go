@@ -0,0 +1,62 @@
+package sources
+
+import (
+	"context"
+	"sort"
+	"time"
+)
+
+// ActionType is the kind of a corporate action.
+type ActionType string
+
+// Corporate action types.
+const (
+	// ActionDividend is a cash dividend; Action.Amount is paid per share.
+	ActionDividend ActionType = "dividend"
+
+	// ActionSplit is a stock split; Action.Ratio is the shares held after
+	// the split per share held before (4 for a 4-for-1 split, 0.1 for a
+	// 1-for-10 reverse split).
+	ActionSplit ActionType = "split"
+
+	// ActionStockDividend is a dividend paid in shares, common in Taiwan;
+	// Action.Ratio is the shares held after it per share held before (1.05
+	// for 0.5 TWD of stock per share at the 10 TWD par value). Prices
+	// adjust for it like for a split.
+	ActionStockDividend ActionType = "stock_dividend"
+)
+
+// Action is a dividend or split, dated by its ex-date: the first session
+// whose price no longer includes it.
+type Action struct {
+	// Symbol is the symbol the action applies to
+	Symbol string
+	// Date is the ex-date
+	Date time.Time
+	// Type is the kind of action
+	Type ActionType
+	// Amount is the cash dividend per share, in the listing currency; zero
+	// for other types
+	Amount float64
+	// Ratio is the shares held after the action per share held before;
+	// zero for cash dividends
+	Ratio float64
+}
+
+// ActionReader is implemented by readers that serve corporate actions,
+// for building adjusted return series.
+type ActionReader interface {
+	// ReadActions returns the dividends and splits of symbol with ex-dates
+	// in the range, sorted by date.
+	ReadActions(ctx context.Context, symbol string, start, end time.Time) ([]Action, error)
+}
+
+// SortActions sorts actions by date, then by type.
+func SortActions(actions []Action) {
+	sort.SliceStable(actions, func(i, j int) bool {
+		if !actions[i].Date.Equal(actions[j].Date) {
+			return actions[i].Date.Before(actions[j].Date)
+		}
+		return actions[i].Type < actions[j].Type
+	})
+}
@@ -0,0 +1,47 @@
+package sources
+
+import (
+	"strconv"
+)
+
+// AdjustedRows returns copies of rows with split- and dividend-adjusted
+// prices: Open, High and Low are scaled by the ratio of "Adj Close" to
+// "Close", and Close is replaced by "Adj Close". Volume and the "Adj
+// Close" column are kept. Rows without a usable Close or Adj Close are
+// copied unchanged. The input rows, which may be shared through the
+// decoded cache, are not modified.
+func AdjustedRows(rows []map[string]string) []map[string]string {
+	adjusted := make([]map[string]string, len(rows))
+	for i, row := range rows {
+		out := make(map[string]string, len(row))
+		for k, v := range row {
+			out[k] = v
+		}
+		adjusted[i] = out
+
+		closePrice, err1 := strconv.ParseFloat(row["Close"], 64)
+		adjClose, err2 := strconv.ParseFloat(row["Adj Close"], 64)
+		if err1 != nil || err2 != nil || closePrice == 0 {
+			continue
+		}
+
+		factor := adjClose / closePrice
+		for _, name := range []string{"Open", "High", "Low"} {
+			if v, err := strconv.ParseFloat(row[name], 64); err == nil {
+				out[name] = strconv.FormatFloat(v*factor, 'f', -1, 64)
+			}
+		}
+		out["Close"] = row["Adj Close"]
+	}
+	return adjusted
+}
+
+// AdjustedMeta records meta["adjusted"] = "true" for data whose prices
+// were adjusted, allocating meta if needed.
+func AdjustedMeta(meta map[string]string) map[string]string {
+	if meta == nil {
+		meta = make(map[string]string)
+	}
+	meta["adjusted"] = "true"
+	return meta
+}
@@ -0,0 +1,55 @@
+package sources_test
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/julianshen/gonp-datareader/sources"
+)
+
+func TestAdjustedRows(t *testing.T) {
+	tests := []struct {
+		name string
+		row  map[string]string
+		want map[string]string
+	}{
+		{
+			name: "scaled",
+			row:  map[string]string{"Date": "2024-01-02", "Open": "20", "High": "40", "Low": "10", "Close": "20", "Adj Close": "10", "Volume": "100"},
+			want: map[string]string{"Date": "2024-01-02", "Open": "10", "High": "20", "Low": "5", "Close": "10", "Adj Close": "10", "Volume": "100"},
+		},
+		{
+			name: "null adj close",
+			row:  map[string]string{"Open": "20", "Close": "20", "Adj Close": "null"},
+			want: map[string]string{"Open": "20", "Close": "20", "Adj Close": "null"},
+		},
+		{
+			name: "no adj close",
+			row:  map[string]string{"Open": "20", "Close": "20"},
+			want: map[string]string{"Open": "20", "Close": "20"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			original := make(map[string]string, len(tt.row))
+			for k, v := range tt.row {
+				original[k] = v
+			}
+
+			got := sources.AdjustedRows([]map[string]string{tt.row})
+			if !reflect.DeepEqual(got[0], tt.want) {
+				t.Errorf("AdjustedRows() = %v, want %v", got[0], tt.want)
+			}
+			if !reflect.DeepEqual(tt.row, original) {
+				t.Errorf("AdjustedRows() modified its input: %v", tt.row)
+			}
+		})
+	}
+}
+
+func TestAdjustedMeta(t *testing.T) {
+	if got := sources.AdjustedMeta(nil); got["adjusted"] != "true" {
+		t.Errorf("AdjustedMeta(nil) = %v", got)
+	}
+}
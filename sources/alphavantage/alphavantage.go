@@ -3,9 +3,11 @@ package alphavantage
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"io"
 	"net/http"
+	"net/url"
 	"time"
 
 	internalhttp "github.com/julianshen/gonp-datareader/internal/http"
@@ -19,12 +21,21 @@ type AlphaVantageReader struct {
 	client  *internalhttp.RetryableClient
 	apiKey  string
 	baseURL string // For testing with mock servers
+
+	// searchURL overrides the endpoint of SearchSymbols; see SetSearchURL
+	searchURL string
+
+	// interval is the bar interval; see SetInterval
+	interval sources.Interval
+
+	// adjustPrices selects the adjusted functions; see SetAdjustPrices
+	adjustPrices bool
 }
 
 // NewAlphaVantageReader creates a new Alpha Vantage data reader.
 // An API key is required to use the Alpha Vantage API.
 func NewAlphaVantageReader(opts *internalhttp.ClientOptions, apiKey string) *AlphaVantageReader {
-	return NewAlphaVantageReaderWithBaseURL(opts, apiKey, "https://www.alphavantage.co/query?function=TIME_SERIES_DAILY&symbol=%s&apikey=%s&outputsize=full")
+	return NewAlphaVantageReaderWithBaseURL(opts, apiKey, defaultURL)
 }
 
 // NewAlphaVantageReaderWithBaseURL creates a new Alpha Vantage reader with a custom base URL.
@@ -42,44 +53,145 @@ func NewAlphaVantageReaderWithBaseURL(opts *internalhttp.ClientOptions, apiKey,
 	}
 }
 
+// Schema returns the columns of the ParsedData returned by ReadSingle.
+func (a *AlphaVantageReader) Schema() sources.Schema {
+	return sources.NewSchema(a.Source(), a.Name(), dailyRecord{})
+}
+
+// defaultURL is the request URL format of daily bars, with the symbol and
+// API key.
+const defaultURL = "https://www.alphavantage.co/query?function=TIME_SERIES_DAILY&symbol=%s&apikey=%s&outputsize=full"
+
 // BuildURL constructs the Alpha Vantage API URL for fetching daily time series data.
 // The Alpha Vantage API format is:
 // https://www.alphavantage.co/query?function=TIME_SERIES_DAILY&symbol={symbol}&apikey={apikey}&outputsize=full
+//
+// Deprecated: BuildURL ignores the interval and price adjustment of a
+// reader; use (*AlphaVantageReader).BuildURL.
 func BuildURL(symbol, apiKey string) string {
-	return fmt.Sprintf(
-		"https://www.alphavantage.co/query?function=TIME_SERIES_DAILY&symbol=%s&apikey=%s&outputsize=full",
-		symbol,
-		apiKey,
-	)
+	sources.ReportDeprecation(sources.Deprecation{
+		API:         "alphavantage.BuildURL",
+		Replacement: "(*alphavantage.AlphaVantageReader).BuildURL",
+		Since:       "v1.1.0",
+	})
+	return NewAlphaVantageReader(nil, apiKey).BuildURL(symbol, apiKey)
+}
+
+// BuildURL constructs the request URL of symbol, for the reader's base URL,
+// interval (see SetInterval) and price adjustment (see SetAdjustPrices).
+func (a *AlphaVantageReader) BuildURL(symbol, apiKey string) string {
+	return a.withInterval(fmt.Sprintf(a.baseURL, symbol, apiKey))
+}
+
+// Intervals lists the bar intervals Alpha Vantage serves. Intraday bars
+// cover the last 30 days.
+var Intervals = []sources.Interval{
+	sources.Interval1m, sources.Interval5m, sources.Interval15m, sources.Interval30m,
+	sources.Interval1h, sources.Interval1d, sources.Interval1wk, sources.Interval1mo,
+}
+
+// functions maps the non-intraday intervals to their time series function.
+var functions = map[sources.Interval]string{
+	sources.Interval1d:  "TIME_SERIES_DAILY",
+	sources.Interval1wk: "TIME_SERIES_WEEKLY",
+	sources.Interval1mo: "TIME_SERIES_MONTHLY",
+}
+
+// intradayIntervals maps the intraday intervals to the interval parameter
+// of TIME_SERIES_INTRADAY.
+var intradayIntervals = map[sources.Interval]string{
+	sources.Interval1m:  "1min",
+	sources.Interval5m:  "5min",
+	sources.Interval15m: "15min",
+	sources.Interval30m: "30min",
+	sources.Interval1h:  "60min",
+}
+
+// SetInterval sets the bar interval of ReadSingle and Read; empty means
+// daily. Intervals not in Intervals return an error matching
+// sources.ErrUnsupportedInterval.
+func (a *AlphaVantageReader) SetInterval(interval sources.Interval) error {
+	if err := sources.CheckInterval(interval, Intervals); err != nil {
+		return err
+	}
+	a.interval = interval
+	return nil
+}
+
+// SetAdjustPrices selects split- and dividend-adjusted prices. Daily,
+// weekly and monthly bars are then read from TIME_SERIES_DAILY_ADJUSTED,
+// TIME_SERIES_WEEKLY_ADJUSTED and TIME_SERIES_MONTHLY_ADJUSTED, whose Adj
+// Close is used to scale Open, High and Low and replace Close; intraday
+// bars are requested with adjusted=true. Otherwise intraday bars are
+// requested with adjusted=false. TIME_SERIES_DAILY_ADJUSTED is a premium
+// endpoint. Meta["adjusted"] is set to "true".
+func (a *AlphaVantageReader) SetAdjustPrices(enabled bool) {
+	a.adjustPrices = enabled
+}
+
+// withInterval sets the function (and, for intraday bars, the interval
+// and adjusted) parameter of a request URL to the reader's interval and
+// price adjustment.
+func (a *AlphaVantageReader) withInterval(urlStr string) string {
+	if a.interval.OrDefault() == sources.Interval1d && !a.adjustPrices {
+		return urlStr
+	}
+	u, err := url.Parse(urlStr)
+	if err != nil {
+		return urlStr
+	}
+	q := u.Query()
+	if interval, ok := intradayIntervals[a.interval]; ok {
+		q.Set("function", "TIME_SERIES_INTRADAY")
+		q.Set("interval", interval)
+		q.Set("adjusted", fmt.Sprint(a.adjustPrices))
+	} else {
+		function := functions[a.interval.OrDefault()]
+		if a.adjustPrices {
+			function += "_ADJUSTED"
+		}
+		q.Set("function", function)
+		if a.interval.OrDefault() != sources.Interval1d {
+			q.Del("outputsize")
+		}
+	}
+	u.RawQuery = q.Encode()
+	return u.String()
 }
 
 // ReadSingle fetches data for a single stock symbol.
 func (a *AlphaVantageReader) ReadSingle(ctx context.Context, symbol string, start, end time.Time) (interface{}, error) {
+	return a.Finish(ctx, symbol, start, end, a.readSingle)
+}
+
+// readSingle implements ReadSingle before Finish is applied.
+func (a *AlphaVantageReader) readSingle(ctx context.Context, symbol string, start, end time.Time) (interface{}, error) {
+	// Normalize user input; the original is kept in Meta["symbol_input"]
+	input := symbol
+	symbol = a.NormalizeSymbol(symbol)
+
 	// Validate symbol
 	if err := a.ValidateSymbol(symbol); err != nil {
 		return nil, err
 	}
 
 	// Check API key
-	if a.apiKey == "" {
+	apiKey := internalhttp.APIKey(ctx, a.apiKey)
+	if apiKey == "" {
 		return nil, fmt.Errorf("API key is required for Alpha Vantage")
 	}
 
-	// Build URL - use custom baseURL if set (for testing), otherwise use standard format
-	var url string
-	if a.baseURL != "" {
-		url = fmt.Sprintf(a.baseURL, symbol, a.apiKey)
-	} else {
-		url = BuildURL(symbol, a.apiKey)
-	}
+	// Build URL
+	urlStr := a.BuildURL(symbol, apiKey)
 
 	// Create HTTP request
-	req, err := http.NewRequestWithContext(ctx, "GET", url, nil)
+	req, err := http.NewRequestWithContext(internalhttp.WithContentType(ctx, "application/json"), "GET", urlStr, nil)
 	if err != nil {
 		return nil, fmt.Errorf("create request: %w", err)
 	}
 
 	// Execute request
+	sent := time.Now()
 	resp, err := a.client.Do(req)
 	if err != nil {
 		return nil, fmt.Errorf("fetch data: %w", err)
@@ -88,7 +200,7 @@ func (a *AlphaVantageReader) ReadSingle(ctx context.Context, symbol string, star
 
 	// Check status code
 	if resp.StatusCode != 200 {
-		return nil, fmt.Errorf("HTTP %d: %s", resp.StatusCode, resp.Status)
+		return nil, internalhttp.StatusError(resp, fmt.Errorf("HTTP %d: %s", resp.StatusCode, resp.Status))
 	}
 
 	// Read response body
@@ -98,19 +210,35 @@ func (a *AlphaVantageReader) ReadSingle(ctx context.Context, symbol string, star
 	}
 
 	// Parse response
-	data, err := ParseResponse(body)
+	decoded, err := a.client.Decode("alphavantage", body, func(b []byte) (interface{}, error) {
+		return ParseResponse(b)
+	})
 	if err != nil {
+		// Rate limit notes come with a 200 status
+		if errors.Is(err, sources.ErrRateLimited) {
+			a.client.Throttled(sent)
+		}
 		return nil, fmt.Errorf("parse response: %w", err)
 	}
 
-	return data, nil
+	// Copy the decoded value, which may be shared through the decoded cache,
+	// and flag data served from an expired cache entry
+	data := *decoded.(*ParsedData)
+	data.Meta = sources.InputMeta(internalhttp.StaleMeta(resp), input, symbol)
+	if a.adjustPrices {
+		data.Rows = sources.AdjustedRows(data.Rows)
+		data.cache = sources.NewColumnCache()
+		data.Meta = sources.AdjustedMeta(data.Meta)
+	}
+
+	return &data, nil
 }
 
 // Read fetches data for multiple stock symbols from Alpha Vantage.
 // Symbols are fetched in parallel for better performance.
 func (a *AlphaVantageReader) Read(ctx context.Context, symbols []string, start, end time.Time) (interface{}, error) {
 	// Validate inputs
-	if err := utils.ValidateSymbols(symbols); err != nil {
+	if err := utils.ValidateSymbols(a.NormalizeSymbols(symbols)); err != nil {
 		return nil, fmt.Errorf("invalid symbols: %w", err)
 	}
 
@@ -183,3 +311,14 @@ func (a *AlphaVantageReader) readParallel(ctx context.Context, symbols []string,
 func (a *AlphaVantageReader) ValidateSymbol(symbol string) error {
 	return a.BaseSource.ValidateSymbol(symbol)
 }
+
+// Shutdown stops accepting requests and waits for in-flight requests to
+// finish, cancelling them if ctx expires first.
+func (a *AlphaVantageReader) Shutdown(ctx context.Context) error {
+	return a.client.Shutdown(ctx)
+}
+
+// Close cancels in-flight requests and releases the reader's connections.
+func (a *AlphaVantageReader) Close() error {
+	return a.client.Close()
+}
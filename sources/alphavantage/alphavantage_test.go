@@ -9,6 +9,7 @@ import (
 	"time"
 
 	internalhttp "github.com/julianshen/gonp-datareader/internal/http"
+	"github.com/julianshen/gonp-datareader/sources"
 	"github.com/julianshen/gonp-datareader/sources/alphavantage"
 )
 
@@ -89,6 +90,38 @@ func TestBuildURL(t *testing.T) {
 	}
 }
 
+func TestBuildURL_Deprecated(t *testing.T) {
+	var reported []sources.Deprecation
+	sources.SetDeprecationHandler(func(d sources.Deprecation) {
+		reported = append(reported, d)
+	})
+	t.Cleanup(func() { sources.SetDeprecationHandler(nil) })
+
+	got := alphavantage.BuildURL("AAPL", "demo")
+	want := alphavantage.NewAlphaVantageReader(nil, "demo").BuildURL("AAPL", "demo")
+	if got != want {
+		t.Errorf("BuildURL() = %q, want %q", got, want)
+	}
+	if len(reported) != 1 || reported[0].API != "alphavantage.BuildURL" {
+		t.Errorf("reported %v, want one alphavantage.BuildURL deprecation", reported)
+	}
+}
+
+func TestAlphaVantageReader_BuildURL(t *testing.T) {
+	reader := alphavantage.NewAlphaVantageReader(nil, "demo")
+	if err := reader.SetInterval(sources.Interval1wk); err != nil {
+		t.Fatal(err)
+	}
+	reader.SetAdjustPrices(true)
+
+	url := reader.BuildURL("IBM", "demo")
+	for _, part := range []string{"function=TIME_SERIES_WEEKLY_ADJUSTED", "symbol=IBM", "apikey=demo"} {
+		if !strings.Contains(url, part) {
+			t.Errorf("BuildURL() missing part %q, got %q", part, url)
+		}
+	}
+}
+
 // TestAlphaVantageReader_ValidateSymbol tests symbol validation
 func TestAlphaVantageReader_ValidateSymbol(t *testing.T) {
 	reader := alphavantage.NewAlphaVantageReader(nil, "test_key")
@@ -193,6 +226,109 @@ func TestAlphaVantageReader_ReadSingle_WithMockServer(t *testing.T) {
 }
 
 // TestAlphaVantageReader_ReadSingle_InvalidSymbol tests error handling for invalid symbols
+func TestAlphaVantageReader_SetInterval(t *testing.T) {
+	var gotQuery string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		gotQuery = r.URL.RawQuery
+		w.Header().Set("Content-Type", "application/json")
+		switch r.URL.Query().Get("function") {
+		case "TIME_SERIES_WEEKLY":
+			w.Write([]byte(`{"Meta Data":{},"Weekly Time Series":{"2024-01-05":{"1. open":"187.15","2. high":"188.44","3. low":"180.17","4. close":"181.18","5. volume":"300000000"}}}`))
+		case "TIME_SERIES_INTRADAY":
+			w.Write([]byte(`{"Meta Data":{},"Time Series (60min)":{"2024-01-05 15:00:00":{"1. open":"181.00","2. high":"181.50","3. low":"180.90","4. close":"181.18","5. volume":"5000000"}}}`))
+		default:
+			t.Errorf("unexpected query %s", r.URL.RawQuery)
+		}
+	}))
+	defer server.Close()
+
+	tests := []struct {
+		interval sources.Interval
+		want     []string
+		date     string
+	}{
+		{sources.Interval1wk, []string{"function=TIME_SERIES_WEEKLY"}, "2024-01-05"},
+		{sources.Interval1h, []string{"function=TIME_SERIES_INTRADAY", "interval=60min"}, "2024-01-05 15:00:00"},
+	}
+
+	for _, tt := range tests {
+		t.Run(string(tt.interval), func(t *testing.T) {
+			reader := alphavantage.NewAlphaVantageReaderWithBaseURL(nil, "key", server.URL+"?function=TIME_SERIES_DAILY&symbol=%s&apikey=%s&outputsize=full")
+			if err := reader.SetInterval(tt.interval); err != nil {
+				t.Fatalf("SetInterval(%q) error = %v", tt.interval, err)
+			}
+
+			result, err := reader.ReadSingle(context.Background(), "IBM", time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC), time.Date(2024, 1, 31, 0, 0, 0, 0, time.UTC))
+			if err != nil {
+				t.Fatalf("ReadSingle() error = %v", err)
+			}
+			for _, want := range tt.want {
+				if !strings.Contains(gotQuery, want) {
+					t.Errorf("query = %s, want %s", gotQuery, want)
+				}
+			}
+			data := result.(*alphavantage.ParsedData)
+			if len(data.Rows) != 1 || data.Rows[0]["Date"] != tt.date || data.Rows[0]["Close"] != "181.18" {
+				t.Errorf("Rows = %v", data.Rows)
+			}
+		})
+	}
+}
+
+func TestAlphaVantageReader_SetAdjustPrices(t *testing.T) {
+	var gotQuery string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		gotQuery = r.URL.RawQuery
+		w.Header().Set("Content-Type", "application/json")
+		switch r.URL.Query().Get("function") {
+		case "TIME_SERIES_DAILY_ADJUSTED", "TIME_SERIES_WEEKLY_ADJUSTED":
+			w.Write([]byte(`{"Meta Data":{},"Time Series (Daily)":{"2024-01-05":{"1. open":"200","2. high":"210","3. low":"190","4. close":"200","5. adjusted close":"100","6. volume":"5000","7. dividend amount":"0.0000","8. split coefficient":"1.0"}}}`))
+		case "TIME_SERIES_INTRADAY":
+			w.Write([]byte(`{"Meta Data":{},"Time Series (5min)":{"2024-01-05 15:00:00":{"1. open":"181.00","2. high":"181.50","3. low":"180.90","4. close":"181.18","5. volume":"5000000"}}}`))
+		default:
+			t.Errorf("unexpected query %s", r.URL.RawQuery)
+		}
+	}))
+	defer server.Close()
+
+	tests := []struct {
+		interval sources.Interval
+		want     []string
+		close    string
+	}{
+		{"", []string{"function=TIME_SERIES_DAILY_ADJUSTED", "outputsize=full"}, "100"},
+		{sources.Interval1wk, []string{"function=TIME_SERIES_WEEKLY_ADJUSTED"}, "100"},
+		{sources.Interval5m, []string{"function=TIME_SERIES_INTRADAY", "adjusted=true"}, "181.18"},
+	}
+
+	for _, tt := range tests {
+		t.Run(string(tt.interval.OrDefault()), func(t *testing.T) {
+			reader := alphavantage.NewAlphaVantageReaderWithBaseURL(nil, "key", server.URL+"?function=TIME_SERIES_DAILY&symbol=%s&apikey=%s&outputsize=full")
+			if err := reader.SetInterval(tt.interval); err != nil {
+				t.Fatalf("SetInterval(%q) error = %v", tt.interval, err)
+			}
+			reader.SetAdjustPrices(true)
+
+			result, err := reader.ReadSingle(context.Background(), "IBM", time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC), time.Date(2024, 1, 31, 0, 0, 0, 0, time.UTC))
+			if err != nil {
+				t.Fatalf("ReadSingle() error = %v", err)
+			}
+			for _, want := range tt.want {
+				if !strings.Contains(gotQuery, want) {
+					t.Errorf("query = %s, want %s", gotQuery, want)
+				}
+			}
+			data := result.(*alphavantage.ParsedData)
+			if len(data.Rows) != 1 || data.Rows[0]["Close"] != tt.close {
+				t.Errorf("Rows = %v, want Close %s", data.Rows, tt.close)
+			}
+			if data.Meta["adjusted"] != "true" {
+				t.Errorf("Meta = %v, want adjusted", data.Meta)
+			}
+		})
+	}
+}
+
 func TestAlphaVantageReader_ReadSingle_InvalidSymbol(t *testing.T) {
 	reader := alphavantage.NewAlphaVantageReader(nil, "test_key")
 
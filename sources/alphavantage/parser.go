@@ -5,37 +5,170 @@ import (
 	"errors"
 	"fmt"
 	"sort"
+	"strings"
+	"time"
+
+	"github.com/julianshen/gonp-datareader/ohlcv"
+	"github.com/julianshen/gonp-datareader/sources"
 )
 
 // ParsedData represents parsed Alpha Vantage time series data.
 type ParsedData struct {
 	Columns []string
 	Rows    []map[string]string
+	// Meta holds response metadata such as "stale" when the data was
+	// served from an expired cache entry; nil when there is none.
+	Meta map[string]string
+
+	// cache holds the columns parsed by GetFloatColumn and GetTimeColumn
+	cache *sources.ColumnCache
+}
+
+// DateIndex returns the Date column parsed as dates.
+func (p *ParsedData) DateIndex() ([]time.Time, error) {
+	return p.GetTimeColumn("Date")
+}
+
+// SelectRows keeps the rows at indices, in that order (see
+// sources.RowSelector).
+func (p *ParsedData) SelectRows(indices []int) {
+	if p == nil {
+		return
+	}
+	p.Rows = sources.SelectRows(p.Rows, indices)
+	p.cache = sources.NewColumnCache()
+}
+
+// ColumnNames returns the value columns, excluding Date.
+func (p *ParsedData) ColumnNames() []string {
+	if p == nil {
+		return nil
+	}
+	return sources.ValueColumns(p.Columns, "Date")
+}
+
+// Float64Column returns the named value column as float64 values, with
+// missing values as NaN.
+func (p *ParsedData) Float64Column(name string) ([]float64, error) {
+	if name == "Date" {
+		return nil, sources.NoColumn(name)
+	}
+	return p.GetFloatColumn(name)
+}
+
+// Bars returns the price columns as ohlcv.Bars (see ohlcv.Provider).
+func (p *ParsedData) Bars() (*ohlcv.Bars, error) {
+	return ohlcv.FromTimeSeries(p)
+}
+
+// GetColumn returns all values for a given column name.
+func (p *ParsedData) GetColumn(name string) []string {
+	if p == nil || len(p.Rows) == 0 {
+		return nil
+	}
+
+	values := make([]string, 0, len(p.Rows))
+	for _, row := range p.Rows {
+		if val, ok := row[name]; ok {
+			values = append(values, val)
+		}
+	}
+
+	if len(values) == 0 {
+		return nil
+	}
+
+	return values
+}
+
+// GetFloatColumn returns the named column parsed as float64 values, with
+// missing values as NaN. Parsed columns are cached, so the returned slice
+// must not be modified.
+func (p *ParsedData) GetFloatColumn(name string) ([]float64, error) {
+	if p == nil {
+		return nil, fmt.Errorf("%w: %q", sources.ErrNoColumn, name)
+	}
+	return sources.FloatColumn(p.cache, p.Columns, p.Rows, name)
+}
+
+// GetTimeColumn returns the named column parsed as dates. Parsed columns
+// are cached, so the returned slice must not be modified.
+func (p *ParsedData) GetTimeColumn(name string) ([]time.Time, error) {
+	if p == nil {
+		return nil, fmt.Errorf("%w: %q", sources.ErrNoColumn, name)
+	}
+	return sources.TimeColumn(p.cache, p.Columns, p.Rows, name)
+}
+
+// ColumnInfo describes the columns of the data in order, with the value
+// type each string represents.
+func (p *ParsedData) ColumnInfo() []sources.Column {
+	if p == nil {
+		return nil
+	}
+	return sources.DescribeColumns(p.Columns, sources.NewSchema("", "", dailyRecord{}))
+}
+
+// dailyRecord documents the columns of an Alpha Vantage daily time series record.
+// Values are stored as strings in ParsedData.Rows; the field types describe
+// what each string represents.
+type dailyRecord struct {
+	Date   time.Time
+	Open   float64
+	High   float64
+	Low    float64
+	Close  float64
+	Volume int64
+	// AdjClose is returned only by the adjusted functions; see
+	// AlphaVantageReader.SetAdjustPrices
+	AdjClose float64 `schema:"Adj Close"`
 }
 
 // alphaVantageResponse represents the Alpha Vantage API response structure.
 type alphaVantageResponse struct {
-	MetaData   map[string]string            `json:"Meta Data"`
-	TimeSeries map[string]map[string]string `json:"Time Series (Daily)"`
-	Note       string                       `json:"Note"`
-	ErrorMsg   string                       `json:"Error Message"`
+	MetaData map[string]string `json:"Meta Data"`
+	Note     string            `json:"Note"`
+	Info     string            `json:"Information"`
+	ErrorMsg string            `json:"Error Message"`
+
+	// TimeSeries holds the bars, keyed by date, under a key naming the
+	// interval: "Time Series (Daily)", "Weekly Time Series", "Time Series
+	// (5min)" and so on
+	TimeSeries map[string]map[string]string `json:"-"`
 }
 
-// ParseResponse parses the Alpha Vantage API JSON response.
+// ParseResponse parses the Alpha Vantage API JSON response of any time
+// series function.
 func ParseResponse(data []byte) (*ParsedData, error) {
 	var response alphaVantageResponse
 	if err := json.Unmarshal(data, &response); err != nil {
 		return nil, fmt.Errorf("parse JSON: %w", err)
 	}
+	var fields map[string]json.RawMessage
+	if err := json.Unmarshal(data, &fields); err != nil {
+		return nil, fmt.Errorf("parse JSON: %w", err)
+	}
+	for key, raw := range fields {
+		if strings.Contains(key, "Time Series") {
+			if err := json.Unmarshal(raw, &response.TimeSeries); err != nil {
+				return nil, fmt.Errorf("parse %s: %w", key, err)
+			}
+			break
+		}
+	}
 
-	// Check for rate limit
-	if response.Note != "" {
-		return nil, errors.New("rate limit exceeded")
+	// Check for rate limit; newer responses report it in "Information"
+	if response.Note != "" || strings.Contains(strings.ToLower(response.Info), "rate limit") {
+		return nil, &sources.RateLimitError{Err: errors.New("rate limit exceeded")}
 	}
 
-	// Check for error message
+	// Check for error message: a bad key names the apikey parameter,
+	// anything else is an unknown symbol ("Invalid API call")
 	if response.ErrorMsg != "" {
-		return nil, fmt.Errorf("API error: %s", response.ErrorMsg)
+		if strings.Contains(strings.ToLower(response.ErrorMsg), "apikey") {
+			return nil, fmt.Errorf("%w: API error: %s", sources.ErrAuthRequired, response.ErrorMsg)
+		}
+		return nil, fmt.Errorf("%w: API error: %s", sources.ErrSymbolNotFound, response.ErrorMsg)
 	}
 
 	// Check if time series exists
@@ -53,6 +186,14 @@ func ParseResponse(data []byte) (*ParsedData, error) {
 	}
 	sort.Strings(dates)
 
+	// The adjusted functions insert "5. adjusted close" before the volume,
+	// which moves to "6. volume"
+	columns := []string{"Date", "Open", "High", "Low", "Close", "Volume"}
+	_, adjusted := response.TimeSeries[dates[0]]["5. adjusted close"]
+	if adjusted {
+		columns = append(columns, "Adj Close")
+	}
+
 	// Build rows
 	rows := make([]map[string]string, 0, len(dates))
 	for _, date := range dates {
@@ -65,11 +206,16 @@ func ParseResponse(data []byte) (*ParsedData, error) {
 			"Close":  values["4. close"],
 			"Volume": values["5. volume"],
 		}
+		if adjusted {
+			row["Volume"] = values["6. volume"]
+			row["Adj Close"] = values["5. adjusted close"]
+		}
 		rows = append(rows, row)
 	}
 
 	return &ParsedData{
-		Columns: []string{"Date", "Open", "High", "Low", "Close", "Volume"},
+		Columns: columns,
 		Rows:    rows,
+		cache:   sources.NewColumnCache(),
 	}, nil
 }
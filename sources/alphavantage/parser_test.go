@@ -1,8 +1,11 @@
 package alphavantage_test
 
 import (
+	"errors"
+	"reflect"
 	"testing"
 
+	"github.com/julianshen/gonp-datareader/sources"
 	"github.com/julianshen/gonp-datareader/sources/alphavantage"
 )
 
@@ -81,6 +84,21 @@ func TestParseResponse(t *testing.T) {
 	}
 }
 
+func TestParseResponse_Adjusted(t *testing.T) {
+	data, err := alphavantage.ParseResponse([]byte(`{"Meta Data":{},"Time Series (Daily)":{"2024-01-05":{"1. open":"200","2. high":"210","3. low":"190","4. close":"200","5. adjusted close":"100","6. volume":"5000","7. dividend amount":"0.0000","8. split coefficient":"1.0"}}}`))
+	if err != nil {
+		t.Fatalf("ParseResponse() error = %v", err)
+	}
+
+	wantColumns := []string{"Date", "Open", "High", "Low", "Close", "Volume", "Adj Close"}
+	if !reflect.DeepEqual(data.Columns, wantColumns) {
+		t.Errorf("Columns = %v, want %v", data.Columns, wantColumns)
+	}
+	if row := data.Rows[0]; row["Close"] != "200" || row["Adj Close"] != "100" || row["Volume"] != "5000" {
+		t.Errorf("Rows[0] = %v", row)
+	}
+}
+
 func TestParseResponse_EmptyTimeSeries(t *testing.T) {
 	jsonData := `{
 		"Meta Data": {
@@ -124,3 +142,41 @@ func TestParseResponse_RateLimitError(t *testing.T) {
 		t.Errorf("Expected 'rate limit exceeded' error, got %q", err.Error())
 	}
 }
+
+func TestParseResponse_ErrorKinds(t *testing.T) {
+	tests := []struct {
+		name string
+		json string
+		want error
+	}{
+		{
+			name: "rate limit note",
+			json: `{"Note": "Our standard API call frequency is 5 calls per minute."}`,
+			want: sources.ErrRateLimited,
+		},
+		{
+			name: "rate limit information",
+			json: `{"Information": "Our standard API rate limit is 25 requests per day."}`,
+			want: sources.ErrRateLimited,
+		},
+		{
+			name: "invalid API key",
+			json: `{"Error Message": "the parameter apikey is invalid or missing."}`,
+			want: sources.ErrAuthRequired,
+		},
+		{
+			name: "unknown symbol",
+			json: `{"Error Message": "Invalid API call. Please retry or visit the documentation for TIME_SERIES_DAILY."}`,
+			want: sources.ErrSymbolNotFound,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := alphavantage.ParseResponse([]byte(tt.json))
+			if !errors.Is(err, tt.want) {
+				t.Errorf("ParseResponse() error = %v, want %v", err, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,113 @@
+package alphavantage
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+
+	internalhttp "github.com/julianshen/gonp-datareader/internal/http"
+	"github.com/julianshen/gonp-datareader/sources"
+)
+
+// searchQuery is the query string of the SYMBOL_SEARCH endpoint; the
+// verbs take the escaped keywords and API key
+const searchQuery = "?function=SYMBOL_SEARCH&keywords=%s&apikey=%s"
+
+// SetSearchURL overrides the search endpoint, a format string taking the
+// escaped keywords and API key. By default it is derived from the base
+// URL. This is primarily used for testing with mock servers.
+func (a *AlphaVantageReader) SetSearchURL(searchURL string) {
+	a.searchURL = searchURL
+}
+
+// searchEndpoint returns the search endpoint format string.
+func (a *AlphaVantageReader) searchEndpoint() string {
+	if a.searchURL != "" {
+		return a.searchURL
+	}
+	endpoint, _, _ := strings.Cut(a.baseURL, "?")
+	return endpoint + searchQuery
+}
+
+// SearchSymbols looks up tickers by company name or keyword with the
+// SYMBOL_SEARCH endpoint, returning up to 10 matches across global
+// exchanges, best match first. Each search counts against the daily
+// request quota.
+func (a *AlphaVantageReader) SearchSymbols(ctx context.Context, query string) ([]sources.SymbolInfo, error) {
+	query, err := sources.CheckQuery(query)
+	if err != nil {
+		return nil, err
+	}
+
+	apiKey := internalhttp.APIKey(ctx, a.apiKey)
+	if apiKey == "" {
+		return nil, fmt.Errorf("API key is required for Alpha Vantage")
+	}
+
+	searchURL := fmt.Sprintf(a.searchEndpoint(), url.QueryEscape(query), url.QueryEscape(apiKey))
+	req, err := http.NewRequestWithContext(internalhttp.WithContentType(ctx, "application/json"), "GET", searchURL, nil)
+	if err != nil {
+		return nil, fmt.Errorf("create request: %w", err)
+	}
+
+	resp, err := a.client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("fetch search results: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != 200 {
+		return nil, internalhttp.StatusError(resp, fmt.Errorf("HTTP %d: %s", resp.StatusCode, resp.Status))
+	}
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("read response: %w", err)
+	}
+
+	return ParseSearch(body)
+}
+
+// ParseSearch parses a SYMBOL_SEARCH response.
+func ParseSearch(data []byte) ([]sources.SymbolInfo, error) {
+	var response struct {
+		BestMatches []map[string]string `json:"bestMatches"`
+		Note        string              `json:"Note"`
+		Info        string              `json:"Information"`
+		ErrorMsg    string              `json:"Error Message"`
+	}
+	if err := json.Unmarshal(data, &response); err != nil {
+		return nil, fmt.Errorf("parse JSON: %w", err)
+	}
+
+	if response.Note != "" || strings.Contains(strings.ToLower(response.Info), "rate limit") {
+		return nil, &sources.RateLimitError{Err: errors.New("rate limit exceeded")}
+	}
+	if response.ErrorMsg != "" {
+		if strings.Contains(strings.ToLower(response.ErrorMsg), "apikey") {
+			return nil, fmt.Errorf("%w: API error: %s", sources.ErrAuthRequired, response.ErrorMsg)
+		}
+		return nil, fmt.Errorf("API error: %s", response.ErrorMsg)
+	}
+
+	// Fields are numbered, e.g. "1. symbol", "2. name"
+	matches := make([]sources.SymbolInfo, 0, len(response.BestMatches))
+	for _, m := range response.BestMatches {
+		info := sources.SymbolInfo{
+			Symbol: m["1. symbol"],
+			Name:   m["2. name"],
+			Type:   m["3. type"],
+			Market: m["4. region"],
+		}
+		if currency := m["8. currency"]; currency != "" {
+			info.Description = "Currency: " + currency
+		}
+		matches = append(matches, info)
+	}
+	return matches, nil
+}
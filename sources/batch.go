@@ -0,0 +1,52 @@
+package sources
+
+import (
+	"context"
+	"fmt"
+)
+
+// Chunk splits symbols into consecutive chunks of at most size symbols,
+// dropping duplicates. A size of zero or less returns a single chunk.
+func Chunk(symbols []string, size int) [][]string {
+	seen := make(map[string]bool, len(symbols))
+	unique := make([]string, 0, len(symbols))
+	for _, s := range symbols {
+		if !seen[s] {
+			seen[s] = true
+			unique = append(unique, s)
+		}
+	}
+
+	if size <= 0 || len(unique) <= size {
+		return [][]string{unique}
+	}
+
+	chunks := make([][]string, 0, (len(unique)+size-1)/size)
+	for len(unique) > size {
+		chunks = append(chunks, unique[:size:size])
+		unique = unique[size:]
+	}
+	return append(chunks, unique)
+}
+
+// ReadChunked calls read for each chunk of at most size symbols, in order,
+// and merges the per-chunk results keyed by symbol. It lets batch
+// endpoints accept symbol lists larger than the provider's cap. The first
+// failing chunk aborts the read; its error names the chunk's symbols.
+func ReadChunked[T any](ctx context.Context, symbols []string, size int, read func(ctx context.Context, chunk []string) (map[string]T, error)) (map[string]T, error) {
+	merged := make(map[string]T, len(symbols))
+	for _, chunk := range Chunk(symbols, size) {
+		if err := ctx.Err(); err != nil {
+			return nil, err
+		}
+
+		results, err := read(ctx, chunk)
+		if err != nil {
+			return nil, fmt.Errorf("batch %s..%s: %w", chunk[0], chunk[len(chunk)-1], err)
+		}
+		for symbol, v := range results {
+			merged[symbol] = v
+		}
+	}
+	return merged, nil
+}
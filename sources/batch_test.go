@@ -0,0 +1,74 @@
+package sources_test
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"testing"
+
+	"github.com/julianshen/gonp-datareader/sources"
+)
+
+func TestChunk(t *testing.T) {
+	tests := []struct {
+		name    string
+		symbols []string
+		size    int
+		want    [][]string
+	}{
+		{"under cap", []string{"A", "B"}, 3, [][]string{{"A", "B"}}},
+		{"exact multiple", []string{"A", "B", "C", "D"}, 2, [][]string{{"A", "B"}, {"C", "D"}}},
+		{"remainder", []string{"A", "B", "C"}, 2, [][]string{{"A", "B"}, {"C"}}},
+		{"duplicates", []string{"A", "B", "A", "C"}, 2, [][]string{{"A", "B"}, {"C"}}},
+		{"no cap", []string{"A", "B", "C"}, 0, [][]string{{"A", "B", "C"}}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := sources.Chunk(tt.symbols, tt.size); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Chunk() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestReadChunked(t *testing.T) {
+	symbols := make([]string, 2000)
+	for i := range symbols {
+		symbols[i] = string(rune('A'+i%26)) + string(rune('0'+i/26%10)) + string(rune('a'+i/260))
+	}
+
+	var calls int
+	got, err := sources.ReadChunked(context.Background(), symbols, 300,
+		func(ctx context.Context, chunk []string) (map[string]int, error) {
+			calls++
+			if len(chunk) > 300 {
+				t.Errorf("chunk of %d symbols exceeds cap", len(chunk))
+			}
+			out := make(map[string]int, len(chunk))
+			for _, s := range chunk {
+				out[s] = len(s)
+			}
+			return out, nil
+		})
+	if err != nil {
+		t.Fatalf("ReadChunked() error = %v", err)
+	}
+	if calls != 7 || len(got) != 2000 {
+		t.Errorf("calls = %d, results = %d, want 7, 2000", calls, len(got))
+	}
+}
+
+func TestReadChunked_Error(t *testing.T) {
+	errBoom := errors.New("boom")
+	_, err := sources.ReadChunked(context.Background(), []string{"A", "B", "C"}, 2,
+		func(ctx context.Context, chunk []string) (map[string]int, error) {
+			if chunk[0] == "C" {
+				return nil, errBoom
+			}
+			return map[string]int{}, nil
+		})
+	if !errors.Is(err, errBoom) {
+		t.Errorf("ReadChunked() error = %v, want %v", err, errBoom)
+	}
+}
@@ -0,0 +1,209 @@
+// Package bundle provides offline bundles: a set of series fetched from
+// any sources and packaged into a single self-contained file, plus a
+// reader that serves reads from it without network access.
+//
+// Instructors can build a bundle once with datareader.BuildBundle and
+// distribute the file, so every student reads the same fixed dataset
+// without hitting provider rate limits.
+//
+// # Example Usage
+//
+//	b, err := bundle.Open("course.bundle")
+//	if err != nil {
+//		log.Fatal(err)
+//	}
+//	reader := bundle.NewBundleReader(b)
+//	data, err := reader.ReadSingle(ctx, "AAPL", start, end)
+package bundle
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/julianshen/gonp-datareader/internal/utils"
+	"github.com/julianshen/gonp-datareader/sources"
+)
+
+var (
+	// ErrNotInBundle is returned when a bundle has no series for a symbol.
+	ErrNotInBundle = errors.New("symbol not in bundle")
+	// ErrAmbiguousSymbol is returned when several sources in a bundle have
+	// a symbol; qualify it as "source:symbol".
+	ErrAmbiguousSymbol = errors.New("symbol is in several bundled sources")
+)
+
+// BundleReader serves reads from a Bundle.
+type BundleReader struct {
+	*sources.BaseSource
+	bundle *Bundle
+}
+
+// NewBundleReader creates a reader serving reads from b.
+func NewBundleReader(b *Bundle) *BundleReader {
+	return &BundleReader{
+		BaseSource: sources.NewBaseSource("bundle"),
+		bundle:     b,
+	}
+}
+
+// Name returns the display name of the data source.
+func (r *BundleReader) Name() string {
+	return "Offline Bundle"
+}
+
+// ValidateSymbol checks that symbol is non-empty and has no whitespace.
+// Symbols may be qualified with their source as "source:symbol".
+func (r *BundleReader) ValidateSymbol(symbol string) error {
+	symbol = r.NormalizeSymbol(symbol)
+	if symbol == "" {
+		return utils.ErrEmptySymbol
+	}
+	if strings.ContainsAny(symbol, " \t\n\r") {
+		return utils.ErrInvalidSymbolFormat
+	}
+	return nil
+}
+
+// ReadSingle returns the bundled rows of symbol dated from start through
+// end. Rows outside the range the bundle was built for are not available.
+func (r *BundleReader) ReadSingle(ctx context.Context, symbol string, start, end time.Time) (interface{}, error) {
+	return r.Finish(ctx, symbol, start, end, r.readSingle)
+}
+
+// readSingle implements ReadSingle before Finish is applied.
+func (r *BundleReader) readSingle(ctx context.Context, symbol string, start, end time.Time) (interface{}, error) {
+	input := symbol
+	symbol = r.NormalizeSymbol(symbol)
+
+	if err := r.ValidateSymbol(symbol); err != nil {
+		return nil, fmt.Errorf("invalid symbol: %w", err)
+	}
+	if err := utils.ValidateDateRange(start, end); err != nil {
+		return nil, fmt.Errorf("invalid date range: %w", err)
+	}
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	entry, err := r.bundle.Lookup(symbol)
+	if err != nil {
+		return nil, err
+	}
+
+	data := entry.between(start, end)
+	data.Meta = sources.InputMeta(map[string]string{
+		"bundled_at": r.bundle.Created.UTC().Format(time.RFC3339),
+	}, input, symbol)
+	return data, nil
+}
+
+// Read returns the bundled rows of each symbol as a map from symbol to
+// *ParsedData.
+func (r *BundleReader) Read(ctx context.Context, symbols []string, start, end time.Time) (interface{}, error) {
+	if len(symbols) == 0 {
+		return nil, fmt.Errorf("invalid symbols: %w", utils.ErrEmptySymbolList)
+	}
+
+	out := make(map[string]*ParsedData, len(symbols))
+	for _, symbol := range symbols {
+		data, err := r.ReadSingle(ctx, symbol, start, end)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read %s: %w", symbol, err)
+		}
+		out[symbol] = data.(*ParsedData)
+	}
+	return out, nil
+}
+
+// between returns the rows of e dated from the day of start through the
+// day of end.
+func (e *Entry) between(start, end time.Time) *ParsedData {
+	days := utils.NewDayRange(start, end)
+	var rows []int
+	for i, d := range e.Dates {
+		if days.Contains(d) {
+			rows = append(rows, i)
+		}
+	}
+
+	data := &ParsedData{
+		Source:  e.Source,
+		Symbol:  e.Symbol,
+		Dates:   make([]time.Time, len(rows)),
+		Columns: make([]Column, len(e.Columns)),
+	}
+	for k, i := range rows {
+		data.Dates[k] = e.Dates[i]
+	}
+	for j, c := range e.Columns {
+		values := make([]float64, len(rows))
+		for k, i := range rows {
+			values[k] = c.Values[i]
+		}
+		data.Columns[j] = Column{Name: c.Name, Values: values}
+	}
+	return data
+}
+
+// ParsedData holds the bundled rows of one series.
+type ParsedData struct {
+	// Source is the source the series was originally fetched from
+	Source string
+	// Symbol is the symbol as used by that source
+	Symbol string
+	// Dates is the date index of the rows
+	Dates []time.Time
+	// Columns holds the values of each column, aligned with Dates
+	Columns []Column
+	// Meta holds response metadata: "bundled_at" is when the bundle was
+	// built
+	Meta map[string]string
+}
+
+// DateIndex returns a copy of Dates.
+func (p *ParsedData) DateIndex() ([]time.Time, error) {
+	if p == nil {
+		return nil, nil
+	}
+	return append([]time.Time(nil), p.Dates...), nil
+}
+
+// SelectRows keeps the rows at indices, in that order (see
+// sources.RowSelector).
+func (p *ParsedData) SelectRows(indices []int) {
+	if p == nil {
+		return
+	}
+	p.Dates = sources.SelectRows(p.Dates, indices)
+	for i := range p.Columns {
+		p.Columns[i].Values = sources.SelectRows(p.Columns[i].Values, indices)
+	}
+}
+
+// ColumnNames returns the names of the columns in order.
+func (p *ParsedData) ColumnNames() []string {
+	if p == nil {
+		return nil
+	}
+	names := make([]string, len(p.Columns))
+	for i, c := range p.Columns {
+		names[i] = c.Name
+	}
+	return names
+}
+
+// Float64Column returns a copy of the named column, with missing values as
+// NaN.
+func (p *ParsedData) Float64Column(name string) ([]float64, error) {
+	if p != nil {
+		for _, c := range p.Columns {
+			if c.Name == name {
+				return append([]float64(nil), c.Values...), nil
+			}
+		}
+	}
+	return nil, sources.NoColumn(name)
+}
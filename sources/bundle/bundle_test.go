@@ -0,0 +1,189 @@
+package bundle_test
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"math"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/julianshen/gonp-datareader/dataset"
+	"github.com/julianshen/gonp-datareader/sources/bundle"
+)
+
+func day(d int) time.Time {
+	return time.Date(2024, 1, d, 0, 0, 0, 0, time.UTC)
+}
+
+func sample(t *testing.T) *bundle.Bundle {
+	t.Helper()
+
+	b := bundle.New()
+	for _, s := range []struct{ source, symbol string }{
+		{"yahoo", "AAPL"}, {"stooq", "AAPL.US"}, {"yahoo", "MSFT"}, {"stooq", "MSFT"},
+	} {
+		ds := dataset.New(s.symbol, s.source, []time.Time{day(2), day(3), day(4)})
+		if err := ds.AddColumn("Close", []float64{1, math.NaN(), 3}); err != nil {
+			t.Fatal(err)
+		}
+		b.Add(ds, day(1), day(31))
+	}
+	return b
+}
+
+func TestBundle_WriteRead(t *testing.T) {
+	b := sample(t)
+
+	var buf bytes.Buffer
+	if err := b.Write(&buf); err != nil {
+		t.Fatalf("Write() error = %v", err)
+	}
+
+	got, err := bundle.Read(&buf)
+	if err != nil {
+		t.Fatalf("Read() error = %v", err)
+	}
+	if got.Version != bundle.Version || len(got.Entries) != 4 || !got.Created.Equal(b.Created) {
+		t.Fatalf("Read() = version %d, %d entries, created %v", got.Version, len(got.Entries), got.Created)
+	}
+
+	e := got.Entries[0]
+	if e.Source != "yahoo" || e.Symbol != "AAPL" || !e.Start.Equal(day(1)) || len(e.Dates) != 3 {
+		t.Errorf("Entries[0] = %+v", e)
+	}
+	values := e.Columns[0].Values
+	if values[0] != 1 || !math.IsNaN(values[1]) || values[2] != 3 {
+		t.Errorf("Close = %v, want [1 NaN 3]", values)
+	}
+}
+
+func TestBundle_SaveOpen(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "course.bundle")
+	if err := sample(t).Save(path); err != nil {
+		t.Fatalf("Save() error = %v", err)
+	}
+
+	b, err := bundle.Open(path)
+	if err != nil {
+		t.Fatalf("Open() error = %v", err)
+	}
+	if len(b.Entries) != 4 {
+		t.Errorf("Open() returned %d entries, want 4", len(b.Entries))
+	}
+
+	if _, err := bundle.Open(filepath.Join(t.TempDir(), "missing.bundle")); err == nil {
+		t.Error("Open() of a missing file returned nil error")
+	}
+}
+
+func TestRead_Errors(t *testing.T) {
+	tests := []struct {
+		name    string
+		input   string
+		wantErr error
+	}{
+		{"plain JSON", `{"version": 1, "entries": [{"source": "fred", "symbol": "GDP", "dates": ["2024-01-01T00:00:00Z"], "columns": [{"name": "Value", "values": [null]}]}]}`, nil},
+		{"newer version", `{"version": 2}`, bundle.ErrUnsupportedVersion},
+		{"length mismatch", `{"version": 1, "entries": [{"dates": [], "columns": [{"name": "Value", "values": [1]}]}]}`, dataset.ErrLengthMismatch},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := bundle.Read(strings.NewReader(tt.input))
+			if !errors.Is(err, tt.wantErr) {
+				t.Errorf("Read() error = %v, want %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestBundle_Add_Replaces(t *testing.T) {
+	b := sample(t)
+	ds := dataset.New("AAPL", "yahoo", []time.Time{day(5)})
+	b.Add(ds, day(5), day(5))
+
+	if len(b.Entries) != 4 {
+		t.Fatalf("Add() of an existing series gave %d entries, want 4", len(b.Entries))
+	}
+	if e, _ := b.Lookup("yahoo:AAPL"); len(e.Dates) != 1 {
+		t.Errorf("Add() did not replace the entry: %+v", e)
+	}
+}
+
+func TestBundle_Lookup(t *testing.T) {
+	b := sample(t)
+
+	tests := []struct {
+		symbol     string
+		wantSource string
+		wantErr    error
+	}{
+		{"AAPL", "yahoo", nil},
+		{"aapl.us", "stooq", nil},
+		{"stooq:MSFT", "stooq", nil},
+		{"MSFT", "", bundle.ErrAmbiguousSymbol},
+		{"GOOG", "", bundle.ErrNotInBundle},
+		{"fred:AAPL", "", bundle.ErrNotInBundle},
+	}
+	for _, tt := range tests {
+		t.Run(tt.symbol, func(t *testing.T) {
+			e, err := b.Lookup(tt.symbol)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("Lookup() error = %v, want %v", err, tt.wantErr)
+			}
+			if err == nil && e.Source != tt.wantSource {
+				t.Errorf("Lookup() source = %q, want %q", e.Source, tt.wantSource)
+			}
+		})
+	}
+}
+
+func TestBundleReader_ReadSingle(t *testing.T) {
+	reader := bundle.NewBundleReader(sample(t))
+
+	result, err := reader.ReadSingle(context.Background(), " yahoo:AAPL", day(3), day(31))
+	if err != nil {
+		t.Fatalf("ReadSingle() error = %v", err)
+	}
+
+	data := result.(*bundle.ParsedData)
+	if data.Source != "yahoo" || data.Symbol != "AAPL" {
+		t.Errorf("ReadSingle() = %s:%s, want yahoo:AAPL", data.Source, data.Symbol)
+	}
+	if len(data.Dates) != 2 || !data.Dates[0].Equal(day(3)) {
+		t.Errorf("Dates = %v, want rows from 2024-01-03", data.Dates)
+	}
+	closes, err := data.Float64Column("Close")
+	if err != nil || !math.IsNaN(closes[0]) || closes[1] != 3 {
+		t.Errorf("Float64Column(Close) = %v, %v", closes, err)
+	}
+	if data.Meta["bundled_at"] == "" || data.Meta["symbol_input"] != " yahoo:AAPL" {
+		t.Errorf("Meta = %v", data.Meta)
+	}
+
+	if _, err := reader.ReadSingle(context.Background(), "GOOG", day(1), day(31)); !errors.Is(err, bundle.ErrNotInBundle) {
+		t.Errorf("ReadSingle(GOOG) error = %v, want ErrNotInBundle", err)
+	}
+	if _, err := reader.ReadSingle(context.Background(), "AAPL", day(31), day(1)); err == nil {
+		t.Error("ReadSingle() with inverted range returned nil error")
+	}
+}
+
+func TestBundleReader_Read(t *testing.T) {
+	reader := bundle.NewBundleReader(sample(t))
+
+	result, err := reader.Read(context.Background(), []string{"AAPL", "stooq:MSFT"}, day(1), day(31))
+	if err != nil {
+		t.Fatalf("Read() error = %v", err)
+	}
+	data := result.(map[string]*bundle.ParsedData)
+	if len(data) != 2 || data["stooq:MSFT"].Source != "stooq" {
+		t.Errorf("Read() = %v", data)
+	}
+
+	if _, err := reader.Read(context.Background(), []string{"AAPL", "MSFT"}, day(1), day(31)); !errors.Is(err, bundle.ErrAmbiguousSymbol) {
+		t.Errorf("Read() error = %v, want ErrAmbiguousSymbol", err)
+	}
+}
@@ -0,0 +1,227 @@
+package bundle
+
+import (
+	"bufio"
+	"bytes"
+	"compress/gzip"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"math"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/julianshen/gonp-datareader/dataset"
+)
+
+// Version is the bundle format version written by Write.
+const Version = 1
+
+// ErrUnsupportedVersion is returned when a bundle file was written by a
+// newer format version than this package reads.
+var ErrUnsupportedVersion = errors.New("unsupported bundle version")
+
+// Bundle is a self-contained set of series fetched from any sources. It is
+// safe for concurrent reads once built; Add must not be called
+// concurrently with reads.
+type Bundle struct {
+	// Version is the format version of the bundle file
+	Version int `json:"version"`
+	// Created is when the bundle was built
+	Created time.Time `json:"created"`
+	// Entries holds one series per source and symbol
+	Entries []Entry `json:"entries"`
+}
+
+// Entry is a single series in a bundle.
+type Entry struct {
+	// Source is the source the series was fetched from (e.g., "yahoo")
+	Source string `json:"source"`
+	// Symbol is the symbol as used by the source
+	Symbol string `json:"symbol"`
+	// Start and End are the requested date range
+	Start time.Time `json:"start"`
+	End   time.Time `json:"end"`
+	// Dates is the date index of the series
+	Dates []time.Time `json:"dates"`
+	// Columns holds the values of each column, aligned with Dates
+	Columns []Column `json:"columns"`
+}
+
+// Column is a named column of an Entry. Missing values are NaN.
+type Column struct {
+	Name   string
+	Values []float64
+}
+
+// column is the JSON form of Column, with NaN encoded as null.
+type column struct {
+	Name   string     `json:"name"`
+	Values []*float64 `json:"values"`
+}
+
+// MarshalJSON implements json.Marshaler, encoding NaN values as null.
+func (c Column) MarshalJSON() ([]byte, error) {
+	out := column{Name: c.Name, Values: make([]*float64, len(c.Values))}
+	for i, v := range c.Values {
+		if !math.IsNaN(v) {
+			v := v
+			out.Values[i] = &v
+		}
+	}
+	return json.Marshal(out)
+}
+
+// UnmarshalJSON implements json.Unmarshaler, decoding null values as NaN.
+func (c *Column) UnmarshalJSON(data []byte) error {
+	var in column
+	if err := json.Unmarshal(data, &in); err != nil {
+		return err
+	}
+	c.Name = in.Name
+	c.Values = make([]float64, len(in.Values))
+	for i, v := range in.Values {
+		c.Values[i] = math.NaN()
+		if v != nil {
+			c.Values[i] = *v
+		}
+	}
+	return nil
+}
+
+// New creates an empty bundle.
+func New() *Bundle {
+	return &Bundle{Version: Version, Created: time.Now().UTC()}
+}
+
+// Add records ds, fetched for the range start through end, as the entry
+// of its source and symbol, replacing an earlier entry for the same pair.
+// Exact decimal values and upstream flags are not kept.
+func (b *Bundle) Add(ds *dataset.Dataset, start, end time.Time) {
+	entry := Entry{
+		Source:  ds.Source,
+		Symbol:  ds.Symbol,
+		Start:   start,
+		End:     end,
+		Dates:   append([]time.Time(nil), ds.Dates...),
+		Columns: make([]Column, len(ds.Columns)),
+	}
+	for i, c := range ds.Columns {
+		entry.Columns[i] = Column{Name: c.Name, Values: append([]float64(nil), c.Values...)}
+	}
+
+	for i, e := range b.Entries {
+		if e.Source == entry.Source && e.Symbol == entry.Symbol {
+			b.Entries[i] = entry
+			return
+		}
+	}
+	b.Entries = append(b.Entries, entry)
+}
+
+// Lookup returns the entry for symbol. The symbol may be qualified with
+// its source as "source:symbol" (e.g., "yahoo:AAPL"), which is required
+// when several sources in the bundle have the symbol. Symbols match
+// exactly or, failing that, case-insensitively.
+func (b *Bundle) Lookup(symbol string) (*Entry, error) {
+	source := ""
+	if i := strings.Index(symbol, ":"); i >= 0 {
+		source, symbol = symbol[:i], symbol[i+1:]
+	}
+
+	for _, match := range []func(string, string) bool{
+		func(a, b string) bool { return a == b },
+		strings.EqualFold,
+	} {
+		var found []*Entry
+		for i := range b.Entries {
+			e := &b.Entries[i]
+			if match(e.Symbol, symbol) && (source == "" || e.Source == source) {
+				found = append(found, e)
+			}
+		}
+		switch len(found) {
+		case 0:
+			continue
+		case 1:
+			return found[0], nil
+		default:
+			sources := make([]string, len(found))
+			for i, e := range found {
+				sources[i] = e.Source + ":" + e.Symbol
+			}
+			return nil, fmt.Errorf("%w: %s is in %s", ErrAmbiguousSymbol, symbol, strings.Join(sources, ", "))
+		}
+	}
+
+	if source != "" {
+		symbol = source + ":" + symbol
+	}
+	return nil, fmt.Errorf("%w: %s", ErrNotInBundle, symbol)
+}
+
+// Write writes the bundle to w as gzip-compressed JSON.
+func (b *Bundle) Write(w io.Writer) error {
+	zw := gzip.NewWriter(w)
+	if err := json.NewEncoder(zw).Encode(b); err != nil {
+		zw.Close()
+		return fmt.Errorf("encode bundle: %w", err)
+	}
+	return zw.Close()
+}
+
+// Save writes the bundle to the file at path.
+func (b *Bundle) Save(path string) error {
+	f, err := os.Create(path)
+	if err != nil {
+		return fmt.Errorf("create bundle file: %w", err)
+	}
+	if err := b.Write(f); err != nil {
+		f.Close()
+		return err
+	}
+	return f.Close()
+}
+
+// Read reads a bundle written by Write. Plain (uncompressed) JSON is
+// accepted as well, so bundles can be inspected and edited by hand.
+func Read(r io.Reader) (*Bundle, error) {
+	br := bufio.NewReader(r)
+	var src io.Reader = br
+	if magic, _ := br.Peek(2); bytes.Equal(magic, []byte{0x1f, 0x8b}) {
+		zr, err := gzip.NewReader(br)
+		if err != nil {
+			return nil, fmt.Errorf("open bundle: %w", err)
+		}
+		defer zr.Close()
+		src = zr
+	}
+
+	var b Bundle
+	if err := json.NewDecoder(src).Decode(&b); err != nil {
+		return nil, fmt.Errorf("decode bundle: %w", err)
+	}
+	if b.Version > Version {
+		return nil, fmt.Errorf("%w: %d", ErrUnsupportedVersion, b.Version)
+	}
+	for _, e := range b.Entries {
+		for _, c := range e.Columns {
+			if len(c.Values) != len(e.Dates) {
+				return nil, fmt.Errorf("decode bundle: %s:%s column %q: %w", e.Source, e.Symbol, c.Name, dataset.ErrLengthMismatch)
+			}
+		}
+	}
+	return &b, nil
+}
+
+// Open reads the bundle file at path.
+func Open(path string) (*Bundle, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, fmt.Errorf("open bundle: %w", err)
+	}
+	defer f.Close()
+	return Read(f)
+}
@@ -0,0 +1,116 @@
+package sources
+
+import (
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/julianshen/gonp-datareader/dataset"
+)
+
+// ErrNoColumn is returned by typed column accessors when the column does
+// not exist.
+var ErrNoColumn = errors.New("column not found")
+
+// ColumnCache holds the typed columns parsed from a row-based ParsedData,
+// so repeated GetFloatColumn and GetTimeColumn calls parse each column
+// once. Row-based readers create one per parsed response; copies of the
+// ParsedData share it, so their Rows must be treated as read-only. A nil
+// *ColumnCache disables caching. It is safe for concurrent use.
+type ColumnCache struct {
+	mu     sync.Mutex
+	floats map[string][]float64
+	times  map[string][]time.Time
+}
+
+// NewColumnCache creates an empty ColumnCache.
+func NewColumnCache() *ColumnCache {
+	return &ColumnCache{
+		floats: make(map[string][]float64),
+		times:  make(map[string][]time.Time),
+	}
+}
+
+// FloatColumn parses the named column of rows as float64 values. Missing
+// values ("", "null", "NaN", ...) become NaN; any other unparseable value
+// is an error naming its row. The returned slice may be shared through
+// the cache and must not be modified.
+func FloatColumn(c *ColumnCache, columns []string, rows []map[string]string, name string) ([]float64, error) {
+	return cached(c, func(c *ColumnCache) map[string][]float64 { return c.floats }, columns, rows, name, parseFloat)
+}
+
+// TimeColumn parses the named column of rows as dates, accepting the
+// formats of dataset.ParseDate. Empty values become the zero time. The
+// returned slice may be shared through the cache and must not be modified.
+func TimeColumn(c *ColumnCache, columns []string, rows []map[string]string, name string) ([]time.Time, error) {
+	return cached(c, func(c *ColumnCache) map[string][]time.Time { return c.times }, columns, rows, name,
+		func(s string) (time.Time, error) {
+			if s == "" {
+				return time.Time{}, nil
+			}
+			return dataset.ParseDate(s)
+		})
+}
+
+// cached parses a column with parse, consulting and filling the cache map
+// selected by store.
+func cached[T any](c *ColumnCache, store func(*ColumnCache) map[string][]T, columns []string, rows []map[string]string, name string, parse func(string) (T, error)) ([]T, error) {
+	if c != nil {
+		c.mu.Lock()
+		values, ok := store(c)[name]
+		c.mu.Unlock()
+		if ok {
+			return values, nil
+		}
+	}
+
+	if !hasColumn(columns, rows, name) {
+		return nil, NoColumn(name)
+	}
+
+	values := make([]T, len(rows))
+	for i, row := range rows {
+		v, err := parse(row[name])
+		if err != nil {
+			return nil, fmt.Errorf("column %q row %d: %w", name, i, err)
+		}
+		values[i] = v
+	}
+
+	if c != nil {
+		c.mu.Lock()
+		store(c)[name] = values
+		c.mu.Unlock()
+	}
+	return values, nil
+}
+
+// hasColumn reports whether name is a listed column or, when no columns
+// are listed, a key of the first row.
+func hasColumn(columns []string, rows []map[string]string, name string) bool {
+	for _, c := range columns {
+		if c == name {
+			return true
+		}
+	}
+	if len(columns) == 0 && len(rows) > 0 {
+		_, ok := rows[0][name]
+		return ok
+	}
+	return false
+}
+
+// DescribeColumns returns descriptors for the named columns, in order,
+// taking each column's type from schema. Columns the schema does not
+// list are ColumnTypeString.
+func DescribeColumns(names []string, schema Schema) []Column {
+	out := make([]Column, len(names))
+	for i, name := range names {
+		out[i] = Column{Name: name, Type: ColumnTypeString}
+		if c, ok := schema.Column(name); ok {
+			out[i].Type = c.Type
+		}
+	}
+	return out
+}
@@ -0,0 +1,67 @@
+package sources_test
+
+import (
+	"errors"
+	"math"
+	"testing"
+	"time"
+
+	"github.com/julianshen/gonp-datareader/sources"
+)
+
+func TestFloatColumn(t *testing.T) {
+	columns := []string{"Date", "Close"}
+	rows := []map[string]string{
+		{"Date": "2024-01-02", "Close": "1,234.5"},
+		{"Date": "2024-01-03", "Close": "null"},
+	}
+	cache := sources.NewColumnCache()
+
+	got, err := sources.FloatColumn(cache, columns, rows, "Close")
+	if err != nil {
+		t.Fatalf("FloatColumn() error = %v", err)
+	}
+	if len(got) != 2 || got[0] != 1234.5 || !math.IsNaN(got[1]) {
+		t.Errorf("FloatColumn() = %v, want [1234.5 NaN]", got)
+	}
+
+	// The cached column is returned without parsing again
+	rows[0]["Close"] = "1"
+	if again, _ := sources.FloatColumn(cache, columns, rows, "Close"); again[0] != 1234.5 {
+		t.Errorf("FloatColumn() second call = %v, want cached value", again)
+	}
+
+	if _, err := sources.FloatColumn(nil, columns, rows, "Open"); !errors.Is(err, sources.ErrNoColumn) {
+		t.Errorf("FloatColumn(Open) error = %v, want ErrNoColumn", err)
+	}
+	if _, err := sources.FloatColumn(nil, columns, rows, "Date"); err == nil {
+		t.Error("FloatColumn(Date) expected parse error")
+	}
+}
+
+func TestTimeColumn(t *testing.T) {
+	rows := []map[string]string{{"Date": "2024-01-02"}, {"Date": ""}}
+
+	got, err := sources.TimeColumn(nil, []string{"Date"}, rows, "Date")
+	if err != nil {
+		t.Fatalf("TimeColumn() error = %v", err)
+	}
+	if !got[0].Equal(time.Date(2024, 1, 2, 0, 0, 0, 0, time.UTC)) || !got[1].IsZero() {
+		t.Errorf("TimeColumn() = %v", got)
+	}
+}
+
+func TestDescribeColumns(t *testing.T) {
+	schema := sources.Schema{Columns: []sources.Column{
+		{Name: "Date", Type: sources.ColumnTypeTime},
+		{Name: "Close", Type: sources.ColumnTypeFloat},
+	}}
+
+	got := sources.DescribeColumns([]string{"Date", "Close", "Note"}, schema)
+	want := []sources.ColumnType{sources.ColumnTypeTime, sources.ColumnTypeFloat, sources.ColumnTypeString}
+	for i, c := range got {
+		if c.Type != want[i] {
+			t.Errorf("DescribeColumns()[%d] = %+v, want type %s", i, c, want[i])
+		}
+	}
+}
@@ -0,0 +1,57 @@
+package sources
+
+import (
+	"sync/atomic"
+)
+
+// Deprecated APIs remain for at least one minor release after the release
+// named in their Since, so users can migrate incrementally; removals are
+// listed in the CHANGELOG.
+
+// Deprecation describes a use of a deprecated API.
+type Deprecation struct {
+	// API names the deprecated identifier (e.g., "alphavantage.BuildURL")
+	API string
+	// Replacement names what to use instead
+	Replacement string
+	// Since is the release that deprecated the API
+	Since string
+}
+
+// String returns the deprecation as a warning message.
+func (d Deprecation) String() string {
+	return d.API + " is deprecated since " + d.Since + "; use " + d.Replacement
+}
+
+// deprecationHandler holds the func installed by SetDeprecationHandler.
+var deprecationHandler atomic.Pointer[func(Deprecation)]
+
+// SetDeprecationHandler installs fn to be called on every use of a
+// deprecated API that the library can detect at run time, such as a
+// deprecated context key, for finding call sites to migrate. A nil fn
+// removes the handler; there is none by default. fn may be called
+// concurrently and should return quickly; deduplicate in fn if needed.
+//
+// # Example Usage
+//
+//	var seen sync.Map
+//	sources.SetDeprecationHandler(func(d sources.Deprecation) {
+//		if _, dup := seen.LoadOrStore(d.API, true); !dup {
+//			log.Printf("gonp-datareader: %s", d)
+//		}
+//	})
+func SetDeprecationHandler(fn func(Deprecation)) {
+	if fn == nil {
+		deprecationHandler.Store(nil)
+		return
+	}
+	deprecationHandler.Store(&fn)
+}
+
+// ReportDeprecation passes d to the handler installed by
+// SetDeprecationHandler, if any. Deprecated APIs call it on use.
+func ReportDeprecation(d Deprecation) {
+	if fn := deprecationHandler.Load(); fn != nil {
+		(*fn)(d)
+	}
+}
@@ -0,0 +1,36 @@
+package sources_test
+
+import (
+	"testing"
+
+	"github.com/julianshen/gonp-datareader/sources"
+)
+
+func TestReportDeprecation(t *testing.T) {
+	d := sources.Deprecation{API: "pkg.Old", Replacement: "pkg.New", Since: "v1.1.0"}
+
+	// No handler by default
+	sources.ReportDeprecation(d)
+
+	var got []sources.Deprecation
+	sources.SetDeprecationHandler(func(d sources.Deprecation) {
+		got = append(got, d)
+	})
+	t.Cleanup(func() { sources.SetDeprecationHandler(nil) })
+
+	sources.ReportDeprecation(d)
+	sources.ReportDeprecation(d)
+	if len(got) != 2 || got[0] != d {
+		t.Fatalf("handler got %v, want %v twice", got, d)
+	}
+
+	if want := "pkg.Old is deprecated since v1.1.0; use pkg.New"; d.String() != want {
+		t.Errorf("String() = %q, want %q", d.String(), want)
+	}
+
+	sources.SetDeprecationHandler(nil)
+	sources.ReportDeprecation(d)
+	if len(got) != 2 {
+		t.Errorf("handler called after removal, got %d calls", len(got))
+	}
+}
@@ -0,0 +1,186 @@
+// Package ecb provides a European Central Bank euro foreign exchange
+// reference rate reader.
+//
+// The ECB publishes reference rates of about 30 currencies against the
+// euro every TARGET business day around 16:00 CET. Symbols are ISO 4217
+// currency codes (e.g., "USD"); each rate is the units of that currency
+// per euro. Cross rates, such as USD/TWD, are the ratio of two euro
+// rates; package currency computes them.
+package ecb
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"time"
+
+	internalhttp "github.com/julianshen/gonp-datareader/internal/http"
+	"github.com/julianshen/gonp-datareader/internal/utils"
+	"github.com/julianshen/gonp-datareader/sources"
+)
+
+// dataURL is the EXR dataflow of the ECB Data Portal
+const dataURL = "https://data-api.ecb.europa.eu/service/data/EXR"
+
+// ECBReader fetches euro reference rates from the ECB Data Portal.
+type ECBReader struct {
+	*sources.BaseSource
+	client  *internalhttp.RetryableClient
+	baseURL string // For testing with mock servers
+}
+
+// NewECBReader creates a new ECB reference rate reader.
+func NewECBReader(opts *internalhttp.ClientOptions) *ECBReader {
+	return NewECBReaderWithBaseURL(opts, dataURL)
+}
+
+// NewECBReaderWithBaseURL creates a new ECB reader with a custom base
+// URL. This is primarily used for testing with mock servers.
+func NewECBReaderWithBaseURL(opts *internalhttp.ClientOptions, baseURL string) *ECBReader {
+	if opts == nil {
+		opts = internalhttp.DefaultClientOptions()
+	}
+
+	return &ECBReader{
+		BaseSource: sources.NewBaseSource("ecb"),
+		client:     internalhttp.NewRetryableClient(opts),
+		baseURL:    baseURL,
+	}
+}
+
+// Name returns the display name of the data source.
+func (e *ECBReader) Name() string {
+	return "ECB Reference Rates"
+}
+
+// Schema returns the columns of the ParsedData returned by ReadSingle.
+func (e *ECBReader) Schema() sources.Schema {
+	return sources.NewSchema(e.Source(), e.Name(), ParsedData{})
+}
+
+// ValidateSymbol accepts three-letter currency codes other than "EUR".
+func (e *ECBReader) ValidateSymbol(symbol string) error {
+	symbol = e.NormalizeSymbol(symbol)
+	if len(symbol) != 3 {
+		return fmt.Errorf("%w: %q is not a three-letter currency code", utils.ErrInvalidSymbolFormat, symbol)
+	}
+	for _, r := range symbol {
+		if r < 'A' || r > 'Z' {
+			return fmt.Errorf("%w: %q is not a three-letter currency code", utils.ErrInvalidSymbolFormat, symbol)
+		}
+	}
+	if symbol == "EUR" {
+		return fmt.Errorf("%w: rates are quoted against EUR", utils.ErrInvalidSymbolFormat)
+	}
+	return nil
+}
+
+// BuildURL constructs the URL of the daily reference rates of currency
+// between start and end.
+func (e *ECBReader) BuildURL(currency string, start, end time.Time) string {
+	params := url.Values{}
+	params.Set("startPeriod", start.Format("2006-01-02"))
+	params.Set("endPeriod", end.Format("2006-01-02"))
+	params.Set("format", "csvdata")
+	return fmt.Sprintf("%s/D.%s.EUR.SP00.A?%s", e.baseURL, currency, params.Encode())
+}
+
+// ReadSingle fetches the daily reference rates of a currency between
+// start and end. It returns a *ParsedData.
+func (e *ECBReader) ReadSingle(ctx context.Context, symbol string, start, end time.Time) (interface{}, error) {
+	return e.Finish(ctx, symbol, start, end, e.readSingle)
+}
+
+// readSingle implements ReadSingle before Finish is applied.
+func (e *ECBReader) readSingle(ctx context.Context, symbol string, start, end time.Time) (interface{}, error) {
+	// Normalize user input; the original is kept in Meta["symbol_input"]
+	input := symbol
+	symbol = e.NormalizeSymbol(symbol)
+
+	// Validate inputs
+	if err := e.ValidateSymbol(symbol); err != nil {
+		return nil, fmt.Errorf("invalid symbol: %w", err)
+	}
+
+	if err := utils.ValidateDateRange(start, end); err != nil {
+		return nil, fmt.Errorf("invalid date range: %w", err)
+	}
+
+	// Create HTTP request
+	req, err := http.NewRequestWithContext(internalhttp.WithContentType(ctx, "text/csv", "application/vnd.sdmx.data+csv"), "GET", e.BuildURL(symbol, start, end), nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create request: %w", err)
+	}
+	req.Header.Set("Accept", "text/csv")
+
+	// Execute request
+	resp, err := e.client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch data: %w", err)
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read response: %w", err)
+	}
+
+	// The ECB answers queries without observations, including those of
+	// unknown currencies, with 404 Not Found
+	if resp.StatusCode == http.StatusNotFound {
+		return nil, fmt.Errorf("%w: no ECB reference rates of %s", sources.ErrNoData, symbol)
+	}
+
+	// Check status code
+	if resp.StatusCode != http.StatusOK {
+		return nil, internalhttp.StatusError(resp, fmt.Errorf("ECB returned status %d: %s", resp.StatusCode, string(body)))
+	}
+
+	data, err := ParseCSV(body)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse CSV: %w", err)
+	}
+	if len(data.Dates) == 0 {
+		return nil, fmt.Errorf("%w: no ECB reference rates of %s", sources.ErrNoData, symbol)
+	}
+	data.Symbol = symbol
+	data.Meta = sources.InputMeta(nil, input, symbol)
+	return data, nil
+}
+
+// Read fetches the reference rates of multiple currencies, returning a
+// map[string]*ParsedData keyed by symbol.
+func (e *ECBReader) Read(ctx context.Context, symbols []string, start, end time.Time) (interface{}, error) {
+	// Validate inputs
+	if err := utils.ValidateSymbols(e.NormalizeSymbols(symbols)); err != nil {
+		return nil, fmt.Errorf("invalid symbols: %w", err)
+	}
+
+	if err := utils.ValidateDateRange(start, end); err != nil {
+		return nil, fmt.Errorf("invalid date range: %w", err)
+	}
+
+	// The ECB serves a few dozen currencies, so they are read in turn
+	dataMap := make(map[string]*ParsedData, len(symbols))
+	for _, symbol := range symbols {
+		data, err := e.ReadSingle(ctx, symbol, start, end)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read %s: %w", symbol, err)
+		}
+		dataMap[symbol] = data.(*ParsedData)
+	}
+	return dataMap, nil
+}
+
+// Shutdown stops accepting requests and waits for in-flight requests to
+// finish, cancelling them if ctx expires first.
+func (e *ECBReader) Shutdown(ctx context.Context) error {
+	return e.client.Shutdown(ctx)
+}
+
+// Close cancels in-flight requests and releases the reader's connections.
+func (e *ECBReader) Close() error {
+	return e.client.Close()
+}
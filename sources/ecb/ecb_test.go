@@ -0,0 +1,69 @@
+package ecb_test
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/julianshen/gonp-datareader/sources"
+	"github.com/julianshen/gonp-datareader/sources/ecb"
+)
+
+var (
+	testStart = time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	testEnd   = time.Date(2024, 1, 31, 0, 0, 0, 0, time.UTC)
+)
+
+func TestNewECBReader(t *testing.T) {
+	reader := ecb.NewECBReader(nil)
+
+	if reader.Source() != "ecb" {
+		t.Errorf("Source() = %q, want %q", reader.Source(), "ecb")
+	}
+	for _, symbol := range []string{"US", "EUR", "U1D", ""} {
+		if err := reader.ValidateSymbol(symbol); err == nil {
+			t.Errorf("ValidateSymbol(%q) error = nil, want an error", symbol)
+		}
+	}
+	if err := reader.ValidateSymbol("usd"); err != nil {
+		t.Errorf("ValidateSymbol(usd) error = %v", err)
+	}
+
+	want := "https://data-api.ecb.europa.eu/service/data/EXR/D.USD.EUR.SP00.A?endPeriod=2024-01-31&format=csvdata&startPeriod=2024-01-01"
+	if got := reader.BuildURL("USD", testStart, testEnd); got != want {
+		t.Errorf("BuildURL() = %s, want %s", got, want)
+	}
+}
+
+func TestECBReader_ReadSingle(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/D.USD.EUR.SP00.A" {
+			http.Error(w, "No results found.", http.StatusNotFound)
+			return
+		}
+		w.Header().Set("Content-Type", "application/vnd.sdmx.data+csv; version=1.0.0")
+		w.Write([]byte("KEY,TIME_PERIOD,OBS_VALUE\nEXR.D.USD.EUR.SP00.A,2024-01-02,1.0956\n"))
+	}))
+	defer server.Close()
+
+	reader := ecb.NewECBReaderWithBaseURL(nil, server.URL)
+	result, err := reader.ReadSingle(context.Background(), "usd", testStart, testEnd)
+	if err != nil {
+		t.Fatalf("ReadSingle() error = %v", err)
+	}
+	data := result.(*ecb.ParsedData)
+	if data.Symbol != "USD" || len(data.Rates) != 1 || data.Rates[0] != 1.0956 {
+		t.Errorf("ReadSingle() = %+v, want one USD rate of 1.0956", data)
+	}
+	if data.Meta["symbol_input"] != "usd" {
+		t.Errorf("Meta = %v, want symbol_input usd", data.Meta)
+	}
+
+	_, err = reader.ReadSingle(context.Background(), "XXX", testStart, testEnd)
+	if !errors.Is(err, sources.ErrNoData) {
+		t.Errorf("ReadSingle(XXX) error = %v, want ErrNoData", err)
+	}
+}
@@ -0,0 +1,113 @@
+package ecb
+
+import (
+	"bytes"
+	"encoding/csv"
+	"fmt"
+	"io"
+	"math"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/julianshen/gonp-datareader/sources"
+)
+
+// ParsedData holds the daily reference rates of a currency: units of the
+// currency per euro.
+type ParsedData struct {
+	// Symbol is the ISO 4217 code of the currency (e.g., "USD").
+	Symbol string      `schema:"-"`
+	Dates  []time.Time `schema:"Date"`
+	Rates  []float64   `schema:"Rate"`
+	// Meta holds response metadata such as "symbol_input"; nil when there
+	// is none.
+	Meta map[string]string `schema:"-"`
+}
+
+// RateColumn is the name of the rate column of ParsedData.
+const RateColumn = "Rate"
+
+// DateIndex returns a copy of Dates.
+func (p *ParsedData) DateIndex() ([]time.Time, error) {
+	if p == nil {
+		return nil, nil
+	}
+	return append([]time.Time(nil), p.Dates...), nil
+}
+
+// SelectRows keeps the rows at indices, in that order (see
+// sources.RowSelector).
+func (p *ParsedData) SelectRows(indices []int) {
+	if p == nil {
+		return
+	}
+	p.Dates = sources.SelectRows(p.Dates, indices)
+	p.Rates = sources.SelectRows(p.Rates, indices)
+}
+
+// ColumnNames returns RateColumn.
+func (p *ParsedData) ColumnNames() []string {
+	return []string{RateColumn}
+}
+
+// Float64Column returns a copy of Rates for RateColumn.
+func (p *ParsedData) Float64Column(name string) ([]float64, error) {
+	if p == nil || name != RateColumn {
+		return nil, sources.NoColumn(name)
+	}
+	return append([]float64(nil), p.Rates...), nil
+}
+
+// ParseCSV parses an SDMX-CSV response of the ECB Data Portal EXR
+// dataflow, reading the TIME_PERIOD and OBS_VALUE columns. Empty values
+// are NaN.
+func ParseCSV(body []byte) (*ParsedData, error) {
+	r := csv.NewReader(bytes.NewReader(body))
+	r.FieldsPerRecord = -1
+
+	header, err := r.Read()
+	if err != nil {
+		return nil, fmt.Errorf("read header: %w", err)
+	}
+	dateCol, valueCol := -1, -1
+	for i, name := range header {
+		switch strings.TrimPrefix(name, "\ufeff") {
+		case "TIME_PERIOD":
+			dateCol = i
+		case "OBS_VALUE":
+			valueCol = i
+		}
+	}
+	if dateCol < 0 || valueCol < 0 {
+		return nil, fmt.Errorf("missing TIME_PERIOD or OBS_VALUE column in header %v", header)
+	}
+
+	data := &ParsedData{}
+	for line := 2; ; line++ {
+		record, err := r.Read()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, fmt.Errorf("line %d: %w", line, err)
+		}
+		if len(record) <= dateCol || len(record) <= valueCol {
+			return nil, fmt.Errorf("line %d: %d fields, want at least %d", line, len(record), max(dateCol, valueCol)+1)
+		}
+
+		date, err := time.Parse("2006-01-02", record[dateCol])
+		if err != nil {
+			return nil, fmt.Errorf("line %d: invalid date %q", line, record[dateCol])
+		}
+		rate := math.NaN()
+		if s := strings.TrimSpace(record[valueCol]); s != "" {
+			if rate, err = strconv.ParseFloat(s, 64); err != nil {
+				return nil, fmt.Errorf("line %d: invalid rate %q", line, s)
+			}
+		}
+		data.Dates = append(data.Dates, date)
+		data.Rates = append(data.Rates, rate)
+	}
+	return data, nil
+}
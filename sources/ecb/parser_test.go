@@ -0,0 +1,37 @@
+package ecb_test
+
+import (
+	"math"
+	"testing"
+	"time"
+
+	"github.com/julianshen/gonp-datareader/sources/ecb"
+)
+
+func TestParseCSV(t *testing.T) {
+	body := "\ufeffKEY,FREQ,CURRENCY,CURRENCY_DENOM,EXR_TYPE,EXR_SUFFIX,TIME_PERIOD,OBS_VALUE,OBS_STATUS\n" +
+		"EXR.D.USD.EUR.SP00.A,D,USD,EUR,SP00,A,2024-01-02,1.0956,A\n" +
+		"EXR.D.USD.EUR.SP00.A,D,USD,EUR,SP00,A,2024-01-03,,M\n"
+
+	data, err := ecb.ParseCSV([]byte(body))
+	if err != nil {
+		t.Fatalf("ParseCSV() error = %v", err)
+	}
+	if len(data.Dates) != 2 || !data.Dates[0].Equal(time.Date(2024, 1, 2, 0, 0, 0, 0, time.UTC)) {
+		t.Fatalf("Dates = %v, want Jan 2 and 3", data.Dates)
+	}
+	if data.Rates[0] != 1.0956 || !math.IsNaN(data.Rates[1]) {
+		t.Errorf("Rates = %v, want [1.0956 NaN]", data.Rates)
+	}
+
+	for _, bad := range []string{
+		"",
+		"KEY,VALUE\nX,1\n",
+		"TIME_PERIOD,OBS_VALUE\n2024-13-01,1\n",
+		"TIME_PERIOD,OBS_VALUE\n2024-01-02,abc\n",
+	} {
+		if _, err := ecb.ParseCSV([]byte(bad)); err == nil {
+			t.Errorf("ParseCSV(%q) error = nil, want an error", bad)
+		}
+	}
+}
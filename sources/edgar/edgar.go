@@ -0,0 +1,348 @@
+// Package edgar provides access to SEC EDGAR full-text search.
+//
+// EDGAR full-text search (efts.sec.gov) finds filings since 2001 whose
+// documents contain words or phrases, for research tooling such as event
+// studies or filing monitors. It serves filings rather than time series,
+// so EDGARReader is not a sources.Reader and is not listed by
+// datareader.DataReader.
+//
+// The SEC rejects requests without a User-Agent naming the requester and
+// a contact email (e.g., "Sample Company admin@example.com"); set it with
+// ClientOptions.UserAgent, or Options.UserAgent with
+// datareader.NewEDGARReader. The SEC allows at most 10 requests per second.
+package edgar
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	internalhttp "github.com/julianshen/gonp-datareader/internal/http"
+	"github.com/julianshen/gonp-datareader/sources"
+)
+
+const (
+	// eftsSearchURL is the full-text search endpoint
+	eftsSearchURL = "https://efts.sec.gov/LATEST/search-index"
+
+	// archivesURL is the base URL of filing documents
+	archivesURL = "https://www.sec.gov/Archives/edgar/data"
+
+	// MaxResults is the number of hits EDGAR full-text search serves for a
+	// query; hits beyond it cannot be paged to. Narrow the query with
+	// forms or dates to see the rest.
+	MaxResults = 10000
+)
+
+// Query selects the filings of a full-text search.
+type Query struct {
+	// Query holds the words to find; wrap phrases in double quotes
+	// (e.g., `"going concern"`). Required.
+	Query string
+	// Forms restricts hits to form types (e.g., "10-K", "8-K"); empty
+	// means every form.
+	Forms []string
+	// CIKs restricts hits to filers by Central Index Key; empty means
+	// every filer.
+	CIKs []string
+	// Start and End bound the filed date, inclusive; zero means unbounded.
+	Start time.Time
+	End   time.Time
+	// Limit caps the number of hits returned; 0 means every hit up to
+	// MaxResults.
+	Limit int
+}
+
+// Filing is one document of a filing matching a full-text search.
+type Filing struct {
+	// AccessionNumber identifies the filing (e.g., "0000320193-20-000096").
+	AccessionNumber string
+	// CIK is the Central Index Key of the first filer, without leading zeros.
+	CIK string
+	// CompanyName is the display name of the first filer.
+	CompanyName string
+	// Form is the form type (e.g., "10-K").
+	Form string
+	// FiledDate is the date the filing was accepted.
+	FiledDate time.Time
+	// PeriodEnding is the end of the reporting period; zero when the form
+	// has none.
+	PeriodEnding time.Time
+	// URL is the matching document in the EDGAR archives.
+	URL string
+}
+
+// SearchResult holds the hits of a full-text search.
+type SearchResult struct {
+	// Filings holds the hits in EDGAR's relevance order.
+	Filings []Filing
+	// Total is the number of hits EDGAR reports for the query, which may
+	// exceed len(Filings).
+	Total int
+	// Truncated reports that hits were left out, by Query.Limit or
+	// MaxResults.
+	Truncated bool
+}
+
+// EDGARReader searches SEC EDGAR filings.
+type EDGARReader struct {
+	*sources.BaseSource
+	client  *internalhttp.RetryableClient
+	baseURL string
+	// archivesURL is the base URL of Filing.URL
+	archivesURL string
+}
+
+// NewEDGARReader creates a new EDGAR full-text search reader.
+func NewEDGARReader(opts *internalhttp.ClientOptions) *EDGARReader {
+	return NewEDGARReaderWithBaseURL(opts, eftsSearchURL)
+}
+
+// NewEDGARReaderWithBaseURL creates a new EDGAR reader with a custom search
+// URL. This is primarily used for testing with mock servers.
+func NewEDGARReaderWithBaseURL(opts *internalhttp.ClientOptions, baseURL string) *EDGARReader {
+	if opts == nil {
+		opts = internalhttp.DefaultClientOptions()
+	}
+
+	return &EDGARReader{
+		BaseSource:  sources.NewBaseSource("edgar"),
+		client:      internalhttp.NewRetryableClient(opts),
+		baseURL:     baseURL,
+		archivesURL: archivesURL,
+	}
+}
+
+// Name returns the display name of the data source.
+func (e *EDGARReader) Name() string {
+	return "SEC EDGAR"
+}
+
+// BuildSearchURL constructs the URL of the page of hits of q starting at
+// offset from.
+func (e *EDGARReader) BuildSearchURL(q Query, from int) string {
+	params := url.Values{}
+	params.Set("q", q.Query)
+	if len(q.Forms) > 0 {
+		params.Set("forms", strings.Join(q.Forms, ","))
+	}
+	if len(q.CIKs) > 0 {
+		ciks := make([]string, len(q.CIKs))
+		for i, cik := range q.CIKs {
+			ciks[i] = padCIK(cik)
+		}
+		params.Set("ciks", strings.Join(ciks, ","))
+	}
+	if !q.Start.IsZero() || !q.End.IsZero() {
+		params.Set("dateRange", "custom")
+		if !q.Start.IsZero() {
+			params.Set("startdt", q.Start.Format("2006-01-02"))
+		}
+		if !q.End.IsZero() {
+			params.Set("enddt", q.End.Format("2006-01-02"))
+		}
+	}
+	if from > 0 {
+		params.Set("from", strconv.Itoa(from))
+	}
+	return e.baseURL + "?" + params.Encode()
+}
+
+// SearchFilings runs a full-text search and returns its hits, following
+// pages until every hit, q.Limit hits or MaxResults hits are read. Hits
+// repeated across pages, as happens when new filings are indexed while
+// paging, are returned once.
+//
+// # Example Usage
+//
+//	result, err := edgar.NewEDGARReader(opts).SearchFilings(ctx, edgar.Query{
+//		Query: `"going concern"`,
+//		Forms: []string{"10-K"},
+//		Start: time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC),
+//		Limit: 200,
+//	})
+//	for _, f := range result.Filings {
+//		fmt.Println(f.FiledDate.Format("2006-01-02"), f.CompanyName, f.URL)
+//	}
+func (e *EDGARReader) SearchFilings(ctx context.Context, q Query) (*SearchResult, error) {
+	query, err := sources.CheckQuery(q.Query)
+	if err != nil {
+		return nil, err
+	}
+	q.Query = query
+	if !q.Start.IsZero() && !q.End.IsZero() && q.End.Before(q.Start) {
+		return nil, fmt.Errorf("invalid date range: %w", sources.ErrInvalidDateRange)
+	}
+
+	limit := MaxResults
+	if q.Limit > 0 && q.Limit < limit {
+		limit = q.Limit
+	}
+
+	result := &SearchResult{}
+	seen := make(map[string]bool)
+	from := 0
+	for from < limit {
+		page, err := e.fetchPage(ctx, q, from)
+		if err != nil {
+			return nil, err
+		}
+		result.Total = page.total
+		if len(page.filings) == 0 {
+			break
+		}
+
+		for _, f := range page.filings {
+			key := f.AccessionNumber + " " + f.URL
+			if seen[key] || len(result.Filings) == limit {
+				continue
+			}
+			seen[key] = true
+			result.Filings = append(result.Filings, f)
+		}
+
+		from += len(page.filings)
+		if from >= page.total {
+			break
+		}
+		if len(result.Filings) == limit {
+			break
+		}
+	}
+
+	// Paging stopped before the last hit EDGAR reported
+	result.Truncated = from < result.Total
+	return result, nil
+}
+
+// page is one page of search hits.
+type page struct {
+	filings []Filing
+	total   int
+}
+
+// fetchPage fetches the hits of q starting at offset from.
+func (e *EDGARReader) fetchPage(ctx context.Context, q Query, from int) (*page, error) {
+	// Create HTTP request
+	req, err := http.NewRequestWithContext(internalhttp.WithContentType(ctx, "application/json"), "GET", e.BuildSearchURL(q, from), nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create request: %w", err)
+	}
+
+	// Execute request
+	resp, err := e.client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch data: %w", err)
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read response: %w", err)
+	}
+
+	// Check status code
+	if resp.StatusCode != http.StatusOK {
+		return nil, internalhttp.StatusError(resp, fmt.Errorf("EDGAR returned status %d: %s", resp.StatusCode, string(body)))
+	}
+
+	filings, total, err := ParseSearch(body, e.archivesURL)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse search results: %w", err)
+	}
+	return &page{filings: filings, total: total}, nil
+}
+
+// searchResponse is the Elasticsearch response of full-text search.
+type searchResponse struct {
+	Hits struct {
+		Total struct {
+			Value int `json:"value"`
+		} `json:"total"`
+		Hits []struct {
+			ID     string `json:"_id"`
+			Source struct {
+				CIKs         []string `json:"ciks"`
+				DisplayNames []string `json:"display_names"`
+				Form         string   `json:"form"`
+				FileDate     string   `json:"file_date"`
+				PeriodEnding string   `json:"period_ending"`
+				ADSH         string   `json:"adsh"`
+			} `json:"_source"`
+		} `json:"hits"`
+	} `json:"hits"`
+}
+
+// ParseSearch parses a page of full-text search hits and returns the
+// filings, with URLs under archivesURL, and the total number of hits.
+func ParseSearch(body []byte, archivesURL string) ([]Filing, int, error) {
+	var response searchResponse
+	if err := json.Unmarshal(body, &response); err != nil {
+		return nil, 0, fmt.Errorf("unmarshal JSON: %w", err)
+	}
+
+	filings := make([]Filing, 0, len(response.Hits.Hits))
+	for _, hit := range response.Hits.Hits {
+		src := hit.Source
+		f := Filing{AccessionNumber: src.ADSH, Form: src.Form}
+
+		// The _id is "<accession number>:<document file name>"
+		adsh, document, _ := strings.Cut(hit.ID, ":")
+		if f.AccessionNumber == "" {
+			f.AccessionNumber = adsh
+		}
+		if len(src.CIKs) > 0 {
+			f.CIK = strings.TrimLeft(src.CIKs[0], "0")
+		}
+		if len(src.DisplayNames) > 0 {
+			f.CompanyName = companyName(src.DisplayNames[0])
+		}
+		if t, err := time.Parse("2006-01-02", src.FileDate); err == nil {
+			f.FiledDate = t
+		}
+		if t, err := time.Parse("2006-01-02", src.PeriodEnding); err == nil {
+			f.PeriodEnding = t
+		}
+		if f.CIK != "" && f.AccessionNumber != "" && document != "" {
+			f.URL = fmt.Sprintf("%s/%s/%s/%s", archivesURL, f.CIK,
+				strings.ReplaceAll(f.AccessionNumber, "-", ""), document)
+		}
+		filings = append(filings, f)
+	}
+	return filings, response.Hits.Total.Value, nil
+}
+
+// companyName strips the tickers and CIK EDGAR appends to display names,
+// as in "Apple Inc.  (AAPL)  (CIK 0000320193)".
+func companyName(display string) string {
+	if i := strings.Index(display, "  ("); i >= 0 {
+		display = display[:i]
+	}
+	return strings.TrimSpace(display)
+}
+
+// padCIK left-pads a CIK with zeros to the 10 digits EDGAR expects.
+func padCIK(cik string) string {
+	cik = strings.TrimSpace(cik)
+	if len(cik) < 10 {
+		cik = strings.Repeat("0", 10-len(cik)) + cik
+	}
+	return cik
+}
+
+// Shutdown stops accepting requests and waits for in-flight requests to
+// finish, cancelling them if ctx expires first.
+func (e *EDGARReader) Shutdown(ctx context.Context) error {
+	return e.client.Shutdown(ctx)
+}
+
+// Close cancels in-flight requests and releases the reader's connections.
+func (e *EDGARReader) Close() error {
+	return e.client.Close()
+}
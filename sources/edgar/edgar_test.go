@@ -0,0 +1,180 @@
+package edgar_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/julianshen/gonp-datareader/sources"
+	"github.com/julianshen/gonp-datareader/sources/edgar"
+)
+
+// hit returns a search hit of the filing with accession number adsh.
+func hit(adsh string) string {
+	return fmt.Sprintf(`{"_id":"%s:doc.htm","_source":{"ciks":["0000320193"],
+		"display_names":["Apple Inc.  (AAPL)  (CIK 0000320193)"],"form":"10-K",
+		"file_date":"2020-10-30","period_ending":"2020-09-26","adsh":"%s"}}`, adsh, adsh)
+}
+
+// newSearchServer serves pages of two hits from hits, reporting total, and
+// records the from offset of each request.
+func newSearchServer(t *testing.T, hits []string, total int, offsets *[]int) *httptest.Server {
+	t.Helper()
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		from, _ := strconv.Atoi(r.URL.Query().Get("from"))
+		*offsets = append(*offsets, from)
+		end := from + 2
+		if end > len(hits) {
+			end = len(hits)
+		}
+		if from > end {
+			from = end
+		}
+		w.Header().Set("Content-Type", "application/json")
+		fmt.Fprintf(w, `{"hits":{"total":{"value":%d,"relation":"eq"},"hits":[%s]}}`, total, strings.Join(hits[from:end], ","))
+	}))
+	t.Cleanup(server.Close)
+	return server
+}
+
+func TestEDGARReader_BuildSearchURL(t *testing.T) {
+	reader := edgar.NewEDGARReader(nil)
+	got := reader.BuildSearchURL(edgar.Query{
+		Query: `"going concern"`,
+		Forms: []string{"10-K", "10-Q"},
+		CIKs:  []string{"320193"},
+		Start: time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC),
+		End:   time.Date(2024, 6, 30, 0, 0, 0, 0, time.UTC),
+	}, 100)
+
+	for _, part := range []string{
+		"https://efts.sec.gov/LATEST/search-index?",
+		"q=%22going+concern%22",
+		"forms=10-K%2C10-Q",
+		"ciks=0000320193",
+		"dateRange=custom",
+		"startdt=2024-01-01",
+		"enddt=2024-06-30",
+		"from=100",
+	} {
+		if !strings.Contains(got, part) {
+			t.Errorf("BuildSearchURL() = %q, missing %q", got, part)
+		}
+	}
+}
+
+func TestEDGARReader_SearchFilings(t *testing.T) {
+	hits := []string{hit("0000320193-20-000001"), hit("0000320193-20-000002"), hit("0000320193-20-000003"),
+		hit("0000320193-20-000003"), hit("0000320193-20-000004")}
+
+	tests := []struct {
+		name          string
+		limit         int
+		total         int
+		wantAccession []string
+		wantOffsets   []int
+		wantTruncated bool
+	}{
+		{
+			name:          "all pages, repeated hit once",
+			total:         5,
+			wantAccession: []string{"0000320193-20-000001", "0000320193-20-000002", "0000320193-20-000003", "0000320193-20-000004"},
+			wantOffsets:   []int{0, 2, 4},
+		},
+		{
+			name:          "limit",
+			limit:         3,
+			total:         5,
+			wantAccession: []string{"0000320193-20-000001", "0000320193-20-000002", "0000320193-20-000003"},
+			wantOffsets:   []int{0, 2},
+			wantTruncated: true,
+		},
+		{
+			name:          "total beyond served hits",
+			total:         50,
+			wantAccession: []string{"0000320193-20-000001", "0000320193-20-000002", "0000320193-20-000003", "0000320193-20-000004"},
+			wantOffsets:   []int{0, 2, 4, 5},
+			wantTruncated: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var offsets []int
+			server := newSearchServer(t, hits, tt.total, &offsets)
+			reader := edgar.NewEDGARReaderWithBaseURL(nil, server.URL)
+
+			result, err := reader.SearchFilings(context.Background(), edgar.Query{Query: "iphone", Limit: tt.limit})
+			if err != nil {
+				t.Fatalf("SearchFilings() error = %v", err)
+			}
+
+			var got []string
+			for _, f := range result.Filings {
+				got = append(got, f.AccessionNumber)
+			}
+			if fmt.Sprint(got) != fmt.Sprint(tt.wantAccession) {
+				t.Errorf("accession numbers = %v, want %v", got, tt.wantAccession)
+			}
+			if fmt.Sprint(offsets) != fmt.Sprint(tt.wantOffsets) {
+				t.Errorf("offsets = %v, want %v", offsets, tt.wantOffsets)
+			}
+			if result.Total != tt.total || result.Truncated != tt.wantTruncated {
+				t.Errorf("Total = %d, Truncated = %v, want %d, %v", result.Total, result.Truncated, tt.total, tt.wantTruncated)
+			}
+		})
+	}
+}
+
+func TestEDGARReader_SearchFilings_Errors(t *testing.T) {
+	reader := edgar.NewEDGARReader(nil)
+	ctx := context.Background()
+
+	if _, err := reader.SearchFilings(ctx, edgar.Query{Query: " "}); !errors.Is(err, sources.ErrEmptyQuery) {
+		t.Errorf("SearchFilings() error = %v, want %v", err, sources.ErrEmptyQuery)
+	}
+
+	q := edgar.Query{
+		Query: "iphone",
+		Start: time.Date(2024, 2, 1, 0, 0, 0, 0, time.UTC),
+		End:   time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC),
+	}
+	if _, err := reader.SearchFilings(ctx, q); !errors.Is(err, sources.ErrInvalidDateRange) {
+		t.Errorf("SearchFilings() error = %v, want %v", err, sources.ErrInvalidDateRange)
+	}
+}
+
+func TestParseSearch(t *testing.T) {
+	body := `{"hits":{"total":{"value":1},"hits":[` + hit("0000320193-20-000096") + `]}}`
+
+	filings, total, err := edgar.ParseSearch([]byte(body), "https://archives.test")
+	if err != nil {
+		t.Fatalf("ParseSearch() error = %v", err)
+	}
+	if total != 1 || len(filings) != 1 {
+		t.Fatalf("ParseSearch() = %d filings of %d, want 1 of 1", len(filings), total)
+	}
+
+	want := edgar.Filing{
+		AccessionNumber: "0000320193-20-000096",
+		CIK:             "320193",
+		CompanyName:     "Apple Inc.",
+		Form:            "10-K",
+		FiledDate:       time.Date(2020, 10, 30, 0, 0, 0, 0, time.UTC),
+		PeriodEnding:    time.Date(2020, 9, 26, 0, 0, 0, 0, time.UTC),
+		URL:             "https://archives.test/320193/000032019320000096/doc.htm",
+	}
+	if filings[0] != want {
+		t.Errorf("ParseSearch() = %+v, want %+v", filings[0], want)
+	}
+
+	if _, _, err := edgar.ParseSearch([]byte("not json"), ""); err == nil {
+		t.Error("ParseSearch() should fail for invalid JSON")
+	}
+}
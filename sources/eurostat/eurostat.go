@@ -2,6 +2,7 @@
 package eurostat
 
 import (
+	"bytes"
 	"context"
 	"fmt"
 	"io"
@@ -24,6 +25,8 @@ type EurostatReader struct {
 	*sources.BaseSource
 	client  *internalhttp.RetryableClient
 	baseURL string
+	sdmxURL string
+	format  Format
 }
 
 // NewEurostatReader creates a new Eurostat data reader.
@@ -42,17 +45,49 @@ func NewEurostatReaderWithBaseURL(opts *internalhttp.ClientOptions, baseURL stri
 		BaseSource: sources.NewBaseSource("eurostat"),
 		client:     internalhttp.NewRetryableClient(opts),
 		baseURL:    baseURL,
+		sdmxURL:    sdmxCSVURL,
+		format:     FormatJSONStat,
 	}
 }
 
+// SetFormat selects the response format requested by ReadSingle and Read:
+// FormatJSONStat (the default) or FormatSDMXCSV.
+func (e *EurostatReader) SetFormat(format Format) error {
+	f, err := ParseFormat(string(format))
+	if err != nil {
+		return err
+	}
+	e.format = f
+	return nil
+}
+
+// Format returns the response format requested by the reader.
+func (e *EurostatReader) Format() Format {
+	return e.format
+}
+
+// SetSDMXURL sets the SDMX-CSV data URL used with FormatSDMXCSV. The URL
+// has one %s verb for the dataset code. This is primarily used for testing
+// with mock servers.
+func (e *EurostatReader) SetSDMXURL(url string) {
+	e.sdmxURL = url
+}
+
 // Name returns the display name of the data source.
 func (e *EurostatReader) Name() string {
 	return "Eurostat"
 }
 
+// Schema returns the columns of the ParsedData returned by ReadSingle.
+func (e *EurostatReader) Schema() sources.Schema {
+	return sources.NewSchema(e.Source(), e.Name(), ParsedData{})
+}
+
 // ValidateSymbol validates a Eurostat dataset code.
 // Eurostat symbols are dataset codes like "DEMO_R_D3DENS", "GDP", etc.
 func (e *EurostatReader) ValidateSymbol(symbol string) error {
+	symbol = e.NormalizeSymbol(symbol)
+
 	if symbol == "" {
 		return fmt.Errorf("symbol cannot be empty")
 	}
@@ -80,8 +115,28 @@ func (e *EurostatReader) BuildURL(symbol string, start, end time.Time) string {
 	return url
 }
 
+// BuildSDMXURL constructs the SDMX-CSV URL for the given symbol, limited to
+// the years from start through end.
+func (e *EurostatReader) BuildSDMXURL(symbol string, start, end time.Time) string {
+	url := fmt.Sprintf(e.sdmxURL, symbol)
+	sep := "?"
+	if strings.Contains(url, "?") {
+		sep = "&"
+	}
+	return fmt.Sprintf("%s%sstartPeriod=%d&endPeriod=%d", url, sep, start.Year(), end.Year())
+}
+
 // ReadSingle fetches data for a single symbol from Eurostat.
 func (e *EurostatReader) ReadSingle(ctx context.Context, symbol string, start, end time.Time) (interface{}, error) {
+	return e.Finish(ctx, symbol, start, end, e.readSingle)
+}
+
+// readSingle implements ReadSingle before Finish is applied.
+func (e *EurostatReader) readSingle(ctx context.Context, symbol string, start, end time.Time) (interface{}, error) {
+	// Normalize user input; the original is kept in Meta["symbol_input"]
+	input := symbol
+	symbol = e.NormalizeSymbol(symbol)
+
 	// Validate inputs
 	if err := e.ValidateSymbol(symbol); err != nil {
 		return nil, fmt.Errorf("invalid symbol: %w", err)
@@ -91,17 +146,19 @@ func (e *EurostatReader) ReadSingle(ctx context.Context, symbol string, start, e
 		return nil, fmt.Errorf("invalid date range: %w", err)
 	}
 
-	// Build URL
-	url := e.BuildURL(symbol, start, end)
+	// Build URL for the selected format
+	url, accept := e.BuildURL(symbol, start, end), []string{"application/json"}
+	if e.format == FormatSDMXCSV {
+		url, accept = e.BuildSDMXURL(symbol, start, end), []string{"text/csv", "application/vnd.sdmx.data+csv"}
+	}
 
 	// Create HTTP request
-	req, err := http.NewRequestWithContext(ctx, "GET", url, nil)
+	req, err := http.NewRequestWithContext(internalhttp.WithContentType(ctx, accept...), "GET", url, nil)
 	if err != nil {
 		return nil, fmt.Errorf("failed to create request: %w", err)
 	}
 
-	// Set Accept header for JSON
-	req.Header.Set("Accept", "application/json")
+	req.Header.Set("Accept", accept[0])
 
 	// Execute request
 	resp, err := e.client.Do(req)
@@ -116,23 +173,45 @@ func (e *EurostatReader) ReadSingle(ctx context.Context, symbol string, start, e
 		if err != nil {
 			return nil, fmt.Errorf("Eurostat returned status %d (failed to read response body: %w)", resp.StatusCode, err)
 		}
-		return nil, fmt.Errorf("Eurostat returned status %d: %s", resp.StatusCode, string(body))
+		return nil, internalhttp.StatusError(resp, fmt.Errorf("Eurostat returned status %d: %s", resp.StatusCode, string(body)))
 	}
 
-	// Parse JSON response
-	data, err := ParseJSON(resp.Body)
+	// Read response body
+	body, err := io.ReadAll(resp.Body)
 	if err != nil {
-		return nil, fmt.Errorf("failed to parse JSON: %w", err)
+		return nil, fmt.Errorf("failed to read response: %w", err)
 	}
 
-	return data, nil
+	var decoded interface{}
+	if e.format == FormatSDMXCSV {
+		decoded, err = e.client.Decode("eurostat/sdmx-csv", body, func(b []byte) (interface{}, error) {
+			return ParseSDMXCSV(bytes.NewReader(b))
+		})
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse SDMX-CSV: %w", err)
+		}
+	} else {
+		decoded, err = e.client.Decode("eurostat", body, func(b []byte) (interface{}, error) {
+			return ParseJSON(bytes.NewReader(b))
+		})
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse JSON: %w", err)
+		}
+	}
+
+	// Copy the decoded value, which may be shared through the decoded cache,
+	// and flag data served from an expired cache entry
+	data := *decoded.(*ParsedData)
+	data.Meta = sources.InputMeta(internalhttp.StaleMeta(resp), input, symbol)
+
+	return &data, nil
 }
 
 // Read fetches data for multiple symbols from Eurostat.
 // Symbols are fetched in parallel for better performance.
 func (e *EurostatReader) Read(ctx context.Context, symbols []string, start, end time.Time) (interface{}, error) {
 	// Validate inputs
-	if err := utils.ValidateSymbols(symbols); err != nil {
+	if err := utils.ValidateSymbols(e.NormalizeSymbols(symbols)); err != nil {
 		return nil, fmt.Errorf("invalid symbols: %w", err)
 	}
 
@@ -200,3 +279,14 @@ func (e *EurostatReader) readParallel(ctx context.Context, symbols []string, sta
 
 	return dataMap, nil
 }
+
+// Shutdown stops accepting requests and waits for in-flight requests to
+// finish, cancelling them if ctx expires first.
+func (e *EurostatReader) Shutdown(ctx context.Context) error {
+	return e.client.Shutdown(ctx)
+}
+
+// Close cancels in-flight requests and releases the reader's connections.
+func (e *EurostatReader) Close() error {
+	return e.client.Close()
+}
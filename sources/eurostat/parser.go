@@ -5,12 +5,49 @@ import (
 	"fmt"
 	"io"
 	"sort"
+	"time"
+
+	"github.com/julianshen/gonp-datareader/sources"
 )
 
 // ParsedData holds parsed Eurostat data.
 type ParsedData struct {
-	Dates  []string
-	Values []float64
+	Dates  []string  `schema:"Date"`
+	Values []float64 `schema:"Value"`
+	// Meta holds response metadata such as "stale" when the data was
+	// served from an expired cache entry; nil when there is none.
+	Meta map[string]string `schema:"-"`
+}
+
+// DateIndex returns Dates parsed as dates.
+func (p *ParsedData) DateIndex() ([]time.Time, error) {
+	if p == nil {
+		return nil, nil
+	}
+	return sources.ParseDates(p.Dates)
+}
+
+// SelectRows keeps the rows at indices, in that order (see
+// sources.RowSelector).
+func (p *ParsedData) SelectRows(indices []int) {
+	if p == nil {
+		return
+	}
+	p.Dates = sources.SelectRows(p.Dates, indices)
+	p.Values = sources.SelectRows(p.Values, indices)
+}
+
+// ColumnNames returns the single value column, "Value".
+func (p *ParsedData) ColumnNames() []string {
+	return []string{"Value"}
+}
+
+// Float64Column returns a copy of the "Value" column.
+func (p *ParsedData) Float64Column(name string) ([]float64, error) {
+	if p == nil || name != "Value" {
+		return nil, sources.NoColumn(name)
+	}
+	return append([]float64(nil), p.Values...), nil
 }
 
 // GetColumn returns a column of data by name.
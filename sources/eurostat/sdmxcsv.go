@@ -0,0 +1,128 @@
+package eurostat
+
+import (
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"math"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// sdmxCSVURL is the Eurostat SDMX 2.1 data endpoint serving SDMX-CSV.
+const sdmxCSVURL = "https://ec.europa.eu/eurostat/api/dissemination/sdmx/2.1/data/%s?format=SDMX-CSV"
+
+// Format selects the response format a EurostatReader requests.
+type Format string
+
+const (
+	// FormatJSONStat requests JSON-stat, the default.
+	FormatJSONStat Format = "jsonstat"
+	// FormatSDMXCSV requests SDMX-CSV, which is parsed as a stream and
+	// filtered to the requested years by the server, so large regional
+	// extracts use far less memory than JSON-stat.
+	FormatSDMXCSV Format = "sdmx-csv"
+)
+
+// ErrUnknownFormat is returned by SetFormat for an unsupported format.
+var ErrUnknownFormat = errors.New("unknown eurostat format")
+
+// ParseFormat returns the Format named s; the empty string means
+// FormatJSONStat.
+func ParseFormat(s string) (Format, error) {
+	switch f := Format(strings.ToLower(s)); f {
+	case "", FormatJSONStat:
+		return FormatJSONStat, nil
+	case FormatSDMXCSV:
+		return f, nil
+	default:
+		return "", fmt.Errorf("%w: %q (want %q or %q)", ErrUnknownFormat, s, FormatJSONStat, FormatSDMXCSV)
+	}
+}
+
+// ParseSDMXCSV parses Eurostat SDMX-CSV data row by row. Like ParseJSON,
+// it returns one value per TIME_PERIOD, averaging the observations of all
+// other dimensions, so memory use grows with the number of periods rather
+// than the number of rows. Rows without OBS_VALUE are skipped; a period
+// without any observation has a NaN value.
+func ParseSDMXCSV(reader io.Reader) (*ParsedData, error) {
+	r := csv.NewReader(reader)
+	r.FieldsPerRecord = -1
+	r.ReuseRecord = true
+
+	header, err := r.Read()
+	if err == io.EOF {
+		return nil, fmt.Errorf("empty SDMX-CSV response")
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read header: %w", err)
+	}
+	timeCol, valueCol := -1, -1
+	for i, name := range header {
+		switch strings.ToUpper(strings.TrimSpace(strings.TrimPrefix(name, "\ufeff"))) {
+		case "TIME_PERIOD":
+			timeCol = i
+		case "OBS_VALUE":
+			valueCol = i
+		}
+	}
+	if timeCol < 0 || valueCol < 0 {
+		return nil, fmt.Errorf("TIME_PERIOD or OBS_VALUE column not found")
+	}
+
+	type sum struct {
+		total float64
+		count int
+	}
+	periods := make(map[string]*sum)
+	for line := 2; ; line++ {
+		record, err := r.Read()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, fmt.Errorf("line %d: %w", line, err)
+		}
+		if timeCol >= len(record) || valueCol >= len(record) {
+			continue
+		}
+
+		period := strings.TrimSpace(record[timeCol])
+		s, ok := periods[period]
+		if !ok {
+			s = &sum{}
+			periods[period] = s
+		}
+		raw := strings.TrimSpace(record[valueCol])
+		if raw == "" {
+			continue
+		}
+		value, err := strconv.ParseFloat(raw, 64)
+		if err != nil {
+			return nil, fmt.Errorf("line %d: invalid OBS_VALUE %q", line, raw)
+		}
+		s.total += value
+		s.count++
+	}
+
+	dates := make([]string, 0, len(periods))
+	for period := range periods {
+		dates = append(dates, period)
+	}
+	// Eurostat periods (2020, 2020-Q1, 2020-01) sort chronologically as
+	// strings within a frequency
+	sort.Strings(dates)
+
+	values := make([]float64, len(dates))
+	for i, period := range dates {
+		if s := periods[period]; s.count > 0 {
+			values[i] = s.total / float64(s.count)
+		} else {
+			values[i] = math.NaN()
+		}
+	}
+
+	return &ParsedData{Dates: dates, Values: values}, nil
+}
@@ -0,0 +1,113 @@
+package eurostat_test
+
+import (
+	"context"
+	"errors"
+	"math"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/julianshen/gonp-datareader/sources/eurostat"
+)
+
+const sdmxCSV = `DATAFLOW,LAST UPDATE,freq,unit,geo,TIME_PERIOD,OBS_VALUE,OBS_FLAG
+ESTAT:DEMO_R_D3DENS(1.0),21/03/24 23:00:00,A,PER_KM2,AT,2021,107.5,
+ESTAT:DEMO_R_D3DENS(1.0),21/03/24 23:00:00,A,PER_KM2,AT,2020,107.1,
+ESTAT:DEMO_R_D3DENS(1.0),21/03/24 23:00:00,A,PER_KM2,BE,2020,378.9,
+ESTAT:DEMO_R_D3DENS(1.0),21/03/24 23:00:00,A,PER_KM2,BE,2021,,p
+`
+
+func TestParseSDMXCSV(t *testing.T) {
+	data, err := eurostat.ParseSDMXCSV(strings.NewReader(sdmxCSV))
+	if err != nil {
+		t.Fatalf("ParseSDMXCSV() error = %v", err)
+	}
+	if want := []string{"2020", "2021"}; !reflect.DeepEqual(data.Dates, want) {
+		t.Errorf("Dates = %v, want %v", data.Dates, want)
+	}
+	if want := []float64{243, 107.5}; !reflect.DeepEqual(data.Values, want) {
+		t.Errorf("Values = %v, want %v", data.Values, want)
+	}
+}
+
+func TestParseSDMXCSV_MissingPeriod(t *testing.T) {
+	input := `DATAFLOW,LAST UPDATE,freq,unit,geo,TIME_PERIOD,OBS_VALUE,OBS_FLAG
+ESTAT:DEMO_R_D3DENS(1.0),21/03/24 23:00:00,A,PER_KM2,AT,2020,107.1,
+ESTAT:DEMO_R_D3DENS(1.0),21/03/24 23:00:00,A,PER_KM2,AT,2021,,:
+ESTAT:DEMO_R_D3DENS(1.0),21/03/24 23:00:00,A,PER_KM2,BE,2021,,:
+`
+	data, err := eurostat.ParseSDMXCSV(strings.NewReader(input))
+	if err != nil {
+		t.Fatalf("ParseSDMXCSV() error = %v", err)
+	}
+	if want := []string{"2020", "2021"}; !reflect.DeepEqual(data.Dates, want) {
+		t.Fatalf("Dates = %v, want %v", data.Dates, want)
+	}
+	if data.Values[0] != 107.1 || !math.IsNaN(data.Values[1]) {
+		t.Errorf("Values = %v, want [107.1 NaN]", data.Values)
+	}
+}
+
+func TestParseSDMXCSV_Errors(t *testing.T) {
+	tests := []struct {
+		name  string
+		input string
+	}{
+		{"empty", ""},
+		{"missing columns", "DATAFLOW,geo\nX,AT\n"},
+		{"invalid value", "TIME_PERIOD,OBS_VALUE\n2020,abc\n"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := eurostat.ParseSDMXCSV(strings.NewReader(tt.input)); err == nil {
+				t.Error("ParseSDMXCSV() returned nil error")
+			}
+		})
+	}
+}
+
+func TestEurostatReader_SDMXCSV(t *testing.T) {
+	var gotQuery, gotAccept string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		gotQuery, gotAccept = r.URL.RawQuery, r.Header.Get("Accept")
+		w.Header().Set("Content-Type", "application/vnd.sdmx.data+csv; version=1.0.0")
+		w.Write([]byte(sdmxCSV))
+	}))
+	defer server.Close()
+
+	reader := eurostat.NewEurostatReader(nil)
+	if err := reader.SetFormat(eurostat.FormatSDMXCSV); err != nil {
+		t.Fatal(err)
+	}
+	reader.SetSDMXURL(server.URL + "/data/%s?format=SDMX-CSV")
+
+	start := time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC)
+	end := time.Date(2021, 12, 31, 0, 0, 0, 0, time.UTC)
+	result, err := reader.ReadSingle(context.Background(), "DEMO_R_D3DENS", start, end)
+	if err != nil {
+		t.Fatalf("ReadSingle() error = %v", err)
+	}
+	if gotQuery != "format=SDMX-CSV&startPeriod=2020&endPeriod=2021" || gotAccept != "text/csv" {
+		t.Errorf("query = %q, Accept = %q", gotQuery, gotAccept)
+	}
+	if data := result.(*eurostat.ParsedData); len(data.Dates) != 2 {
+		t.Errorf("Dates = %v, want 2 periods", data.Dates)
+	}
+}
+
+func TestEurostatReader_SetFormat(t *testing.T) {
+	reader := eurostat.NewEurostatReader(nil)
+	if reader.Format() != eurostat.FormatJSONStat {
+		t.Errorf("default Format() = %q, want jsonstat", reader.Format())
+	}
+	if err := reader.SetFormat("SDMX-CSV"); err != nil || reader.Format() != eurostat.FormatSDMXCSV {
+		t.Errorf("SetFormat(SDMX-CSV) = %v, Format() = %q", err, reader.Format())
+	}
+	if err := reader.SetFormat("xml"); !errors.Is(err, eurostat.ErrUnknownFormat) {
+		t.Errorf("SetFormat(xml) error = %v, want ErrUnknownFormat", err)
+	}
+}
@@ -0,0 +1,34 @@
+package sources
+
+import (
+	"fmt"
+
+	"github.com/julianshen/gonp-datareader/dataset"
+)
+
+// Fill returns the dates and numeric columns of ts, the ParsedData of any
+// source, as a Dataset whose missing values are filled by method (see
+// dataset.Dataset.Fill). The Dataset has no symbol, source or metadata.
+//
+// # Example Usage
+//
+//	data, _ := reader.ReadSingle(ctx, "DGS10", start, end) // "." on holidays
+//	filled, err := sources.Fill(data.(sources.TimeSeries), dataset.FillForward)
+func Fill(ts TimeSeries, method dataset.FillMethod) (*dataset.Dataset, error) {
+	dates, err := ts.DateIndex()
+	if err != nil {
+		return nil, err
+	}
+
+	ds := dataset.New("", "", dates)
+	for _, name := range ts.ColumnNames() {
+		values, err := ts.Float64Column(name)
+		if err != nil {
+			return nil, fmt.Errorf("column %s: %w", name, err)
+		}
+		if err := ds.AddColumn(name, values); err != nil {
+			return nil, err
+		}
+	}
+	return ds.Fill(method), nil
+}
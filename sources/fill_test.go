@@ -0,0 +1,34 @@
+package sources_test
+
+import (
+	"testing"
+
+	"github.com/julianshen/gonp-datareader/dataset"
+	"github.com/julianshen/gonp-datareader/sources"
+	"github.com/julianshen/gonp-datareader/sources/fred"
+)
+
+func TestFill(t *testing.T) {
+	// FRED marks holidays with "."
+	data := &fred.ParsedData{
+		Dates:  []string{"2024-01-12", "2024-01-15", "2024-01-16"},
+		Values: []string{"3.94", ".", "4.07"},
+	}
+
+	filled, err := sources.Fill(data, dataset.FillForward)
+	if err != nil {
+		t.Fatalf("Fill() error = %v", err)
+	}
+	values, ok := filled.Column("Value")
+	if !ok || len(values) != 3 || values[1] != 3.94 {
+		t.Errorf("Fill(FillForward) = %v, want [3.94 3.94 4.07]", values)
+	}
+
+	dropped, err := sources.Fill(data, dataset.FillDrop)
+	if err != nil {
+		t.Fatalf("Fill() error = %v", err)
+	}
+	if dropped.Len() != 2 {
+		t.Errorf("Fill(FillDrop) has %d rows, want 2", dropped.Len())
+	}
+}
@@ -0,0 +1,88 @@
+package finmind
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/julianshen/gonp-datareader/internal/utils"
+	"github.com/julianshen/gonp-datareader/sources"
+)
+
+// DividendDataset holds each stock's announced cash and stock dividends
+// with their ex-dividend dates.
+const DividendDataset = "TaiwanStockDividend"
+
+// dividendLookbackYears is how far before the range ReadActions requests
+// announcements, which FinMind dates by announcement rather than ex-date.
+const dividendLookbackYears = 1
+
+// parValue is the par value in TWD of Taiwan shares, in which stock
+// dividends are announced.
+const parValue = 10
+
+// dividendRecord is the part of a TaiwanStockDividend entry used by
+// ReadActions. Amounts are in TWD per share.
+type dividendRecord struct {
+	CashEarningsDistribution   float64 `json:"CashEarningsDistribution"`
+	CashStatutorySurplus       float64 `json:"CashStatutorySurplus"`
+	CashExDividendTradingDate  string  `json:"CashExDividendTradingDate"`
+	StockEarningsDistribution  float64 `json:"StockEarningsDistribution"`
+	StockStatutorySurplus      float64 `json:"StockStatutorySurplus"`
+	StockExDividendTradingDate string  `json:"StockExDividendTradingDate"`
+}
+
+// ReadActions returns the cash and stock dividends of a Taiwan stock with
+// ex-dates in the range, from the TaiwanStockDividend dataset (one
+// request). Cash dividends are the sum of the earnings and statutory
+// surplus distributions; stock dividends, announced in TWD of shares per
+// share, become sources.ActionStockDividend with a Ratio at the 10 TWD par
+// value. Announcements up to a year before start are requested, since the
+// dataset is dated by announcement.
+func (f *FinMindReader) ReadActions(ctx context.Context, symbol string, start, end time.Time) ([]sources.Action, error) {
+	symbol = f.NormalizeSymbol(symbol)
+	if err := f.ValidateSymbol(symbol); err != nil {
+		return nil, fmt.Errorf("invalid symbol: %w", err)
+	}
+	if err := utils.ValidateDateRange(start, end); err != nil {
+		return nil, fmt.Errorf("invalid date range: %w", err)
+	}
+
+	var dividends struct {
+		Data []dividendRecord `json:"data"`
+	}
+	if _, err := f.getDataset(ctx, DividendDataset, symbol, start.AddDate(-dividendLookbackYears, 0, 0), end, &dividends); err != nil {
+		return nil, fmt.Errorf("%s: %w", DividendDataset, err)
+	}
+
+	return dividendActions(symbol, dividends.Data, start, end), nil
+}
+
+// dividendActions converts dividend records to the actions with ex-dates
+// in the range.
+func dividendActions(symbol string, records []dividendRecord, start, end time.Time) []sources.Action {
+	days := utils.NewDayRange(start, end)
+	inRange := func(s string) (time.Time, bool) {
+		date, err := time.Parse("2006-01-02", s)
+		if err != nil {
+			return time.Time{}, false
+		}
+		return date, days.Contains(date)
+	}
+
+	var actions []sources.Action
+	for _, r := range records {
+		if cash := r.CashEarningsDistribution + r.CashStatutorySurplus; cash > 0 {
+			if date, ok := inRange(r.CashExDividendTradingDate); ok {
+				actions = append(actions, sources.Action{Symbol: symbol, Date: date, Type: sources.ActionDividend, Amount: cash})
+			}
+		}
+		if stock := r.StockEarningsDistribution + r.StockStatutorySurplus; stock > 0 {
+			if date, ok := inRange(r.StockExDividendTradingDate); ok {
+				actions = append(actions, sources.Action{Symbol: symbol, Date: date, Type: sources.ActionStockDividend, Ratio: 1 + stock/parValue})
+			}
+		}
+	}
+	sources.SortActions(actions)
+	return actions
+}
@@ -0,0 +1,52 @@
+package finmind_test
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"testing"
+	"time"
+
+	internalhttp "github.com/julianshen/gonp-datareader/internal/http"
+	"github.com/julianshen/gonp-datareader/sources"
+	"github.com/julianshen/gonp-datareader/sources/finmind"
+)
+
+func TestFinMindReader_ReadActions(t *testing.T) {
+	var gotStart string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if got := r.URL.Query().Get("dataset"); got != finmind.DividendDataset {
+			t.Errorf("dataset = %q, want %q", got, finmind.DividendDataset)
+		}
+		gotStart = r.URL.Query().Get("start_date")
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"data":[
+			{"date":"2023-02-14","stock_id":"2330","CashEarningsDistribution":2.75,"CashStatutorySurplus":0.25,"CashExDividendTradingDate":"2023-06-15","StockEarningsDistribution":0,"StockStatutorySurplus":0,"StockExDividendTradingDate":""},
+			{"date":"2023-03-20","stock_id":"2330","CashEarningsDistribution":1,"CashStatutorySurplus":0,"CashExDividendTradingDate":"2023-07-20","StockEarningsDistribution":0.5,"StockStatutorySurplus":0,"StockExDividendTradingDate":"2023-07-20"},
+			{"date":"2022-05-10","stock_id":"2330","CashEarningsDistribution":2.75,"CashStatutorySurplus":0,"CashExDividendTradingDate":"2022-06-16","StockEarningsDistribution":0,"StockStatutorySurplus":0,"StockExDividendTradingDate":""}
+		]}`))
+	}))
+	defer server.Close()
+
+	reader := finmind.NewFinMindReaderWithEndpoint(&internalhttp.ClientOptions{RateLimit: 100}, server.URL)
+	start := time.Date(2023, 6, 1, 0, 0, 0, 0, time.UTC)
+	end := time.Date(2023, 12, 31, 0, 0, 0, 0, time.UTC)
+
+	actions, err := reader.ReadActions(context.Background(), "2330", start, end)
+	if err != nil {
+		t.Fatalf("ReadActions() error = %v", err)
+	}
+	if gotStart != "2022-06-01" {
+		t.Errorf("start_date = %q, want announcements from a year before start", gotStart)
+	}
+
+	want := []sources.Action{
+		{Symbol: "2330", Date: time.Date(2023, 6, 15, 0, 0, 0, 0, time.UTC), Type: sources.ActionDividend, Amount: 3},
+		{Symbol: "2330", Date: time.Date(2023, 7, 20, 0, 0, 0, 0, time.UTC), Type: sources.ActionDividend, Amount: 1},
+		{Symbol: "2330", Date: time.Date(2023, 7, 20, 0, 0, 0, 0, time.UTC), Type: sources.ActionStockDividend, Ratio: 1.05},
+	}
+	if !reflect.DeepEqual(actions, want) {
+		t.Errorf("ReadActions() = %+v, want %+v", actions, want)
+	}
+}
@@ -0,0 +1,127 @@
+package finmind
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"sort"
+	"time"
+
+	internalhttp "github.com/julianshen/gonp-datareader/internal/http"
+	"github.com/julianshen/gonp-datareader/internal/utils"
+)
+
+const (
+	// MarketValueDataset holds each stock's daily market capitalization.
+	MarketValueDataset = "TaiwanStockMarketValue"
+
+	// ShareholdingDataset holds each stock's daily shareholding statistics,
+	// including the number of shares issued.
+	ShareholdingDataset = "TaiwanStockShareholding"
+)
+
+// CapitalColumns are the columns of the ParsedData returned by ReadCapital.
+var CapitalColumns = []string{"date", "stock_id", "SharesOutstanding", "MarketCap"}
+
+// marketValueRecord is one entry of the TaiwanStockMarketValue dataset.
+type marketValueRecord struct {
+	Date        string  `json:"date"`
+	StockID     string  `json:"stock_id"`
+	MarketValue float64 `json:"market_value"`
+}
+
+// shareholdingRecord is the part of a TaiwanStockShareholding entry used
+// by ReadCapital.
+type shareholdingRecord struct {
+	Date                 string  `json:"date"`
+	StockID              string  `json:"stock_id"`
+	NumberOfSharesIssued float64 `json:"NumberOfSharesIssued"`
+}
+
+// ReadCapital fetches the daily shares outstanding and market
+// capitalization (in TWD) of a Taiwan stock, for cap-weighted aggregation
+// and liquidity filters. It combines the TaiwanStockShareholding and
+// TaiwanStockMarketValue datasets (two requests) by date; a value missing
+// from one of them is left empty and becomes NaN in a Dataset.
+//
+// The returned ParsedData has the columns in CapitalColumns.
+func (f *FinMindReader) ReadCapital(ctx context.Context, symbol string, start, end time.Time) (*ParsedData, error) {
+	symbol = f.NormalizeSymbol(symbol)
+	if err := f.ValidateSymbol(symbol); err != nil {
+		return nil, fmt.Errorf("invalid symbol: %w", err)
+	}
+	if err := utils.ValidateDateRange(start, end); err != nil {
+		return nil, fmt.Errorf("invalid date range: %w", err)
+	}
+
+	var shares struct {
+		Data []shareholdingRecord `json:"data"`
+	}
+	meta, err := f.getDataset(ctx, ShareholdingDataset, symbol, start, end, &shares)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", ShareholdingDataset, err)
+	}
+
+	var values struct {
+		Data []marketValueRecord `json:"data"`
+	}
+	valuesMeta, err := f.getDataset(ctx, MarketValueDataset, symbol, start, end, &values)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", MarketValueDataset, err)
+	}
+	for k, v := range valuesMeta {
+		if meta == nil {
+			meta = make(map[string]string)
+		}
+		meta[k] = v
+	}
+
+	rows := make(map[string]map[string]string)
+	row := func(date string) map[string]string {
+		r, ok := rows[date]
+		if !ok {
+			r = map[string]string{"date": date, "stock_id": symbol, "SharesOutstanding": "", "MarketCap": ""}
+			rows[date] = r
+		}
+		return r
+	}
+	for _, s := range shares.Data {
+		row(s.Date)["SharesOutstanding"] = formatFloat(s.NumberOfSharesIssued)
+	}
+	for _, v := range values.Data {
+		row(v.Date)["MarketCap"] = formatFloat(v.MarketValue)
+	}
+
+	data := &ParsedData{
+		Symbol:  symbol,
+		Columns: CapitalColumns,
+		Rows:    make([]map[string]string, 0, len(rows)),
+		Meta:    meta,
+	}
+	for _, r := range rows {
+		data.Rows = append(data.Rows, r)
+	}
+	sort.Slice(data.Rows, func(i, j int) bool { return data.Rows[i]["date"] < data.Rows[j]["date"] })
+
+	return data, nil
+}
+
+// getDataset fetches one dataset for symbol and decodes the JSON response
+// into v. It returns the response metadata.
+func (f *FinMindReader) getDataset(ctx context.Context, dataset, symbol string, start, end time.Time, v interface{}) (map[string]string, error) {
+	params := url.Values{}
+	params.Set("dataset", dataset)
+	params.Set("data_id", symbol)
+	params.Set("start_date", formatDate(start))
+	params.Set("end_date", formatDate(end))
+
+	body, resp, err := f.get(ctx, fmt.Sprintf("%s?%s", f.endpoint, params.Encode()))
+	if err != nil {
+		return nil, err
+	}
+	if err := json.Unmarshal(body, v); err != nil {
+		return nil, fmt.Errorf("parse response: unmarshal JSON: %w", err)
+	}
+	return internalhttp.StaleMeta(resp), nil
+}
@@ -0,0 +1,95 @@
+package finmind_test
+
+import (
+	"context"
+	"math"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"testing"
+	"time"
+
+	datareader "github.com/julianshen/gonp-datareader"
+	internalhttp "github.com/julianshen/gonp-datareader/internal/http"
+	"github.com/julianshen/gonp-datareader/sources/finmind"
+)
+
+func TestFinMindReader_ReadCapital(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		switch r.URL.Query().Get("dataset") {
+		case finmind.ShareholdingDataset:
+			w.Write([]byte(`{"data":[
+				{"date":"2024-01-02","stock_id":"2330","NumberOfSharesIssued":25932070000},
+				{"date":"2024-01-03","stock_id":"2330","NumberOfSharesIssued":25932070000}
+			]}`))
+		case finmind.MarketValueDataset:
+			w.Write([]byte(`{"data":[
+				{"date":"2024-01-03","stock_id":"2330","market_value":15040600000000},
+				{"date":"2024-01-02","stock_id":"2330","market_value":15300000000000}
+			]}`))
+		default:
+			t.Errorf("unexpected dataset %q", r.URL.Query().Get("dataset"))
+		}
+	}))
+	defer server.Close()
+
+	reader := finmind.NewFinMindReaderWithEndpoint(&internalhttp.ClientOptions{RateLimit: 100}, server.URL)
+	start := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	end := time.Date(2024, 1, 4, 0, 0, 0, 0, time.UTC)
+
+	data, err := reader.ReadCapital(context.Background(), "2330", start, end)
+	if err != nil {
+		t.Fatalf("ReadCapital() error = %v", err)
+	}
+
+	if !reflect.DeepEqual(data.Columns, finmind.CapitalColumns) {
+		t.Errorf("Columns = %v, want %v", data.Columns, finmind.CapitalColumns)
+	}
+	if len(data.Rows) != 2 || data.Rows[0]["date"] != "2024-01-02" {
+		t.Fatalf("Rows = %v, want 2 rows sorted by date", data.Rows)
+	}
+	if got := data.Rows[0]["MarketCap"]; got != "15300000000000" {
+		t.Errorf("MarketCap = %q, want 15300000000000", got)
+	}
+
+	ds, err := datareader.ToDataset("2330", data)
+	if err != nil {
+		t.Fatalf("ToDataset() error = %v", err)
+	}
+	shares, ok := ds.Column("SharesOutstanding")
+	if !ok || shares[1] != 25932070000 {
+		t.Errorf("SharesOutstanding = %v", shares)
+	}
+}
+
+func TestFinMindReader_ReadCapital_MissingValues(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Query().Get("dataset") == finmind.MarketValueDataset {
+			w.Write([]byte(`{"data":[{"date":"2024-01-02","stock_id":"2330","market_value":15300000000000}]}`))
+			return
+		}
+		w.Write([]byte(`{"data":[]}`))
+	}))
+	defer server.Close()
+
+	reader := finmind.NewFinMindReaderWithEndpoint(&internalhttp.ClientOptions{RateLimit: 100}, server.URL)
+	start := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	end := time.Date(2024, 1, 4, 0, 0, 0, 0, time.UTC)
+
+	data, err := reader.ReadCapital(context.Background(), "2330", start, end)
+	if err != nil {
+		t.Fatalf("ReadCapital() error = %v", err)
+	}
+	ds, err := datareader.ToDataset("2330", data)
+	if err != nil {
+		t.Fatalf("ToDataset() error = %v", err)
+	}
+	if shares, ok := ds.Column("SharesOutstanding"); !ok || !math.IsNaN(shares[0]) {
+		t.Errorf("SharesOutstanding = %v, want NaN", shares)
+	}
+
+	if _, err := reader.ReadCapital(context.Background(), "", start, end); err == nil {
+		t.Error("ReadCapital() expected error for empty symbol")
+	}
+}
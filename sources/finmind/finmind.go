@@ -42,6 +42,9 @@ const (
 	// DefaultDataset is the default dataset to fetch (Taiwan stock prices).
 	DefaultDataset = "TaiwanStockPrice"
 
+	// StockInfoDataset lists every security with its industry category.
+	StockInfoDataset = "TaiwanStockInfo"
+
 	// DefaultRateLimit is the default rate limit without token (300 requests/hour).
 	DefaultRateLimit = 300.0 / 3600.0 // requests per second
 
@@ -61,6 +64,7 @@ type FinMindReader struct {
 	token    string
 	endpoint string
 	dataset  string
+	workers  int
 }
 
 // NewFinMindReader creates a new FinMind reader without authentication token.
@@ -103,6 +107,9 @@ func NewFinMindReaderWithTokenAndEndpoint(opts *internalhttp.ClientOptions, toke
 	if opts == nil {
 		opts = internalhttp.DefaultClientOptions()
 	}
+	// Copy so the rate limit below does not leak into the caller's options
+	o := *opts
+	opts = &o
 
 	// Set appropriate rate limit based on token presence
 	if token != "" && opts.RateLimit == 0 {
@@ -117,14 +124,36 @@ func NewFinMindReaderWithTokenAndEndpoint(opts *internalhttp.ClientOptions, toke
 		token:      token,
 		endpoint:   endpoint,
 		dataset:    DefaultDataset,
+		workers:    readWorkers(opts),
 	}
 }
 
+// readWorkers returns how many symbols Read fetches at once. Below one
+// request per second the limiter paces requests minutes apart, so running
+// more workers than its burst only queues requests at the limiter, where a
+// failure elsewhere cannot stop them from spending quota.
+func readWorkers(opts *internalhttp.ClientOptions) int {
+	workers := 10
+	if opts.RateLimit > 0 && opts.RateLimit < 1 {
+		workers = opts.RateBurst
+		if workers < 1 {
+			workers = 1
+		}
+	}
+	return workers
+}
+
 // Name returns the display name of the data source.
 func (f *FinMindReader) Name() string {
 	return "FinMind"
 }
 
+// Schema returns the columns of the ParsedData returned by ReadSingle
+// for the default TaiwanStockPrice dataset.
+func (f *FinMindReader) Schema() sources.Schema {
+	return sources.NewSchema(f.Source(), f.Name(), FinMindStockData{})
+}
+
 // SetToken sets the authentication token for the reader.
 //
 // This allows updating the token after reader creation. Setting a token
@@ -163,9 +192,14 @@ func (f *FinMindReader) SetDataset(dataset string) {
 //
 //	https://api.finmindtrade.com/api/v4/data?dataset=TaiwanStockPrice&data_id=2330&start_date=2020-04-02&end_date=2020-04-12
 func (f *FinMindReader) BuildURL(symbol string, start, end time.Time) string {
+	return f.buildURL(f.dataset, symbol, start, end)
+}
+
+// buildURL constructs the API URL for dataset.
+func (f *FinMindReader) buildURL(dataset, symbol string, start, end time.Time) string {
 	// Build query parameters
 	params := url.Values{}
-	params.Set("dataset", f.dataset)
+	params.Set("dataset", dataset)
 	params.Set("data_id", symbol)
 	params.Set("start_date", formatDate(start))
 	params.Set("end_date", formatDate(end))
@@ -191,6 +225,15 @@ func formatDate(t time.Time) string {
 // Returns ParsedData containing the fetched data with columns and rows.
 // Returns an error if the symbol is invalid, the request fails, or no data is found.
 func (f *FinMindReader) ReadSingle(ctx context.Context, symbol string, start, end time.Time) (interface{}, error) {
+	return f.Finish(ctx, symbol, start, end, f.readSingle)
+}
+
+// readSingle implements ReadSingle before Finish is applied.
+func (f *FinMindReader) readSingle(ctx context.Context, symbol string, start, end time.Time) (interface{}, error) {
+	// Normalize user input; the original is kept in Meta["symbol_input"]
+	input := symbol
+	symbol = f.NormalizeSymbol(symbol)
+
 	// Validate symbol
 	if err := f.ValidateSymbol(symbol); err != nil {
 		return nil, fmt.Errorf("invalid symbol: %w", err)
@@ -202,54 +245,136 @@ func (f *FinMindReader) ReadSingle(ctx context.Context, symbol string, start, en
 	}
 
 	// Build API URL
-	urlStr := f.BuildURL(symbol, start, end)
+	urlStr := f.buildURL(internalhttp.Dataset(ctx, f.dataset), symbol, start, end)
+
+	body, resp, err := f.get(ctx, urlStr)
+	if err != nil {
+		return nil, err
+	}
+
+	// Parse JSON response
+	decoded, err := f.client.Decode("finmind", body, func(b []byte) (interface{}, error) {
+		return ParseFinMindResponse(b)
+	})
+	if err != nil {
+		return nil, fmt.Errorf("parse response: %w", err)
+	}
+	if len(decoded.(*ParsedData).Rows) == 0 {
+		return nil, fmt.Errorf("%w: no FinMind data of %s", sources.ErrNoData, symbol)
+	}
 
+	// Copy the decoded value, which may be shared through the decoded cache,
+	// and flag data served from an expired cache entry
+	data := *decoded.(*ParsedData)
+	data.Meta = sources.InputMeta(internalhttp.StaleMeta(resp), input, symbol)
+
+	return &data, nil
+}
+
+// get performs an authenticated GET request and returns the response body.
+// The response body is already closed.
+func (f *FinMindReader) get(ctx context.Context, urlStr string) ([]byte, *http.Response, error) {
 	// Create HTTP request
-	req, err := http.NewRequestWithContext(ctx, "GET", urlStr, nil)
+	req, err := http.NewRequestWithContext(internalhttp.WithContentType(ctx, "application/json"), "GET", urlStr, nil)
 	if err != nil {
-		return nil, fmt.Errorf("create request: %w", err)
+		return nil, nil, fmt.Errorf("create request: %w", err)
 	}
 
 	// Add Authorization header if token is present
-	if f.token != "" {
-		req.Header.Set("Authorization", "Bearer "+f.token)
+	if token := internalhttp.APIKey(ctx, f.token); token != "" {
+		req.Header.Set("Authorization", "Bearer "+token)
 	}
 
 	// Execute HTTP request
+	sent := time.Now()
 	resp, err := f.client.Do(req)
 	if err != nil {
-		return nil, fmt.Errorf("fetch data: %w", err)
+		return nil, nil, fmt.Errorf("fetch data: %w", err)
 	}
 	defer resp.Body.Close()
 
 	// Check HTTP status code
 	if resp.StatusCode != http.StatusOK {
 		body, _ := io.ReadAll(resp.Body) //nolint:errcheck // Best effort error message
-		return nil, fmt.Errorf("HTTP %d: %s", resp.StatusCode, string(body))
+		err := fmt.Errorf("HTTP %d: %s", resp.StatusCode, string(body))
+		// FinMind answers 402 Payment Required once the hourly quota is used up
+		if resp.StatusCode == http.StatusPaymentRequired {
+			f.client.Throttled(sent)
+			return nil, nil, &sources.RateLimitError{
+				StatusCode: resp.StatusCode,
+				RetryAfter: internalhttp.RetryAfter(resp.Header, time.Now()),
+				Err:        err,
+			}
+		}
+		return nil, nil, internalhttp.StatusError(resp, err)
 	}
 
 	// Read response body
 	body, err := io.ReadAll(resp.Body)
 	if err != nil {
-		return nil, fmt.Errorf("read response: %w", err)
+		return nil, nil, fmt.Errorf("read response: %w", err)
 	}
 
-	// Parse JSON response
-	data, err := ParseFinMindResponse(body)
+	return body, resp, nil
+}
+
+// ReadStockInfo fetches the TaiwanStockInfo dataset: every listed
+// security's name, industry category and market type. A security listed
+// under several industries appears once per industry.
+func (f *FinMindReader) ReadStockInfo(ctx context.Context) ([]StockInfo, error) {
+	params := url.Values{}
+	params.Set("dataset", StockInfoDataset)
+
+	body, _, err := f.get(ctx, fmt.Sprintf("%s?%s", f.endpoint, params.Encode()))
+	if err != nil {
+		return nil, err
+	}
+
+	info, err := ParseStockInfoResponse(body)
 	if err != nil {
 		return nil, fmt.Errorf("parse response: %w", err)
 	}
 
-	return data, nil
+	return info, nil
+}
+
+// ReadSymbolInfo fetches every security of the TaiwanStockInfo dataset as
+// a sources.SymbolInfo, once per stock with its first industry. FinMind
+// publishes Traditional Chinese names only; English names can be added
+// from the TWSE directory:
+//
+//	names, err := twseReader.ReadEnglishNames(ctx)
+//	infos, err := finmindReader.ReadSymbolInfo(ctx)
+//	names.Apply(infos)
+func (f *FinMindReader) ReadSymbolInfo(ctx context.Context) ([]sources.SymbolInfo, error) {
+	stocks, err := f.ReadStockInfo(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	infos := make([]sources.SymbolInfo, 0, len(stocks))
+	seen := make(map[string]bool, len(stocks))
+	for _, s := range stocks {
+		if seen[s.StockID] {
+			continue
+		}
+		seen[s.StockID] = true
+		infos = append(infos, s.SymbolInfo())
+	}
+	return infos, nil
 }
 
 // Read fetches data for multiple symbols from FinMind in parallel.
 //
 // This method fetches data for all symbols concurrently with a worker pool pattern
-// to respect rate limits. The maximum number of concurrent workers is 10.
+// to respect rate limits. The maximum number of concurrent workers is 10; at
+// FinMind's hourly rate limits (300 or 600 requests/hour) it is the rate
+// limiter's burst, one by default.
 //
 // Returns a map of symbol to ParsedData.
-// Returns an error if any symbol fails to fetch.
+// Returns an error if any symbol fails to fetch; symbols and the date range
+// are validated before any request is made, and the first failure cancels
+// the remaining requests so they do not use up the hourly quota.
 func (f *FinMindReader) Read(ctx context.Context, symbols []string, start, end time.Time) (interface{}, error) {
 	if len(symbols) == 0 {
 		return make(map[string]*ParsedData), nil
@@ -269,6 +394,17 @@ func (f *FinMindReader) Read(ctx context.Context, symbols []string, start, end t
 		return result, nil
 	}
 
+	// Validate everything up front so a bad symbol does not cost the
+	// quota spent on the symbols before it
+	for _, symbol := range symbols {
+		if err := f.ValidateSymbol(f.NormalizeSymbol(symbol)); err != nil {
+			return nil, fmt.Errorf("invalid symbol %s: %w", symbol, err)
+		}
+	}
+	if err := utils.ValidateDateRange(start, end); err != nil {
+		return nil, fmt.Errorf("invalid date range: %w", err)
+	}
+
 	return f.readParallel(ctx, symbols, start, end)
 }
 
@@ -283,8 +419,12 @@ func (f *FinMindReader) readParallel(ctx context.Context, symbols []string, star
 	// Create channels for work distribution and results
 	results := make(chan result, len(symbols))
 
+	// Cancel queued and in-flight requests once any symbol fails
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
 	// Create worker pool - limit concurrency to avoid overwhelming the server
-	maxWorkers := 10
+	maxWorkers := f.workers
 	if len(symbols) < maxWorkers {
 		maxWorkers = len(symbols)
 	}
@@ -301,6 +441,12 @@ func (f *FinMindReader) readParallel(ctx context.Context, symbols []string, star
 			semaphore <- struct{}{}
 			defer func() { <-semaphore }()
 
+			// Skip symbols still queued after a failure
+			if err := ctx.Err(); err != nil {
+				results <- result{symbol: sym, err: err}
+				return
+			}
+
 			// Fetch data
 			data, err := f.ReadSingle(ctx, sym, start, end)
 
@@ -327,3 +473,14 @@ func (f *FinMindReader) readParallel(ctx context.Context, symbols []string, star
 
 	return dataMap, nil
 }
+
+// Shutdown stops accepting requests and waits for in-flight requests to
+// finish, cancelling them if ctx expires first.
+func (f *FinMindReader) Shutdown(ctx context.Context) error {
+	return f.client.Shutdown(ctx)
+}
+
+// Close cancels in-flight requests and releases the reader's connections.
+func (f *FinMindReader) Close() error {
+	return f.client.Close()
+}
@@ -3,12 +3,16 @@ package finmind_test
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"net/http"
 	"net/http/httptest"
+	"reflect"
+	"sync/atomic"
 	"testing"
 	"time"
 
 	internalhttp "github.com/julianshen/gonp-datareader/internal/http"
+	"github.com/julianshen/gonp-datareader/sources"
 	"github.com/julianshen/gonp-datareader/sources/finmind"
 )
 
@@ -407,3 +411,123 @@ func TestFinMindReader_Read_EmptySymbols(t *testing.T) {
 		t.Errorf("Expected empty map, got %d entries", len(dataMap))
 	}
 }
+
+func TestFinMindReader_Read_InvalidSymbolMakesNoRequests(t *testing.T) {
+	var requests atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests.Add(1)
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"data":[]}`))
+	}))
+	defer server.Close()
+
+	reader := finmind.NewFinMindReaderWithEndpoint(&internalhttp.ClientOptions{Timeout: 5 * time.Second}, server.URL)
+
+	start := time.Date(2020, 4, 2, 0, 0, 0, 0, time.UTC)
+	end := time.Date(2020, 4, 12, 0, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name       string
+		symbols    []string
+		start, end time.Time
+	}{
+		{name: "empty symbol", symbols: []string{"2330", "", "2454"}, start: start, end: end},
+		{name: "reversed date range", symbols: []string{"2330", "2317"}, start: end, end: start},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := reader.Read(context.Background(), tt.symbols, tt.start, tt.end); err == nil {
+				t.Fatal("Read() expected error")
+			}
+			if n := requests.Load(); n != 0 {
+				t.Errorf("Read() made %d requests, want 0", n)
+			}
+		})
+	}
+}
+
+func TestFinMindReader_Read_CancelsAfterFailure(t *testing.T) {
+	var requests atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests.Add(1)
+		http.Error(w, "quota exceeded", http.StatusPaymentRequired)
+	}))
+	defer server.Close()
+
+	// At the hourly rate the second request would wait 12 seconds for
+	// the limiter; the first failure must cancel it instead
+	opts := &internalhttp.ClientOptions{Timeout: 5 * time.Second, RateLimit: finmind.DefaultRateLimit}
+	reader := finmind.NewFinMindReaderWithEndpoint(opts, server.URL)
+
+	start := time.Date(2020, 4, 2, 0, 0, 0, 0, time.UTC)
+	end := time.Date(2020, 4, 12, 0, 0, 0, 0, time.UTC)
+
+	begin := time.Now()
+	_, err := reader.Read(context.Background(), []string{"2330", "2317", "2454"}, start, end)
+	if err == nil {
+		t.Fatal("Read() expected error")
+	}
+	if elapsed := time.Since(begin); elapsed > 5*time.Second {
+		t.Errorf("Read() took %v after the first failure", elapsed)
+	}
+	if n := requests.Load(); n != 1 {
+		t.Errorf("Read() made %d requests, want 1", n)
+	}
+}
+
+func TestNewFinMindReader_DoesNotModifyOptions(t *testing.T) {
+	opts := &internalhttp.ClientOptions{Timeout: 5 * time.Second}
+	finmind.NewFinMindReaderWithTokenAndEndpoint(opts, "token", "http://localhost")
+
+	if opts.RateLimit != 0 {
+		t.Errorf("RateLimit = %v, want caller's options unchanged", opts.RateLimit)
+	}
+}
+
+func TestFinMindReader_ReadSymbolInfo(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if got := r.URL.Query().Get("dataset"); got != finmind.StockInfoDataset {
+			t.Errorf("dataset = %q, want %q", got, finmind.StockInfoDataset)
+		}
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"data":[
+			{"industry_category":"半導體業","stock_id":"2330","stock_name":"台積電","type":"twse","date":"2024-01-02"},
+			{"industry_category":"電子工業","stock_id":"2330","stock_name":"台積電","type":"twse","date":"2024-01-02"},
+			{"industry_category":"半導體業","stock_id":"5347","stock_name":"世界","type":"tpex","date":"2024-01-02"}
+		]}`))
+	}))
+	defer server.Close()
+
+	reader := finmind.NewFinMindReaderWithEndpoint(&internalhttp.ClientOptions{RateLimit: 100}, server.URL)
+	infos, err := reader.ReadSymbolInfo(context.Background())
+	if err != nil {
+		t.Fatalf("ReadSymbolInfo() error = %v", err)
+	}
+
+	sources.NameMap{"2330": "TSMC"}.Apply(infos)
+	want := []sources.SymbolInfo{
+		{Symbol: "2330", Name: "台積電", EnglishName: "TSMC", Market: "twse", Industry: "半導體業"},
+		{Symbol: "5347", Name: "世界", Market: "tpex", Industry: "半導體業"},
+	}
+	if !reflect.DeepEqual(infos, want) {
+		t.Errorf("ReadSymbolInfo() = %+v, want %+v", infos, want)
+	}
+}
+
+func TestFinMindReader_ReadSingle_NoData(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"msg": "success", "status": 200, "data": []}`))
+	}))
+	defer server.Close()
+
+	reader := finmind.NewFinMindReaderWithEndpoint(nil, server.URL)
+
+	start := time.Date(2020, 4, 2, 0, 0, 0, 0, time.UTC)
+	end := time.Date(2020, 4, 8, 0, 0, 0, 0, time.UTC)
+	_, err := reader.ReadSingle(context.Background(), "2330", start, end)
+	if !errors.Is(err, sources.ErrNoData) {
+		t.Errorf("ReadSingle() error = %v, want ErrNoData", err)
+	}
+}
@@ -4,6 +4,10 @@ import (
 	"encoding/json"
 	"fmt"
 	"strconv"
+	"time"
+
+	"github.com/julianshen/gonp-datareader/ohlcv"
+	"github.com/julianshen/gonp-datareader/sources"
 )
 
 // FinMindResponse represents the JSON response from FinMind API.
@@ -13,16 +17,35 @@ type FinMindResponse struct {
 
 // FinMindStockData represents a single stock data entry from FinMind.
 type FinMindStockData struct {
-	Date            string  `json:"date"`
+	Date            string  `json:"date" schema:",time"`
 	StockID         string  `json:"stock_id"`
-	TradingVolume   int64   `json:"Trading_Volume"`
-	TradingMoney    int64   `json:"Trading_money"`
+	TradingVolume   float64 `json:"Trading_Volume"`
+	TradingMoney    float64 `json:"Trading_money"`
 	Open            float64 `json:"open"`
 	Max             float64 `json:"max"`
 	Min             float64 `json:"min"`
 	Close           float64 `json:"close"`
 	Spread          float64 `json:"spread"`
-	TradingTurnover int64   `json:"Trading_turnover"`
+	TradingTurnover float64 `json:"Trading_turnover"`
+}
+
+// StockInfo is one entry of the TaiwanStockInfo dataset.
+type StockInfo struct {
+	IndustryCategory string `json:"industry_category"`
+	StockID          string `json:"stock_id"`
+	StockName        string `json:"stock_name"`
+	Type             string `json:"type"` // Market: "twse" (listed) or "tpex" (OTC)
+	Date             string `json:"date"`
+}
+
+// SymbolInfo returns the entry as a sources.SymbolInfo.
+func (s StockInfo) SymbolInfo() sources.SymbolInfo {
+	return sources.SymbolInfo{
+		Symbol:   s.StockID,
+		Name:     s.StockName,
+		Market:   s.Type,
+		Industry: s.IndustryCategory,
+	}
 }
 
 // ParsedData represents parsed stock data in a tabular format.
@@ -33,6 +56,49 @@ type ParsedData struct {
 	Symbol  string              // Stock symbol
 	Columns []string            // Column names
 	Rows    []map[string]string // Data rows (as string maps for flexibility)
+	Meta    map[string]string   // Response metadata (e.g., "stale"); nil when there is none
+}
+
+// DateIndex returns the date column parsed as dates.
+func (p *ParsedData) DateIndex() ([]time.Time, error) {
+	if p == nil {
+		return nil, nil
+	}
+	return sources.TimeColumn(nil, p.Columns, p.Rows, "date")
+}
+
+// SelectRows keeps the rows at indices, in that order (see
+// sources.RowSelector).
+func (p *ParsedData) SelectRows(indices []int) {
+	if p == nil {
+		return
+	}
+	p.Rows = sources.SelectRows(p.Rows, indices)
+}
+
+// ColumnNames returns the value columns, excluding date and stock_id.
+func (p *ParsedData) ColumnNames() []string {
+	if p == nil {
+		return nil
+	}
+	return sources.ValueColumns(p.Columns, "date", "stock_id")
+}
+
+// Float64Column returns the named value column as float64 values, with
+// missing values as NaN.
+func (p *ParsedData) Float64Column(name string) ([]float64, error) {
+	if name == "date" {
+		return nil, sources.NoColumn(name)
+	}
+	if p == nil {
+		return nil, sources.NoColumn(name)
+	}
+	return sources.FloatColumn(nil, p.Columns, p.Rows, name)
+}
+
+// Bars returns the price columns as ohlcv.Bars (see ohlcv.Provider).
+func (p *ParsedData) Bars() (*ohlcv.Bars, error) {
+	return ohlcv.FromTimeSeries(p)
 }
 
 // ParseFinMindResponse parses the JSON response from FinMind API.
@@ -88,14 +154,14 @@ func ParseFinMindResponse(body []byte) (*ParsedData, error) {
 		row := map[string]string{
 			"date":             entry.Date,
 			"stock_id":         entry.StockID,
-			"Trading_Volume":   strconv.FormatInt(entry.TradingVolume, 10),
-			"Trading_money":    strconv.FormatInt(entry.TradingMoney, 10),
+			"Trading_Volume":   formatFloat(entry.TradingVolume),
+			"Trading_money":    formatFloat(entry.TradingMoney),
 			"open":             formatFloat(entry.Open),
 			"max":              formatFloat(entry.Max),
 			"min":              formatFloat(entry.Min),
 			"close":            formatFloat(entry.Close),
 			"spread":           formatFloat(entry.Spread),
-			"Trading_turnover": strconv.FormatInt(entry.TradingTurnover, 10),
+			"Trading_turnover": formatFloat(entry.TradingTurnover),
 		}
 		rows = append(rows, row)
 	}
@@ -107,11 +173,21 @@ func ParseFinMindResponse(body []byte) (*ParsedData, error) {
 	}, nil
 }
 
+// ParseStockInfoResponse parses a TaiwanStockInfo response from FinMind API.
+func ParseStockInfoResponse(body []byte) ([]StockInfo, error) {
+	var response struct {
+		Data []StockInfo `json:"data"`
+	}
+	if err := json.Unmarshal(body, &response); err != nil {
+		return nil, fmt.Errorf("unmarshal JSON: %w", err)
+	}
+	return response.Data, nil
+}
+
 // formatFloat converts a float64 to string, removing unnecessary decimals.
+//
+// Integral values are written without a decimal point at any magnitude;
+// converting through int64 would overflow for aggregates above 2^63.
 func formatFloat(f float64) string {
-	// Check if the float is actually an integer
-	if f == float64(int64(f)) {
-		return strconv.FormatInt(int64(f), 10)
-	}
 	return strconv.FormatFloat(f, 'f', -1, 64)
 }
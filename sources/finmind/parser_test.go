@@ -77,6 +77,42 @@ func TestParseFinMindResponse(t *testing.T) {
 	}
 }
 
+func TestParseFinMindResponse_ExtremeValues(t *testing.T) {
+	jsonData := `{
+		"data": [
+			{
+				"date": "2024-01-02",
+				"stock_id": "BTC",
+				"Trading_Volume": 1.2345e3,
+				"Trading_money": 1e20,
+				"open": 1,
+				"max": 1,
+				"min": 1,
+				"close": 1,
+				"spread": 0,
+				"Trading_turnover": 19971
+			}
+		]
+	}`
+
+	data, err := finmind.ParseFinMindResponse([]byte(jsonData))
+	if err != nil {
+		t.Fatalf("ParseFinMindResponse() error = %v", err)
+	}
+
+	row := data.Rows[0]
+	want := map[string]string{
+		"Trading_Volume":   "1234.5",
+		"Trading_money":    "100000000000000000000",
+		"Trading_turnover": "19971",
+	}
+	for col, w := range want {
+		if row[col] != w {
+			t.Errorf("%s = %q, want %q", col, row[col], w)
+		}
+	}
+}
+
 func TestParseFinMindResponse_EmptyData(t *testing.T) {
 	jsonData := `{"data": []}`
 
@@ -0,0 +1,297 @@
+// Package finra provides a FINRA OTC Transparency data source reader.
+//
+// FINRA publishes the weekly share and trade volume of every equity traded
+// off-exchange, split into trading on alternative trading systems (ATS, or
+// dark pools) and other over-the-counter trading (non-ATS). Data for NMS
+// Tier 1 symbols is published two weeks after the trading week and for
+// other symbols four weeks after; FINRA may revise a week afterwards (see
+// Record.LastUpdated).
+package finra
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"time"
+
+	internalhttp "github.com/julianshen/gonp-datareader/internal/http"
+	"github.com/julianshen/gonp-datareader/internal/utils"
+	"github.com/julianshen/gonp-datareader/sources"
+)
+
+const (
+	// weeklySummaryURL is the weekly summary dataset of the FINRA Query API
+	weeklySummaryURL = "https://api.finra.org/data/group/otcMarket/name/weeklySummary"
+
+	// PageSize is the number of records requested per call; longer
+	// results are fetched page by page.
+	PageSize = 5000
+)
+
+// FINRAReader fetches weekly OTC volume from the FINRA Query API.
+type FINRAReader struct {
+	*sources.BaseSource
+	client   *internalhttp.RetryableClient
+	baseURL  string // For testing with mock servers
+	pageSize int
+}
+
+// NewFINRAReader creates a new FINRA OTC Transparency reader.
+func NewFINRAReader(opts *internalhttp.ClientOptions) *FINRAReader {
+	return NewFINRAReaderWithBaseURL(opts, weeklySummaryURL)
+}
+
+// NewFINRAReaderWithBaseURL creates a new FINRA reader with a custom base
+// URL. This is primarily used for testing with mock servers.
+func NewFINRAReaderWithBaseURL(opts *internalhttp.ClientOptions, baseURL string) *FINRAReader {
+	if opts == nil {
+		opts = internalhttp.DefaultClientOptions()
+	}
+
+	return &FINRAReader{
+		BaseSource: sources.NewBaseSource("finra"),
+		client:     internalhttp.NewRetryableClient(opts),
+		baseURL:    baseURL,
+		pageSize:   PageSize,
+	}
+}
+
+// SetPageSize overrides the number of records requested per call; zero
+// keeps PageSize. This is primarily used for testing with mock servers.
+func (f *FINRAReader) SetPageSize(size int) {
+	if size > 0 {
+		f.pageSize = size
+	}
+}
+
+// Name returns the display name of the data source.
+func (f *FINRAReader) Name() string {
+	return "FINRA OTC Transparency"
+}
+
+// Schema returns the columns of the ParsedData returned by ReadSingle.
+func (f *FINRAReader) Schema() sources.Schema {
+	return sources.NewSchema(f.Source(), f.Name(), ParsedData{})
+}
+
+// query is a request of the FINRA Query API.
+type query struct {
+	CompareFilters   []compareFilter   `json:"compareFilters"`
+	DomainFilters    []domainFilter    `json:"domainFilters"`
+	DateRangeFilters []dateRangeFilter `json:"dateRangeFilters"`
+	Limit            int               `json:"limit"`
+	Offset           int               `json:"offset"`
+}
+
+type compareFilter struct {
+	CompareType string `json:"compareType"`
+	FieldName   string `json:"fieldName"`
+	FieldValue  string `json:"fieldValue"`
+}
+
+type domainFilter struct {
+	FieldName string   `json:"fieldName"`
+	Values    []string `json:"values"`
+}
+
+type dateRangeFilter struct {
+	FieldName string `json:"fieldName"`
+	StartDate string `json:"startDate"`
+	EndDate   string `json:"endDate"`
+}
+
+// BuildQuery constructs the request body selecting the symbol-level ATS
+// and non-ATS records of symbol for the weeks starting between start and
+// end, from record offset.
+func (f *FINRAReader) BuildQuery(symbol string, start, end time.Time, offset int) ([]byte, error) {
+	return json.Marshal(query{
+		CompareFilters: []compareFilter{
+			{CompareType: "EQUAL", FieldName: "issueSymbolIdentifier", FieldValue: symbol},
+		},
+		DomainFilters: []domainFilter{
+			{FieldName: "summaryTypeCode", Values: []string{"ATS_W_SMBL", "OTC_W_SMBL"}},
+		},
+		DateRangeFilters: []dateRangeFilter{
+			{FieldName: "weekStartDate", StartDate: start.Format("2006-01-02"), EndDate: end.Format("2006-01-02")},
+		},
+		Limit:  f.pageSize,
+		Offset: offset,
+	})
+}
+
+// ReadSingle fetches the weekly ATS and non-ATS volume of a symbol for the
+// weeks starting between start and end. It returns a *ParsedData with one
+// row per week; ParsedData.Records holds the records as published.
+func (f *FINRAReader) ReadSingle(ctx context.Context, symbol string, start, end time.Time) (interface{}, error) {
+	return f.Finish(ctx, symbol, start, end, f.readSingle)
+}
+
+// readSingle implements ReadSingle before Finish is applied.
+func (f *FINRAReader) readSingle(ctx context.Context, symbol string, start, end time.Time) (interface{}, error) {
+	// Normalize user input; the original is kept in Meta["symbol_input"]
+	input := symbol
+	symbol = f.NormalizeSymbol(symbol)
+
+	// Validate inputs
+	if err := f.ValidateSymbol(symbol); err != nil {
+		return nil, fmt.Errorf("invalid symbol: %w", err)
+	}
+
+	if err := utils.ValidateDateRange(start, end); err != nil {
+		return nil, fmt.Errorf("invalid date range: %w", err)
+	}
+
+	var records []Record
+	for offset := 0; ; offset += f.pageSize {
+		page, n, err := f.fetchPage(ctx, symbol, start, end, offset)
+		if err != nil {
+			return nil, err
+		}
+		records = append(records, page...)
+		if n < f.pageSize {
+			break
+		}
+	}
+	if len(records) == 0 {
+		return nil, fmt.Errorf("%w: no weekly OTC volume of %s", sources.ErrNoData, symbol)
+	}
+
+	return &ParsedData{
+		Symbol:  symbol,
+		Name:    records[0].IssueName,
+		Weeks:   weeks(records),
+		Records: records,
+		Meta:    sources.InputMeta(nil, input, symbol),
+	}, nil
+}
+
+// fetchPage fetches the records of symbol from offset and returns them
+// with the number of records on the page.
+func (f *FINRAReader) fetchPage(ctx context.Context, symbol string, start, end time.Time, offset int) ([]Record, int, error) {
+	body, err := f.BuildQuery(symbol, start, end, offset)
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to build query: %w", err)
+	}
+
+	// Create HTTP request
+	req, err := http.NewRequestWithContext(internalhttp.WithContentType(ctx, "application/json"), "POST", f.baseURL, bytes.NewReader(body))
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to create request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Accept", "application/json")
+
+	// Execute request
+	resp, err := f.client.Do(req)
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to fetch data: %w", err)
+	}
+	defer resp.Body.Close()
+
+	// FINRA answers queries without records with 204 No Content
+	if resp.StatusCode == http.StatusNoContent {
+		return nil, 0, nil
+	}
+
+	respBody, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to read response: %w", err)
+	}
+
+	// Check status code
+	if resp.StatusCode != http.StatusOK {
+		return nil, 0, internalhttp.StatusError(resp, fmt.Errorf("FINRA returned status %d: %s", resp.StatusCode, string(respBody)))
+	}
+
+	records, n, err := parsePage(respBody)
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to parse response: %w", err)
+	}
+	return records, n, nil
+}
+
+// Read fetches the weekly OTC volume of multiple symbols.
+// Symbols are fetched in parallel for better performance.
+func (f *FINRAReader) Read(ctx context.Context, symbols []string, start, end time.Time) (interface{}, error) {
+	// Validate inputs
+	if err := utils.ValidateSymbols(f.NormalizeSymbols(symbols)); err != nil {
+		return nil, fmt.Errorf("invalid symbols: %w", err)
+	}
+
+	if err := utils.ValidateDateRange(start, end); err != nil {
+		return nil, fmt.Errorf("invalid date range: %w", err)
+	}
+
+	// Use parallel fetching for multiple symbols
+	return f.readParallel(ctx, symbols, start, end)
+}
+
+// readParallel fetches multiple symbols in parallel using a worker pool.
+func (f *FINRAReader) readParallel(ctx context.Context, symbols []string, start, end time.Time) (map[string]*ParsedData, error) {
+	type result struct {
+		symbol string
+		data   *ParsedData
+		err    error
+	}
+
+	// Create channels for work distribution and results
+	results := make(chan result, len(symbols))
+
+	// Create worker pool - limit concurrency to avoid overwhelming the server
+	maxWorkers := 5
+	if len(symbols) < maxWorkers {
+		maxWorkers = len(symbols)
+	}
+
+	// Use a semaphore pattern to limit concurrent workers
+	semaphore := make(chan struct{}, maxWorkers)
+
+	// Launch goroutines for each symbol
+	for _, symbol := range symbols {
+		sym := symbol
+
+		go func() {
+			// Acquire semaphore
+			semaphore <- struct{}{}
+			defer func() { <-semaphore }()
+
+			// Fetch data
+			data, err := f.ReadSingle(ctx, sym, start, end)
+
+			// Send result
+			res := result{symbol: sym, err: err}
+			if err == nil {
+				if parsedData, ok := data.(*ParsedData); ok {
+					res.data = parsedData
+				}
+			}
+			results <- res
+		}()
+	}
+
+	// Collect results
+	dataMap := make(map[string]*ParsedData, len(symbols))
+	for i := 0; i < len(symbols); i++ {
+		res := <-results
+		if res.err != nil {
+			return nil, fmt.Errorf("failed to read %s: %w", res.symbol, res.err)
+		}
+		dataMap[res.symbol] = res.data
+	}
+
+	return dataMap, nil
+}
+
+// Shutdown stops accepting requests and waits for in-flight requests to
+// finish, cancelling them if ctx expires first.
+func (f *FINRAReader) Shutdown(ctx context.Context) error {
+	return f.client.Shutdown(ctx)
+}
+
+// Close cancels in-flight requests and releases the reader's connections.
+func (f *FINRAReader) Close() error {
+	return f.client.Close()
+}
@@ -0,0 +1,208 @@
+package finra_test
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/julianshen/gonp-datareader/sources"
+	"github.com/julianshen/gonp-datareader/sources/finra"
+)
+
+var (
+	testStart = time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	testEnd   = time.Date(2024, 1, 31, 0, 0, 0, 0, time.UTC)
+)
+
+func TestNewFINRAReader(t *testing.T) {
+	reader := finra.NewFINRAReader(nil)
+
+	if reader.Name() != "FINRA OTC Transparency" {
+		t.Errorf("Name() = %q, want %q", reader.Name(), "FINRA OTC Transparency")
+	}
+	if reader.Source() != "finra" {
+		t.Errorf("Source() = %q, want %q", reader.Source(), "finra")
+	}
+
+	schema := reader.Schema()
+	var names []string
+	for _, c := range schema.Columns {
+		names = append(names, c.Name)
+	}
+	if got := strings.Join(names, ","); got != "Date,ATS Shares,ATS Trades,Non-ATS Shares,Non-ATS Trades" {
+		t.Errorf("Schema() columns = %s", got)
+	}
+}
+
+func TestFINRAReader_BuildQuery(t *testing.T) {
+	reader := finra.NewFINRAReader(nil)
+
+	body, err := reader.BuildQuery("AAPL", testStart, testEnd, 5000)
+	if err != nil {
+		t.Fatalf("BuildQuery() error = %v", err)
+	}
+
+	for _, want := range []string{
+		`{"compareType":"EQUAL","fieldName":"issueSymbolIdentifier","fieldValue":"AAPL"}`,
+		`{"fieldName":"summaryTypeCode","values":["ATS_W_SMBL","OTC_W_SMBL"]}`,
+		`{"fieldName":"weekStartDate","startDate":"2024-01-01","endDate":"2024-01-31"}`,
+		`"limit":5000`,
+		`"offset":5000`,
+	} {
+		if !strings.Contains(string(body), want) {
+			t.Errorf("BuildQuery() = %s, want it to contain %s", body, want)
+		}
+	}
+}
+
+func TestFINRAReader_ReadSingle(t *testing.T) {
+	var method, contentType string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		method, contentType = r.Method, r.Header.Get("Content-Type")
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(samplePage))
+	}))
+	defer server.Close()
+
+	reader := finra.NewFINRAReaderWithBaseURL(nil, server.URL)
+	result, err := reader.ReadSingle(context.Background(), " aapl", testStart, testEnd)
+	if err != nil {
+		t.Fatalf("ReadSingle() error = %v", err)
+	}
+	if method != "POST" || contentType != "application/json" {
+		t.Errorf("request = %s %s, want a JSON POST", method, contentType)
+	}
+
+	data, ok := result.(*finra.ParsedData)
+	if !ok {
+		t.Fatalf("ReadSingle() returned %T, want *finra.ParsedData", result)
+	}
+	if data.Symbol != "AAPL" || data.Name != "Apple Inc. Common Stock" || data.Meta["symbol_input"] != " aapl" {
+		t.Errorf("data = %s %q %v", data.Symbol, data.Name, data.Meta)
+	}
+	if len(data.Records) != 3 {
+		t.Errorf("len(Records) = %d, want 3", len(data.Records))
+	}
+
+	// Weeks are in ascending order with ATS and non-ATS volume side by side
+	want := []finra.WeeklyVolume{
+		{WeekStart: time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC), ATSShares: 20000000, ATSTrades: 120000},
+		{WeekStart: time.Date(2024, 1, 8, 0, 0, 0, 0, time.UTC), ATSShares: 25000000, ATSTrades: 150000, NonATSShares: 60000000, NonATSTrades: 900000},
+	}
+	if len(data.Weeks) != len(want) {
+		t.Fatalf("Weeks = %+v, want %+v", data.Weeks, want)
+	}
+	for i := range want {
+		if data.Weeks[i] != want[i] {
+			t.Errorf("Weeks[%d] = %+v, want %+v", i, data.Weeks[i], want[i])
+		}
+	}
+}
+
+func TestFINRAReader_ReadSingle_Paging(t *testing.T) {
+	var offsets []int
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var q struct {
+			Offset int `json:"offset"`
+		}
+		json.NewDecoder(r.Body).Decode(&q)
+		offsets = append(offsets, q.Offset)
+
+		// Two full pages of two records, then a partial page
+		week := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC).AddDate(0, 0, 7*q.Offset)
+		var records []string
+		n := 2
+		if q.Offset >= 4 {
+			n = 1
+		}
+		for i := 0; i < n; i++ {
+			records = append(records, fmt.Sprintf(`{"issueSymbolIdentifier":"AAPL","weekStartDate":%q,"summaryTypeCode":"ATS_W_SMBL","totalWeeklyShareQuantity":1}`,
+				week.AddDate(0, 0, 7*i).Format("2006-01-02")))
+		}
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte("[" + strings.Join(records, ",") + "]"))
+	}))
+	defer server.Close()
+
+	reader := finra.NewFINRAReaderWithBaseURL(nil, server.URL)
+	reader.SetPageSize(2)
+	result, err := reader.ReadSingle(context.Background(), "AAPL", testStart, testEnd.AddDate(1, 0, 0))
+	if err != nil {
+		t.Fatalf("ReadSingle() error = %v", err)
+	}
+
+	if fmt.Sprint(offsets) != "[0 2 4]" {
+		t.Errorf("offsets = %v, want [0 2 4]", offsets)
+	}
+	if data := result.(*finra.ParsedData); len(data.Records) != 5 || len(data.Weeks) != 5 {
+		t.Errorf("got %d records and %d weeks, want 5 of each", len(data.Records), len(data.Weeks))
+	}
+}
+
+func TestFINRAReader_ReadSingle_Errors(t *testing.T) {
+	tests := []struct {
+		name    string
+		status  int
+		body    string
+		wantErr error
+	}{
+		{"no content", http.StatusNoContent, "", sources.ErrNoData},
+		{"empty array", http.StatusOK, "[]", sources.ErrNoData},
+		{"bad request", http.StatusBadRequest, `{"message":"invalid filter"}`, nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				w.Header().Set("Content-Type", "application/json")
+				w.WriteHeader(tt.status)
+				w.Write([]byte(tt.body))
+			}))
+			defer server.Close()
+
+			reader := finra.NewFINRAReaderWithBaseURL(nil, server.URL)
+			_, err := reader.ReadSingle(context.Background(), "AAPL", testStart, testEnd)
+			if err == nil {
+				t.Fatal("ReadSingle() expected error")
+			}
+			if tt.wantErr != nil && !errors.Is(err, tt.wantErr) {
+				t.Errorf("ReadSingle() error = %v, want %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestFINRAReader_Read(t *testing.T) {
+	var requests atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests.Add(1)
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(samplePage))
+	}))
+	defer server.Close()
+
+	reader := finra.NewFINRAReaderWithBaseURL(nil, server.URL)
+	result, err := reader.Read(context.Background(), []string{"AAPL", "MSFT"}, testStart, testEnd)
+	if err != nil {
+		t.Fatalf("Read() error = %v", err)
+	}
+
+	data, ok := result.(map[string]*finra.ParsedData)
+	if !ok || len(data) != 2 {
+		t.Fatalf("Read() = %T with %d symbols, want map of 2", result, len(data))
+	}
+	if requests.Load() != 2 {
+		t.Errorf("requests = %d, want 2", requests.Load())
+	}
+
+	if _, err := reader.Read(context.Background(), []string{"AAPL"}, testEnd, testStart); err == nil {
+		t.Error("Read() with end before start expected error")
+	}
+}
@@ -0,0 +1,222 @@
+package finra
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/julianshen/gonp-datareader/sources"
+)
+
+// Venue is the kind of off-exchange trading a record covers.
+type Venue string
+
+const (
+	// VenueATS is trading on alternative trading systems (dark pools).
+	VenueATS Venue = "ATS"
+	// VenueNonATS is other over-the-counter trading, such as internalized
+	// retail orders.
+	VenueNonATS Venue = "Non-ATS"
+)
+
+// summaryTypes maps FINRA summary type codes of symbol-level records to
+// venues.
+var summaryTypes = map[string]Venue{
+	"ATS_W_SMBL": VenueATS,
+	"OTC_W_SMBL": VenueNonATS,
+}
+
+// Record is one weekly volume record as published by FINRA: the shares
+// and trades of one symbol on one venue for the week starting WeekStart.
+type Record struct {
+	Symbol    string
+	IssueName string
+	// WeekStart is the Monday of the reporting week.
+	WeekStart time.Time
+	// Tier is the tier of the symbol: "T1" (NMS Tier 1), "T2" (NMS Tier 2)
+	// or "OTCE" (OTC equities).
+	Tier   string
+	Venue  Venue
+	Shares int64
+	Trades int64
+	// LastUpdated is the date FINRA last revised the record.
+	LastUpdated time.Time
+}
+
+// WeeklyVolume is the off-exchange volume of a symbol in one week.
+type WeeklyVolume struct {
+	WeekStart    time.Time `schema:"Date"`
+	ATSShares    int64     `schema:"ATS Shares"`
+	ATSTrades    int64     `schema:"ATS Trades"`
+	NonATSShares int64     `schema:"Non-ATS Shares"`
+	NonATSTrades int64     `schema:"Non-ATS Trades"`
+}
+
+// ParsedData holds the weekly OTC volume of a symbol.
+type ParsedData struct {
+	Symbol string `schema:"-"`
+	// Name is the issue name FINRA reports (e.g., "Apple Inc. Common Stock").
+	Name string `schema:"-"`
+	// Weeks holds one row per week, in ascending order, with ATS and
+	// non-ATS volume side by side.
+	Weeks []WeeklyVolume
+	// Records holds the records Weeks was built from, as published.
+	Records []Record `schema:"-"`
+	// Meta holds response metadata such as "symbol_input"; nil when there
+	// is none.
+	Meta map[string]string `schema:"-"`
+}
+
+// DateIndex returns the start date of each week.
+func (p *ParsedData) DateIndex() ([]time.Time, error) {
+	if p == nil {
+		return nil, nil
+	}
+	dates := make([]time.Time, len(p.Weeks))
+	for i, w := range p.Weeks {
+		dates[i] = w.WeekStart
+	}
+	return dates, nil
+}
+
+// SelectRows keeps the rows at indices, in that order (see
+// sources.RowSelector).
+func (p *ParsedData) SelectRows(indices []int) {
+	if p == nil {
+		return
+	}
+	p.Weeks = sources.SelectRows(p.Weeks, indices)
+}
+
+// ColumnNames returns the volume columns: "ATS Shares", "ATS Trades",
+// "Non-ATS Shares" and "Non-ATS Trades".
+func (p *ParsedData) ColumnNames() []string {
+	return []string{"ATS Shares", "ATS Trades", "Non-ATS Shares", "Non-ATS Trades"}
+}
+
+// Float64Column returns the named volume column.
+func (p *ParsedData) Float64Column(name string) ([]float64, error) {
+	var field func(WeeklyVolume) int64
+	switch name {
+	case "ATS Shares":
+		field = func(w WeeklyVolume) int64 { return w.ATSShares }
+	case "ATS Trades":
+		field = func(w WeeklyVolume) int64 { return w.ATSTrades }
+	case "Non-ATS Shares":
+		field = func(w WeeklyVolume) int64 { return w.NonATSShares }
+	case "Non-ATS Trades":
+		field = func(w WeeklyVolume) int64 { return w.NonATSTrades }
+	}
+	if p == nil || field == nil {
+		return nil, sources.NoColumn(name)
+	}
+
+	values := make([]float64, len(p.Weeks))
+	for i, w := range p.Weeks {
+		values[i] = float64(field(w))
+	}
+	return values, nil
+}
+
+// weeklySummary is a record of FINRA's weeklySummary dataset.
+type weeklySummary struct {
+	IssueSymbolIdentifier    string `json:"issueSymbolIdentifier"`
+	IssueName                string `json:"issueName"`
+	WeekStartDate            string `json:"weekStartDate"`
+	TierIdentifier           string `json:"tierIdentifier"`
+	SummaryTypeCode          string `json:"summaryTypeCode"`
+	TotalWeeklyShareQuantity int64  `json:"totalWeeklyShareQuantity"`
+	TotalWeeklyTradeCount    int64  `json:"totalWeeklyTradeCount"`
+	LastUpdateDate           string `json:"lastUpdateDate"`
+}
+
+// ParseRecords parses a page of the weeklySummary dataset. Records other
+// than the symbol-level ATS and non-ATS summaries, such as per-firm
+// breakdowns, are skipped.
+func ParseRecords(body []byte) ([]Record, error) {
+	records, _, err := parsePage(body)
+	return records, err
+}
+
+// parsePage parses a page of the weeklySummary dataset and returns its
+// symbol-level records and the number of records on the page.
+func parsePage(body []byte) ([]Record, int, error) {
+	var response []weeklySummary
+	if err := json.Unmarshal(body, &response); err != nil {
+		return nil, 0, fmt.Errorf("unmarshal JSON: %w", err)
+	}
+
+	records := make([]Record, 0, len(response))
+	for i, r := range response {
+		venue, ok := summaryTypes[r.SummaryTypeCode]
+		if !ok {
+			continue
+		}
+		week, err := time.Parse("2006-01-02", r.WeekStartDate)
+		if err != nil {
+			return nil, 0, fmt.Errorf("record %d: invalid week start %q: %w", i, r.WeekStartDate, err)
+		}
+		record := Record{
+			Symbol:    r.IssueSymbolIdentifier,
+			IssueName: r.IssueName,
+			WeekStart: week,
+			Tier:      r.TierIdentifier,
+			Venue:     venue,
+			Shares:    r.TotalWeeklyShareQuantity,
+			Trades:    r.TotalWeeklyTradeCount,
+		}
+		if t, err := time.Parse("2006-01-02", r.LastUpdateDate); err == nil {
+			record.LastUpdated = t
+		}
+		records = append(records, record)
+	}
+	return records, len(response), nil
+}
+
+// ParseResponse parses a page of the weeklySummary dataset holding the
+// records of a single symbol into ParsedData, as ReadSingle returns it.
+func ParseResponse(body []byte) (*ParsedData, error) {
+	records, err := ParseRecords(body)
+	if err != nil {
+		return nil, err
+	}
+	if len(records) == 0 {
+		return nil, fmt.Errorf("%w: no weekly OTC volume records", sources.ErrNoData)
+	}
+	return &ParsedData{
+		Symbol:  records[0].Symbol,
+		Name:    records[0].IssueName,
+		Weeks:   weeks(records),
+		Records: records,
+	}, nil
+}
+
+// weeks sums records by week into rows with ATS and non-ATS volume side
+// by side, in ascending order. A symbol that changed tiers mid-week has a
+// record per tier.
+func weeks(records []Record) []WeeklyVolume {
+	byWeek := make(map[time.Time]*WeeklyVolume)
+	for _, r := range records {
+		w, ok := byWeek[r.WeekStart]
+		if !ok {
+			w = &WeeklyVolume{WeekStart: r.WeekStart}
+			byWeek[r.WeekStart] = w
+		}
+		switch r.Venue {
+		case VenueATS:
+			w.ATSShares += r.Shares
+			w.ATSTrades += r.Trades
+		case VenueNonATS:
+			w.NonATSShares += r.Shares
+			w.NonATSTrades += r.Trades
+		}
+	}
+
+	out := make([]WeeklyVolume, 0, len(byWeek))
+	for _, w := range byWeek {
+		out = append(out, *w)
+	}
+	sort.Slice(out, func(i, j int) bool { return out[i].WeekStart.Before(out[j].WeekStart) })
+	return out
+}
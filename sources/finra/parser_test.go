@@ -0,0 +1,98 @@
+package finra_test
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/julianshen/gonp-datareader/sources"
+	"github.com/julianshen/gonp-datareader/sources/finra"
+)
+
+const samplePage = `[
+	{"issueSymbolIdentifier":"AAPL","issueName":"Apple Inc. Common Stock","weekStartDate":"2024-01-08","tierIdentifier":"T1","summaryTypeCode":"ATS_W_SMBL","totalWeeklyShareQuantity":25000000,"totalWeeklyTradeCount":150000,"lastUpdateDate":"2024-01-22"},
+	{"issueSymbolIdentifier":"AAPL","issueName":"Apple Inc. Common Stock","weekStartDate":"2024-01-08","tierIdentifier":"T1","summaryTypeCode":"OTC_W_SMBL","totalWeeklyShareQuantity":60000000,"totalWeeklyTradeCount":900000,"lastUpdateDate":"2024-01-22"},
+	{"issueSymbolIdentifier":"AAPL","issueName":"Apple Inc. Common Stock","weekStartDate":"2024-01-08","tierIdentifier":"T1","summaryTypeCode":"ATS_W_FIRM","totalWeeklyShareQuantity":1000,"totalWeeklyTradeCount":10,"lastUpdateDate":"2024-01-22"},
+	{"issueSymbolIdentifier":"AAPL","issueName":"Apple Inc. Common Stock","weekStartDate":"2024-01-01","tierIdentifier":"T1","summaryTypeCode":"ATS_W_SMBL","totalWeeklyShareQuantity":20000000,"totalWeeklyTradeCount":120000,"lastUpdateDate":"2024-01-15"}
+]`
+
+func TestParseRecords(t *testing.T) {
+	records, err := finra.ParseRecords([]byte(samplePage))
+	if err != nil {
+		t.Fatalf("ParseRecords() error = %v", err)
+	}
+
+	// The per-firm record is skipped
+	if len(records) != 3 {
+		t.Fatalf("ParseRecords() returned %d records, want 3", len(records))
+	}
+
+	want := finra.Record{
+		Symbol:      "AAPL",
+		IssueName:   "Apple Inc. Common Stock",
+		WeekStart:   time.Date(2024, 1, 8, 0, 0, 0, 0, time.UTC),
+		Tier:        "T1",
+		Venue:       finra.VenueNonATS,
+		Shares:      60000000,
+		Trades:      900000,
+		LastUpdated: time.Date(2024, 1, 22, 0, 0, 0, 0, time.UTC),
+	}
+	if records[1] != want {
+		t.Errorf("records[1] = %+v, want %+v", records[1], want)
+	}
+	if records[0].Venue != finra.VenueATS {
+		t.Errorf("records[0].Venue = %q, want %q", records[0].Venue, finra.VenueATS)
+	}
+}
+
+func TestParseResponse(t *testing.T) {
+	data, err := finra.ParseResponse([]byte(samplePage))
+	if err != nil {
+		t.Fatalf("ParseResponse() error = %v", err)
+	}
+	if data.Symbol != "AAPL" || data.Name != "Apple Inc. Common Stock" || len(data.Weeks) != 2 {
+		t.Errorf("ParseResponse() = %s (%s) with %d weeks", data.Symbol, data.Name, len(data.Weeks))
+	}
+
+	if _, err := finra.ParseResponse([]byte(`[]`)); !errors.Is(err, sources.ErrNoData) {
+		t.Errorf("ParseResponse([]) error = %v, want ErrNoData", err)
+	}
+}
+
+func TestParseRecords_Invalid(t *testing.T) {
+	tests := []struct {
+		name string
+		body string
+	}{
+		{"not JSON", `<html>`},
+		{"not an array", `{"error":"bad request"}`},
+		{"bad week start", `[{"issueSymbolIdentifier":"AAPL","weekStartDate":"01/08/2024","summaryTypeCode":"ATS_W_SMBL"}]`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := finra.ParseRecords([]byte(tt.body)); err == nil {
+				t.Error("ParseRecords() expected error")
+			}
+		})
+	}
+}
+
+func TestParsedData_Float64Column(t *testing.T) {
+	data := &finra.ParsedData{Weeks: []finra.WeeklyVolume{
+		{WeekStart: time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC), ATSShares: 10, NonATSTrades: 3},
+		{WeekStart: time.Date(2024, 1, 8, 0, 0, 0, 0, time.UTC), ATSShares: 20, NonATSTrades: 4},
+	}}
+
+	values, err := data.Float64Column("ATS Shares")
+	if err != nil || len(values) != 2 || values[0] != 10 || values[1] != 20 {
+		t.Errorf("Float64Column(ATS Shares) = %v, %v, want [10 20]", values, err)
+	}
+	values, err = data.Float64Column("Non-ATS Trades")
+	if err != nil || len(values) != 2 || values[0] != 3 || values[1] != 4 {
+		t.Errorf("Float64Column(Non-ATS Trades) = %v, %v, want [3 4]", values, err)
+	}
+	if _, err := data.Float64Column("Close"); !errors.Is(err, sources.ErrNoColumn) {
+		t.Errorf("Float64Column(Close) error = %v, want ErrNoColumn", err)
+	}
+}
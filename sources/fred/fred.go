@@ -2,10 +2,13 @@
 package fred
 
 import (
+	"bytes"
 	"context"
+	"encoding/json"
 	"fmt"
 	"io"
 	"net/http"
+	"strings"
 	"time"
 
 	internalhttp "github.com/julianshen/gonp-datareader/internal/http"
@@ -16,6 +19,10 @@ import (
 const (
 	// fredAPIURL is the base URL for FRED API
 	fredAPIURL = "https://api.stlouisfed.org/fred/series/observations"
+
+	// alfredRealtimeStart and alfredRealtimeEnd span every vintage ALFRED holds
+	alfredRealtimeStart = "1776-07-04"
+	alfredRealtimeEnd   = "9999-12-31"
 )
 
 // FREDReader fetches data from FRED (Federal Reserve Economic Data).
@@ -24,6 +31,11 @@ type FREDReader struct {
 	client  *internalhttp.RetryableClient
 	apiKey  string
 	baseURL string // For testing with mock servers
+	merge   bool
+	// seriesURL is the series endpoint used by ReadSeriesInfo
+	seriesURL string
+	// frequencyMeta enables native frequency entries in ParsedData.Meta
+	frequencyMeta bool
 }
 
 // NewFREDReader creates a new FRED data reader.
@@ -38,10 +50,17 @@ func NewFREDReaderWithBaseURL(opts *internalhttp.ClientOptions, baseURL string)
 		opts = internalhttp.DefaultClientOptions()
 	}
 
+	// The series endpoint is the observations endpoint's parent
+	seriesURL := fredSeriesURL
+	if trimmed := strings.TrimSuffix(baseURL, "/observations"); trimmed != baseURL {
+		seriesURL = trimmed
+	}
+
 	return &FREDReader{
 		BaseSource: sources.NewBaseSource("fred"),
 		client:     internalhttp.NewRetryableClient(opts),
 		baseURL:    baseURL,
+		seriesURL:  seriesURL,
 	}
 }
 
@@ -57,6 +76,12 @@ func (f *FREDReader) SetAPIKey(apiKey string) {
 	f.apiKey = apiKey
 }
 
+// SetMergeSeries makes Read return a *MultiSeries with every series
+// aligned on a common date index instead of a map of per-series results.
+func (f *FREDReader) SetMergeSeries(merge bool) {
+	f.merge = merge
+}
+
 // GetAPIKey returns the currently configured API key.
 func (f *FREDReader) GetAPIKey() string {
 	return f.apiKey
@@ -67,6 +92,11 @@ func (f *FREDReader) Name() string {
 	return "FRED"
 }
 
+// Schema returns the columns of the ParsedData returned by ReadSingle.
+func (f *FREDReader) Schema() sources.Schema {
+	return sources.NewSchema(f.Source(), f.Name(), ParsedData{})
+}
+
 // BuildURL constructs the FRED API URL for the given series and date range.
 func (f *FREDReader) BuildURL(seriesID string, start, end time.Time, apiKey string) string {
 	// Format dates as YYYY-MM-DD
@@ -87,6 +117,15 @@ func (f *FREDReader) BuildURL(seriesID string, start, end time.Time, apiKey stri
 
 // ReadSingle fetches data for a single series from FRED.
 func (f *FREDReader) ReadSingle(ctx context.Context, symbol string, start, end time.Time) (interface{}, error) {
+	return f.Finish(ctx, symbol, start, end, f.readSingle)
+}
+
+// readSingle implements ReadSingle before Finish is applied.
+func (f *FREDReader) readSingle(ctx context.Context, symbol string, start, end time.Time) (interface{}, error) {
+	// Normalize user input; the original is kept in Meta["symbol_input"]
+	input := symbol
+	symbol = f.NormalizeSymbol(symbol)
+
 	// Validate inputs
 	if err := f.ValidateSymbol(symbol); err != nil {
 		return nil, fmt.Errorf("invalid symbol: %w", err)
@@ -97,15 +136,64 @@ func (f *FREDReader) ReadSingle(ctx context.Context, symbol string, start, end t
 	}
 
 	// Check API key
-	if f.apiKey == "" {
+	apiKey := internalhttp.APIKey(ctx, f.apiKey)
+	if apiKey == "" {
 		return nil, fmt.Errorf("FRED API key is required")
 	}
 
-	// Build URL
-	url := f.BuildURL(symbol, start, end, f.apiKey)
+	data, err := f.fetch(ctx, f.BuildURL(symbol, start, end, apiKey))
+	if err != nil {
+		return nil, err
+	}
+	data.Meta = sources.InputMeta(data.Meta, input, symbol)
+
+	if f.frequencyMeta {
+		if err := f.annotateFrequency(ctx, symbol, data); err != nil {
+			return nil, err
+		}
+	}
+	return data, nil
+}
+
+// ReadVintages fetches every vintage of a series from ALFRED (ArchivaL FRED).
+//
+// Unlike ReadSingle, which returns the values as currently revised, the
+// result contains one row per observation per revision. RealtimeStart holds
+// the date each value was published, which allows point-in-time analysis
+// without look-ahead bias. Use datareader.ToVintageSeries and
+// dataset.AsOfJoin to align the values to the dates they became known.
+func (f *FREDReader) ReadVintages(ctx context.Context, symbol string, start, end time.Time) (*ParsedData, error) {
+	symbol = f.NormalizeSymbol(symbol)
+
+	// Validate inputs
+	if err := f.ValidateSymbol(symbol); err != nil {
+		return nil, fmt.Errorf("invalid symbol: %w", err)
+	}
+
+	if err := utils.ValidateDateRange(start, end); err != nil {
+		return nil, fmt.Errorf("invalid date range: %w", err)
+	}
+
+	// Check API key
+	apiKey := internalhttp.APIKey(ctx, f.apiKey)
+	if apiKey == "" {
+		return nil, fmt.Errorf("FRED API key is required")
+	}
+
+	return f.fetch(ctx, f.BuildVintageURL(symbol, start, end, apiKey))
+}
+
+// BuildVintageURL constructs the FRED API URL requesting all vintages of
+// the given series, using the full ALFRED real-time period.
+func (f *FREDReader) BuildVintageURL(seriesID string, start, end time.Time, apiKey string) string {
+	return f.BuildURL(seriesID, start, end, apiKey) +
+		"&realtime_start=" + alfredRealtimeStart + "&realtime_end=" + alfredRealtimeEnd
+}
 
+// fetch requests url and parses the FRED observations response.
+func (f *FREDReader) fetch(ctx context.Context, url string) (*ParsedData, error) {
 	// Create HTTP request
-	req, err := http.NewRequestWithContext(ctx, "GET", url, nil)
+	req, err := http.NewRequestWithContext(internalhttp.WithContentType(ctx, "application/json"), "GET", url, nil)
 	if err != nil {
 		return nil, fmt.Errorf("failed to create request: %w", err)
 	}
@@ -123,22 +211,40 @@ func (f *FREDReader) ReadSingle(ctx context.Context, symbol string, start, end t
 		if err != nil {
 			return nil, fmt.Errorf("FRED API returned status %d (failed to read response body: %w)", resp.StatusCode, err)
 		}
-		return nil, fmt.Errorf("FRED API returned status %d: %s", resp.StatusCode, string(body))
+		return nil, statusError(resp, body)
+	}
+
+	// Read response body
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read response: %w", err)
 	}
 
 	// Parse JSON response
-	data, err := ParseJSON(resp.Body)
+	decoded, err := f.client.Decode("fred", body, func(b []byte) (interface{}, error) {
+		return ParseJSON(bytes.NewReader(b))
+	})
 	if err != nil {
 		return nil, fmt.Errorf("failed to parse JSON: %w", err)
 	}
 
-	return data, nil
+	// Copy the decoded value, which may be shared through the decoded cache,
+	// and flag data served from an expired cache entry
+	data := *decoded.(*ParsedData)
+	data.Meta = internalhttp.StaleMeta(resp)
+
+	return &data, nil
 }
 
 // Read fetches data for multiple series from FRED.
+//
+// FRED serves one series per request, so series are fetched in parallel
+// over the reader's shared connections (multiplexed over HTTP/2). The
+// result is a map[string]*ParsedData keyed by series ID, or a *MultiSeries
+// when SetMergeSeries is enabled.
 func (f *FREDReader) Read(ctx context.Context, symbols []string, start, end time.Time) (interface{}, error) {
 	// Validate inputs
-	if err := utils.ValidateSymbols(symbols); err != nil {
+	if err := utils.ValidateSymbols(f.NormalizeSymbols(symbols)); err != nil {
 		return nil, fmt.Errorf("invalid symbols: %w", err)
 	}
 
@@ -147,22 +253,93 @@ func (f *FREDReader) Read(ctx context.Context, symbols []string, start, end time
 	}
 
 	// Check API key
-	if f.apiKey == "" {
+	if internalhttp.APIKey(ctx, f.apiKey) == "" {
 		return nil, fmt.Errorf("FRED API key is required")
 	}
 
-	// Fetch data for each series
-	results := make(map[string]*ParsedData)
+	results, err := f.readParallel(ctx, symbols, start, end)
+	if err != nil {
+		return nil, err
+	}
+	if f.merge {
+		return Merge(symbols, results), nil
+	}
+	return results, nil
+}
+
+// readParallel fetches multiple series in parallel using a worker pool.
+func (f *FREDReader) readParallel(ctx context.Context, symbols []string, start, end time.Time) (map[string]*ParsedData, error) {
+	type result struct {
+		symbol string
+		data   *ParsedData
+		err    error
+	}
+
+	// Create channels for work distribution and results
+	results := make(chan result, len(symbols))
+
+	// Create worker pool - limit concurrency to avoid overwhelming the server
+	maxWorkers := 10
+	if len(symbols) < maxWorkers {
+		maxWorkers = len(symbols)
+	}
+
+	// Use a semaphore pattern to limit concurrent workers
+	semaphore := make(chan struct{}, maxWorkers)
+
+	// Launch goroutines for each series
 	for _, symbol := range symbols {
-		data, err := f.ReadSingle(ctx, symbol, start, end)
-		if err != nil {
-			return nil, fmt.Errorf("failed to read %s: %w", symbol, err)
-		}
+		sym := symbol
+
+		go func() {
+			// Acquire semaphore
+			semaphore <- struct{}{}
+			defer func() { <-semaphore }()
+
+			// Fetch data
+			data, err := f.ReadSingle(ctx, sym, start, end)
+
+			// Send result
+			res := result{symbol: sym, err: err}
+			if err == nil {
+				if parsedData, ok := data.(*ParsedData); ok {
+					res.data = parsedData
+				}
+			}
+			results <- res
+		}()
+	}
 
-		if parsedData, ok := data.(*ParsedData); ok {
-			results[symbol] = parsedData
+	// Collect results
+	dataMap := make(map[string]*ParsedData, len(symbols))
+	for i := 0; i < len(symbols); i++ {
+		res := <-results
+		if res.err != nil {
+			return nil, fmt.Errorf("failed to read %s: %w", res.symbol, res.err)
 		}
+		dataMap[res.symbol] = res.data
 	}
 
-	return results, nil
+	return dataMap, nil
+}
+
+// Shutdown stops accepting requests and waits for in-flight requests to
+// finish, cancelling them if ctx expires first.
+func (f *FREDReader) Shutdown(ctx context.Context) error {
+	return f.client.Shutdown(ctx)
+}
+
+// Close cancels in-flight requests and releases the reader's connections.
+func (f *FREDReader) Close() error {
+	return f.client.Close()
+}
+
+// statusError returns the error for a non-200 response, classified by the
+// error message FRED sends with 400 responses.
+func statusError(resp *http.Response, body []byte) error {
+	var fredErr fredResponse
+	if err := json.Unmarshal(body, &fredErr); err == nil && fredErr.ErrorMessage != "" {
+		return internalhttp.StatusError(resp, fmt.Errorf("FRED API returned status %d: %w", resp.StatusCode, apiError(fredErr.ErrorMessage)))
+	}
+	return internalhttp.StatusError(resp, fmt.Errorf("FRED API returned status %d: %s", resp.StatusCode, string(body)))
 }
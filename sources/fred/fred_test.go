@@ -4,6 +4,7 @@ import (
 	"context"
 	"net/http"
 	"net/http/httptest"
+	"sync/atomic"
 	"testing"
 	"time"
 
@@ -341,6 +342,88 @@ func TestFREDReader_HTTPError(t *testing.T) {
 	}
 }
 
+func TestFREDReader_ReadSingle_ServeStaleOnError(t *testing.T) {
+	var failing atomic.Bool
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if failing.Load() {
+			w.WriteHeader(http.StatusBadGateway)
+			return
+		}
+		w.Write([]byte(`{"observations": [{"date": "2020-01-01", "value": "21427.91"}]}`))
+	}))
+	defer server.Close()
+
+	opts := &internalhttp.ClientOptions{
+		Timeout:           5 * time.Second,
+		CacheDir:          t.TempDir(),
+		CacheTTL:          time.Nanosecond,
+		ServeStaleOnError: true,
+	}
+	reader := fred.NewFREDReaderWithBaseURL(opts, server.URL)
+	reader.SetAPIKey("test-api-key")
+
+	ctx := context.Background()
+	start := time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC)
+	end := time.Date(2020, 12, 31, 0, 0, 0, 0, time.UTC)
+
+	result, err := reader.ReadSingle(ctx, "GDP", start, end)
+	if err != nil {
+		t.Fatalf("ReadSingle() error = %v", err)
+	}
+	if meta := result.(*fred.ParsedData).Meta; meta != nil {
+		t.Errorf("Expected nil Meta for fresh data, got %v", meta)
+	}
+
+	failing.Store(true)
+
+	result, err = reader.ReadSingle(ctx, "GDP", start, end)
+	if err != nil {
+		t.Fatalf("ReadSingle() should serve stale data, got error: %v", err)
+	}
+
+	data := result.(*fred.ParsedData)
+	if data.Meta["stale"] != "true" {
+		t.Errorf("Expected Meta[stale] = true, got %v", data.Meta)
+	}
+	if len(data.Values) != 1 || data.Values[0] != "21427.91" {
+		t.Errorf("Unexpected stale values: %v", data.Values)
+	}
+}
+
+func TestFREDReader_ReadVintages(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		q := r.URL.Query()
+		if q.Get("realtime_start") != "1776-07-04" || q.Get("realtime_end") != "9999-12-31" {
+			t.Errorf("expected full ALFRED real-time period, got %s", r.URL.RawQuery)
+		}
+		w.Write([]byte(`{"observations": [
+			{"realtime_start": "2024-02-13", "realtime_end": "2024-03-11", "date": "2024-01-01", "value": "308.4"},
+			{"realtime_start": "2024-03-12", "realtime_end": "9999-12-31", "date": "2024-01-01", "value": "308.6"}
+		]}`))
+	}))
+	defer server.Close()
+
+	reader := fred.NewFREDReaderWithBaseURL(nil, server.URL)
+
+	ctx := context.Background()
+	start := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	end := time.Date(2024, 12, 31, 0, 0, 0, 0, time.UTC)
+
+	if _, err := reader.ReadVintages(ctx, "CPIAUCSL", start, end); err == nil {
+		t.Error("ReadVintages() should require an API key")
+	}
+
+	reader.SetAPIKey("test-api-key")
+	data, err := reader.ReadVintages(ctx, "CPIAUCSL", start, end)
+	if err != nil {
+		t.Fatalf("ReadVintages() error = %v", err)
+	}
+
+	if len(data.Dates) != 2 || data.RealtimeStart[1] != "2024-03-12" || data.Values[1] != "308.6" {
+		t.Errorf("unexpected vintages: %+v", data)
+	}
+}
+
 func TestFREDReader_Read_RequiresAPIKey(t *testing.T) {
 	reader := fred.NewFREDReader(nil)
 
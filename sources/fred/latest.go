@@ -0,0 +1,69 @@
+package fred
+
+import (
+	"context"
+	"fmt"
+
+	internalhttp "github.com/julianshen/gonp-datareader/internal/http"
+	"github.com/julianshen/gonp-datareader/sources"
+)
+
+// latestLimit is the number of most recent observations requested by
+// ReadLatest, so a latest value FRED reports as missing (".") falls back
+// to the last reported one.
+const latestLimit = 10
+
+// BuildLatestURL constructs the FRED API URL requesting the most recent
+// observations of the given series, newest first.
+func (f *FREDReader) BuildLatestURL(seriesID, apiKey string) string {
+	baseURL := f.baseURL
+	if baseURL == "" {
+		baseURL = fredAPIURL
+	}
+
+	return fmt.Sprintf("%s?series_id=%s&api_key=%s&sort_order=desc&limit=%d&file_type=json",
+		baseURL, seriesID, apiKey, latestLimit)
+}
+
+// ReadLatest returns the most recent reported observation of a series,
+// requesting only the last few observations instead of a date range.
+func (f *FREDReader) ReadLatest(ctx context.Context, symbol string) (interface{}, error) {
+	// Normalize user input; the original is kept in Meta["symbol_input"]
+	input := symbol
+	symbol = f.NormalizeSymbol(symbol)
+	if err := f.ValidateSymbol(symbol); err != nil {
+		return nil, fmt.Errorf("invalid symbol: %w", err)
+	}
+
+	// Check API key
+	apiKey := internalhttp.APIKey(ctx, f.apiKey)
+	if apiKey == "" {
+		return nil, fmt.Errorf("FRED API key is required")
+	}
+
+	data, err := f.fetch(ctx, f.BuildLatestURL(symbol, apiKey))
+	if err != nil {
+		return nil, err
+	}
+
+	// Keep the newest reported observation
+	newest := -1
+	for i, v := range data.Values {
+		if v != "." {
+			newest = i
+			break
+		}
+	}
+	if newest < 0 {
+		return nil, fmt.Errorf("%w: no recent observations of %s", sources.ErrNoData, symbol)
+	}
+	data.SelectRows([]int{newest})
+	data.Meta = sources.InputMeta(data.Meta, input, symbol)
+
+	if f.frequencyMeta {
+		if err := f.annotateFrequency(ctx, symbol, data); err != nil {
+			return nil, err
+		}
+	}
+	return data, nil
+}
@@ -0,0 +1,84 @@
+package fred_test
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/julianshen/gonp-datareader/sources"
+	"github.com/julianshen/gonp-datareader/sources/fred"
+)
+
+func TestFREDReader_ReadLatest(t *testing.T) {
+	tests := []struct {
+		name      string
+		body      string
+		wantDate  string
+		wantValue string
+		wantErr   error
+	}{
+		{
+			name: "latest reported",
+			body: `{"observations":[
+				{"date":"2024-01-03","value":"3.91"},
+				{"date":"2024-01-02","value":"3.95"}]}`,
+			wantDate:  "2024-01-03",
+			wantValue: "3.91",
+		},
+		{
+			name: "latest missing",
+			body: `{"observations":[
+				{"date":"2024-01-01","value":"."},
+				{"date":"2023-12-29","value":"3.88"}]}`,
+			wantDate:  "2023-12-29",
+			wantValue: "3.88",
+		},
+		{
+			name:    "no observations",
+			body:    `{"observations":[]}`,
+			wantErr: sources.ErrNoData,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				q := r.URL.Query()
+				if q.Get("sort_order") != "desc" || q.Get("limit") == "" || q.Get("observation_start") != "" {
+					t.Errorf("unexpected query %q", r.URL.RawQuery)
+				}
+				w.Header().Set("Content-Type", "application/json")
+				w.Write([]byte(tt.body))
+			}))
+			defer server.Close()
+
+			reader := fred.NewFREDReaderWithBaseURL(nil, server.URL)
+			reader.SetAPIKey("key")
+
+			result, err := reader.ReadLatest(context.Background(), "DGS10")
+			if tt.wantErr != nil {
+				if !errors.Is(err, tt.wantErr) {
+					t.Fatalf("ReadLatest() error = %v, want %v", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("ReadLatest() error = %v", err)
+			}
+
+			data := result.(*fred.ParsedData)
+			if len(data.Dates) != 1 || data.Dates[0] != tt.wantDate || data.Values[0] != tt.wantValue {
+				t.Errorf("ReadLatest() = %v %v, want [%s] [%s]", data.Dates, data.Values, tt.wantDate, tt.wantValue)
+			}
+		})
+	}
+}
+
+func TestFREDReader_ReadLatest_NoAPIKey(t *testing.T) {
+	reader := fred.NewFREDReader(nil)
+	if _, err := reader.ReadLatest(context.Background(), "DGS10"); err == nil {
+		t.Error("ReadLatest() should fail without an API key")
+	}
+}
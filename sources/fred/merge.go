@@ -0,0 +1,116 @@
+package fred
+
+import (
+	"sort"
+	"time"
+
+	"github.com/julianshen/gonp-datareader/sources"
+)
+
+// MultiSeries holds several FRED series aligned on the union of their
+// observation dates, one value column per series. It is returned by Read
+// when merging is enabled with SetMergeSeries.
+type MultiSeries struct {
+	// Dates holds every date observed in any series, in ascending order.
+	Dates []string
+	// Series holds the series IDs in request order.
+	Series []string
+	// Values holds each series' values aligned to Dates, keyed by series
+	// ID, with "." (FRED's missing-value marker) where a series has no
+	// observation on a date.
+	Values map[string][]string
+	// Meta holds response metadata merged from every series, such as
+	// "stale" when any series was served from an expired cache entry.
+	Meta map[string]string
+}
+
+// Merge pivots the per-series results of Read into a MultiSeries with
+// columns in the order of series. Series missing from data are skipped.
+func Merge(series []string, data map[string]*ParsedData) *MultiSeries {
+	m := &MultiSeries{Values: make(map[string][]string, len(series))}
+
+	seen := make(map[string]bool)
+	for _, id := range series {
+		d, ok := data[id]
+		if !ok || d == nil {
+			continue
+		}
+		m.Series = append(m.Series, id)
+		for _, date := range d.Dates {
+			if !seen[date] {
+				seen[date] = true
+				m.Dates = append(m.Dates, date)
+			}
+		}
+		for k, v := range d.Meta {
+			if k == "symbol_input" {
+				continue
+			}
+			if m.Meta == nil {
+				m.Meta = make(map[string]string)
+			}
+			m.Meta[k] = v
+		}
+	}
+	// FRED dates are ISO 8601, so they sort as strings
+	sort.Strings(m.Dates)
+
+	index := make(map[string]int, len(m.Dates))
+	for i, date := range m.Dates {
+		index[date] = i
+	}
+	for _, id := range m.Series {
+		d := data[id]
+		values := make([]string, len(m.Dates))
+		for i := range values {
+			values[i] = "."
+		}
+		for i, date := range d.Dates {
+			if i < len(d.Values) {
+				values[index[date]] = d.Values[i]
+			}
+		}
+		m.Values[id] = values
+	}
+	return m
+}
+
+// DateIndex returns Dates parsed as dates.
+func (m *MultiSeries) DateIndex() ([]time.Time, error) {
+	if m == nil {
+		return nil, nil
+	}
+	return sources.ParseDates(m.Dates)
+}
+
+// ColumnNames returns the series IDs, one column per series.
+func (m *MultiSeries) ColumnNames() []string {
+	if m == nil {
+		return nil
+	}
+	return m.Series
+}
+
+// Float64Column returns the values of the named series as float64 values,
+// with missing values as NaN.
+func (m *MultiSeries) Float64Column(name string) ([]float64, error) {
+	if m == nil {
+		return nil, sources.NoColumn(name)
+	}
+	values, ok := m.Values[name]
+	if !ok {
+		return nil, sources.NoColumn(name)
+	}
+	return sources.ParseFloats(values)
+}
+
+// GetColumn returns a column of data by name: "Date" or a series ID.
+func (m *MultiSeries) GetColumn(name string) []string {
+	if m == nil {
+		return nil
+	}
+	if name == "Date" {
+		return m.Dates
+	}
+	return m.Values[name]
+}
@@ -0,0 +1,87 @@
+package fred_test
+
+import (
+	"context"
+	"math"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/julianshen/gonp-datareader/sources/fred"
+)
+
+func TestFREDReader_Read_MergeSeries(t *testing.T) {
+	responses := map[string]string{
+		"GDP": `{"observations": [
+			{"date": "2020-01-01", "value": "21481.4"},
+			{"date": "2020-04-01", "value": "19477.4"}
+		]}`,
+		"UNRATE": `{"observations": [
+			{"date": "2020-01-01", "value": "3.6"},
+			{"date": "2020-02-01", "value": "3.5"},
+			{"date": "2020-04-01", "value": "."}
+		]}`,
+	}
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(responses[r.URL.Query().Get("series_id")]))
+	}))
+	defer server.Close()
+
+	reader := fred.NewFREDReaderWithBaseURL(nil, server.URL)
+	reader.SetAPIKey("test-api-key")
+	reader.SetMergeSeries(true)
+
+	start := time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC)
+	end := time.Date(2020, 12, 31, 0, 0, 0, 0, time.UTC)
+	result, err := reader.Read(context.Background(), []string{"UNRATE", "GDP"}, start, end)
+	if err != nil {
+		t.Fatalf("Read() error = %v", err)
+	}
+
+	merged, ok := result.(*fred.MultiSeries)
+	if !ok {
+		t.Fatalf("Read() returned %T, want *fred.MultiSeries", result)
+	}
+	if want := []string{"2020-01-01", "2020-02-01", "2020-04-01"}; !reflect.DeepEqual(merged.Dates, want) {
+		t.Errorf("Dates = %v, want %v", merged.Dates, want)
+	}
+	if want := []string{"UNRATE", "GDP"}; !reflect.DeepEqual(merged.ColumnNames(), want) {
+		t.Errorf("ColumnNames() = %v, want %v", merged.ColumnNames(), want)
+	}
+	if want := []string{"21481.4", ".", "19477.4"}; !reflect.DeepEqual(merged.GetColumn("GDP"), want) {
+		t.Errorf("GetColumn(GDP) = %v, want %v", merged.GetColumn("GDP"), want)
+	}
+
+	unrate, err := merged.Float64Column("UNRATE")
+	if err != nil {
+		t.Fatalf("Float64Column(UNRATE) error = %v", err)
+	}
+	if unrate[0] != 3.6 || unrate[1] != 3.5 || !math.IsNaN(unrate[2]) {
+		t.Errorf("Float64Column(UNRATE) = %v, want [3.6 3.5 NaN]", unrate)
+	}
+	if _, err := merged.Float64Column("CPIAUCSL"); err == nil {
+		t.Error("Float64Column(CPIAUCSL) returned nil error")
+	}
+}
+
+func TestMerge(t *testing.T) {
+	data := map[string]*fred.ParsedData{
+		"A": {Dates: []string{"2020-02-01"}, Values: []string{"2"}, Meta: map[string]string{"stale": "true"}},
+		"B": {Dates: []string{"2020-01-01"}, Values: []string{"1"}},
+	}
+
+	merged := fred.Merge([]string{"A", "B", "C"}, data)
+	if want := []string{"A", "B"}; !reflect.DeepEqual(merged.Series, want) {
+		t.Errorf("Series = %v, want %v", merged.Series, want)
+	}
+	if want := []string{".", "2"}; !reflect.DeepEqual(merged.Values["A"], want) {
+		t.Errorf("Values[A] = %v, want %v", merged.Values["A"], want)
+	}
+	if merged.Meta["stale"] != "true" {
+		t.Errorf("Meta = %v, want stale", merged.Meta)
+	}
+}
@@ -4,16 +4,70 @@ import (
 	"encoding/json"
 	"fmt"
 	"io"
+	"strings"
+	"time"
+
+	"github.com/julianshen/gonp-datareader/sources"
 )
 
 // ParsedData holds parsed FRED data.
 type ParsedData struct {
-	Dates  []string
-	Values []string
+	Dates []string `schema:"Date,time"`
+	// Values holds the values as FRED publishes them, with "." for missing
+	// observations such as market holidays. Floats returns them as
+	// float64 values with a validity mask.
+	Values []string `schema:"Value,float64"`
+	// RealtimeStart and RealtimeEnd hold the FRED real-time period during
+	// which each observation value was current (ALFRED vintage semantics).
+	RealtimeStart []string `schema:",time"`
+	RealtimeEnd   []string `schema:",time"`
+	// Meta holds response metadata such as "stale" when the data was
+	// served from an expired cache entry; nil when there is none.
+	Meta map[string]string `schema:"-"`
+}
+
+// DateIndex returns Dates parsed as dates.
+func (p *ParsedData) DateIndex() ([]time.Time, error) {
+	if p == nil {
+		return nil, nil
+	}
+	return sources.ParseDates(p.Dates)
+}
+
+// SelectRows keeps the rows at indices, in that order (see
+// sources.RowSelector).
+func (p *ParsedData) SelectRows(indices []int) {
+	if p == nil {
+		return
+	}
+	p.Dates = sources.SelectRows(p.Dates, indices)
+	p.Values = sources.SelectRows(p.Values, indices)
+	p.RealtimeStart = sources.SelectRows(p.RealtimeStart, indices)
+	p.RealtimeEnd = sources.SelectRows(p.RealtimeEnd, indices)
+}
+
+// ColumnNames returns the single value column, "Value".
+func (p *ParsedData) ColumnNames() []string {
+	return []string{"Value"}
+}
+
+// Float64Column returns the "Value" column as float64 values, with missing
+// values as NaN.
+func (p *ParsedData) Float64Column(name string) ([]float64, error) {
+	if p == nil || name != "Value" {
+		return nil, sources.NoColumn(name)
+	}
+	return sources.ParseFloats(p.Values)
+}
+
+// Floats returns Values as float64 values, with missing observations as
+// NaN, and a mask that is false where an observation is missing.
+func (p *ParsedData) Floats() ([]float64, []bool, error) {
+	return sources.MaskedColumn(p, "Value")
 }
 
 // GetColumn returns a column of data by name.
-// Supported column names: "Date", "Value"
+// Supported column names: "Date", "Value", "RealtimeStart", "RealtimeEnd"
 func (p *ParsedData) GetColumn(name string) []string {
 	if p == nil {
 		return nil
@@ -24,6 +78,10 @@ func (p *ParsedData) GetColumn(name string) []string {
 		return p.Dates
 	case "Value":
 		return p.Values
+	case "RealtimeStart":
+		return p.RealtimeStart
+	case "RealtimeEnd":
+		return p.RealtimeEnd
 	default:
 		return nil
 	}
@@ -38,8 +96,10 @@ type fredResponse struct {
 
 // observation represents a single data point from FRED.
 type observation struct {
-	Date  string `json:"date"`
-	Value string `json:"value"`
+	RealtimeStart string `json:"realtime_start"`
+	RealtimeEnd   string `json:"realtime_end"`
+	Date          string `json:"date"`
+	Value         string `json:"value"`
 }
 
 // ParseJSON parses FRED JSON response data.
@@ -53,25 +113,40 @@ func ParseJSON(reader io.Reader) (*ParsedData, error) {
 
 	// Check for API errors
 	if resp.ErrorMessage != "" {
-		return nil, fmt.Errorf("FRED API error: %s", resp.ErrorMessage)
+		return nil, apiError(resp.ErrorMessage)
 	}
 
 	// Parse observations
 	dates := make([]string, 0, len(resp.Observations))
 	values := make([]string, 0, len(resp.Observations))
+	realtimeStart := make([]string, 0, len(resp.Observations))
+	realtimeEnd := make([]string, 0, len(resp.Observations))
 
 	for _, obs := range resp.Observations {
-		// Skip missing values (represented as ".")
-		if obs.Value == "." {
-			continue
-		}
-
 		dates = append(dates, obs.Date)
 		values = append(values, obs.Value)
+		realtimeStart = append(realtimeStart, obs.RealtimeStart)
+		realtimeEnd = append(realtimeEnd, obs.RealtimeEnd)
 	}
 
 	return &ParsedData{
-		Dates:  dates,
-		Values: values,
+		Dates:         dates,
+		Values:        values,
+		RealtimeStart: realtimeStart,
+		RealtimeEnd:   realtimeEnd,
 	}, nil
 }
+
+// apiError returns the error for a FRED error message, matching
+// sources.ErrAuthRequired for a missing or unregistered API key and
+// sources.ErrSymbolNotFound for an unknown series.
+func apiError(message string) error {
+	lower := strings.ToLower(message)
+	switch {
+	case strings.Contains(lower, "api_key"):
+		return fmt.Errorf("%w: FRED API error: %s", sources.ErrAuthRequired, message)
+	case strings.Contains(lower, "series does not exist"):
+		return fmt.Errorf("%w: FRED API error: %s", sources.ErrSymbolNotFound, message)
+	}
+	return fmt.Errorf("FRED API error: %s", message)
+}
@@ -1,9 +1,13 @@
 package fred_test
 
 import (
+	"errors"
+	"math"
+	"reflect"
 	"strings"
 	"testing"
 
+	"github.com/julianshen/gonp-datareader/sources"
 	"github.com/julianshen/gonp-datareader/sources/fred"
 )
 
@@ -127,22 +131,24 @@ func TestParseJSON_MissingValues(t *testing.T) {
 		t.Fatalf("ParseJSON failed: %v", err)
 	}
 
-	// Should skip the observation with missing value (".")
-	if len(data.Dates) != 2 {
-		t.Errorf("Expected 2 dates (skipping missing value), got %d", len(data.Dates))
+	// The missing observation (".") is kept
+	if len(data.Dates) != 3 || len(data.Values) != 3 {
+		t.Fatalf("Expected 3 observations, got %d dates and %d values", len(data.Dates), len(data.Values))
 	}
 
-	if len(data.Values) != 2 {
-		t.Errorf("Expected 2 values (skipping missing value), got %d", len(data.Values))
+	if data.Dates[1] != "2020-01-02" || data.Values[1] != "." {
+		t.Errorf("Missing observation incorrect: date=%s, value=%s", data.Dates[1], data.Values[1])
 	}
 
-	// Check that we got the non-missing values
-	if data.Dates[0] != "2020-01-01" || data.Values[0] != "100.5" {
-		t.Errorf("First observation incorrect: date=%s, value=%s", data.Dates[0], data.Values[0])
+	values, valid, err := data.Floats()
+	if err != nil {
+		t.Fatalf("Floats() error = %v", err)
 	}
-
-	if data.Dates[1] != "2020-01-03" || data.Values[1] != "102.3" {
-		t.Errorf("Second observation incorrect: date=%s, value=%s", data.Dates[1], data.Values[1])
+	if values[0] != 100.5 || !math.IsNaN(values[1]) || values[2] != 102.3 {
+		t.Errorf("Floats() values = %v, want [100.5 NaN 102.3]", values)
+	}
+	if !reflect.DeepEqual(valid, []bool{true, false, true}) {
+		t.Errorf("Floats() valid = %v, want [true false true]", valid)
 	}
 }
 
@@ -169,6 +175,21 @@ func TestParseJSON_APIError(t *testing.T) {
 	if err != nil && !strings.Contains(err.Error(), "Bad Request") {
 		t.Errorf("Expected error to contain 'Bad Request', got: %v", err)
 	}
+	if !errors.Is(err, sources.ErrAuthRequired) {
+		t.Errorf("Expected error to match ErrAuthRequired, got: %v", err)
+	}
+}
+
+func TestParseJSON_UnknownSeries(t *testing.T) {
+	jsonData := `{
+		"error_code": 400,
+		"error_message": "Bad Request.  The series does not exist."
+	}`
+
+	_, err := fred.ParseJSON(strings.NewReader(jsonData))
+	if !errors.Is(err, sources.ErrSymbolNotFound) {
+		t.Errorf("Expected error to match ErrSymbolNotFound, got: %v", err)
+	}
 }
 
 func TestParsedData_GetColumn(t *testing.T) {
@@ -259,3 +280,24 @@ func BenchmarkParseJSON_LargeDataset(b *testing.B) {
 		}
 	}
 }
+
+func TestParseJSON_RealtimeFields(t *testing.T) {
+	jsonData := `{
+		"observations": [
+			{"realtime_start": "2023-01-26", "realtime_end": "2023-02-22", "date": "2022-10-01", "value": "26132.5"},
+			{"realtime_start": "2023-02-23", "realtime_end": "9999-12-31", "date": "2023-01-01", "value": "26400.1"}
+		]
+	}`
+
+	data, err := fred.ParseJSON(strings.NewReader(jsonData))
+	if err != nil {
+		t.Fatalf("ParseJSON() error = %v", err)
+	}
+
+	if got := data.GetColumn("RealtimeStart"); len(got) != 2 || got[0] != "2023-01-26" {
+		t.Errorf("RealtimeStart = %v", got)
+	}
+	if got := data.GetColumn("RealtimeEnd"); len(got) != 2 || got[1] != "9999-12-31" {
+		t.Errorf("RealtimeEnd = %v", got)
+	}
+}
@@ -0,0 +1,263 @@
+package fred
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/julianshen/gonp-datareader/dataset"
+	internalhttp "github.com/julianshen/gonp-datareader/internal/http"
+	"github.com/julianshen/gonp-datareader/sources"
+)
+
+// fredSeriesURL is the FRED endpoint describing a series.
+const fredSeriesURL = "https://api.stlouisfed.org/fred/series"
+
+// SearchLimit is the number of series SearchSeries returns at most.
+const SearchLimit = 25
+
+// SeriesInfo describes a FRED series, as returned by the series endpoint.
+type SeriesInfo struct {
+	ID    string
+	Title string
+	// Frequency is the native frequency (e.g., "Monthly" or "Weekly,
+	// Ending Saturday"); FrequencyShort its code ("M", "W").
+	Frequency          string
+	FrequencyShort     string
+	Units              string
+	SeasonalAdjustment string
+	// ObservationStart and ObservationEnd are the dates of the first and
+	// last observations.
+	ObservationStart time.Time
+	ObservationEnd   time.Time
+}
+
+// NativeFrequency returns the series' frequency as a dataset.Frequency;
+// FrequencyUnknown for frequencies without one, such as biweekly.
+func (s *SeriesInfo) NativeFrequency() dataset.Frequency {
+	return dataset.ParseFrequency(s.Frequency)
+}
+
+// DateConvention returns how FRED dates the series' observations: weekly
+// (and biweekly) series by the last day of the week, such as "Weekly,
+// Ending Friday" or "Weekly, As of Wednesday"; all others by the first
+// day of the period.
+func (s *SeriesInfo) DateConvention() dataset.DateConvention {
+	if strings.Contains(strings.ToLower(s.Frequency), "weekly") {
+		return dataset.DateAtEnd
+	}
+	return dataset.DateAtStart
+}
+
+// seriesResponse is the JSON body of the series endpoint.
+type seriesResponse struct {
+	ErrorMessage string `json:"error_message"`
+	Series       []struct {
+		ID                 string `json:"id"`
+		Title              string `json:"title"`
+		Frequency          string `json:"frequency"`
+		FrequencyShort     string `json:"frequency_short"`
+		Units              string `json:"units"`
+		SeasonalAdjustment string `json:"seasonal_adjustment"`
+		ObservationStart   string `json:"observation_start"`
+		ObservationEnd     string `json:"observation_end"`
+	} `json:"seriess"`
+}
+
+// SetSeriesURL sets the URL of the series endpoint used by
+// ReadSeriesInfo; SearchSeries uses its "/search" path. This is primarily
+// used for testing with mock servers.
+func (f *FREDReader) SetSeriesURL(url string) {
+	f.seriesURL = url
+}
+
+// SetFrequencyMeta makes ReadSingle also fetch the series' description
+// and record its native frequency in ParsedData.Meta: "frequency" (e.g.,
+// "weekly"), "native_frequency" (FRED's own name, e.g., "Weekly, Ending
+// Saturday"), "date_convention" ("start" or "end") and "unit" (e.g.,
+// "Percent"). ToDataset carries them over, so dataset.Periods gives each
+// observation's period and the value column has its unit. This costs one
+// extra request per series.
+func (f *FREDReader) SetFrequencyMeta(enabled bool) {
+	f.frequencyMeta = enabled
+}
+
+// ReadSeriesInfo fetches the description of a series, including its
+// native frequency and observation period.
+func (f *FREDReader) ReadSeriesInfo(ctx context.Context, symbol string) (*SeriesInfo, error) {
+	symbol = f.NormalizeSymbol(symbol)
+	if err := f.ValidateSymbol(symbol); err != nil {
+		return nil, fmt.Errorf("invalid symbol: %w", err)
+	}
+
+	apiKey := internalhttp.APIKey(ctx, f.apiKey)
+	if apiKey == "" {
+		return nil, fmt.Errorf("FRED API key is required")
+	}
+
+	seriesURL := fmt.Sprintf("%s?series_id=%s&api_key=%s&file_type=json",
+		f.seriesURL, url.QueryEscape(symbol), url.QueryEscape(apiKey))
+	body, err := f.fetchSeries(ctx, seriesURL)
+	if err != nil {
+		return nil, err
+	}
+	return ParseSeriesInfo(bytes.NewReader(body))
+}
+
+// SearchSeries looks up series by keywords in their titles, units and
+// notes (e.g., "unemployment rate"), returning up to SearchLimit series,
+// most relevant first.
+func (f *FREDReader) SearchSeries(ctx context.Context, query string) ([]*SeriesInfo, error) {
+	query, err := sources.CheckQuery(query)
+	if err != nil {
+		return nil, err
+	}
+
+	apiKey := internalhttp.APIKey(ctx, f.apiKey)
+	if apiKey == "" {
+		return nil, fmt.Errorf("FRED API key is required")
+	}
+
+	searchURL := fmt.Sprintf("%s/search?search_text=%s&limit=%d&api_key=%s&file_type=json",
+		f.seriesURL, url.QueryEscape(query), SearchLimit, url.QueryEscape(apiKey))
+	body, err := f.fetchSeries(ctx, searchURL)
+	if err != nil {
+		return nil, err
+	}
+	return ParseSeriesSearch(bytes.NewReader(body))
+}
+
+// SearchSymbols looks up series like SearchSeries, describing each by its
+// frequency, units and seasonal adjustment.
+//
+// # Example Usage
+//
+//	matches, err := reader.SearchSymbols(ctx, "unemployment rate")
+//	fmt.Println(matches[0].Symbol, matches[0].Name) // UNRATE Unemployment Rate
+func (f *FREDReader) SearchSymbols(ctx context.Context, query string) ([]sources.SymbolInfo, error) {
+	series, err := f.SearchSeries(ctx, query)
+	if err != nil {
+		return nil, err
+	}
+
+	matches := make([]sources.SymbolInfo, len(series))
+	for i, s := range series {
+		var details []string
+		for _, d := range []string{s.Frequency, s.Units, s.SeasonalAdjustment} {
+			if d != "" {
+				details = append(details, d)
+			}
+		}
+		matches[i] = sources.SymbolInfo{
+			Symbol:      s.ID,
+			Name:        s.Title,
+			Type:        "series",
+			Description: strings.Join(details, "; "),
+		}
+	}
+	return matches, nil
+}
+
+// fetchSeries fetches the body of a series endpoint response.
+func (f *FREDReader) fetchSeries(ctx context.Context, seriesURL string) ([]byte, error) {
+	req, err := http.NewRequestWithContext(internalhttp.WithContentType(ctx, "application/json"), "GET", seriesURL, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create request: %w", err)
+	}
+
+	resp, err := f.client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch series info: %w", err)
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read response: %w", err)
+	}
+	if resp.StatusCode != http.StatusOK {
+		return nil, statusError(resp, body)
+	}
+	return body, nil
+}
+
+// ParseSeriesInfo parses a FRED series endpoint response.
+func ParseSeriesInfo(r io.Reader) (*SeriesInfo, error) {
+	series, err := ParseSeriesSearch(r)
+	if err != nil {
+		return nil, err
+	}
+	if len(series) == 0 {
+		return nil, fmt.Errorf("no series in response")
+	}
+	return series[0], nil
+}
+
+// ParseSeriesSearch parses a FRED series search response, which lists
+// series like the series endpoint does.
+func ParseSeriesSearch(r io.Reader) ([]*SeriesInfo, error) {
+	var resp seriesResponse
+	if err := json.NewDecoder(r).Decode(&resp); err != nil {
+		return nil, fmt.Errorf("failed to decode JSON: %w", err)
+	}
+	if resp.ErrorMessage != "" {
+		return nil, apiError(resp.ErrorMessage)
+	}
+
+	series := make([]*SeriesInfo, len(resp.Series))
+	for i, s := range resp.Series {
+		info := &SeriesInfo{
+			ID:                 s.ID,
+			Title:              s.Title,
+			Frequency:          s.Frequency,
+			FrequencyShort:     s.FrequencyShort,
+			Units:              s.Units,
+			SeasonalAdjustment: s.SeasonalAdjustment,
+		}
+
+		var err error
+		if info.ObservationStart, err = parseSeriesDate(s.ObservationStart); err != nil {
+			return nil, fmt.Errorf("parse observation_start: %w", err)
+		}
+		if info.ObservationEnd, err = parseSeriesDate(s.ObservationEnd); err != nil {
+			return nil, fmt.Errorf("parse observation_end: %w", err)
+		}
+		series[i] = info
+	}
+	return series, nil
+}
+
+// parseSeriesDate parses a YYYY-MM-DD date; empty means the zero time.
+func parseSeriesDate(s string) (time.Time, error) {
+	if s == "" {
+		return time.Time{}, nil
+	}
+	return time.Parse("2006-01-02", s)
+}
+
+// annotateFrequency adds the native frequency of symbol to data.Meta.
+func (f *FREDReader) annotateFrequency(ctx context.Context, symbol string, data *ParsedData) error {
+	info, err := f.ReadSeriesInfo(ctx, symbol)
+	if err != nil {
+		return err
+	}
+
+	if data.Meta == nil {
+		data.Meta = make(map[string]string)
+	}
+	if freq := info.NativeFrequency(); freq != dataset.FrequencyUnknown {
+		data.Meta[dataset.MetaFrequency] = freq.String()
+	}
+	data.Meta["native_frequency"] = info.Frequency
+	if info.Units != "" {
+		data.Meta[dataset.MetaUnit] = info.Units
+	}
+	data.Meta[dataset.MetaDateConvention] = info.DateConvention().String()
+	return nil
+}
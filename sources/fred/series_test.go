@@ -0,0 +1,124 @@
+package fred_test
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/julianshen/gonp-datareader/dataset"
+	"github.com/julianshen/gonp-datareader/sources"
+	"github.com/julianshen/gonp-datareader/sources/fred"
+)
+
+const icsaSeries = `{
+	"seriess": [{
+		"id": "ICSA",
+		"title": "Initial Claims",
+		"observation_start": "1967-01-07",
+		"observation_end": "2024-01-13",
+		"frequency": "Weekly, Ending Saturday",
+		"frequency_short": "W",
+		"units": "Number",
+		"seasonal_adjustment": "Seasonally Adjusted"
+	}]
+}`
+
+func TestParseSeriesInfo(t *testing.T) {
+	tests := []struct {
+		name     string
+		body     string
+		wantFreq dataset.Frequency
+		wantConv dataset.DateConvention
+		wantErr  error
+	}{
+		{
+			name:     "weekly dated at end",
+			body:     icsaSeries,
+			wantFreq: dataset.FrequencyWeekly,
+			wantConv: dataset.DateAtEnd,
+		},
+		{
+			name:     "monthly dated at start",
+			body:     `{"seriess":[{"id":"CPIAUCSL","frequency":"Monthly","frequency_short":"M","observation_start":"1947-01-01","observation_end":"2024-01-01"}]}`,
+			wantFreq: dataset.FrequencyMonthly,
+			wantConv: dataset.DateAtStart,
+		},
+		{
+			name:     "biweekly",
+			body:     `{"seriess":[{"id":"X","frequency":"Biweekly, Ending Wednesday","frequency_short":"BW"}]}`,
+			wantFreq: dataset.FrequencyUnknown,
+			wantConv: dataset.DateAtEnd,
+		},
+		{
+			name:    "unknown series",
+			body:    `{"error_code":400,"error_message":"Bad Request.  The series does not exist."}`,
+			wantErr: sources.ErrSymbolNotFound,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			info, err := fred.ParseSeriesInfo(strings.NewReader(tt.body))
+			if tt.wantErr != nil {
+				if !errors.Is(err, tt.wantErr) {
+					t.Fatalf("ParseSeriesInfo() error = %v, want %v", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("ParseSeriesInfo() error = %v", err)
+			}
+			if got := info.NativeFrequency(); got != tt.wantFreq {
+				t.Errorf("NativeFrequency() = %v, want %v", got, tt.wantFreq)
+			}
+			if got := info.DateConvention(); got != tt.wantConv {
+				t.Errorf("DateConvention() = %v, want %v", got, tt.wantConv)
+			}
+		})
+	}
+}
+
+func TestFREDReader_ReadSingle_FrequencyMeta(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		switch r.URL.Path {
+		case "/fred/series":
+			w.Write([]byte(icsaSeries))
+		case "/fred/series/observations":
+			w.Write([]byte(`{"observations":[
+				{"date":"2024-01-06","value":"203000"},
+				{"date":"2024-01-13","value":"187000"}
+			]}`))
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	defer server.Close()
+
+	reader := fred.NewFREDReaderWithBaseURL(nil, server.URL+"/fred/series/observations")
+	reader.SetAPIKey("test-api-key")
+	reader.SetFrequencyMeta(true)
+
+	start := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	end := time.Date(2024, 1, 31, 0, 0, 0, 0, time.UTC)
+	result, err := reader.ReadSingle(context.Background(), "ICSA", start, end)
+	if err != nil {
+		t.Fatalf("ReadSingle() error = %v", err)
+	}
+
+	data := result.(*fred.ParsedData)
+	want := map[string]string{
+		"frequency":        "weekly",
+		"native_frequency": "Weekly, Ending Saturday",
+		"date_convention":  "end",
+	}
+	for k, v := range want {
+		if data.Meta[k] != v {
+			t.Errorf("Meta[%q] = %q, want %q", k, data.Meta[k], v)
+		}
+	}
+}
@@ -0,0 +1,422 @@
+//go:build gateway
+// +build gateway
+
+// Package gateway provides a reader of historical bars from a locally
+// running broker gateway, so users with brokerage data entitlements can
+// read their licensed data through the same Reader interface as the
+// public sources.
+//
+// The package is only built with the gateway build tag; datareader then
+// registers it as the "gateway" source. Two protocols are supported:
+//
+//   - ProtocolGeneric, for any REST bridge to a broker API: GET
+//     {base}/v1/bars?symbol=AAPL&start=2024-01-02&end=2024-01-31&interval=1d
+//     answering {"symbol": "AAPL", "bars": [{"time": "2024-01-02",
+//     "open": 1, "high": 1, "low": 1, "close": 1, "volume": 1}]}, with
+//     times as dates or RFC 3339 timestamps and a 404 status for unknown
+//     symbols.
+//   - ProtocolIBKR, for the Interactive Brokers Client Portal gateway or a
+//     REST bridge to the TWS API exposing its endpoints: symbols are
+//     resolved to contract IDs with /v1/api/iserver/secdef/search and bars
+//     read from /v1/api/iserver/marketdata/history. Numeric symbols are
+//     used as contract IDs directly.
+//
+// The gateway must already be running and authenticated; this package
+// does not log in. The Client Portal gateway serves a self-signed
+// certificate, which Go rejects: put a bridge serving plain HTTP on
+// localhost in front of it, or have it serve a trusted certificate.
+package gateway
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"math"
+	"net/http"
+	"net/url"
+	"sync"
+	"time"
+
+	internalhttp "github.com/julianshen/gonp-datareader/internal/http"
+	"github.com/julianshen/gonp-datareader/internal/utils"
+	"github.com/julianshen/gonp-datareader/sources"
+)
+
+// DefaultURL is the base URL of a gateway running with default settings.
+const DefaultURL = "http://localhost:5000"
+
+// Protocol selects the API a gateway speaks.
+type Protocol string
+
+const (
+	// ProtocolGeneric is the bars endpoint described in the package
+	// documentation.
+	ProtocolGeneric Protocol = "generic"
+	// ProtocolIBKR is the Interactive Brokers Client Portal Web API.
+	ProtocolIBKR Protocol = "ibkr"
+)
+
+// Intervals lists the bar intervals a gateway can serve; which ones a
+// broker delivers depends on its entitlements.
+var Intervals = []sources.Interval{
+	sources.Interval1m, sources.Interval5m, sources.Interval15m, sources.Interval30m,
+	sources.Interval1h, sources.Interval1d, sources.Interval1wk, sources.Interval1mo,
+}
+
+// ibkrBars maps intervals to the bar parameter of the IBKR history endpoint.
+var ibkrBars = map[sources.Interval]string{
+	sources.Interval1m:  "1min",
+	sources.Interval5m:  "5min",
+	sources.Interval15m: "15min",
+	sources.Interval30m: "30min",
+	sources.Interval1h:  "1h",
+	sources.Interval1d:  "1d",
+	sources.Interval1wk: "1w",
+	sources.Interval1mo: "1m",
+}
+
+// GatewayReader fetches historical bars from a local broker gateway.
+type GatewayReader struct {
+	*sources.BaseSource
+	client   *internalhttp.RetryableClient
+	baseURL  string
+	protocol Protocol
+
+	// interval is the bar interval; see SetInterval
+	interval sources.Interval
+
+	// conids caches the IBKR contract IDs of symbols
+	mu     sync.Mutex
+	conids map[string]string
+}
+
+// NewGatewayReader creates a reader of a gateway at DefaultURL speaking
+// ProtocolGeneric.
+func NewGatewayReader(opts *internalhttp.ClientOptions) *GatewayReader {
+	return NewGatewayReaderWithBaseURL(opts, DefaultURL)
+}
+
+// NewGatewayReaderWithBaseURL creates a reader of a gateway at baseURL
+// speaking ProtocolGeneric.
+func NewGatewayReaderWithBaseURL(opts *internalhttp.ClientOptions, baseURL string) *GatewayReader {
+	if opts == nil {
+		opts = internalhttp.DefaultClientOptions()
+	}
+
+	return &GatewayReader{
+		BaseSource: sources.NewBaseSource("gateway"),
+		client:     internalhttp.NewRetryableClient(opts),
+		baseURL:    baseURL,
+		protocol:   ProtocolGeneric,
+		conids:     make(map[string]string),
+	}
+}
+
+// Name returns the display name of the data source.
+func (g *GatewayReader) Name() string {
+	return "Broker Gateway"
+}
+
+// Schema returns the columns of the ParsedData returned by ReadSingle.
+func (g *GatewayReader) Schema() sources.Schema {
+	return sources.NewSchema(g.Source(), g.Name(), ParsedData{})
+}
+
+// SetProtocol selects the API of the gateway; empty means
+// ProtocolGeneric. Unknown protocols return an error.
+func (g *GatewayReader) SetProtocol(protocol Protocol) error {
+	switch protocol {
+	case "":
+		protocol = ProtocolGeneric
+	case ProtocolGeneric, ProtocolIBKR:
+	default:
+		return fmt.Errorf("unknown gateway protocol %q (want %q or %q)", protocol, ProtocolGeneric, ProtocolIBKR)
+	}
+	g.protocol = protocol
+	return nil
+}
+
+// SetInterval sets the bar interval of ReadSingle and Read; empty means
+// daily. Intervals not in Intervals return an error matching
+// sources.ErrUnsupportedInterval.
+func (g *GatewayReader) SetInterval(interval sources.Interval) error {
+	if err := sources.CheckInterval(interval, Intervals); err != nil {
+		return err
+	}
+	g.interval = interval
+	return nil
+}
+
+// ReadSingle fetches the bars of a symbol between start and end.
+func (g *GatewayReader) ReadSingle(ctx context.Context, symbol string, start, end time.Time) (interface{}, error) {
+	return g.Finish(ctx, symbol, start, end, g.readSingle)
+}
+
+// readSingle implements ReadSingle before Finish is applied.
+func (g *GatewayReader) readSingle(ctx context.Context, symbol string, start, end time.Time) (interface{}, error) {
+	// Normalize user input; the original is kept in Meta["symbol_input"]
+	input := symbol
+	symbol = g.NormalizeSymbol(symbol)
+
+	// Validate inputs
+	if err := g.ValidateSymbol(symbol); err != nil {
+		return nil, fmt.Errorf("invalid symbol: %w", err)
+	}
+
+	if err := utils.ValidateDateRange(start, end); err != nil {
+		return nil, fmt.Errorf("invalid date range: %w", err)
+	}
+
+	var (
+		data *ParsedData
+		err  error
+	)
+	if g.protocol == ProtocolIBKR {
+		data, err = g.readIBKR(ctx, symbol, start, end)
+	} else {
+		data, err = g.readGeneric(ctx, symbol, start, end)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	data = between(data, start, end)
+	data.Symbol = symbol
+	data.Meta = sources.InputMeta(map[string]string{"gateway_protocol": string(g.protocol)}, input, symbol)
+	return data, nil
+}
+
+// readGeneric fetches bars from the generic bars endpoint.
+func (g *GatewayReader) readGeneric(ctx context.Context, symbol string, start, end time.Time) (*ParsedData, error) {
+	params := url.Values{}
+	params.Set("symbol", symbol)
+	params.Set("start", start.Format("2006-01-02"))
+	params.Set("end", end.Format("2006-01-02"))
+	params.Set("interval", string(g.interval.OrDefault()))
+
+	body, err := g.get(ctx, g.baseURL+"/v1/bars?"+params.Encode())
+	if err != nil {
+		return nil, err
+	}
+
+	data, err := ParseGeneric(body)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse bars: %w", err)
+	}
+	return data, nil
+}
+
+// readIBKR resolves symbol to a contract ID and fetches its bars from the
+// IBKR history endpoint, over the period from start to now.
+func (g *GatewayReader) readIBKR(ctx context.Context, symbol string, start, end time.Time) (*ParsedData, error) {
+	conid, err := g.conid(ctx, symbol)
+	if err != nil {
+		return nil, err
+	}
+
+	interval := g.interval.OrDefault()
+	params := url.Values{}
+	params.Set("conid", conid)
+	params.Set("period", ibkrPeriod(start, time.Now()))
+	params.Set("bar", ibkrBars[interval])
+
+	body, err := g.get(ctx, g.baseURL+"/v1/api/iserver/marketdata/history?"+params.Encode())
+	if err != nil {
+		return nil, err
+	}
+
+	daily := interval == sources.Interval1d || interval == sources.Interval1wk || interval == sources.Interval1mo
+	data, err := ParseIBKRHistory(body, daily)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse bars: %w", err)
+	}
+	return data, nil
+}
+
+// conid returns the IBKR contract ID of symbol, looking it up once.
+func (g *GatewayReader) conid(ctx context.Context, symbol string) (string, error) {
+	if isDigits(symbol) {
+		return symbol, nil
+	}
+
+	g.mu.Lock()
+	conid, ok := g.conids[symbol]
+	g.mu.Unlock()
+	if ok {
+		return conid, nil
+	}
+
+	body, err := g.get(ctx, g.baseURL+"/v1/api/iserver/secdef/search?symbol="+url.QueryEscape(symbol))
+	if err != nil {
+		return "", err
+	}
+	conid, err = ParseIBKRConid(body)
+	if err != nil {
+		return "", fmt.Errorf("failed to parse contract search: %w", err)
+	}
+	if conid == "" {
+		return "", fmt.Errorf("%w: no IBKR contract for %s", sources.ErrSymbolNotFound, symbol)
+	}
+
+	g.mu.Lock()
+	g.conids[symbol] = conid
+	g.mu.Unlock()
+	return conid, nil
+}
+
+// ibkrPeriod returns the period parameter covering start to now: days up
+// to 1000 days, then whole years up to IBKR's maximum of 15.
+func ibkrPeriod(start, now time.Time) string {
+	days := int(math.Ceil(now.Sub(start).Hours()/24)) + 1
+	if days < 1 {
+		days = 1
+	}
+	if days <= 1000 {
+		return fmt.Sprintf("%dd", days)
+	}
+	years := (days + 364) / 365
+	if years > 15 {
+		years = 15
+	}
+	return fmt.Sprintf("%dy", years)
+}
+
+// get fetches url and returns the body of a 200 JSON response.
+func (g *GatewayReader) get(ctx context.Context, url string) ([]byte, error) {
+	// Create HTTP request
+	req, err := http.NewRequestWithContext(internalhttp.WithContentType(ctx, "application/json"), "GET", url, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create request: %w", err)
+	}
+	req.Header.Set("Accept", "application/json")
+
+	// Execute request
+	resp, err := g.client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch data: %w", err)
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read response: %w", err)
+	}
+
+	// Check status code
+	if resp.StatusCode != http.StatusOK {
+		return nil, internalhttp.StatusError(resp, fmt.Errorf("gateway returned status %d: %s", resp.StatusCode, string(body)))
+	}
+
+	return body, nil
+}
+
+// between returns the bars of data dated from the day of start through
+// the day of end.
+func between(data *ParsedData, start, end time.Time) *ParsedData {
+	days := utils.NewDayRange(start, end)
+	out := &ParsedData{}
+	for i, t := range data.Dates {
+		if days.Contains(t) {
+			out.Dates = append(out.Dates, t)
+			out.Bars = append(out.Bars, data.Bars[i])
+		}
+	}
+	return out
+}
+
+// isDigits reports whether s is a non-empty string of ASCII digits.
+func isDigits(s string) bool {
+	if s == "" {
+		return false
+	}
+	for _, r := range s {
+		if r < '0' || r > '9' {
+			return false
+		}
+	}
+	return true
+}
+
+// Read fetches the bars of multiple symbols.
+// Symbols are fetched in parallel for better performance.
+func (g *GatewayReader) Read(ctx context.Context, symbols []string, start, end time.Time) (interface{}, error) {
+	// Validate inputs
+	if err := utils.ValidateSymbols(g.NormalizeSymbols(symbols)); err != nil {
+		return nil, fmt.Errorf("invalid symbols: %w", err)
+	}
+
+	if err := utils.ValidateDateRange(start, end); err != nil {
+		return nil, fmt.Errorf("invalid date range: %w", err)
+	}
+
+	// Use parallel fetching for multiple symbols
+	return g.readParallel(ctx, symbols, start, end)
+}
+
+// readParallel fetches multiple symbols in parallel using a worker pool.
+func (g *GatewayReader) readParallel(ctx context.Context, symbols []string, start, end time.Time) (map[string]*ParsedData, error) {
+	type result struct {
+		symbol string
+		data   *ParsedData
+		err    error
+	}
+
+	// Create channels for work distribution and results
+	results := make(chan result, len(symbols))
+
+	// Create worker pool - gateways such as IBKR's allow few concurrent
+	// history requests
+	maxWorkers := 5
+	if len(symbols) < maxWorkers {
+		maxWorkers = len(symbols)
+	}
+
+	// Use a semaphore pattern to limit concurrent workers
+	semaphore := make(chan struct{}, maxWorkers)
+
+	// Launch goroutines for each symbol
+	for _, symbol := range symbols {
+		sym := symbol
+
+		go func() {
+			// Acquire semaphore
+			semaphore <- struct{}{}
+			defer func() { <-semaphore }()
+
+			// Fetch data
+			data, err := g.ReadSingle(ctx, sym, start, end)
+
+			// Send result
+			res := result{symbol: sym, err: err}
+			if err == nil {
+				if parsedData, ok := data.(*ParsedData); ok {
+					res.data = parsedData
+				}
+			}
+			results <- res
+		}()
+	}
+
+	// Collect results
+	dataMap := make(map[string]*ParsedData, len(symbols))
+	for i := 0; i < len(symbols); i++ {
+		res := <-results
+		if res.err != nil {
+			return nil, fmt.Errorf("failed to read %s: %w", res.symbol, res.err)
+		}
+		dataMap[res.symbol] = res.data
+	}
+
+	return dataMap, nil
+}
+
+// Shutdown stops accepting requests and waits for in-flight requests to
+// finish, cancelling them if ctx expires first.
+func (g *GatewayReader) Shutdown(ctx context.Context) error {
+	return g.client.Shutdown(ctx)
+}
+
+// Close cancels in-flight requests and releases the reader's connections.
+func (g *GatewayReader) Close() error {
+	return g.client.Close()
+}
@@ -0,0 +1,148 @@
+//go:build gateway
+// +build gateway
+
+package gateway_test
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/julianshen/gonp-datareader/sources"
+	"github.com/julianshen/gonp-datareader/sources/gateway"
+)
+
+var (
+	testStart = time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	testEnd   = time.Date(2024, 1, 3, 0, 0, 0, 0, time.UTC)
+)
+
+func TestNewGatewayReader(t *testing.T) {
+	reader := gateway.NewGatewayReader(nil)
+
+	if reader.Name() != "Broker Gateway" {
+		t.Errorf("Name() = %q, want %q", reader.Name(), "Broker Gateway")
+	}
+	if reader.Source() != "gateway" {
+		t.Errorf("Source() = %q, want %q", reader.Source(), "gateway")
+	}
+	if err := reader.SetProtocol("fix"); err == nil {
+		t.Error("SetProtocol(fix) error = nil, want an error")
+	}
+	if err := reader.SetProtocol(""); err != nil {
+		t.Errorf("SetProtocol(\"\") error = %v", err)
+	}
+}
+
+func TestGatewayReader_ReadSingle_Generic(t *testing.T) {
+	var query string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/v1/bars" {
+			http.NotFound(w, r)
+			return
+		}
+		query = r.URL.RawQuery
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"symbol":"AAPL","bars":[
+			{"time":"2024-01-04","open":4,"high":4,"low":4,"close":4,"volume":40},
+			{"time":"2024-01-03T15:30:00Z","open":3,"high":3.5,"low":2.5,"close":3.2,"volume":30},
+			{"time":"2024-01-02","open":2,"high":2.5,"low":1.5,"close":2.2,"volume":20}]}`))
+	}))
+	defer server.Close()
+
+	reader := gateway.NewGatewayReaderWithBaseURL(nil, server.URL)
+	result, err := reader.ReadSingle(context.Background(), "aapl", testStart, testEnd)
+	if err != nil {
+		t.Fatalf("ReadSingle() error = %v", err)
+	}
+	if query != "end=2024-01-03&interval=1d&start=2024-01-01&symbol=AAPL" {
+		t.Errorf("query = %s", query)
+	}
+
+	data := result.(*gateway.ParsedData)
+	// Bars are sorted and the bar after the end day is dropped
+	if len(data.Bars) != 2 || data.Bars[0].Close != 2.2 || data.Bars[1].Close != 3.2 {
+		t.Errorf("Bars = %+v, want the bars of Jan 2 and 3", data.Bars)
+	}
+	if data.Meta["gateway_protocol"] != "generic" {
+		t.Errorf("Meta = %v, want gateway_protocol generic", data.Meta)
+	}
+}
+
+func TestGatewayReader_ReadSingle_IBKR(t *testing.T) {
+	var searches atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		switch r.URL.Path {
+		case "/v1/api/iserver/secdef/search":
+			searches.Add(1)
+			if r.URL.Query().Get("symbol") != "AAPL" {
+				w.Write([]byte(`[]`))
+				return
+			}
+			w.Write([]byte(`[{"conid":"265598","symbol":"AAPL"}]`))
+		case "/v1/api/iserver/marketdata/history":
+			if r.URL.Query().Get("conid") != "265598" || r.URL.Query().Get("bar") != "1d" {
+				http.Error(w, "bad request", http.StatusBadRequest)
+				return
+			}
+			// 2024-01-02 and 2024-01-03, 14:30 UTC
+			w.Write([]byte(`{"symbol":"AAPL","data":[
+				{"o":185,"h":186,"l":183,"c":185.6,"v":1000,"t":1704205800000},
+				{"o":184,"h":185,"l":182,"c":184.2,"v":900,"t":1704292200000}]}`))
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	defer server.Close()
+
+	reader := gateway.NewGatewayReaderWithBaseURL(nil, server.URL)
+	if err := reader.SetProtocol(gateway.ProtocolIBKR); err != nil {
+		t.Fatal(err)
+	}
+
+	for i := 0; i < 2; i++ {
+		result, err := reader.ReadSingle(context.Background(), "AAPL", testStart, testEnd)
+		if err != nil {
+			t.Fatalf("ReadSingle() error = %v", err)
+		}
+		data := result.(*gateway.ParsedData)
+		if len(data.Dates) != 2 || !data.Dates[0].Equal(time.Date(2024, 1, 2, 0, 0, 0, 0, time.UTC)) {
+			t.Errorf("Dates = %v, want Jan 2 and 3", data.Dates)
+		}
+	}
+	if got := searches.Load(); got != 1 {
+		t.Errorf("contract searches = %d, want 1 (cached)", got)
+	}
+
+	// Numeric symbols are contract IDs
+	if _, err := reader.ReadSingle(context.Background(), "265598", testStart, testEnd); err != nil {
+		t.Errorf("ReadSingle(265598) error = %v", err)
+	}
+	if got := searches.Load(); got != 1 {
+		t.Errorf("contract searches = %d, want 1", got)
+	}
+
+	_, err := reader.ReadSingle(context.Background(), "NOPE", testStart, testEnd)
+	if !errors.Is(err, sources.ErrSymbolNotFound) {
+		t.Errorf("ReadSingle(NOPE) error = %v, want ErrSymbolNotFound", err)
+	}
+}
+
+func TestGatewayReader_ReadSingle_HTMLResponse(t *testing.T) {
+	// IBKR gateways serve a login page until the session is authenticated
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "text/html")
+		w.Write([]byte("<html>Login</html>"))
+	}))
+	defer server.Close()
+
+	reader := gateway.NewGatewayReaderWithBaseURL(nil, server.URL)
+	if _, err := reader.ReadSingle(context.Background(), "AAPL", testStart, testEnd); err == nil {
+		t.Error("ReadSingle() error = nil, want an error")
+	}
+}
@@ -0,0 +1,204 @@
+//go:build gateway
+// +build gateway
+
+package gateway
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/julianshen/gonp-datareader/dataset"
+	"github.com/julianshen/gonp-datareader/sources"
+)
+
+// Bar is one OHLCV bar.
+type Bar struct {
+	Open   float64
+	High   float64
+	Low    float64
+	Close  float64
+	Volume float64
+}
+
+// ParsedData holds the bars of a symbol read from a gateway.
+type ParsedData struct {
+	Symbol string      `schema:"-"`
+	Dates  []time.Time `schema:"Date"`
+	Bars   []Bar
+	// Meta holds response metadata such as "gateway_protocol"; nil when
+	// there is none.
+	Meta map[string]string `schema:"-"`
+}
+
+// DateIndex returns a copy of Dates.
+func (p *ParsedData) DateIndex() ([]time.Time, error) {
+	if p == nil {
+		return nil, nil
+	}
+	return append([]time.Time(nil), p.Dates...), nil
+}
+
+// SelectRows keeps the rows at indices, in that order (see
+// sources.RowSelector).
+func (p *ParsedData) SelectRows(indices []int) {
+	if p == nil {
+		return
+	}
+	p.Dates = sources.SelectRows(p.Dates, indices)
+	p.Bars = sources.SelectRows(p.Bars, indices)
+}
+
+// ColumnNames returns the bar columns: "Open", "High", "Low", "Close" and
+// "Volume".
+func (p *ParsedData) ColumnNames() []string {
+	return []string{"Open", "High", "Low", "Close", "Volume"}
+}
+
+// Float64Column returns the named bar column.
+func (p *ParsedData) Float64Column(name string) ([]float64, error) {
+	var field func(Bar) float64
+	switch name {
+	case "Open":
+		field = func(b Bar) float64 { return b.Open }
+	case "High":
+		field = func(b Bar) float64 { return b.High }
+	case "Low":
+		field = func(b Bar) float64 { return b.Low }
+	case "Close":
+		field = func(b Bar) float64 { return b.Close }
+	case "Volume":
+		field = func(b Bar) float64 { return b.Volume }
+	}
+	if p == nil || field == nil {
+		return nil, sources.NoColumn(name)
+	}
+
+	values := make([]float64, len(p.Bars))
+	for i, bar := range p.Bars {
+		values[i] = field(bar)
+	}
+	return values, nil
+}
+
+// genericResponse is the response of the generic bars endpoint.
+type genericResponse struct {
+	Symbol string `json:"symbol"`
+	Bars   []struct {
+		Time   string  `json:"time"`
+		Open   float64 `json:"open"`
+		High   float64 `json:"high"`
+		Low    float64 `json:"low"`
+		Close  float64 `json:"close"`
+		Volume float64 `json:"volume"`
+	} `json:"bars"`
+	Error string `json:"error"`
+}
+
+// ParseGeneric parses a response of the generic bars endpoint. Bar times
+// are dates ("2024-01-02") or RFC 3339 timestamps.
+func ParseGeneric(body []byte) (*ParsedData, error) {
+	var response genericResponse
+	if err := json.Unmarshal(body, &response); err != nil {
+		return nil, fmt.Errorf("unmarshal JSON: %w", err)
+	}
+	if response.Error != "" {
+		return nil, fmt.Errorf("gateway error: %s", response.Error)
+	}
+
+	data := &ParsedData{Symbol: response.Symbol}
+	for i, b := range response.Bars {
+		t, err := parseTime(b.Time)
+		if err != nil {
+			return nil, fmt.Errorf("bar %d: %w", i, err)
+		}
+		data.Dates = append(data.Dates, t)
+		data.Bars = append(data.Bars, Bar{Open: b.Open, High: b.High, Low: b.Low, Close: b.Close, Volume: b.Volume})
+	}
+	sortBars(data)
+	return data, nil
+}
+
+// parseTime parses an RFC 3339 timestamp or a date.
+func parseTime(s string) (time.Time, error) {
+	if t, err := time.Parse(time.RFC3339, s); err == nil {
+		return t, nil
+	}
+	return dataset.ParseDate(s)
+}
+
+// ibkrHistory is the response of the IBKR Client Portal market data
+// history endpoint.
+type ibkrHistory struct {
+	Symbol string `json:"symbol"`
+	Data   []struct {
+		Open   float64 `json:"o"`
+		High   float64 `json:"h"`
+		Low    float64 `json:"l"`
+		Close  float64 `json:"c"`
+		Volume float64 `json:"v"`
+		Time   int64   `json:"t"` // Unix milliseconds
+	} `json:"data"`
+	Error string `json:"error"`
+}
+
+// ParseIBKRHistory parses a response of the IBKR Client Portal
+// /iserver/marketdata/history endpoint. Bars of a day or longer are dated
+// by the UTC date of their start.
+func ParseIBKRHistory(body []byte, daily bool) (*ParsedData, error) {
+	var response ibkrHistory
+	if err := json.Unmarshal(body, &response); err != nil {
+		return nil, fmt.Errorf("unmarshal JSON: %w", err)
+	}
+	if response.Error != "" {
+		return nil, fmt.Errorf("IBKR gateway error: %s", response.Error)
+	}
+
+	data := &ParsedData{Symbol: response.Symbol}
+	for _, b := range response.Data {
+		t := time.UnixMilli(b.Time).UTC()
+		if daily {
+			t = time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, time.UTC)
+		}
+		data.Dates = append(data.Dates, t)
+		data.Bars = append(data.Bars, Bar{Open: b.Open, High: b.High, Low: b.Low, Close: b.Close, Volume: b.Volume})
+	}
+	sortBars(data)
+	return data, nil
+}
+
+// ParseIBKRConid returns the contract ID of the first result of the IBKR
+// Client Portal /iserver/secdef/search endpoint, or "" when there is none.
+// IBKR returns contract IDs as numbers or strings.
+func ParseIBKRConid(body []byte) (string, error) {
+	var results []struct {
+		Conid json.RawMessage `json:"conid"`
+	}
+	if err := json.Unmarshal(body, &results); err != nil {
+		return "", fmt.Errorf("unmarshal JSON: %w", err)
+	}
+	for _, r := range results {
+		if conid := strings.Trim(string(r.Conid), `"`); conid != "" && conid != "null" {
+			return conid, nil
+		}
+	}
+	return "", nil
+}
+
+// sortBars orders the bars of data by date.
+func sortBars(data *ParsedData) {
+	idx := make([]int, len(data.Dates))
+	for i := range idx {
+		idx[i] = i
+	}
+	sort.SliceStable(idx, func(a, b int) bool { return data.Dates[idx[a]].Before(data.Dates[idx[b]]) })
+
+	dates := make([]time.Time, len(idx))
+	bars := make([]Bar, len(idx))
+	for i, j := range idx {
+		dates[i], bars[i] = data.Dates[j], data.Bars[j]
+	}
+	data.Dates, data.Bars = dates, bars
+}
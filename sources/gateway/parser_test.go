@@ -0,0 +1,80 @@
+//go:build gateway
+// +build gateway
+
+package gateway_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/julianshen/gonp-datareader/sources/gateway"
+)
+
+func TestParseGeneric(t *testing.T) {
+	tests := []struct {
+		name    string
+		body    string
+		want    int
+		wantErr bool
+	}{
+		{"bars", `{"symbol":"AAPL","bars":[{"time":"2024-01-02","close":1},{"time":"2024-01-02T14:30:00Z","close":2}]}`, 2, false},
+		{"empty", `{"symbol":"AAPL","bars":[]}`, 0, false},
+		{"error", `{"error":"not subscribed"}`, 0, true},
+		{"bad time", `{"bars":[{"time":"yesterday"}]}`, 0, true},
+		{"invalid JSON", `{`, 0, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			data, err := gateway.ParseGeneric([]byte(tt.body))
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("ParseGeneric() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if err == nil && len(data.Bars) != tt.want {
+				t.Errorf("ParseGeneric() bars = %d, want %d", len(data.Bars), tt.want)
+			}
+		})
+	}
+}
+
+func TestParseIBKRHistory(t *testing.T) {
+	body := []byte(`{"data":[{"o":1,"h":2,"l":0.5,"c":1.5,"v":10,"t":1704205800000}]}`)
+
+	data, err := gateway.ParseIBKRHistory(body, false)
+	if err != nil {
+		t.Fatalf("ParseIBKRHistory() error = %v", err)
+	}
+	if want := time.Date(2024, 1, 2, 14, 30, 0, 0, time.UTC); !data.Dates[0].Equal(want) {
+		t.Errorf("Dates[0] = %v, want %v", data.Dates[0], want)
+	}
+	if closes, _ := data.Float64Column("Close"); len(closes) != 1 || closes[0] != 1.5 {
+		t.Errorf("Close = %v, want [1.5]", closes)
+	}
+
+	data, err = gateway.ParseIBKRHistory(body, true)
+	if err != nil {
+		t.Fatalf("ParseIBKRHistory(daily) error = %v", err)
+	}
+	if want := time.Date(2024, 1, 2, 0, 0, 0, 0, time.UTC); !data.Dates[0].Equal(want) {
+		t.Errorf("Dates[0] = %v, want %v", data.Dates[0], want)
+	}
+}
+
+func TestParseIBKRConid(t *testing.T) {
+	tests := []struct {
+		body string
+		want string
+	}{
+		{`[{"conid":"265598"}]`, "265598"},
+		{`[{"conid":265598}]`, "265598"},
+		{`[{"conid":null},{"conid":8314}]`, "8314"},
+		{`[]`, ""},
+	}
+
+	for _, tt := range tests {
+		got, err := gateway.ParseIBKRConid([]byte(tt.body))
+		if err != nil || got != tt.want {
+			t.Errorf("ParseIBKRConid(%s) = %q, %v, want %q", tt.body, got, err, tt.want)
+		}
+	}
+}
@@ -42,6 +42,11 @@ func NewIEXReaderWithBaseURL(opts *internalhttp.ClientOptions, apiKey, baseURL s
 	}
 }
 
+// Schema returns the columns of the ParsedData returned by ReadSingle.
+func (i *IEXReader) Schema() sources.Schema {
+	return sources.NewSchema(i.Source(), i.Name(), chartDataPoint{})
+}
+
 // BuildURL constructs the IEX Cloud API URL for fetching historical chart data.
 // The IEX Cloud format is:
 // https://cloud.iexapis.com/stable/stock/{symbol}/chart/{range}?token={token}
@@ -54,6 +59,9 @@ func BuildURL(symbol, dateRange, apiKey string) string {
 	)
 }
 
+// maxHistoryYears is the history covered by the longest range, "5y"
+const maxHistoryYears = 5
+
 // CalculateDateRange converts start/end dates to IEX Cloud date range format.
 // IEX Cloud uses ranges like: 1m, 3m, 6m, 1y, 2y, 5y
 func CalculateDateRange(start, end time.Time) string {
@@ -78,11 +86,21 @@ func CalculateDateRange(start, end time.Time) string {
 
 // ReadSingle fetches data for a single stock symbol.
 func (i *IEXReader) ReadSingle(ctx context.Context, symbol string, start, end time.Time) (interface{}, error) {
+	return i.Finish(ctx, symbol, start, end, i.readSingle)
+}
+
+// readSingle implements ReadSingle before Finish is applied.
+func (i *IEXReader) readSingle(ctx context.Context, symbol string, start, end time.Time) (interface{}, error) {
+	// Normalize user input; the original is kept in Meta["symbol_input"]
+	input := symbol
+	symbol = i.NormalizeSymbol(symbol)
+
 	if err := i.ValidateSymbol(symbol); err != nil {
 		return nil, err
 	}
 
-	if i.apiKey == "" {
+	apiKey := internalhttp.APIKey(ctx, i.apiKey)
+	if apiKey == "" {
 		return nil, fmt.Errorf("API key is required for IEX Cloud")
 	}
 
@@ -92,13 +110,13 @@ func (i *IEXReader) ReadSingle(ctx context.Context, symbol string, start, end ti
 	// Build request URL - use custom baseURL if set (for testing), otherwise use standard format
 	var url string
 	if i.baseURL != "" {
-		url = fmt.Sprintf(i.baseURL, symbol, dateRange, i.apiKey)
+		url = fmt.Sprintf(i.baseURL, symbol, dateRange, apiKey)
 	} else {
-		url = BuildURL(symbol, dateRange, i.apiKey)
+		url = BuildURL(symbol, dateRange, apiKey)
 	}
 
 	// Create HTTP request
-	req, err := http.NewRequestWithContext(ctx, "GET", url, nil)
+	req, err := http.NewRequestWithContext(internalhttp.WithContentType(ctx, "application/json"), "GET", url, nil)
 	if err != nil {
 		return nil, fmt.Errorf("create request: %w", err)
 	}
@@ -116,7 +134,7 @@ func (i *IEXReader) ReadSingle(ctx context.Context, symbol string, start, end ti
 		if err != nil {
 			return nil, fmt.Errorf("IEX Cloud returned status %d (failed to read response body: %w)", resp.StatusCode, err)
 		}
-		return nil, fmt.Errorf("IEX Cloud returned status %d: %s", resp.StatusCode, string(body))
+		return nil, internalhttp.StatusError(resp, fmt.Errorf("IEX Cloud returned status %d: %s", resp.StatusCode, string(body)))
 	}
 
 	// Read response body
@@ -126,19 +144,38 @@ func (i *IEXReader) ReadSingle(ctx context.Context, symbol string, start, end ti
 	}
 
 	// Parse response
-	data, err := ParseResponse(body)
+	decoded, err := i.client.Decode("iex", body, func(b []byte) (interface{}, error) {
+		return ParseResponse(b)
+	})
 	if err != nil {
 		return nil, fmt.Errorf("parse IEX Cloud response: %w", err)
 	}
+	if len(decoded.(*ParsedData).Rows) == 0 {
+		return nil, fmt.Errorf("%w: no IEX Cloud prices of %s", sources.ErrNoData, symbol)
+	}
 
-	return data, nil
+	// Copy the decoded value, which may be shared through the decoded cache,
+	// and flag data served from an expired cache entry
+	data := *decoded.(*ParsedData)
+	data.Meta = sources.InputMeta(internalhttp.StaleMeta(resp), input, symbol)
+	data.Meta = sources.WarningsMeta(data.Meta, data.Warnings)
+
+	// The longest range covers the last five years only
+	if earliest := time.Now().AddDate(-maxHistoryYears, 0, 0); dateRange == "5y" && start.Before(earliest) {
+		data.Meta = sources.AddWarning(data.Meta, sources.Warning{
+			Code:    sources.WarnStartClamped,
+			Message: fmt.Sprintf("IEX Cloud serves at most %d years of history; start %s moved to %s", maxHistoryYears, start.Format("2006-01-02"), earliest.Format("2006-01-02")),
+		})
+	}
+
+	return &data, nil
 }
 
 // Read fetches data for multiple stock symbols from IEX Cloud.
 // Symbols are fetched in parallel for better performance.
 func (i *IEXReader) Read(ctx context.Context, symbols []string, start, end time.Time) (interface{}, error) {
 	// Validate inputs
-	if err := utils.ValidateSymbols(symbols); err != nil {
+	if err := utils.ValidateSymbols(i.NormalizeSymbols(symbols)); err != nil {
 		return nil, fmt.Errorf("invalid symbols: %w", err)
 	}
 
@@ -211,3 +248,14 @@ func (i *IEXReader) readParallel(ctx context.Context, symbols []string, start, e
 func (i *IEXReader) ValidateSymbol(symbol string) error {
 	return i.BaseSource.ValidateSymbol(symbol)
 }
+
+// Shutdown stops accepting requests and waits for in-flight requests to
+// finish, cancelling them if ctx expires first.
+func (i *IEXReader) Shutdown(ctx context.Context) error {
+	return i.client.Shutdown(ctx)
+}
+
+// Close cancels in-flight requests and releases the reader's connections.
+func (i *IEXReader) Close() error {
+	return i.client.Close()
+}
@@ -2,6 +2,7 @@ package iex_test
 
 import (
 	"context"
+	"errors"
 	"net/http"
 	"net/http/httptest"
 	"strings"
@@ -9,6 +10,7 @@ import (
 	"time"
 
 	internalhttp "github.com/julianshen/gonp-datareader/internal/http"
+	"github.com/julianshen/gonp-datareader/sources"
 	"github.com/julianshen/gonp-datareader/sources/iex"
 )
 
@@ -229,6 +231,40 @@ func TestIEXReader_ReadSingle_WithMockServer(t *testing.T) {
 	}
 }
 
+// TestIEXReader_ReadSingle_StartClamped tests that a range beyond the
+// five years IEX Cloud serves is flagged in Meta
+func TestIEXReader_ReadSingle_StartClamped(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`[{"date":"2023-01-04","open":128,"high":132,"low":127.5,"close":130,"volume":70000000}]`))
+	}))
+	defer server.Close()
+
+	reader := iex.NewIEXReaderWithBaseURL(nil, "test_key", server.URL+"?symbol=%s&range=%s&token=%s")
+
+	tests := []struct {
+		name  string
+		start time.Time
+		want  bool
+	}{
+		{"within five years", time.Now().AddDate(-1, 0, 0), false},
+		{"beyond five years", time.Now().AddDate(-10, 0, 0), true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result, err := reader.ReadSingle(context.Background(), "AAPL", tt.start, time.Now())
+			if err != nil {
+				t.Fatalf("ReadSingle() error = %v", err)
+			}
+			warnings := sources.Warnings(result.(*iex.ParsedData).Meta)
+			if got := len(warnings) == 1 && warnings[0].Code == sources.WarnStartClamped; got != tt.want {
+				t.Errorf("warnings = %+v, want start_clamped %v", warnings, tt.want)
+			}
+		})
+	}
+}
+
 // TestIEXReader_ReadSingle_InvalidSymbol tests error handling for invalid symbols
 func TestIEXReader_ReadSingle_InvalidSymbol(t *testing.T) {
 	reader := iex.NewIEXReader(nil, "test_key")
@@ -342,3 +378,20 @@ func TestIEXReader_HTTPError(t *testing.T) {
 		t.Error("ReadSingle() should return error for HTTP 500")
 	}
 }
+
+func TestIEXReader_ReadSingle_NoData(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`[]`))
+	}))
+	defer server.Close()
+
+	reader := iex.NewIEXReaderWithBaseURL(nil, "test_key", server.URL+"?symbol=%s&range=%s&token=%s")
+
+	start := time.Date(2023, 1, 1, 0, 0, 0, 0, time.UTC)
+	end := time.Date(2023, 1, 31, 0, 0, 0, 0, time.UTC)
+	_, err := reader.ReadSingle(context.Background(), "AAPL", start, end)
+	if !errors.Is(err, sources.ErrNoData) {
+		t.Errorf("ReadSingle() error = %v, want ErrNoData", err)
+	}
+}
@@ -4,22 +4,88 @@ import (
 	"encoding/json"
 	"fmt"
 	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/julianshen/gonp-datareader/ohlcv"
+	"github.com/julianshen/gonp-datareader/sources"
 )
 
 // ParsedData represents parsed IEX Cloud chart data.
 type ParsedData struct {
 	Columns []string
 	Rows    []map[string]string
+	// Meta holds response metadata such as "stale" when the data was
+	// served from an expired cache entry; nil when there is none.
+	Meta map[string]string
+	// Warnings lists the rows with null fields, one message per row
+	// (e.g., "2020-03-16: null open, high").
+	Warnings []string
+}
+
+// DateIndex returns the Date column parsed as dates.
+func (p *ParsedData) DateIndex() ([]time.Time, error) {
+	if p == nil {
+		return nil, nil
+	}
+	return sources.TimeColumn(nil, p.Columns, p.Rows, "Date")
+}
+
+// SelectRows keeps the rows at indices, in that order (see
+// sources.RowSelector).
+func (p *ParsedData) SelectRows(indices []int) {
+	if p == nil {
+		return
+	}
+	p.Rows = sources.SelectRows(p.Rows, indices)
+}
+
+// ColumnNames returns the value columns, excluding Date.
+func (p *ParsedData) ColumnNames() []string {
+	if p == nil {
+		return nil
+	}
+	return sources.ValueColumns(p.Columns, "Date")
 }
 
-// chartDataPoint represents a single day of IEX Cloud chart data.
+// Float64Column returns the named value column as float64 values, with
+// missing values as NaN.
+func (p *ParsedData) Float64Column(name string) ([]float64, error) {
+	if name == "Date" {
+		return nil, sources.NoColumn(name)
+	}
+	if p == nil {
+		return nil, sources.NoColumn(name)
+	}
+	return sources.FloatColumn(nil, p.Columns, p.Rows, name)
+}
+
+// Bars returns the price columns as ohlcv.Bars (see ohlcv.Provider).
+func (p *ParsedData) Bars() (*ohlcv.Bars, error) {
+	return ohlcv.FromTimeSeries(p)
+}
+
+// chartDataPoint represents a single day of IEX Cloud chart data and
+// defines the reader's Schema.
 type chartDataPoint struct {
-	Date   string  `json:"date"`
-	Open   float64 `json:"open"`
-	High   float64 `json:"high"`
-	Low    float64 `json:"low"`
-	Close  float64 `json:"close"`
-	Volume int64   `json:"volume"`
+	Date   string  `json:"date" schema:"Date,time"`
+	Open   float64 `json:"open" schema:"Open"`
+	High   float64 `json:"high" schema:"High"`
+	Low    float64 `json:"low" schema:"Low"`
+	Close  float64 `json:"close" schema:"Close"`
+	Volume float64 `json:"volume" schema:"Volume"`
+}
+
+// nullableDataPoint is the decoding form of chartDataPoint. Prices are
+// pointers because IEX returns null for OHLC fields on halted days.
+type nullableDataPoint struct {
+	Date   string   `json:"date"`
+	Open   *float64 `json:"open"`
+	High   *float64 `json:"high"`
+	Low    *float64 `json:"low"`
+	Close  *float64 `json:"close"`
+	Volume *float64 `json:"volume"`
 }
 
 // errorResponse represents an IEX Cloud API error.
@@ -37,7 +103,7 @@ func ParseResponse(data []byte) (*ParsedData, error) {
 	}
 
 	// Parse as array of chart data
-	var chartData []chartDataPoint
+	var chartData []nullableDataPoint
 	if err := json.Unmarshal(data, &chartData); err != nil {
 		return nil, fmt.Errorf("parse JSON: %w", err)
 	}
@@ -47,22 +113,42 @@ func ParseResponse(data []byte) (*ParsedData, error) {
 		return chartData[i].Date < chartData[j].Date
 	})
 
-	// Convert to parsed data format
+	// Convert to parsed data format; null fields are left empty, which
+	// converts to NaN, with one warning per affected row
 	rows := make([]map[string]string, 0, len(chartData))
+	var warnings []string
 	for _, point := range chartData {
+		var nulls []string
 		row := map[string]string{
 			"Date":   point.Date,
-			"Open":   fmt.Sprintf("%.2f", point.Open),
-			"High":   fmt.Sprintf("%.2f", point.High),
-			"Low":    fmt.Sprintf("%.2f", point.Low),
-			"Close":  fmt.Sprintf("%.2f", point.Close),
-			"Volume": fmt.Sprintf("%d", point.Volume),
+			"Open":   formatNullable(point.Open, "open", &nulls, formatPrice),
+			"High":   formatNullable(point.High, "high", &nulls, formatPrice),
+			"Low":    formatNullable(point.Low, "low", &nulls, formatPrice),
+			"Close":  formatNullable(point.Close, "close", &nulls, formatPrice),
+			"Volume": formatNullable(point.Volume, "volume", &nulls, formatVolume),
+		}
+		if len(nulls) > 0 {
+			warnings = append(warnings, fmt.Sprintf("%s: null %s", point.Date, strings.Join(nulls, ", ")))
 		}
 		rows = append(rows, row)
 	}
 
 	return &ParsedData{
-		Columns: []string{"Date", "Open", "High", "Low", "Close", "Volume"},
-		Rows:    rows,
+		Columns:  []string{"Date", "Open", "High", "Low", "Close", "Volume"},
+		Rows:     rows,
+		Warnings: warnings,
 	}, nil
 }
+
+// formatNullable formats *v, or returns "" with name appended to nulls
+// when v is nil.
+func formatNullable(v *float64, name string, nulls *[]string, format func(float64) string) string {
+	if v == nil {
+		*nulls = append(*nulls, name)
+		return ""
+	}
+	return format(*v)
+}
+
+func formatPrice(v float64) string  { return fmt.Sprintf("%.2f", v) }
+func formatVolume(v float64) string { return strconv.FormatFloat(v, 'f', -1, 64) }
@@ -68,6 +68,25 @@ func TestParseResponse(t *testing.T) {
 	}
 }
 
+func TestParseResponse_ExtremeVolumes(t *testing.T) {
+	jsonData := `[
+		{"date": "2024-01-02", "close": 1, "volume": 0.00012345},
+		{"date": "2024-01-03", "close": 1, "volume": 2.5e20}
+	]`
+
+	data, err := iex.ParseResponse([]byte(jsonData))
+	if err != nil {
+		t.Fatalf("ParseResponse failed: %v", err)
+	}
+
+	if got := data.Rows[0]["Volume"]; got != "0.00012345" {
+		t.Errorf("Expected fractional volume '0.00012345', got %q", got)
+	}
+	if got := data.Rows[1]["Volume"]; got != "250000000000000000000" {
+		t.Errorf("Expected volume '250000000000000000000', got %q", got)
+	}
+}
+
 func TestParseResponse_EmptyArray(t *testing.T) {
 	jsonData := `[]`
 
@@ -142,3 +161,40 @@ func TestParseResponse_ErrorMessage(t *testing.T) {
 		t.Errorf("Expected 'API error' in error message, got %q", err.Error())
 	}
 }
+
+func TestParseResponse_NullPrices(t *testing.T) {
+	jsonData := `[
+		{"date": "2020-03-17", "open": 10.5, "high": 11, "low": 10, "close": 10.75, "volume": 1000},
+		{"date": "2020-03-16", "open": null, "high": null, "low": null, "close": 10.25, "volume": 0},
+		{"date": "2020-03-18", "open": 11, "high": 12, "low": 10.5, "close": 11.5}
+	]`
+
+	data, err := iex.ParseResponse([]byte(jsonData))
+	if err != nil {
+		t.Fatalf("ParseResponse failed: %v", err)
+	}
+
+	tests := []struct {
+		row    int
+		column string
+		want   string
+	}{
+		{0, "Open", ""},
+		{0, "High", ""},
+		{0, "Low", ""},
+		{0, "Close", "10.25"},
+		{0, "Volume", "0"},
+		{1, "Open", "10.50"},
+		{2, "Volume", ""},
+	}
+	for _, tt := range tests {
+		if got := data.Rows[tt.row][tt.column]; got != tt.want {
+			t.Errorf("Rows[%d][%s] = %q, want %q", tt.row, tt.column, got, tt.want)
+		}
+	}
+
+	want := []string{"2020-03-16: null open, high, low", "2020-03-18: null volume"}
+	if strings.Join(data.Warnings, "|") != strings.Join(want, "|") {
+		t.Errorf("Warnings = %v, want %v", data.Warnings, want)
+	}
+}
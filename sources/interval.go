@@ -0,0 +1,95 @@
+package sources
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"strings"
+	"time"
+)
+
+// ErrUnsupportedInterval is returned for a bar interval a source does not
+// serve.
+var ErrUnsupportedInterval = errors.New("unsupported interval")
+
+// Interval is the length of a price bar, as named by Yahoo Finance.
+type Interval string
+
+// Bar intervals. The empty Interval means each source's default, daily
+// bars.
+const (
+	Interval1m  Interval = "1m"
+	Interval5m  Interval = "5m"
+	Interval15m Interval = "15m"
+	Interval30m Interval = "30m"
+	Interval1h  Interval = "1h"
+	Interval1d  Interval = "1d"
+	Interval1wk Interval = "1wk"
+	Interval1mo Interval = "1mo"
+)
+
+// sessionMinutes is the length of a regular US trading session, used to
+// estimate the intraday bars of a session.
+const sessionMinutes = 390
+
+// minutes holds the length of each intraday interval.
+var minutes = map[Interval]int{
+	Interval1m:  1,
+	Interval5m:  5,
+	Interval15m: 15,
+	Interval30m: 30,
+	Interval1h:  60,
+}
+
+// OrDefault returns i, or Interval1d when i is empty.
+func (i Interval) OrDefault() Interval {
+	if i == "" {
+		return Interval1d
+	}
+	return i
+}
+
+// IsIntraday reports whether bars are shorter than a day.
+func (i Interval) IsIntraday() bool {
+	_, ok := minutes[i]
+	return ok
+}
+
+// Duration returns the length of an intraday bar, or 0 for daily and
+// longer bars, whose length depends on the calendar.
+func (i Interval) Duration() time.Duration {
+	return time.Duration(minutes[i]) * time.Minute
+}
+
+// Bars estimates the bars in a range of sessions trading sessions,
+// rounding up, for sizing requests before they are sent.
+func (i Interval) Bars(sessions int) int {
+	switch i = i.OrDefault(); {
+	case i.IsIntraday():
+		return sessions * int(math.Ceil(float64(sessionMinutes)/float64(minutes[i])))
+	case i == Interval1wk:
+		return (sessions + 4) / 5
+	case i == Interval1mo:
+		return (sessions + 20) / 21
+	default:
+		return sessions
+	}
+}
+
+// CheckInterval returns an error matching ErrUnsupportedInterval unless i
+// is empty or one of supported.
+func CheckInterval(i Interval, supported []Interval) error {
+	if i == "" {
+		return nil
+	}
+	for _, s := range supported {
+		if i == s {
+			return nil
+		}
+	}
+	names := make([]string, len(supported))
+	for n, s := range supported {
+		names[n] = string(s)
+	}
+	return fmt.Errorf("%w: %q (supported: %s)", ErrUnsupportedInterval, i, strings.Join(names, ", "))
+}
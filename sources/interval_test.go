@@ -0,0 +1,44 @@
+package sources_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/julianshen/gonp-datareader/sources"
+)
+
+func TestInterval_Bars(t *testing.T) {
+	tests := []struct {
+		interval sources.Interval
+		sessions int
+		want     int
+	}{
+		{"", 252, 252},
+		{sources.Interval1d, 252, 252},
+		{sources.Interval1wk, 252, 51},
+		{sources.Interval1mo, 252, 12},
+		{sources.Interval1h, 5, 35},
+		{sources.Interval1m, 5, 1950},
+	}
+	for _, tt := range tests {
+		if got := tt.interval.Bars(tt.sessions); got != tt.want {
+			t.Errorf("Interval(%q).Bars(%d) = %d, want %d", tt.interval, tt.sessions, got, tt.want)
+		}
+	}
+}
+
+func TestCheckInterval(t *testing.T) {
+	supported := []sources.Interval{sources.Interval1d, sources.Interval1wk}
+	for _, i := range []sources.Interval{"", sources.Interval1d, sources.Interval1wk} {
+		if err := sources.CheckInterval(i, supported); err != nil {
+			t.Errorf("CheckInterval(%q) error = %v", i, err)
+		}
+	}
+	err := sources.CheckInterval(sources.Interval5m, supported)
+	if !errors.Is(err, sources.ErrUnsupportedInterval) {
+		t.Fatalf("CheckInterval(5m) error = %v, want ErrUnsupportedInterval", err)
+	}
+	if want := `unsupported interval: "5m" (supported: 1d, 1wk)`; err.Error() != want {
+		t.Errorf("error = %q, want %q", err, want)
+	}
+}
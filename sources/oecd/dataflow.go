@@ -0,0 +1,367 @@
+package oecd
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"sort"
+	"strings"
+
+	internalhttp "github.com/julianshen/gonp-datareader/internal/http"
+)
+
+// oecdStructureURL is the base URL of the OECD SDMX REST structure API.
+const oecdStructureURL = "https://sdmx.oecd.org/public/rest"
+
+// sdmxStructureJSON is the media type of SDMX-JSON structure messages.
+const sdmxStructureJSON = "application/vnd.sdmx.structure+json"
+
+// ErrDataflowNotFound is returned by DescribeDataflow for an unknown
+// dataflow.
+var ErrDataflowNotFound = errors.New("dataflow not found")
+
+// Dataflow describes one OECD dataset.
+type Dataflow struct {
+	// ID is the dataflow identifier, e.g. "DSD_NAMAIN10@DF_TABLE1_EXPENDITURE".
+	ID string
+	// Agency is the maintaining agency, e.g. "OECD.SDD.NAD".
+	Agency string
+	// Version is the dataflow version, e.g. "2.0".
+	Version string
+	// Name is the dataflow's English name.
+	Name string
+	// Description is the dataflow's English description, if any.
+	Description string
+}
+
+// Dimension is one key dimension of a dataflow.
+type Dimension struct {
+	// ID is the dimension identifier, e.g. "REF_AREA".
+	ID string
+	// Name is the dimension's English name, e.g. "Reference area".
+	Name string
+	// Position is the dimension's zero-based position in a data key.
+	Position int
+	// Codes lists the values the dimension takes; empty when the
+	// dimension is not coded.
+	Codes []Code
+}
+
+// Code is one value of a coded dimension.
+type Code struct {
+	// ID is the code used in data keys, e.g. "USA".
+	ID string
+	// Name is the code's English name, e.g. "United States".
+	Name string
+}
+
+// DataflowStructure describes a dataflow and the dimensions of its data
+// keys.
+type DataflowStructure struct {
+	Dataflow
+	// Dimensions lists the key dimensions in key order; the time
+	// dimension is not included.
+	Dimensions []Dimension
+}
+
+// SetStructureURL sets the base URL of the SDMX structure API used by
+// ListDataflows and DescribeDataflow. This is primarily used for testing
+// with mock servers.
+func (o *OECDReader) SetStructureURL(url string) {
+	o.structureURL = strings.TrimSuffix(url, "/")
+}
+
+// ListDataflows lists the dataflows (datasets) published by the OECD.
+//
+// # Example Usage
+//
+//	reader := oecd.NewOECDReader(nil)
+//	flows, err := reader.ListDataflows(ctx)
+//	for _, flow := range flows {
+//		fmt.Println(flow.ID, flow.Name)
+//	}
+func (o *OECDReader) ListDataflows(ctx context.Context) ([]Dataflow, error) {
+	body, err := o.getStructure(ctx, "/dataflow/all/all/latest")
+	if err != nil {
+		return nil, err
+	}
+
+	flows, err := ParseDataflows(body)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse dataflows: %w", err)
+	}
+	return flows, nil
+}
+
+// DescribeDataflow fetches a dataflow with its key dimensions and their
+// codes. The id is a dataflow ID, optionally qualified by agency and
+// version as "AGENCY,ID" or "AGENCY,ID,VERSION". An unknown dataflow
+// returns ErrDataflowNotFound.
+//
+// # Example Usage
+//
+//	structure, err := reader.DescribeDataflow(ctx, "OECD.SDD.NAD,DSD_NAMAIN10@DF_TABLE1_EXPENDITURE")
+//	for _, dim := range structure.Dimensions {
+//		fmt.Println(dim.Position, dim.ID, len(dim.Codes))
+//	}
+func (o *OECDReader) DescribeDataflow(ctx context.Context, id string) (*DataflowStructure, error) {
+	agency, flowID, version, err := parseFlowRef(id)
+	if err != nil {
+		return nil, err
+	}
+
+	path := fmt.Sprintf("/dataflow/%s/%s/%s?references=all",
+		url.PathEscape(agency), url.PathEscape(flowID), url.PathEscape(version))
+	body, err := o.getStructure(ctx, path)
+	if err != nil {
+		return nil, err
+	}
+
+	structure, err := ParseDataflowStructure(body, flowID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse dataflow %s: %w", id, err)
+	}
+	return structure, nil
+}
+
+// parseFlowRef splits a dataflow reference "ID", "AGENCY,ID" or
+// "AGENCY,ID,VERSION", defaulting to any agency and the latest version.
+func parseFlowRef(ref string) (agency, id, version string, err error) {
+	parts := strings.Split(strings.TrimSpace(ref), ",")
+	for i := range parts {
+		parts[i] = strings.TrimSpace(parts[i])
+	}
+
+	agency, version = "all", "latest"
+	switch len(parts) {
+	case 1:
+		id = parts[0]
+	case 2:
+		agency, id = parts[0], parts[1]
+	case 3:
+		agency, id, version = parts[0], parts[1], parts[2]
+	default:
+		return "", "", "", fmt.Errorf("invalid dataflow %q", ref)
+	}
+	if id == "" || agency == "" || version == "" || strings.ContainsAny(ref, " /") {
+		return "", "", "", fmt.Errorf("invalid dataflow %q", ref)
+	}
+	return agency, id, version, nil
+}
+
+// getStructure fetches an SDMX-JSON structure message.
+func (o *OECDReader) getStructure(ctx context.Context, path string) ([]byte, error) {
+	req, err := http.NewRequestWithContext(internalhttp.WithContentType(ctx, "application/json"), "GET", o.structureURL+path, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create request: %w", err)
+	}
+	req.Header.Set("Accept", sdmxStructureJSON)
+	req.Header.Set("Accept-Language", "en")
+
+	resp, err := o.client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch structure: %w", err)
+	}
+	defer resp.Body.Close()
+
+	// The SDMX API answers 404 when no structure matches the query
+	if resp.StatusCode == http.StatusNotFound {
+		return nil, ErrDataflowNotFound
+	}
+	if resp.StatusCode != http.StatusOK {
+		body, _ := io.ReadAll(resp.Body) //nolint:errcheck // Best effort error message
+		return nil, internalhttp.StatusError(resp, fmt.Errorf("OECD returned status %d: %s", resp.StatusCode, string(body)))
+	}
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read response: %w", err)
+	}
+
+	return body, nil
+}
+
+// sdmxText is an SDMX-JSON localised text: "name" in the requested
+// language, or "names" keyed by language.
+type sdmxText struct {
+	Text  string            `json:"name"`
+	Texts map[string]string `json:"names"`
+}
+
+// english returns the English text, falling back to any language.
+func (t sdmxText) english() string {
+	if t.Text != "" {
+		return t.Text
+	}
+	if text, ok := t.Texts["en"]; ok {
+		return text
+	}
+	langs := make([]string, 0, len(t.Texts))
+	for lang := range t.Texts {
+		langs = append(langs, lang)
+	}
+	sort.Strings(langs)
+	if len(langs) > 0 {
+		return t.Texts[langs[0]]
+	}
+	return ""
+}
+
+// sdmxArtefact holds the fields shared by SDMX structures.
+type sdmxArtefact struct {
+	ID      string `json:"id"`
+	Agency  string `json:"agencyID"`
+	Version string `json:"version"`
+	sdmxText
+	Description  string            `json:"description"`
+	Descriptions map[string]string `json:"descriptions"`
+}
+
+// key returns the artefact's "AGENCY:ID(VERSION)" reference, as used in
+// SDMX URNs.
+func (a sdmxArtefact) key() string {
+	return fmt.Sprintf("%s:%s(%s)", a.Agency, a.ID, a.Version)
+}
+
+// dataflow converts the artefact to a Dataflow.
+func (a sdmxArtefact) dataflow() Dataflow {
+	return Dataflow{
+		ID:          a.ID,
+		Agency:      a.Agency,
+		Version:     a.Version,
+		Name:        a.english(),
+		Description: sdmxText{Text: a.Description, Texts: a.Descriptions}.english(),
+	}
+}
+
+// structureResponse represents an SDMX-JSON structure message.
+type structureResponse struct {
+	Data struct {
+		Dataflows []struct {
+			sdmxArtefact
+			Structure string `json:"structure"`
+		} `json:"dataflows"`
+		DataStructures []struct {
+			sdmxArtefact
+			Components struct {
+				DimensionList struct {
+					Dimensions []struct {
+						ID                  string `json:"id"`
+						Position            int    `json:"position"`
+						ConceptIdentity     string `json:"conceptIdentity"`
+						LocalRepresentation struct {
+							Enumeration string `json:"enumeration"`
+						} `json:"localRepresentation"`
+					} `json:"dimensions"`
+				} `json:"dimensionList"`
+			} `json:"dataStructureComponents"`
+		} `json:"dataStructures"`
+		Codelists []struct {
+			sdmxArtefact
+			Codes []struct {
+				ID string `json:"id"`
+				sdmxText
+			} `json:"codes"`
+		} `json:"codelists"`
+		ConceptSchemes []struct {
+			sdmxArtefact
+			Concepts []struct {
+				ID string `json:"id"`
+				sdmxText
+			} `json:"concepts"`
+		} `json:"conceptSchemes"`
+	} `json:"data"`
+}
+
+// ParseDataflows parses an SDMX-JSON structure message listing dataflows.
+func ParseDataflows(body []byte) ([]Dataflow, error) {
+	var resp structureResponse
+	if err := json.Unmarshal(body, &resp); err != nil {
+		return nil, fmt.Errorf("failed to decode JSON: %w", err)
+	}
+
+	flows := make([]Dataflow, 0, len(resp.Data.Dataflows))
+	for _, flow := range resp.Data.Dataflows {
+		flows = append(flows, flow.dataflow())
+	}
+	return flows, nil
+}
+
+// ParseDataflowStructure parses an SDMX-JSON structure message holding the
+// dataflow id with its data structure, codelists and concept schemes.
+func ParseDataflowStructure(body []byte, id string) (*DataflowStructure, error) {
+	var resp structureResponse
+	if err := json.Unmarshal(body, &resp); err != nil {
+		return nil, fmt.Errorf("failed to decode JSON: %w", err)
+	}
+
+	var structure *DataflowStructure
+	var dsdRef string
+	for _, flow := range resp.Data.Dataflows {
+		if flow.ID == id {
+			structure = &DataflowStructure{Dataflow: flow.dataflow()}
+			dsdRef, _ = urnRef(flow.Structure)
+			break
+		}
+	}
+	if structure == nil {
+		return nil, ErrDataflowNotFound
+	}
+
+	// Index codelists and concepts by their URN references
+	codes := make(map[string][]Code)
+	for _, list := range resp.Data.Codelists {
+		values := make([]Code, 0, len(list.Codes))
+		for _, code := range list.Codes {
+			values = append(values, Code{ID: code.ID, Name: code.english()})
+		}
+		codes[list.key()] = values
+	}
+	concepts := make(map[string]string)
+	for _, scheme := range resp.Data.ConceptSchemes {
+		for _, concept := range scheme.Concepts {
+			concepts[scheme.key()+"."+concept.ID] = concept.english()
+		}
+	}
+
+	for _, dsd := range resp.Data.DataStructures {
+		if dsdRef != "" && dsd.key() != dsdRef {
+			continue
+		}
+		for _, dim := range dsd.Components.DimensionList.Dimensions {
+			dimension := Dimension{ID: dim.ID, Position: dim.Position}
+			if ref, item := urnRef(dim.ConceptIdentity); ref != "" {
+				dimension.Name = concepts[ref+"."+item]
+			}
+			if ref, _ := urnRef(dim.LocalRepresentation.Enumeration); ref != "" {
+				dimension.Codes = codes[ref]
+			}
+			structure.Dimensions = append(structure.Dimensions, dimension)
+		}
+		break
+	}
+	sort.SliceStable(structure.Dimensions, func(i, j int) bool {
+		return structure.Dimensions[i].Position < structure.Dimensions[j].Position
+	})
+
+	return structure, nil
+}
+
+// urnRef splits an SDMX URN such as
+// "urn:sdmx:org.sdmx.infomodel.conceptscheme.Concept=OECD:CS_COMMON(2.0).REF_AREA"
+// into its artefact reference "OECD:CS_COMMON(2.0)" and item "REF_AREA".
+func urnRef(urn string) (ref, item string) {
+	i := strings.LastIndex(urn, "=")
+	if i < 0 {
+		return "", ""
+	}
+	ref = urn[i+1:]
+	if j := strings.LastIndex(ref, ")"); j >= 0 && j+1 < len(ref) {
+		ref, item = ref[:j+1], strings.TrimPrefix(ref[j+1:], ".")
+	}
+	return ref, item
+}
@@ -0,0 +1,265 @@
+package oecd_test
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/julianshen/gonp-datareader/sources/oecd"
+)
+
+const dataflowsJSON = `{
+  "data": {
+    "dataflows": [
+      {
+        "id": "DSD_NAMAIN10@DF_TABLE1_EXPENDITURE",
+        "agencyID": "OECD.SDD.NAD",
+        "version": "2.0",
+        "name": "Annual GDP and components - expenditure approach",
+        "description": "Gross domestic product by expenditure"
+      },
+      {
+        "id": "DSD_PRICES@DF_PRICES_ALL",
+        "agencyID": "OECD.SDD.TPS",
+        "version": "1.0",
+        "names": {"fr": "Prix", "en": "Consumer price indices"}
+      }
+    ]
+  }
+}`
+
+const structureJSON = `{
+  "data": {
+    "dataflows": [
+      {
+        "id": "DSD_PRICES@DF_PRICES_ALL",
+        "agencyID": "OECD.SDD.TPS",
+        "version": "1.0",
+        "name": "Consumer price indices",
+        "structure": "urn:sdmx:org.sdmx.infomodel.datastructure.DataStructure=OECD.SDD.TPS:DSD_PRICES(1.0)"
+      }
+    ],
+    "dataStructures": [
+      {
+        "id": "DSD_PRICES",
+        "agencyID": "OECD.SDD.TPS",
+        "version": "1.0",
+        "dataStructureComponents": {
+          "dimensionList": {
+            "dimensions": [
+              {
+                "id": "FREQ",
+                "position": 1,
+                "conceptIdentity": "urn:sdmx:org.sdmx.infomodel.conceptscheme.Concept=OECD:CS_COMMON(2.0).FREQ",
+                "localRepresentation": {"enumeration": "urn:sdmx:org.sdmx.infomodel.codelist.Codelist=SDMX:CL_FREQ(2.1)"}
+              },
+              {
+                "id": "REF_AREA",
+                "position": 0,
+                "conceptIdentity": "urn:sdmx:org.sdmx.infomodel.conceptscheme.Concept=OECD:CS_COMMON(2.0).REF_AREA",
+                "localRepresentation": {"enumeration": "urn:sdmx:org.sdmx.infomodel.codelist.Codelist=OECD:CL_AREA(1.1)"}
+              }
+            ],
+            "timeDimensions": [{"id": "TIME_PERIOD", "position": 2}]
+          }
+        }
+      }
+    ],
+    "codelists": [
+      {
+        "id": "CL_AREA",
+        "agencyID": "OECD",
+        "version": "1.1",
+        "codes": [
+          {"id": "USA", "name": "United States"},
+          {"id": "JPN", "names": {"en": "Japan"}}
+        ]
+      },
+      {
+        "id": "CL_FREQ",
+        "agencyID": "SDMX",
+        "version": "2.1",
+        "codes": [{"id": "M", "name": "Monthly"}]
+      }
+    ],
+    "conceptSchemes": [
+      {
+        "id": "CS_COMMON",
+        "agencyID": "OECD",
+        "version": "2.0",
+        "concepts": [
+          {"id": "REF_AREA", "name": "Reference area"},
+          {"id": "FREQ", "name": "Frequency of observation"}
+        ]
+      }
+    ]
+  }
+}`
+
+func TestOECDReader_ListDataflows(t *testing.T) {
+	var gotPath, gotAccept string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		gotPath, gotAccept = r.URL.Path, r.Header.Get("Accept")
+		w.Header().Set("Content-Type", "application/vnd.sdmx.structure+json; version=1.0; charset=utf-8")
+		w.Write([]byte(dataflowsJSON))
+	}))
+	defer server.Close()
+
+	reader := oecd.NewOECDReader(nil)
+	reader.SetStructureURL(server.URL)
+
+	flows, err := reader.ListDataflows(context.Background())
+	if err != nil {
+		t.Fatalf("ListDataflows() error = %v", err)
+	}
+
+	if gotPath != "/dataflow/all/all/latest" {
+		t.Errorf("path = %q, want /dataflow/all/all/latest", gotPath)
+	}
+	if gotAccept != "application/vnd.sdmx.structure+json" {
+		t.Errorf("Accept = %q", gotAccept)
+	}
+
+	want := []oecd.Dataflow{
+		{
+			ID:          "DSD_NAMAIN10@DF_TABLE1_EXPENDITURE",
+			Agency:      "OECD.SDD.NAD",
+			Version:     "2.0",
+			Name:        "Annual GDP and components - expenditure approach",
+			Description: "Gross domestic product by expenditure",
+		},
+		{
+			ID:      "DSD_PRICES@DF_PRICES_ALL",
+			Agency:  "OECD.SDD.TPS",
+			Version: "1.0",
+			Name:    "Consumer price indices",
+		},
+	}
+	if len(flows) != len(want) {
+		t.Fatalf("got %d dataflows, want %d", len(flows), len(want))
+	}
+	for i := range want {
+		if flows[i] != want[i] {
+			t.Errorf("flows[%d] = %+v, want %+v", i, flows[i], want[i])
+		}
+	}
+}
+
+func TestOECDReader_DescribeDataflow(t *testing.T) {
+	tests := []struct {
+		name     string
+		id       string
+		wantPath string
+	}{
+		{
+			name:     "bare ID",
+			id:       "DSD_PRICES@DF_PRICES_ALL",
+			wantPath: "/dataflow/all/DSD_PRICES@DF_PRICES_ALL/latest",
+		},
+		{
+			name:     "agency and ID",
+			id:       "OECD.SDD.TPS,DSD_PRICES@DF_PRICES_ALL",
+			wantPath: "/dataflow/OECD.SDD.TPS/DSD_PRICES@DF_PRICES_ALL/latest",
+		},
+		{
+			name:     "agency, ID and version",
+			id:       "OECD.SDD.TPS,DSD_PRICES@DF_PRICES_ALL,1.0",
+			wantPath: "/dataflow/OECD.SDD.TPS/DSD_PRICES@DF_PRICES_ALL/1.0",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var gotPath, gotQuery string
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				gotPath, gotQuery = r.URL.Path, r.URL.RawQuery
+				w.Header().Set("Content-Type", "application/vnd.sdmx.structure+json")
+				w.Write([]byte(structureJSON))
+			}))
+			defer server.Close()
+
+			reader := oecd.NewOECDReader(nil)
+			reader.SetStructureURL(server.URL + "/")
+
+			structure, err := reader.DescribeDataflow(context.Background(), tt.id)
+			if err != nil {
+				t.Fatalf("DescribeDataflow() error = %v", err)
+			}
+			if gotPath != tt.wantPath {
+				t.Errorf("path = %q, want %q", gotPath, tt.wantPath)
+			}
+			if gotQuery != "references=all" {
+				t.Errorf("query = %q, want references=all", gotQuery)
+			}
+
+			if structure.ID != "DSD_PRICES@DF_PRICES_ALL" || structure.Name != "Consumer price indices" {
+				t.Errorf("dataflow = %+v", structure.Dataflow)
+			}
+			if len(structure.Dimensions) != 2 {
+				t.Fatalf("got %d dimensions, want 2", len(structure.Dimensions))
+			}
+
+			area := structure.Dimensions[0]
+			if area.ID != "REF_AREA" || area.Name != "Reference area" || area.Position != 0 {
+				t.Errorf("Dimensions[0] = %+v, want REF_AREA first", area)
+			}
+			wantCodes := []oecd.Code{{ID: "USA", Name: "United States"}, {ID: "JPN", Name: "Japan"}}
+			if len(area.Codes) != len(wantCodes) {
+				t.Fatalf("REF_AREA codes = %v, want %v", area.Codes, wantCodes)
+			}
+			for i := range wantCodes {
+				if area.Codes[i] != wantCodes[i] {
+					t.Errorf("REF_AREA code %d = %+v, want %+v", i, area.Codes[i], wantCodes[i])
+				}
+			}
+
+			freq := structure.Dimensions[1]
+			if freq.ID != "FREQ" || freq.Name != "Frequency of observation" || len(freq.Codes) != 1 {
+				t.Errorf("Dimensions[1] = %+v", freq)
+			}
+		})
+	}
+}
+
+func TestOECDReader_DescribeDataflow_Errors(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		http.Error(w, "NoResultsFound", http.StatusNotFound)
+	}))
+	defer server.Close()
+
+	reader := oecd.NewOECDReader(nil)
+	reader.SetStructureURL(server.URL)
+
+	tests := []struct {
+		name     string
+		id       string
+		notFound bool
+	}{
+		{name: "unknown dataflow", id: "DF_MISSING", notFound: true},
+		{name: "empty", id: ""},
+		{name: "too many parts", id: "A,B,C,D"},
+		{name: "empty ID", id: "OECD,"},
+		{name: "slash", id: "MEI/USA"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := reader.DescribeDataflow(context.Background(), tt.id)
+			if err == nil {
+				t.Fatal("DescribeDataflow() expected error")
+			}
+			if got := errors.Is(err, oecd.ErrDataflowNotFound); got != tt.notFound {
+				t.Errorf("errors.Is(err, ErrDataflowNotFound) = %v, want %v (err = %v)", got, tt.notFound, err)
+			}
+		})
+	}
+}
+
+func TestParseDataflowStructure_MissingDataflow(t *testing.T) {
+	_, err := oecd.ParseDataflowStructure([]byte(structureJSON), "DF_OTHER")
+	if !errors.Is(err, oecd.ErrDataflowNotFound) {
+		t.Errorf("ParseDataflowStructure() error = %v, want ErrDataflowNotFound", err)
+	}
+}
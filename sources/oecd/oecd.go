@@ -2,6 +2,7 @@
 package oecd
 
 import (
+	"bytes"
 	"context"
 	"fmt"
 	"io"
@@ -22,8 +23,9 @@ const (
 // OECDReader fetches data from OECD API.
 type OECDReader struct {
 	*sources.BaseSource
-	client  *internalhttp.RetryableClient
-	baseURL string
+	client       *internalhttp.RetryableClient
+	baseURL      string
+	structureURL string
 }
 
 // NewOECDReader creates a new OECD data reader.
@@ -39,9 +41,10 @@ func NewOECDReaderWithBaseURL(opts *internalhttp.ClientOptions, baseURL string)
 	}
 
 	return &OECDReader{
-		BaseSource: sources.NewBaseSource("oecd"),
-		client:     internalhttp.NewRetryableClient(opts),
-		baseURL:    baseURL,
+		BaseSource:   sources.NewBaseSource("oecd"),
+		client:       internalhttp.NewRetryableClient(opts),
+		baseURL:      baseURL,
+		structureURL: oecdStructureURL,
 	}
 }
 
@@ -50,10 +53,17 @@ func (o *OECDReader) Name() string {
 	return "OECD"
 }
 
+// Schema returns the columns of the ParsedData returned by ReadSingle.
+func (o *OECDReader) Schema() sources.Schema {
+	return sources.NewSchema(o.Source(), o.Name(), ParsedData{})
+}
+
 // ValidateSymbol validates an OECD dataset identifier.
 // OECD symbols are in the format "DATASET/DIMENSIONS" or just "DATASET".
 // Examples: "MEI/USA", "QNA/AUS.GDP", "REGION_ECONOM"
 func (o *OECDReader) ValidateSymbol(symbol string) error {
+	symbol = o.NormalizeSymbol(symbol)
+
 	if symbol == "" {
 		return fmt.Errorf("symbol cannot be empty")
 	}
@@ -82,6 +92,15 @@ func (o *OECDReader) BuildURL(symbol string, start, end time.Time) string {
 
 // ReadSingle fetches data for a single symbol from OECD.
 func (o *OECDReader) ReadSingle(ctx context.Context, symbol string, start, end time.Time) (interface{}, error) {
+	return o.Finish(ctx, symbol, start, end, o.readSingle)
+}
+
+// readSingle implements ReadSingle before Finish is applied.
+func (o *OECDReader) readSingle(ctx context.Context, symbol string, start, end time.Time) (interface{}, error) {
+	// Normalize user input; the original is kept in Meta["symbol_input"]
+	input := symbol
+	symbol = o.NormalizeSymbol(symbol)
+
 	// Validate inputs
 	if err := o.ValidateSymbol(symbol); err != nil {
 		return nil, fmt.Errorf("invalid symbol: %w", err)
@@ -95,7 +114,7 @@ func (o *OECDReader) ReadSingle(ctx context.Context, symbol string, start, end t
 	url := o.BuildURL(symbol, start, end)
 
 	// Create HTTP request
-	req, err := http.NewRequestWithContext(ctx, "GET", url, nil)
+	req, err := http.NewRequestWithContext(internalhttp.WithContentType(ctx, "application/json"), "GET", url, nil)
 	if err != nil {
 		return nil, fmt.Errorf("failed to create request: %w", err)
 	}
@@ -116,23 +135,36 @@ func (o *OECDReader) ReadSingle(ctx context.Context, symbol string, start, end t
 		if err != nil {
 			return nil, fmt.Errorf("OECD returned status %d (failed to read response body: %w)", resp.StatusCode, err)
 		}
-		return nil, fmt.Errorf("OECD returned status %d: %s", resp.StatusCode, string(body))
+		return nil, internalhttp.StatusError(resp, fmt.Errorf("OECD returned status %d: %s", resp.StatusCode, string(body)))
+	}
+
+	// Read response body
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read response: %w", err)
 	}
 
 	// Parse JSON response
-	data, err := ParseJSON(resp.Body)
+	decoded, err := o.client.Decode("oecd", body, func(b []byte) (interface{}, error) {
+		return ParseJSON(bytes.NewReader(b))
+	})
 	if err != nil {
 		return nil, fmt.Errorf("failed to parse JSON: %w", err)
 	}
 
-	return data, nil
+	// Copy the decoded value, which may be shared through the decoded cache,
+	// and flag data served from an expired cache entry
+	data := *decoded.(*ParsedData)
+	data.Meta = sources.InputMeta(internalhttp.StaleMeta(resp), input, symbol)
+
+	return &data, nil
 }
 
 // Read fetches data for multiple symbols from OECD.
 // Symbols are fetched in parallel for better performance.
 func (o *OECDReader) Read(ctx context.Context, symbols []string, start, end time.Time) (interface{}, error) {
 	// Validate inputs
-	if err := utils.ValidateSymbols(symbols); err != nil {
+	if err := utils.ValidateSymbols(o.NormalizeSymbols(symbols)); err != nil {
 		return nil, fmt.Errorf("invalid symbols: %w", err)
 	}
 
@@ -200,3 +232,14 @@ func (o *OECDReader) readParallel(ctx context.Context, symbols []string, start,
 
 	return dataMap, nil
 }
+
+// Shutdown stops accepting requests and waits for in-flight requests to
+// finish, cancelling them if ctx expires first.
+func (o *OECDReader) Shutdown(ctx context.Context) error {
+	return o.client.Shutdown(ctx)
+}
+
+// Close cancels in-flight requests and releases the reader's connections.
+func (o *OECDReader) Close() error {
+	return o.client.Close()
+}
@@ -0,0 +1,81 @@
+package sources
+
+import (
+	"sort"
+)
+
+// RowSelector is implemented by the ParsedData of every built-in source,
+// so results can be sorted and deduplicated without knowing the source.
+type RowSelector interface {
+	TimeSeries
+
+	// SelectRows keeps the rows at indices, rows of DateIndex, in that
+	// order.
+	SelectRows(indices []int)
+}
+
+// SortRows sorts data, the ParsedData of any source, ascending by date and
+// drops rows repeating a date, keeping the last row the source returned
+// for it, such as a correction appended to a snapshot. Sources differ:
+// Yahoo returns ascending dates, Alpha Vantage and IEX descending ones.
+// It returns the number of rows dropped.
+//
+// Data already in ascending order without duplicates is not modified, and
+// data that does not implement RowSelector is returned as is. Rows with a
+// date DateIndex cannot parse cause an error and leave data unchanged.
+//
+// # Example Usage
+//
+//	data, _ := reader.ReadSingle(ctx, "IBM", start, end) // newest first
+//	dropped, err := sources.SortRows(data)
+func SortRows(data interface{}) (int, error) {
+	rs, ok := data.(RowSelector)
+	if !ok {
+		return 0, nil
+	}
+	dates, err := rs.DateIndex()
+	if err != nil {
+		return 0, err
+	}
+
+	order := make([]int, len(dates))
+	for i := range order {
+		order[i] = i
+	}
+	sort.SliceStable(order, func(a, b int) bool { return dates[order[a]].Before(dates[order[b]]) })
+
+	// Equal dates are adjacent in source order; keep the last of each run
+	kept := make([]int, 0, len(order))
+	for k, i := range order {
+		if k+1 < len(order) && dates[order[k+1]].Equal(dates[i]) {
+			continue
+		}
+		kept = append(kept, i)
+	}
+
+	changed := len(kept) != len(dates)
+	for k, i := range kept {
+		if k != i {
+			changed = true
+			break
+		}
+	}
+	if changed {
+		rs.SelectRows(kept)
+	}
+	return len(dates) - len(kept), nil
+}
+
+// SelectRows returns the values at indices, in that order, for
+// implementations of RowSelector. Empty values, such as optional columns
+// no row has, are returned as is.
+func SelectRows[T any](values []T, indices []int) []T {
+	if len(values) == 0 {
+		return values
+	}
+	out := make([]T, len(indices))
+	for k, i := range indices {
+		out[k] = values[i]
+	}
+	return out
+}
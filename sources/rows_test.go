@@ -0,0 +1,131 @@
+package sources_test
+
+import (
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/julianshen/gonp-datareader/sources"
+	"github.com/julianshen/gonp-datareader/sources/alphavantage"
+	"github.com/julianshen/gonp-datareader/sources/ecb"
+	"github.com/julianshen/gonp-datareader/sources/eurostat"
+	"github.com/julianshen/gonp-datareader/sources/finmind"
+	"github.com/julianshen/gonp-datareader/sources/fred"
+	"github.com/julianshen/gonp-datareader/sources/iex"
+	"github.com/julianshen/gonp-datareader/sources/oecd"
+	"github.com/julianshen/gonp-datareader/sources/stooq"
+	"github.com/julianshen/gonp-datareader/sources/tiingo"
+	"github.com/julianshen/gonp-datareader/sources/twse"
+	"github.com/julianshen/gonp-datareader/sources/worldbank"
+	"github.com/julianshen/gonp-datareader/sources/yahoo"
+)
+
+func TestSortRows_AllSources(t *testing.T) {
+	// Newest first, with 2024-01-03 repeated by a later correction
+	dates := []string{"2024-01-03", "2024-01-02", "2024-01-03"}
+	values := []float64{3, 2, 4}
+
+	times := make([]time.Time, len(dates))
+	strs := make([]string, len(values))
+	for i := range dates {
+		times[i], _ = time.Parse("2006-01-02", dates[i])
+		strs[i] = strconv.FormatFloat(values[i], 'f', -1, 64)
+	}
+	rows := func(date, value string) []map[string]string {
+		out := make([]map[string]string, len(dates))
+		for i := range dates {
+			out[i] = map[string]string{date: dates[i], value: strs[i]}
+		}
+		return out
+	}
+
+	tests := []struct {
+		name   string
+		data   sources.RowSelector
+		column string
+	}{
+		{"yahoo", &yahoo.ParsedData{Columns: []string{"Date", "Close"}, Rows: rows("Date", "Close")}, "Close"},
+		{"stooq", &stooq.ParsedData{Columns: []string{"Date", "Close"}, Rows: rows("Date", "Close")}, "Close"},
+		{"alphavantage", &alphavantage.ParsedData{Columns: []string{"Date", "Close"}, Rows: rows("Date", "Close")}, "Close"},
+		{"iex", &iex.ParsedData{Columns: []string{"Date", "Close"}, Rows: rows("Date", "Close")}, "Close"},
+		{"finmind", &finmind.ParsedData{Columns: []string{"date", "close"}, Rows: rows("date", "close")}, "close"},
+		{"fred", &fred.ParsedData{Dates: dates, Values: strs, RealtimeStart: dates, RealtimeEnd: dates}, "Value"},
+		{"worldbank", &worldbank.ParsedData{Dates: dates, Values: strs}, "Value"},
+		{"oecd", &oecd.ParsedData{Dates: dates, Values: values}, "Value"},
+		{"eurostat", &eurostat.ParsedData{Dates: dates, Values: values}, "Value"},
+		{"ecb", &ecb.ParsedData{Dates: times, Rates: values}, ecb.RateColumn},
+		{"tiingo", &tiingo.ParsedData{
+			Dates:  dates,
+			Prices: []tiingo.PriceData{{Close: 3}, {Close: 2}, {Close: 4}},
+		}, "Close"},
+		{"twse", &twse.ParsedData{Date: times, Close: values, Volume: []int64{3, 2, 4}}, "Close"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Read a column first so cached columns are reset by the sort
+			if _, err := tt.data.Float64Column(tt.column); err != nil {
+				t.Fatal(err)
+			}
+
+			dropped, err := sources.SortRows(tt.data)
+			if err != nil {
+				t.Fatalf("SortRows() error = %v", err)
+			}
+			if dropped != 1 {
+				t.Errorf("SortRows() dropped %d rows, want 1", dropped)
+			}
+
+			got, err := tt.data.DateIndex()
+			if err != nil {
+				t.Fatal(err)
+			}
+			if len(got) != 2 || !got[0].Equal(times[1]) || !got[1].Equal(times[0]) {
+				t.Errorf("DateIndex() = %v, want [2024-01-02 2024-01-03]", got)
+			}
+
+			col, err := tt.data.Float64Column(tt.column)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if len(col) != 2 || col[0] != 2 || col[1] != 4 {
+				t.Errorf("Float64Column(%q) = %v, want [2 4] (the last row of a repeated date)", tt.column, col)
+			}
+		})
+	}
+}
+
+func TestSortRows_Unchanged(t *testing.T) {
+	data := &fred.ParsedData{Dates: []string{"2024-01-02", "2024-01-03"}, Values: []string{"1", "."}}
+	values := data.Values
+
+	dropped, err := sources.SortRows(data)
+	if err != nil || dropped != 0 {
+		t.Fatalf("SortRows() = %d, %v; want 0, nil", dropped, err)
+	}
+	if &data.Values[0] != &values[0] {
+		t.Error("SortRows() replaced the columns of data already in order")
+	}
+
+	// Data without a date index is left alone
+	if dropped, err := sources.SortRows(map[string]string{}); dropped != 0 || err != nil {
+		t.Errorf("SortRows(map) = %d, %v; want 0, nil", dropped, err)
+	}
+
+	bad := &fred.ParsedData{Dates: []string{"2024-01-03", "not a date"}, Values: []string{"1", "2"}}
+	if _, err := sources.SortRows(bad); err == nil {
+		t.Error("SortRows() with an invalid date returned nil error")
+	}
+	if bad.Dates[0] != "2024-01-03" {
+		t.Error("SortRows() modified data with an invalid date")
+	}
+}
+
+func TestSelectRows(t *testing.T) {
+	if got := sources.SelectRows([]string{"a", "b", "c"}, []int{2, 0}); len(got) != 2 || got[0] != "c" || got[1] != "a" {
+		t.Errorf("SelectRows() = %v, want [c a]", got)
+	}
+	if got := sources.SelectRows([]float64(nil), []int{1, 0}); got != nil {
+		t.Errorf("SelectRows(nil) = %v, want nil", got)
+	}
+}
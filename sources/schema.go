@@ -0,0 +1,162 @@
+package sources
+
+import (
+	"reflect"
+	"strings"
+	"time"
+)
+
+// ColumnType identifies the value type of a column in a source's result.
+type ColumnType string
+
+const (
+	// ColumnTypeString is a free-form text column.
+	ColumnTypeString ColumnType = "string"
+	// ColumnTypeFloat is a floating point numeric column.
+	ColumnTypeFloat ColumnType = "float64"
+	// ColumnTypeInt is an integer numeric column.
+	ColumnTypeInt ColumnType = "int64"
+	// ColumnTypeTime is a date or timestamp column.
+	ColumnTypeTime ColumnType = "time"
+	// ColumnTypeBool is a boolean column.
+	ColumnTypeBool ColumnType = "bool"
+)
+
+// Column describes a single column returned by a data source.
+type Column struct {
+	// Name is the column name as exposed by the source's ParsedData.
+	Name string `json:"name"`
+	// Type is the logical value type of the column. Row-based sources
+	// store values as strings; Type describes what the string represents.
+	Type ColumnType `json:"type"`
+}
+
+// Schema describes the columns returned by a data source.
+type Schema struct {
+	// Source is the source identifier (e.g., "yahoo").
+	Source string `json:"source"`
+	// Name is the display name of the source.
+	Name string `json:"name"`
+	// Columns lists the returned columns in order.
+	Columns []Column `json:"columns"`
+}
+
+// SchemaProvider is implemented by readers that can describe their output.
+type SchemaProvider interface {
+	// Schema returns the column schema of data returned by ReadSingle.
+	Schema() Schema
+}
+
+// NewSchema builds a Schema by reflecting over the fields of a typed struct.
+//
+// The struct may be row-oriented (one field per column) or column-oriented
+// (one slice field per column); slice fields contribute their element type,
+// and slices of structs are expanded into one column per struct field.
+//
+// Column names and types are taken from the `schema:"name,type"` struct tag
+// when present, then from the `json` tag name, then from the field name.
+// Fields tagged `schema:"-"` and unexported fields are skipped.
+func NewSchema(source, name string, v interface{}) Schema {
+	return Schema{
+		Source:  source,
+		Name:    name,
+		Columns: columnsOf(reflect.TypeOf(v)),
+	}
+}
+
+// Column returns the column with the given name and whether it exists.
+func (s Schema) Column(name string) (Column, bool) {
+	for _, c := range s.Columns {
+		if c.Name == name {
+			return c, true
+		}
+	}
+	return Column{}, false
+}
+
+// columnsOf extracts column descriptors from a struct type.
+func columnsOf(t reflect.Type) []Column {
+	for t != nil && t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+	if t == nil || t.Kind() != reflect.Struct {
+		return nil
+	}
+
+	var columns []Column
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		if !field.IsExported() {
+			continue
+		}
+
+		name, typ, skip := parseSchemaTag(field)
+		if skip {
+			continue
+		}
+
+		ft := field.Type
+		if ft.Kind() == reflect.Slice {
+			ft = ft.Elem()
+			// Slices of row structs expand into one column per field
+			if ft.Kind() == reflect.Struct && ft != reflect.TypeOf(time.Time{}) {
+				columns = append(columns, columnsOf(ft)...)
+				continue
+			}
+		}
+
+		if typ == "" {
+			typ = columnTypeOf(ft)
+		}
+		columns = append(columns, Column{Name: name, Type: typ})
+	}
+
+	return columns
+}
+
+// parseSchemaTag resolves the column name and optional type override of a field.
+func parseSchemaTag(field reflect.StructField) (string, ColumnType, bool) {
+	name := field.Name
+	if tag, ok := field.Tag.Lookup("json"); ok {
+		if jsonName := strings.Split(tag, ",")[0]; jsonName != "" && jsonName != "-" {
+			name = jsonName
+		}
+	}
+
+	tag, ok := field.Tag.Lookup("schema")
+	if !ok {
+		return name, "", false
+	}
+	if tag == "-" {
+		return "", "", true
+	}
+
+	parts := strings.SplitN(tag, ",", 2)
+	if parts[0] != "" {
+		name = parts[0]
+	}
+	var typ ColumnType
+	if len(parts) == 2 {
+		typ = ColumnType(parts[1])
+	}
+	return name, typ, false
+}
+
+// columnTypeOf maps a Go type to a ColumnType.
+func columnTypeOf(t reflect.Type) ColumnType {
+	if t == reflect.TypeOf(time.Time{}) {
+		return ColumnTypeTime
+	}
+
+	switch t.Kind() {
+	case reflect.Float32, reflect.Float64:
+		return ColumnTypeFloat
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return ColumnTypeInt
+	case reflect.Bool:
+		return ColumnTypeBool
+	default:
+		return ColumnTypeString
+	}
+}
@@ -0,0 +1,93 @@
+package sources_test
+
+import (
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/julianshen/gonp-datareader/sources"
+)
+
+type rowStruct struct {
+	Date   string  `schema:"Date,time"`
+	Close  float64 `json:"close"`
+	Volume int64
+	Note   string `schema:"-"`
+	hidden string
+}
+
+type priceStruct struct {
+	Open  float64
+	Close float64
+}
+
+type columnStruct struct {
+	Symbol string      `schema:"-"`
+	Dates  []time.Time `schema:"Date"`
+	Values []string    `schema:"Value,float64"`
+	Prices []priceStruct
+	Flags  []bool
+}
+
+func TestNewSchema(t *testing.T) {
+	tests := []struct {
+		name string
+		v    interface{}
+		want []sources.Column
+	}{
+		{
+			name: "row struct",
+			v:    rowStruct{},
+			want: []sources.Column{
+				{Name: "Date", Type: sources.ColumnTypeTime},
+				{Name: "close", Type: sources.ColumnTypeFloat},
+				{Name: "Volume", Type: sources.ColumnTypeInt},
+			},
+		},
+		{
+			name: "column struct pointer",
+			v:    &columnStruct{},
+			want: []sources.Column{
+				{Name: "Date", Type: sources.ColumnTypeTime},
+				{Name: "Value", Type: sources.ColumnTypeFloat},
+				{Name: "Open", Type: sources.ColumnTypeFloat},
+				{Name: "Close", Type: sources.ColumnTypeFloat},
+				{Name: "Flags", Type: sources.ColumnTypeBool},
+			},
+		},
+		{
+			name: "non-struct",
+			v:    42,
+			want: nil,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			schema := sources.NewSchema("test", "Test Source", tt.v)
+
+			if schema.Source != "test" || schema.Name != "Test Source" {
+				t.Errorf("unexpected schema identity: %+v", schema)
+			}
+			if !reflect.DeepEqual(schema.Columns, tt.want) {
+				t.Errorf("Columns = %+v, want %+v", schema.Columns, tt.want)
+			}
+		})
+	}
+}
+
+func TestSchema_Column(t *testing.T) {
+	schema := sources.NewSchema("test", "Test", rowStruct{})
+
+	col, ok := schema.Column("close")
+	if !ok {
+		t.Fatal("expected column 'close' to exist")
+	}
+	if col.Type != sources.ColumnTypeFloat {
+		t.Errorf("expected float64 type, got %s", col.Type)
+	}
+
+	if _, ok := schema.Column("missing"); ok {
+		t.Error("expected missing column lookup to fail")
+	}
+}
@@ -3,18 +3,75 @@ package sources
 
 import (
 	"context"
+	"errors"
+	"strings"
 	"time"
 
+	internalhttp "github.com/julianshen/gonp-datareader/internal/http"
 	"github.com/julianshen/gonp-datareader/internal/utils"
 )
 
+// ErrClosed is returned by readers used after Close or Shutdown.
+var ErrClosed = internalhttp.ErrClientClosed
+
+// ErrUnexpectedContentType is matched by errors.Is when a response is not
+// of the type the reader parses, typically an HTML consent, login or error
+// page. The error is an *UnexpectedContentTypeError.
+var ErrUnexpectedContentType = internalhttp.ErrUnexpectedContentType
+
+// UnexpectedContentTypeError carries the response's Content-Type, final
+// URL and a snippet of its body.
+type UnexpectedContentTypeError = internalhttp.UnexpectedContentTypeError
+
+// ErrTooManyRedirects is returned when a request is redirected more often
+// than the configured limit (Options.MaxRedirects).
+var ErrTooManyRedirects = internalhttp.ErrTooManyRedirects
+
+// ErrUnknownCharset is returned for a charset name that is not a known
+// encoding label (Options.FallbackCharset).
+var ErrUnknownCharset = internalhttp.ErrUnknownCharset
+
+// ErrRetriesExhausted is matched by errors.Is when a request still fails
+// with a network error or 5xx status after all retries. The error is a
+// *RetryError.
+var ErrRetriesExhausted = internalhttp.ErrRetriesExhausted
+
+// RetryError records the attempts made, the status code of each attempt
+// and the total elapsed time of a request that exhausted its retries.
+type RetryError = internalhttp.RetryError
+
+// ErrSymbolNotFound is matched by errors.Is when the provider does not
+// know the requested symbol, series or dataset.
+var ErrSymbolNotFound = internalhttp.ErrSymbolNotFound
+
+// ErrAuthRequired is matched by errors.Is when the provider rejects a
+// missing or invalid API key, token or session.
+var ErrAuthRequired = internalhttp.ErrAuthRequired
+
+// ErrRateLimited is matched by errors.Is when the provider rejects a
+// request for exceeding its rate limit or quota. The error is a
+// *RateLimitError.
+var ErrRateLimited = internalhttp.ErrRateLimited
+
+// RateLimitError carries the status code and the provider's Retry-After
+// wait of a rate-limited request.
+type RateLimitError = internalhttp.RateLimitError
+
+// ErrInvalidDateRange is matched by errors.Is when the end date is before
+// the start date.
+var ErrInvalidDateRange = utils.ErrInvalidDateRange
+
+// ErrNoData is matched by errors.Is when the provider answers but has no
+// observations for the symbol.
+var ErrNoData = errors.New("no data")
+
 // Reader is the main interface for all data sources.
 // Implementations must be safe for concurrent use.
 type Reader interface {
 	// Read fetches data for the given symbols within the date range.
 	// It returns an error if any symbol is invalid or if the request fails.
-	// The return type is interface{} to allow flexibility for different data sources
-	// until we integrate with gonp DataFrames.
+	// The return type is interface{} to allow flexibility for different data sources;
+	// datareader.ToDataset and datareader.ReadFrame convert it to a uniform Dataset.
 	Read(ctx context.Context, symbols []string, start, end time.Time) (interface{}, error)
 
 	// ReadSingle fetches data for a single symbol within the date range.
@@ -31,11 +88,60 @@ type Reader interface {
 	Source() string
 }
 
+// ErrSnapshotUnavailable is returned by ReadMarketSnapshot when the source
+// has no snapshot for the requested date.
+var ErrSnapshotUnavailable = errors.New("market snapshot unavailable")
+
+// SnapshotReader is implemented by readers that fetch one trading day of
+// every symbol on an exchange in a single request, so whole-market
+// ingestion costs one request per day instead of one per symbol.
+type SnapshotReader interface {
+	// ReadMarketSnapshot returns the data of every symbol traded on date
+	// as a map from symbol to the source's ParsedData, each holding that
+	// day's row. A zero date requests the latest available trading day
+	// where the source supports it.
+	ReadMarketSnapshot(ctx context.Context, date time.Time) (interface{}, error)
+}
+
+// LatestReader is implemented by readers that fetch the most recent
+// observation of a symbol from a cheaper endpoint than a date-range read,
+// such as a quote or a latest-day snapshot.
+type LatestReader interface {
+	// ReadLatest returns the most recent bar or observation of symbol as
+	// the source's ParsedData, holding one row.
+	ReadLatest(ctx context.Context, symbol string) (interface{}, error)
+}
+
+// Pinger is implemented by readers with a cheap health check of their
+// upstream, such as a status endpoint that does not count against quotas.
+type Pinger interface {
+	// Ping returns nil when the upstream answers and accepts the reader's
+	// credentials.
+	Ping(ctx context.Context) error
+}
+
+// Closer is implemented by readers that hold network resources. All
+// built-in network readers implement it.
+type Closer interface {
+	// Shutdown stops accepting requests and waits for in-flight requests
+	// to finish, cancelling them if ctx expires first.
+	Shutdown(ctx context.Context) error
+
+	// Close cancels in-flight requests immediately.
+	Close() error
+}
+
 // BaseSource provides common functionality for data source implementations.
 type BaseSource struct {
 	source string
+
+	// finish wraps ReadSingle; see SetFinish
+	finish func(ctx context.Context, symbol string, start, end time.Time, read ReadFunc) (interface{}, error)
 }
 
+// ReadFunc reads one symbol over [start, end], like Reader.ReadSingle.
+type ReadFunc func(ctx context.Context, symbol string, start, end time.Time) (interface{}, error)
+
 // NewBaseSource creates a new BaseSource.
 func NewBaseSource(source string) *BaseSource {
 	return &BaseSource{
@@ -54,8 +160,80 @@ func (b *BaseSource) Source() string {
 	return b.source
 }
 
-// ValidateSymbol validates a symbol using the common validation rules.
-// Data sources can override this method for source-specific validation.
+// SetFinish sets a function wrapping each ReadSingle call of the reader,
+// and so each symbol's read of Read. It is given the request and the
+// reader's own read function, so it can refuse a request before it is
+// sent and adjust or check the result, as the datareader package does for
+// row sorting, provisional bars and Options.MaxRows. It must be set before
+// the reader is used.
+func (b *BaseSource) SetFinish(finish func(ctx context.Context, symbol string, start, end time.Time, read ReadFunc) (interface{}, error)) {
+	b.finish = finish
+}
+
+// Finish reads symbol with read, through the function set by SetFinish if
+// any. Readers implement ReadSingle with it:
+//
+//	return r.Finish(ctx, symbol, start, end, r.readSingle)
+func (b *BaseSource) Finish(ctx context.Context, symbol string, start, end time.Time, read ReadFunc) (interface{}, error) {
+	if b.finish == nil {
+		return read(ctx, symbol, start, end)
+	}
+	return b.finish(ctx, symbol, start, end, read)
+}
+
+// ValidateSymbol validates a symbol, after NormalizeSymbol, using the
+// common validation rules. Data sources can override this method for
+// source-specific validation.
 func (b *BaseSource) ValidateSymbol(symbol string) error {
-	return utils.ValidateSymbol(symbol)
+	return utils.ValidateSymbol(b.NormalizeSymbol(symbol))
+}
+
+// caseSensitiveSources lists the sources whose identifiers must keep their
+// case (e.g., Eurostat's "nama_10_gdp", World Bank's "all" country code).
+// Bundles hold symbols of any source, so they keep case as well.
+var caseSensitiveSources = map[string]bool{
+	"bundle":    true,
+	"eurostat":  true,
+	"oecd":      true,
+	"worldbank": true,
+}
+
+// NormalizeSymbol trims whitespace and strips an exchange prefix such as
+// "NASDAQ:" from a symbol, and uppercases it unless the source's
+// identifiers are case-sensitive. Readers normalize symbols before
+// validating them, so " nasdaq:aapl" reads AAPL.
+func (b *BaseSource) NormalizeSymbol(symbol string) string {
+	return utils.NormalizeSymbol(symbol, !caseSensitiveSources[b.source])
+}
+
+// NormalizeSymbols applies NormalizeSymbol to each symbol.
+func (b *BaseSource) NormalizeSymbols(symbols []string) []string {
+	out := make([]string, len(symbols))
+	for i, s := range symbols {
+		out[i] = b.NormalizeSymbol(s)
+	}
+	return out
+}
+
+// InputMeta records the symbol as given by the caller in meta["symbol_input"]
+// when normalization changed it, allocating meta if needed.
+func InputMeta(meta map[string]string, input, symbol string) map[string]string {
+	if input == symbol {
+		return meta
+	}
+	if meta == nil {
+		meta = make(map[string]string)
+	}
+	meta["symbol_input"] = input
+	return meta
+}
+
+// WarningsMeta records parse warnings, such as rows with null values, in
+// meta["warnings"] joined by "; " and as a WarnParse warning (see
+// AddWarning), allocating meta if needed.
+func WarningsMeta(meta map[string]string, warnings []string) map[string]string {
+	if len(warnings) == 0 {
+		return meta
+	}
+	return AddWarning(meta, Warning{Code: WarnParse, Message: strings.Join(warnings, "; ")})
 }
@@ -122,3 +122,38 @@ func TestBaseSource_ValidateSymbol(t *testing.T) {
 		})
 	}
 }
+
+func TestBaseSource_NormalizeSymbol(t *testing.T) {
+	tests := []struct {
+		source string
+		symbol string
+		want   string
+	}{
+		{"yahoo", " nasdaq:aapl ", "AAPL"},
+		{"stooq", "aapl.us", "AAPL.US"},
+		{"eurostat", " nama_10_gdp", "nama_10_gdp"},
+		{"worldbank", "all/SP.POP.TOTL", "all/SP.POP.TOTL"},
+	}
+
+	for _, tt := range tests {
+		b := sources.NewBaseSource(tt.source)
+		if got := b.NormalizeSymbol(tt.symbol); got != tt.want {
+			t.Errorf("%s: NormalizeSymbol(%q) = %q, want %q", tt.source, tt.symbol, got, tt.want)
+		}
+	}
+
+	if err := sources.NewBaseSource("yahoo").ValidateSymbol(" NASDAQ:AAPL "); err != nil {
+		t.Errorf("ValidateSymbol() error = %v, want nil after normalization", err)
+	}
+}
+
+func TestInputMeta(t *testing.T) {
+	if got := sources.InputMeta(nil, "AAPL", "AAPL"); got != nil {
+		t.Errorf("InputMeta() = %v, want nil when unchanged", got)
+	}
+
+	got := sources.InputMeta(map[string]string{"stale": "true"}, " aapl", "AAPL")
+	if got["symbol_input"] != " aapl" || got["stale"] != "true" {
+		t.Errorf("InputMeta() = %v", got)
+	}
+}
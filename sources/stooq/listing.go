@@ -0,0 +1,63 @@
+package stooq
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/julianshen/gonp-datareader/sources"
+)
+
+// listLookback is the number of days ListSymbols steps back looking for a
+// published bulk file, enough to cover long holiday weekends
+const listLookback = 7
+
+// ListSymbols lists every ticker of the most recent Stooq bulk file,
+// stepping back from yesterday over weekends and holidays, sorted by
+// ticker. Stooq's bulk files carry no names, so entries hold the ticker
+// (e.g., "AAPL.US") and the market taken from its suffix (e.g., "US"), or
+// "index" for tickers such as "^SPX".
+func (s *StooqReader) ListSymbols(ctx context.Context) ([]sources.SymbolInfo, error) {
+	var lastErr error
+	date := time.Now().UTC().Truncate(24 * time.Hour)
+	for i := 0; i < listLookback; i++ {
+		date = date.AddDate(0, 0, -1)
+		if date.Weekday() == time.Saturday || date.Weekday() == time.Sunday {
+			continue
+		}
+
+		snapshot, err := s.ReadMarketSnapshot(ctx, date)
+		if err == nil && len(snapshot.(map[string]*ParsedData)) == 0 {
+			err = fmt.Errorf("%w: empty bulk file", sources.ErrNoData)
+		}
+		if err == nil {
+			return listTickers(snapshot.(map[string]*ParsedData)), nil
+		}
+
+		// Only a missing file moves on to the day before
+		if !errors.Is(err, sources.ErrSymbolNotFound) && !errors.Is(err, sources.ErrUnexpectedContentType) && !errors.Is(err, sources.ErrNoData) {
+			return nil, err
+		}
+		lastErr = err
+	}
+	return nil, fmt.Errorf("no bulk file in the last %d days: %w", listLookback, lastErr)
+}
+
+// listTickers returns the tickers of a bulk file snapshot as symbol info.
+func listTickers(snapshot map[string]*ParsedData) []sources.SymbolInfo {
+	infos := make([]sources.SymbolInfo, 0, len(snapshot))
+	for ticker := range snapshot {
+		info := sources.SymbolInfo{Symbol: ticker}
+		if strings.HasPrefix(ticker, "^") {
+			info.Market = "index"
+		} else if i := strings.LastIndex(ticker, "."); i >= 0 {
+			info.Market = ticker[i+1:]
+		}
+		infos = append(infos, info)
+	}
+	sort.Slice(infos, func(i, j int) bool { return infos[i].Symbol < infos[j].Symbol })
+	return infos
+}
@@ -3,19 +3,135 @@ package stooq
 import (
 	"bytes"
 	"encoding/csv"
+	"errors"
 	"fmt"
 	"io"
 	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/julianshen/gonp-datareader/internal/numparse"
+	"github.com/julianshen/gonp-datareader/ohlcv"
+	"github.com/julianshen/gonp-datareader/sources"
 )
 
+// csvRecord documents the columns of a Stooq CSV record.
+// Values are stored as strings in ParsedData.Rows; the field types describe
+// what each string represents.
+type csvRecord struct {
+	Date   time.Time
+	Open   float64
+	High   float64
+	Low    float64
+	Close  float64
+	Volume int64
+}
+
 // ParsedData represents parsed Stooq CSV data.
 type ParsedData struct {
 	Columns []string
 	Rows    []map[string]string
+	// Meta holds response metadata such as "stale" when the data was
+	// served from an expired cache entry; nil when there is none.
+	Meta map[string]string
+
+	// cache holds the columns parsed by GetFloatColumn and GetTimeColumn
+	cache *sources.ColumnCache
+}
+
+// DateIndex returns the Date column parsed as dates.
+func (p *ParsedData) DateIndex() ([]time.Time, error) {
+	return p.GetTimeColumn("Date")
+}
+
+// SelectRows keeps the rows at indices, in that order (see
+// sources.RowSelector).
+func (p *ParsedData) SelectRows(indices []int) {
+	if p == nil {
+		return
+	}
+	p.Rows = sources.SelectRows(p.Rows, indices)
+	p.cache = sources.NewColumnCache()
+}
+
+// ColumnNames returns the value columns, excluding Date.
+func (p *ParsedData) ColumnNames() []string {
+	if p == nil {
+		return nil
+	}
+	return sources.ValueColumns(p.Columns, "Date")
+}
+
+// Float64Column returns the named value column as float64 values, with
+// missing values as NaN.
+func (p *ParsedData) Float64Column(name string) ([]float64, error) {
+	if name == "Date" {
+		return nil, sources.NoColumn(name)
+	}
+	return p.GetFloatColumn(name)
+}
+
+// Bars returns the price columns as ohlcv.Bars (see ohlcv.Provider).
+func (p *ParsedData) Bars() (*ohlcv.Bars, error) {
+	return ohlcv.FromTimeSeries(p)
+}
+
+// GetColumn returns all values for a given column name.
+func (p *ParsedData) GetColumn(name string) []string {
+	if p == nil || len(p.Rows) == 0 {
+		return nil
+	}
+
+	values := make([]string, 0, len(p.Rows))
+	for _, row := range p.Rows {
+		if val, ok := row[name]; ok {
+			values = append(values, val)
+		}
+	}
+
+	if len(values) == 0 {
+		return nil
+	}
+
+	return values
+}
+
+// GetFloatColumn returns the named column parsed as float64 values, with
+// missing values as NaN. Parsed columns are cached, so the returned slice
+// must not be modified.
+func (p *ParsedData) GetFloatColumn(name string) ([]float64, error) {
+	if p == nil {
+		return nil, fmt.Errorf("%w: %q", sources.ErrNoColumn, name)
+	}
+	return sources.FloatColumn(p.cache, p.Columns, p.Rows, name)
+}
+
+// GetTimeColumn returns the named column parsed as dates. Parsed columns
+// are cached, so the returned slice must not be modified.
+func (p *ParsedData) GetTimeColumn(name string) ([]time.Time, error) {
+	if p == nil {
+		return nil, fmt.Errorf("%w: %q", sources.ErrNoColumn, name)
+	}
+	return sources.TimeColumn(p.cache, p.Columns, p.Rows, name)
+}
+
+// ColumnInfo describes the columns of the data in order, with the value
+// type each string represents.
+func (p *ParsedData) ColumnInfo() []sources.Column {
+	if p == nil {
+		return nil
+	}
+	return sources.DescribeColumns(p.Columns, sources.NewSchema("", "", csvRecord{}))
 }
 
 // ParseCSV parses Stooq CSV response data.
 func ParseCSV(data []byte) (*ParsedData, error) {
+	// Stooq answers unknown symbols and empty ranges with a bare "No data"
+	if strings.EqualFold(strings.TrimSpace(string(data)), "No data") {
+		return nil, fmt.Errorf("%w: stooq returned \"No data\"", sources.ErrNoData)
+	}
+
 	reader := csv.NewReader(bytes.NewReader(data))
 
 	// Read header
@@ -48,6 +164,9 @@ func ParseCSV(data []byte) (*ParsedData, error) {
 		// Create row map
 		row := make(map[string]string)
 		for i, value := range record {
+			if header[i] != "Date" {
+				value = normalizeNumber(value)
+			}
 			row[header[i]] = value
 		}
 		rows = append(rows, row)
@@ -61,5 +180,25 @@ func ParseCSV(data []byte) (*ParsedData, error) {
 	return &ParsedData{
 		Columns: header,
 		Rows:    rows,
+		cache:   sources.NewColumnCache(),
 	}, nil
 }
+
+// normalizeNumber rewrites a value of a Stooq file in plain notation, so
+// the display formatting of regional files ("1,234,567", full-width
+// digits, "--" placeholders) reads like any other value. Values are parsed
+// with numparse: placeholders become empty (missing), and values it
+// rejects are kept for GetFloatColumn to report.
+func normalizeNumber(s string) string {
+	if _, err := strconv.ParseFloat(s, 64); err == nil {
+		return s
+	}
+	f, err := numparse.ParseFloat(s)
+	if errors.Is(err, numparse.ErrMissing) {
+		return ""
+	}
+	if err != nil {
+		return s
+	}
+	return strconv.FormatFloat(f, 'f', -1, 64)
+}
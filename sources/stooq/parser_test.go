@@ -1,6 +1,7 @@
 package stooq_test
 
 import (
+	"math"
 	"testing"
 
 	"github.com/julianshen/gonp-datareader/sources/stooq"
@@ -47,6 +48,29 @@ func TestParseCSV(t *testing.T) {
 	}
 }
 
+func TestParseCSV_DisplayFormatting(t *testing.T) {
+	csvData := "Date,Open,High,Low,Close,Volume\n" +
+		"2024-01-15,\"1,185.89\",１８６．９５,--,186.51,\"51,234,567\"\n"
+
+	result, err := stooq.ParseCSV([]byte(csvData))
+	if err != nil {
+		t.Fatalf("ParseCSV failed: %v", err)
+	}
+
+	row := result.Rows[0]
+	want := map[string]string{"Open": "1185.89", "High": "186.95", "Low": "", "Close": "186.51", "Volume": "51234567"}
+	for name, value := range want {
+		if row[name] != value {
+			t.Errorf("%s = %q, want %q", name, row[name], value)
+		}
+	}
+
+	low, err := result.GetFloatColumn("Low")
+	if err != nil || len(low) != 1 || !math.IsNaN(low[0]) {
+		t.Errorf("Low = %v, %v, want NaN for the placeholder", low, err)
+	}
+}
+
 func TestParseCSV_EmptyData(t *testing.T) {
 	csvData := `Date,Open,High,Low,Close,Volume
 `
@@ -70,3 +94,25 @@ func TestParseCSV_InvalidCSV(t *testing.T) {
 		t.Error("Expected error for empty CSV")
 	}
 }
+
+func TestParsedData_GetFloatColumn(t *testing.T) {
+	data, err := stooq.ParseCSV([]byte("Date,Open,High,Low,Close,Volume\n2024-01-16,1,1,1,187.5,100\n2024-01-15,1,1,1,186.5,200\n"))
+	if err != nil {
+		t.Fatalf("ParseCSV() error = %v", err)
+	}
+
+	volumes, err := data.GetFloatColumn("Volume")
+	if err != nil {
+		t.Fatalf("GetFloatColumn() error = %v", err)
+	}
+	// Rows are sorted by date
+	if len(volumes) != 2 || volumes[0] != 200 || volumes[1] != 100 {
+		t.Errorf("GetFloatColumn(Volume) = %v, want [200 100]", volumes)
+	}
+	if got := data.GetColumn("Close"); len(got) != 2 || got[0] != "186.5" {
+		t.Errorf("GetColumn(Close) = %v", got)
+	}
+	if _, err := data.GetFloatColumn("Adj Close"); err == nil {
+		t.Error("GetFloatColumn() expected error for missing column")
+	}
+}
@@ -0,0 +1,145 @@
+package stooq
+
+import (
+	"archive/zip"
+	"bytes"
+	"context"
+	"encoding/csv"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"time"
+
+	internalhttp "github.com/julianshen/gonp-datareader/internal/http"
+	"github.com/julianshen/gonp-datareader/internal/utils"
+)
+
+// snapshotURL is the Stooq bulk file of all symbols' daily data for a
+// date formatted as YYYYMMDD.
+const snapshotURL = "https://stooq.com/db/d/?d=%s&t=d"
+
+// bulkColumns are the columns of a Stooq bulk file, which lists one row per
+// symbol in the form <TICKER>,<PER>,<DATE>,<TIME>,<OPEN>,<HIGH>,<LOW>,<CLOSE>,<VOL>,<OPENINT>.
+var bulkColumns = []string{"<TICKER>", "<PER>", "<DATE>", "<TIME>", "<OPEN>", "<HIGH>", "<LOW>", "<CLOSE>", "<VOL>", "<OPENINT>"}
+
+// SetSnapshotURL sets the bulk file URL used by ReadMarketSnapshot. The URL
+// has one %s verb for the date as YYYYMMDD. This is primarily used for
+// testing with mock servers.
+func (s *StooqReader) SetSnapshotURL(url string) {
+	s.snapshotURL = url
+}
+
+// ReadMarketSnapshot fetches the Stooq bulk file for date in a single
+// request and returns a map[string]*ParsedData keyed by ticker (e.g.,
+// "AAPL.US"), each holding that day's row with the columns of ReadSingle.
+// Zipped and plain-text bulk files are accepted. Stooq has no "latest"
+// bulk file, so date must not be zero.
+func (s *StooqReader) ReadMarketSnapshot(ctx context.Context, date time.Time) (interface{}, error) {
+	if date.IsZero() {
+		return nil, fmt.Errorf("invalid date: %w", utils.ErrZeroTime)
+	}
+
+	req, err := http.NewRequestWithContext(internalhttp.WithContentType(ctx, "application/zip", "text/csv"), "GET", fmt.Sprintf(s.snapshotURL, date.Format("20060102")), nil)
+	if err != nil {
+		return nil, fmt.Errorf("create request: %w", err)
+	}
+
+	resp, err := s.client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("fetch data: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != 200 {
+		return nil, internalhttp.StatusError(resp, fmt.Errorf("HTTP %d: %s", resp.StatusCode, resp.Status))
+	}
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("read response: %w", err)
+	}
+
+	snapshot, err := parseBulk(body)
+	if err != nil {
+		return nil, fmt.Errorf("parse bulk file: %w", err)
+	}
+
+	meta := internalhttp.StaleMeta(resp)
+	for _, data := range snapshot {
+		data.Meta = meta
+	}
+
+	return snapshot, nil
+}
+
+// parseBulk parses a Stooq bulk file, unpacking every file of a zip
+// archive.
+func parseBulk(body []byte) (map[string]*ParsedData, error) {
+	snapshot := make(map[string]*ParsedData)
+
+	if !bytes.HasPrefix(body, []byte("PK")) {
+		return snapshot, parseBulkText(bytes.NewReader(body), snapshot)
+	}
+
+	archive, err := zip.NewReader(bytes.NewReader(body), int64(len(body)))
+	if err != nil {
+		return nil, fmt.Errorf("open zip: %w", err)
+	}
+	for _, file := range archive.File {
+		if file.FileInfo().IsDir() {
+			continue
+		}
+		rc, err := file.Open()
+		if err != nil {
+			return nil, fmt.Errorf("open %s: %w", file.Name, err)
+		}
+		err = parseBulkText(rc, snapshot)
+		rc.Close()
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", file.Name, err)
+		}
+	}
+
+	return snapshot, nil
+}
+
+// parseBulkText adds the rows of one bulk text file to snapshot. Header
+// lines are skipped, so concatenated files parse as well.
+func parseBulkText(r io.Reader, snapshot map[string]*ParsedData) error {
+	reader := csv.NewReader(r)
+	reader.FieldsPerRecord = -1
+
+	for {
+		record, err := reader.Read()
+		if err == io.EOF {
+			return nil
+		}
+		if err != nil {
+			return fmt.Errorf("read CSV row: %w", err)
+		}
+
+		// Skip headers and rows with the wrong number of columns
+		if len(record) != len(bulkColumns) || strings.HasPrefix(record[0], "<") {
+			continue
+		}
+
+		date, err := time.Parse("20060102", record[2])
+		if err != nil {
+			return fmt.Errorf("parse date %q: %w", record[2], err)
+		}
+
+		ticker := record[0]
+		snapshot[ticker] = &ParsedData{
+			Columns: []string{"Date", "Open", "High", "Low", "Close", "Volume"},
+			Rows: []map[string]string{{
+				"Date":   date.Format("2006-01-02"),
+				"Open":   normalizeNumber(record[4]),
+				"High":   normalizeNumber(record[5]),
+				"Low":    normalizeNumber(record[6]),
+				"Close":  normalizeNumber(record[7]),
+				"Volume": normalizeNumber(record[8]),
+			}},
+		}
+	}
+}
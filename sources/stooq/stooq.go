@@ -17,8 +17,12 @@ import (
 // StooqReader fetches data from Stooq.
 type StooqReader struct {
 	*sources.BaseSource
-	client  *internalhttp.RetryableClient
-	baseURL string // For testing with mock servers
+	client      *internalhttp.RetryableClient
+	baseURL     string // For testing with mock servers
+	snapshotURL string
+
+	// interval is the bar interval; see SetInterval
+	interval sources.Interval
 }
 
 // NewStooqReader creates a new Stooq data reader.
@@ -34,12 +38,18 @@ func NewStooqReaderWithBaseURL(opts *internalhttp.ClientOptions, baseURL string)
 	}
 
 	return &StooqReader{
-		BaseSource: sources.NewBaseSource("stooq"),
-		client:     internalhttp.NewRetryableClient(opts),
-		baseURL:    baseURL,
+		BaseSource:  sources.NewBaseSource("stooq"),
+		client:      internalhttp.NewRetryableClient(opts),
+		baseURL:     baseURL,
+		snapshotURL: snapshotURL,
 	}
 }
 
+// Schema returns the columns of the ParsedData returned by ReadSingle.
+func (s *StooqReader) Schema() sources.Schema {
+	return sources.NewSchema(s.Source(), s.Name(), csvRecord{})
+}
+
 // BuildURL constructs the Stooq URL for fetching historical data.
 // The Stooq format is:
 // https://stooq.com/q/d/l/?s={symbol}&i=d
@@ -51,8 +61,53 @@ func BuildURL(symbol string) string {
 	)
 }
 
+// Intervals lists the bar intervals Stooq serves.
+var Intervals = []sources.Interval{sources.Interval1d, sources.Interval1wk, sources.Interval1mo}
+
+// stooqIntervals maps intervals to the i parameter of the download URL.
+var stooqIntervals = map[sources.Interval]string{
+	sources.Interval1d:  "d",
+	sources.Interval1wk: "w",
+	sources.Interval1mo: "m",
+}
+
+// SetInterval sets the bar interval of ReadSingle and Read; empty means
+// daily. Intervals not in Intervals return an error matching
+// sources.ErrUnsupportedInterval.
+func (s *StooqReader) SetInterval(interval sources.Interval) error {
+	if err := sources.CheckInterval(interval, Intervals); err != nil {
+		return err
+	}
+	s.interval = interval
+	return nil
+}
+
+// withInterval sets the i parameter of a download URL to the interval.
+func (s *StooqReader) withInterval(urlStr string) string {
+	if s.interval.OrDefault() == sources.Interval1d {
+		return urlStr
+	}
+	u, err := url.Parse(urlStr)
+	if err != nil {
+		return urlStr
+	}
+	q := u.Query()
+	q.Set("i", stooqIntervals[s.interval])
+	u.RawQuery = q.Encode()
+	return u.String()
+}
+
 // ReadSingle fetches data for a single symbol.
 func (s *StooqReader) ReadSingle(ctx context.Context, symbol string, start, end time.Time) (interface{}, error) {
+	return s.Finish(ctx, symbol, start, end, s.readSingle)
+}
+
+// readSingle implements ReadSingle before Finish is applied.
+func (s *StooqReader) readSingle(ctx context.Context, symbol string, start, end time.Time) (interface{}, error) {
+	// Normalize user input; the original is kept in Meta["symbol_input"]
+	input := symbol
+	symbol = s.NormalizeSymbol(symbol)
+
 	// Validate symbol
 	if err := s.ValidateSymbol(symbol); err != nil {
 		return nil, err
@@ -65,9 +120,10 @@ func (s *StooqReader) ReadSingle(ctx context.Context, symbol string, start, end
 	} else {
 		urlStr = BuildURL(symbol)
 	}
+	urlStr = s.withInterval(urlStr)
 
 	// Create HTTP request
-	req, err := http.NewRequestWithContext(ctx, "GET", urlStr, nil)
+	req, err := http.NewRequestWithContext(internalhttp.WithContentType(ctx, "text/csv"), "GET", urlStr, nil)
 	if err != nil {
 		return nil, fmt.Errorf("create request: %w", err)
 	}
@@ -81,7 +137,7 @@ func (s *StooqReader) ReadSingle(ctx context.Context, symbol string, start, end
 
 	// Check status code
 	if resp.StatusCode != 200 {
-		return nil, fmt.Errorf("HTTP %d: %s", resp.StatusCode, resp.Status)
+		return nil, internalhttp.StatusError(resp, fmt.Errorf("HTTP %d: %s", resp.StatusCode, resp.Status))
 	}
 
 	// Read response body
@@ -91,19 +147,26 @@ func (s *StooqReader) ReadSingle(ctx context.Context, symbol string, start, end
 	}
 
 	// Parse CSV
-	data, err := ParseCSV(body)
+	decoded, err := s.client.Decode("stooq", body, func(b []byte) (interface{}, error) {
+		return ParseCSV(b)
+	})
 	if err != nil {
 		return nil, fmt.Errorf("parse CSV: %w", err)
 	}
 
-	return data, nil
+	// Copy the decoded value, which may be shared through the decoded cache,
+	// and flag data served from an expired cache entry
+	data := *decoded.(*ParsedData)
+	data.Meta = sources.InputMeta(internalhttp.StaleMeta(resp), input, symbol)
+
+	return &data, nil
 }
 
 // Read fetches data for multiple symbols from Stooq.
 // Symbols are fetched in parallel for better performance.
 func (s *StooqReader) Read(ctx context.Context, symbols []string, start, end time.Time) (interface{}, error) {
 	// Validate inputs
-	if err := utils.ValidateSymbols(symbols); err != nil {
+	if err := utils.ValidateSymbols(s.NormalizeSymbols(symbols)); err != nil {
 		return nil, fmt.Errorf("invalid symbols: %w", err)
 	}
 
@@ -176,3 +239,14 @@ func (s *StooqReader) readParallel(ctx context.Context, symbols []string, start,
 func (s *StooqReader) ValidateSymbol(symbol string) error {
 	return s.BaseSource.ValidateSymbol(symbol)
 }
+
+// Shutdown stops accepting requests and waits for in-flight requests to
+// finish, cancelling them if ctx expires first.
+func (s *StooqReader) Shutdown(ctx context.Context) error {
+	return s.client.Shutdown(ctx)
+}
+
+// Close cancels in-flight requests and releases the reader's connections.
+func (s *StooqReader) Close() error {
+	return s.client.Close()
+}
@@ -1,7 +1,10 @@
 package stooq_test
 
 import (
+	"archive/zip"
+	"bytes"
 	"context"
+	"errors"
 	"net/http"
 	"net/http/httptest"
 	"strings"
@@ -9,6 +12,7 @@ import (
 	"time"
 
 	internalhttp "github.com/julianshen/gonp-datareader/internal/http"
+	"github.com/julianshen/gonp-datareader/sources"
 	"github.com/julianshen/gonp-datareader/sources/stooq"
 )
 
@@ -151,6 +155,94 @@ func TestStooqReader_ReadSingle_WithMockServer(t *testing.T) {
 	}
 }
 
+func TestStooqReader_ReadSingle_NormalizesSymbol(t *testing.T) {
+	var requested string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requested = r.URL.Query().Get("s")
+		w.Write([]byte("Date,Open,High,Low,Close,Volume\n2023-01-04,128.00,132.00,127.50,130.00,70000000"))
+	}))
+	defer server.Close()
+
+	reader := stooq.NewStooqReaderWithBaseURL(nil, server.URL+"?s=%s")
+	start := time.Date(2023, 1, 1, 0, 0, 0, 0, time.UTC)
+	end := time.Date(2023, 1, 31, 0, 0, 0, 0, time.UTC)
+
+	result, err := reader.ReadSingle(context.Background(), " nasdaq:aapl.us ", start, end)
+	if err != nil {
+		t.Fatalf("ReadSingle() error = %v", err)
+	}
+
+	if requested != "AAPL.US" {
+		t.Errorf("requested symbol = %q, want AAPL.US", requested)
+	}
+	if got := result.(*stooq.ParsedData).Meta["symbol_input"]; got != " nasdaq:aapl.us " {
+		t.Errorf("Meta[symbol_input] = %q, want the original input", got)
+	}
+}
+
+func TestStooqReader_ReadSingle_HTMLPage(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "text/html; charset=utf-8")
+		w.Write([]byte("<html><body>Please accept cookies</body></html>"))
+	}))
+	defer server.Close()
+
+	reader := stooq.NewStooqReaderWithBaseURL(nil, server.URL+"?s=%s")
+	start := time.Date(2023, 1, 1, 0, 0, 0, 0, time.UTC)
+	end := time.Date(2023, 1, 31, 0, 0, 0, 0, time.UTC)
+
+	_, err := reader.ReadSingle(context.Background(), "AAPL.US", start, end)
+	if !errors.Is(err, sources.ErrUnexpectedContentType) {
+		t.Fatalf("ReadSingle() error = %v, want ErrUnexpectedContentType", err)
+	}
+	if !strings.Contains(err.Error(), "Please accept cookies") {
+		t.Errorf("error %q should include a body snippet", err)
+	}
+}
+
+func TestStooqReader_ReadSingle_DecodedCache(t *testing.T) {
+	csvData := `Date,Open,High,Low,Close,Volume
+2023-01-05,130.00,135.00,129.00,134.50,75000000`
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(csvData))
+	}))
+	defer server.Close()
+
+	opts := &internalhttp.ClientOptions{
+		Timeout:          5 * time.Second,
+		DecodedCacheSize: 10,
+	}
+	reader := stooq.NewStooqReaderWithBaseURL(opts, server.URL+"?s=%s")
+
+	ctx := context.Background()
+	start := time.Date(2023, 1, 1, 0, 0, 0, 0, time.UTC)
+	end := time.Date(2023, 1, 31, 0, 0, 0, 0, time.UTC)
+
+	first, err := reader.ReadSingle(ctx, "AAPL.US", start, end)
+	if err != nil {
+		t.Fatalf("ReadSingle() error = %v", err)
+	}
+	// Per-call metadata must not leak into the shared decoded value
+	first.(*stooq.ParsedData).Meta = map[string]string{"note": "mutated"}
+
+	second, err := reader.ReadSingle(ctx, "AAPL.US", start, end)
+	if err != nil {
+		t.Fatalf("ReadSingle() error = %v", err)
+	}
+
+	data := second.(*stooq.ParsedData)
+	if data == first {
+		t.Error("Expected a distinct ParsedData per call")
+	}
+	if data.Meta != nil {
+		t.Errorf("Expected nil Meta, got %v", data.Meta)
+	}
+	if len(data.Rows) != 1 || data.Rows[0]["Close"] != "134.50" {
+		t.Errorf("Unexpected rows: %v", data.Rows)
+	}
+}
+
 // TestStooqReader_ReadSingle_InvalidSymbol tests error handling for invalid symbols
 func TestStooqReader_ReadSingle_InvalidSymbol(t *testing.T) {
 	reader := stooq.NewStooqReader(nil)
@@ -238,3 +330,191 @@ func TestStooqReader_HTTPError(t *testing.T) {
 		t.Error("ReadSingle() should return error for HTTP 500")
 	}
 }
+
+func TestStooqReader_ReadMarketSnapshot(t *testing.T) {
+	const bulk = "<TICKER>,<PER>,<DATE>,<TIME>,<OPEN>,<HIGH>,<LOW>,<CLOSE>,<VOL>,<OPENINT>\n" +
+		"AAPL.US,D,20240102,000000,187.15,188.44,183.885,185.64,82488674,0\n" +
+		"MSFT.US,D,20240102,000000,373.86,375.9,366.77,370.87,25258633,0\n"
+
+	var zipped bytes.Buffer
+	zw := zip.NewWriter(&zipped)
+	f, _ := zw.Create("data/daily/us/nasdaq.txt")
+	f.Write([]byte(bulk))
+	zw.Close()
+
+	tests := []struct {
+		name string
+		body []byte
+	}{
+		{"plain text", []byte(bulk)},
+		{"zip archive", zipped.Bytes()},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var gotDate string
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				gotDate = r.URL.Query().Get("d")
+				w.Write(tt.body)
+			}))
+			defer server.Close()
+
+			reader := stooq.NewStooqReader(nil)
+			reader.SetSnapshotURL(server.URL + "?d=%s")
+
+			result, err := reader.ReadMarketSnapshot(context.Background(), time.Date(2024, 1, 2, 0, 0, 0, 0, time.UTC))
+			if err != nil {
+				t.Fatalf("ReadMarketSnapshot() error = %v", err)
+			}
+			if gotDate != "20240102" {
+				t.Errorf("requested date = %q, want 20240102", gotDate)
+			}
+
+			snapshot := result.(map[string]*stooq.ParsedData)
+			if len(snapshot) != 2 {
+				t.Fatalf("len(snapshot) = %d, want 2", len(snapshot))
+			}
+			row := snapshot["MSFT.US"].Rows[0]
+			if row["Date"] != "2024-01-02" || row["Close"] != "370.87" {
+				t.Errorf("MSFT.US row = %v", row)
+			}
+		})
+	}
+
+	reader := stooq.NewStooqReader(nil)
+	if _, err := reader.ReadMarketSnapshot(context.Background(), time.Time{}); err == nil {
+		t.Error("ReadMarketSnapshot() with zero date should fail")
+	}
+}
+
+func TestStooqReader_SetInterval(t *testing.T) {
+	var gotInterval string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		gotInterval = r.URL.Query().Get("i")
+		w.Write([]byte("Date,Open,High,Low,Close,Volume\n2024-01-05,187.15,188.44,180.88,181.18,300000000\n"))
+	}))
+	defer server.Close()
+
+	tests := []struct {
+		interval sources.Interval
+		want     string
+	}{
+		{"", "d"},
+		{sources.Interval1wk, "w"},
+		{sources.Interval1mo, "m"},
+	}
+	for _, tt := range tests {
+		reader := stooq.NewStooqReaderWithBaseURL(nil, server.URL+"?s=%s&i=d")
+		if err := reader.SetInterval(tt.interval); err != nil {
+			t.Fatalf("SetInterval(%q) error = %v", tt.interval, err)
+		}
+		if _, err := reader.ReadSingle(context.Background(), "AAPL.US", time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC), time.Date(2024, 1, 31, 0, 0, 0, 0, time.UTC)); err != nil {
+			t.Fatalf("ReadSingle() error = %v", err)
+		}
+		if gotInterval != tt.want {
+			t.Errorf("i = %q with %q, want %q", gotInterval, tt.interval, tt.want)
+		}
+	}
+
+	if err := stooq.NewStooqReader(nil).SetInterval(sources.Interval1h); !errors.Is(err, sources.ErrUnsupportedInterval) {
+		t.Errorf("SetInterval(1h) error = %v, want ErrUnsupportedInterval", err)
+	}
+}
+
+func TestStooqReader_ListSymbols(t *testing.T) {
+	const bulk = "<TICKER>,<PER>,<DATE>,<TIME>,<OPEN>,<HIGH>,<LOW>,<CLOSE>,<VOL>,<OPENINT>\n" +
+		"MSFT.US,D,20240102,000000,373.86,375.9,366.77,370.87,25258633,0\n" +
+		"^SPX,D,20240102,000000,4745.2,4754.33,4722.67,4742.83,0,0\n" +
+		"AAPL.US,D,20240102,000000,187.15,188.44,183.885,185.64,82488674,0\n"
+
+	// The most recent weekday has no bulk file yet
+	var requests int
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		if requests == 1 {
+			http.NotFound(w, r)
+			return
+		}
+		w.Write([]byte(bulk))
+	}))
+	defer server.Close()
+
+	reader := stooq.NewStooqReader(nil)
+	reader.SetSnapshotURL(server.URL + "?d=%s")
+
+	infos, err := reader.ListSymbols(context.Background())
+	if err != nil {
+		t.Fatalf("ListSymbols() error = %v", err)
+	}
+	want := []sources.SymbolInfo{
+		{Symbol: "AAPL.US", Market: "US"},
+		{Symbol: "MSFT.US", Market: "US"},
+		{Symbol: "^SPX", Market: "index"},
+	}
+	if len(infos) != len(want) {
+		t.Fatalf("ListSymbols() = %+v, want %+v", infos, want)
+	}
+	for i := range want {
+		if infos[i] != want[i] {
+			t.Errorf("infos[%d] = %+v, want %+v", i, infos[i], want[i])
+		}
+	}
+	if requests != 2 {
+		t.Errorf("requests = %d, want 2", requests)
+	}
+}
+
+func TestStooqReader_ListSymbols_Unavailable(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusForbidden)
+	}))
+	defer server.Close()
+
+	reader := stooq.NewStooqReader(nil)
+	reader.SetSnapshotURL(server.URL + "?d=%s")
+
+	// Errors other than a missing file are returned at once
+	if _, err := reader.ListSymbols(context.Background()); !errors.Is(err, sources.ErrAuthRequired) {
+		t.Errorf("ListSymbols() error = %v, want ErrAuthRequired", err)
+	}
+}
+
+func TestStooqReader_ReadSingle_ErrorKinds(t *testing.T) {
+	tests := []struct {
+		name   string
+		status int
+		body   string
+		want   error
+	}{
+		{name: "not found", status: http.StatusNotFound, body: "not found", want: sources.ErrSymbolNotFound},
+		{name: "forbidden", status: http.StatusForbidden, body: "forbidden", want: sources.ErrAuthRequired},
+		{name: "rate limited", status: http.StatusTooManyRequests, body: "slow down", want: sources.ErrRateLimited},
+		{name: "no data", status: http.StatusOK, body: "No data", want: sources.ErrNoData},
+	}
+
+	start := time.Date(2023, 1, 1, 0, 0, 0, 0, time.UTC)
+	end := time.Date(2023, 1, 31, 0, 0, 0, 0, time.UTC)
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				w.Header().Set("Content-Type", "text/csv")
+				w.Header().Set("Retry-After", "60")
+				w.WriteHeader(tt.status)
+				w.Write([]byte(tt.body))
+			}))
+			defer server.Close()
+
+			reader := stooq.NewStooqReaderWithBaseURL(nil, server.URL+"?s=%s")
+			_, err := reader.ReadSingle(context.Background(), "AAPL.US", start, end)
+			if !errors.Is(err, tt.want) {
+				t.Fatalf("ReadSingle() error = %v, want %v", err, tt.want)
+			}
+
+			var rateErr *sources.RateLimitError
+			if errors.As(err, &rateErr) && rateErr.RetryAfter != time.Minute {
+				t.Errorf("RetryAfter = %v, want 1m", rateErr.RetryAfter)
+			}
+		})
+	}
+}
@@ -0,0 +1,96 @@
+package sources
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"time"
+)
+
+// ErrEmptyQuery is returned by SearchSymbols for a blank query.
+var ErrEmptyQuery = errors.New("empty search query")
+
+// Searcher is implemented by readers that look up symbols by name or
+// keyword, for when the ticker or series ID is not known.
+type Searcher interface {
+	// SearchSymbols returns the symbols matching query, most relevant
+	// first, as ranked by the provider.
+	SearchSymbols(ctx context.Context, query string) ([]SymbolInfo, error)
+}
+
+// Lister is implemented by readers that enumerate every symbol a source
+// serves, for screeners and full-universe backfills.
+type Lister interface {
+	// ListSymbols returns the source's symbol directory in the order the
+	// provider publishes it.
+	ListSymbols(ctx context.Context) ([]SymbolInfo, error)
+}
+
+// CheckQuery returns query without surrounding whitespace, or
+// ErrEmptyQuery when nothing is left.
+func CheckQuery(query string) (string, error) {
+	query = strings.TrimSpace(query)
+	if query == "" {
+		return "", ErrEmptyQuery
+	}
+	return query, nil
+}
+
+// SymbolInfo describes a security or series served by a source.
+type SymbolInfo struct {
+	// Symbol is the code passed to ReadSingle (e.g., "2330")
+	Symbol string
+	// Name is the name as the source publishes it, e.g., in Traditional
+	// Chinese for Taiwan securities
+	Name string
+	// EnglishName is the English or romanized name; empty when unknown
+	EnglishName string
+	// Market is the market the security trades on (e.g., "twse", "tpex");
+	// empty when unknown
+	Market string
+	// Industry is the industry category; empty when unknown
+	Industry string
+	// Type is the kind of instrument as the source names it (e.g.,
+	// "EQUITY", "ETF", "Stock"); "series" for economic series
+	Type string
+	// Description adds detail, such as a series' frequency and units
+	Description string
+	// ISIN is the International Securities Identification Number; empty
+	// when unknown
+	ISIN string
+	// ListingDate is the date the security was listed; zero when unknown
+	ListingDate time.Time
+}
+
+// DisplayName returns EnglishName when known and Name otherwise.
+func (s SymbolInfo) DisplayName() string {
+	if s.EnglishName != "" {
+		return s.EnglishName
+	}
+	return s.Name
+}
+
+// Matches reports whether query occurs in the symbol, name or English
+// name, ignoring case, so securities can be found without typing Chinese.
+func (s SymbolInfo) Matches(query string) bool {
+	query = strings.ToLower(strings.TrimSpace(query))
+	for _, field := range []string{s.Symbol, s.Name, s.EnglishName} {
+		if strings.Contains(strings.ToLower(field), query) {
+			return true
+		}
+	}
+	return false
+}
+
+// NameMap maps symbols to English names.
+type NameMap map[string]string
+
+// Apply sets the EnglishName of every entry of infos that has none and
+// whose symbol m holds.
+func (m NameMap) Apply(infos []SymbolInfo) {
+	for i := range infos {
+		if infos[i].EnglishName == "" {
+			infos[i].EnglishName = m[infos[i].Symbol]
+		}
+	}
+}
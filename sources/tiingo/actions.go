@@ -0,0 +1,57 @@
+package tiingo
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"time"
+
+	"github.com/julianshen/gonp-datareader/internal/utils"
+	"github.com/julianshen/gonp-datareader/sources"
+)
+
+// ReadActions returns the dividends and splits of symbol with ex-dates in
+// the range, read from the divCash and splitFactor fields of Tiingo's
+// daily prices. It costs one request, for daily prices whatever the
+// interval set with SetInterval.
+func (t *TiingoReader) ReadActions(ctx context.Context, symbol string, start, end time.Time) ([]sources.Action, error) {
+	symbol = t.NormalizeSymbol(symbol)
+	if err := t.ValidateSymbol(symbol); err != nil {
+		return nil, fmt.Errorf("invalid symbol: %w", err)
+	}
+	if err := utils.ValidateDateRange(start, end); err != nil {
+		return nil, fmt.Errorf("invalid date range: %w", err)
+	}
+
+	apiKey := t.getAPIKey(ctx)
+	if apiKey == "" {
+		return nil, fmt.Errorf("Tiingo API key is required")
+	}
+
+	data, _, err := t.fetchPrices(ctx, t.buildURL(symbol, start, end, apiKey, sources.Interval1d))
+	if err != nil {
+		return nil, err
+	}
+	dates, err := data.DateIndex()
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse dates: %w", err)
+	}
+
+	return priceActions(symbol, dates, data.Prices), nil
+}
+
+// priceActions returns the actions recorded in prices: a dividend for each
+// positive divCash and a split for each splitFactor other than 1.
+func priceActions(symbol string, dates []time.Time, prices []PriceData) []sources.Action {
+	var actions []sources.Action
+	for i, p := range prices {
+		if p.DivCash > 0 {
+			actions = append(actions, sources.Action{Symbol: symbol, Date: dates[i], Type: sources.ActionDividend, Amount: p.DivCash})
+		}
+		if p.SplitFactor > 0 && p.SplitFactor != 1 && !math.IsInf(p.SplitFactor, 0) {
+			actions = append(actions, sources.Action{Symbol: symbol, Date: dates[i], Type: sources.ActionSplit, Ratio: p.SplitFactor})
+		}
+	}
+	sources.SortActions(actions)
+	return actions
+}
@@ -0,0 +1,50 @@
+package tiingo_test
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/julianshen/gonp-datareader/sources"
+	"github.com/julianshen/gonp-datareader/sources/tiingo"
+)
+
+func TestTiingoReader_ReadActions(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Query().Get("resampleFreq") != "" {
+			t.Errorf("actions request resampled: %s", r.URL)
+		}
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`[
+			{"date": "2020-08-07T00:00:00.000Z", "close": 444.45, "divCash": 0.82, "splitFactor": 1},
+			{"date": "2020-08-10T00:00:00.000Z", "close": 450.91, "divCash": 0, "splitFactor": 1},
+			{"date": "2020-08-31T00:00:00.000Z", "close": 129.04, "divCash": 0, "splitFactor": 4},
+			{"date": "2020-09-01T00:00:00.000Z", "close": 134.18, "divCash": null, "splitFactor": null}
+		]`))
+	}))
+	defer server.Close()
+
+	reader := tiingo.NewTiingoReaderWithBaseURL(nil, server.URL+"/tiingo/daily/%s/prices")
+	reader.SetAPIKey("test-api-key")
+	if err := reader.SetInterval(sources.Interval1wk); err != nil {
+		t.Fatalf("SetInterval() error = %v", err)
+	}
+
+	start := time.Date(2020, 8, 1, 0, 0, 0, 0, time.UTC)
+	end := time.Date(2020, 9, 30, 0, 0, 0, 0, time.UTC)
+	actions, err := reader.ReadActions(context.Background(), "AAPL", start, end)
+	if err != nil {
+		t.Fatalf("ReadActions() error = %v", err)
+	}
+
+	want := []sources.Action{
+		{Symbol: "AAPL", Date: time.Date(2020, 8, 7, 0, 0, 0, 0, time.UTC), Type: sources.ActionDividend, Amount: 0.82},
+		{Symbol: "AAPL", Date: time.Date(2020, 8, 31, 0, 0, 0, 0, time.UTC), Type: sources.ActionSplit, Ratio: 4},
+	}
+	if !reflect.DeepEqual(actions, want) {
+		t.Errorf("ReadActions() = %+v, want %+v", actions, want)
+	}
+}
@@ -0,0 +1,142 @@
+package tiingo
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	internalhttp "github.com/julianshen/gonp-datareader/internal/http"
+)
+
+// DelistedGrace is how long a ticker may go without new prices before
+// Metadata.Delisted reports it as delisted; it covers holidays and the
+// delay of end-of-day updates.
+const DelistedGrace = 10 * 24 * time.Hour
+
+// Metadata describes a ticker, as returned by the Tiingo meta endpoint.
+// Tiingo keeps delisted tickers, whose EndDate is their last trading day.
+type Metadata struct {
+	Ticker       string
+	Name         string
+	ExchangeCode string
+	Description  string
+	// StartDate and EndDate are the first and last dates with prices;
+	// zero when Tiingo has no prices for the ticker.
+	StartDate time.Time
+	EndDate   time.Time
+}
+
+// Delisted reports whether the ticker stopped trading before asOf: its
+// last price is older than DelistedGrace.
+func (m *Metadata) Delisted(asOf time.Time) bool {
+	return !m.EndDate.IsZero() && m.EndDate.Before(asOf.Add(-DelistedGrace))
+}
+
+// metadataResponse is the JSON body of the meta endpoint.
+type metadataResponse struct {
+	Ticker       string `json:"ticker"`
+	Name         string `json:"name"`
+	ExchangeCode string `json:"exchangeCode"`
+	Description  string `json:"description"`
+	StartDate    string `json:"startDate"`
+	EndDate      string `json:"endDate"`
+}
+
+// ReadMetadata fetches the metadata of a ticker, including delisted ones.
+func (t *TiingoReader) ReadMetadata(ctx context.Context, symbol string) (*Metadata, error) {
+	symbol = t.NormalizeSymbol(symbol)
+	if err := t.ValidateSymbol(symbol); err != nil {
+		return nil, fmt.Errorf("invalid symbol: %w", err)
+	}
+
+	apiKey := t.getAPIKey(ctx)
+	if apiKey == "" {
+		return nil, fmt.Errorf("Tiingo API key is required")
+	}
+
+	// The meta endpoint is the prices endpoint without "/prices"
+	metaURL := strings.TrimSuffix(fmt.Sprintf(t.baseURL, symbol), "/prices")
+	req, err := http.NewRequestWithContext(internalhttp.WithContentType(ctx, "application/json"), "GET", metaURL+"?token="+url.QueryEscape(apiKey), nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create request: %w", err)
+	}
+
+	resp, err := t.client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch metadata: %w", err)
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read response: %w", err)
+	}
+	if resp.StatusCode != http.StatusOK {
+		return nil, internalhttp.StatusError(resp, fmt.Errorf("tiingo returned status %d: %s", resp.StatusCode, string(body)))
+	}
+
+	return parseMetadata(body)
+}
+
+// parseMetadata parses a meta endpoint response.
+func parseMetadata(body []byte) (*Metadata, error) {
+	var r metadataResponse
+	if err := json.Unmarshal(body, &r); err != nil {
+		return nil, fmt.Errorf("failed to parse JSON: %w", err)
+	}
+
+	m := &Metadata{
+		Ticker:       r.Ticker,
+		Name:         r.Name,
+		ExchangeCode: r.ExchangeCode,
+		Description:  r.Description,
+	}
+
+	var err error
+	if m.StartDate, err = parseMetaDate(r.StartDate); err != nil {
+		return nil, fmt.Errorf("parse startDate: %w", err)
+	}
+	if m.EndDate, err = parseMetaDate(r.EndDate); err != nil {
+		return nil, fmt.Errorf("parse endDate: %w", err)
+	}
+	return m, nil
+}
+
+// parseMetaDate parses a YYYY-MM-DD date; empty means the zero time.
+func parseMetaDate(s string) (time.Time, error) {
+	if s == "" {
+		return time.Time{}, nil
+	}
+	return time.Parse("2006-01-02", s)
+}
+
+// SetDelistingMeta makes ReadSingle also fetch the ticker's metadata and
+// flag delisted tickers in ParsedData.Meta with "delisted" = "true" and
+// "delisted_date" = their last trading day (YYYY-MM-DD), so backtests can
+// account for survivorship bias. This costs one extra request per symbol.
+func (t *TiingoReader) SetDelistingMeta(enabled bool) {
+	t.delistingMeta = enabled
+}
+
+// annotateDelisting adds the delisting flags of symbol to data.Meta.
+func (t *TiingoReader) annotateDelisting(ctx context.Context, symbol string, data *ParsedData) error {
+	meta, err := t.ReadMetadata(ctx, symbol)
+	if err != nil {
+		return err
+	}
+	if !meta.Delisted(time.Now()) {
+		return nil
+	}
+
+	if data.Meta == nil {
+		data.Meta = make(map[string]string)
+	}
+	data.Meta["delisted"] = "true"
+	data.Meta["delisted_date"] = meta.EndDate.Format("2006-01-02")
+	return nil
+}
@@ -0,0 +1,90 @@
+package tiingo_test
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/julianshen/gonp-datareader/sources/tiingo"
+)
+
+// newMetadataServer serves Tiingo prices and metadata; ticker "LEH" is
+// delisted.
+func newMetadataServer(t *testing.T) *httptest.Server {
+	t.Helper()
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		switch {
+		case strings.HasSuffix(r.URL.Path, "/prices"):
+			w.Write([]byte(`[{"date":"2008-09-12T00:00:00.000Z","close":3.65,"high":4.0,"low":3.5,"open":3.8,"volume":1000}]`))
+		case strings.HasSuffix(r.URL.Path, "/LEH"):
+			w.Write([]byte(`{"ticker":"LEH","name":"Lehman Brothers","exchangeCode":"NYSE","startDate":"1994-05-31","endDate":"2008-09-17"}`))
+		case strings.HasSuffix(r.URL.Path, "/AAPL"):
+			end := time.Now().Format("2006-01-02")
+			w.Write([]byte(`{"ticker":"AAPL","name":"Apple Inc","exchangeCode":"NASDAQ","startDate":"1980-12-12","endDate":"` + end + `"}`))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	t.Cleanup(server.Close)
+	return server
+}
+
+func TestTiingoReader_ReadMetadata(t *testing.T) {
+	server := newMetadataServer(t)
+	reader := tiingo.NewTiingoReaderWithBaseURL(nil, server.URL+"/tiingo/daily/%s/prices")
+	reader.SetAPIKey("test-key")
+
+	tests := []struct {
+		symbol       string
+		wantDelisted bool
+	}{
+		{"LEH", true},
+		{"AAPL", false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.symbol, func(t *testing.T) {
+			meta, err := reader.ReadMetadata(context.Background(), tt.symbol)
+			if err != nil {
+				t.Fatalf("ReadMetadata() error = %v", err)
+			}
+			if meta.Ticker != tt.symbol || meta.StartDate.IsZero() {
+				t.Errorf("ReadMetadata() = %+v", meta)
+			}
+			if got := meta.Delisted(time.Now()); got != tt.wantDelisted {
+				t.Errorf("Delisted() = %v, want %v", got, tt.wantDelisted)
+			}
+		})
+	}
+}
+
+func TestTiingoReader_DelistingMeta(t *testing.T) {
+	server := newMetadataServer(t)
+	reader := tiingo.NewTiingoReaderWithBaseURL(nil, server.URL+"/tiingo/daily/%s/prices")
+	reader.SetAPIKey("test-key")
+	reader.SetDelistingMeta(true)
+
+	start := time.Date(2008, 9, 1, 0, 0, 0, 0, time.UTC)
+	end := time.Date(2008, 9, 30, 0, 0, 0, 0, time.UTC)
+
+	result, err := reader.ReadSingle(context.Background(), "LEH", start, end)
+	if err != nil {
+		t.Fatalf("ReadSingle() error = %v", err)
+	}
+	data := result.(*tiingo.ParsedData)
+	if data.Meta["delisted"] != "true" || data.Meta["delisted_date"] != "2008-09-17" {
+		t.Errorf("Meta = %v, want delisted on 2008-09-17", data.Meta)
+	}
+
+	result, err = reader.ReadSingle(context.Background(), "AAPL", start, end)
+	if err != nil {
+		t.Fatalf("ReadSingle() error = %v", err)
+	}
+	if _, ok := result.(*tiingo.ParsedData).Meta["delisted"]; ok {
+		t.Error("active ticker flagged as delisted")
+	}
+}
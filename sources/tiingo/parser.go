@@ -4,27 +4,119 @@ import (
 	"encoding/json"
 	"fmt"
 	"io"
+	"math"
+	"strconv"
 	"strings"
 	"time"
+
+	"github.com/julianshen/gonp-datareader/ohlcv"
+	"github.com/julianshen/gonp-datareader/sources"
 )
 
-// PriceData represents a single price record from Tiingo.
+// PriceData represents a single price record from Tiingo. Fields Tiingo
+// reported as null (e.g., on halted days) are NaN.
 type PriceData struct {
-	Close  float64
-	Open   float64
-	High   float64
-	Low    float64
-	Volume int64
+	Close float64
+	Open  float64
+	High  float64
+	Low   float64
+	// Volume is a float64 because crypto and aggregate volumes can be
+	// fractional or exceed int64 precision.
+	Volume float64
+
+	// AdjOpen through AdjVolume are the split- and dividend-adjusted
+	// prices and volume; see TiingoReader.SetAdjustPrices
+	AdjOpen   float64
+	AdjHigh   float64
+	AdjLow    float64
+	AdjClose  float64
+	AdjVolume float64
+	// DivCash is the cash dividend paid on the day, and SplitFactor the
+	// split ratio (1 on days without a split)
+	DivCash     float64
+	SplitFactor float64
+}
+
+// adjusted returns p with the adjusted prices and volume in place of the
+// raw ones.
+func (p PriceData) adjusted() PriceData {
+	p.Open, p.High, p.Low, p.Close, p.Volume = p.AdjOpen, p.AdjHigh, p.AdjLow, p.AdjClose, p.AdjVolume
+	return p
 }
 
 // ParsedData holds parsed Tiingo data.
 type ParsedData struct {
-	Dates  []string
+	Dates  []string `schema:"Date,time"`
 	Prices []PriceData
+	// Meta holds response metadata such as "stale" when the data was
+	// served from an expired cache entry; nil when there is none.
+	Meta map[string]string `schema:"-"`
+	// Warnings lists the rows with null fields, one message per row
+	// (e.g., "2020-03-16: null open, high").
+	Warnings []string `schema:"-"`
+}
+
+// DateIndex returns Dates parsed as dates.
+func (p *ParsedData) DateIndex() ([]time.Time, error) {
+	if p == nil {
+		return nil, nil
+	}
+	return sources.ParseDates(p.Dates)
+}
+
+// SelectRows keeps the rows at indices, in that order (see
+// sources.RowSelector).
+func (p *ParsedData) SelectRows(indices []int) {
+	if p == nil {
+		return
+	}
+	p.Dates = sources.SelectRows(p.Dates, indices)
+	p.Prices = sources.SelectRows(p.Prices, indices)
 }
 
-// GetColumn returns a column of data by name.
-// Supported column names: "Date", "Close", "Open", "High", "Low", "Volume"
+// ColumnNames returns the price columns: "Open", "High", "Low", "Close",
+// "Volume" and "Adj Close".
+func (p *ParsedData) ColumnNames() []string {
+	return []string{"Open", "High", "Low", "Close", "Volume", "Adj Close"}
+}
+
+// Float64Column returns the named price column, with null values as NaN.
+func (p *ParsedData) Float64Column(name string) ([]float64, error) {
+	var field func(PriceData) float64
+	switch name {
+	case "Open":
+		field = func(d PriceData) float64 { return d.Open }
+	case "High":
+		field = func(d PriceData) float64 { return d.High }
+	case "Low":
+		field = func(d PriceData) float64 { return d.Low }
+	case "Close":
+		field = func(d PriceData) float64 { return d.Close }
+	case "Volume":
+		field = func(d PriceData) float64 { return d.Volume }
+	case "Adj Close":
+		field = func(d PriceData) float64 { return d.AdjClose }
+	}
+	if p == nil || field == nil {
+		return nil, sources.NoColumn(name)
+	}
+
+	values := make([]float64, len(p.Prices))
+	for i, price := range p.Prices {
+		values[i] = field(price)
+	}
+	return values, nil
+}
+
+// Bars returns the price columns as ohlcv.Bars (see ohlcv.Provider).
+func (p *ParsedData) Bars() (*ohlcv.Bars, error) {
+	return ohlcv.FromTimeSeries(p)
+}
+
+// GetColumn returns a column of data by name. Null values are returned
+// as empty strings.
+// Supported column names: "Date", "Close", "Open", "High", "Low", "Volume",
+// "Adj Close"
 func (p *ParsedData) GetColumn(name string) []string {
 	if p == nil {
 		return nil
@@ -36,31 +128,39 @@ func (p *ParsedData) GetColumn(name string) []string {
 	case "Close":
 		result := make([]string, len(p.Prices))
 		for i, price := range p.Prices {
-			result[i] = fmt.Sprintf("%g", price.Close)
+			result[i] = formatPrice(price.Close)
 		}
 		return result
 	case "Open":
 		result := make([]string, len(p.Prices))
 		for i, price := range p.Prices {
-			result[i] = fmt.Sprintf("%g", price.Open)
+			result[i] = formatPrice(price.Open)
 		}
 		return result
 	case "High":
 		result := make([]string, len(p.Prices))
 		for i, price := range p.Prices {
-			result[i] = fmt.Sprintf("%g", price.High)
+			result[i] = formatPrice(price.High)
 		}
 		return result
 	case "Low":
 		result := make([]string, len(p.Prices))
 		for i, price := range p.Prices {
-			result[i] = fmt.Sprintf("%g", price.Low)
+			result[i] = formatPrice(price.Low)
+		}
+		return result
+	case "Adj Close":
+		result := make([]string, len(p.Prices))
+		for i, price := range p.Prices {
+			result[i] = formatPrice(price.AdjClose)
 		}
 		return result
 	case "Volume":
 		result := make([]string, len(p.Prices))
 		for i, price := range p.Prices {
-			result[i] = fmt.Sprintf("%d", price.Volume)
+			if !math.IsNaN(price.Volume) {
+				result[i] = strconv.FormatFloat(price.Volume, 'f', -1, 64)
+			}
 		}
 		return result
 	default:
@@ -68,21 +168,48 @@ func (p *ParsedData) GetColumn(name string) []string {
 	}
 }
 
+// formatPrice formats a price, returning "" for null (NaN) values.
+func formatPrice(v float64) string {
+	if math.IsNaN(v) {
+		return ""
+	}
+	return fmt.Sprintf("%g", v)
+}
+
 // tiingoResponse represents the JSON structure returned by Tiingo API.
+// Prices are pointers because Tiingo returns null for fields it has no
+// value for, such as OHLC on halted days.
 type tiingoResponse struct {
-	Date        string  `json:"date"`
-	Close       float64 `json:"close"`
-	High        float64 `json:"high"`
-	Low         float64 `json:"low"`
-	Open        float64 `json:"open"`
-	Volume      int64   `json:"volume"`
-	AdjClose    float64 `json:"adjClose"`
-	AdjHigh     float64 `json:"adjHigh"`
-	AdjLow      float64 `json:"adjLow"`
-	AdjOpen     float64 `json:"adjOpen"`
-	AdjVolume   int64   `json:"adjVolume"`
-	DivCash     float64 `json:"divCash"`
-	SplitFactor float64 `json:"splitFactor"`
+	Date        string   `json:"date"`
+	Close       *float64 `json:"close"`
+	High        *float64 `json:"high"`
+	Low         *float64 `json:"low"`
+	Open        *float64 `json:"open"`
+	Volume      *float64 `json:"volume"`
+	AdjClose    *float64 `json:"adjClose"`
+	AdjHigh     *float64 `json:"adjHigh"`
+	AdjLow      *float64 `json:"adjLow"`
+	AdjOpen     *float64 `json:"adjOpen"`
+	AdjVolume   *float64 `json:"adjVolume"`
+	DivCash     *float64 `json:"divCash"`
+	SplitFactor *float64 `json:"splitFactor"`
+}
+
+// orNaN returns *v, or NaN when v is nil.
+func orNaN(v *float64) float64 {
+	if v == nil {
+		return math.NaN()
+	}
+	return *v
+}
+
+// nullable returns *v, or NaN with name appended to nulls when v is nil.
+func nullable(v *float64, name string, nulls *[]string) float64 {
+	if v == nil {
+		*nulls = append(*nulls, name)
+		return math.NaN()
+	}
+	return *v
 }
 
 // ParseJSON parses Tiingo JSON response data.
@@ -97,6 +224,7 @@ func ParseJSON(reader io.Reader) (*ParsedData, error) {
 	// Parse records
 	dates := make([]string, 0, len(resp))
 	prices := make([]PriceData, 0, len(resp))
+	var warnings []string
 
 	for _, record := range resp {
 		// Parse date (format: "2020-01-02T00:00:00.000Z")
@@ -108,18 +236,34 @@ func ParseJSON(reader io.Reader) (*ParsedData, error) {
 			date = strings.Split(date, "T")[0]
 		}
 
+		// Null fields become NaN, with one warning per affected row
+		var nulls []string
+		price := PriceData{
+			Open:   nullable(record.Open, "open", &nulls),
+			High:   nullable(record.High, "high", &nulls),
+			Low:    nullable(record.Low, "low", &nulls),
+			Close:  nullable(record.Close, "close", &nulls),
+			Volume: nullable(record.Volume, "volume", &nulls),
+
+			AdjOpen:     orNaN(record.AdjOpen),
+			AdjHigh:     orNaN(record.AdjHigh),
+			AdjLow:      orNaN(record.AdjLow),
+			AdjClose:    orNaN(record.AdjClose),
+			AdjVolume:   orNaN(record.AdjVolume),
+			DivCash:     orNaN(record.DivCash),
+			SplitFactor: orNaN(record.SplitFactor),
+		}
+		if len(nulls) > 0 {
+			warnings = append(warnings, fmt.Sprintf("%s: null %s", date, strings.Join(nulls, ", ")))
+		}
+
 		dates = append(dates, date)
-		prices = append(prices, PriceData{
-			Close:  record.Close,
-			Open:   record.Open,
-			High:   record.High,
-			Low:    record.Low,
-			Volume: record.Volume,
-		})
+		prices = append(prices, price)
 	}
 
 	return &ParsedData{
-		Dates:  dates,
-		Prices: prices,
+		Dates:    dates,
+		Prices:   prices,
+		Warnings: warnings,
 	}, nil
 }
@@ -1,12 +1,38 @@
 package tiingo_test
 
 import (
+	"math"
 	"strings"
 	"testing"
 
 	"github.com/julianshen/gonp-datareader/sources/tiingo"
 )
 
+func TestParseJSON_ExtremeVolumes(t *testing.T) {
+	jsonData := `[
+		{"date": "2024-01-02T00:00:00.000Z", "close": 1, "volume": 12345.6789},
+		{"date": "2024-01-03T00:00:00.000Z", "close": 1, "volume": 1.5e19},
+		{"date": "2024-01-04T00:00:00.000Z", "close": 1, "volume": 9223372036854775807}
+	]`
+
+	data, err := tiingo.ParseJSON(strings.NewReader(jsonData))
+	if err != nil {
+		t.Fatalf("ParseJSON failed: %v", err)
+	}
+
+	want := []float64{12345.6789, 1.5e19, 9223372036854775807}
+	for i, w := range want {
+		if data.Prices[i].Volume != w {
+			t.Errorf("Prices[%d].Volume = %v, want %v", i, data.Prices[i].Volume, w)
+		}
+	}
+
+	volumes := data.GetColumn("Volume")
+	if volumes[0] != "12345.6789" || volumes[1] != "15000000000000000000" {
+		t.Errorf("GetColumn(Volume) = %v", volumes)
+	}
+}
+
 func TestParseJSON(t *testing.T) {
 	jsonData := `[
 		{
@@ -81,7 +107,7 @@ func TestParseJSON(t *testing.T) {
 	}
 
 	if firstPrice.Volume != 33911900 {
-		t.Errorf("Expected volume 33911900, got %d", firstPrice.Volume)
+		t.Errorf("Expected volume 33911900, got %v", firstPrice.Volume)
 	}
 }
 
@@ -162,3 +188,64 @@ func BenchmarkParseJSON(b *testing.B) {
 		}
 	}
 }
+
+func TestParseJSON_NullPrices(t *testing.T) {
+	tests := []struct {
+		name         string
+		json         string
+		wantNaN      []string
+		wantWarnings []string
+	}{
+		{
+			name:    "no nulls",
+			json:    `[{"date": "2020-03-13T00:00:00.000Z", "open": 1, "high": 2, "low": 0.5, "close": 1.5, "volume": 100}]`,
+			wantNaN: nil,
+		},
+		{
+			name:         "halted day",
+			json:         `[{"date": "2020-03-16T00:00:00.000Z", "open": null, "high": null, "low": null, "close": 1.5, "volume": 0}]`,
+			wantNaN:      []string{"Open", "High", "Low"},
+			wantWarnings: []string{"2020-03-16: null open, high, low"},
+		},
+		{
+			name:         "null volume and adjusted fields",
+			json:         `[{"date": "2020-03-17T00:00:00.000Z", "open": 1, "high": 2, "low": 0.5, "close": 1.5, "volume": null, "adjClose": null}]`,
+			wantNaN:      []string{"Volume"},
+			wantWarnings: []string{"2020-03-17: null volume"},
+		},
+		{
+			name:         "missing fields",
+			json:         `[{"date": "2020-03-18T00:00:00.000Z", "close": 1.5}]`,
+			wantNaN:      []string{"Open", "High", "Low", "Volume"},
+			wantWarnings: []string{"2020-03-18: null open, high, low, volume"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			data, err := tiingo.ParseJSON(strings.NewReader(tt.json))
+			if err != nil {
+				t.Fatalf("ParseJSON failed: %v", err)
+			}
+
+			p := data.Prices[0]
+			values := map[string]float64{"Open": p.Open, "High": p.High, "Low": p.Low, "Close": p.Close, "Volume": p.Volume}
+			nan := make(map[string]bool)
+			for _, name := range tt.wantNaN {
+				nan[name] = true
+			}
+			for name, v := range values {
+				if math.IsNaN(v) != nan[name] {
+					t.Errorf("%s = %v, want NaN: %v", name, v, nan[name])
+				}
+				if got := data.GetColumn(name)[0]; (got == "") != nan[name] {
+					t.Errorf("GetColumn(%s) = %q", name, got)
+				}
+			}
+
+			if strings.Join(data.Warnings, "|") != strings.Join(tt.wantWarnings, "|") {
+				t.Errorf("Warnings = %v, want %v", data.Warnings, tt.wantWarnings)
+			}
+		})
+	}
+}
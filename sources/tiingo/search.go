@@ -0,0 +1,100 @@
+package tiingo
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+
+	internalhttp "github.com/julianshen/gonp-datareader/internal/http"
+	"github.com/julianshen/gonp-datareader/sources"
+)
+
+// tiingoSearchURL is the ticker search endpoint
+const tiingoSearchURL = "https://api.tiingo.com/tiingo/utilities/search"
+
+// SetSearchURL overrides the search endpoint. By default it is derived
+// from the base URL. This is primarily used for testing with mock servers.
+func (t *TiingoReader) SetSearchURL(searchURL string) {
+	t.searchURL = searchURL
+}
+
+// searchEndpoint returns the search endpoint: the utilities/search path
+// next to the daily prices path of the base URL.
+func (t *TiingoReader) searchEndpoint() string {
+	if t.searchURL != "" {
+		return t.searchURL
+	}
+	if root, ok := strings.CutSuffix(t.baseURL, "/daily/%s/prices"); ok {
+		return root + "/utilities/search"
+	}
+	return tiingoSearchURL
+}
+
+// SearchSymbols looks up tickers by company name or keyword, including
+// delisted ones, with Tiingo's search endpoint.
+func (t *TiingoReader) SearchSymbols(ctx context.Context, query string) ([]sources.SymbolInfo, error) {
+	query, err := sources.CheckQuery(query)
+	if err != nil {
+		return nil, err
+	}
+
+	apiKey := t.getAPIKey(ctx)
+	if apiKey == "" {
+		return nil, fmt.Errorf("Tiingo API key is required")
+	}
+
+	searchURL := fmt.Sprintf("%s?query=%s&token=%s", t.searchEndpoint(), url.QueryEscape(query), url.QueryEscape(apiKey))
+	req, err := http.NewRequestWithContext(internalhttp.WithContentType(ctx, "application/json"), "GET", searchURL, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create request: %w", err)
+	}
+
+	resp, err := t.client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch search results: %w", err)
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read response: %w", err)
+	}
+	if resp.StatusCode != http.StatusOK {
+		return nil, internalhttp.StatusError(resp, fmt.Errorf("tiingo returned status %d: %s", resp.StatusCode, string(body)))
+	}
+
+	return ParseSearch(body)
+}
+
+// ParseSearch parses a search endpoint response. Inactive tickers are
+// described as delisted.
+func ParseSearch(body []byte) ([]sources.SymbolInfo, error) {
+	var results []struct {
+		Ticker      string `json:"ticker"`
+		Name        string `json:"name"`
+		AssetType   string `json:"assetType"`
+		IsActive    bool   `json:"isActive"`
+		CountryCode string `json:"countryCode"`
+	}
+	if err := json.Unmarshal(body, &results); err != nil {
+		return nil, fmt.Errorf("failed to parse JSON: %w", err)
+	}
+
+	matches := make([]sources.SymbolInfo, len(results))
+	for i, r := range results {
+		matches[i] = sources.SymbolInfo{
+			Symbol: r.Ticker,
+			Name:   r.Name,
+			Market: r.CountryCode,
+			Type:   r.AssetType,
+		}
+		if !r.IsActive {
+			matches[i].Description = "Delisted"
+		}
+	}
+	return matches, nil
+}
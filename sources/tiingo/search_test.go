@@ -0,0 +1,44 @@
+package tiingo_test
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"testing"
+
+	"github.com/julianshen/gonp-datareader/sources"
+	"github.com/julianshen/gonp-datareader/sources/tiingo"
+)
+
+func TestTiingoReader_SearchSymbols(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/tiingo/utilities/search" {
+			t.Errorf("path = %q, want the search endpoint next to the prices endpoint", r.URL.Path)
+		}
+		if r.URL.Query().Get("query") != "lehman" || r.URL.Query().Get("token") != "test-key" {
+			t.Errorf("unexpected query %s", r.URL.RawQuery)
+		}
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`[{"ticker":"LEHMQ","name":"Lehman Brothers Holdings Inc","assetType":"Stock","isActive":false,"countryCode":"US"}]`))
+	}))
+	defer server.Close()
+
+	reader := tiingo.NewTiingoReaderWithBaseURL(nil, server.URL+"/tiingo/daily/%s/prices")
+	reader.SetAPIKey("test-key")
+
+	matches, err := reader.SearchSymbols(context.Background(), "lehman")
+	if err != nil {
+		t.Fatalf("SearchSymbols() error = %v", err)
+	}
+	want := []sources.SymbolInfo{
+		{Symbol: "LEHMQ", Name: "Lehman Brothers Holdings Inc", Market: "US", Type: "Stock", Description: "Delisted"},
+	}
+	if !reflect.DeepEqual(matches, want) {
+		t.Errorf("SearchSymbols() = %+v, want %+v", matches, want)
+	}
+
+	if _, err := tiingo.NewTiingoReader(nil).SearchSymbols(context.Background(), "lehman"); err == nil {
+		t.Error("SearchSymbols() without an API key succeeded, want an error")
+	}
+}
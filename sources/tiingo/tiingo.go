@@ -2,6 +2,7 @@
 package tiingo
 
 import (
+	"bytes"
 	"context"
 	"fmt"
 	"io"
@@ -23,6 +24,9 @@ type contextKey string
 
 const (
 	// APIKeyContextKey is the context key for the API key
+	//
+	// Deprecated: pass the key with datareader.WithAPIKey, which applies
+	// to every source.
 	APIKeyContextKey contextKey = "apiKey"
 )
 
@@ -32,6 +36,18 @@ type TiingoReader struct {
 	client  *internalhttp.RetryableClient
 	baseURL string
 	apiKey  string
+
+	// delistingMeta enables delisting flags in ParsedData.Meta
+	delistingMeta bool
+
+	// searchURL overrides the endpoint of SearchSymbols; see SetSearchURL
+	searchURL string
+
+	// interval is the bar interval; see SetInterval
+	interval sources.Interval
+
+	// adjustPrices selects the adjusted prices; see SetAdjustPrices
+	adjustPrices bool
 }
 
 // NewTiingoReader creates a new Tiingo data reader.
@@ -59,8 +75,18 @@ func (t *TiingoReader) Name() string {
 	return "Tiingo"
 }
 
+// Schema returns the columns of the ParsedData returned by ReadSingle.
+func (t *TiingoReader) Schema() sources.Schema {
+	return sources.NewSchema(t.Source(), t.Name(), ParsedData{})
+}
+
 // BuildURL constructs the Tiingo API URL for the given symbol and date range.
 func (t *TiingoReader) BuildURL(symbol string, start, end time.Time, apiKey string) string {
+	return t.buildURL(symbol, start, end, apiKey, t.interval)
+}
+
+// buildURL constructs the API URL of bars of interval.
+func (t *TiingoReader) buildURL(symbol string, start, end time.Time, apiKey string, interval sources.Interval) string {
 	baseURL := fmt.Sprintf(t.baseURL, symbol)
 
 	// Format dates as YYYY-MM-DD
@@ -70,12 +96,53 @@ func (t *TiingoReader) BuildURL(symbol string, start, end time.Time, apiKey stri
 	// Build query parameters
 	url := fmt.Sprintf("%s?startDate=%s&endDate=%s&token=%s",
 		baseURL, startDate, endDate, apiKey)
+	if freq, ok := resampleFreqs[interval]; ok {
+		url += "&resampleFreq=" + freq
+	}
 
 	return url
 }
 
+// Intervals lists the bar intervals of Tiingo's end-of-day prices.
+var Intervals = []sources.Interval{sources.Interval1d, sources.Interval1wk, sources.Interval1mo}
+
+// resampleFreqs maps the resampled intervals to the resampleFreq
+// parameter.
+var resampleFreqs = map[sources.Interval]string{
+	sources.Interval1wk: "weekly",
+	sources.Interval1mo: "monthly",
+}
+
+// SetInterval sets the bar interval of ReadSingle and Read; empty means
+// daily. Weekly and monthly bars are resampled by Tiingo. Intervals not in
+// Intervals return an error matching sources.ErrUnsupportedInterval.
+func (t *TiingoReader) SetInterval(interval sources.Interval) error {
+	if err := sources.CheckInterval(interval, Intervals); err != nil {
+		return err
+	}
+	t.interval = interval
+	return nil
+}
+
+// SetAdjustPrices selects split- and dividend-adjusted prices. When
+// enabled, ReadSingle replaces Open, High, Low, Close and Volume with
+// Tiingo's adjOpen, adjHigh, adjLow, adjClose and adjVolume; the Adj Close
+// column is unchanged. Meta["adjusted"] is set to "true".
+func (t *TiingoReader) SetAdjustPrices(enabled bool) {
+	t.adjustPrices = enabled
+}
+
 // ReadSingle fetches data for a single symbol from Tiingo.
 func (t *TiingoReader) ReadSingle(ctx context.Context, symbol string, start, end time.Time) (interface{}, error) {
+	return t.Finish(ctx, symbol, start, end, t.readSingle)
+}
+
+// readSingle implements ReadSingle before Finish is applied.
+func (t *TiingoReader) readSingle(ctx context.Context, symbol string, start, end time.Time) (interface{}, error) {
+	// Normalize user input; the original is kept in Meta["symbol_input"]
+	input := symbol
+	symbol = t.NormalizeSymbol(symbol)
+
 	// Validate inputs
 	if err := t.ValidateSymbol(symbol); err != nil {
 		return nil, fmt.Errorf("invalid symbol: %w", err)
@@ -94,16 +161,51 @@ func (t *TiingoReader) ReadSingle(ctx context.Context, symbol string, start, end
 	// Build URL
 	url := t.BuildURL(symbol, start, end, apiKey)
 
+	decoded, resp, err := t.fetchPrices(ctx, url)
+	if err != nil {
+		return nil, err
+	}
+	if len(decoded.Dates) == 0 {
+		return nil, fmt.Errorf("%w: no Tiingo prices of %s", sources.ErrNoData, symbol)
+	}
+
+	// Copy the decoded value, which may be shared through the decoded cache,
+	// and flag data served from an expired cache entry
+	data := *decoded
+	data.Meta = sources.InputMeta(internalhttp.StaleMeta(resp), input, symbol)
+	data.Meta = sources.WarningsMeta(data.Meta, data.Warnings)
+	if t.adjustPrices {
+		prices := make([]PriceData, len(data.Prices))
+		for i, p := range data.Prices {
+			prices[i] = p.adjusted()
+		}
+		data.Prices = prices
+		data.Meta = sources.AdjustedMeta(data.Meta)
+	}
+
+	if t.delistingMeta {
+		if err := t.annotateDelisting(ctx, symbol, &data); err != nil {
+			return nil, fmt.Errorf("failed to read metadata: %w", err)
+		}
+	}
+
+	return &data, nil
+}
+
+// fetchPrices requests url and returns the decoded prices, which may be
+// shared through the decoded cache and must not be modified, and the
+// response, whose body is already closed.
+func (t *TiingoReader) fetchPrices(ctx context.Context, url string) (*ParsedData, *http.Response, error) {
 	// Create HTTP request
-	req, err := http.NewRequestWithContext(ctx, "GET", url, nil)
+	req, err := http.NewRequestWithContext(internalhttp.WithContentType(ctx, "application/json"), "GET", url, nil)
 	if err != nil {
-		return nil, fmt.Errorf("failed to create request: %w", err)
+		return nil, nil, fmt.Errorf("failed to create request: %w", err)
 	}
 
 	// Execute request
 	resp, err := t.client.Do(req)
 	if err != nil {
-		return nil, fmt.Errorf("failed to fetch data: %w", err)
+		return nil, nil, fmt.Errorf("failed to fetch data: %w", err)
 	}
 	defer resp.Body.Close()
 
@@ -111,25 +213,33 @@ func (t *TiingoReader) ReadSingle(ctx context.Context, symbol string, start, end
 	if resp.StatusCode != http.StatusOK {
 		body, err := io.ReadAll(resp.Body)
 		if err != nil {
-			return nil, fmt.Errorf("tiingo returned status %d (failed to read response body: %w)", resp.StatusCode, err)
+			return nil, nil, fmt.Errorf("tiingo returned status %d (failed to read response body: %w)", resp.StatusCode, err)
 		}
-		return nil, fmt.Errorf("tiingo returned status %d: %s", resp.StatusCode, string(body))
+		return nil, nil, internalhttp.StatusError(resp, fmt.Errorf("tiingo returned status %d: %s", resp.StatusCode, string(body)))
+	}
+
+	// Read response body
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to read response: %w", err)
 	}
 
 	// Parse JSON response
-	data, err := ParseJSON(resp.Body)
+	decoded, err := t.client.Decode("tiingo", body, func(b []byte) (interface{}, error) {
+		return ParseJSON(bytes.NewReader(b))
+	})
 	if err != nil {
-		return nil, fmt.Errorf("failed to parse JSON: %w", err)
+		return nil, nil, fmt.Errorf("failed to parse JSON: %w", err)
 	}
 
-	return data, nil
+	return decoded.(*ParsedData), resp, nil
 }
 
 // Read fetches data for multiple symbols from Tiingo.
 // Symbols are fetched in parallel for better performance.
 func (t *TiingoReader) Read(ctx context.Context, symbols []string, start, end time.Time) (interface{}, error) {
 	// Validate inputs
-	if err := utils.ValidateSymbols(symbols); err != nil {
+	if err := utils.ValidateSymbols(t.NormalizeSymbols(symbols)); err != nil {
 		return nil, fmt.Errorf("invalid symbols: %w", err)
 	}
 
@@ -198,20 +308,37 @@ func (t *TiingoReader) readParallel(ctx context.Context, symbols []string, start
 	return dataMap, nil
 }
 
-// getAPIKey retrieves the API key from context or the reader's stored key.
+// getAPIKey retrieves the API key from context (APIKeyContextKey, then
+// the call options) or the reader's stored key.
 func (t *TiingoReader) getAPIKey(ctx context.Context) string {
 	// Try to get from context first
 	if key := ctx.Value(APIKeyContextKey); key != nil {
 		if apiKey, ok := key.(string); ok && apiKey != "" {
+			sources.ReportDeprecation(sources.Deprecation{
+				API:         "tiingo.APIKeyContextKey",
+				Replacement: "datareader.WithAPIKey",
+				Since:       "v1.1.0",
+			})
 			return apiKey
 		}
 	}
 
-	// Fall back to stored API key
-	return t.apiKey
+	// Fall back to the call's override, then the stored API key
+	return internalhttp.APIKey(ctx, t.apiKey)
 }
 
 // SetAPIKey sets the API key for the reader.
 func (t *TiingoReader) SetAPIKey(apiKey string) {
 	t.apiKey = apiKey
 }
+
+// Shutdown stops accepting requests and waits for in-flight requests to
+// finish, cancelling them if ctx expires first.
+func (t *TiingoReader) Shutdown(ctx context.Context) error {
+	return t.client.Shutdown(ctx)
+}
+
+// Close cancels in-flight requests and releases the reader's connections.
+func (t *TiingoReader) Close() error {
+	return t.client.Close()
+}
@@ -2,12 +2,15 @@ package tiingo_test
 
 import (
 	"context"
+	"errors"
 	"net/http"
 	"net/http/httptest"
+	"strings"
 	"testing"
 	"time"
 
 	internalhttp "github.com/julianshen/gonp-datareader/internal/http"
+	"github.com/julianshen/gonp-datareader/sources"
 	"github.com/julianshen/gonp-datareader/sources/tiingo"
 )
 
@@ -68,6 +71,34 @@ func TestTiingoReader_ValidateSymbol(t *testing.T) {
 	}
 }
 
+func TestTiingoReader_SetInterval(t *testing.T) {
+	start := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	end := time.Date(2024, 12, 31, 0, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		interval sources.Interval
+		want     string
+	}{
+		{sources.Interval1d, ""},
+		{sources.Interval1wk, "&resampleFreq=weekly"},
+		{sources.Interval1mo, "&resampleFreq=monthly"},
+	}
+	for _, tt := range tests {
+		reader := tiingo.NewTiingoReader(nil)
+		if err := reader.SetInterval(tt.interval); err != nil {
+			t.Fatalf("SetInterval(%q) error = %v", tt.interval, err)
+		}
+		url := reader.BuildURL("AAPL", start, end, "key")
+		if got := strings.Contains(url, "resampleFreq"); got != (tt.want != "") || !strings.Contains(url, tt.want) {
+			t.Errorf("BuildURL() with %q = %s, want %q", tt.interval, url, tt.want)
+		}
+	}
+
+	if err := tiingo.NewTiingoReader(nil).SetInterval(sources.Interval5m); !errors.Is(err, sources.ErrUnsupportedInterval) {
+		t.Errorf("SetInterval(5m) error = %v, want ErrUnsupportedInterval", err)
+	}
+}
+
 func TestTiingoReader_BuildURL(t *testing.T) {
 	reader := tiingo.NewTiingoReader(nil)
 
@@ -174,6 +205,70 @@ func TestTiingoReader_ReadSingle_WithMockServer(t *testing.T) {
 	}
 }
 
+func TestTiingoReader_ReadSingle_NullPricesWarning(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`[{"date": "2020-03-16T00:00:00.000Z", "open": null, "high": null, "low": null, "close": 1.5, "volume": 0}]`))
+	}))
+	defer server.Close()
+
+	reader := tiingo.NewTiingoReaderWithBaseURL(nil, server.URL+"/tiingo/daily/%s/prices")
+	reader.SetAPIKey("test-api-key")
+
+	start := time.Date(2020, 3, 1, 0, 0, 0, 0, time.UTC)
+	end := time.Date(2020, 3, 31, 0, 0, 0, 0, time.UTC)
+	result, err := reader.ReadSingle(context.Background(), "AAPL", start, end)
+	if err != nil {
+		t.Fatalf("ReadSingle() error = %v", err)
+	}
+
+	data := result.(*tiingo.ParsedData)
+	if got, want := data.Meta["warnings"], "2020-03-16: null open, high, low"; got != want {
+		t.Errorf("Meta[warnings] = %q, want %q", got, want)
+	}
+}
+
+func TestTiingoReader_SetAdjustPrices(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`[{"date": "2020-01-02T00:00:00.000Z", "open": 300, "high": 310, "low": 290, "close": 300, "volume": 1000,
+			"adjOpen": 150, "adjHigh": 155, "adjLow": 145, "adjClose": 150, "adjVolume": 2000, "divCash": 0, "splitFactor": 1}]`))
+	}))
+	defer server.Close()
+
+	start := time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC)
+	end := time.Date(2020, 1, 31, 0, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		adjust bool
+		want   tiingo.PriceData
+	}{
+		{false, tiingo.PriceData{Open: 300, High: 310, Low: 290, Close: 300, Volume: 1000}},
+		{true, tiingo.PriceData{Open: 150, High: 155, Low: 145, Close: 150, Volume: 2000}},
+	}
+	for _, tt := range tests {
+		reader := tiingo.NewTiingoReaderWithBaseURL(nil, server.URL+"/tiingo/daily/%s/prices")
+		reader.SetAPIKey("test-api-key")
+		reader.SetAdjustPrices(tt.adjust)
+
+		result, err := reader.ReadSingle(context.Background(), "AAPL", start, end)
+		if err != nil {
+			t.Fatalf("ReadSingle() error = %v", err)
+		}
+		data := result.(*tiingo.ParsedData)
+		got := data.Prices[0]
+		if got.Open != tt.want.Open || got.High != tt.want.High || got.Low != tt.want.Low || got.Close != tt.want.Close || got.Volume != tt.want.Volume {
+			t.Errorf("adjust=%v: Prices[0] = %+v, want %+v", tt.adjust, got, tt.want)
+		}
+		if got.AdjClose != 150 {
+			t.Errorf("adjust=%v: AdjClose = %v, want 150", tt.adjust, got.AdjClose)
+		}
+		if got := data.Meta["adjusted"] == "true"; got != tt.adjust {
+			t.Errorf("adjust=%v: Meta = %v", tt.adjust, data.Meta)
+		}
+	}
+}
+
 func TestTiingoReader_ReadSingle_InvalidSymbol(t *testing.T) {
 	reader := tiingo.NewTiingoReader(nil)
 
@@ -374,10 +469,19 @@ func TestTiingoReader_APIKeyFromContext(t *testing.T) {
 	start := time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC)
 	end := time.Date(2020, 1, 31, 0, 0, 0, 0, time.UTC)
 
+	var reported []sources.Deprecation
+	sources.SetDeprecationHandler(func(d sources.Deprecation) {
+		reported = append(reported, d)
+	})
+	t.Cleanup(func() { sources.SetDeprecationHandler(nil) })
+
 	_, err := reader.ReadSingle(ctx, "AAPL", start, end)
 	if err != nil {
 		t.Errorf("ReadSingle() should succeed with API key from context: %v", err)
 	}
+	if len(reported) != 1 || reported[0].API != "tiingo.APIKeyContextKey" {
+		t.Errorf("reported %v, want one tiingo.APIKeyContextKey deprecation", reported)
+	}
 }
 
 // TestTiingoReader_Read_EmptySymbols tests error handling for empty symbol list
@@ -408,3 +512,21 @@ func findSubstring(s, substr string) bool {
 	}
 	return false
 }
+
+func TestTiingoReader_ReadSingle_NoData(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`[]`))
+	}))
+	defer server.Close()
+
+	reader := tiingo.NewTiingoReaderWithBaseURL(nil, server.URL+"/tiingo/daily/%s/prices")
+	reader.SetAPIKey("test-api-key")
+
+	start := time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC)
+	end := time.Date(2020, 1, 31, 0, 0, 0, 0, time.UTC)
+	_, err := reader.ReadSingle(context.Background(), "AAPL", start, end)
+	if !errors.Is(err, sources.ErrNoData) {
+		t.Errorf("ReadSingle() error = %v, want ErrNoData", err)
+	}
+}
@@ -0,0 +1,123 @@
+package sources
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"time"
+
+	"github.com/julianshen/gonp-datareader/dataset"
+	"github.com/julianshen/gonp-datareader/internal/numparse"
+)
+
+// TimeSeries is implemented by the ParsedData of every source, so analysis
+// code can read dates and numeric columns without asserting on each
+// concrete type.
+//
+// The methods are named DateIndex and ColumnNames rather than Dates and
+// Columns because several ParsedData types already have fields of those
+// names.
+//
+// # Example Usage
+//
+//	data, _ := reader.ReadSingle(ctx, "AAPL", start, end)
+//	ts := data.(sources.TimeSeries)
+//	dates, _ := ts.DateIndex()
+//	for _, name := range ts.ColumnNames() {
+//		values, err := ts.Float64Column(name)
+//		...
+//	}
+type TimeSeries interface {
+	// DateIndex returns the date of each row.
+	DateIndex() ([]time.Time, error)
+
+	// ColumnNames returns the names of the value columns in order,
+	// excluding the date column.
+	ColumnNames() []string
+
+	// Float64Column returns the named column as float64 values aligned
+	// with DateIndex, with missing values as NaN. It returns an error
+	// wrapping ErrNoColumn for unknown columns, and an error naming the
+	// row for columns holding text.
+	Float64Column(name string) ([]float64, error)
+}
+
+// ParseDates parses a date column as returned by the sources, accepting
+// the formats of dataset.ParseDate. Empty values become the zero time.
+func ParseDates(values []string) ([]time.Time, error) {
+	dates := make([]time.Time, len(values))
+	for i, s := range values {
+		if s == "" {
+			continue
+		}
+		t, err := dataset.ParseDate(s)
+		if err != nil {
+			return nil, fmt.Errorf("row %d: %w", i, err)
+		}
+		dates[i] = t
+	}
+	return dates, nil
+}
+
+// ParseFloats parses a numeric column as returned by the sources. Missing
+// values ("", ".", "null", "NaN", ...) become NaN.
+func ParseFloats(values []string) ([]float64, error) {
+	floats := make([]float64, len(values))
+	for i, s := range values {
+		v, err := parseFloat(s)
+		if err != nil {
+			return nil, fmt.Errorf("row %d: %w", i, err)
+		}
+		floats[i] = v
+	}
+	return floats, nil
+}
+
+// MaskedColumn returns the named column of ts as Float64Column does, with
+// missing values as NaN, together with its validity mask: false where a
+// value is missing. Numeric code can use it for any source instead of
+// checking source-specific markers such as FRED's "." or World Bank nulls.
+func MaskedColumn(ts TimeSeries, name string) ([]float64, []bool, error) {
+	values, err := ts.Float64Column(name)
+	if err != nil {
+		return nil, nil, err
+	}
+	return values, dataset.ValidMask(values), nil
+}
+
+// ValueColumns returns columns without the date column and any other
+// excluded non-numeric columns.
+func ValueColumns(columns []string, exclude ...string) []string {
+	out := make([]string, 0, len(columns))
+	for _, c := range columns {
+		skip := false
+		for _, e := range exclude {
+			if c == e {
+				skip = true
+				break
+			}
+		}
+		if !skip {
+			out = append(out, c)
+		}
+	}
+	return out
+}
+
+// NoColumn returns the error of a TimeSeries for an unknown column.
+func NoColumn(name string) error {
+	return fmt.Errorf("%w: %q", ErrNoColumn, name)
+}
+
+// parseFloat parses a single value, returning NaN for missing values,
+// including FRED's "." placeholder.
+func parseFloat(s string) (float64, error) {
+	if s == "." {
+		return math.NaN(), nil
+	}
+	v, err := numparse.ParseFloat(s)
+	if errors.Is(err, numparse.ErrMissing) {
+		return math.NaN(), nil
+	}
+	return v, err
+}
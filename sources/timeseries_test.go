@@ -0,0 +1,183 @@
+package sources_test
+
+import (
+	"errors"
+	"math"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/julianshen/gonp-datareader/sources"
+	"github.com/julianshen/gonp-datareader/sources/alphavantage"
+	"github.com/julianshen/gonp-datareader/sources/eurostat"
+	"github.com/julianshen/gonp-datareader/sources/finmind"
+	"github.com/julianshen/gonp-datareader/sources/fred"
+	"github.com/julianshen/gonp-datareader/sources/iex"
+	"github.com/julianshen/gonp-datareader/sources/oecd"
+	"github.com/julianshen/gonp-datareader/sources/stooq"
+	"github.com/julianshen/gonp-datareader/sources/tiingo"
+	"github.com/julianshen/gonp-datareader/sources/twse"
+	"github.com/julianshen/gonp-datareader/sources/worldbank"
+	"github.com/julianshen/gonp-datareader/sources/yahoo"
+)
+
+func TestParseDates(t *testing.T) {
+	got, err := sources.ParseDates([]string{"2024-01-02", "", "2024-Q2", "2023"})
+	if err != nil {
+		t.Fatalf("ParseDates() error = %v", err)
+	}
+	want := []time.Time{
+		time.Date(2024, 1, 2, 0, 0, 0, 0, time.UTC),
+		{},
+		time.Date(2024, 4, 1, 0, 0, 0, 0, time.UTC),
+		time.Date(2023, 1, 1, 0, 0, 0, 0, time.UTC),
+	}
+	for i := range want {
+		if !got[i].Equal(want[i]) {
+			t.Errorf("ParseDates()[%d] = %v, want %v", i, got[i], want[i])
+		}
+	}
+
+	if _, err := sources.ParseDates([]string{"2024-01-02", "not a date"}); err == nil {
+		t.Error("ParseDates() with invalid date returned nil error")
+	}
+}
+
+func TestParseFloats(t *testing.T) {
+	tests := []struct {
+		name    string
+		values  []string
+		want    []float64
+		wantErr bool
+	}{
+		{"numbers", []string{"1.5", "1,234", "-2"}, []float64{1.5, 1234, -2}, false},
+		{"missing", []string{"", ".", "null", "NaN"}, []float64{math.NaN(), math.NaN(), math.NaN(), math.NaN()}, false},
+		{"text", []string{"1", "abc"}, nil, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := sources.ParseFloats(tt.values)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("ParseFloats() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			for i, w := range tt.want {
+				if got[i] != w && !(math.IsNaN(got[i]) && math.IsNaN(w)) {
+					t.Errorf("ParseFloats()[%d] = %v, want %v", i, got[i], w)
+				}
+			}
+		})
+	}
+}
+
+func TestValueColumns(t *testing.T) {
+	got := sources.ValueColumns([]string{"date", "stock_id", "open", "close"}, "date", "stock_id")
+	if want := []string{"open", "close"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("ValueColumns() = %v, want %v", got, want)
+	}
+}
+
+func TestMaskedColumn(t *testing.T) {
+	// FRED marks missing values with "."; World Bank nulls parse as ""
+	series := []sources.TimeSeries{
+		&fred.ParsedData{Dates: []string{"2024-01-01", "2024-01-02"}, Values: []string{".", "4.1"}},
+		&worldbank.ParsedData{Dates: []string{"2022", "2023"}, Values: []string{"", "4.1"}},
+	}
+	for _, ts := range series {
+		values, valid, err := sources.MaskedColumn(ts, "Value")
+		if err != nil {
+			t.Fatalf("MaskedColumn(%T) error = %v", ts, err)
+		}
+		if !math.IsNaN(values[0]) || values[1] != 4.1 || !reflect.DeepEqual(valid, []bool{false, true}) {
+			t.Errorf("MaskedColumn(%T) = %v, %v, want [NaN 4.1] [false true]", ts, values, valid)
+		}
+	}
+
+	if _, _, err := sources.MaskedColumn(series[0], "Close"); !errors.Is(err, sources.ErrNoColumn) {
+		t.Errorf("MaskedColumn(Close) error = %v, want ErrNoColumn", err)
+	}
+}
+
+func TestNoColumn(t *testing.T) {
+	if err := sources.NoColumn("Foo"); !errors.Is(err, sources.ErrNoColumn) {
+		t.Errorf("NoColumn() = %v, want ErrNoColumn", err)
+	}
+}
+
+func TestTimeSeries_AllSources(t *testing.T) {
+	day1 := time.Date(2024, 1, 2, 0, 0, 0, 0, time.UTC)
+	day2 := time.Date(2024, 1, 3, 0, 0, 0, 0, time.UTC)
+	rows := []map[string]string{
+		{"Date": "2024-01-02", "Open": "1.5", "Close": "2"},
+		{"Date": "2024-01-03", "Open": "null", "Close": "3"},
+	}
+
+	tests := []struct {
+		name        string
+		data        sources.TimeSeries
+		wantColumns []string
+		column      string
+		want        []float64
+	}{
+		{"yahoo", &yahoo.ParsedData{Columns: []string{"Date", "Open", "Close"}, Rows: rows},
+			[]string{"Open", "Close"}, "Open", []float64{1.5, math.NaN()}},
+		{"stooq", &stooq.ParsedData{Columns: []string{"Date", "Open", "Close"}, Rows: rows},
+			[]string{"Open", "Close"}, "Close", []float64{2, 3}},
+		{"alphavantage", &alphavantage.ParsedData{Columns: []string{"Date", "Open", "Close"}, Rows: rows},
+			[]string{"Open", "Close"}, "Close", []float64{2, 3}},
+		{"iex", &iex.ParsedData{Columns: []string{"Date", "Open", "Close"}, Rows: rows},
+			[]string{"Open", "Close"}, "Open", []float64{1.5, math.NaN()}},
+		{"finmind", &finmind.ParsedData{
+			Columns: []string{"date", "stock_id", "close"},
+			Rows: []map[string]string{
+				{"date": "2024-01-02", "stock_id": "2330", "close": "590"},
+				{"date": "2024-01-03", "stock_id": "2330", "close": "593"},
+			}}, []string{"close"}, "close", []float64{590, 593}},
+		{"fred", &fred.ParsedData{Dates: []string{"2024-01-02", "2024-01-03"}, Values: []string{"4.5", "."}},
+			[]string{"Value"}, "Value", []float64{4.5, math.NaN()}},
+		{"worldbank", &worldbank.ParsedData{Dates: []string{"2024-01-02", "2024-01-03"}, Values: []string{"100", ""}},
+			[]string{"Value"}, "Value", []float64{100, math.NaN()}},
+		{"oecd", &oecd.ParsedData{Dates: []string{"2024-01-02", "2024-01-03"}, Values: []float64{1, 2}},
+			[]string{"Value"}, "Value", []float64{1, 2}},
+		{"eurostat", &eurostat.ParsedData{Dates: []string{"2024-01-02", "2024-01-03"}, Values: []float64{1, 2}},
+			[]string{"Value"}, "Value", []float64{1, 2}},
+		{"tiingo", &tiingo.ParsedData{
+			Dates:  []string{"2024-01-02", "2024-01-03"},
+			Prices: []tiingo.PriceData{{Close: 2, Volume: 10}, {Close: 3, Volume: math.NaN()}},
+		}, []string{"Open", "High", "Low", "Close", "Volume", "Adj Close"}, "Volume", []float64{10, math.NaN()}},
+		{"twse", &twse.ParsedData{
+			Date:   []time.Time{day1, day2},
+			Close:  []float64{590, 593},
+			Volume: []int64{1000, 2000},
+		}, []string{"Open", "High", "Low", "Close", "Volume", "Transactions", "Change"}, "Volume", []float64{1000, 2000}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			dates, err := tt.data.DateIndex()
+			if err != nil {
+				t.Fatalf("DateIndex() error = %v", err)
+			}
+			if len(dates) != 2 || !dates[0].Equal(day1) || !dates[1].Equal(day2) {
+				t.Errorf("DateIndex() = %v", dates)
+			}
+
+			if got := tt.data.ColumnNames(); !reflect.DeepEqual(got, tt.wantColumns) {
+				t.Errorf("ColumnNames() = %v, want %v", got, tt.wantColumns)
+			}
+
+			got, err := tt.data.Float64Column(tt.column)
+			if err != nil {
+				t.Fatalf("Float64Column(%q) error = %v", tt.column, err)
+			}
+			for i, w := range tt.want {
+				if got[i] != w && !(math.IsNaN(got[i]) && math.IsNaN(w)) {
+					t.Errorf("Float64Column(%q)[%d] = %v, want %v", tt.column, i, got[i], w)
+				}
+			}
+
+			if _, err := tt.data.Float64Column("NoSuchColumn"); !errors.Is(err, sources.ErrNoColumn) {
+				t.Errorf("Float64Column(NoSuchColumn) error = %v, want ErrNoColumn", err)
+			}
+		})
+	}
+}
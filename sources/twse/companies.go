@@ -0,0 +1,140 @@
+package twse
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/julianshen/gonp-datareader/sources"
+)
+
+// companiesEndpoint lists the basic information of every listed company,
+// including its English short name
+const companiesEndpoint = "/opendata/t187ap03_L"
+
+// Company is one entry of the listed-company directory. The Open API names
+// its fields in Traditional Chinese.
+type Company struct {
+	Code        string `json:"公司代號"` // Stock code (e.g., "2330")
+	FullName    string `json:"公司名稱"` // Registered name in Traditional Chinese
+	Name        string `json:"公司簡稱"` // Short name in Traditional Chinese, as in STOCK_DAY_ALL
+	EnglishName string `json:"英文簡稱"` // English short name (e.g., "TSMC")
+	Industry    string `json:"產業別"`  // Industry code (e.g., "24" for semiconductors)
+}
+
+// SymbolInfo returns the company as a sources.SymbolInfo.
+func (c Company) SymbolInfo() sources.SymbolInfo {
+	return sources.SymbolInfo{
+		Symbol:      c.Code,
+		Name:        c.Name,
+		EnglishName: c.EnglishName,
+		Market:      "twse",
+		Industry:    c.Industry,
+	}
+}
+
+// buildCompaniesURL constructs the URL for the listed-company directory.
+//
+// Example: https://openapi.twse.com.tw/v1/opendata/t187ap03_L
+func buildCompaniesURL(baseURL string) string {
+	return strings.TrimSuffix(baseURL, "/") + companiesEndpoint
+}
+
+// ReadCompanies fetches the directory of listed companies with their
+// Chinese and English names.
+func (t *TWSEReader) ReadCompanies(ctx context.Context) ([]Company, error) {
+	body, _, err := t.fetch(ctx, buildCompaniesURL(t.baseURL))
+	if err != nil {
+		return nil, err
+	}
+
+	decoded, err := t.client.Decode("twse/companies", body, func(b []byte) (interface{}, error) {
+		return ParseCompanies(b)
+	})
+	if err != nil {
+		return nil, fmt.Errorf("parse JSON: %w", err)
+	}
+	return decoded.([]Company), nil
+}
+
+// ParseCompanies parses a listed-company directory response, trimming the
+// padding the API leaves in names.
+func ParseCompanies(body []byte) ([]Company, error) {
+	var companies []Company
+	if err := json.Unmarshal(body, &companies); err != nil {
+		return nil, err
+	}
+	for i := range companies {
+		c := &companies[i]
+		c.Code = strings.TrimSpace(c.Code)
+		c.FullName = strings.TrimSpace(c.FullName)
+		c.Name = strings.TrimSpace(c.Name)
+		c.EnglishName = strings.TrimSpace(c.EnglishName)
+		c.Industry = strings.TrimSpace(c.Industry)
+	}
+	return companies, nil
+}
+
+// ReadSymbolInfo fetches every listed company as a sources.SymbolInfo,
+// including its English name, so securities can be searched and
+// displayed without Chinese text handling.
+//
+// # Example Usage
+//
+//	infos, err := reader.ReadSymbolInfo(ctx)
+//	for _, info := range infos {
+//		if info.Matches("tsmc") {
+//			fmt.Println(info.Symbol, info.DisplayName()) // 2330 TSMC
+//		}
+//	}
+func (t *TWSEReader) ReadSymbolInfo(ctx context.Context) ([]sources.SymbolInfo, error) {
+	companies, err := t.ReadCompanies(ctx)
+	if err != nil {
+		return nil, err
+	}
+	infos := make([]sources.SymbolInfo, len(companies))
+	for i, c := range companies {
+		infos[i] = c.SymbolInfo()
+	}
+	return infos, nil
+}
+
+// ReadEnglishNames fetches the English short name of every listed company,
+// keyed by stock code, for labelling data from other Taiwan sources (see
+// sources.NameMap.Apply).
+func (t *TWSEReader) ReadEnglishNames(ctx context.Context) (sources.NameMap, error) {
+	companies, err := t.ReadCompanies(ctx)
+	if err != nil {
+		return nil, err
+	}
+	names := make(sources.NameMap, len(companies))
+	for _, c := range companies {
+		if c.EnglishName != "" {
+			names[c.Code] = c.EnglishName
+		}
+	}
+	return names, nil
+}
+
+// SetEnglishNames makes ReadSingle, Read and ReadMarketSnapshot fill in
+// ParsedData.EnglishName from the listed-company directory. Costs one
+// extra request per call.
+func (t *TWSEReader) SetEnglishNames(enabled bool) {
+	t.englishNames = enabled
+}
+
+// addEnglishNames sets the English name of each of data when enabled.
+func (t *TWSEReader) addEnglishNames(ctx context.Context, data ...*ParsedData) error {
+	if !t.englishNames {
+		return nil
+	}
+	names, err := t.ReadEnglishNames(ctx)
+	if err != nil {
+		return fmt.Errorf("read English names: %w", err)
+	}
+	for _, d := range data {
+		d.EnglishName = names[d.Symbol]
+	}
+	return nil
+}
@@ -0,0 +1,87 @@
+package twse
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/julianshen/gonp-datareader/sources"
+)
+
+// companiesJSON is a listed-company directory response; the API pads
+// some names with spaces.
+const companiesJSON = `[
+	{"公司代號":"2330","公司名稱":"台灣積體電路製造股份有限公司","公司簡稱":"台積電","英文簡稱":"TSMC  ","產業別":"24"},
+	{"公司代號":"2317","公司名稱":"鴻海精密工業股份有限公司","公司簡稱":"鴻海","英文簡稱":"HON HAI","產業別":"31"}
+]`
+
+// newDirectoryServer serves the listed-company directory and a
+// STOCK_DAY_ALL snapshot of 2330.
+func newDirectoryServer(t *testing.T) *httptest.Server {
+	t.Helper()
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		switch r.URL.Path {
+		case companiesEndpoint:
+			w.Write([]byte(companiesJSON))
+		case dailyStocksEndpoint:
+			w.Write([]byte(`[{"Date":"1141028","Code":"2330","Name":"台積電","TradeVolume":"1","OpeningPrice":"950","HighestPrice":"960","LowestPrice":"945","ClosingPrice":"955","Change":"5","Transaction":"1"}]`))
+		default:
+			t.Errorf("unexpected path %q", r.URL.Path)
+		}
+	}))
+	t.Cleanup(server.Close)
+	return server
+}
+
+func TestTWSEReader_ReadSymbolInfo(t *testing.T) {
+	reader := NewTWSEReaderWithBaseURL(nil, newDirectoryServer(t).URL+"/")
+
+	infos, err := reader.ReadSymbolInfo(context.Background())
+	if err != nil {
+		t.Fatalf("ReadSymbolInfo() error = %v", err)
+	}
+	want := []sources.SymbolInfo{
+		{Symbol: "2330", Name: "台積電", EnglishName: "TSMC", Market: "twse", Industry: "24"},
+		{Symbol: "2317", Name: "鴻海", EnglishName: "HON HAI", Market: "twse", Industry: "31"},
+	}
+	if !reflect.DeepEqual(infos, want) {
+		t.Errorf("ReadSymbolInfo() = %+v, want %+v", infos, want)
+	}
+	if !infos[1].Matches("hon hai") || infos[1].DisplayName() != "HON HAI" {
+		t.Errorf("infos[1] does not match %q by English name", "hon hai")
+	}
+}
+
+func TestTWSEReader_SetEnglishNames(t *testing.T) {
+	server := newDirectoryServer(t)
+	day := time.Date(2025, 10, 28, 0, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name    string
+		enabled bool
+		want    string
+	}{
+		{"enabled", true, "TSMC"},
+		{"disabled", false, ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			reader := NewTWSEReaderWithBaseURL(nil, server.URL)
+			reader.SetEnglishNames(tt.enabled)
+
+			result, err := reader.ReadSingle(context.Background(), "2330", day, day)
+			if err != nil {
+				t.Fatalf("ReadSingle() error = %v", err)
+			}
+			data := result.(*ParsedData)
+			if data.EnglishName != tt.want || data.Name != "台積電" {
+				t.Errorf("names = %q, %q; want 台積電, %q", data.Name, data.EnglishName, tt.want)
+			}
+		})
+	}
+}
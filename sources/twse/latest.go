@@ -0,0 +1,43 @@
+package twse
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/julianshen/gonp-datareader/sources"
+)
+
+// ReadLatest returns the latest trading day's data of symbol from the
+// STOCK_DAY_ALL snapshot, which is the only day the Open API serves. The
+// snapshot is shared by every symbol, so with the response cache enabled
+// reading many symbols costs one request.
+func (t *TWSEReader) ReadLatest(ctx context.Context, symbol string) (interface{}, error) {
+	// Normalize user input; the original is kept in Meta["symbol_input"]
+	input := symbol
+	symbol = t.NormalizeSymbol(symbol)
+	if err := t.ValidateSymbol(symbol); err != nil {
+		return nil, fmt.Errorf("invalid symbol: %w", err)
+	}
+
+	allStocks, meta, err := t.fetchAll(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	stockData, err := filterBySymbol(allStocks, symbol)
+	if err != nil {
+		return nil, fmt.Errorf("filter symbol: %w", err)
+	}
+
+	data, err := parseStockData(stockData)
+	if err != nil {
+		return nil, fmt.Errorf("parse stock data: %w", err)
+	}
+	if err := t.addEnglishNames(ctx, data); err != nil {
+		return nil, err
+	}
+
+	// Flag data served from an expired cache entry
+	data.Meta = sources.InputMeta(meta, input, symbol)
+	return data, nil
+}
@@ -0,0 +1,148 @@
+package twse
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"html"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/julianshen/gonp-datareader/sources"
+)
+
+// isinURL is the ISIN listing page of every security listed on TWSE
+// (strMode=2), served as Big5 HTML
+const isinURL = "https://isin.twse.com.tw/isin/C_public.jsp?strMode=2"
+
+var (
+	// isinRowPattern and isinCellPattern split the listing table
+	isinRowPattern  = regexp.MustCompile(`(?is)<tr[^>]*>(.*?)</tr>`)
+	isinCellPattern = regexp.MustCompile(`(?is)<td[^>]*>(.*?)</td>`)
+
+	// tagPattern matches the markup left inside cells
+	tagPattern = regexp.MustCompile(`<[^>]*>`)
+
+	// isinPattern matches an ISIN: country code, nine characters and a
+	// check digit
+	isinPattern = regexp.MustCompile(`^[A-Z]{2}[A-Z0-9]{9}[0-9]$`)
+)
+
+// errEmptyListing is returned for a listing page without securities, such
+// as a maintenance page.
+var errEmptyListing = errors.New("no securities in ISIN listing")
+
+// SetISINURL overrides the ISIN listing page used by ListSymbols. This is
+// primarily used for testing with mock servers.
+func (t *TWSEReader) SetISINURL(url string) {
+	t.isinURL = url
+}
+
+// ListSymbols fetches every security listed on TWSE, including ETFs,
+// warrants and bonds, from the ISIN listing page. Each entry carries the
+// code, Chinese name, ISIN, listing date, industry and the section it is
+// listed under as Type (e.g., "股票", "ETF"). English names are added when
+// SetEnglishNames is enabled, at the cost of one extra request.
+//
+// # Example Usage
+//
+//	infos, err := reader.ListSymbols(ctx)
+//	for _, info := range infos {
+//		if info.Type == "股票" {
+//			fmt.Println(info.Symbol, info.ISIN, info.ListingDate.Format("2006-01-02"))
+//		}
+//	}
+func (t *TWSEReader) ListSymbols(ctx context.Context) ([]sources.SymbolInfo, error) {
+	body, _, err := t.get(ctx, t.isinURL)
+	if err != nil {
+		return nil, err
+	}
+
+	decoded, err := t.client.Decode("twse/isin", body, func(b []byte) (interface{}, error) {
+		return ParseISINListing(b)
+	})
+	if err != nil {
+		return nil, fmt.Errorf("parse ISIN listing: %w", err)
+	}
+	infos := append([]sources.SymbolInfo(nil), decoded.([]sources.SymbolInfo)...)
+
+	if t.englishNames {
+		names, err := t.ReadEnglishNames(ctx)
+		if err != nil {
+			return nil, fmt.Errorf("read English names: %w", err)
+		}
+		names.Apply(infos)
+	}
+	return infos, nil
+}
+
+// ParseISINListing parses an ISIN listing page already converted to
+// UTF-8. Single-cell rows head the sections that give each security's
+// Type; rows without a valid ISIN, such as the table header, are skipped.
+func ParseISINListing(body []byte) ([]sources.SymbolInfo, error) {
+	var (
+		infos   []sources.SymbolInfo
+		section string
+	)
+
+	for _, row := range isinRowPattern.FindAllSubmatch(body, -1) {
+		var cells []string
+		for _, cell := range isinCellPattern.FindAllSubmatch(row[1], -1) {
+			text := html.UnescapeString(tagPattern.ReplaceAllString(string(cell[1]), ""))
+			cells = append(cells, strings.TrimSpace(text))
+		}
+
+		if len(cells) == 1 {
+			section = cells[0]
+			continue
+		}
+		if len(cells) < 5 || !isinPattern.MatchString(cells[1]) {
+			continue
+		}
+
+		// The first cell holds the code and name separated by an
+		// ideographic space
+		fields := strings.Fields(cells[0])
+		if len(fields) == 0 {
+			continue
+		}
+
+		info := sources.SymbolInfo{
+			Symbol:   fields[0],
+			Name:     strings.Join(fields[1:], " "),
+			Market:   listingMarket(cells[3]),
+			Industry: cells[4],
+			Type:     section,
+			ISIN:     cells[1],
+		}
+		if cells[2] != "" {
+			date, err := time.Parse("2006/01/02", cells[2])
+			if err != nil {
+				return nil, fmt.Errorf("parse listing date of %s: %w", info.Symbol, err)
+			}
+			info.ListingDate = date
+		}
+		infos = append(infos, info)
+	}
+
+	if len(infos) == 0 {
+		return nil, errEmptyListing
+	}
+	return infos, nil
+}
+
+// listingMarket returns the market code for the market column of the
+// ISIN listing.
+func listingMarket(market string) string {
+	switch market {
+	case "上市":
+		return "twse"
+	case "上櫃":
+		return "tpex"
+	case "興櫃":
+		return "emerging"
+	default:
+		return market
+	}
+}
@@ -0,0 +1,71 @@
+package twse
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"testing"
+	"time"
+
+	"golang.org/x/text/encoding/traditionalchinese"
+
+	"github.com/julianshen/gonp-datareader/sources"
+)
+
+// isinHTML is an excerpt of the ISIN listing page; code and name are
+// separated by an ideographic space.
+const isinHTML = `<html><body>
+<table class='h4' align=center cellSpacing=3 cellPadding=2 width=750 border=0>
+<tr align=center><td bgcolor=#D5FFD5>有價證券代號及名稱 </td><td bgcolor=#D5FFD5>國際證券辨識號碼(ISIN Code)</td><td bgcolor=#D5FFD5>上市日</td><td bgcolor=#D5FFD5>市場別</td><td bgcolor=#D5FFD5>產業別</td><td bgcolor=#D5FFD5>CFICode</td><td bgcolor=#D5FFD5>備註</td></tr>
+<tr><td bgcolor=#FAFAD2 colspan=7 ><B> 股票 <B> </td></tr>
+<tr><td bgcolor=#FAFAD2>1101　台泥</td><td bgcolor=#FAFAD2>TW0001101004</td><td bgcolor=#FAFAD2>1962/02/09</td><td bgcolor=#FAFAD2>上市</td><td bgcolor=#FAFAD2>水泥工業</td><td bgcolor=#FAFAD2>ESVUFR</td><td bgcolor=#FAFAD2></td></tr>
+<tr><td bgcolor=#FAFAD2>2330　台積電</td><td bgcolor=#FAFAD2>TW0002330008</td><td bgcolor=#FAFAD2>1994/09/05</td><td bgcolor=#FAFAD2>上市</td><td bgcolor=#FAFAD2>半導體業</td><td bgcolor=#FAFAD2>ESVUFR</td><td bgcolor=#FAFAD2></td></tr>
+<tr><td bgcolor=#FAFAD2 colspan=7 ><B> ETF <B> </td></tr>
+<tr><td bgcolor=#FAFAD2>0050　元大台灣50</td><td bgcolor=#FAFAD2>TW0000050004</td><td bgcolor=#FAFAD2>2003/06/30</td><td bgcolor=#FAFAD2>上市</td><td bgcolor=#FAFAD2></td><td bgcolor=#FAFAD2>CEOGEU</td><td bgcolor=#FAFAD2></td></tr>
+</table></body></html>`
+
+func TestTWSEReader_ListSymbols(t *testing.T) {
+	big5, err := traditionalchinese.Big5.NewEncoder().String(isinHTML)
+	if err != nil {
+		t.Fatal(err)
+	}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/isin":
+			w.Header().Set("Content-Type", "text/html;charset=MS950")
+			w.Write([]byte(big5))
+		case companiesEndpoint:
+			w.Header().Set("Content-Type", "application/json")
+			w.Write([]byte(companiesJSON))
+		default:
+			t.Errorf("unexpected path %q", r.URL.Path)
+		}
+	}))
+	defer server.Close()
+
+	reader := NewTWSEReaderWithBaseURL(nil, server.URL)
+	reader.SetISINURL(server.URL + "/isin")
+	reader.SetEnglishNames(true)
+
+	infos, err := reader.ListSymbols(context.Background())
+	if err != nil {
+		t.Fatalf("ListSymbols() error = %v", err)
+	}
+	want := []sources.SymbolInfo{
+		{Symbol: "1101", Name: "台泥", Market: "twse", Industry: "水泥工業", Type: "股票", ISIN: "TW0001101004", ListingDate: time.Date(1962, 2, 9, 0, 0, 0, 0, time.UTC)},
+		{Symbol: "2330", Name: "台積電", EnglishName: "TSMC", Market: "twse", Industry: "半導體業", Type: "股票", ISIN: "TW0002330008", ListingDate: time.Date(1994, 9, 5, 0, 0, 0, 0, time.UTC)},
+		{Symbol: "0050", Name: "元大台灣50", Market: "twse", Type: "ETF", ISIN: "TW0000050004", ListingDate: time.Date(2003, 6, 30, 0, 0, 0, 0, time.UTC)},
+	}
+	if !reflect.DeepEqual(infos, want) {
+		t.Errorf("ListSymbols() = %+v, want %+v", infos, want)
+	}
+}
+
+func TestParseISINListing_Empty(t *testing.T) {
+	_, err := ParseISINListing([]byte(`<html><body>系統維護中</body></html>`))
+	if !errors.Is(err, errEmptyListing) {
+		t.Errorf("ParseISINListing() error = %v, want %v", err, errEmptyListing)
+	}
+}
@@ -2,9 +2,17 @@ package twse
 
 import (
 	"encoding/json"
+	"errors"
 	"fmt"
+	"math"
 	"strconv"
+	"strings"
 	"time"
+
+	"github.com/julianshen/gonp-datareader/internal/numparse"
+	"github.com/julianshen/gonp-datareader/internal/utils"
+	"github.com/julianshen/gonp-datareader/ohlcv"
+	"github.com/julianshen/gonp-datareader/sources"
 )
 
 const (
@@ -122,6 +130,7 @@ type TWSEStockData struct {
 	ClosingPrice string `json:"ClosingPrice"` // Closing price
 	Change       string `json:"Change"`       // Price change
 	Transaction  string `json:"Transaction"`  // Number of transactions
+	Remark       string `json:"Remark"`       // Trading remark (e.g., ex-dividend), if provided
 }
 
 // ParsedData represents parsed stock data ready for use.
@@ -129,16 +138,93 @@ type TWSEStockData struct {
 // This structure contains typed data with time.Time dates and numeric values
 // converted from the API's string format.
 type ParsedData struct {
-	Symbol       string      // Stock symbol
-	Name         string      // Company name
-	Date         []time.Time // Trading dates
-	Open         []float64   // Opening prices
-	High         []float64   // Highest prices
-	Low          []float64   // Lowest prices
-	Close        []float64   // Closing prices
-	Volume       []int64     // Trading volumes
-	Transactions []int64     // Transaction counts
-	Change       []float64   // Price changes
+	Symbol       string            `schema:"-"` // Stock symbol
+	Name         string            `schema:"-"` // Company name in Traditional Chinese
+	EnglishName  string            `schema:"-"` // English short name; set with SetEnglishNames
+	Date         []time.Time       // Trading dates
+	Open         []float64         // Opening prices
+	High         []float64         // Highest prices
+	Low          []float64         // Lowest prices
+	Close        []float64         // Closing prices
+	Volume       []int64           // Trading volumes
+	Transactions []int64           // Transaction counts
+	Change       []float64         // Price changes
+	Flags        []string          // Upstream remarks per row; nil when no row has a remark
+	Meta         map[string]string `schema:"-"` // Response metadata (e.g., "stale"); nil when there is none
+}
+
+// DateIndex returns a copy of Date.
+func (p *ParsedData) DateIndex() ([]time.Time, error) {
+	if p == nil {
+		return nil, nil
+	}
+	return append([]time.Time(nil), p.Date...), nil
+}
+
+// SelectRows keeps the rows at indices, in that order (see
+// sources.RowSelector).
+func (p *ParsedData) SelectRows(indices []int) {
+	if p == nil {
+		return
+	}
+	p.Date = sources.SelectRows(p.Date, indices)
+	p.Open = sources.SelectRows(p.Open, indices)
+	p.High = sources.SelectRows(p.High, indices)
+	p.Low = sources.SelectRows(p.Low, indices)
+	p.Close = sources.SelectRows(p.Close, indices)
+	p.Volume = sources.SelectRows(p.Volume, indices)
+	p.Transactions = sources.SelectRows(p.Transactions, indices)
+	p.Change = sources.SelectRows(p.Change, indices)
+	p.Flags = sources.SelectRows(p.Flags, indices)
+}
+
+// ColumnNames returns the numeric columns: "Open", "High", "Low", "Close",
+// "Volume", "Transactions" and "Change".
+func (p *ParsedData) ColumnNames() []string {
+	return []string{"Open", "High", "Low", "Close", "Volume", "Transactions", "Change"}
+}
+
+// Float64Column returns a copy of the named numeric column, with counts
+// converted to float64.
+func (p *ParsedData) Float64Column(name string) ([]float64, error) {
+	if p == nil {
+		return nil, sources.NoColumn(name)
+	}
+
+	var floats []float64
+	var ints []int64
+	switch name {
+	case "Open":
+		floats = p.Open
+	case "High":
+		floats = p.High
+	case "Low":
+		floats = p.Low
+	case "Close":
+		floats = p.Close
+	case "Change":
+		floats = p.Change
+	case "Volume":
+		ints = p.Volume
+	case "Transactions":
+		ints = p.Transactions
+	default:
+		return nil, sources.NoColumn(name)
+	}
+
+	if ints == nil {
+		return append([]float64(nil), floats...), nil
+	}
+	values := make([]float64, len(ints))
+	for i, v := range ints {
+		values[i] = float64(v)
+	}
+	return values, nil
+}
+
+// Bars returns the price columns as ohlcv.Bars (see ohlcv.Provider).
+func (p *ParsedData) Bars() (*ohlcv.Bars, error) {
+	return ohlcv.FromTimeSeries(p)
 }
 
 // parseDailyStockJSON parses the TWSE daily stock data JSON response.
@@ -223,7 +309,7 @@ func parseStockData(stock TWSEStockData) (*ParsedData, error) {
 		return nil, fmt.Errorf("parse transactions %q: %w", stock.Transaction, err)
 	}
 
-	return &ParsedData{
+	data := &ParsedData{
 		Symbol:       stock.Code,
 		Name:         stock.Name,
 		Date:         []time.Time{date},
@@ -234,15 +320,25 @@ func parseStockData(stock TWSEStockData) (*ParsedData, error) {
 		Volume:       []int64{volume},
 		Transactions: []int64{transactions},
 		Change:       []float64{change},
-	}, nil
+	}
+	if remark := strings.TrimSpace(stock.Remark); remark != "" {
+		data.Flags = []string{remark}
+	}
+
+	return data, nil
 }
 
 // parseFloat converts a string to float64, handling empty strings.
+//
+// Display formatting such as thousands separators ("1,234.5"), full-width
+// digits and arrow sign markers is accepted. Missing-value placeholders
+// ("", "--"), as on days a stock did not trade, are NaN rather than a
+// price of zero.
 func parseFloat(s string) (float64, error) {
-	if s == "" {
-		return 0, nil
+	f, err := numparse.ParseFloat(s)
+	if errors.Is(err, numparse.ErrMissing) {
+		return math.NaN(), nil
 	}
-	f, err := strconv.ParseFloat(s, 64)
 	if err != nil {
 		return 0, fmt.Errorf("invalid float: %w", err)
 	}
@@ -250,17 +346,41 @@ func parseFloat(s string) (float64, error) {
 }
 
 // parseInt converts a string to int64, handling empty strings.
+//
+// Thousands separators, full-width digits and integral values in scientific
+// notation ("1.5e9") are accepted; values beyond int64 are rejected rather
+// than wrapped. Missing-value placeholders ("", "--") are treated as zero,
+// since a stock without trades has a volume of zero.
 func parseInt(s string) (int64, error) {
-	if s == "" {
+	i, err := numparse.ParseCount(s)
+	if errors.Is(err, numparse.ErrMissing) {
 		return 0, nil
 	}
-	i, err := strconv.ParseInt(s, 10, 64)
 	if err != nil {
 		return 0, fmt.Errorf("invalid int: %w", err)
 	}
 	return i, nil
 }
 
+// ParseResponse parses a STOCK_DAY_ALL response, which holds every
+// listed stock, into the ParsedData of symbol.
+func ParseResponse(body []byte, symbol string) (*ParsedData, error) {
+	stocks, err := parseDailyStockJSON(body)
+	if err != nil {
+		return nil, err
+	}
+	stock, err := filterBySymbol(stocks, symbol)
+	if err != nil {
+		return nil, err
+	}
+
+	data, err := parseStockData(stock)
+	if err != nil {
+		return nil, fmt.Errorf("parse stock data: %w", err)
+	}
+	return data, nil
+}
+
 // filterBySymbol finds a specific stock symbol in the array of stocks.
 //
 // Returns the matching TWSEStockData or an error if the symbol is not found.
@@ -277,12 +397,13 @@ func filterBySymbol(stocks []TWSEStockData, symbol string) (TWSEStockData, error
 		}
 	}
 
-	return TWSEStockData{}, fmt.Errorf("symbol %q not found in response", symbol)
+	return TWSEStockData{}, fmt.Errorf("%w in response: %q", sources.ErrSymbolNotFound, symbol)
 }
 
 // filterByDateRange filters ParsedData to include only dates within the specified range.
 //
-// The filtering is inclusive: both start and end dates are included if present.
+// The filtering is inclusive: both start and end dates are included if present,
+// comparing calendar days in each timestamp's location (see utils.DayRange).
 // Returns a new ParsedData with filtered data, preserving all slices in sync.
 func filterByDateRange(data *ParsedData, start, end time.Time) *ParsedData {
 	if data == nil || len(data.Date) == 0 {
@@ -307,14 +428,9 @@ func filterByDateRange(data *ParsedData, start, end time.Time) *ParsedData {
 	}
 
 	// Filter data within date range (inclusive)
+	days := utils.NewDayRange(start, end)
 	for i, date := range data.Date {
-		// Compare dates (ignore time component)
-		dateOnly := time.Date(date.Year(), date.Month(), date.Day(), 0, 0, 0, 0, time.UTC)
-		startOnly := time.Date(start.Year(), start.Month(), start.Day(), 0, 0, 0, 0, time.UTC)
-		endOnly := time.Date(end.Year(), end.Month(), end.Day(), 0, 0, 0, 0, time.UTC)
-
-		if (dateOnly.Equal(startOnly) || dateOnly.After(startOnly)) &&
-			(dateOnly.Equal(endOnly) || dateOnly.Before(endOnly)) {
+		if days.Contains(date) {
 			// Date is within range, include all data for this index
 			filtered.Date = append(filtered.Date, data.Date[i])
 			filtered.Open = append(filtered.Open, data.Open[i])
@@ -324,6 +440,9 @@ func filterByDateRange(data *ParsedData, start, end time.Time) *ParsedData {
 			filtered.Volume = append(filtered.Volume, data.Volume[i])
 			filtered.Transactions = append(filtered.Transactions, data.Transactions[i])
 			filtered.Change = append(filtered.Change, data.Change[i])
+			if data.Flags != nil {
+				filtered.Flags = append(filtered.Flags, data.Flags[i])
+			}
 		}
 	}
 
@@ -1,6 +1,7 @@
 package twse
 
 import (
+	"math"
 	"testing"
 	"time"
 )
@@ -628,8 +629,8 @@ func TestParseStockData(t *testing.T) {
 			},
 			wantErr: false,
 			check: func(t *testing.T, p *ParsedData) {
-				if p.Open[0] != 0 {
-					t.Errorf("Empty OpeningPrice should be 0, got %v", p.Open[0])
+				if !math.IsNaN(p.Open[0]) || !math.IsNaN(p.Close[0]) {
+					t.Errorf("Empty prices should be NaN, got Open %v, Close %v", p.Open[0], p.Close[0])
 				}
 				if p.Volume[0] != 0 {
 					t.Errorf("Empty TradeVolume should be 0, got %v", p.Volume[0])
@@ -708,9 +709,13 @@ func TestParseFloat(t *testing.T) {
 		{"valid integer", "100", 100.0, false},
 		{"valid decimal", "64.75", 64.75, false},
 		{"valid negative", "-10.5", -10.5, false},
-		{"empty string", "", 0, false},
+		{"empty string", "", math.NaN(), false},
 		{"invalid string", "abc", 0, true},
 		{"invalid format", "12.34.56", 0, true},
+		{"thousands separators", "1,234.50", 1234.5, false},
+		{"dash placeholder", "--", math.NaN(), false},
+		{"full-width digits", "６４．７５", 64.75, false},
+		{"down arrow", "▼0.35", -0.35, false},
 	}
 
 	for _, tt := range tests {
@@ -720,7 +725,7 @@ func TestParseFloat(t *testing.T) {
 				t.Errorf("parseFloat(%q) error = %v, wantErr %v", tt.input, err, tt.wantErr)
 				return
 			}
-			if !tt.wantErr && got != tt.want {
+			if !tt.wantErr && got != tt.want && !(math.IsNaN(got) && math.IsNaN(tt.want)) {
 				t.Errorf("parseFloat(%q) = %v, want %v", tt.input, got, tt.want)
 			}
 		})
@@ -740,6 +745,12 @@ func TestParseInt(t *testing.T) {
 		{"empty string", "", 0, false},
 		{"invalid string", "abc", 0, true},
 		{"decimal number", "12.34", 0, true},
+		{"thousands separators", "55,956,524", 55956524, false},
+		{"dash placeholder", "--", 0, false},
+		{"scientific notation", "1.5e9", 1500000000, false},
+		{"max int64", "9,223,372,036,854,775,807", 9223372036854775807, false},
+		{"overflow", "9,223,372,036,854,775,808", 0, true},
+		{"overflow scientific", "2e19", 0, true},
 	}
 
 	for _, tt := range tests {
@@ -998,3 +1009,83 @@ func TestFilterByDateRange_PreservesData(t *testing.T) {
 		t.Errorf("Volume = %v, want 1200000", result.Volume[0])
 	}
 }
+
+// TestParseStockData_Remark tests that upstream remarks are preserved as flags
+func TestParseStockData_Remark(t *testing.T) {
+	stock := TWSEStockData{
+		Date:         "1141031",
+		Code:         "2330",
+		Name:         "台積電",
+		TradeVolume:  "1000",
+		OpeningPrice: "100",
+		HighestPrice: "101",
+		LowestPrice:  "99",
+		ClosingPrice: "100.5",
+		Change:       "0.5",
+		Transaction:  "10",
+		Remark:       " 除息 ",
+	}
+
+	data, err := parseStockData(stock)
+	if err != nil {
+		t.Fatalf("parseStockData() error = %v", err)
+	}
+	if len(data.Flags) != 1 || data.Flags[0] != "除息" {
+		t.Errorf("Flags = %q, want [除息]", data.Flags)
+	}
+
+	stock.Remark = ""
+	data, err = parseStockData(stock)
+	if err != nil {
+		t.Fatalf("parseStockData() error = %v", err)
+	}
+	if data.Flags != nil {
+		t.Errorf("expected nil Flags without remark, got %q", data.Flags)
+	}
+}
+
+// TestFilterByDateRange_PreservesFlags tests that flags stay aligned after filtering
+func TestFilterByDateRange_PreservesFlags(t *testing.T) {
+	data := &ParsedData{
+		Symbol: "2330",
+		Date: []time.Time{
+			time.Date(2025, 10, 29, 0, 0, 0, 0, time.UTC),
+			time.Date(2025, 10, 30, 0, 0, 0, 0, time.UTC),
+		},
+		Open:         []float64{1, 2},
+		High:         []float64{1, 2},
+		Low:          []float64{1, 2},
+		Close:        []float64{1, 2},
+		Volume:       []int64{1, 2},
+		Transactions: []int64{1, 2},
+		Change:       []float64{1, 2},
+		Flags:        []string{"", "除權"},
+	}
+
+	filtered := filterByDateRange(data,
+		time.Date(2025, 10, 30, 0, 0, 0, 0, time.UTC),
+		time.Date(2025, 10, 31, 0, 0, 0, 0, time.UTC))
+
+	if len(filtered.Flags) != 1 || filtered.Flags[0] != "除權" {
+		t.Errorf("Flags = %q, want [除權]", filtered.Flags)
+	}
+}
+
+func TestParseResponse(t *testing.T) {
+	body := `[
+		{"Date": "1130110", "Code": "2317", "Name": "鴻海", "TradeVolume": "20213452", "OpeningPrice": "103.50", "HighestPrice": "104.50", "LowestPrice": "103.00", "ClosingPrice": "104.00", "Change": "0.5000", "Transaction": "14802"},
+		{"Date": "1130110", "Code": "2330", "Name": "台積電", "TradeVolume": "22487305", "OpeningPrice": "580.00", "HighestPrice": "585.00", "LowestPrice": "579.00", "ClosingPrice": "583.00", "Change": "5.0000", "Transaction": "21637"}
+	]`
+
+	data, err := ParseResponse([]byte(body), "2330")
+	if err != nil {
+		t.Fatalf("ParseResponse() error = %v", err)
+	}
+	if data.Symbol != "2330" || len(data.Close) != 1 || data.Close[0] != 583 {
+		t.Errorf("ParseResponse() = %s %v", data.Symbol, data.Close)
+	}
+
+	if _, err := ParseResponse([]byte(body), "9999"); err == nil {
+		t.Error("ParseResponse(9999) error = nil, want symbol not found")
+	}
+}
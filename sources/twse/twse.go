@@ -50,6 +50,11 @@ const (
 	indexEndpoint = "/exchangeReport/MI_INDEX"
 )
 
+// DefaultCharset is the charset assumed for TWSE responses that declare
+// none and are not valid UTF-8, unless ClientOptions.FallbackCharset is
+// set.
+const DefaultCharset = "big5"
+
 var (
 	// twseSymbolPattern matches valid Taiwan stock codes (4 or 6 digits)
 	twseSymbolPattern = regexp.MustCompile(`^[0-9]{4}$|^[0-9]{6}$`)
@@ -60,6 +65,12 @@ type TWSEReader struct {
 	*sources.BaseSource
 	client  *internalhttp.RetryableClient
 	baseURL string
+
+	// englishNames fills in ParsedData.EnglishName; see SetEnglishNames
+	englishNames bool
+
+	// isinURL is the ISIN listing page of ListSymbols
+	isinURL string
 }
 
 // NewTWSEReader creates a new TWSE data reader.
@@ -76,11 +87,20 @@ func NewTWSEReaderWithBaseURL(opts *internalhttp.ClientOptions, baseURL string)
 	if opts == nil {
 		opts = internalhttp.DefaultClientOptions()
 	}
+	// Copy so the charset below does not leak into the caller's options
+	o := *opts
+	opts = &o
+
+	// Legacy TWSE endpoints serve Big5 without declaring it
+	if opts.FallbackCharset == "" {
+		opts.FallbackCharset = DefaultCharset
+	}
 
 	return &TWSEReader{
 		BaseSource: sources.NewBaseSource("twse"),
 		client:     internalhttp.NewRetryableClient(opts),
 		baseURL:    baseURL,
+		isinURL:    isinURL,
 	}
 }
 
@@ -89,6 +109,11 @@ func (t *TWSEReader) Name() string {
 	return "Taiwan Stock Exchange"
 }
 
+// Schema returns the columns of the ParsedData returned by ReadSingle.
+func (t *TWSEReader) Schema() sources.Schema {
+	return sources.NewSchema(t.Source(), t.Name(), ParsedData{})
+}
+
 // ValidateSymbol checks if a symbol is valid for TWSE.
 //
 // Taiwan stock symbols are typically 4-6 digit numeric codes:
@@ -99,6 +124,8 @@ func (t *TWSEReader) Name() string {
 // Returns an error if the symbol is empty, contains non-numeric characters,
 // or has an invalid length (not 4 or 6 digits).
 func (t *TWSEReader) ValidateSymbol(symbol string) error {
+	symbol = t.NormalizeSymbol(symbol)
+
 	// First check basic validation (empty, whitespace)
 	if err := t.BaseSource.ValidateSymbol(symbol); err != nil {
 		return err
@@ -160,6 +187,15 @@ func buildIndexURL(baseURL string) string {
 // The start and end parameters are validated but may not affect the returned
 // data range depending on API capabilities.
 func (t *TWSEReader) ReadSingle(ctx context.Context, symbol string, start, end time.Time) (interface{}, error) {
+	return t.Finish(ctx, symbol, start, end, t.readSingle)
+}
+
+// readSingle implements ReadSingle before Finish is applied.
+func (t *TWSEReader) readSingle(ctx context.Context, symbol string, start, end time.Time) (interface{}, error) {
+	// Normalize user input; the original is kept in Meta["symbol_input"]
+	input := symbol
+	symbol = t.NormalizeSymbol(symbol)
+
 	// Validate inputs
 	if err := t.ValidateSymbol(symbol); err != nil {
 		return nil, fmt.Errorf("invalid symbol: %w", err)
@@ -169,55 +205,145 @@ func (t *TWSEReader) ReadSingle(ctx context.Context, symbol string, start, end t
 		return nil, fmt.Errorf("invalid date range: %w", err)
 	}
 
-	// Build URL
-	urlStr := t.BuildURL()
+	allStocks, meta, err := t.fetchAll(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	// Filter for the requested symbol
+	stockData, err := filterBySymbol(allStocks, symbol)
+	if err != nil {
+		return nil, fmt.Errorf("filter symbol: %w", err)
+	}
+
+	// Parse the stock data into ParsedData structure
+	data, err := parseStockData(stockData)
+	if err != nil {
+		return nil, fmt.Errorf("parse stock data: %w", err)
+	}
+
+	// Filter by date range
+	filteredData := filterByDateRange(data, start, end)
+	if err := t.addEnglishNames(ctx, filteredData); err != nil {
+		return nil, err
+	}
+
+	// Flag data served from an expired cache entry
+	filteredData.Meta = sources.InputMeta(meta, input, symbol)
+
+	// The Open API serves the latest trading day only
+	if len(data.Date) > 0 && start.Format("2006-01-02") < data.Date[0].Format("2006-01-02") {
+		filteredData.Meta = sources.AddWarning(filteredData.Meta, sources.Warning{
+			Code:    sources.WarnStartClamped,
+			Message: fmt.Sprintf("TWSE Open API serves the latest trading day only; start %s moved to %s", start.Format("2006-01-02"), data.Date[0].Format("2006-01-02")),
+		})
+	}
+
+	return filteredData, nil
+}
+
+// fetchAll fetches the STOCK_DAY_ALL snapshot of every listed stock. The
+// returned metadata flags data served from an expired cache entry.
+func (t *TWSEReader) fetchAll(ctx context.Context) ([]TWSEStockData, map[string]string, error) {
+	body, meta, err := t.fetch(ctx, t.BuildURL())
+	if err != nil {
+		return nil, nil, err
+	}
+
+	// Parse JSON response; the market-wide snapshot is shared by every
+	// symbol, so identical bodies are decoded once when the decoded cache is on
+	decoded, err := t.client.Decode("twse", body, func(b []byte) (interface{}, error) {
+		return parseDailyStockJSON(b)
+	})
+	if err != nil {
+		return nil, nil, fmt.Errorf("parse JSON: %w", err)
+	}
+
+	return decoded.([]TWSEStockData), meta, nil
+}
 
+// fetch fetches the JSON body of an Open API endpoint. The returned
+// metadata flags data served from an expired cache entry.
+func (t *TWSEReader) fetch(ctx context.Context, urlStr string) ([]byte, map[string]string, error) {
+	return t.get(ctx, urlStr, "application/json")
+}
+
+// get fetches the body of urlStr, which must have one of the accept
+// media types. Without accept types the body is not checked, for HTML
+// pages.
+func (t *TWSEReader) get(ctx context.Context, urlStr string, accept ...string) ([]byte, map[string]string, error) {
 	// Create HTTP request
-	req, err := http.NewRequestWithContext(ctx, "GET", urlStr, nil)
+	req, err := http.NewRequestWithContext(internalhttp.WithContentType(ctx, accept...), "GET", urlStr, nil)
 	if err != nil {
-		return nil, fmt.Errorf("create request: %w", err)
+		return nil, nil, fmt.Errorf("create request: %w", err)
 	}
 
 	// Execute request
 	resp, err := t.client.Do(req)
 	if err != nil {
-		return nil, fmt.Errorf("fetch data: %w", err)
+		return nil, nil, fmt.Errorf("fetch data: %w", err)
 	}
 	defer resp.Body.Close()
 
 	// Check status code
 	if resp.StatusCode != 200 {
-		return nil, fmt.Errorf("HTTP %d: %s", resp.StatusCode, resp.Status)
+		return nil, nil, internalhttp.StatusError(resp, fmt.Errorf("HTTP %d: %s", resp.StatusCode, resp.Status))
 	}
 
 	// Read response body
 	body, err := io.ReadAll(resp.Body)
 	if err != nil {
-		return nil, fmt.Errorf("read response: %w", err)
+		return nil, nil, fmt.Errorf("read response: %w", err)
 	}
 
-	// Parse JSON response
-	allStocks, err := parseDailyStockJSON(body)
+	return body, internalhttp.StaleMeta(resp), nil
+}
+
+// ReadMarketSnapshot fetches the daily trading data of every listed stock
+// in a single request, returning a map[string]*ParsedData keyed by stock
+// code.
+//
+// The TWSE Open API only publishes the latest trading day. A zero date
+// returns that day; any other date that does not match it fails with
+// sources.ErrSnapshotUnavailable.
+func (t *TWSEReader) ReadMarketSnapshot(ctx context.Context, date time.Time) (interface{}, error) {
+	allStocks, meta, err := t.fetchAll(ctx)
 	if err != nil {
-		return nil, fmt.Errorf("parse JSON: %w", err)
+		return nil, err
 	}
 
-	// Filter for the requested symbol
-	stockData, err := filterBySymbol(allStocks, symbol)
-	if err != nil {
-		return nil, fmt.Errorf("filter symbol: %w", err)
+	snapshot := make(map[string]*ParsedData, len(allStocks))
+	for _, stock := range allStocks {
+		data, err := parseStockData(stock)
+		if err != nil {
+			return nil, fmt.Errorf("parse stock data %s: %w", stock.Code, err)
+		}
+		if !date.IsZero() && !sameDay(data.Date[0], date) {
+			return nil, fmt.Errorf("%w: TWSE publishes %s only, requested %s",
+				sources.ErrSnapshotUnavailable, data.Date[0].Format("2006-01-02"), date.Format("2006-01-02"))
+		}
+		data.Meta = meta
+		snapshot[stock.Code] = data
 	}
 
-	// Parse the stock data into ParsedData structure
-	data, err := parseStockData(stockData)
-	if err != nil {
-		return nil, fmt.Errorf("parse stock data: %w", err)
+	if t.englishNames {
+		all := make([]*ParsedData, 0, len(snapshot))
+		for _, data := range snapshot {
+			all = append(all, data)
+		}
+		if err := t.addEnglishNames(ctx, all...); err != nil {
+			return nil, err
+		}
 	}
 
-	// Filter by date range
-	filteredData := filterByDateRange(data, start, end)
+	return snapshot, nil
+}
 
-	return filteredData, nil
+// sameDay reports whether a and b fall on the same calendar date.
+func sameDay(a, b time.Time) bool {
+	ay, am, ad := a.Date()
+	by, bm, bd := b.Date()
+	return ay == by && am == bm && ad == bd
 }
 
 // Read fetches data for multiple symbols from TWSE.
@@ -225,7 +351,7 @@ func (t *TWSEReader) ReadSingle(ctx context.Context, symbol string, start, end t
 // Symbols are fetched in parallel for better performance.
 func (t *TWSEReader) Read(ctx context.Context, symbols []string, start, end time.Time) (interface{}, error) {
 	// Validate inputs
-	if err := utils.ValidateSymbols(symbols); err != nil {
+	if err := utils.ValidateSymbols(t.NormalizeSymbols(symbols)); err != nil {
 		return nil, fmt.Errorf("invalid symbols: %w", err)
 	}
 
@@ -293,3 +419,14 @@ func (t *TWSEReader) readParallel(ctx context.Context, symbols []string, start,
 
 	return dataMap, nil
 }
+
+// Shutdown stops accepting requests and waits for in-flight requests to
+// finish, cancelling them if ctx expires first.
+func (t *TWSEReader) Shutdown(ctx context.Context) error {
+	return t.client.Shutdown(ctx)
+}
+
+// Close cancels in-flight requests and releases the reader's connections.
+func (t *TWSEReader) Close() error {
+	return t.client.Close()
+}
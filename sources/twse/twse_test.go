@@ -3,12 +3,16 @@ package twse
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"net/http"
 	"net/http/httptest"
+	"reflect"
 	"strings"
 	"testing"
 	"time"
 
+	"golang.org/x/text/encoding/traditionalchinese"
+
 	internalhttp "github.com/julianshen/gonp-datareader/internal/http"
 	"github.com/julianshen/gonp-datareader/sources"
 )
@@ -367,6 +371,75 @@ func TestTWSEReader_ReadSingle(t *testing.T) {
 	}
 }
 
+// TestTWSEReader_ReadSingle_StartClamped tests that a start before the
+// latest trading day, the only one the Open API serves, is flagged in Meta
+func TestTWSEReader_ReadSingle_StartClamped(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`[{"Date":"1141028","Code":"2330","Name":"台積電","TradeVolume":"1","OpeningPrice":"950","HighestPrice":"960","LowestPrice":"945","ClosingPrice":"955","Change":"5","Transaction":"1"}]`))
+	}))
+	defer server.Close()
+
+	reader := NewTWSEReaderWithBaseURL(nil, server.URL)
+	end := time.Date(2025, 10, 28, 0, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name  string
+		start time.Time
+		want  []sources.Warning
+	}{
+		{name: "latest day", start: end},
+		{
+			name:  "earlier start",
+			start: time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC),
+			want: []sources.Warning{{
+				Code:    sources.WarnStartClamped,
+				Message: "TWSE Open API serves the latest trading day only; start 2025-01-01 moved to 2025-10-28",
+			}},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result, err := reader.ReadSingle(context.Background(), "2330", tt.start, end)
+			if err != nil {
+				t.Fatalf("ReadSingle() error = %v", err)
+			}
+			if got := sources.Warnings(result.(*ParsedData).Meta); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Warnings() = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
+
+// TestTWSEReader_ReadSingle_Big5 tests that undeclared Big5 responses are
+// converted before parsing, so names are not garbled
+func TestTWSEReader_ReadSingle_Big5(t *testing.T) {
+	body, _ := json.Marshal([]TWSEStockData{{
+		Date: "1141028", Code: "2330", Name: "台積電", TradeVolume: "25000000",
+		OpeningPrice: "950.00", HighestPrice: "960.00", LowestPrice: "945.00", ClosingPrice: "955.00",
+	}})
+	big5, err := traditionalchinese.Big5.NewEncoder().Bytes(body)
+	if err != nil {
+		t.Fatal(err)
+	}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.Write(big5)
+	}))
+	defer server.Close()
+
+	reader := NewTWSEReaderWithBaseURL(nil, server.URL)
+	day := time.Date(2025, 10, 28, 0, 0, 0, 0, time.UTC)
+	result, err := reader.ReadSingle(context.Background(), "2330", day, day)
+	if err != nil {
+		t.Fatalf("ReadSingle() error = %v", err)
+	}
+	if name := result.(*ParsedData).Name; name != "台積電" {
+		t.Errorf("Name = %q, want 台積電", name)
+	}
+}
+
 // TestTWSEReader_ReadSingle_ValidatesSymbol tests that ReadSingle validates symbols
 func TestTWSEReader_ReadSingle_ValidatesSymbol(t *testing.T) {
 	reader := NewTWSEReader(nil)
@@ -694,13 +767,13 @@ func TestTWSEReader_ReadSingle_HTTPErrors(t *testing.T) {
 			name:           "500 Internal Server Error",
 			statusCode:     500,
 			responseBody:   "Internal Server Error",
-			wantErrContain: "HTTP 500",
+			wantErrContain: "status 500",
 		},
 		{
 			name:           "503 Service Unavailable",
 			statusCode:     503,
 			responseBody:   "Service Unavailable",
-			wantErrContain: "HTTP 503",
+			wantErrContain: "status 503",
 		},
 	}
 
@@ -869,3 +942,83 @@ func TestTWSEReader_Read_PartialFailure(t *testing.T) {
 		t.Errorf("Read() error should mention failed symbol 2317, got: %v", err)
 	}
 }
+
+func TestTWSEReader_ReadMarketSnapshot(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode([]TWSEStockData{
+			{Date: "1141028", Code: "2330", Name: "台積電", TradeVolume: "25000000", OpeningPrice: "950.00",
+				HighestPrice: "960.00", LowestPrice: "945.00", ClosingPrice: "955.00", Change: "+5.00", Transaction: "12500"},
+			{Date: "1141028", Code: "2317", Name: "鴻海", TradeVolume: "30000000", OpeningPrice: "180.00",
+				HighestPrice: "182.00", LowestPrice: "179.00", ClosingPrice: "181.00", Change: "+1.00", Transaction: "20000"},
+		})
+	}))
+	defer server.Close()
+
+	reader := NewTWSEReaderWithBaseURL(nil, server.URL)
+	ctx := context.Background()
+
+	tests := []struct {
+		name    string
+		date    time.Time
+		wantErr error
+	}{
+		{"latest", time.Time{}, nil},
+		{"published date", time.Date(2025, 10, 28, 0, 0, 0, 0, time.UTC), nil},
+		{"other date", time.Date(2025, 10, 27, 0, 0, 0, 0, time.UTC), sources.ErrSnapshotUnavailable},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result, err := reader.ReadMarketSnapshot(ctx, tt.date)
+			if tt.wantErr != nil {
+				if !errors.Is(err, tt.wantErr) {
+					t.Fatalf("ReadMarketSnapshot() error = %v, want %v", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("ReadMarketSnapshot() error = %v", err)
+			}
+
+			snapshot := result.(map[string]*ParsedData)
+			if len(snapshot) != 2 {
+				t.Fatalf("len(snapshot) = %d, want 2", len(snapshot))
+			}
+			if got := snapshot["2317"].Close[0]; got != 181 {
+				t.Errorf("2317 close = %v, want 181", got)
+			}
+		})
+	}
+}
+
+func TestTWSEReader_ReadLatest(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode([]TWSEStockData{
+			{Date: "1141028", Code: "2330", Name: "台積電", TradeVolume: "25000000", OpeningPrice: "950.00",
+				HighestPrice: "960.00", LowestPrice: "945.00", ClosingPrice: "955.00", Change: "+5.00", Transaction: "12500"},
+			{Date: "1141028", Code: "2317", Name: "鴻海", TradeVolume: "30000000", OpeningPrice: "180.00",
+				HighestPrice: "182.00", LowestPrice: "179.00", ClosingPrice: "181.00", Change: "+1.00", Transaction: "20000"},
+		})
+	}))
+	defer server.Close()
+
+	reader := NewTWSEReaderWithBaseURL(nil, server.URL)
+
+	result, err := reader.ReadLatest(context.Background(), "2330")
+	if err != nil {
+		t.Fatalf("ReadLatest() error = %v", err)
+	}
+	data := result.(*ParsedData)
+	if len(data.Date) != 1 || data.Close[0] != 955 {
+		t.Errorf("ReadLatest() = %v close %v, want one row closing at 955", data.Date, data.Close)
+	}
+	if !sameDay(data.Date[0], time.Date(2025, 10, 28, 0, 0, 0, 0, time.UTC)) {
+		t.Errorf("date = %v, want 2025-10-28", data.Date[0])
+	}
+
+	if _, err := reader.ReadLatest(context.Background(), "9999"); err == nil {
+		t.Error("ReadLatest() should fail for a symbol not in the snapshot")
+	}
+}
@@ -0,0 +1,80 @@
+package sources
+
+import (
+	"sort"
+	"strings"
+)
+
+// Warning codes recorded by AddWarning.
+const (
+	// WarnEndInFuture flags an end date after the current time; data
+	// ends at the latest available session.
+	WarnEndInFuture = "end_in_future"
+
+	// WarnStartClamped flags a start date before the earliest data the
+	// source serves; data starts later than requested.
+	WarnStartClamped = "start_clamped"
+
+	// WarnParse flags rows the parser adjusted, such as null values read
+	// as NaN.
+	WarnParse = "parse"
+
+	// WarnProvisional flags bars whose period has not ended, such as
+	// today's daily bar during trading hours; their values change until
+	// the close.
+	WarnProvisional = "provisional"
+)
+
+// warningPrefix prefixes the Meta key of each warning code.
+const warningPrefix = "warning_"
+
+// Warning describes something the library adjusted on the caller's
+// behalf, such as a clamped date range, instead of failing.
+type Warning struct {
+	// Code identifies the kind of adjustment (e.g., WarnStartClamped)
+	Code string
+	// Message describes the adjustment for people
+	Message string
+}
+
+// String returns the warning as "code: message".
+func (w Warning) String() string {
+	return w.Code + ": " + w.Message
+}
+
+// AddWarning records w in meta["warning_<code>"] and appends its message
+// to meta["warnings"], joined by "; ", allocating meta if needed. A second
+// warning with the same code replaces the first in its key.
+func AddWarning(meta map[string]string, w Warning) map[string]string {
+	if meta == nil {
+		meta = make(map[string]string)
+	}
+	meta[warningPrefix+w.Code] = w.Message
+	if prev := meta["warnings"]; prev != "" {
+		meta["warnings"] = prev + "; " + w.Message
+	} else {
+		meta["warnings"] = w.Message
+	}
+	return meta
+}
+
+// Warnings returns the warnings recorded in meta by AddWarning, sorted by
+// code.
+//
+// # Example Usage
+//
+//	for _, w := range sources.Warnings(ds.Meta) {
+//		if w.Code == sources.WarnStartClamped {
+//			log.Printf("%s: %s", ds.Symbol, w.Message)
+//		}
+//	}
+func Warnings(meta map[string]string) []Warning {
+	var warnings []Warning
+	for key, message := range meta {
+		if code, ok := strings.CutPrefix(key, warningPrefix); ok {
+			warnings = append(warnings, Warning{Code: code, Message: message})
+		}
+	}
+	sort.Slice(warnings, func(i, j int) bool { return warnings[i].Code < warnings[j].Code })
+	return warnings
+}
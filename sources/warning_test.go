@@ -0,0 +1,37 @@
+package sources_test
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/julianshen/gonp-datareader/sources"
+)
+
+func TestAddWarning(t *testing.T) {
+	meta := sources.AddWarning(nil, sources.Warning{Code: sources.WarnStartClamped, Message: "start moved"})
+	meta = sources.WarningsMeta(meta, []string{"2020-03-16: null open", "2020-03-17: null close"})
+
+	if got, want := meta["warnings"], "start moved; 2020-03-16: null open; 2020-03-17: null close"; got != want {
+		t.Errorf(`meta["warnings"] = %q, want %q`, got, want)
+	}
+
+	want := []sources.Warning{
+		{Code: sources.WarnParse, Message: "2020-03-16: null open; 2020-03-17: null close"},
+		{Code: sources.WarnStartClamped, Message: "start moved"},
+	}
+	if got := sources.Warnings(meta); !reflect.DeepEqual(got, want) {
+		t.Errorf("Warnings() = %+v, want %+v", got, want)
+	}
+	if got := want[1].String(); got != "start_clamped: start moved" {
+		t.Errorf("String() = %q", got)
+	}
+}
+
+func TestWarnings_None(t *testing.T) {
+	if got := sources.Warnings(map[string]string{"stale": "true", "warnings": "legacy"}); got != nil {
+		t.Errorf("Warnings() = %+v, want nil", got)
+	}
+	if got := sources.WarningsMeta(nil, nil); got != nil {
+		t.Errorf("WarningsMeta(nil, nil) = %v, want nil", got)
+	}
+}
@@ -0,0 +1,369 @@
+package worldbank
+
+import (
+	"archive/zip"
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	internalhttp "github.com/julianshen/gonp-datareader/internal/http"
+	"github.com/julianshen/gonp-datareader/sources"
+)
+
+// bulkURL is the World Bank bulk download of an indicator for all
+// countries and years, a zip archive of CSV files.
+const bulkURL = "https://api.worldbank.org/v2/en/indicator/%s?downloadformat=csv"
+
+// ErrNoBulkData is returned by ReadBulk when the downloaded archive holds
+// no indicator data file.
+var ErrNoBulkData = errors.New("no indicator data in bulk file")
+
+// BulkFilter selects the part of a bulk download ReadBulk keeps.
+type BulkFilter struct {
+	// Countries lists the ISO3 country or aggregate codes to keep (e.g.,
+	// "USA", "WLD"); empty keeps all.
+	Countries []string
+	// Start and End limit the years kept; zero values leave the range open.
+	Start time.Time
+	End   time.Time
+	// ExcludeAggregates drops regional and income-group aggregates (e.g.,
+	// "WLD", "EUU"), keeping only countries, as listed in the archive's
+	// country metadata.
+	ExcludeAggregates bool
+}
+
+// BulkData holds one indicator for many countries, one value column per
+// country aligned to Years.
+type BulkData struct {
+	// Indicator is the indicator code, e.g. "NY.GDP.MKTP.CD".
+	Indicator string
+	// IndicatorName is the indicator's description.
+	IndicatorName string
+	// Years holds the years kept, in ascending order.
+	Years []string
+	// Countries holds the ISO3 codes kept, in file order.
+	Countries []string
+	// Names holds each country's name, keyed by code.
+	Names map[string]string
+	// Values holds each country's values aligned to Years, keyed by code,
+	// with "" for missing observations.
+	Values map[string][]string
+	// Meta holds response metadata such as "last_updated", the date the
+	// World Bank last updated the indicator.
+	Meta map[string]string
+}
+
+// SetBulkURL sets the bulk download URL used by ReadBulk. The URL has one
+// %s verb for the indicator code. This is primarily used for testing with
+// mock servers.
+func (w *WorldBankReader) SetBulkURL(url string) {
+	w.bulkURL = url
+}
+
+// ReadBulk downloads an indicator for all countries and years in a single
+// request, which is far cheaper than per-country calls for global
+// cross-sections. The CSV inside the zip archive is decompressed and
+// filtered as a stream, so only the rows and years selected by filter are
+// kept in memory.
+//
+// # Example Usage
+//
+//	reader := worldbank.NewWorldBankReader(nil)
+//	gdp, err := reader.ReadBulk(ctx, "NY.GDP.MKTP.CD", worldbank.BulkFilter{
+//		Start:             time.Date(2010, 1, 1, 0, 0, 0, 0, time.UTC),
+//		ExcludeAggregates: true,
+//	})
+//	values, err := gdp.Float64Column("USA")
+func (w *WorldBankReader) ReadBulk(ctx context.Context, indicator string, filter BulkFilter) (*BulkData, error) {
+	indicator = strings.TrimSpace(indicator)
+	if indicator == "" || strings.ContainsAny(indicator, " /") {
+		return nil, fmt.Errorf("invalid indicator %q", indicator)
+	}
+	if !filter.Start.IsZero() && !filter.End.IsZero() && filter.Start.Year() > filter.End.Year() {
+		return nil, fmt.Errorf("%w: start year %d after end year %d", sources.ErrInvalidDateRange, filter.Start.Year(), filter.End.Year())
+	}
+
+	req, err := http.NewRequestWithContext(internalhttp.WithContentType(ctx, "application/zip", "application/x-zip-compressed"), "GET", fmt.Sprintf(w.bulkURL, url.PathEscape(indicator)), nil)
+	if err != nil {
+		return nil, fmt.Errorf("create request: %w", err)
+	}
+
+	resp, err := w.client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("fetch data: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != 200 {
+		return nil, internalhttp.StatusError(resp, fmt.Errorf("HTTP %d: %s", resp.StatusCode, resp.Status))
+	}
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("read response: %w", err)
+	}
+
+	data, err := ParseBulk(body, filter)
+	if err != nil {
+		return nil, fmt.Errorf("parse bulk file: %w", err)
+	}
+	for k, v := range internalhttp.StaleMeta(resp) {
+		data.Meta[k] = v
+	}
+	return data, nil
+}
+
+// ParseBulk parses a World Bank bulk download archive, keeping the rows
+// and years selected by filter.
+func ParseBulk(body []byte, filter BulkFilter) (*BulkData, error) {
+	archive, err := zip.NewReader(bytes.NewReader(body), int64(len(body)))
+	if err != nil {
+		return nil, fmt.Errorf("open zip: %w", err)
+	}
+
+	// The archive holds the data file "API_<indicator>_..." and metadata
+	// files "Metadata_Country_..." and "Metadata_Indicator_..."
+	var dataFile, countryFile *zip.File
+	for _, file := range archive.File {
+		switch {
+		case strings.HasPrefix(file.Name, "API_"):
+			dataFile = file
+		case strings.HasPrefix(file.Name, "Metadata_Country_"):
+			countryFile = file
+		}
+	}
+	if dataFile == nil {
+		return nil, ErrNoBulkData
+	}
+
+	var countriesOnly map[string]bool
+	if filter.ExcludeAggregates {
+		if countryFile == nil {
+			return nil, fmt.Errorf("exclude aggregates: no country metadata in bulk file")
+		}
+		if countriesOnly, err = readCountryCodes(countryFile); err != nil {
+			return nil, fmt.Errorf("%s: %w", countryFile.Name, err)
+		}
+	}
+
+	rc, err := dataFile.Open()
+	if err != nil {
+		return nil, fmt.Errorf("open %s: %w", dataFile.Name, err)
+	}
+	defer rc.Close()
+
+	keep := func(code string) bool {
+		if countriesOnly != nil && !countriesOnly[code] {
+			return false
+		}
+		if len(filter.Countries) == 0 {
+			return true
+		}
+		for _, c := range filter.Countries {
+			if strings.EqualFold(c, code) {
+				return true
+			}
+		}
+		return false
+	}
+
+	data, err := parseBulkCSV(rc, filter, keep)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", dataFile.Name, err)
+	}
+	return data, nil
+}
+
+// parseBulkCSV reads the bulk data CSV row by row. The file starts with a
+// preamble ("Data Source", "Last Updated Date"), followed by a header of
+// "Country Name", "Country Code", "Indicator Name", "Indicator Code" and
+// one column per year.
+func parseBulkCSV(r io.Reader, filter BulkFilter, keep func(code string) bool) (*BulkData, error) {
+	reader := csv.NewReader(skipBOM(r))
+	reader.FieldsPerRecord = -1
+	reader.LazyQuotes = true
+
+	data := &BulkData{
+		Names:  make(map[string]string),
+		Values: make(map[string][]string),
+		Meta:   make(map[string]string),
+	}
+
+	// yearCols holds the CSV column of each kept year
+	var yearCols []int
+	for {
+		record, err := reader.Read()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, err
+		}
+
+		if yearCols == nil {
+			switch {
+			case len(record) >= 2 && record[0] == "Last Updated Date":
+				data.Meta["last_updated"] = record[1]
+			case len(record) >= 4 && record[0] == "Country Name":
+				yearCols = bulkYearColumns(record, filter)
+				for _, col := range yearCols {
+					data.Years = append(data.Years, strings.TrimSpace(record[col]))
+				}
+			}
+			continue
+		}
+
+		if len(record) < 4 || !keep(record[1]) {
+			continue
+		}
+		code := record[1]
+		if data.Indicator == "" {
+			data.Indicator, data.IndicatorName = record[3], record[2]
+		}
+		values := make([]string, len(yearCols))
+		for i, col := range yearCols {
+			if col < len(record) {
+				values[i] = strings.TrimSpace(record[col])
+			}
+		}
+		data.Countries = append(data.Countries, code)
+		data.Names[code] = record[0]
+		data.Values[code] = values
+	}
+
+	if yearCols == nil {
+		return nil, fmt.Errorf("missing header row")
+	}
+	return data, nil
+}
+
+// bulkYearColumns returns the header's year columns within the filter's
+// range; year columns start after the four identifier columns.
+func bulkYearColumns(header []string, filter BulkFilter) []int {
+	cols := []int{}
+	for i := 4; i < len(header); i++ {
+		year, err := strconv.Atoi(strings.TrimSpace(header[i]))
+		if err != nil {
+			continue
+		}
+		if !filter.Start.IsZero() && year < filter.Start.Year() {
+			continue
+		}
+		if !filter.End.IsZero() && year > filter.End.Year() {
+			continue
+		}
+		cols = append(cols, i)
+	}
+	return cols
+}
+
+// readCountryCodes returns the codes of the country metadata file that
+// belong to a region; aggregates have no region.
+func readCountryCodes(file *zip.File) (map[string]bool, error) {
+	rc, err := file.Open()
+	if err != nil {
+		return nil, err
+	}
+	defer rc.Close()
+
+	reader := csv.NewReader(skipBOM(rc))
+	reader.FieldsPerRecord = -1
+	reader.LazyQuotes = true
+
+	header, err := reader.Read()
+	if err != nil {
+		return nil, err
+	}
+	codeCol, regionCol := -1, -1
+	for i, name := range header {
+		switch strings.TrimSpace(name) {
+		case "Country Code":
+			codeCol = i
+		case "Region":
+			regionCol = i
+		}
+	}
+	if codeCol < 0 || regionCol < 0 {
+		return nil, fmt.Errorf("missing Country Code or Region column")
+	}
+
+	codes := make(map[string]bool)
+	for {
+		record, err := reader.Read()
+		if err == io.EOF {
+			return codes, nil
+		}
+		if err != nil {
+			return nil, err
+		}
+		if codeCol < len(record) && regionCol < len(record) && strings.TrimSpace(record[regionCol]) != "" {
+			codes[record[codeCol]] = true
+		}
+	}
+}
+
+// skipBOM drops a leading UTF-8 byte order mark, which World Bank CSV
+// files start with.
+func skipBOM(r io.Reader) io.Reader {
+	br := bufio.NewReader(r)
+	if b, err := br.Peek(3); err == nil && bytes.Equal(b, []byte{0xEF, 0xBB, 0xBF}) {
+		_, _ = br.Discard(3)
+	}
+	return br
+}
+
+// DateIndex returns Years parsed as dates.
+func (b *BulkData) DateIndex() ([]time.Time, error) {
+	if b == nil {
+		return nil, nil
+	}
+	return sources.ParseDates(b.Years)
+}
+
+// ColumnNames returns the country codes, one column per country.
+func (b *BulkData) ColumnNames() []string {
+	if b == nil {
+		return nil
+	}
+	return b.Countries
+}
+
+// Float64Column returns the values of the country with the given code as
+// float64 values, with missing values as NaN.
+func (b *BulkData) Float64Column(name string) ([]float64, error) {
+	if b == nil {
+		return nil, sources.NoColumn(name)
+	}
+	values, ok := b.Values[name]
+	if !ok {
+		return nil, sources.NoColumn(name)
+	}
+	return sources.ParseFloats(values)
+}
+
+// Series returns one country's observations in the form returned by
+// ReadSingle, with "" for missing years.
+func (b *BulkData) Series(country string) (*ParsedData, bool) {
+	if b == nil {
+		return nil, false
+	}
+	values, ok := b.Values[country]
+	if !ok {
+		return nil, false
+	}
+	data := &ParsedData{
+		Dates:  append([]string{}, b.Years...),
+		Values: append([]string{}, values...),
+		Meta:   b.Meta,
+	}
+	return data, true
+}
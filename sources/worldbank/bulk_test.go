@@ -0,0 +1,152 @@
+package worldbank_test
+
+import (
+	"archive/zip"
+	"bytes"
+	"context"
+	"errors"
+	"math"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/julianshen/gonp-datareader/sources/worldbank"
+)
+
+const bulkCSV = "\ufeff\"Data Source\",\"World Development Indicators\",\n" +
+	"\n" +
+	"\"Last Updated Date\",\"2024-06-28\",\n" +
+	"\n" +
+	"\"Country Name\",\"Country Code\",\"Indicator Name\",\"Indicator Code\",\"2019\",\"2020\",\"2021\",\n" +
+	"\"Aruba\",\"ABW\",\"GDP (current US$)\",\"NY.GDP.MKTP.CD\",\"3395798882\",\"\",\"3126019385\",\n" +
+	"\"World\",\"WLD\",\"GDP (current US$)\",\"NY.GDP.MKTP.CD\",\"87.6e12\",\"85.2e12\",\"97.5e12\",\n" +
+	"\"United States\",\"USA\",\"GDP (current US$)\",\"NY.GDP.MKTP.CD\",\"21.5e12\",\"21.3e12\",\"23.6e12\",\n"
+
+const countryCSV = "\ufeff\"Country Code\",\"Region\",\"IncomeGroup\",\"SpecialNotes\",\"TableName\",\n" +
+	"\"ABW\",\"Latin America & Caribbean\",\"High income\",\"\",\"Aruba\",\n" +
+	"\"WLD\",\"\",\"\",\"World aggregate\",\"World\",\n" +
+	"\"USA\",\"North America\",\"High income\",\"\",\"United States\",\n"
+
+func bulkZip(t *testing.T, files map[string]string) []byte {
+	t.Helper()
+
+	var buf bytes.Buffer
+	zw := zip.NewWriter(&buf)
+	for name, content := range files {
+		w, err := zw.Create(name)
+		if err != nil {
+			t.Fatal(err)
+		}
+		w.Write([]byte(content))
+	}
+	if err := zw.Close(); err != nil {
+		t.Fatal(err)
+	}
+	return buf.Bytes()
+}
+
+func TestWorldBankReader_ReadBulk(t *testing.T) {
+	archive := bulkZip(t, map[string]string{
+		"API_NY.GDP.MKTP.CD_DS2_en_csv_v2_1.csv":                  bulkCSV,
+		"Metadata_Country_API_NY.GDP.MKTP.CD_DS2_en_csv_v2_1.csv": countryCSV,
+	})
+
+	var gotPath string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		gotPath = r.URL.Path
+		w.Header().Set("Content-Type", "application/zip")
+		w.Write(archive)
+	}))
+	defer server.Close()
+
+	reader := worldbank.NewWorldBankReader(nil)
+	reader.SetBulkURL(server.URL + "/indicator/%s")
+
+	data, err := reader.ReadBulk(context.Background(), "NY.GDP.MKTP.CD", worldbank.BulkFilter{})
+	if err != nil {
+		t.Fatalf("ReadBulk() error = %v", err)
+	}
+	if gotPath != "/indicator/NY.GDP.MKTP.CD" {
+		t.Errorf("request path = %q", gotPath)
+	}
+	if data.Indicator != "NY.GDP.MKTP.CD" || data.IndicatorName != "GDP (current US$)" {
+		t.Errorf("indicator = %q (%q)", data.Indicator, data.IndicatorName)
+	}
+	if want := []string{"2019", "2020", "2021"}; !reflect.DeepEqual(data.Years, want) {
+		t.Errorf("Years = %v, want %v", data.Years, want)
+	}
+	if want := []string{"ABW", "WLD", "USA"}; !reflect.DeepEqual(data.ColumnNames(), want) {
+		t.Errorf("ColumnNames() = %v, want %v", data.ColumnNames(), want)
+	}
+	if data.Names["USA"] != "United States" || data.Meta["last_updated"] != "2024-06-28" {
+		t.Errorf("Names = %v, Meta = %v", data.Names, data.Meta)
+	}
+
+	aruba, err := data.Float64Column("ABW")
+	if err != nil || aruba[0] != 3395798882 || !math.IsNaN(aruba[1]) {
+		t.Errorf("Float64Column(ABW) = %v, %v", aruba, err)
+	}
+	series, ok := data.Series("ABW")
+	if !ok || !reflect.DeepEqual(series.Dates, []string{"2019", "2020", "2021"}) || series.Values[1] != "" {
+		t.Errorf("Series(ABW) = %+v, %v", series, ok)
+	}
+}
+
+func TestParseBulk_Filter(t *testing.T) {
+	archive := bulkZip(t, map[string]string{
+		"API_X_DS2.csv":              bulkCSV,
+		"Metadata_Country_API_X.csv": countryCSV,
+	})
+
+	tests := []struct {
+		name          string
+		filter        worldbank.BulkFilter
+		wantCountries []string
+		wantYears     []string
+	}{
+		{"countries", worldbank.BulkFilter{Countries: []string{"usa", "WLD"}}, []string{"WLD", "USA"}, []string{"2019", "2020", "2021"}},
+		{"years", worldbank.BulkFilter{
+			Start: time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC),
+			End:   time.Date(2020, 12, 31, 0, 0, 0, 0, time.UTC),
+		}, []string{"ABW", "WLD", "USA"}, []string{"2020"}},
+		{"exclude aggregates", worldbank.BulkFilter{ExcludeAggregates: true}, []string{"ABW", "USA"}, []string{"2019", "2020", "2021"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			data, err := worldbank.ParseBulk(archive, tt.filter)
+			if err != nil {
+				t.Fatalf("ParseBulk() error = %v", err)
+			}
+			if !reflect.DeepEqual(data.Countries, tt.wantCountries) {
+				t.Errorf("Countries = %v, want %v", data.Countries, tt.wantCountries)
+			}
+			if !reflect.DeepEqual(data.Years, tt.wantYears) {
+				t.Errorf("Years = %v, want %v", data.Years, tt.wantYears)
+			}
+			for _, code := range data.Countries {
+				if len(data.Values[code]) != len(data.Years) {
+					t.Errorf("Values[%s] has %d values for %d years", code, len(data.Values[code]), len(data.Years))
+				}
+			}
+		})
+	}
+}
+
+func TestParseBulk_Errors(t *testing.T) {
+	if _, err := worldbank.ParseBulk(bulkZip(t, map[string]string{"Metadata_Country_X.csv": countryCSV}), worldbank.BulkFilter{}); !errors.Is(err, worldbank.ErrNoBulkData) {
+		t.Errorf("ParseBulk() without data file error = %v, want ErrNoBulkData", err)
+	}
+	if _, err := worldbank.ParseBulk(bulkZip(t, map[string]string{"API_X.csv": bulkCSV}), worldbank.BulkFilter{ExcludeAggregates: true}); err == nil {
+		t.Error("ParseBulk() with ExcludeAggregates and no country metadata returned nil error")
+	}
+	if _, err := worldbank.ParseBulk([]byte("not a zip"), worldbank.BulkFilter{}); err == nil {
+		t.Error("ParseBulk() of non-zip data returned nil error")
+	}
+
+	reader := worldbank.NewWorldBankReader(nil)
+	if _, err := reader.ReadBulk(context.Background(), "USA/NY.GDP.MKTP.CD", worldbank.BulkFilter{}); err == nil {
+		t.Error("ReadBulk() with a country/indicator symbol returned nil error")
+	}
+}
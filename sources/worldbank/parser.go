@@ -4,12 +4,69 @@ import (
 	"encoding/json"
 	"fmt"
 	"sort"
+	"strings"
+	"time"
+
+	"github.com/julianshen/gonp-datareader/sources"
 )
 
 // ParsedData represents parsed World Bank indicator data.
 type ParsedData struct {
-	Dates  []string
-	Values []string
+	Dates []string `schema:"Date"`
+	// Values holds the values, with "" for years without data. Floats
+	// returns them as float64 values with a validity mask.
+	Values []string `schema:"Value,float64"`
+	// Flags holds the upstream obs_status of each observation (e.g., "E" for
+	// estimated). It is nil when no observation carries a status.
+	Flags []string `schema:"Flags"`
+	// Indicator is the indicator's name (e.g., "GDP (current US$)").
+	Indicator string `schema:"-"`
+	// Unit is the unit of the values: the unit the API reports or, as it
+	// rarely does, the parenthesized part of the indicator's name (e.g.,
+	// "current US$", "% of total labor force"); empty when unknown.
+	Unit string `schema:"-"`
+	// Meta holds response metadata such as "stale" when the data was
+	// served from an expired cache entry; nil when there is none.
+	Meta map[string]string `schema:"-"`
+}
+
+// DateIndex returns Dates parsed as dates.
+func (p *ParsedData) DateIndex() ([]time.Time, error) {
+	if p == nil {
+		return nil, nil
+	}
+	return sources.ParseDates(p.Dates)
+}
+
+// SelectRows keeps the rows at indices, in that order (see
+// sources.RowSelector).
+func (p *ParsedData) SelectRows(indices []int) {
+	if p == nil {
+		return
+	}
+	p.Dates = sources.SelectRows(p.Dates, indices)
+	p.Values = sources.SelectRows(p.Values, indices)
+	p.Flags = sources.SelectRows(p.Flags, indices)
+}
+
+// ColumnNames returns the single value column, "Value".
+func (p *ParsedData) ColumnNames() []string {
+	return []string{"Value"}
+}
+
+// Float64Column returns the "Value" column as float64 values, with missing
+// values as NaN.
+func (p *ParsedData) Float64Column(name string) ([]float64, error) {
+	if p == nil || name != "Value" {
+		return nil, sources.NoColumn(name)
+	}
+	return sources.ParseFloats(p.Values)
+}
+
+// Floats returns Values as float64 values, with missing observations as
+// NaN, and a mask that is false where an observation is missing.
+func (p *ParsedData) Floats() ([]float64, []bool, error) {
+	return sources.MaskedColumn(p, "Value")
 }
 
 // observation represents a single data point from the World Bank API.
@@ -49,22 +106,28 @@ func ParseResponse(data []byte) (*ParsedData, error) {
 		return nil, fmt.Errorf("parse observations: %w", err)
 	}
 
-	// Extract dates and values, filtering out null values
+	// Extract dates and values, with "" for null values
 	type dataPoint struct {
 		date  string
 		value string
+		flag  string
 	}
 	var points []dataPoint
 
+	var indicator, unit string
 	for _, obs := range observations {
-		// Skip null values
-		if obs.Value == nil {
-			continue
+		if indicator == "" {
+			indicator = obs.Indicator.Value
+		}
+		if unit == "" {
+			unit = obs.Unit
 		}
 
 		// Format the value properly (handle large numbers without scientific notation)
 		var valueStr string
 		switch v := obs.Value.(type) {
+		case nil:
+			// Missing observation
 		case float64:
 			// Use %.0f to avoid scientific notation for large numbers
 			valueStr = fmt.Sprintf("%.0f", v)
@@ -75,6 +138,7 @@ func ParseResponse(data []byte) (*ParsedData, error) {
 		points = append(points, dataPoint{
 			date:  obs.Date,
 			value: valueStr,
+			flag:  obs.ObsStatus,
 		})
 	}
 
@@ -85,14 +149,42 @@ func ParseResponse(data []byte) (*ParsedData, error) {
 	})
 
 	// Extract sorted dates and values
+	if unit == "" {
+		unit = indicatorUnit(indicator)
+	}
 	result := &ParsedData{
-		Dates:  make([]string, len(points)),
-		Values: make([]string, len(points)),
+		Dates:     make([]string, len(points)),
+		Values:    make([]string, len(points)),
+		Indicator: indicator,
+		Unit:      unit,
 	}
 	for i, p := range points {
 		result.Dates[i] = p.date
 		result.Values[i] = p.value
+
+		// Only materialize the Flags column when a status is present
+		if p.flag != "" && result.Flags == nil {
+			result.Flags = make([]string, len(points))
+		}
+		if result.Flags != nil {
+			result.Flags[i] = p.flag
+		}
 	}
 
 	return result, nil
 }
+
+// indicatorUnit returns the last parenthesized part of an indicator name,
+// which the World Bank uses for units: "GDP (current US$)" has unit
+// "current US$".
+func indicatorUnit(name string) string {
+	end := strings.LastIndex(name, ")")
+	if end < 0 || end != len(strings.TrimSpace(name))-1 {
+		return ""
+	}
+	start := strings.LastIndex(name[:end], "(")
+	if start < 0 {
+		return ""
+	}
+	return strings.TrimSpace(name[start+1 : end])
+}
@@ -1,6 +1,8 @@
 package worldbank_test
 
 import (
+	"math"
+	"reflect"
 	"testing"
 
 	"github.com/julianshen/gonp-datareader/sources/worldbank"
@@ -72,26 +74,41 @@ func TestParseResponse(t *testing.T) {
 		t.Fatalf("ParseResponse failed: %v", err)
 	}
 
-	if len(result.Dates) != 2 {
-		t.Errorf("Expected 2 dates (null filtered), got %d", len(result.Dates))
+	// The null 2020 value is kept as a missing observation
+	if len(result.Dates) != 3 {
+		t.Errorf("Expected 3 dates, got %d", len(result.Dates))
 	}
 
-	if len(result.Values) != 2 {
-		t.Errorf("Expected 2 values (null filtered), got %d", len(result.Values))
+	if len(result.Values) != 3 {
+		t.Errorf("Expected 3 values, got %d", len(result.Values))
 	}
 
 	// Check that dates are in order (World Bank returns newest first)
-	if result.Dates[0] != "2021" {
-		t.Errorf("Expected first date '2021', got %q", result.Dates[0])
+	if result.Dates[0] != "2020" {
+		t.Errorf("Expected first date '2020', got %q", result.Dates[0])
 	}
 
-	if result.Dates[1] != "2022" {
-		t.Errorf("Expected second date '2022', got %q", result.Dates[1])
+	if result.Dates[1] != "2021" {
+		t.Errorf("Expected second date '2021', got %q", result.Dates[1])
 	}
 
 	// Check values
-	if result.Values[0] != "23315100000000" {
-		t.Errorf("Expected first value '23315100000000', got %q", result.Values[0])
+	if result.Values[0] != "" {
+		t.Errorf("Expected the null value as \"\", got %q", result.Values[0])
+	}
+	if result.Values[1] != "23315100000000" {
+		t.Errorf("Expected second value '23315100000000', got %q", result.Values[1])
+	}
+
+	values, valid, err := result.Floats()
+	if err != nil {
+		t.Fatalf("Floats() error = %v", err)
+	}
+	if !math.IsNaN(values[0]) || values[1] != 23315100000000 {
+		t.Errorf("Floats() values = %v, want NaN for 2020", values)
+	}
+	if !reflect.DeepEqual(valid, []bool{false, true, true}) {
+		t.Errorf("Floats() valid = %v, want [false true true]", valid)
 	}
 }
 
@@ -124,3 +141,66 @@ func TestParseResponse_InvalidJSON(t *testing.T) {
 		t.Error("Expected error for invalid JSON")
 	}
 }
+
+func TestParseResponse_ObsStatusFlags(t *testing.T) {
+	jsonData := `[
+		{"page": 1, "pages": 1, "per_page": "1000", "total": 2},
+		[
+			{"date": "2023", "value": 100, "obs_status": "E"},
+			{"date": "2022", "value": 90, "obs_status": ""}
+		]
+	]`
+
+	data, err := worldbank.ParseResponse([]byte(jsonData))
+	if err != nil {
+		t.Fatalf("ParseResponse() error = %v", err)
+	}
+
+	if len(data.Flags) != 2 {
+		t.Fatalf("expected 2 flags, got %d", len(data.Flags))
+	}
+	// Data is sorted ascending, so 2022 comes first
+	if data.Flags[0] != "" || data.Flags[1] != "E" {
+		t.Errorf("Flags = %q, want [\"\" \"E\"]", data.Flags)
+	}
+}
+
+func TestParseResponse_Unit(t *testing.T) {
+	tests := []struct {
+		name string
+		obs  string
+		want string
+	}{
+		{"from indicator name", `{"indicator": {"id": "NY.GDP.MKTP.CD", "value": "GDP (current US$)"}, "date": "2023", "value": 1, "unit": ""}`, "current US$"},
+		{"reported unit", `{"indicator": {"value": "Population, total"}, "date": "2023", "value": 1, "unit": "persons"}`, "persons"},
+		{"unknown", `{"indicator": {"value": "Population, total"}, "date": "2023", "value": 1}`, ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			data, err := worldbank.ParseResponse([]byte(`[{"page": 1}, [` + tt.obs + `]]`))
+			if err != nil {
+				t.Fatalf("ParseResponse() error = %v", err)
+			}
+			if data.Unit != tt.want {
+				t.Errorf("Unit = %q, want %q", data.Unit, tt.want)
+			}
+		})
+	}
+}
+
+func TestParseResponse_NoFlags(t *testing.T) {
+	jsonData := `[
+		{"page": 1},
+		[{"date": "2022", "value": 90, "obs_status": ""}]
+	]`
+
+	data, err := worldbank.ParseResponse([]byte(jsonData))
+	if err != nil {
+		t.Fatalf("ParseResponse() error = %v", err)
+	}
+
+	if data.Flags != nil {
+		t.Errorf("expected nil Flags when no status present, got %q", data.Flags)
+	}
+}
@@ -9,6 +9,7 @@ import (
 	"strings"
 	"time"
 
+	"github.com/julianshen/gonp-datareader/dataset"
 	internalhttp "github.com/julianshen/gonp-datareader/internal/http"
 	"github.com/julianshen/gonp-datareader/internal/utils"
 	"github.com/julianshen/gonp-datareader/sources"
@@ -19,6 +20,7 @@ type WorldBankReader struct {
 	*sources.BaseSource
 	client  *internalhttp.RetryableClient
 	baseURL string // For testing with mock servers
+	bulkURL string
 }
 
 // NewWorldBankReader creates a new World Bank data reader.
@@ -37,9 +39,15 @@ func NewWorldBankReaderWithBaseURL(opts *internalhttp.ClientOptions, baseURL str
 		BaseSource: sources.NewBaseSource("worldbank"),
 		client:     internalhttp.NewRetryableClient(opts),
 		baseURL:    baseURL,
+		bulkURL:    bulkURL,
 	}
 }
 
+// Schema returns the columns of the ParsedData returned by ReadSingle.
+func (w *WorldBankReader) Schema() sources.Schema {
+	return sources.NewSchema(w.Source(), w.Name(), ParsedData{})
+}
+
 // BuildURL constructs the World Bank API URL for fetching indicator data.
 // The World Bank API format is:
 // https://api.worldbank.org/v2/country/{countries}/indicator/{indicator}?date={start}:{end}&format=json
@@ -59,6 +67,15 @@ func BuildURL(country, indicator string, start, end time.Time) string {
 // ReadSingle fetches data for a single indicator and country.
 // The symbol parameter should be in the format "country/indicator", e.g., "USA/NY.GDP.MKTP.CD"
 func (w *WorldBankReader) ReadSingle(ctx context.Context, symbol string, start, end time.Time) (interface{}, error) {
+	return w.Finish(ctx, symbol, start, end, w.readSingle)
+}
+
+// readSingle implements ReadSingle before Finish is applied.
+func (w *WorldBankReader) readSingle(ctx context.Context, symbol string, start, end time.Time) (interface{}, error) {
+	// Normalize user input; the original is kept in Meta["symbol_input"]
+	input := symbol
+	symbol = w.NormalizeSymbol(symbol)
+
 	// Validate symbol
 	if err := w.ValidateSymbol(symbol); err != nil {
 		return nil, err
@@ -84,7 +101,7 @@ func (w *WorldBankReader) ReadSingle(ctx context.Context, symbol string, start,
 	}
 
 	// Create HTTP request
-	req, err := newRequest(ctx, "GET", url)
+	req, err := newRequest(internalhttp.WithContentType(ctx, "application/json"), "GET", url)
 	if err != nil {
 		return nil, fmt.Errorf("create request: %w", err)
 	}
@@ -98,7 +115,7 @@ func (w *WorldBankReader) ReadSingle(ctx context.Context, symbol string, start,
 
 	// Check status code
 	if resp.StatusCode != 200 {
-		return nil, fmt.Errorf("HTTP %d: %s", resp.StatusCode, resp.Status)
+		return nil, internalhttp.StatusError(resp, fmt.Errorf("HTTP %d: %s", resp.StatusCode, resp.Status))
 	}
 
 	// Read response body
@@ -108,12 +125,28 @@ func (w *WorldBankReader) ReadSingle(ctx context.Context, symbol string, start,
 	}
 
 	// Parse response
-	data, err := ParseResponse(body)
+	decoded, err := w.client.Decode("worldbank", body, func(b []byte) (interface{}, error) {
+		return ParseResponse(b)
+	})
 	if err != nil {
 		return nil, fmt.Errorf("parse response: %w", err)
 	}
+	if len(decoded.(*ParsedData).Dates) == 0 {
+		return nil, fmt.Errorf("%w: no World Bank observations of %s", sources.ErrNoData, symbol)
+	}
+
+	// Copy the decoded value, which may be shared through the decoded cache,
+	// and flag data served from an expired cache entry
+	data := *decoded.(*ParsedData)
+	data.Meta = sources.InputMeta(internalhttp.StaleMeta(resp), input, symbol)
+	if data.Unit != "" {
+		if data.Meta == nil {
+			data.Meta = make(map[string]string)
+		}
+		data.Meta[dataset.MetaUnit] = data.Unit
+	}
 
-	return data, nil
+	return &data, nil
 }
 
 // Read fetches data for multiple indicators and countries from World Bank.
@@ -199,6 +232,8 @@ func (w *WorldBankReader) readParallel(ctx context.Context, symbols []string, st
 // ValidateSymbol checks if a symbol is valid for World Bank.
 // World Bank symbols are in the format "country/indicator", e.g., "USA/NY.GDP.MKTP.CD"
 func (w *WorldBankReader) ValidateSymbol(symbol string) error {
+	symbol = w.NormalizeSymbol(symbol)
+
 	if symbol == "" {
 		return fmt.Errorf("symbol cannot be empty")
 	}
@@ -228,3 +263,14 @@ func newRequest(ctx context.Context, method, url string) (*http.Request, error)
 func readAll(r io.Reader) ([]byte, error) {
 	return io.ReadAll(r)
 }
+
+// Shutdown stops accepting requests and waits for in-flight requests to
+// finish, cancelling them if ctx expires first.
+func (w *WorldBankReader) Shutdown(ctx context.Context) error {
+	return w.client.Shutdown(ctx)
+}
+
+// Close cancels in-flight requests and releases the reader's connections.
+func (w *WorldBankReader) Close() error {
+	return w.client.Close()
+}
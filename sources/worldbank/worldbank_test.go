@@ -2,6 +2,7 @@ package worldbank_test
 
 import (
 	"context"
+	"errors"
 	"net/http"
 	"net/http/httptest"
 	"strings"
@@ -9,6 +10,7 @@ import (
 	"time"
 
 	internalhttp "github.com/julianshen/gonp-datareader/internal/http"
+	"github.com/julianshen/gonp-datareader/sources"
 	"github.com/julianshen/gonp-datareader/sources/worldbank"
 )
 
@@ -296,3 +298,20 @@ func TestWorldBankReader_HTTPError(t *testing.T) {
 		t.Error("ReadSingle() should return error for HTTP 500")
 	}
 }
+
+func TestWorldBankReader_ReadSingle_NoData(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`[{"page": 1, "pages": 0, "per_page": 1000, "total": 0}, null]`))
+	}))
+	defer server.Close()
+
+	reader := worldbank.NewWorldBankReaderWithBaseURL(nil, server.URL+"?country=%s&indicator=%s&start=%d&end=%d")
+
+	start := time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC)
+	end := time.Date(2023, 12, 31, 0, 0, 0, 0, time.UTC)
+	_, err := reader.ReadSingle(context.Background(), "USA/NY.GDP.MKTP.CD", start, end)
+	if !errors.Is(err, sources.ErrNoData) {
+		t.Errorf("ReadSingle() error = %v, want ErrNoData", err)
+	}
+}
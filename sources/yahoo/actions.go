@@ -0,0 +1,117 @@
+package yahoo
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/julianshen/gonp-datareader/internal/utils"
+	"github.com/julianshen/gonp-datareader/sources"
+)
+
+// actionEvents are the events parameters of the download endpoint read by
+// ReadActions.
+var actionEvents = []string{"div", "split"}
+
+// BuildActionsURL constructs the download URL of the corporate actions of
+// symbol; event is "div" or "split".
+func (y *YahooReader) BuildActionsURL(symbol string, start, end time.Time, event string) string {
+	return fmt.Sprintf("%s?period1=%d&period2=%d&interval=1d&events=%s&includeAdjustedClose=true",
+		fmt.Sprintf(y.baseURL, symbol), start.Unix(), end.Unix(), event)
+}
+
+// ReadActions returns the dividends and splits of symbol with ex-dates in
+// the range, from the download endpoint's div and split events. It costs
+// two requests.
+func (y *YahooReader) ReadActions(ctx context.Context, symbol string, start, end time.Time) ([]sources.Action, error) {
+	symbol = y.NormalizeSymbol(symbol)
+	if err := y.ValidateSymbol(symbol); err != nil {
+		return nil, fmt.Errorf("invalid symbol: %w", err)
+	}
+	if err := utils.ValidateDateRange(start, end); err != nil {
+		return nil, fmt.Errorf("invalid date range: %w", err)
+	}
+
+	var actions []sources.Action
+	for _, event := range actionEvents {
+		body, _, err := y.get(ctx, y.BuildActionsURL(symbol, start, end, event), "text/csv")
+		if err != nil {
+			return nil, err
+		}
+		parsed, err := ParseActions(body, symbol)
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse %s events: %w", event, err)
+		}
+		actions = append(actions, parsed...)
+	}
+	sources.SortActions(actions)
+	return actions, nil
+}
+
+// ParseActions parses a dividend ("Date,Dividends") or split ("Date,Stock
+// Splits") CSV of the download endpoint. Splits are written "4:1" (new
+// shares to old). An empty body has no actions; rows with null or
+// unparsable values are skipped.
+func ParseActions(body []byte, symbol string) ([]sources.Action, error) {
+	data, err := ParseCSV(bytes.NewReader(body))
+	if errors.Is(err, ErrEmptyCSV) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	var column string
+	var actionType sources.ActionType
+	for _, c := range data.Columns {
+		switch c {
+		case "Dividends":
+			column, actionType = c, sources.ActionDividend
+		case "Stock Splits":
+			column, actionType = c, sources.ActionSplit
+		}
+	}
+	if column == "" {
+		return nil, fmt.Errorf("%w: no Dividends or Stock Splits column in %v", sources.ErrNoColumn, data.Columns)
+	}
+
+	var actions []sources.Action
+	for _, row := range data.Rows {
+		dates, err := sources.ParseDates([]string{row["Date"]})
+		if err != nil {
+			continue
+		}
+		action := sources.Action{Symbol: symbol, Date: dates[0], Type: actionType}
+		if actionType == sources.ActionDividend {
+			if action.Amount, err = strconv.ParseFloat(row[column], 64); err != nil {
+				continue
+			}
+		} else if action.Ratio, err = parseSplitRatio(row[column]); err != nil {
+			continue
+		}
+		actions = append(actions, action)
+	}
+	sources.SortActions(actions)
+	return actions, nil
+}
+
+// parseSplitRatio parses a split written "new:old" or "new/old".
+func parseSplitRatio(s string) (float64, error) {
+	newShares, oldShares, ok := strings.Cut(s, ":")
+	if !ok {
+		newShares, oldShares, ok = strings.Cut(s, "/")
+	}
+	if !ok {
+		return 0, fmt.Errorf("invalid split %q", s)
+	}
+	n, err1 := strconv.ParseFloat(strings.TrimSpace(newShares), 64)
+	o, err2 := strconv.ParseFloat(strings.TrimSpace(oldShares), 64)
+	if err1 != nil || err2 != nil || n <= 0 || o <= 0 {
+		return 0, fmt.Errorf("invalid split %q", s)
+	}
+	return n / o, nil
+}
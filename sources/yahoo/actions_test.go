@@ -0,0 +1,89 @@
+package yahoo_test
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/julianshen/gonp-datareader/sources"
+	"github.com/julianshen/gonp-datareader/sources/yahoo"
+)
+
+func TestParseActions(t *testing.T) {
+	day := func(d int) time.Time { return time.Date(2020, 8, d, 0, 0, 0, 0, time.UTC) }
+
+	tests := []struct {
+		name string
+		csv  string
+		want []sources.Action
+	}{
+		{
+			name: "dividends",
+			csv:  "Date,Dividends\n2020-08-07,0.82\n2020-08-03,null\n",
+			want: []sources.Action{{Symbol: "AAPL", Date: day(7), Type: sources.ActionDividend, Amount: 0.82}},
+		},
+		{
+			name: "splits",
+			csv:  "Date,Stock Splits\n2020-08-31,4:1\n2020-08-14,1/10\n",
+			want: []sources.Action{
+				{Symbol: "AAPL", Date: day(14), Type: sources.ActionSplit, Ratio: 0.1},
+				{Symbol: "AAPL", Date: day(31), Type: sources.ActionSplit, Ratio: 4},
+			},
+		},
+		{
+			name: "no events",
+			csv:  "Date,Dividends\n",
+			want: nil,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := yahoo.ParseActions([]byte(tt.csv), "AAPL")
+			if err != nil {
+				t.Fatalf("ParseActions() error = %v", err)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("ParseActions() = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+
+	if _, err := yahoo.ParseActions([]byte("Date,Close\n2020-08-07,1\n"), "AAPL"); err == nil {
+		t.Error("ParseActions() should fail without an action column")
+	}
+}
+
+func TestYahooReader_ReadActions(t *testing.T) {
+	var events []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		event := r.URL.Query().Get("events")
+		events = append(events, event)
+		w.Header().Set("Content-Type", "text/csv")
+		switch event {
+		case "div":
+			w.Write([]byte("Date,Dividends\n2020-08-07,0.82\n"))
+		case "split":
+			w.Write([]byte("Date,Stock Splits\n2020-08-31,4:1\n"))
+		}
+	}))
+	defer server.Close()
+
+	reader := yahoo.NewYahooReaderWithBaseURL(nil, server.URL+"/%s")
+	start := time.Date(2020, 8, 1, 0, 0, 0, 0, time.UTC)
+	end := time.Date(2020, 9, 30, 0, 0, 0, 0, time.UTC)
+
+	actions, err := reader.ReadActions(context.Background(), "AAPL", start, end)
+	if err != nil {
+		t.Fatalf("ReadActions() error = %v", err)
+	}
+	if !reflect.DeepEqual(events, []string{"div", "split"}) {
+		t.Errorf("events requested = %v", events)
+	}
+	if len(actions) != 2 || actions[0].Type != sources.ActionDividend || actions[1].Type != sources.ActionSplit {
+		t.Errorf("ReadActions() = %+v", actions)
+	}
+}
@@ -0,0 +1,158 @@
+package yahoo
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+)
+
+const (
+	// yahooCookieURL issues the session cookie required to obtain a crumb
+	yahooCookieURL = "https://fc.yahoo.com"
+
+	// yahooCrumbURL returns a crumb token bound to the session cookie
+	yahooCrumbURL = "https://query2.finance.yahoo.com/v1/test/getcrumb"
+
+	// Yahoo serves the same API from two interchangeable hosts
+	yahooPrimaryHost   = "query1.finance.yahoo.com"
+	yahooSecondaryHost = "query2.finance.yahoo.com"
+)
+
+// SetAuthEndpoints overrides the URLs used to obtain the session cookie and crumb.
+// This is primarily used for testing with mock servers.
+func (y *YahooReader) SetAuthEndpoints(cookieURL, crumbURL string) {
+	y.mu.Lock()
+	defer y.mu.Unlock()
+	y.cookieURL = cookieURL
+	y.crumbURL = crumbURL
+}
+
+// isAuthFailure reports whether a response indicates an expired or missing crumb.
+func isAuthFailure(statusCode int, body []byte) bool {
+	if statusCode == http.StatusUnauthorized {
+		return true
+	}
+	if statusCode == http.StatusOK {
+		return false
+	}
+	text := string(body)
+	return strings.Contains(text, "Invalid Crumb") || strings.Contains(text, "Unauthorized")
+}
+
+// alternateHost swaps between the query1 and query2 Yahoo hosts.
+// URLs pointing at other hosts (e.g. mock servers) are returned unchanged.
+func alternateHost(rawURL string) string {
+	switch {
+	case strings.Contains(rawURL, yahooPrimaryHost):
+		return strings.Replace(rawURL, yahooPrimaryHost, yahooSecondaryHost, 1)
+	case strings.Contains(rawURL, yahooSecondaryHost):
+		return strings.Replace(rawURL, yahooSecondaryHost, yahooPrimaryHost, 1)
+	default:
+		return rawURL
+	}
+}
+
+// withCrumb appends the current crumb, if any, to the request URL.
+func (y *YahooReader) withCrumb(rawURL string) string {
+	y.mu.Lock()
+	crumb := y.crumb
+	y.mu.Unlock()
+
+	if crumb == "" {
+		return rawURL
+	}
+
+	u, err := url.Parse(rawURL)
+	if err != nil {
+		return rawURL
+	}
+	q := u.Query()
+	q.Set("crumb", crumb)
+	u.RawQuery = q.Encode()
+	return u.String()
+}
+
+// applyCookie sets the session cookie on a request, if one has been obtained.
+func (y *YahooReader) applyCookie(req *http.Request) {
+	y.mu.Lock()
+	cookie := y.cookie
+	y.mu.Unlock()
+
+	if cookie != "" {
+		req.Header.Set("Cookie", cookie)
+	}
+}
+
+// refreshCrumb obtains a fresh session cookie and crumb pair.
+//
+// The auth requests bypass the response cache so that a stale crumb is
+// never replayed.
+func (y *YahooReader) refreshCrumb(ctx context.Context) error {
+	y.mu.Lock()
+	cookieURL, crumbURL := y.cookieURL, y.crumbURL
+	y.mu.Unlock()
+
+	// Step 1: obtain the session cookie. The endpoint may answer with a
+	// non-200 status while still setting the cookie, so only cookies matter.
+	req, err := http.NewRequestWithContext(ctx, "GET", cookieURL, nil)
+	if err != nil {
+		return fmt.Errorf("create cookie request: %w", err)
+	}
+	y.setUserAgent(req)
+
+	resp, err := y.authClient.Do(req)
+	if err != nil {
+		return fmt.Errorf("fetch cookie: %w", err)
+	}
+	_, _ = io.Copy(io.Discard, resp.Body) //nolint:errcheck // Draining for connection reuse
+	_ = resp.Body.Close()
+
+	cookies := make([]string, 0, len(resp.Cookies()))
+	for _, c := range resp.Cookies() {
+		cookies = append(cookies, c.Name+"="+c.Value)
+	}
+	cookie := strings.Join(cookies, "; ")
+
+	// Step 2: exchange the cookie for a crumb
+	req, err = http.NewRequestWithContext(ctx, "GET", crumbURL, nil)
+	if err != nil {
+		return fmt.Errorf("create crumb request: %w", err)
+	}
+	y.setUserAgent(req)
+	if cookie != "" {
+		req.Header.Set("Cookie", cookie)
+	}
+
+	resp, err = y.authClient.Do(req)
+	if err != nil {
+		return fmt.Errorf("fetch crumb: %w", err)
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return fmt.Errorf("read crumb: %w", err)
+	}
+
+	crumb := strings.TrimSpace(string(body))
+	if resp.StatusCode != http.StatusOK || crumb == "" || strings.ContainsAny(crumb, "<{") {
+		return fmt.Errorf("crumb endpoint returned status %d", resp.StatusCode)
+	}
+
+	y.mu.Lock()
+	y.cookie = cookie
+	y.crumb = crumb
+	y.mu.Unlock()
+
+	return nil
+}
+
+// setUserAgent applies the configured User-Agent to an auth request.
+func (y *YahooReader) setUserAgent(req *http.Request) {
+	if y.userAgent != "" {
+		req.Header.Set("User-Agent", y.userAgent)
+	}
+}
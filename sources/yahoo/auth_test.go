@@ -0,0 +1,136 @@
+package yahoo
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestAlternateHost(t *testing.T) {
+	tests := []struct {
+		input string
+		want  string
+	}{
+		{
+			input: "https://query1.finance.yahoo.com/v7/finance/download/AAPL",
+			want:  "https://query2.finance.yahoo.com/v7/finance/download/AAPL",
+		},
+		{
+			input: "https://query2.finance.yahoo.com/v7/finance/download/AAPL",
+			want:  "https://query1.finance.yahoo.com/v7/finance/download/AAPL",
+		},
+		{
+			input: "http://127.0.0.1:1234/AAPL",
+			want:  "http://127.0.0.1:1234/AAPL",
+		},
+	}
+
+	for _, tt := range tests {
+		if got := alternateHost(tt.input); got != tt.want {
+			t.Errorf("alternateHost(%q) = %q, want %q", tt.input, got, tt.want)
+		}
+	}
+}
+
+func TestIsAuthFailure(t *testing.T) {
+	tests := []struct {
+		name   string
+		status int
+		body   string
+		want   bool
+	}{
+		{"401", http.StatusUnauthorized, "", true},
+		{"invalid crumb body", http.StatusBadRequest, `{"finance":{"error":{"description":"Invalid Crumb"}}}`, true},
+		{"unauthorized body", http.StatusForbidden, "Unauthorized", true},
+		{"ok", http.StatusOK, "Unauthorized", false},
+		{"not found", http.StatusNotFound, "No data found", false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := isAuthFailure(tt.status, []byte(tt.body)); got != tt.want {
+				t.Errorf("isAuthFailure(%d, %q) = %v, want %v", tt.status, tt.body, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestYahooReader_ReadSingle_RefreshesCrumb(t *testing.T) {
+	csvData := "Date,Open,High,Low,Close,Adj Close,Volume\n2024-01-02,1,2,0.5,1.5,1.5,100\n"
+	var crumbRequests int32
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/cookie":
+			http.SetCookie(w, &http.Cookie{Name: "A3", Value: "session"})
+			w.WriteHeader(http.StatusNotFound)
+		case "/crumb":
+			atomic.AddInt32(&crumbRequests, 1)
+			if !strings.Contains(r.Header.Get("Cookie"), "A3=session") {
+				w.WriteHeader(http.StatusUnauthorized)
+				return
+			}
+			w.Write([]byte("fresh-crumb"))
+		default:
+			if r.URL.Query().Get("crumb") != "fresh-crumb" {
+				w.WriteHeader(http.StatusUnauthorized)
+				w.Write([]byte(`{"finance":{"error":{"code":"Unauthorized","description":"Invalid Crumb"}}}`))
+				return
+			}
+			w.Write([]byte(csvData))
+		}
+	}))
+	defer server.Close()
+
+	reader := NewYahooReaderWithBaseURL(nil, server.URL+"/download/%s")
+	reader.SetAuthEndpoints(server.URL+"/cookie", server.URL+"/crumb")
+
+	start := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	end := time.Date(2024, 1, 31, 0, 0, 0, 0, time.UTC)
+
+	data, err := reader.ReadSingle(context.Background(), "AAPL", start, end)
+	if err != nil {
+		t.Fatalf("ReadSingle() error = %v", err)
+	}
+
+	parsed, ok := data.(*ParsedData)
+	if !ok || len(parsed.Rows) != 1 {
+		t.Fatalf("unexpected data: %#v", data)
+	}
+	if got := atomic.LoadInt32(&crumbRequests); got != 1 {
+		t.Errorf("expected 1 crumb request, got %d", got)
+	}
+
+	// The crumb is reused for subsequent requests
+	if _, err := reader.ReadSingle(context.Background(), "MSFT", start, end); err != nil {
+		t.Fatalf("second ReadSingle() error = %v", err)
+	}
+	if got := atomic.LoadInt32(&crumbRequests); got != 1 {
+		t.Errorf("expected crumb to be reused, got %d crumb requests", got)
+	}
+}
+
+func TestYahooReader_ReadSingle_CrumbRefreshFails(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusUnauthorized)
+	}))
+	defer server.Close()
+
+	reader := NewYahooReaderWithBaseURL(nil, server.URL+"/download/%s")
+	reader.SetAuthEndpoints(server.URL+"/cookie", server.URL+"/crumb")
+
+	start := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	end := time.Date(2024, 1, 31, 0, 0, 0, 0, time.UTC)
+
+	_, err := reader.ReadSingle(context.Background(), "AAPL", start, end)
+	if err == nil {
+		t.Fatal("expected error when crumb refresh fails")
+	}
+	if !strings.Contains(err.Error(), "crumb refresh failed") {
+		t.Errorf("unexpected error: %v", err)
+	}
+}
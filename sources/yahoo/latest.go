@@ -0,0 +1,69 @@
+package yahoo
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/julianshen/gonp-datareader/sources"
+)
+
+// ReadLatest returns the current day's bar of symbol from the quote
+// endpoint, in one request, with the regular-market price as Close and
+// Adj Close. During trading hours the bar is incomplete;
+// Meta["market_state"] holds Yahoo's market state (e.g., "REGULAR",
+// "CLOSED").
+func (y *YahooReader) ReadLatest(ctx context.Context, symbol string) (interface{}, error) {
+	// Normalize user input; the original is kept in Meta["symbol_input"]
+	input := symbol
+	symbol = y.NormalizeSymbol(symbol)
+	if err := y.ValidateSymbol(symbol); err != nil {
+		return nil, fmt.Errorf("invalid symbol: %w", err)
+	}
+
+	quotes, err := y.readQuoteBatch(ctx, []string{symbol})
+	if err != nil {
+		return nil, err
+	}
+	quote, ok := quotes[symbol]
+	if !ok {
+		return nil, fmt.Errorf("%w: no yahoo quote for %s", sources.ErrSymbolNotFound, symbol)
+	}
+
+	data := QuoteBar(quote)
+	data.Meta = sources.InputMeta(data.Meta, input, symbol)
+	return data, nil
+}
+
+// QuoteBar converts a quote to ParsedData holding one bar dated by the
+// quote's regular-market time at the exchange, in the columns of
+// ReadSingle.
+func QuoteBar(q *Quote) *ParsedData {
+	format := func(v float64) string {
+		return strconv.FormatFloat(v, 'f', -1, 64)
+	}
+	price := format(q.Price)
+
+	columns := []string{"Date", "Open", "High", "Low", "Close", "Adj Close", "Volume"}
+	row := map[string]string{
+		"Date":      time.Unix(q.RegularMarketTime+q.GMTOffsetMillis/1000, 0).UTC().Format("2006-01-02"),
+		"Open":      format(q.Open),
+		"High":      format(q.DayHigh),
+		"Low":       format(q.DayLow),
+		"Close":     price,
+		"Adj Close": price,
+		"Volume":    format(q.Volume),
+	}
+
+	var meta map[string]string
+	if q.MarketState != "" {
+		meta = map[string]string{"market_state": q.MarketState}
+	}
+	return &ParsedData{
+		Columns: columns,
+		Rows:    []map[string]string{row},
+		Meta:    meta,
+		cache:   sources.NewColumnCache(),
+	}
+}
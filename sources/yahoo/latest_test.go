@@ -0,0 +1,71 @@
+package yahoo_test
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/julianshen/gonp-datareader/sources"
+	"github.com/julianshen/gonp-datareader/sources/yahoo"
+)
+
+func TestYahooReader_ReadLatest(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if got := r.URL.Query().Get("symbols"); got != "AAPL" {
+			t.Errorf("symbols = %q, want AAPL", got)
+		}
+		w.Header().Set("Content-Type", "application/json")
+		// 2024-01-02 21:00 UTC is 16:00 in New York (gmtoffset -5h)
+		w.Write([]byte(`{"quoteResponse":{"result":[{"symbol":"AAPL","marketState":"CLOSED",
+			"regularMarketPrice":185.64,"regularMarketOpen":187.15,"regularMarketDayHigh":188.44,
+			"regularMarketDayLow":183.89,"regularMarketVolume":82488700,
+			"regularMarketTime":1704229200,"gmtOffSetMilliseconds":-18000000}],"error":null}}`))
+	}))
+	defer server.Close()
+
+	reader := yahoo.NewYahooReader(nil)
+	reader.SetQuoteURL(server.URL+"?symbols=%s", 0)
+
+	result, err := reader.ReadLatest(context.Background(), "aapl")
+	if err != nil {
+		t.Fatalf("ReadLatest() error = %v", err)
+	}
+	data := result.(*yahoo.ParsedData)
+
+	if len(data.Rows) != 1 {
+		t.Fatalf("len(Rows) = %d, want 1", len(data.Rows))
+	}
+	want := map[string]string{
+		"Date": "2024-01-02", "Open": "187.15", "High": "188.44", "Low": "183.89",
+		"Close": "185.64", "Adj Close": "185.64", "Volume": "82488700",
+	}
+	for column, value := range want {
+		if got := data.Rows[0][column]; got != value {
+			t.Errorf("%s = %q, want %q", column, got, value)
+		}
+	}
+	if data.Meta["market_state"] != "CLOSED" {
+		t.Errorf(`Meta["market_state"] = %q, want CLOSED`, data.Meta["market_state"])
+	}
+	if data.Meta["symbol_input"] != "aapl" {
+		t.Errorf(`Meta["symbol_input"] = %q, want aapl`, data.Meta["symbol_input"])
+	}
+}
+
+func TestYahooReader_ReadLatest_UnknownSymbol(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"quoteResponse":{"result":[],"error":null}}`))
+	}))
+	defer server.Close()
+
+	reader := yahoo.NewYahooReader(nil)
+	reader.SetQuoteURL(server.URL+"?symbols=%s", 0)
+
+	_, err := reader.ReadLatest(context.Background(), "NOPE")
+	if !errors.Is(err, sources.ErrSymbolNotFound) {
+		t.Errorf("ReadLatest() error = %v, want %v", err, sources.ErrSymbolNotFound)
+	}
+}
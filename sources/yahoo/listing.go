@@ -0,0 +1,175 @@
+package yahoo
+
+import (
+	"bytes"
+	"context"
+	"encoding/csv"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+
+	internalhttp "github.com/julianshen/gonp-datareader/internal/http"
+	"github.com/julianshen/gonp-datareader/sources"
+)
+
+const (
+	// nasdaqListedURL is Nasdaq Trader's directory of Nasdaq-listed
+	// securities
+	nasdaqListedURL = "https://www.nasdaqtrader.com/dynamic/SymDir/nasdaqlisted.txt"
+
+	// otherListedURL is Nasdaq Trader's directory of securities listed on
+	// NYSE, NYSE American, NYSE Arca, Cboe and IEX
+	otherListedURL = "https://www.nasdaqtrader.com/dynamic/SymDir/otherlisted.txt"
+)
+
+// otherListedExchanges names the exchange codes of otherlisted.txt.
+var otherListedExchanges = map[string]string{
+	"A": "NYSE American",
+	"N": "NYSE",
+	"P": "NYSE Arca",
+	"Z": "Cboe BZX",
+	"V": "IEX",
+}
+
+// SetDirectoryURLs overrides the Nasdaq Trader symbol directory files used
+// by ListSymbols. This is primarily used for testing with mock servers.
+func (y *YahooReader) SetDirectoryURLs(nasdaqListed, otherListed string) {
+	y.nasdaqListedURL = nasdaqListed
+	y.otherListedURL = otherListed
+}
+
+// ListSymbols lists every US-listed stock and ETF from Nasdaq Trader's
+// symbol directory files, with tickers in Yahoo's notation (e.g., "BRK-B"
+// for "BRK.B"). Test issues are skipped. Type is "EQUITY" or "ETF" and
+// Market the listing exchange; the files carry no ISIN or listing date.
+// Costs two requests to nasdaqtrader.com.
+func (y *YahooReader) ListSymbols(ctx context.Context) ([]sources.SymbolInfo, error) {
+	var infos []sources.SymbolInfo
+	for _, file := range []struct {
+		url   string
+		parse func([]byte) ([]sources.SymbolInfo, error)
+	}{
+		{y.nasdaqListedURL, ParseNasdaqListed},
+		{y.otherListedURL, ParseOtherListed},
+	} {
+		body, err := y.fetchDirectory(ctx, file.url)
+		if err != nil {
+			return nil, err
+		}
+		listed, err := file.parse(body)
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse symbol directory: %w", err)
+		}
+		infos = append(infos, listed...)
+	}
+	return infos, nil
+}
+
+// fetchDirectory fetches a symbol directory file. Unlike fetch, it sends
+// no Yahoo session cookie or crumb.
+func (y *YahooReader) fetchDirectory(ctx context.Context, url string) ([]byte, error) {
+	req, err := http.NewRequestWithContext(internalhttp.WithContentType(ctx, "text/plain"), "GET", url, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create request: %w", err)
+	}
+
+	resp, err := y.client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch symbol directory: %w", err)
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read response: %w", err)
+	}
+	if resp.StatusCode != http.StatusOK {
+		return nil, internalhttp.StatusError(resp, fmt.Errorf("nasdaqtrader returned status %d", resp.StatusCode))
+	}
+
+	return body, nil
+}
+
+// ParseNasdaqListed parses nasdaqlisted.txt.
+func ParseNasdaqListed(body []byte) ([]sources.SymbolInfo, error) {
+	return parseDirectory(body, "Symbol", func(map[string]string) string { return "NASDAQ" })
+}
+
+// ParseOtherListed parses otherlisted.txt.
+func ParseOtherListed(body []byte) ([]sources.SymbolInfo, error) {
+	return parseDirectory(body, "ACT Symbol", func(row map[string]string) string {
+		if name, ok := otherListedExchanges[row["Exchange"]]; ok {
+			return name
+		}
+		return row["Exchange"]
+	})
+}
+
+// parseDirectory parses a pipe-delimited symbol directory whose ticker is
+// in symbolColumn, ending with a "File Creation Time" line.
+func parseDirectory(body []byte, symbolColumn string, market func(map[string]string) string) ([]sources.SymbolInfo, error) {
+	reader := csv.NewReader(bytes.NewReader(body))
+	reader.Comma = '|'
+	reader.FieldsPerRecord = -1
+	reader.LazyQuotes = true
+
+	header, err := reader.Read()
+	if err != nil {
+		return nil, fmt.Errorf("read header: %w", err)
+	}
+	if !containsColumn(header, symbolColumn) {
+		return nil, fmt.Errorf("missing %q column", symbolColumn)
+	}
+
+	var infos []sources.SymbolInfo
+	for {
+		record, err := reader.Read()
+		if err == io.EOF {
+			return infos, nil
+		}
+		if err != nil {
+			return nil, fmt.Errorf("read row: %w", err)
+		}
+		if strings.HasPrefix(record[0], "File Creation Time") || len(record) != len(header) {
+			continue
+		}
+
+		row := make(map[string]string, len(header))
+		for i, column := range header {
+			row[column] = strings.TrimSpace(record[i])
+		}
+		if row["Test Issue"] == "Y" || row[symbolColumn] == "" {
+			continue
+		}
+
+		info := sources.SymbolInfo{
+			Symbol: DirectorySymbol(row[symbolColumn]),
+			Name:   row["Security Name"],
+			Market: market(row),
+			Type:   "EQUITY",
+		}
+		if row["ETF"] == "Y" {
+			info.Type = "ETF"
+		}
+		infos = append(infos, info)
+	}
+}
+
+// DirectorySymbol converts a Nasdaq Trader ticker to Yahoo's notation:
+// share classes use "-" instead of "." ("BRK.B" becomes "BRK-B") and
+// preferred shares "-P" instead of "$" ("ABR$D" becomes "ABR-PD").
+func DirectorySymbol(symbol string) string {
+	symbol = strings.ReplaceAll(symbol, "$", "-P")
+	return strings.ReplaceAll(symbol, ".", "-")
+}
+
+// containsColumn reports whether header contains column.
+func containsColumn(header []string, column string) bool {
+	for _, h := range header {
+		if strings.TrimSpace(h) == column {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,68 @@
+package yahoo_test
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"testing"
+
+	"github.com/julianshen/gonp-datareader/sources"
+	"github.com/julianshen/gonp-datareader/sources/yahoo"
+)
+
+const nasdaqListed = `Symbol|Security Name|Market Category|Test Issue|Financial Status|Round Lot Size|ETF|NextShares
+AAPL|Apple Inc. - Common Stock|Q|N|N|100|N|N
+QQQ|Invesco QQQ Trust, Series 1|G|N|N|100|Y|N
+ZVZZT|NASDAQ TEST STOCK|G|Y|N|100|N|N
+File Creation Time: 1016202617:31|||||||
+`
+
+const otherListed = `ACT Symbol|Security Name|Exchange|CQS Symbol|ETF|Round Lot Size|Test Issue|NASDAQ Symbol
+BRK.B|Berkshire Hathaway Inc. Class B|N|BRK.B|N|100|N|BRK.B
+ABR$D|Arbor Realty Trust Preferred Series D|N|ABRpD|N|100|N|ABR-D
+SPY|SPDR S&P 500 ETF Trust|P|SPY|Y|100|N|SPY
+File Creation Time: 1016202617:31|||||||
+`
+
+func TestYahooReader_ListSymbols(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Cookie") != "" || r.URL.Query().Get("crumb") != "" {
+			t.Errorf("directory request carries Yahoo session: %s", r.URL)
+		}
+		w.Header().Set("Content-Type", "text/plain")
+		switch r.URL.Path {
+		case "/nasdaqlisted.txt":
+			w.Write([]byte(nasdaqListed))
+		case "/otherlisted.txt":
+			w.Write([]byte(otherListed))
+		default:
+			t.Errorf("unexpected path %q", r.URL.Path)
+		}
+	}))
+	defer server.Close()
+
+	reader := yahoo.NewYahooReader(nil)
+	reader.SetDirectoryURLs(server.URL+"/nasdaqlisted.txt", server.URL+"/otherlisted.txt")
+
+	infos, err := reader.ListSymbols(context.Background())
+	if err != nil {
+		t.Fatalf("ListSymbols() error = %v", err)
+	}
+	want := []sources.SymbolInfo{
+		{Symbol: "AAPL", Name: "Apple Inc. - Common Stock", Market: "NASDAQ", Type: "EQUITY"},
+		{Symbol: "QQQ", Name: "Invesco QQQ Trust, Series 1", Market: "NASDAQ", Type: "ETF"},
+		{Symbol: "BRK-B", Name: "Berkshire Hathaway Inc. Class B", Market: "NYSE", Type: "EQUITY"},
+		{Symbol: "ABR-PD", Name: "Arbor Realty Trust Preferred Series D", Market: "NYSE", Type: "EQUITY"},
+		{Symbol: "SPY", Name: "SPDR S&P 500 ETF Trust", Market: "NYSE Arca", Type: "ETF"},
+	}
+	if !reflect.DeepEqual(infos, want) {
+		t.Errorf("ListSymbols() = %+v, want %+v", infos, want)
+	}
+}
+
+func TestParseNasdaqListed_MissingColumn(t *testing.T) {
+	if _, err := yahoo.ParseNasdaqListed([]byte("Ticker|Name\nAAPL|Apple\n")); err == nil {
+		t.Error("ParseNasdaqListed() should fail without a Symbol column")
+	}
+}
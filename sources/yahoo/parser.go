@@ -2,21 +2,82 @@ package yahoo
 
 import (
 	"encoding/csv"
-	"errors"
+	"fmt"
 	"io"
+	"time"
+
+	"github.com/julianshen/gonp-datareader/ohlcv"
+	"github.com/julianshen/gonp-datareader/sources"
 )
 
 var (
-	// ErrEmptyCSV is returned when CSV data is empty
-	ErrEmptyCSV = errors.New("CSV data is empty")
+	// ErrEmptyCSV is returned when CSV data is empty; it matches
+	// sources.ErrNoData
+	ErrEmptyCSV = fmt.Errorf("%w: CSV data is empty", sources.ErrNoData)
 )
 
+// csvRecord documents the columns of a Yahoo Finance CSV record.
+// Values are stored as strings in ParsedData.Rows; the field types describe
+// what each string represents.
+type csvRecord struct {
+	Date     time.Time
+	Open     float64
+	High     float64
+	Low      float64
+	Close    float64
+	AdjClose float64 `schema:"Adj Close"`
+	Volume   int64
+}
+
 // ParsedData represents parsed CSV data from Yahoo Finance.
 type ParsedData struct {
 	// Columns contains the column names from the CSV header
 	Columns []string
 	// Rows contains the data rows as maps from column name to value
 	Rows []map[string]string
+	// Meta holds response metadata such as "stale" when the data was
+	// served from an expired cache entry; nil when there is none.
+	Meta map[string]string
+
+	// cache holds the columns parsed by GetFloatColumn and GetTimeColumn
+	cache *sources.ColumnCache
+}
+
+// DateIndex returns the Date column parsed as dates.
+func (p *ParsedData) DateIndex() ([]time.Time, error) {
+	return p.GetTimeColumn("Date")
+}
+
+// SelectRows keeps the rows at indices, in that order (see
+// sources.RowSelector).
+func (p *ParsedData) SelectRows(indices []int) {
+	if p == nil {
+		return
+	}
+	p.Rows = sources.SelectRows(p.Rows, indices)
+	p.cache = sources.NewColumnCache()
+}
+
+// ColumnNames returns the value columns, excluding Date.
+func (p *ParsedData) ColumnNames() []string {
+	if p == nil {
+		return nil
+	}
+	return sources.ValueColumns(p.Columns, "Date")
+}
+
+// Float64Column returns the named value column as float64 values, with
+// missing values as NaN.
+func (p *ParsedData) Float64Column(name string) ([]float64, error) {
+	if name == "Date" {
+		return nil, sources.NoColumn(name)
+	}
+	return p.GetFloatColumn(name)
+}
+
+// Bars returns the price columns as ohlcv.Bars (see ohlcv.Provider).
+func (p *ParsedData) Bars() (*ohlcv.Bars, error) {
+	return ohlcv.FromTimeSeries(p)
 }
 
 // GetColumn returns all values for a given column name.
@@ -39,6 +100,34 @@ func (p *ParsedData) GetColumn(name string) []string {
 	return values
 }
 
+// GetFloatColumn returns the named column parsed as float64 values, with
+// missing values as NaN. Parsed columns are cached, so the returned slice
+// must not be modified.
+func (p *ParsedData) GetFloatColumn(name string) ([]float64, error) {
+	if p == nil {
+		return nil, fmt.Errorf("%w: %q", sources.ErrNoColumn, name)
+	}
+	return sources.FloatColumn(p.cache, p.Columns, p.Rows, name)
+}
+
+// GetTimeColumn returns the named column parsed as dates. Parsed columns
+// are cached, so the returned slice must not be modified.
+func (p *ParsedData) GetTimeColumn(name string) ([]time.Time, error) {
+	if p == nil {
+		return nil, fmt.Errorf("%w: %q", sources.ErrNoColumn, name)
+	}
+	return sources.TimeColumn(p.cache, p.Columns, p.Rows, name)
+}
+
+// ColumnInfo describes the columns of the data in order, with the value
+// type each string represents.
+func (p *ParsedData) ColumnInfo() []sources.Column {
+	if p == nil {
+		return nil
+	}
+	return sources.DescribeColumns(p.Columns, sources.NewSchema("", "", csvRecord{}))
+}
+
 // ParseCSV parses CSV data from Yahoo Finance.
 func ParseCSV(reader io.Reader) (*ParsedData, error) {
 	csvReader := csv.NewReader(reader)
@@ -54,11 +143,14 @@ func ParseCSV(reader io.Reader) (*ParsedData, error) {
 		return nil, ErrEmptyCSV
 	}
 
-	// First row is the header
+	// First row is the header; intraday bars name their column Datetime
 	header := records[0]
 	if len(header) == 0 {
 		return nil, ErrEmptyCSV
 	}
+	if header[0] == "Datetime" {
+		header[0] = "Date"
+	}
 
 	// Parse data rows
 	rows := make([]map[string]string, 0, len(records)-1)
@@ -79,5 +171,6 @@ func ParseCSV(reader io.Reader) (*ParsedData, error) {
 	return &ParsedData{
 		Columns: header,
 		Rows:    rows,
+		cache:   sources.NewColumnCache(),
 	}, nil
 }
@@ -1,9 +1,12 @@
 package yahoo_test
 
 import (
+	"math"
 	"strings"
 	"testing"
+	"time"
 
+	"github.com/julianshen/gonp-datareader/sources"
 	"github.com/julianshen/gonp-datareader/sources/yahoo"
 )
 
@@ -42,6 +45,23 @@ func TestParseCSV(t *testing.T) {
 	}
 }
 
+func TestParseCSV_Intraday(t *testing.T) {
+	csv := "Datetime,Open,High,Low,Close,Adj Close,Volume\n" +
+		"2024-01-05 09:30:00-05:00,181.99,182.76,181.50,182.10,182.10,5000000\n"
+
+	data, err := yahoo.ParseCSV(strings.NewReader(csv))
+	if err != nil {
+		t.Fatalf("ParseCSV() error = %v", err)
+	}
+	dates, err := data.DateIndex()
+	if err != nil {
+		t.Fatalf("DateIndex() error = %v", err)
+	}
+	if want := time.Date(2024, 1, 5, 14, 30, 0, 0, time.UTC); !dates[0].Equal(want) {
+		t.Errorf("DateIndex()[0] = %v, want %v", dates[0], want)
+	}
+}
+
 func TestParseCSV_EmptyData(t *testing.T) {
 	csvData := ``
 
@@ -215,3 +235,34 @@ func BenchmarkGetColumn(b *testing.B) {
 		_ = result.GetColumn("Close")
 	}
 }
+
+func TestParsedData_TypedColumns(t *testing.T) {
+	data, err := yahoo.ParseCSV(strings.NewReader("Date,Open,High,Low,Close,Adj Close,Volume\n" +
+		"2020-01-02,296.24,300.60,295.19,300.35,297.45,33911900\n" +
+		"2020-01-03,null,null,null,null,null,null\n"))
+	if err != nil {
+		t.Fatalf("ParseCSV() error = %v", err)
+	}
+
+	closes, err := data.GetFloatColumn("Close")
+	if err != nil {
+		t.Fatalf("GetFloatColumn() error = %v", err)
+	}
+	if len(closes) != 2 || closes[0] != 300.35 || !math.IsNaN(closes[1]) {
+		t.Errorf("GetFloatColumn(Close) = %v", closes)
+	}
+
+	dates, err := data.GetTimeColumn("Date")
+	if err != nil {
+		t.Fatalf("GetTimeColumn() error = %v", err)
+	}
+	if dates[1] != time.Date(2020, 1, 3, 0, 0, 0, 0, time.UTC) {
+		t.Errorf("GetTimeColumn(Date)[1] = %v", dates[1])
+	}
+
+	info := data.ColumnInfo()
+	if len(info) != 7 || info[0].Type != sources.ColumnTypeTime || info[5].Name != "Adj Close" ||
+		info[5].Type != sources.ColumnTypeFloat || info[6].Type != sources.ColumnTypeInt {
+		t.Errorf("ColumnInfo() = %+v", info)
+	}
+}
@@ -0,0 +1,149 @@
+package yahoo
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+
+	internalhttp "github.com/julianshen/gonp-datareader/internal/http"
+	"github.com/julianshen/gonp-datareader/internal/utils"
+	"github.com/julianshen/gonp-datareader/sources"
+)
+
+const (
+	// yahooQuoteURL is the batch quote endpoint; %s is a comma-separated symbol list
+	yahooQuoteURL = "https://query1.finance.yahoo.com/v7/finance/quote?symbols=%s"
+
+	// MaxQuoteBatch is the number of symbols requested per quote call.
+	// Yahoo rejects or truncates longer lists, so ReadQuotes splits
+	// larger requests into batches of this size.
+	MaxQuoteBatch = 200
+)
+
+// Quote is a real-time (or delayed) quote from Yahoo's quote endpoint.
+// Fields Yahoo omits for a symbol are zero.
+type Quote struct {
+	Symbol            string  `json:"symbol"`
+	ShortName         string  `json:"shortName"`
+	Currency          string  `json:"currency"`
+	MarketState       string  `json:"marketState"`
+	Price             float64 `json:"regularMarketPrice"`
+	Change            float64 `json:"regularMarketChange"`
+	ChangePercent     float64 `json:"regularMarketChangePercent"`
+	Open              float64 `json:"regularMarketOpen"`
+	DayHigh           float64 `json:"regularMarketDayHigh"`
+	DayLow            float64 `json:"regularMarketDayLow"`
+	PreviousClose     float64 `json:"regularMarketPreviousClose"`
+	Volume            float64 `json:"regularMarketVolume"`
+	RegularMarketTime int64   `json:"regularMarketTime"` // Unix seconds
+	GMTOffsetMillis   int64   `json:"gmtOffSetMilliseconds"`
+}
+
+// SetQuoteURL overrides the quote endpoint (a format string taking the
+// comma-separated symbol list) and the batch size. A batchSize of zero
+// keeps MaxQuoteBatch. This is primarily used for testing with mock servers.
+func (y *YahooReader) SetQuoteURL(quoteURL string, batchSize int) {
+	y.quoteURL = quoteURL
+	if batchSize > 0 {
+		y.quoteBatch = batchSize
+	}
+}
+
+// ReadQuotes fetches current quotes for any number of symbols. Lists
+// longer than MaxQuoteBatch are split into batches, fetched in order and
+// reassembled, so callers need not know Yahoo's cap. Symbols Yahoo does
+// not recognise are absent from the result.
+//
+// # Example Usage
+//
+//	quotes, err := yahoo.NewYahooReader(nil).ReadQuotes(ctx, symbols)
+//	if err != nil {
+//		log.Fatal(err)
+//	}
+//	fmt.Println(quotes["AAPL"].Price)
+func (y *YahooReader) ReadQuotes(ctx context.Context, symbols []string) (map[string]*Quote, error) {
+	symbols = y.NormalizeSymbols(symbols)
+	if err := utils.ValidateSymbols(symbols); err != nil {
+		return nil, fmt.Errorf("invalid symbols: %w", err)
+	}
+
+	return sources.ReadChunked(ctx, symbols, y.quoteBatch, y.readQuoteBatch)
+}
+
+// readQuoteBatch fetches one batch of quotes.
+func (y *YahooReader) readQuoteBatch(ctx context.Context, symbols []string) (map[string]*Quote, error) {
+	escaped := make([]string, len(symbols))
+	for i, s := range symbols {
+		escaped[i] = url.QueryEscape(s)
+	}
+
+	body, _, err := y.get(ctx, fmt.Sprintf(y.quoteURL, strings.Join(escaped, ",")), "application/json")
+	if err != nil {
+		return nil, err
+	}
+
+	quotes, err := ParseQuotes(body)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse quotes: %w", err)
+	}
+	return quotes, nil
+}
+
+// ParseQuotes parses a response of Yahoo's quote endpoint into quotes
+// keyed by symbol.
+func ParseQuotes(body []byte) (map[string]*Quote, error) {
+	var response struct {
+		QuoteResponse struct {
+			Result []*Quote `json:"result"`
+			Error  *struct {
+				Description string `json:"description"`
+			} `json:"error"`
+		} `json:"quoteResponse"`
+	}
+	if err := json.Unmarshal(body, &response); err != nil {
+		return nil, fmt.Errorf("unmarshal JSON: %w", err)
+	}
+	if e := response.QuoteResponse.Error; e != nil {
+		return nil, fmt.Errorf("yahoo finance error: %s", e.Description)
+	}
+
+	quotes := make(map[string]*Quote, len(response.QuoteResponse.Result))
+	for _, q := range response.QuoteResponse.Result {
+		if q != nil && q.Symbol != "" {
+			quotes[q.Symbol] = q
+		}
+	}
+	return quotes, nil
+}
+
+// get fetches url, refreshing the session cookie and crumb and retrying
+// once on the alternate host when Yahoo rejects the request, and returns
+// the body of a 200 response of an accepted media type with its
+// stale-cache metadata.
+func (y *YahooReader) get(ctx context.Context, url string, accept ...string) ([]byte, map[string]string, error) {
+	resp, body, meta, err := y.fetch(ctx, url, accept...)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	// Refresh the cookie/crumb pair and retry once on the alternate host
+	if isAuthFailure(resp.StatusCode, body) {
+		if err := y.refreshCrumb(ctx); err != nil {
+			return nil, nil, fmt.Errorf("yahoo finance returned status %d and crumb refresh failed: %w: %w", resp.StatusCode, sources.ErrAuthRequired, err)
+		}
+		resp, body, meta, err = y.fetch(ctx, alternateHost(url), accept...)
+		if err != nil {
+			return nil, nil, err
+		}
+	}
+
+	// Check status code
+	if resp.StatusCode != http.StatusOK {
+		return nil, nil, internalhttp.StatusError(resp, fmt.Errorf("yahoo finance returned status %d: %s", resp.StatusCode, string(body)))
+	}
+
+	return body, meta, nil
+}
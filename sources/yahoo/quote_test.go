@@ -0,0 +1,76 @@
+package yahoo_test
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+
+	"github.com/julianshen/gonp-datareader/sources/yahoo"
+)
+
+func TestReadQuotes_SplitsBatches(t *testing.T) {
+	var requests atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests.Add(1)
+		symbols := strings.Split(r.URL.Query().Get("symbols"), ",")
+		if len(symbols) > 2 {
+			http.Error(w, "too many symbols", http.StatusBadRequest)
+			return
+		}
+
+		var results []string
+		for _, s := range symbols {
+			if s == "NOPE" {
+				continue
+			}
+			results = append(results, fmt.Sprintf(`{"symbol":%q,"regularMarketPrice":%d.5}`, s, len(s)))
+		}
+		fmt.Fprintf(w, `{"quoteResponse":{"result":[%s],"error":null}}`, strings.Join(results, ","))
+	}))
+	defer server.Close()
+
+	reader := yahoo.NewYahooReader(nil)
+	reader.SetQuoteURL(server.URL+"?symbols=%s", 2)
+
+	quotes, err := reader.ReadQuotes(context.Background(), []string{"AAPL", "MSFT", "IBM", "NOPE", "BRK-B"})
+	if err != nil {
+		t.Fatalf("ReadQuotes() error = %v", err)
+	}
+
+	if got := requests.Load(); got != 3 {
+		t.Errorf("requests = %d, want 3", got)
+	}
+	if len(quotes) != 4 {
+		t.Errorf("len(quotes) = %d, want 4", len(quotes))
+	}
+	if q := quotes["BRK-B"]; q == nil || q.Price != 5.5 {
+		t.Errorf("quotes[BRK-B] = %+v, want Price 5.5", q)
+	}
+	if _, ok := quotes["NOPE"]; ok {
+		t.Error("unknown symbol should be absent")
+	}
+}
+
+func TestParseQuotes(t *testing.T) {
+	body := `{"quoteResponse":{"result":[{"symbol":"AAPL","shortName":"Apple Inc.","currency":"USD",
+		"regularMarketPrice":190.5,"regularMarketChange":-1.25,"regularMarketVolume":51234567,
+		"regularMarketTime":1704229200}],"error":null}}`
+
+	quotes, err := yahoo.ParseQuotes([]byte(body))
+	if err != nil {
+		t.Fatalf("ParseQuotes() error = %v", err)
+	}
+	q := quotes["AAPL"]
+	if q == nil || q.ShortName != "Apple Inc." || q.Price != 190.5 || q.Change != -1.25 ||
+		q.Volume != 51234567 || q.RegularMarketTime != 1704229200 {
+		t.Errorf("quotes[AAPL] = %+v", q)
+	}
+
+	if _, err := yahoo.ParseQuotes([]byte(`{"quoteResponse":{"result":[],"error":{"description":"Missing symbols"}}}`)); err == nil {
+		t.Error("ParseQuotes() expected error for error response")
+	}
+}
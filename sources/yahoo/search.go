@@ -0,0 +1,86 @@
+package yahoo
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/url"
+
+	"github.com/julianshen/gonp-datareader/sources"
+)
+
+// yahooSearchURL is the autocomplete endpoint; %s is the query
+const yahooSearchURL = "https://query2.finance.yahoo.com/v1/finance/search?q=%s&quotesCount=25&newsCount=0"
+
+// SetSearchURL overrides the search endpoint, a format string taking the
+// escaped query. This is primarily used for testing with mock servers.
+func (y *YahooReader) SetSearchURL(searchURL string) {
+	y.searchURL = searchURL
+}
+
+// SearchSymbols looks up tickers by company name or keyword through
+// Yahoo's autocomplete endpoint, returning up to 25 matches across
+// exchanges.
+//
+// # Example Usage
+//
+//	matches, err := yahoo.NewYahooReader(nil).SearchSymbols(ctx, "apple")
+//	fmt.Println(matches[0].Symbol, matches[0].Name) // AAPL Apple Inc.
+func (y *YahooReader) SearchSymbols(ctx context.Context, query string) ([]sources.SymbolInfo, error) {
+	query, err := sources.CheckQuery(query)
+	if err != nil {
+		return nil, err
+	}
+
+	body, _, err := y.get(ctx, fmt.Sprintf(y.searchURL, url.QueryEscape(query)), "application/json")
+	if err != nil {
+		return nil, err
+	}
+
+	matches, err := ParseSearch(body)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse search results: %w", err)
+	}
+	return matches, nil
+}
+
+// ParseSearch parses a response of Yahoo's search endpoint. Entries
+// without a symbol, such as news, are skipped.
+func ParseSearch(body []byte) ([]sources.SymbolInfo, error) {
+	var response struct {
+		Quotes []struct {
+			Symbol    string `json:"symbol"`
+			ShortName string `json:"shortname"`
+			LongName  string `json:"longname"`
+			QuoteType string `json:"quoteType"`
+			Exchange  string `json:"exchange"`
+			ExchDisp  string `json:"exchDisp"`
+			Industry  string `json:"industry"`
+		} `json:"quotes"`
+	}
+	if err := json.Unmarshal(body, &response); err != nil {
+		return nil, fmt.Errorf("unmarshal JSON: %w", err)
+	}
+
+	matches := make([]sources.SymbolInfo, 0, len(response.Quotes))
+	for _, q := range response.Quotes {
+		if q.Symbol == "" {
+			continue
+		}
+		info := sources.SymbolInfo{
+			Symbol:   q.Symbol,
+			Name:     q.LongName,
+			Market:   q.ExchDisp,
+			Industry: q.Industry,
+			Type:     q.QuoteType,
+		}
+		if info.Name == "" {
+			info.Name = q.ShortName
+		}
+		if info.Market == "" {
+			info.Market = q.Exchange
+		}
+		matches = append(matches, info)
+	}
+	return matches, nil
+}
@@ -0,0 +1,42 @@
+package yahoo_test
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"testing"
+
+	"github.com/julianshen/gonp-datareader/sources"
+	"github.com/julianshen/gonp-datareader/sources/yahoo"
+)
+
+func TestYahooReader_SearchSymbols(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if got := r.URL.Query().Get("q"); got != "apple inc" {
+			t.Errorf("q = %q, want %q", got, "apple inc")
+		}
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"quotes":[
+			{"exchange":"NMS","shortname":"Apple Inc.","quoteType":"EQUITY","symbol":"AAPL","longname":"Apple Inc.","exchDisp":"NASDAQ","industry":"Consumer Electronics"},
+			{"exchange":"NEO","shortname":"APPLE CDR","quoteType":"EQUITY","symbol":"AAPL.NE"},
+			{"type":"news"}
+		],"news":[]}`))
+	}))
+	defer server.Close()
+
+	reader := yahoo.NewYahooReader(nil)
+	reader.SetSearchURL(server.URL + "?q=%s")
+
+	matches, err := reader.SearchSymbols(context.Background(), "apple inc")
+	if err != nil {
+		t.Fatalf("SearchSymbols() error = %v", err)
+	}
+	want := []sources.SymbolInfo{
+		{Symbol: "AAPL", Name: "Apple Inc.", Market: "NASDAQ", Industry: "Consumer Electronics", Type: "EQUITY"},
+		{Symbol: "AAPL.NE", Name: "APPLE CDR", Market: "NEO", Type: "EQUITY"},
+	}
+	if !reflect.DeepEqual(matches, want) {
+		t.Errorf("SearchSymbols() = %+v, want %+v", matches, want)
+	}
+}
@@ -2,10 +2,12 @@
 package yahoo
 
 import (
+	"bytes"
 	"context"
 	"fmt"
 	"io"
 	"net/http"
+	"sync"
 	"time"
 
 	internalhttp "github.com/julianshen/gonp-datareader/internal/http"
@@ -21,8 +23,36 @@ const (
 // YahooReader fetches data from Yahoo Finance.
 type YahooReader struct {
 	*sources.BaseSource
-	client  *internalhttp.RetryableClient
-	baseURL string
+	client     *internalhttp.RetryableClient
+	authClient *http.Client
+	userAgent  string
+	baseURL    string
+
+	// quoteURL and quoteBatch configure ReadQuotes
+	quoteURL   string
+	quoteBatch int
+
+	// interval is the bar interval; see SetInterval
+	interval sources.Interval
+
+	// adjustPrices selects split- and dividend-adjusted prices; see
+	// SetAdjustPrices
+	adjustPrices bool
+
+	// searchURL is the endpoint of SearchSymbols
+	searchURL string
+
+	// nasdaqListedURL and otherListedURL are the directory files of
+	// ListSymbols
+	nasdaqListedURL string
+	otherListedURL  string
+
+	// mu guards the session cookie/crumb pair and the auth endpoints
+	mu        sync.Mutex
+	cookieURL string
+	crumbURL  string
+	cookie    string
+	crumb     string
 }
 
 // NewYahooReader creates a new Yahoo Finance data reader.
@@ -38,9 +68,18 @@ func NewYahooReaderWithBaseURL(opts *internalhttp.ClientOptions, baseURL string)
 	}
 
 	return &YahooReader{
-		BaseSource: sources.NewBaseSource("yahoo"),
-		client:     internalhttp.NewRetryableClient(opts),
-		baseURL:    baseURL,
+		BaseSource:      sources.NewBaseSource("yahoo"),
+		client:          internalhttp.NewRetryableClient(opts),
+		authClient:      internalhttp.NewHTTPClient(opts),
+		userAgent:       opts.UserAgent,
+		baseURL:         baseURL,
+		quoteURL:        yahooQuoteURL,
+		quoteBatch:      MaxQuoteBatch,
+		searchURL:       yahooSearchURL,
+		nasdaqListedURL: nasdaqListedURL,
+		otherListedURL:  otherListedURL,
+		cookieURL:       yahooCookieURL,
+		crumbURL:        yahooCrumbURL,
 	}
 }
 
@@ -49,6 +88,38 @@ func (y *YahooReader) Name() string {
 	return "Yahoo Finance"
 }
 
+// Schema returns the columns of the ParsedData returned by ReadSingle.
+func (y *YahooReader) Schema() sources.Schema {
+	return sources.NewSchema(y.Source(), y.Name(), csvRecord{})
+}
+
+// Intervals lists the bar intervals Yahoo Finance serves. Intraday bars
+// reach back 7 days (1m) to 730 days (1h).
+var Intervals = []sources.Interval{
+	sources.Interval1m, sources.Interval5m, sources.Interval15m, sources.Interval30m,
+	sources.Interval1h, sources.Interval1d, sources.Interval1wk, sources.Interval1mo,
+}
+
+// SetInterval sets the bar interval of ReadSingle and Read; empty means
+// daily. Intervals not in Intervals return an error matching
+// sources.ErrUnsupportedInterval.
+func (y *YahooReader) SetInterval(interval sources.Interval) error {
+	if err := sources.CheckInterval(interval, Intervals); err != nil {
+		return err
+	}
+	y.interval = interval
+	return nil
+}
+
+// SetAdjustPrices selects split- and dividend-adjusted prices. When
+// enabled, ReadSingle scales Open, High and Low by the ratio of Adj Close
+// to Close and replaces Close with Adj Close, matching Yahoo's auto-adjust;
+// Volume and the Adj Close column are unchanged. Meta["adjusted"] is set
+// to "true".
+func (y *YahooReader) SetAdjustPrices(enabled bool) {
+	y.adjustPrices = enabled
+}
+
 // BuildURL constructs the Yahoo Finance API URL for the given symbol and date range.
 func (y *YahooReader) BuildURL(symbol string, start, end time.Time) string {
 	baseURL := fmt.Sprintf(y.baseURL, symbol)
@@ -58,14 +129,27 @@ func (y *YahooReader) BuildURL(symbol string, start, end time.Time) string {
 	period2 := end.Unix()
 
 	// Build query parameters
-	url := fmt.Sprintf("%s?period1=%d&period2=%d&interval=1d&events=history&includeAdjustedClose=true",
-		baseURL, period1, period2)
+	url := fmt.Sprintf("%s?period1=%d&period2=%d&interval=%s&events=history&includeAdjustedClose=true",
+		baseURL, period1, period2, y.interval.OrDefault())
 
 	return url
 }
 
 // ReadSingle fetches data for a single symbol from Yahoo Finance.
+//
+// If Yahoo rejects the request with 401 or an "Invalid Crumb" error, the
+// session cookie and crumb are refreshed and the request is retried once
+// against the alternate query host before the error is surfaced.
 func (y *YahooReader) ReadSingle(ctx context.Context, symbol string, start, end time.Time) (interface{}, error) {
+	return y.Finish(ctx, symbol, start, end, y.readSingle)
+}
+
+// readSingle implements ReadSingle before Finish is applied.
+func (y *YahooReader) readSingle(ctx context.Context, symbol string, start, end time.Time) (interface{}, error) {
+	// Normalize user input; the original is kept in Meta["symbol_input"]
+	input := symbol
+	symbol = y.NormalizeSymbol(symbol)
+
 	// Validate inputs
 	if err := y.ValidateSymbol(symbol); err != nil {
 		return nil, fmt.Errorf("invalid symbol: %w", err)
@@ -78,42 +162,64 @@ func (y *YahooReader) ReadSingle(ctx context.Context, symbol string, start, end
 	// Build URL
 	url := y.BuildURL(symbol, start, end)
 
+	body, meta, err := y.get(ctx, url, "text/csv")
+	if err != nil {
+		return nil, err
+	}
+
+	// Parse CSV response
+	decoded, err := y.client.Decode("yahoo", body, func(b []byte) (interface{}, error) {
+		return ParseCSV(bytes.NewReader(b))
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse CSV: %w", err)
+	}
+
+	// Copy the decoded value, which may be shared through the decoded cache,
+	// and flag data served from an expired cache entry
+	data := *decoded.(*ParsedData)
+	data.Meta = sources.InputMeta(meta, input, symbol)
+	if y.adjustPrices {
+		data.Rows = sources.AdjustedRows(data.Rows)
+		data.cache = sources.NewColumnCache()
+		data.Meta = sources.AdjustedMeta(data.Meta)
+	}
+
+	return &data, nil
+}
+
+// fetch performs a GET request with the current session cookie and crumb,
+// returning the response, whose body is already closed, the body and
+// stale-cache metadata. Successful responses must be of one of the
+// accepted media types.
+func (y *YahooReader) fetch(ctx context.Context, url string, accept ...string) (*http.Response, []byte, map[string]string, error) {
 	// Create HTTP request
-	req, err := http.NewRequestWithContext(ctx, "GET", url, nil)
+	req, err := http.NewRequestWithContext(internalhttp.WithContentType(ctx, accept...), "GET", y.withCrumb(url), nil)
 	if err != nil {
-		return nil, fmt.Errorf("failed to create request: %w", err)
+		return nil, nil, nil, fmt.Errorf("failed to create request: %w", err)
 	}
+	y.applyCookie(req)
 
 	// Execute request
 	resp, err := y.client.Do(req)
 	if err != nil {
-		return nil, fmt.Errorf("failed to fetch data: %w", err)
+		return nil, nil, nil, fmt.Errorf("failed to fetch data: %w", err)
 	}
 	defer resp.Body.Close()
 
-	// Check status code
-	if resp.StatusCode != http.StatusOK {
-		body, err := io.ReadAll(resp.Body)
-		if err != nil {
-			return nil, fmt.Errorf("yahoo finance returned status %d (failed to read response body: %w)", resp.StatusCode, err)
-		}
-		return nil, fmt.Errorf("yahoo finance returned status %d: %s", resp.StatusCode, string(body))
-	}
-
-	// Parse CSV response
-	data, err := ParseCSV(resp.Body)
+	body, err := io.ReadAll(resp.Body)
 	if err != nil {
-		return nil, fmt.Errorf("failed to parse CSV: %w", err)
+		return nil, nil, nil, fmt.Errorf("yahoo finance returned status %d (failed to read response body: %w)", resp.StatusCode, err)
 	}
 
-	return data, nil
+	return resp, body, internalhttp.StaleMeta(resp), nil
 }
 
 // Read fetches data for multiple symbols from Yahoo Finance.
 // Symbols are fetched in parallel for better performance.
 func (y *YahooReader) Read(ctx context.Context, symbols []string, start, end time.Time) (interface{}, error) {
 	// Validate inputs
-	if err := utils.ValidateSymbols(symbols); err != nil {
+	if err := utils.ValidateSymbols(y.NormalizeSymbols(symbols)); err != nil {
 		return nil, fmt.Errorf("invalid symbols: %w", err)
 	}
 
@@ -181,3 +287,14 @@ func (y *YahooReader) readParallel(ctx context.Context, symbols []string, start,
 
 	return dataMap, nil
 }
+
+// Shutdown stops accepting requests and waits for in-flight requests to
+// finish, cancelling them if ctx expires first.
+func (y *YahooReader) Shutdown(ctx context.Context) error {
+	return y.client.Shutdown(ctx)
+}
+
+// Close cancels in-flight requests and releases the reader's connections.
+func (y *YahooReader) Close() error {
+	return y.client.Close()
+}
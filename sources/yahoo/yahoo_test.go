@@ -2,11 +2,13 @@ package yahoo_test
 
 import (
 	"context"
+	"errors"
 	"net/http"
 	"net/http/httptest"
 	"testing"
 	"time"
 
+	"github.com/julianshen/gonp-datareader/sources"
 	"github.com/julianshen/gonp-datareader/sources/yahoo"
 )
 
@@ -123,6 +125,42 @@ func TestYahooReader_BuildURL_Timestamps(t *testing.T) {
 	}
 }
 
+func TestYahooReader_SetInterval(t *testing.T) {
+	start := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	end := time.Date(2024, 1, 31, 0, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		interval sources.Interval
+		want     string
+		wantErr  bool
+	}{
+		{"", "interval=1d", false},
+		{sources.Interval1wk, "interval=1wk", false},
+		{sources.Interval1mo, "interval=1mo", false},
+		{sources.Interval1h, "interval=1h", false},
+		{"2h", "", true},
+	}
+
+	for _, tt := range tests {
+		t.Run(string(tt.interval), func(t *testing.T) {
+			reader := yahoo.NewYahooReader(nil)
+			err := reader.SetInterval(tt.interval)
+			if tt.wantErr {
+				if !errors.Is(err, sources.ErrUnsupportedInterval) {
+					t.Errorf("SetInterval(%q) error = %v, want ErrUnsupportedInterval", tt.interval, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("SetInterval(%q) error = %v", tt.interval, err)
+			}
+			if url := reader.BuildURL("AAPL", start, end); !contains(url, tt.want) {
+				t.Errorf("BuildURL() = %s, want %s", url, tt.want)
+			}
+		})
+	}
+}
+
 func TestYahooReader_Read(t *testing.T) {
 	reader := yahoo.NewYahooReader(nil)
 
@@ -198,6 +236,47 @@ func TestYahooReader_ReadSingle_WithMockServer(t *testing.T) {
 	}
 }
 
+func TestYahooReader_SetAdjustPrices(t *testing.T) {
+	csvData := `Date,Open,High,Low,Close,Adj Close,Volume
+2020-01-02,300,310,290,300,150,33911900`
+
+	server := createMockYahooServer(csvData)
+	defer server.Close()
+
+	start := time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC)
+	end := time.Date(2020, 1, 31, 0, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		adjust    bool
+		want      map[string]string
+		wantClose float64
+	}{
+		{false, map[string]string{"Open": "300", "High": "310", "Low": "290", "Close": "300", "Adj Close": "150", "Volume": "33911900"}, 300},
+		{true, map[string]string{"Open": "150", "High": "155", "Low": "145", "Close": "150", "Adj Close": "150", "Volume": "33911900"}, 150},
+	}
+	for _, tt := range tests {
+		reader := yahoo.NewYahooReaderWithBaseURL(nil, server.URL+"/%s")
+		reader.SetAdjustPrices(tt.adjust)
+
+		result, err := reader.ReadSingle(context.Background(), "AAPL", start, end)
+		if err != nil {
+			t.Fatalf("ReadSingle() error = %v", err)
+		}
+		data := result.(*yahoo.ParsedData)
+		for name, want := range tt.want {
+			if got := data.Rows[0][name]; got != want {
+				t.Errorf("adjust=%v: %s = %q, want %q", tt.adjust, name, got, want)
+			}
+		}
+		if closes, err := data.GetFloatColumn("Close"); err != nil || closes[0] != tt.wantClose {
+			t.Errorf("adjust=%v: GetFloatColumn(Close) = %v, %v", tt.adjust, closes, err)
+		}
+		if got := data.Meta["adjusted"] == "true"; got != tt.adjust {
+			t.Errorf("adjust=%v: Meta = %v", tt.adjust, data.Meta)
+		}
+	}
+}
+
 func TestYahooReader_ReadSingle_InvalidSymbol(t *testing.T) {
 	reader := yahoo.NewYahooReader(nil)
 
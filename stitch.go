@@ -0,0 +1,57 @@
+package datareader
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/julianshen/gonp-datareader/dataset"
+	"github.com/julianshen/gonp-datareader/sources"
+	"github.com/julianshen/gonp-datareader/tickers"
+)
+
+// readStitched reads the history of symbol across ticker changes: each
+// ticker the company traded under is fetched for its part of [start, end]
+// and the parts are concatenated into one series labelled with the
+// current ticker. Meta["stitched"] lists the tickers used, oldest first.
+func readStitched(ctx context.Context, symbol, source string, start, end time.Time, opts *Options) (*dataset.Dataset, error) {
+	history := opts.TickerHistory
+	if history == nil {
+		history = tickers.Bundled()
+	}
+
+	current := history.Current(sources.NewBaseSource(source).NormalizeSymbol(symbol))
+	var parts []*dataset.Dataset
+	var used []string
+	for _, seg := range history.Lineage(current) {
+		from, to := start, end
+		if !seg.From.IsZero() && seg.From.After(from) {
+			from = seg.From
+		}
+		if !seg.To.IsZero() && !seg.To.After(to) {
+			to = seg.To.AddDate(0, 0, -1)
+		}
+		if to.Before(from) {
+			continue
+		}
+
+		ds, err := readDataset(ctx, seg.Symbol, source, from, to, opts)
+		if err != nil {
+			return nil, fmt.Errorf("stitch %s: %w", seg.Symbol, err)
+		}
+		// Not every source filters by date, so trim to the segment
+		parts = append(parts, ds.BetweenDays(from, to))
+		used = append(used, seg.Symbol)
+	}
+
+	stitched, err := dataset.Concat(parts...)
+	if err != nil {
+		return nil, fmt.Errorf("stitch %s: %w", current, err)
+	}
+	stitched.Symbol = current
+	if len(used) > 1 {
+		stitched.Meta["stitched"] = strings.Join(used, ",")
+	}
+	return stitched, nil
+}
@@ -0,0 +1,62 @@
+package datareader_test
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	datareader "github.com/julianshen/gonp-datareader"
+	"github.com/julianshen/gonp-datareader/tickers"
+)
+
+func TestReadDataset_StitchRenames(t *testing.T) {
+	// Stooq returns each ticker's whole history regardless of the range
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Query().Get("s") {
+		case "FB":
+			w.Write([]byte("Date,Open,High,Low,Close,Volume\n2022-06-07,1,1,1,195,10\n2022-06-08,1,1,1,196,10\n2022-06-09,1,1,1,999,10\n"))
+		case "META":
+			w.Write([]byte("Date,Open,High,Low,Close,Volume\n2022-06-09,1,1,1,197,10\n2022-06-10,1,1,1,175,10\n"))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+
+	opts := &datareader.Options{
+		Environment:     datareader.EnvironmentSandbox,
+		SandboxBaseURLs: map[string]string{"stooq": server.URL + "?s=%s"},
+		StitchRenames:   true,
+		TickerHistory: tickers.NewHistory(tickers.Change{
+			Old: "FB", New: "META", Date: time.Date(2022, 6, 9, 0, 0, 0, 0, time.UTC),
+		}),
+	}
+	start := time.Date(2022, 6, 1, 0, 0, 0, 0, time.UTC)
+	end := time.Date(2022, 6, 30, 0, 0, 0, 0, time.UTC)
+
+	for _, symbol := range []string{"META", "FB"} {
+		t.Run(symbol, func(t *testing.T) {
+			ds, err := datareader.ReadDataset(context.Background(), symbol, "stooq", start, end, opts)
+			if err != nil {
+				t.Fatalf("ReadDataset() error = %v", err)
+			}
+
+			closes, _ := ds.Column("Close")
+			want := []float64{195, 196, 197, 175}
+			if len(closes) != len(want) {
+				t.Fatalf("Close = %v, want %v", closes, want)
+			}
+			for i := range want {
+				if closes[i] != want[i] {
+					t.Errorf("Close = %v, want %v", closes, want)
+					break
+				}
+			}
+			if ds.Symbol != "META" || ds.Meta["stitched"] != "FB,META" {
+				t.Errorf("Symbol = %q, Meta = %v", ds.Symbol, ds.Meta)
+			}
+		})
+	}
+}
@@ -0,0 +1,287 @@
+// Package symbols converts between canonical tickers and the symbol
+// formats of each source, so multi-source code can name an instrument
+// once: "AAPL" is "AAPL" on Yahoo and "AAPL.US" on Stooq, "2330" is
+// "2330.TW" on Yahoo and "2330" on TWSE.
+//
+// A canonical symbol is a ticker with an optional market prefix,
+// "MARKET:TICKER" (e.g., "JP:7203", "TWO:6488"). Without a prefix, tickers
+// of digits are Taiwan Stock Exchange codes and others US tickers. Share
+// classes use a dot ("BRK.B") and are spelled as each source expects.
+//
+// Sources without ticker symbols (e.g., FRED series IDs) and sources the
+// package has no rules for pass symbols through unchanged.
+//
+// # Example Usage
+//
+//	symbols.ToSource("stooq", "AAPL")     // "AAPL.US"
+//	symbols.ToSource("yahoo", "2330")     // "2330.TW"
+//	symbols.ToSource("yahoo", "BRK.B")    // "BRK-B"
+//	symbols.FromSource("yahoo", "7203.T") // "JP:7203"
+//
+//	composite := datareader.NewCompositeReader(yahooReader, stooqReader)
+//	composite.SetSymbols(symbols.Default)
+package symbols
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"sync"
+)
+
+var (
+	// ErrInvalidSymbol is returned for empty symbols and unknown market
+	// prefixes.
+	ErrInvalidSymbol = errors.New("invalid symbol")
+	// ErrUnsupportedMarket is returned when a source does not cover the
+	// market of a symbol (e.g., Taiwan stocks on Stooq).
+	ErrUnsupportedMarket = errors.New("market not supported by source")
+)
+
+// Market is the market a ticker trades on.
+type Market string
+
+const (
+	// US covers NYSE, Nasdaq and other US exchanges.
+	US Market = "US"
+	// TW is the Taiwan Stock Exchange.
+	TW Market = "TW"
+	// TWO is the Taipei Exchange (TPEx, over-the-counter).
+	TWO Market = "TWO"
+	// JP is the Tokyo Stock Exchange.
+	JP Market = "JP"
+	// HK is the Hong Kong Stock Exchange.
+	HK Market = "HK"
+	// UK is the London Stock Exchange.
+	UK Market = "UK"
+	// DE is the Xetra/Frankfurt exchange.
+	DE Market = "DE"
+)
+
+// Markets lists the known markets.
+var Markets = []Market{US, TW, TWO, JP, HK, UK, DE}
+
+// Symbol is a parsed canonical symbol.
+type Symbol struct {
+	Ticker string
+	Market Market
+}
+
+// Parse parses a canonical symbol, upper-casing it.
+func Parse(canonical string) (Symbol, error) {
+	s := strings.ToUpper(strings.TrimSpace(canonical))
+	var sym Symbol
+	if market, ticker, ok := strings.Cut(s, ":"); ok {
+		sym = Symbol{Ticker: ticker, Market: Market(market)}
+		if !knownMarket(sym.Market) {
+			return Symbol{}, fmt.Errorf("%w: unknown market %q in %q", ErrInvalidSymbol, market, canonical)
+		}
+	} else {
+		sym = Symbol{Ticker: s, Market: defaultMarket(s)}
+	}
+	if sym.Ticker == "" {
+		return Symbol{}, fmt.Errorf("%w: empty ticker in %q", ErrInvalidSymbol, canonical)
+	}
+	return sym, nil
+}
+
+// String returns the canonical form of s, without the market prefix when
+// it is the default for the ticker.
+func (s Symbol) String() string {
+	if s.Market == defaultMarket(s.Ticker) {
+		return s.Ticker
+	}
+	return string(s.Market) + ":" + s.Ticker
+}
+
+// defaultMarket returns TW for tickers of digits and US otherwise.
+func defaultMarket(ticker string) Market {
+	if ticker == "" {
+		return US
+	}
+	for _, r := range ticker {
+		if r < '0' || r > '9' {
+			return US
+		}
+	}
+	return TW
+}
+
+// knownMarket reports whether m is in Markets.
+func knownMarket(m Market) bool {
+	for _, known := range Markets {
+		if m == known {
+			return true
+		}
+	}
+	return false
+}
+
+// suffix is the symbol suffix a source uses for a market.
+type suffix struct {
+	market Market
+	suffix string
+}
+
+// rule describes the symbol format of a source.
+type rule struct {
+	// suffixes lists the markets the source covers, longest suffix
+	// first so ".TWO" is tried before ".TW"; a market with an empty
+	// suffix is matched last
+	suffixes []suffix
+	// classSep replaces the dot of share classes ("BRK.B")
+	classSep string
+}
+
+// rules holds the symbol formats of the built-in sources.
+var rules = map[string]rule{
+	"yahoo": {
+		suffixes: []suffix{{TWO, ".TWO"}, {TW, ".TW"}, {HK, ".HK"}, {DE, ".DE"}, {JP, ".T"}, {UK, ".L"}, {US, ""}},
+		classSep: "-",
+	},
+	"stooq": {
+		suffixes: []suffix{{US, ".US"}, {JP, ".JP"}, {HK, ".HK"}, {UK, ".UK"}, {DE, ".DE"}},
+		classSep: "-",
+	},
+	"alphavantage": {
+		suffixes: []suffix{{UK, ".LON"}, {DE, ".DEX"}, {US, ""}},
+		classSep: ".",
+	},
+	"tiingo":  {suffixes: []suffix{{US, ""}}, classSep: "-"},
+	"iex":     {suffixes: []suffix{{US, ""}}, classSep: "."},
+	"twse":    {suffixes: []suffix{{TW, ""}}},
+	"finmind": {suffixes: []suffix{{TW, ""}, {TWO, ""}}},
+}
+
+// Mapper converts symbols between the canonical form and source formats,
+// applying per-symbol overrides before the source rules. It is safe for
+// concurrent use.
+type Mapper struct {
+	mu sync.RWMutex
+	// to maps source → canonical → source symbol; from is its inverse
+	to   map[string]map[string]string
+	from map[string]map[string]string
+}
+
+// Default is the Mapper used by ToSource and FromSource.
+var Default = NewMapper()
+
+// NewMapper returns a Mapper with the built-in source rules and no
+// overrides.
+func NewMapper() *Mapper {
+	return &Mapper{
+		to:   make(map[string]map[string]string),
+		from: make(map[string]map[string]string),
+	}
+}
+
+// Set records that canonical is symbol on source, for instruments the
+// rules do not cover (e.g., "SPX" is "^GSPC" on Yahoo).
+func (m *Mapper) Set(source, canonical, symbol string) error {
+	sym, err := Parse(canonical)
+	if err != nil {
+		return err
+	}
+	symbol = strings.TrimSpace(symbol)
+	if symbol == "" {
+		return fmt.Errorf("%w: empty %s symbol for %s", ErrInvalidSymbol, source, canonical)
+	}
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if m.to[source] == nil {
+		m.to[source] = make(map[string]string)
+		m.from[source] = make(map[string]string)
+	}
+	m.to[source][sym.String()] = symbol
+	m.from[source][strings.ToUpper(symbol)] = sym.String()
+	return nil
+}
+
+// ToSource returns canonical as named by source. It returns an error
+// matching ErrUnsupportedMarket when source does not cover the symbol's
+// market.
+func (m *Mapper) ToSource(source, canonical string) (string, error) {
+	sym, err := Parse(canonical)
+	if err != nil {
+		return "", err
+	}
+
+	m.mu.RLock()
+	symbol, ok := m.to[source][sym.String()]
+	m.mu.RUnlock()
+	if ok {
+		return symbol, nil
+	}
+
+	r, ok := rules[source]
+	if !ok {
+		return strings.TrimSpace(canonical), nil
+	}
+	for _, s := range r.suffixes {
+		if s.market == sym.Market {
+			return r.class(sym.Ticker, ".", r.classSep) + s.suffix, nil
+		}
+	}
+	return "", fmt.Errorf("%w: %s has no %s symbols (%s)", ErrUnsupportedMarket, source, sym.Market, sym)
+}
+
+// FromSource returns the canonical form of a symbol of source.
+func (m *Mapper) FromSource(source, symbol string) (string, error) {
+	symbol = strings.ToUpper(strings.TrimSpace(symbol))
+	if symbol == "" {
+		return "", fmt.Errorf("%w: empty symbol", ErrInvalidSymbol)
+	}
+
+	m.mu.RLock()
+	canonical, ok := m.from[source][symbol]
+	m.mu.RUnlock()
+	if ok {
+		return canonical, nil
+	}
+
+	r, ok := rules[source]
+	if !ok {
+		return symbol, nil
+	}
+	for _, s := range r.suffixes {
+		if s.suffix == "" || strings.HasSuffix(symbol, s.suffix) {
+			ticker := r.class(strings.TrimSuffix(symbol, s.suffix), r.classSep, ".")
+			return Symbol{Ticker: ticker, Market: s.market}.String(), nil
+		}
+	}
+	return "", fmt.Errorf("%w: %s has no market suffix of %s", ErrInvalidSymbol, symbol, source)
+}
+
+// Func returns a function converting canonical symbols for source, which
+// returns its input unchanged when it cannot be converted, for
+// datareader.CompositeReader.SetSymbolMapper.
+func (m *Mapper) Func(source string) func(string) string {
+	return func(canonical string) string {
+		symbol, err := m.ToSource(source, canonical)
+		if err != nil {
+			return canonical
+		}
+		return symbol
+	}
+}
+
+// class replaces the share-class separator from with to; sources without
+// share classes leave the ticker unchanged.
+func (r rule) class(ticker, from, to string) string {
+	if r.classSep == "" || from == to {
+		return ticker
+	}
+	return strings.ReplaceAll(ticker, from, to)
+}
+
+// ToSource converts canonical for source with Default.
+func ToSource(source, canonical string) (string, error) {
+	return Default.ToSource(source, canonical)
+}
+
+// FromSource converts a symbol of source to its canonical form with
+// Default.
+func FromSource(source, symbol string) (string, error) {
+	return Default.FromSource(source, symbol)
+}
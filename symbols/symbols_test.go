@@ -0,0 +1,130 @@
+package symbols_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/julianshen/gonp-datareader/symbols"
+)
+
+func TestParse(t *testing.T) {
+	tests := []struct {
+		canonical string
+		want      symbols.Symbol
+		wantErr   bool
+	}{
+		{"aapl", symbols.Symbol{Ticker: "AAPL", Market: symbols.US}, false},
+		{"2330", symbols.Symbol{Ticker: "2330", Market: symbols.TW}, false},
+		{"jp:7203", symbols.Symbol{Ticker: "7203", Market: symbols.JP}, false},
+		{"XX:ABC", symbols.Symbol{}, true},
+		{"US:", symbols.Symbol{}, true},
+		{" ", symbols.Symbol{}, true},
+	}
+
+	for _, tt := range tests {
+		got, err := symbols.Parse(tt.canonical)
+		if (err != nil) != tt.wantErr {
+			t.Errorf("Parse(%q) error = %v, wantErr %v", tt.canonical, err, tt.wantErr)
+			continue
+		}
+		if got != tt.want {
+			t.Errorf("Parse(%q) = %+v, want %+v", tt.canonical, got, tt.want)
+		}
+	}
+
+	if got := (symbols.Symbol{Ticker: "2330", Market: symbols.TW}).String(); got != "2330" {
+		t.Errorf("String() = %q, want 2330", got)
+	}
+	if got := (symbols.Symbol{Ticker: "6488", Market: symbols.TWO}).String(); got != "TWO:6488" {
+		t.Errorf("String() = %q, want TWO:6488", got)
+	}
+}
+
+func TestToSource(t *testing.T) {
+	tests := []struct {
+		source    string
+		canonical string
+		want      string
+		wantErr   error
+	}{
+		{"stooq", "AAPL", "AAPL.US", nil},
+		{"stooq", "BRK.B", "BRK-B.US", nil},
+		{"stooq", "2330", "", symbols.ErrUnsupportedMarket},
+		{"yahoo", "AAPL", "AAPL", nil},
+		{"yahoo", "BRK.B", "BRK-B", nil},
+		{"yahoo", "2330", "2330.TW", nil},
+		{"yahoo", "TWO:6488", "6488.TWO", nil},
+		{"yahoo", "JP:7203", "7203.T", nil},
+		{"alphavantage", "UK:VOD", "VOD.LON", nil},
+		{"twse", "2330", "2330", nil},
+		{"twse", "AAPL", "", symbols.ErrUnsupportedMarket},
+		{"fred", "GDP", "GDP", nil},
+		{"yahoo", "XX:ABC", "", symbols.ErrInvalidSymbol},
+	}
+
+	for _, tt := range tests {
+		got, err := symbols.ToSource(tt.source, tt.canonical)
+		if !errors.Is(err, tt.wantErr) || (tt.wantErr == nil && err != nil) {
+			t.Errorf("ToSource(%s, %s) error = %v, want %v", tt.source, tt.canonical, err, tt.wantErr)
+			continue
+		}
+		if got != tt.want {
+			t.Errorf("ToSource(%s, %s) = %q, want %q", tt.source, tt.canonical, got, tt.want)
+		}
+	}
+}
+
+func TestFromSource(t *testing.T) {
+	tests := []struct {
+		source  string
+		symbol  string
+		want    string
+		wantErr bool
+	}{
+		{"stooq", "aapl.us", "AAPL", false},
+		{"stooq", "BRK-B.US", "BRK.B", false},
+		{"stooq", "AAPL", "", true},
+		{"yahoo", "2330.TW", "2330", false},
+		{"yahoo", "6488.TWO", "TWO:6488", false},
+		{"yahoo", "7203.T", "JP:7203", false},
+		{"yahoo", "BRK-B", "BRK.B", false},
+		{"twse", "2330", "2330", false},
+		{"fred", "GDP", "GDP", false},
+	}
+
+	for _, tt := range tests {
+		got, err := symbols.FromSource(tt.source, tt.symbol)
+		if (err != nil) != tt.wantErr {
+			t.Errorf("FromSource(%s, %s) error = %v, wantErr %v", tt.source, tt.symbol, err, tt.wantErr)
+			continue
+		}
+		if got != tt.want {
+			t.Errorf("FromSource(%s, %s) = %q, want %q", tt.source, tt.symbol, got, tt.want)
+		}
+	}
+}
+
+func TestMapper_Set(t *testing.T) {
+	m := symbols.NewMapper()
+	if err := m.Set("yahoo", "SPX", "^GSPC"); err != nil {
+		t.Fatalf("Set() error = %v", err)
+	}
+	if got, _ := m.ToSource("yahoo", "spx"); got != "^GSPC" {
+		t.Errorf("ToSource(yahoo, spx) = %q, want ^GSPC", got)
+	}
+	if got, _ := m.FromSource("yahoo", "^gspc"); got != "SPX" {
+		t.Errorf("FromSource(yahoo, ^gspc) = %q, want SPX", got)
+	}
+	// Other sources keep the rules
+	if got, _ := m.ToSource("stooq", "SPX"); got != "SPX.US" {
+		t.Errorf("ToSource(stooq, SPX) = %q, want SPX.US", got)
+	}
+	if err := m.Set("yahoo", "SPX", ""); !errors.Is(err, symbols.ErrInvalidSymbol) {
+		t.Errorf("Set() with empty symbol error = %v, want ErrInvalidSymbol", err)
+	}
+
+	// Func passes unconvertible symbols through
+	if got := m.Func("stooq")("2330"); got != "2330" {
+		t.Errorf("Func(stooq)(2330) = %q, want 2330", got)
+	}
+}
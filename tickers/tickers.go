@@ -0,0 +1,235 @@
+// Package tickers tracks ticker changes (renames such as FB → META) so a
+// company's price history can be followed across symbols.
+//
+// Bundled returns a History of well-known US ticker changes embedded in
+// the package. Add custom changes with History.Add or Load, and plug in
+// source-backed lookups through the Lookup interface.
+//
+// # Example Usage
+//
+//	h := tickers.Bundled()
+//	h.Current("FB")  // "META"
+//	for _, seg := range h.Lineage("META") {
+//		fmt.Println(seg.Symbol, seg.From, seg.To) // FB until 2022-06-09, then META
+//	}
+//
+// datareader.ReadDataset stitches the segments into one continuous series
+// when Options.StitchRenames is set.
+package tickers
+
+import (
+	"bytes"
+	"context"
+	_ "embed"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"sort"
+	"sync"
+	"time"
+)
+
+//go:embed changes.json
+var bundled []byte
+
+// Change records a ticker change: Old trades as New from Date on.
+type Change struct {
+	Old  string
+	New  string
+	Date time.Time
+	// Name is the company name after the change, if known.
+	Name string
+}
+
+// Segment is the period a company traded under Symbol: From (inclusive)
+// to To (exclusive). Zero times mean unbounded.
+type Segment struct {
+	Symbol string
+	From   time.Time
+	To     time.Time
+}
+
+// Lookup is implemented by sources that publish ticker changes.
+type Lookup interface {
+	// TickerChanges returns the known changes involving symbol.
+	TickerChanges(ctx context.Context, symbol string) ([]Change, error)
+}
+
+// History is a set of ticker changes. It is safe for concurrent use.
+type History struct {
+	mu      sync.RWMutex
+	changes []Change
+}
+
+// NewHistory returns a History holding changes.
+func NewHistory(changes ...Change) *History {
+	h := &History{}
+	h.Add(changes...)
+	return h
+}
+
+// Bundled returns a new History with the ticker changes embedded in the
+// package.
+func Bundled() *History {
+	h, err := Read(bytes.NewReader(bundled))
+	if err != nil {
+		panic(fmt.Sprintf("tickers: invalid bundled data: %v", err))
+	}
+	return h
+}
+
+// Add records changes, ignoring exact duplicates.
+func (h *History) Add(changes ...Change) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	for _, c := range changes {
+		if !h.contains(c) {
+			h.changes = append(h.changes, c)
+		}
+	}
+	sort.SliceStable(h.changes, func(i, j int) bool { return h.changes[i].Date.Before(h.changes[j].Date) })
+}
+
+// contains reports whether c is already recorded. Callers hold h.mu.
+func (h *History) contains(c Change) bool {
+	for _, existing := range h.changes {
+		if existing.Old == c.Old && existing.New == c.New && existing.Date.Equal(c.Date) {
+			return true
+		}
+	}
+	return false
+}
+
+// Fetch adds the changes lookup reports for each symbol.
+func (h *History) Fetch(ctx context.Context, lookup Lookup, symbols ...string) error {
+	for _, symbol := range symbols {
+		changes, err := lookup.TickerChanges(ctx, symbol)
+		if err != nil {
+			return fmt.Errorf("ticker changes for %s: %w", symbol, err)
+		}
+		h.Add(changes...)
+	}
+	return nil
+}
+
+// Changes returns all recorded changes in date order.
+func (h *History) Changes() []Change {
+	h.mu.RLock()
+	defer h.mu.RUnlock()
+	return append([]Change(nil), h.changes...)
+}
+
+// Current follows symbol through later ticker changes and returns the
+// symbol the company trades under today.
+func (h *History) Current(symbol string) string {
+	h.mu.RLock()
+	defer h.mu.RUnlock()
+
+	var since time.Time
+	for seen := 0; seen <= len(h.changes); seen++ {
+		c, ok := h.renamedFrom(symbol, since)
+		if !ok {
+			break
+		}
+		symbol, since = c.New, c.Date
+	}
+	return symbol
+}
+
+// SymbolAt returns the ticker the company currently trading as symbol (or
+// formerly trading as it) used on date t.
+func (h *History) SymbolAt(symbol string, t time.Time) string {
+	for _, seg := range h.Lineage(h.Current(symbol)) {
+		if !t.Before(seg.From) && (seg.To.IsZero() || t.Before(seg.To)) {
+			return seg.Symbol
+		}
+	}
+	return symbol
+}
+
+// Lineage returns the segments of the company trading as symbol, oldest
+// first, following ticker changes back in time. A symbol without changes
+// has a single unbounded segment.
+func (h *History) Lineage(symbol string) []Segment {
+	h.mu.RLock()
+	defer h.mu.RUnlock()
+
+	segments := []Segment{{Symbol: symbol}}
+	until := time.Time{}
+	for seen := 0; seen < len(h.changes); seen++ {
+		c, ok := h.renamedTo(segments[0].Symbol, until)
+		if !ok {
+			break
+		}
+		segments[0].From = c.Date
+		segments = append([]Segment{{Symbol: c.Old, To: c.Date}}, segments...)
+		until = c.Date
+	}
+	return segments
+}
+
+// renamedFrom returns the earliest change away from symbol after since.
+// Callers hold h.mu.
+func (h *History) renamedFrom(symbol string, since time.Time) (Change, bool) {
+	for _, c := range h.changes {
+		if c.Old == symbol && c.Date.After(since) {
+			return c, true
+		}
+	}
+	return Change{}, false
+}
+
+// renamedTo returns the latest change to symbol before until (zero means
+// no bound). Callers hold h.mu.
+func (h *History) renamedTo(symbol string, until time.Time) (Change, bool) {
+	for i := len(h.changes) - 1; i >= 0; i-- {
+		c := h.changes[i]
+		if c.New == symbol && (until.IsZero() || c.Date.Before(until)) {
+			return c, true
+		}
+	}
+	return Change{}, false
+}
+
+// changeJSON is the file format of a change.
+type changeJSON struct {
+	Old  string `json:"old"`
+	New  string `json:"new"`
+	Date string `json:"date"`
+	Name string `json:"name,omitempty"`
+}
+
+// Read decodes a JSON array of changes, e.g.:
+//
+//	[{"old": "FB", "new": "META", "date": "2022-06-09", "name": "Meta Platforms"}]
+func Read(r io.Reader) (*History, error) {
+	var entries []changeJSON
+	if err := json.NewDecoder(r).Decode(&entries); err != nil {
+		return nil, fmt.Errorf("decode ticker changes: %w", err)
+	}
+
+	changes := make([]Change, 0, len(entries))
+	for _, e := range entries {
+		if e.Old == "" || e.New == "" {
+			return nil, fmt.Errorf("decode ticker changes: change needs old and new symbols")
+		}
+		date, err := time.Parse("2006-01-02", e.Date)
+		if err != nil {
+			return nil, fmt.Errorf("decode ticker changes: %s → %s: %w", e.Old, e.New, err)
+		}
+		changes = append(changes, Change{Old: e.Old, New: e.New, Date: date, Name: e.Name})
+	}
+	return NewHistory(changes...), nil
+}
+
+// Load reads a JSON file of changes in the format of Read.
+func Load(path string) (*History, error) {
+	f, err := os.Open(path) // #nosec G304 - Path is chosen by the caller
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+	return Read(f)
+}
@@ -0,0 +1,111 @@
+package tickers_test
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/julianshen/gonp-datareader/tickers"
+)
+
+func date(s string) time.Time {
+	t, err := time.Parse("2006-01-02", s)
+	if err != nil {
+		panic(err)
+	}
+	return t
+}
+
+func TestBundled(t *testing.T) {
+	h := tickers.Bundled()
+
+	if got := h.Current("FB"); got != "META" {
+		t.Errorf("Current(FB) = %q, want META", got)
+	}
+	if got := h.Current("META"); got != "META" {
+		t.Errorf("Current(META) = %q, want META", got)
+	}
+	if got := h.SymbolAt("META", date("2021-01-04")); got != "FB" {
+		t.Errorf("SymbolAt(META, 2021) = %q, want FB", got)
+	}
+	if got := h.SymbolAt("FB", date("2023-01-03")); got != "META" {
+		t.Errorf("SymbolAt(FB, 2023) = %q, want META", got)
+	}
+}
+
+func TestHistory_Lineage(t *testing.T) {
+	// A company renamed twice: A → B → C
+	h := tickers.NewHistory(
+		tickers.Change{Old: "B", New: "C", Date: date("2022-01-03")},
+		tickers.Change{Old: "A", New: "B", Date: date("2020-01-02")},
+	)
+
+	want := []tickers.Segment{
+		{Symbol: "A", To: date("2020-01-02")},
+		{Symbol: "B", From: date("2020-01-02"), To: date("2022-01-03")},
+		{Symbol: "C", From: date("2022-01-03")},
+	}
+	if got := h.Lineage("C"); !reflect.DeepEqual(got, want) {
+		t.Errorf("Lineage(C) = %+v, want %+v", got, want)
+	}
+	if got := h.Current("A"); got != "C" {
+		t.Errorf("Current(A) = %q, want C", got)
+	}
+	if got := h.Lineage("Z"); !reflect.DeepEqual(got, []tickers.Segment{{Symbol: "Z"}}) {
+		t.Errorf("Lineage(Z) = %+v", got)
+	}
+}
+
+// stubLookup reports one change per symbol.
+type stubLookup map[string][]tickers.Change
+
+func (s stubLookup) TickerChanges(ctx context.Context, symbol string) ([]tickers.Change, error) {
+	changes, ok := s[symbol]
+	if !ok {
+		return nil, errors.New("unknown symbol")
+	}
+	return changes, nil
+}
+
+func TestHistory_FetchAndAdd(t *testing.T) {
+	h := tickers.NewHistory()
+	lookup := stubLookup{"NEW": {{Old: "OLD", New: "NEW", Date: date("2024-01-02")}}}
+
+	if err := h.Fetch(context.Background(), lookup, "NEW"); err != nil {
+		t.Fatalf("Fetch() error = %v", err)
+	}
+	h.Add(tickers.Change{Old: "OLD", New: "NEW", Date: date("2024-01-02")})
+	if got := len(h.Changes()); got != 1 {
+		t.Errorf("len(Changes()) = %d, want 1 (duplicates ignored)", got)
+	}
+	if err := h.Fetch(context.Background(), lookup, "MISSING"); err == nil {
+		t.Error("Fetch() expected error from lookup")
+	}
+}
+
+func TestLoad(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "changes.json")
+	content := `[{"old": "TWTR", "new": "X", "date": "2023-07-24"}]`
+	if err := os.WriteFile(path, []byte(content), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	h, err := tickers.Load(path)
+	if err != nil {
+		t.Fatalf("Load() error = %v", err)
+	}
+	if got := h.Current("TWTR"); got != "X" {
+		t.Errorf("Current(TWTR) = %q, want X", got)
+	}
+
+	for _, bad := range []string{`[{"old": "A", "date": "2024-01-02"}]`, `[{"old": "A", "new": "B", "date": "01/02/2024"}]`} {
+		if _, err := tickers.Read(strings.NewReader(bad)); err == nil {
+			t.Errorf("Read(%s) expected error", bad)
+		}
+	}
+}
@@ -0,0 +1,102 @@
+package datareader
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"reflect"
+	"time"
+
+	"github.com/julianshen/gonp-datareader/sources"
+)
+
+// ErrUnexpectedType is returned by a typed Reader when its source returns
+// data of a type other than the Reader's type parameter.
+var ErrUnexpectedType = errors.New("unexpected data type")
+
+// Reader is a type-safe view of a sources.Reader whose ReadSingle returns
+// T, the source's ParsedData type (e.g., *yahoo.ParsedData), so results
+// need no type assertion. T may also be an interface every ParsedData
+// implements, such as sources.TimeSeries. The embedded sources.Reader
+// remains available for the untyped API.
+//
+// # Example Usage
+//
+//	reader, err := datareader.NewReader[*yahoo.ParsedData]("yahoo", nil)
+//	if err != nil {
+//		log.Fatal(err)
+//	}
+//	data, err := reader.ReadSingle(ctx, "AAPL", start, end)
+//	closes, err := data.GetFloatColumn("Close") // data is *yahoo.ParsedData
+type Reader[T any] struct {
+	sources.Reader
+}
+
+// NewReader creates a typed Reader for source, configured like DataReader.
+// A T that does not match the source's ParsedData type is reported by the
+// first read as ErrUnexpectedType.
+func NewReader[T any](source string, opts *Options) (*Reader[T], error) {
+	r, err := DataReader(source, opts)
+	if err != nil {
+		return nil, err
+	}
+	return Typed[T](r), nil
+}
+
+// Typed wraps an existing reader, such as one created with a source's
+// constructor, in a typed Reader.
+func Typed[T any](r sources.Reader) *Reader[T] {
+	return &Reader[T]{Reader: r}
+}
+
+// ReadSingle fetches data for a single symbol as T.
+func (r *Reader[T]) ReadSingle(ctx context.Context, symbol string, start, end time.Time) (T, error) {
+	var zero T
+	data, err := r.Reader.ReadSingle(labelCache(ctx, symbol, start, end), symbol, start, end)
+	if err != nil {
+		return zero, err
+	}
+	return as[T](r.Source(), data)
+}
+
+// Read fetches data for multiple symbols, keyed by symbol.
+func (r *Reader[T]) Read(ctx context.Context, symbols []string, start, end time.Time) (map[string]T, error) {
+	data, err := r.Reader.Read(ctx, symbols, start, end)
+	if err != nil {
+		return nil, err
+	}
+	if typed, ok := data.(map[string]T); ok {
+		return typed, nil
+	}
+
+	// Convert maps of another value type, e.g. to an interface T
+	m := reflect.ValueOf(data)
+	if m.Kind() != reflect.Map || m.Type().Key().Kind() != reflect.String {
+		return nil, fmt.Errorf("%w: %s returned %T, want map[string]%s", ErrUnexpectedType, r.Source(), data, typeName[T]())
+	}
+	typed := make(map[string]T, m.Len())
+	iter := m.MapRange()
+	for iter.Next() {
+		v, err := as[T](r.Source(), iter.Value().Interface())
+		if err != nil {
+			return nil, err
+		}
+		typed[iter.Key().String()] = v
+	}
+	return typed, nil
+}
+
+// as asserts that data, returned by source, is a T.
+func as[T any](source string, data interface{}) (T, error) {
+	typed, ok := data.(T)
+	if !ok {
+		var zero T
+		return zero, fmt.Errorf("%w: %s returned %T, want %s", ErrUnexpectedType, source, data, typeName[T]())
+	}
+	return typed, nil
+}
+
+// typeName returns the name of T, including interface types.
+func typeName[T any]() string {
+	return reflect.TypeOf((*T)(nil)).Elem().String()
+}
@@ -0,0 +1,117 @@
+package datareader_test
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	datareader "github.com/julianshen/gonp-datareader"
+	"github.com/julianshen/gonp-datareader/sources"
+	"github.com/julianshen/gonp-datareader/sources/stooq"
+	"github.com/julianshen/gonp-datareader/sources/yahoo"
+)
+
+func stooqServer(t *testing.T) (*httptest.Server, *datareader.Options) {
+	t.Helper()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte("Date,Open,High,Low,Close,Volume\n2024-01-02,1,1,1,10,100\n2024-01-03,1,1,1,11,100\n"))
+	}))
+	t.Cleanup(server.Close)
+
+	return server, &datareader.Options{
+		Environment:     datareader.EnvironmentSandbox,
+		SandboxBaseURLs: map[string]string{"stooq": server.URL + "?s=%s"},
+	}
+}
+
+func TestNewReader_Typed(t *testing.T) {
+	_, opts := stooqServer(t)
+	start := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	end := time.Date(2024, 1, 31, 0, 0, 0, 0, time.UTC)
+
+	reader, err := datareader.NewReader[*stooq.ParsedData]("stooq", opts)
+	if err != nil {
+		t.Fatalf("NewReader() error = %v", err)
+	}
+	if reader.Source() != "stooq" {
+		t.Errorf("Source() = %q, want stooq", reader.Source())
+	}
+
+	data, err := reader.ReadSingle(context.Background(), "AAA.US", start, end)
+	if err != nil {
+		t.Fatalf("ReadSingle() error = %v", err)
+	}
+	if closes, err := data.GetFloatColumn("Close"); err != nil || closes[1] != 11 {
+		t.Errorf("GetFloatColumn(Close) = %v, %v", closes, err)
+	}
+
+	all, err := reader.Read(context.Background(), []string{"AAA.US", "BBB.US"}, start, end)
+	if err != nil {
+		t.Fatalf("Read() error = %v", err)
+	}
+	if len(all) != 2 || len(all["BBB.US"].Rows) != 2 {
+		t.Errorf("Read() = %v", all)
+	}
+}
+
+func TestNewReader_Interface(t *testing.T) {
+	_, opts := stooqServer(t)
+	start := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	end := time.Date(2024, 1, 31, 0, 0, 0, 0, time.UTC)
+
+	reader, err := datareader.NewReader[sources.TimeSeries]("stooq", opts)
+	if err != nil {
+		t.Fatalf("NewReader() error = %v", err)
+	}
+
+	all, err := reader.Read(context.Background(), []string{"AAA.US", "BBB.US"}, start, end)
+	if err != nil {
+		t.Fatalf("Read() error = %v", err)
+	}
+	for symbol, ts := range all {
+		if closes, err := ts.Float64Column("Close"); err != nil || len(closes) != 2 {
+			t.Errorf("%s: Float64Column(Close) = %v, %v", symbol, closes, err)
+		}
+	}
+}
+
+func TestNewReader_UnexpectedType(t *testing.T) {
+	_, opts := stooqServer(t)
+	start := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	end := time.Date(2024, 1, 31, 0, 0, 0, 0, time.UTC)
+
+	reader, err := datareader.NewReader[*yahoo.ParsedData]("stooq", opts)
+	if err != nil {
+		t.Fatalf("NewReader() error = %v", err)
+	}
+
+	if _, err := reader.ReadSingle(context.Background(), "AAA.US", start, end); !errors.Is(err, datareader.ErrUnexpectedType) {
+		t.Errorf("ReadSingle() error = %v, want ErrUnexpectedType", err)
+	}
+	if _, err := reader.Read(context.Background(), []string{"AAA.US"}, start, end); !errors.Is(err, datareader.ErrUnexpectedType) {
+		t.Errorf("Read() error = %v, want ErrUnexpectedType", err)
+	}
+
+	if _, err := datareader.NewReader[*yahoo.ParsedData]("nosuch", nil); !errors.Is(err, datareader.ErrUnknownSource) {
+		t.Errorf("NewReader(nosuch) error = %v, want ErrUnknownSource", err)
+	}
+}
+
+func TestTyped(t *testing.T) {
+	server, _ := stooqServer(t)
+	start := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	end := time.Date(2024, 1, 31, 0, 0, 0, 0, time.UTC)
+
+	reader := datareader.Typed[*stooq.ParsedData](stooq.NewStooqReaderWithBaseURL(nil, server.URL+"?s=%s"))
+	data, err := reader.ReadSingle(context.Background(), "AAA.US", start, end)
+	if err != nil {
+		t.Fatalf("ReadSingle() error = %v", err)
+	}
+	if len(data.Rows) != 2 {
+		t.Errorf("Rows = %d, want 2", len(data.Rows))
+	}
+}
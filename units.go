@@ -0,0 +1,100 @@
+package datareader
+
+import (
+	"strings"
+
+	"github.com/julianshen/gonp-datareader/dataset"
+	"github.com/julianshen/gonp-datareader/symbols"
+)
+
+// priceColumns are the columns quoted in the currency of the security.
+var priceColumns = map[string]bool{
+	"Open": true, "High": true, "Low": true, "Close": true, "Adj Close": true,
+	"Change": true, "Dividends": true,
+	// FinMind names its columns in lower case
+	"open": true, "max": true, "min": true, "close": true, "spread": true,
+}
+
+// shareColumns are the columns counting shares.
+var shareColumns = map[string]bool{
+	"Volume": true, "ATS Shares": true, "Non-ATS Shares": true, "Trading_Volume": true,
+}
+
+// marketCurrencies are the currencies prices are quoted in on each market.
+// London prices are left out: most are quoted in pence.
+var marketCurrencies = map[symbols.Market]string{
+	symbols.US:  "USD",
+	symbols.TW:  "TWD",
+	symbols.TWO: "TWD",
+	symbols.JP:  "JPY",
+	symbols.HK:  "HKD",
+	symbols.DE:  "EUR",
+}
+
+// setUnits sets the units of the columns of ds, a dataset of source, that
+// have none: Meta["unit"] of single-series sources applies to their value
+// columns; price columns are in the currency of Meta["currency"] or of
+// the symbol's market, or in index points for indexes; volume columns
+// are in shares.
+func setUnits(ds *dataset.Dataset, source string) {
+	if source == "ecb" {
+		// Units of the currency per euro
+		setUnit(ds, func(string) bool { return true }, ds.Symbol+"/EUR")
+		return
+	}
+	if unit := ds.Meta[dataset.MetaUnit]; unit != "" {
+		setUnit(ds, func(string) bool { return true }, unit)
+		return
+	}
+
+	priceUnit := ds.Meta[dataset.MetaCurrency]
+	if priceUnit == "" {
+		priceUnit = quoteUnit(source, ds.Symbol)
+	}
+	setUnit(ds, func(name string) bool { return priceColumns[name] }, priceUnit)
+	setUnit(ds, func(name string) bool { return shareColumns[name] }, dataset.UnitShares)
+}
+
+// setUnit sets unit on the columns of ds matching match that have none.
+func setUnit(ds *dataset.Dataset, match func(string) bool, unit string) {
+	if unit == "" {
+		return
+	}
+	for i := range ds.Columns {
+		if ds.Columns[i].Unit == "" && match(ds.Columns[i].Name) {
+			ds.Columns[i].Unit = unit
+		}
+	}
+}
+
+// quoteUnit returns the unit of the prices of symbol on source: index
+// points for indexes ("^GSPC"), the quote currency of Yahoo currency pairs
+// ("USDTWD=X"), and otherwise the currency of the symbol's market; empty
+// when unknown.
+func quoteUnit(source, symbol string) string {
+	switch source {
+	case "twse":
+		return "TWD"
+	case "tiingo", "iex":
+		return "USD"
+	case "yahoo", "stooq", "alphavantage":
+	default:
+		return ""
+	}
+
+	if strings.HasPrefix(symbol, "^") {
+		return dataset.UnitIndex
+	}
+	if pair, ok := strings.CutSuffix(symbol, "=X"); ok && len(pair) == 6 {
+		return strings.ToUpper(pair[3:])
+	}
+	canonical, err := symbols.FromSource(source, symbol)
+	if err != nil {
+		return ""
+	}
+	sym, err := symbols.Parse(canonical)
+	if err != nil {
+		return ""
+	}
+	return marketCurrencies[sym.Market]
+}
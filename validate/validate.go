@@ -0,0 +1,315 @@
+// Package validate checks fetched data for quality problems: dates out of
+// order or repeated, negative volumes, highs below lows, zero prices and
+// gaps of more trading days than expected. It returns a structured Report
+// so pipelines can gate on the checks instead of writing them per source.
+//
+// # Example Usage
+//
+//	ds, err := datareader.ReadDataset(ctx, "AAPL", "yahoo", start, end, nil)
+//	if err != nil {
+//		log.Fatal(err)
+//	}
+//	report := validate.Dataset(ds, validate.Options{MaxGap: 3})
+//	if err := report.Err(); err != nil {
+//		log.Fatal(err) // e.g. "AAPL: 1 duplicate_date, 2 gap"
+//	}
+package validate
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"strings"
+	"time"
+
+	datareader "github.com/julianshen/gonp-datareader"
+	"github.com/julianshen/gonp-datareader/calendar"
+	"github.com/julianshen/gonp-datareader/dataset"
+)
+
+// ErrInvalid is matched by errors.Is for the errors of Report.Err.
+var ErrInvalid = errors.New("data quality check failed")
+
+// Check names a data-quality check.
+type Check string
+
+const (
+	// NonMonotonic flags rows dated before the row preceding them.
+	NonMonotonic Check = "non_monotonic"
+	// DuplicateDate flags rows with the date of an earlier row.
+	DuplicateDate Check = "duplicate_date"
+	// NegativeVolume flags negative volumes.
+	NegativeVolume Check = "negative_volume"
+	// HighBelowLow flags rows whose high is below their low.
+	HighBelowLow Check = "high_below_low"
+	// ZeroPrice flags zero or negative prices, which sources such as TWSE
+	// report for securities that did not trade.
+	ZeroPrice Check = "zero_price"
+	// Gap flags more missing trading days between consecutive rows than
+	// Options.MaxGap.
+	Gap Check = "gap"
+)
+
+// Checks lists every check in the order they are reported.
+var Checks = []Check{NonMonotonic, DuplicateDate, NegativeVolume, HighBelowLow, ZeroPrice, Gap}
+
+// DefaultMaxGap is the number of missing trading days between rows
+// tolerated when Options.MaxGap is zero.
+const DefaultMaxGap = 5
+
+// Price, high, low and volume columns in the spellings of the sources.
+var (
+	priceColumns  = []string{"Open", "High", "Low", "Close", "Adj Close", "open", "max", "min", "close"}
+	highColumns   = []string{"High", "max"}
+	lowColumns    = []string{"Low", "min"}
+	volumeColumns = []string{"Volume", "Trading_Volume", "volume"}
+)
+
+// Options configures the checks.
+type Options struct {
+	// Checks selects the checks to run. Default: Checks
+	Checks []Check
+	// MaxGap is the largest number of trading days that may be missing
+	// between consecutive rows. Negative disables the Gap check.
+	// Default: DefaultMaxGap
+	MaxGap int
+	// Calendar is the exchange calendar gaps are counted in. Default:
+	// calendar.ForSource of the dataset's source; series of sources
+	// without a calendar, such as economic series, and series of weekly
+	// or longer bars are not checked for gaps
+	Calendar *calendar.Calendar
+}
+
+// Issue is one problem found in a dataset.
+type Issue struct {
+	Check Check `json:"check"`
+	// Row is the index of the offending row
+	Row  int       `json:"row"`
+	Date time.Time `json:"date"`
+	// Column is the offending column, if the check is about one
+	Column  string `json:"column,omitempty"`
+	Message string `json:"message"`
+}
+
+// Report holds the issues found in a dataset, in check order and then row
+// order.
+type Report struct {
+	Symbol string  `json:"symbol"`
+	Source string  `json:"source"`
+	Rows   int     `json:"rows"`
+	Issues []Issue `json:"issues"`
+}
+
+// OK reports whether no issue was found.
+func (r *Report) OK() bool {
+	return len(r.Issues) == 0
+}
+
+// Count returns the number of issues found by check.
+func (r *Report) Count(check Check) int {
+	n := 0
+	for _, issue := range r.Issues {
+		if issue.Check == check {
+			n++
+		}
+	}
+	return n
+}
+
+// Err returns nil when no issue was found, and otherwise an error matching
+// ErrInvalid that counts the issues of each check.
+func (r *Report) Err() error {
+	if r.OK() {
+		return nil
+	}
+	return fmt.Errorf("%w: %s", ErrInvalid, r.summary())
+}
+
+// String returns the summary of the report followed by one line per
+// issue.
+func (r *Report) String() string {
+	var b strings.Builder
+	if r.OK() {
+		fmt.Fprintf(&b, "%s: %d rows, no issues\n", label(r), r.Rows)
+		return b.String()
+	}
+	fmt.Fprintf(&b, "%s\n", r.summary())
+	for _, issue := range r.Issues {
+		fmt.Fprintf(&b, "  %s  %-15s %s\n", issue.Date.Format("2006-01-02"), issue.Check, issue.Message)
+	}
+	return b.String()
+}
+
+// summary counts the issues of each check, e.g. "AAPL: 1 duplicate_date,
+// 2 gap".
+func (r *Report) summary() string {
+	var counts []string
+	for _, check := range Checks {
+		if n := r.Count(check); n > 0 {
+			counts = append(counts, fmt.Sprintf("%d %s", n, check))
+		}
+	}
+	return label(r) + ": " + strings.Join(counts, ", ")
+}
+
+// label names the dataset of a report.
+func label(r *Report) string {
+	if r.Source == "" {
+		return r.Symbol
+	}
+	return r.Source + ":" + r.Symbol
+}
+
+// Data converts data, as returned by datareader.Read for symbol, with
+// datareader.ToDataset and checks it.
+func Data(symbol string, data interface{}, opts Options) (*Report, error) {
+	ds, err := datareader.ToDataset(symbol, data)
+	if err != nil {
+		return nil, err
+	}
+	return Dataset(ds, opts), nil
+}
+
+// Dataset runs the checks selected by opts on ds. Missing (NaN) values
+// are not flagged.
+func Dataset(ds *dataset.Dataset, opts Options) *Report {
+	r := &Report{}
+	if ds == nil {
+		return r
+	}
+	r.Symbol, r.Source, r.Rows = ds.Symbol, ds.Source, ds.Len()
+
+	checks := opts.Checks
+	if checks == nil {
+		checks = Checks
+	}
+	for _, check := range Checks {
+		if !contains(checks, check) {
+			continue
+		}
+		switch check {
+		case NonMonotonic:
+			r.checkOrder(ds)
+		case DuplicateDate:
+			r.checkDuplicates(ds)
+		case NegativeVolume:
+			r.checkColumns(ds, NegativeVolume, volumeColumns, func(v float64) bool { return v < 0 })
+		case HighBelowLow:
+			r.checkHighLow(ds)
+		case ZeroPrice:
+			r.checkColumns(ds, ZeroPrice, priceColumns, func(v float64) bool { return v <= 0 })
+		case Gap:
+			r.checkGaps(ds, opts)
+		}
+	}
+	return r
+}
+
+// add records an issue.
+func (r *Report) add(check Check, ds *dataset.Dataset, row int, column, format string, args ...interface{}) {
+	r.Issues = append(r.Issues, Issue{
+		Check:   check,
+		Row:     row,
+		Date:    ds.Dates[row],
+		Column:  column,
+		Message: fmt.Sprintf(format, args...),
+	})
+}
+
+// checkOrder flags rows dated before their predecessor.
+func (r *Report) checkOrder(ds *dataset.Dataset) {
+	for i := 1; i < len(ds.Dates); i++ {
+		if ds.Dates[i].Before(ds.Dates[i-1]) {
+			r.add(NonMonotonic, ds, i, "", "dated before the previous row (%s)", ds.Dates[i-1].Format("2006-01-02"))
+		}
+	}
+}
+
+// checkDuplicates flags rows repeating the date of an earlier row.
+func (r *Report) checkDuplicates(ds *dataset.Dataset) {
+	first := make(map[int64]int, len(ds.Dates))
+	for i, t := range ds.Dates {
+		if j, ok := first[t.UnixNano()]; ok {
+			r.add(DuplicateDate, ds, i, "", "same date as row %d", j)
+			continue
+		}
+		first[t.UnixNano()] = i
+	}
+}
+
+// checkColumns flags the values of the named columns for which bad
+// reports true.
+func (r *Report) checkColumns(ds *dataset.Dataset, check Check, columns []string, bad func(float64) bool) {
+	for _, name := range columns {
+		values, ok := ds.Column(name)
+		if !ok {
+			continue
+		}
+		for i, v := range values {
+			if !math.IsNaN(v) && bad(v) {
+				r.add(check, ds, i, name, "%s is %v", name, v)
+			}
+		}
+	}
+}
+
+// checkHighLow flags rows whose high is below their low.
+func (r *Report) checkHighLow(ds *dataset.Dataset) {
+	for k, name := range highColumns {
+		highs, ok := ds.Column(name)
+		if !ok {
+			continue
+		}
+		lows, ok := ds.Column(lowColumns[k])
+		if !ok {
+			continue
+		}
+		for i := range highs {
+			if highs[i] < lows[i] {
+				r.add(HighBelowLow, ds, i, name, "%s %v below %s %v", name, highs[i], lowColumns[k], lows[i])
+			}
+		}
+	}
+}
+
+// checkGaps flags consecutive rows more than opts.MaxGap trading days
+// apart.
+func (r *Report) checkGaps(ds *dataset.Dataset, opts Options) {
+	maxGap := opts.MaxGap
+	if maxGap == 0 {
+		maxGap = DefaultMaxGap
+	}
+	cal := opts.Calendar
+	if cal == nil {
+		cal = calendar.ForSource(ds.Source)
+	}
+	if maxGap < 0 || cal == nil {
+		return
+	}
+	// Weekly or monthly bars are not sessions
+	if f := ds.Frequency(); f != dataset.FrequencyDaily && f != dataset.FrequencyUnknown {
+		return
+	}
+
+	for i := 1; i < len(ds.Dates); i++ {
+		prev, t := ds.Dates[i-1], ds.Dates[i]
+		if !t.After(prev) {
+			continue
+		}
+		// Sessions strictly between the two rows
+		missing := cal.TradingDays(prev.AddDate(0, 0, 1), t.AddDate(0, 0, -1))
+		if missing > maxGap {
+			r.add(Gap, ds, i, "", "%d trading days missing since %s", missing, prev.Format("2006-01-02"))
+		}
+	}
+}
+
+// contains reports whether check is in checks.
+func contains(checks []Check, check Check) bool {
+	for _, c := range checks {
+		if c == check {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,102 @@
+package validate_test
+
+import (
+	"errors"
+	"math"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/julianshen/gonp-datareader/dataset"
+	"github.com/julianshen/gonp-datareader/sources/yahoo"
+	"github.com/julianshen/gonp-datareader/validate"
+)
+
+func day(s string) time.Time {
+	t, err := time.Parse("2006-01-02", s)
+	if err != nil {
+		panic(err)
+	}
+	return t
+}
+
+func bars(dates ...string) *dataset.Dataset {
+	index := make([]time.Time, len(dates))
+	for i, d := range dates {
+		index[i] = day(d)
+	}
+	return dataset.New("AAPL", "yahoo", index)
+}
+
+func TestDataset_Clean(t *testing.T) {
+	ds := bars("2024-01-02", "2024-01-03", "2024-01-04")
+	ds.AddColumn("High", []float64{2, 3, 4})
+	ds.AddColumn("Low", []float64{1, 2, math.NaN()})
+	ds.AddColumn("Close", []float64{1.5, 2.5, 3.5})
+	ds.AddColumn("Volume", []float64{100, 200, 300})
+
+	report := validate.Dataset(ds, validate.Options{})
+	if !report.OK() || report.Err() != nil {
+		t.Errorf("Dataset() = %v, want no issues", report)
+	}
+	if report.Rows != 3 || !strings.Contains(report.String(), "no issues") {
+		t.Errorf("String() = %q", report.String())
+	}
+}
+
+func TestDataset_Issues(t *testing.T) {
+	// 2024-01-15 is Martin Luther King Jr. Day, so 2024-01-05 to 2024-01-22
+	// misses 9 sessions
+	ds := bars("2024-01-02", "2024-01-04", "2024-01-03", "2024-01-03", "2024-01-05", "2024-01-22")
+	ds.AddColumn("High", []float64{2, 3, 1, 4, 5, 6})
+	ds.AddColumn("Low", []float64{1, 2, 2, 3, 4, 5})
+	ds.AddColumn("Close", []float64{1.5, 2.5, 0, 3.5, 4.5, 5.5})
+	ds.AddColumn("Volume", []float64{100, -1, 300, 400, 500, 600})
+
+	report := validate.Dataset(ds, validate.Options{})
+	want := map[validate.Check]int{
+		validate.NonMonotonic:   1,
+		validate.DuplicateDate:  1,
+		validate.NegativeVolume: 1,
+		validate.HighBelowLow:   1,
+		validate.ZeroPrice:      1,
+		validate.Gap:            1,
+	}
+	for check, n := range want {
+		if got := report.Count(check); got != n {
+			t.Errorf("Count(%s) = %d, want %d\n%v", check, got, n, report)
+		}
+	}
+	if issue := report.Issues[0]; issue.Check != validate.NonMonotonic || issue.Row != 2 || !issue.Date.Equal(day("2024-01-03")) {
+		t.Errorf("Issues[0] = %+v", issue)
+	}
+
+	err := report.Err()
+	if !errors.Is(err, validate.ErrInvalid) || !strings.Contains(err.Error(), "1 gap") {
+		t.Errorf("Err() = %v", err)
+	}
+
+	// Selected checks and a larger gap tolerance
+	report = validate.Dataset(ds, validate.Options{Checks: []validate.Check{validate.Gap}, MaxGap: 10})
+	if !report.OK() {
+		t.Errorf("Dataset(MaxGap 10) = %v, want no issues", report)
+	}
+}
+
+func TestData(t *testing.T) {
+	data := &yahoo.ParsedData{
+		Columns: []string{"Date", "Close", "Volume"},
+		Rows: []map[string]string{
+			{"Date": "2024-01-02", "Close": "185.6", "Volume": "1000"},
+			{"Date": "2024-01-02", "Close": "185.6", "Volume": "1000"},
+		},
+	}
+
+	report, err := validate.Data("AAPL", data, validate.Options{})
+	if err != nil {
+		t.Fatalf("Data() error = %v", err)
+	}
+	if report.Count(validate.DuplicateDate) != 1 || report.Symbol != "AAPL" || report.Source != "yahoo" {
+		t.Errorf("Data() = %v", report)
+	}
+}
@@ -0,0 +1,255 @@
+// Package watchlist manages named symbol lists persisted as JSON files in a
+// configuration directory, so that the CLI and long-running updaters share
+// one definition of what to fetch.
+//
+// # Example Usage
+//
+//	store, err := watchlist.DefaultStore()
+//	if err != nil {
+//		log.Fatal(err)
+//	}
+//	if err := store.Save(&watchlist.Watchlist{Name: "tech", Source: "yahoo", Symbols: []string{"AAPL", "MSFT"}}); err != nil {
+//		log.Fatal(err)
+//	}
+//	tech, err := store.Get("tech")
+package watchlist
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+	"time"
+)
+
+var (
+	// ErrNotFound indicates the watchlist does not exist.
+	ErrNotFound = errors.New("watchlist not found")
+	// ErrInvalidName indicates a watchlist name is empty or contains
+	// characters other than letters, digits, '-' and '_'.
+	ErrInvalidName = errors.New("invalid watchlist name")
+)
+
+// fileExt is the extension of watchlist files.
+const fileExt = ".json"
+
+var validName = regexp.MustCompile(`^[A-Za-z0-9_-]+$`)
+
+// Watchlist is a named list of symbols.
+type Watchlist struct {
+	// Name identifies the watchlist and is used as its file name.
+	Name string `json:"name"`
+	// Source is the default data source for the symbols (e.g., "yahoo").
+	// Optional.
+	Source string `json:"source,omitempty"`
+	// Symbols holds the symbols in insertion order, without duplicates.
+	Symbols []string `json:"symbols"`
+	// UpdatedAt is set by Store.Save.
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+// Store persists watchlists as <dir>/<name>.json.
+type Store struct {
+	dir string
+}
+
+// NewStore creates a Store backed by dir. The directory is created on the
+// first Save.
+func NewStore(dir string) *Store {
+	return &Store{dir: dir}
+}
+
+// DefaultDir returns the default watchlist directory,
+// <user config dir>/gonp-datareader/watchlists.
+func DefaultDir() (string, error) {
+	base, err := os.UserConfigDir()
+	if err != nil {
+		return "", fmt.Errorf("locate config dir: %w", err)
+	}
+	return filepath.Join(base, "gonp-datareader", "watchlists"), nil
+}
+
+// DefaultStore returns a Store backed by DefaultDir.
+func DefaultStore() (*Store, error) {
+	dir, err := DefaultDir()
+	if err != nil {
+		return nil, err
+	}
+	return NewStore(dir), nil
+}
+
+// Dir returns the directory backing the store.
+func (s *Store) Dir() string {
+	return s.dir
+}
+
+// List returns the names of all watchlists, sorted.
+func (s *Store) List() ([]string, error) {
+	entries, err := os.ReadDir(s.dir)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("list watchlists: %w", err)
+	}
+
+	var names []string
+	for _, e := range entries {
+		name := strings.TrimSuffix(e.Name(), fileExt)
+		if e.IsDir() || !strings.HasSuffix(e.Name(), fileExt) || !validName.MatchString(name) {
+			continue
+		}
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names, nil
+}
+
+// Get loads the named watchlist.
+func (s *Store) Get(name string) (*Watchlist, error) {
+	path, err := s.path(name)
+	if err != nil {
+		return nil, err
+	}
+
+	data, err := os.ReadFile(path) // #nosec G304 - Name is validated
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, fmt.Errorf("%w: %s", ErrNotFound, name)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("read watchlist %s: %w", name, err)
+	}
+
+	var w Watchlist
+	if err := json.Unmarshal(data, &w); err != nil {
+		return nil, fmt.Errorf("decode watchlist %s: %w", name, err)
+	}
+	w.Name = name
+	return &w, nil
+}
+
+// Save creates or replaces a watchlist. Symbols are trimmed and
+// de-duplicated, and UpdatedAt is set to the current time.
+func (s *Store) Save(w *Watchlist) error {
+	path, err := s.path(w.Name)
+	if err != nil {
+		return err
+	}
+
+	w.Symbols = normalize(w.Symbols)
+	w.UpdatedAt = time.Now().UTC()
+
+	data, err := json.MarshalIndent(w, "", "  ")
+	if err != nil {
+		return fmt.Errorf("encode watchlist %s: %w", w.Name, err)
+	}
+
+	// #nosec G301 - Watchlists are not secret
+	if err := os.MkdirAll(s.dir, 0755); err != nil {
+		return fmt.Errorf("create watchlist dir: %w", err)
+	}
+
+	// Write to a temporary file and rename so readers never see a
+	// partially written watchlist
+	tmp, err := os.CreateTemp(s.dir, w.Name+".*.tmp")
+	if err != nil {
+		return fmt.Errorf("save watchlist %s: %w", w.Name, err)
+	}
+	defer os.Remove(tmp.Name())
+
+	if _, err := tmp.Write(append(data, '\n')); err != nil {
+		_ = tmp.Close()
+		return fmt.Errorf("save watchlist %s: %w", w.Name, err)
+	}
+	if err := tmp.Close(); err != nil {
+		return fmt.Errorf("save watchlist %s: %w", w.Name, err)
+	}
+	if err := os.Rename(tmp.Name(), path); err != nil {
+		return fmt.Errorf("save watchlist %s: %w", w.Name, err)
+	}
+	return nil
+}
+
+// Delete removes the named watchlist.
+func (s *Store) Delete(name string) error {
+	path, err := s.path(name)
+	if err != nil {
+		return err
+	}
+
+	if err := os.Remove(path); errors.Is(err, os.ErrNotExist) {
+		return fmt.Errorf("%w: %s", ErrNotFound, name)
+	} else if err != nil {
+		return fmt.Errorf("delete watchlist %s: %w", name, err)
+	}
+	return nil
+}
+
+// Add appends symbols to the named watchlist, creating it if needed.
+func (s *Store) Add(name string, symbols ...string) (*Watchlist, error) {
+	w, err := s.Get(name)
+	if errors.Is(err, ErrNotFound) {
+		w = &Watchlist{Name: name}
+	} else if err != nil {
+		return nil, err
+	}
+
+	w.Symbols = append(w.Symbols, symbols...)
+	if err := s.Save(w); err != nil {
+		return nil, err
+	}
+	return w, nil
+}
+
+// Remove removes symbols from the named watchlist.
+func (s *Store) Remove(name string, symbols ...string) (*Watchlist, error) {
+	w, err := s.Get(name)
+	if err != nil {
+		return nil, err
+	}
+
+	drop := make(map[string]bool, len(symbols))
+	for _, sym := range symbols {
+		drop[strings.TrimSpace(sym)] = true
+	}
+	kept := w.Symbols[:0]
+	for _, sym := range w.Symbols {
+		if !drop[sym] {
+			kept = append(kept, sym)
+		}
+	}
+	w.Symbols = kept
+
+	if err := s.Save(w); err != nil {
+		return nil, err
+	}
+	return w, nil
+}
+
+// path returns the file path of the named watchlist.
+func (s *Store) path(name string) (string, error) {
+	if !validName.MatchString(name) {
+		return "", fmt.Errorf("%w: %q", ErrInvalidName, name)
+	}
+	return filepath.Join(s.dir, name+fileExt), nil
+}
+
+// normalize trims symbols and drops empty and duplicate entries, keeping
+// the first occurrence.
+func normalize(symbols []string) []string {
+	seen := make(map[string]bool, len(symbols))
+	out := make([]string, 0, len(symbols))
+	for _, sym := range symbols {
+		sym = strings.TrimSpace(sym)
+		if sym == "" || seen[sym] {
+			continue
+		}
+		seen[sym] = true
+		out = append(out, sym)
+	}
+	return out
+}
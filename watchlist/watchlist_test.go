@@ -0,0 +1,132 @@
+package watchlist_test
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+
+	"github.com/julianshen/gonp-datareader/watchlist"
+)
+
+func TestStore_SaveGet(t *testing.T) {
+	store := watchlist.NewStore(filepath.Join(t.TempDir(), "watchlists"))
+
+	w := &watchlist.Watchlist{Name: "tech", Source: "yahoo", Symbols: []string{"AAPL", " MSFT ", "AAPL", ""}}
+	if err := store.Save(w); err != nil {
+		t.Fatalf("Save() error = %v", err)
+	}
+
+	got, err := store.Get("tech")
+	if err != nil {
+		t.Fatalf("Get() error = %v", err)
+	}
+	if got.Source != "yahoo" || !reflect.DeepEqual(got.Symbols, []string{"AAPL", "MSFT"}) {
+		t.Errorf("Get() = %+v, want source yahoo and [AAPL MSFT]", got)
+	}
+	if got.UpdatedAt.IsZero() {
+		t.Error("UpdatedAt not set")
+	}
+
+	// No temporary files are left behind
+	entries, _ := os.ReadDir(store.Dir())
+	if len(entries) != 1 || entries[0].Name() != "tech.json" {
+		t.Errorf("dir entries = %v, want [tech.json]", entries)
+	}
+}
+
+func TestStore_List(t *testing.T) {
+	store := watchlist.NewStore(t.TempDir())
+
+	names, err := store.List()
+	if err != nil || len(names) != 0 {
+		t.Fatalf("List() = %v, %v, want empty", names, err)
+	}
+
+	for _, name := range []string{"macro", "tech"} {
+		if err := store.Save(&watchlist.Watchlist{Name: name}); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := os.WriteFile(filepath.Join(store.Dir(), "notes.txt"), nil, 0600); err != nil {
+		t.Fatal(err)
+	}
+
+	names, err = store.List()
+	if err != nil {
+		t.Fatalf("List() error = %v", err)
+	}
+	if !reflect.DeepEqual(names, []string{"macro", "tech"}) {
+		t.Errorf("List() = %v, want [macro tech]", names)
+	}
+}
+
+func TestStore_List_MissingDir(t *testing.T) {
+	store := watchlist.NewStore(filepath.Join(t.TempDir(), "missing"))
+
+	names, err := store.List()
+	if err != nil || names != nil {
+		t.Errorf("List() = %v, %v, want nil, nil", names, err)
+	}
+}
+
+func TestStore_AddRemove(t *testing.T) {
+	store := watchlist.NewStore(t.TempDir())
+
+	w, err := store.Add("tech", "AAPL", "MSFT")
+	if err != nil {
+		t.Fatalf("Add() error = %v", err)
+	}
+	w, err = store.Add("tech", "NVDA", "AAPL")
+	if err != nil {
+		t.Fatalf("Add() error = %v", err)
+	}
+	if !reflect.DeepEqual(w.Symbols, []string{"AAPL", "MSFT", "NVDA"}) {
+		t.Errorf("Symbols = %v, want [AAPL MSFT NVDA]", w.Symbols)
+	}
+
+	w, err = store.Remove("tech", "MSFT")
+	if err != nil {
+		t.Fatalf("Remove() error = %v", err)
+	}
+	if !reflect.DeepEqual(w.Symbols, []string{"AAPL", "NVDA"}) {
+		t.Errorf("Symbols = %v, want [AAPL NVDA]", w.Symbols)
+	}
+
+	if _, err := store.Remove("missing", "AAPL"); !errors.Is(err, watchlist.ErrNotFound) {
+		t.Errorf("Remove() error = %v, want ErrNotFound", err)
+	}
+}
+
+func TestStore_Delete(t *testing.T) {
+	store := watchlist.NewStore(t.TempDir())
+	if _, err := store.Add("tech", "AAPL"); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := store.Delete("tech"); err != nil {
+		t.Fatalf("Delete() error = %v", err)
+	}
+	if _, err := store.Get("tech"); !errors.Is(err, watchlist.ErrNotFound) {
+		t.Errorf("Get() error = %v, want ErrNotFound", err)
+	}
+	if err := store.Delete("tech"); !errors.Is(err, watchlist.ErrNotFound) {
+		t.Errorf("Delete() error = %v, want ErrNotFound", err)
+	}
+}
+
+func TestStore_InvalidName(t *testing.T) {
+	store := watchlist.NewStore(t.TempDir())
+
+	for _, name := range []string{"", "../etc", "a b", "tech.json"} {
+		t.Run(name, func(t *testing.T) {
+			if err := store.Save(&watchlist.Watchlist{Name: name}); !errors.Is(err, watchlist.ErrInvalidName) {
+				t.Errorf("Save(%q) error = %v, want ErrInvalidName", name, err)
+			}
+			if _, err := store.Get(name); !errors.Is(err, watchlist.ErrInvalidName) {
+				t.Errorf("Get(%q) error = %v, want ErrInvalidName", name, err)
+			}
+		})
+	}
+}